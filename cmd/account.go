@@ -21,7 +21,7 @@ var accountGetCmd = &cobra.Command{
 	Use:   "get",
 	Short: "Get account information",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"time"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/advisor"
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/roboalchemist/opsgenie-cli/pkg/snapshot"
+	"github.com/spf13/cobra"
+)
+
+var (
+	advisorDisabledDays        int
+	advisorDeleteInteractively bool
+)
+
+var advisorCmd = &cobra.Command{
+	Use:   "advisor",
+	Short: "Flag resources that look unused",
+	Long: `Flag resources that look unused, as candidates for account cleanup:
+
+  - teams with no members
+  - schedules with no rotations
+  - escalations not referenced by any integration, alert policy or team
+    routing rule
+  - integrations disabled for longer than --disabled-days (OpsGenie does not
+    always report when an integration changed; undated ones are flagged)
+
+These are heuristics. With --delete-interactively each finding is shown and
+deleted only after confirmation.`,
+	Example: `  # Review cleanup candidates
+  opsgenie-cli advisor
+
+  # Walk through them and delete the ones you confirm
+  opsgenie-cli advisor --delete-interactively`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if advisorDeleteInteractively {
+			if err := output.RequireInteractive("--delete-interactively"); err != nil {
+				return err
+			}
+		}
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		resources, err := snapshot.CollectRaw(client, advisor.Kinds)
+		if err != nil {
+			return err
+		}
+		findings := advisor.Analyze(resources, advisor.Options{DisabledDays: advisorDisabledDays, Now: time.Now()})
+
+		if !advisorDeleteInteractively {
+			headers := []string{"Kind", "ID", "Name", "Reason"}
+			rows := make([][]string, len(findings))
+			for i, f := range findings {
+				rows[i] = []string{f.Kind, f.ID, f.Name, f.Reason}
+			}
+			if findings == nil {
+				findings = []advisor.Finding{}
+			}
+			if err := output.RenderTable(headers, rows, findings, opts); err != nil {
+				return err
+			}
+			output.Success(fmt.Sprintf("%d cleanup candidate(s) found", len(findings)), opts)
+			return nil
+		}
+
+		in := bufio.NewReader(cmd.InOrStdin())
+		var sum output.Summary
+		for _, f := range findings {
+			ok, err := output.Confirm(in, cmd.ErrOrStderr(), fmt.Sprintf("Delete %s %q (%s)?", f.Kind, f.Name, f.Reason))
+			if err != nil {
+				return err
+			}
+			if !ok {
+				sum.Skip()
+				continue
+			}
+			if err := client.Delete(advisorDeletePath(f), nil); err != nil {
+				output.Error(fmt.Sprintf("deleting %s %q: %v", f.Kind, f.Name, err), opts)
+				sum.Fail(f.ID)
+				continue
+			}
+			sum.Succeed()
+		}
+		if err := output.RenderSummary(sum, opts); err != nil {
+			return err
+		}
+		if err := sum.Err(); err != nil {
+			return err
+		}
+		output.Success(fmt.Sprintf("Deleted %d of %d cleanup candidate(s)", sum.Succeeded, len(findings)), opts)
+		return nil
+	},
+}
+
+// advisorDeletePath returns the API path that deletes a finding.
+func advisorDeletePath(f advisor.Finding) string {
+	return "/v2/" + f.Kind + "s/" + f.ID
+}
+
+func init() {
+	advisorCmd.Flags().IntVar(&advisorDisabledDays, "disabled-days", 30, "Flag integrations disabled for at least N days")
+	advisorCmd.Flags().BoolVar(&advisorDeleteInteractively, "delete-interactively", false, "Prompt to delete each finding")
+	addOutputFlags(advisorCmd)
+
+	rootCmd.AddCommand(advisorCmd)
+}
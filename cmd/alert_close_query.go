@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// ─── alerts close --query ────────────────────────────────────────────────────
+
+var (
+	alertsCloseQuery string
+	alertsCloseLimit int
+	alertsCloseYes   bool
+)
+
+// searchAlerts returns the alerts matching query, at most max+1 of them so
+// callers can tell when more than max match.
+func searchAlerts(client *api.Client, query string, max int) ([]api.AlertResponse, error) {
+	var found []api.AlertResponse
+	for len(found) <= max {
+		params := url.Values{
+			"query":  {query},
+			"offset": {strconv.Itoa(len(found))},
+			"limit":  {strconv.Itoa(min(max+1-len(found), 100))},
+		}
+		var page []api.AlertResponse
+		if err := client.GetWithParams("/v2/alerts", params, &page); err != nil {
+			return nil, err
+		}
+		found = append(found, page...)
+		if len(page) == 0 || len(page) < 100 {
+			break
+		}
+	}
+	return found, nil
+}
+
+// closeAlertsByQuery closes every alert matching --query: the matches are
+// listed first and closed only after confirmation or with --yes. More than
+// --limit matches is an error, so a mistyped query cannot close everything.
+func closeAlertsByQuery(cmd *cobra.Command, client *api.Client) error {
+	opts := getOutputOpts()
+	if alertsCloseLimit < 1 {
+		return output.Invalid(fmt.Errorf("--limit must be at least 1"))
+	}
+	alerts, err := searchAlerts(client, alertsCloseQuery, alertsCloseLimit)
+	if err != nil {
+		return err
+	}
+	if len(alerts) > alertsCloseLimit {
+		return fmt.Errorf("more than %d alerts match %q; narrow the query or raise --limit", alertsCloseLimit, alertsCloseQuery)
+	}
+	if len(alerts) == 0 {
+		output.Success(fmt.Sprintf("No alerts match %q", alertsCloseQuery), opts)
+		return nil
+	}
+
+	if !alertsCloseYes {
+		w := cmd.ErrOrStderr()
+		for _, a := range alerts {
+			fmt.Fprintf(w, "  %s  %-8s %s\n", a.ID, a.Status, a.Message)
+		}
+		ok, err := output.Confirm(bufio.NewReader(cmd.InOrStdin()), w, fmt.Sprintf("Close these %d alert(s)?", len(alerts)))
+		if err != nil {
+			return fmt.Errorf("%w (use --yes to close without confirmation)", err)
+		}
+		if !ok {
+			fmt.Fprintln(w, "No alerts closed")
+			return nil
+		}
+	}
+
+	body := map[string]interface{}{}
+	if alertsCloseNote != "" {
+		body["note"] = alertsCloseNote
+	}
+	errs := client.Fanout(len(alerts), func(i int) error {
+		output.Progress("close", alerts[i].ID, i+1, len(alerts))
+		return client.Post("/v2/alerts/"+url.PathEscape(alerts[i].ID)+"/close?identifierType=id", body, nil)
+	})
+
+	headers := []string{"ID", "Message", "Result"}
+	rows := make([][]string, len(alerts))
+	data := make([]map[string]interface{}, len(alerts))
+	var sum output.Summary
+	for i, a := range alerts {
+		result := "closed"
+		if errs[i] != nil {
+			output.Error(fmt.Sprintf("alert %s: %v", a.ID, errs[i]), opts)
+			result = "failed"
+			sum.Fail(a.ID)
+		} else {
+			sum.Succeed()
+		}
+		rows[i] = []string{a.ID, a.Message, result}
+		data[i] = map[string]interface{}{"id": a.ID, "message": a.Message, "result": result}
+	}
+	if err := output.RenderTable(headers, rows, data, opts); err != nil {
+		return err
+	}
+	if err := output.RenderSummary(sum, opts); err != nil {
+		return err
+	}
+	if err := sum.Err(); err != nil {
+		return err
+	}
+	output.Success(fmt.Sprintf("%d alert(s) closed", len(alerts)), opts)
+	return nil
+}
+
+func init() {
+	alertsCloseCmd.Flags().StringVar(&alertsCloseQuery, "query", "", "Close every alert matching this search query instead of one ID")
+	alertsCloseCmd.Flags().IntVar(&alertsCloseLimit, "limit", 100, "With --query, refuse to close if more alerts than this match")
+	alertsCloseCmd.Flags().BoolVar(&alertsCloseYes, "yes", false, "With --query, close without asking for confirmation")
+	addOutputFlags(alertsCloseCmd)
+}
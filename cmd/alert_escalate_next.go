@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// ─── alerts escalate-next ────────────────────────────────────────────────────
+
+var alertsEscalateNextCmd = &cobra.Command{
+	Use:   "escalate-next <id>",
+	Short: "Escalate an alert to the next rule of its current escalation",
+	Long: `Escalate an alert to the next rule of the escalation it is already in,
+without having to look the escalation up.
+
+The escalation is an escalation responder of the alert or, for each team
+on the alert, the escalation its matching routing rule notifies (as "alerts
+why" finds it). When the alert is in more than one escalation, or none,
+name it with "alerts escalate --escalation" instead.`,
+	Example: `  # Nobody is answering: page the next level
+  opsgenie-cli alerts escalate-next 1234`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts := GetOutputOptions()
+
+		var envelope api.APIResponse[api.AlertResponse]
+		path, err := alertPath(args[0], "")
+		if err != nil {
+			return err
+		}
+		if err := client.Get(path, &envelope); err != nil {
+			return err
+		}
+		a := envelope.Data
+
+		escalations, err := alertEscalations(client, a)
+		if err != nil {
+			return err
+		}
+		switch len(escalations) {
+		case 0:
+			return fmt.Errorf("alert %s is not in any escalation; use \"alerts escalate --escalation <name>\"", args[0])
+		case 1:
+		default:
+			names := make([]string, len(escalations))
+			for i, e := range escalations {
+				names[i] = refName(e)
+			}
+			return fmt.Errorf("alert %s is in %d escalations (%s); use \"alerts escalate --escalation <name>\" to pick one", args[0], len(names), strings.Join(names, ", "))
+		}
+
+		e := escalations[0]
+		ref := map[string]interface{}{"id": e.ID}
+		if e.ID == "" {
+			ref = map[string]interface{}{"name": e.Name}
+		}
+		if err := client.Post("/v2/alerts/"+a.ID+"/escalate?identifierType=id", map[string]interface{}{"escalation": ref}, nil); err != nil {
+			return err
+		}
+		output.Success(fmt.Sprintf("Alert escalated to the next rule of %s", refName(e)), opts)
+		return nil
+	},
+}
+
+// alertEscalations returns the escalations an alert is in: its escalation
+// responders, then the escalations the routing rules of its teams notify,
+// without duplicates.
+func alertEscalations(client *api.Client, a api.AlertResponse) ([]api.TeamRef, error) {
+	var out []api.TeamRef
+	seen := map[string]bool{}
+	add := func(e api.TeamRef) {
+		key := e.ID
+		if key == "" {
+			key = strings.ToLower(e.Name)
+		}
+		if key == "" || seen[key] {
+			return
+		}
+		seen[key] = true
+		out = append(out, e)
+	}
+	for _, r := range a.Responders {
+		if r.Type == "escalation" {
+			add(api.TeamRef{ID: r.ID, Name: r.Name})
+		}
+	}
+
+	created, err := time.Parse(time.RFC3339Nano, a.CreatedAt)
+	if err != nil {
+		created = time.Now()
+	}
+	for _, t := range alertTeams(a) {
+		route, err := routeAlert(client, a, t, created)
+		if err != nil {
+			return nil, err
+		}
+		if m, ok := route.notify.(map[string]interface{}); ok && stringVal(m, "type") == "escalation" {
+			add(api.TeamRef{ID: stringVal(m, "id"), Name: stringVal(m, "name")})
+		}
+	}
+	return out, nil
+}
+
+// refName is a reference's name, or its ID when it has none.
+func refName(r api.TeamRef) string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return r.ID
+}
+
+func init() {
+	alertsCmd.AddCommand(alertsEscalateNextCmd)
+}
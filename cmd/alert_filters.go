@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+)
+
+// ─── alerts list filters ─────────────────────────────────────────────────────
+
+var (
+	alertsListPriorities []string
+	alertsListStatus     string
+	alertsListTags       []string
+	alertsListTeams      []string
+)
+
+// alertStatusQueries are the --status values and the query terms they
+// stand for.
+var alertStatusQueries = map[string]string{
+	"open":    "status:open",
+	"closed":  "status:closed",
+	"acked":   "acknowledged:true",
+	"unacked": "acknowledged:false",
+	"snoozed": "snoozed:true",
+}
+
+// queryValue quotes v for the OpsGenie query language when it is not a
+// bare word.
+func queryValue(v string) string {
+	if v != "" && !strings.ContainsAny(v, " \t\"():\\") {
+		return v
+	}
+	return strconv.Quote(v)
+}
+
+// anyOf is field:v for one value and (field:a OR field:b ...) for several.
+func anyOf(field string, values []string) string {
+	terms := make([]string, len(values))
+	for i, v := range values {
+		terms[i] = field + ":" + queryValue(v)
+	}
+	if len(terms) == 1 {
+		return terms[0]
+	}
+	return "(" + strings.Join(terms, " OR ") + ")"
+}
+
+// alertFilterQuery translates the --priority, --status, --tag and --team
+// filters of alerts list into OpsGenie query syntax, ANDed with query.
+// Priorities and teams match any of those given; every tag must be
+// present. Teams may be given by ID and are looked up by name, since the
+// query language matches team names.
+func alertFilterQuery(client *api.Client, query string) (string, error) {
+	var terms []string
+	if len(alertsListPriorities) > 0 {
+		priorities := make([]string, len(alertsListPriorities))
+		for i, p := range alertsListPriorities {
+			p = strings.ToUpper(strings.TrimSpace(p))
+			if len(p) != 2 || p[0] != 'P' || p[1] < '1' || p[1] > '5' {
+				return "", output.Invalid(fmt.Errorf("invalid --priority %q (use P1 to P5)", alertsListPriorities[i]))
+			}
+			priorities[i] = p
+		}
+		terms = append(terms, anyOf("priority", priorities))
+	}
+	if alertsListStatus != "" {
+		term, ok := alertStatusQueries[strings.ToLower(alertsListStatus)]
+		if !ok {
+			return "", output.Invalid(fmt.Errorf("invalid --status %q (use open, closed, acked, unacked or snoozed)", alertsListStatus))
+		}
+		terms = append(terms, term)
+	}
+	for _, tag := range alertsListTags {
+		terms = append(terms, "tag:"+queryValue(tag))
+	}
+	if len(alertsListTeams) > 0 {
+		teams := make([]string, len(alertsListTeams))
+		for i, t := range alertsListTeams {
+			name, err := client.ResolveName("team", t)
+			if err != nil {
+				return "", err
+			}
+			teams[i] = name
+		}
+		terms = append(terms, anyOf("teams", teams))
+	}
+
+	if len(terms) == 0 {
+		return query, nil
+	}
+	filters := strings.Join(terms, " AND ")
+	if query == "" {
+		return filters, nil
+	}
+	return "(" + query + ") AND " + filters, nil
+}
+
+func init() {
+	alertsListCmd.Flags().StringSliceVar(&alertsListPriorities, "priority", nil, "Only alerts of these priorities (comma-separated, e.g. P1,P2)")
+	alertsListCmd.Flags().StringVar(&alertsListStatus, "status", "", "Only alerts in this state: open, closed, acked, unacked or snoozed")
+	alertsListCmd.Flags().StringArrayVar(&alertsListTags, "tag", nil, "Only alerts with this tag (repeatable; all must match)")
+	alertsListCmd.Flags().StringSliceVar(&alertsListTeams, "team", nil, "Only alerts for these teams, by name or ID (comma-separated)")
+}
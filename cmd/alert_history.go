@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/roboalchemist/opsgenie-cli/pkg/report"
+	"github.com/spf13/cobra"
+)
+
+// ─── alerts history ──────────────────────────────────────────────────────────
+
+var alertsHistoryFields []string
+
+var alertsHistoryCmd = &cobra.Command{
+	Use:   "history <id>",
+	Short: "Show how an alert's status, priority, owner and tags changed",
+	Long: `Show how an alert evolved: each change of its status, priority, owner or
+tags, with who made it and when.
+
+Changes are reconstructed from the alert's activity log, whose entries are
+free text; entries that change none of these fields are left out (see
+"alerts show" for the full log). The first rows are the values the alert was
+created with, worked back from its current values. FROM is empty where the
+earlier value is unknown.`,
+	Example: `  # How did this alert get to P1?
+  opsgenie-cli alerts history <alert-id> --field priority
+
+  # The whole change timeline as JSON
+  opsgenie-cli alerts history <alert-id> --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		want := map[string]bool{}
+		for _, f := range alertsHistoryFields {
+			if !slices.Contains(report.HistoryFields, f) {
+				return fmt.Errorf("unknown field %q (valid: %s)", f, strings.Join(report.HistoryFields, ", "))
+			}
+			want[f] = true
+		}
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		var envelope api.APIResponse[api.AlertResponse]
+		path, err := alertPath(args[0], "")
+		if err != nil {
+			return err
+		}
+		if err := client.Get(path, &envelope); err != nil {
+			return err
+		}
+		a := envelope.Data
+		logs := []api.AlertLog{}
+		params := url.Values{"identifierType": {"id"}, "order": {"asc"}}
+		if err := client.ListAll("/v2/alerts/"+url.PathEscape(a.ID)+"/logs", params, &logs); err != nil {
+			return fmt.Errorf("logs: %w", err)
+		}
+
+		changes := []report.FieldChange{}
+		for _, c := range report.AlertHistory(a, logs) {
+			if len(want) == 0 || want[c.Field] {
+				changes = append(changes, c)
+			}
+		}
+
+		headers := []string{"Time", "Actor", "Field", "From", "To"}
+		rows := make([][]string, len(changes))
+		for i, c := range changes {
+			rows[i] = []string{c.Time, c.Actor, c.Field, c.From, c.To}
+		}
+		return output.RenderTable(headers, rows, changes, opts)
+	},
+}
+
+func init() {
+	alertsHistoryCmd.Flags().StringSliceVar(&alertsHistoryFields, "field", nil, "Only show changes of these fields: status, priority, owner, tags")
+	addOutputFlags(alertsHistoryCmd)
+
+	alertsCmd.AddCommand(alertsHistoryCmd)
+}
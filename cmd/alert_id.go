@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// alertIDType is the --id-type of the commands that take an alert: auto,
+// id, tiny or alias.
+var alertIDType string
+
+func init() {
+	for _, c := range []*cobra.Command{
+		alertsGetCmd, alertsShowCmd, alertsHistoryCmd, alertsWhyCmd, alertsUpdateCmd, alertsDeleteCmd,
+		alertsAcknowledgeCmd, alertsUnacknowledgeCmd, alertsExecuteActionCmd, alertsCloseCmd, alertsSnoozeCmd,
+		alertsEscalateCmd, alertsEscalateNextCmd, alertsAssignCmd, alertsAddNoteCmd, alertsAddTagsCmd, alertsRemoveTagsCmd,
+		alertsAttachCmd, alertsAttachmentsListCmd, alertsAttachmentsDownloadCmd,
+	} {
+		c.Flags().StringVar(&alertIDType, "id-type", "auto",
+			"How <id> identifies the alert: id, tiny (the short number in notifications), alias, or auto to guess from its form")
+	}
+}
+
+// alertIdentifierType returns the identifierType to send for the alert
+// arg. In auto mode a number is a tiny ID, a string of hex digits and
+// dashes (with an optional numeric suffix) a full ID, and anything else an
+// alias.
+func alertIdentifierType(arg string) (string, error) {
+	switch alertIDType {
+	case "id", "tiny", "alias":
+		return alertIDType, nil
+	case "auto", "":
+	default:
+		return "", output.Invalid(fmt.Errorf("invalid --id-type %q (valid: auto, id, tiny, alias)", alertIDType))
+	}
+	switch {
+	case arg != "" && strings.Trim(arg, "0123456789") == "":
+		return "tiny", nil
+	case strings.Trim(arg, "0123456789abcdefABCDEF-") == "":
+		return "id", nil
+	}
+	return "alias", nil
+}
+
+// alertPath returns the API path of the alert arg, followed by suffix,
+// with its identifierType query parameter.
+func alertPath(arg, suffix string) (string, error) {
+	idType, err := alertIdentifierType(arg)
+	if err != nil {
+		return "", err
+	}
+	return "/v2/alerts/" + url.PathEscape(arg) + suffix + "?identifierType=" + idType, nil
+}
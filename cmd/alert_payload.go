@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// alertFieldLimits holds the create-alert fields that are plain strings
+// and the API's maximum length of each.
+var alertFieldLimits = map[string]int{
+	"message":     130,
+	"alias":       512,
+	"description": 15000,
+	"entity":      512,
+	"source":      100,
+	"user":        100,
+	"note":        25000,
+	"priority":    2,
+}
+
+// alertPayloadSource names a --file argument in messages.
+func alertPayloadSource(name string) string {
+	if name == "-" {
+		return "stdin"
+	}
+	return name
+}
+
+// readAlertPayload reads a create-alert request body from a JSON file, or
+// stdin for "-".
+func readAlertPayload(name string) (map[string]interface{}, error) {
+	raw, err := readFileOrStdin(name)
+	if err != nil {
+		return nil, err
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal(raw, &body); err != nil {
+		return nil, fmt.Errorf("%s: not a JSON object: %w", alertPayloadSource(name), err)
+	}
+	return body, nil
+}
+
+// stringList converts flag values to the form of a decoded JSON list.
+func stringList(values []string) []interface{} {
+	list := make([]interface{}, len(values))
+	for i, v := range values {
+		list[i] = v
+	}
+	return list
+}
+
+// validateAlertPayload checks a create-alert body against the API's schema
+// before it is sent: message is required, and every field must have the
+// right type, length and values. All problems are returned, one per line.
+func validateAlertPayload(body map[string]interface{}) error {
+	var errs []error
+	add := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("  "+format, args...))
+	}
+
+	if msg, _ := body["message"].(string); strings.TrimSpace(msg) == "" {
+		add("message is required")
+	}
+	keys := make([]string, 0, len(body))
+	for k := range body {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := body[k]
+		switch k {
+		case "tags", "actions":
+			limit := map[string]int{"tags": 20, "actions": 10}[k]
+			list, ok := v.([]interface{})
+			if !ok {
+				add("%s must be a list of strings", k)
+				continue
+			}
+			if len(list) > limit {
+				add("%s has %d entries, at most %d are allowed", k, len(list), limit)
+			}
+			for _, item := range list {
+				if s, ok := item.(string); !ok || s == "" || len(s) > 50 {
+					add("%s entries must be non-empty strings of at most 50 characters, got %v", k, item)
+				}
+			}
+		case "details":
+			details, ok := v.(map[string]interface{})
+			if !ok {
+				add("details must be an object of string values")
+				continue
+			}
+			size := 0
+			for dk, dv := range details {
+				s, ok := dv.(string)
+				if !ok {
+					add("details.%s must be a string", dk)
+				}
+				size += len(dk) + len(s)
+			}
+			if size > 8000 {
+				add("details total %d characters, at most 8000 are allowed", size)
+			}
+		case "responders", "visibleTo":
+			types := []string{"team", "user", "escalation", "schedule"}
+			if k == "visibleTo" {
+				types = types[:2]
+			}
+			list, ok := v.([]interface{})
+			if !ok {
+				add("%s must be a list of objects", k)
+				continue
+			}
+			if len(list) > 50 {
+				add("%s has %d entries, at most 50 are allowed", k, len(list))
+			}
+			for i, item := range list {
+				r, ok := item.(map[string]interface{})
+				if !ok {
+					add("%s[%d] must be an object", k, i)
+					continue
+				}
+				t, _ := r["type"].(string)
+				if !slices.Contains(types, t) {
+					add("%s[%d].type must be one of %s", k, i, strings.Join(types, ", "))
+				}
+				if r["id"] == nil && r["name"] == nil && r["username"] == nil {
+					add("%s[%d] needs an id, name or username", k, i)
+				}
+			}
+		default:
+			limit, known := alertFieldLimits[k]
+			if !known {
+				add("unknown field %q", k)
+				continue
+			}
+			s, ok := v.(string)
+			switch {
+			case !ok:
+				add("%s must be a string", k)
+			case k == "priority":
+				if !validPriority(s) {
+					add("priority must be P1, P2, P3, P4 or P5, got %q", s)
+				}
+			case len(s) > limit:
+				add("%s is %d characters, at most %d are allowed", k, len(s), limit)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// payloadResponders converts the responders of an alert payload to the
+// form parseResponders returns, so they can be merged with others.
+func payloadResponders(v interface{}) []map[string]string {
+	list, _ := v.([]interface{})
+	out := make([]map[string]string, 0, len(list))
+	for _, item := range list {
+		r, _ := item.(map[string]interface{})
+		m := map[string]string{}
+		for k, v := range r {
+			if s, ok := v.(string); ok {
+				m[k] = s
+			}
+		}
+		out = append(out, m)
+	}
+	return out
+}
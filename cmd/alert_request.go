@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// ─── alerts request-status ───────────────────────────────────────────────────
+
+var alertsRequestStatusCmd = &cobra.Command{
+	Use:   "request-status <requestId>",
+	Short: "Show the status of an asynchronous alert request",
+	Long: `Show whether an asynchronous request has been processed.
+
+Alert actions (create, acknowledge, close, ...) are accepted by OpsGenie with
+a request ID and processed in the background. Commands normally wait for
+that; with --no-wait they print the request ID instead, which this command
+looks up.`,
+	Example: `  # Acknowledge without waiting, check later
+  opsgenie-cli alerts acknowledge abc123 --no-wait
+  opsgenie-cli alerts request-status 6ee1e6e1-2b1a-4c5a-9a3e-0d4b2f7e6d10`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		var resp struct {
+			Data api.RequestResult `json:"data"`
+		}
+		if err := client.Get("/v2/alerts/requests/"+url.PathEscape(args[0]), &resp); err != nil {
+			return err
+		}
+
+		if opts.Structured() || opts.Template != "" || opts.JQExpr != "" {
+			return output.RenderJSON(resp.Data, opts)
+		}
+		headers := []string{"FIELD", "VALUE"}
+		rows := [][]string{
+			{"RequestID", args[0]},
+			{"Success", strconv.FormatBool(resp.Data.IsSuccess)},
+			{"Status", resp.Data.Status},
+			{"Action", resp.Data.Action},
+			{"ProcessedAt", resp.Data.ProcessedAt},
+			{"AlertID", resp.Data.AlertID},
+			{"Alias", resp.Data.Alias},
+		}
+		return output.RenderTable(headers, rows, resp.Data, opts)
+	},
+}
+
+func init() {
+	alertsCmd.AddCommand(alertsRequestStatusCmd)
+	addOutputFlags(alertsRequestStatusCmd)
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// ─── alert IDs from stdin ────────────────────────────────────────────────────
+
+// readIDs reads identifiers from r, one per line, ignoring blank lines and
+// surrounding space.
+func readIDs(r io.Reader) ([]string, error) {
+	var ids []string
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		if id := strings.TrimSpace(sc.Text()); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read IDs from stdin: %w", err)
+	}
+	return ids, nil
+}
+
+// runAlertAction runs action on the alert arg identifies and reports msg
+// on success. An arg of "-" runs it instead on every alert identified on
+// stdin, one per line, up to --concurrency at a time, so list output piped
+// in with -q can be acted on in bulk. A bulk run reports each failure, ends
+// with a summary, and fails if any alert did; done (e.g. "acknowledged")
+// names what happened to each alert.
+func runAlertAction(cmd *cobra.Command, client *api.Client, arg, msg, done string, action func(id string) error) error {
+	opts := GetOutputOptions()
+	if arg != "-" {
+		if err := action(arg); err != nil {
+			return err
+		}
+		output.Success(msg, opts)
+		return nil
+	}
+
+	ids, err := readIDs(cmd.InOrStdin())
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return output.Invalid(fmt.Errorf("no alert IDs on stdin"))
+	}
+	errs := client.Fanout(len(ids), func(i int) error {
+		output.Progress(done, ids[i], i+1, len(ids))
+		return action(ids[i])
+	})
+	var sum output.Summary
+	for i, id := range ids {
+		if errs[i] != nil {
+			output.Error(fmt.Sprintf("alert %s: %v", id, errs[i]), opts)
+			sum.Fail(id)
+		} else {
+			sum.Succeed()
+		}
+	}
+	if err := output.RenderSummary(sum, opts); err != nil {
+		return err
+	}
+	if err := sum.Err(); err != nil {
+		return err
+	}
+	output.Success(fmt.Sprintf("%d alert(s) %s", len(ids), done), opts)
+	return nil
+}
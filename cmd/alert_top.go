@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// ─── alerts top ──────────────────────────────────────────────────────────────
+
+var (
+	alertsTopGroupBy string
+	alertsTopSince   = durationFlag(24 * time.Hour)
+	alertsTopQuery   string
+	alertsTopLimit   int
+)
+
+// alertGroupKeys are the --group-by values and how each reads an alert.
+var alertGroupKeys = map[string]func(a api.AlertResponse) string{
+	"alias":  func(a api.AlertResponse) string { return a.Alias },
+	"entity": func(a api.AlertResponse) string { return a.Entity },
+	"source": func(a api.AlertResponse) string { return a.Source },
+}
+
+// alertGroup is the alert volume of one alias, entity or source.
+type alertGroup struct {
+	Key string `json:"key"`
+	// Alerts counts the alerts created; Occurrences adds up their counts,
+	// so it includes the duplicates OpsGenie folded into them.
+	Alerts      int    `json:"alerts"`
+	Occurrences int    `json:"occurrences"`
+	Open        int    `json:"open"`
+	LastSeen    string `json:"lastSeen"`
+	Message     string `json:"message"`
+}
+
+var alertsTopCmd = &cobra.Command{
+	Use:   "top",
+	Short: "Rank the noisiest alert aliases, entities or sources",
+	Long: `Rank alert volume by alias, entity or source over the alerts created in
+the last --since, to find the noisy sources worth tuning.
+
+Occurrences adds up each alert's count, so it includes the duplicates
+OpsGenie deduplicated into an open alert by alias. Groups are ordered by
+occurrences, then alerts. The alerts are fetched page by page and
+aggregated locally; --query narrows them first.`,
+	Example: `  # Noisiest aliases of the last day
+  opsgenie-cli alerts top
+
+  # Sources of the last week, for one team
+  opsgenie-cli alerts top --group-by source --since 1w --query "teams:platform"`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		keyOf, ok := alertGroupKeys[alertsTopGroupBy]
+		if !ok {
+			return output.Invalid(fmt.Errorf("invalid --group-by %q (use alias, entity or source)", alertsTopGroupBy))
+		}
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		now := time.Now()
+		params := url.Values{}
+		params.Set("query", alertsCreatedQuery(now.Add(-time.Duration(alertsTopSince)), now, alertsTopQuery))
+		var alerts []api.AlertResponse
+		if err := client.ListAll("/v2/alerts", params, &alerts); err != nil {
+			return err
+		}
+
+		groups := groupAlerts(alerts, keyOf)
+		if alertsTopLimit > 0 && len(groups) > alertsTopLimit {
+			groups = groups[:alertsTopLimit]
+		}
+
+		column := strings.ToUpper(alertsTopGroupBy[:1]) + alertsTopGroupBy[1:]
+		headers := []string{column, "Alerts", "Occurrences", "Open", "LastSeen", "Message"}
+		rows := make([][]string, len(groups))
+		for i, g := range groups {
+			rows[i] = []string{g.Key, strconv.Itoa(g.Alerts), strconv.Itoa(g.Occurrences), strconv.Itoa(g.Open), g.LastSeen, g.Message}
+		}
+		return output.RenderTable(headers, rows, groups, opts)
+	},
+}
+
+// groupAlerts aggregates alerts by keyOf, busiest first. Alerts without a
+// key are grouped under "(none)". Each group's message is that of its most
+// recent alert.
+func groupAlerts(alerts []api.AlertResponse, keyOf func(api.AlertResponse) string) []alertGroup {
+	byKey := map[string]*alertGroup{}
+	groups := []*alertGroup{}
+	for _, a := range alerts {
+		key := keyOf(a)
+		if key == "" {
+			key = "(none)"
+		}
+		g := byKey[key]
+		if g == nil {
+			g = &alertGroup{Key: key}
+			byKey[key] = g
+			groups = append(groups, g)
+		}
+		g.Alerts++
+		g.Occurrences += max(a.Count, 1)
+		if a.Status == "open" {
+			g.Open++
+		}
+		seen := a.UpdatedAt
+		if seen == "" {
+			seen = a.CreatedAt
+		}
+		if seen > g.LastSeen {
+			g.LastSeen = seen
+			g.Message = a.Message
+		}
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].Occurrences != groups[j].Occurrences {
+			return groups[i].Occurrences > groups[j].Occurrences
+		}
+		if groups[i].Alerts != groups[j].Alerts {
+			return groups[i].Alerts > groups[j].Alerts
+		}
+		return groups[i].Key < groups[j].Key
+	})
+	out := make([]alertGroup, len(groups))
+	for i, g := range groups {
+		out[i] = *g
+	}
+	return out
+}
+
+func init() {
+	alertsTopCmd.Flags().StringVar(&alertsTopGroupBy, "group-by", "alias", "Group alerts by alias, entity or source")
+	alertsTopCmd.Flags().Var(&alertsTopSince, "since", "How far back to look at created alerts (e.g. 24h, 7d)")
+	alertsTopCmd.Flags().StringVar(&alertsTopQuery, "query", "", "Only alerts matching this search query")
+	alertsTopCmd.Flags().IntVar(&alertsTopLimit, "limit", 20, "Show at most this many groups (0 for all)")
+	addOutputFlags(alertsTopCmd)
+	alertsCmd.AddCommand(alertsTopCmd)
+}
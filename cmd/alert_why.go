@@ -0,0 +1,340 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/roboalchemist/opsgenie-cli/pkg/routing"
+	"github.com/spf13/cobra"
+)
+
+// ─── alerts why ──────────────────────────────────────────────────────────────
+
+var alertsWhyCmd = &cobra.Command{
+	Use:   "why <id>",
+	Short: "Explain how an alert was routed",
+	Long: `Explain why an alert was routed where it was.
+
+For each team on the alert, the team's routing rules are evaluated in order
+against the alert as it was created, including time restrictions, to find
+the rule that sent it on and the rules skipped before it. Alert policies,
+global and team, are evaluated the same way and their modifications listed.
+The alert log follows as a timeline, each entry labelled as routing, policy,
+escalation, notify or action.
+
+Rules and policies are read from the current configuration; if they changed
+after the alert was created the explanation may differ from what happened.
+The timeline is the authoritative record.`,
+	Example: `  # Why did this page go to the database team?
+  opsgenie-cli alerts why <alert-id>
+
+  # The same explanation for scripts
+  opsgenie-cli alerts why <alert-id> --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		var envelope api.APIResponse[api.AlertResponse]
+		path, err := alertPath(args[0], "")
+		if err != nil {
+			return err
+		}
+		if err := client.Get(path, &envelope); err != nil {
+			return err
+		}
+		a := envelope.Data
+		logs := []api.AlertLog{}
+		params := url.Values{"identifierType": {"id"}, "order": {"asc"}}
+		if err := client.ListAll("/v2/alerts/"+url.PathEscape(a.ID)+"/logs", params, &logs); err != nil {
+			return fmt.Errorf("logs: %w", err)
+		}
+
+		created, err := time.Parse(time.RFC3339Nano, a.CreatedAt)
+		if err != nil {
+			created = time.Now()
+		}
+		teams := alertTeams(a)
+		routes := []alertRoute{}
+		for _, t := range teams {
+			r, err := routeAlert(client, a, t, created)
+			if err != nil {
+				return err
+			}
+			routes = append(routes, r)
+		}
+		policies, err := applyAlertPolicies(client, a, teams, created)
+		if err != nil {
+			return err
+		}
+
+		timeline := []timelineEntry{{Time: a.CreatedAt, Kind: "created", Actor: a.Source, Text: a.Message}}
+		for _, l := range logs {
+			timeline = append(timeline, timelineEntry{Time: l.CreatedAt, Kind: routing.Classify(l.Log), Actor: l.Owner, Text: l.Log})
+		}
+
+		if opts.Structured() || opts.Template != "" || opts.JQExpr != "" {
+			return output.RenderJSON(map[string]interface{}{
+				"alert":    a,
+				"routing":  routes,
+				"policies": policies,
+				"timeline": timeline,
+			}, opts)
+		}
+		fmt.Print(whyDocument(a, routes, policies, timeline))
+		return nil
+	},
+}
+
+// alertRoute explains which routing rule of a team an alert matched.
+type alertRoute struct {
+	Team    string   `json:"team"`
+	Rule    string   `json:"rule,omitempty"`
+	RuleID  string   `json:"ruleId,omitempty"`
+	Notify  string   `json:"notify,omitempty"`
+	Skipped []string `json:"skipped,omitempty"` // earlier rules and why they did not apply
+
+	notify interface{} // the matched rule's notify target, as the API returned it
+}
+
+// alertPolicy is an alert policy that matched an alert.
+type alertPolicy struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Team    string   `json:"team,omitempty"` // empty for global policies
+	Changes []string `json:"changes"`
+}
+
+// alertTeams returns the teams on an alert and its team responders,
+// without duplicates.
+func alertTeams(a api.AlertResponse) []api.TeamRef {
+	var out []api.TeamRef
+	seen := map[string]bool{}
+	add := func(t api.TeamRef) {
+		key := t.ID
+		if key == "" {
+			key = strings.ToLower(t.Name)
+		}
+		if key == "" || seen[key] {
+			return
+		}
+		seen[key] = true
+		out = append(out, t)
+	}
+	for _, t := range a.Teams {
+		add(t)
+	}
+	for _, r := range a.Responders {
+		if r.Type == "team" {
+			add(api.TeamRef{ID: r.ID, Name: r.Name})
+		}
+	}
+	return out
+}
+
+// teamPath returns the API path of a team and the query naming how it is
+// identified.
+func teamPath(t api.TeamRef) (string, url.Values) {
+	if t.ID != "" {
+		return "/v2/teams/" + url.PathEscape(t.ID), url.Values{}
+	}
+	return "/v2/teams/" + url.PathEscape(t.Name), url.Values{"teamIdentifierType": {"name"}}
+}
+
+// routeAlert evaluates a team's routing rules in order against a, as of
+// the time it was created.
+func routeAlert(client *api.Client, a api.AlertResponse, t api.TeamRef, created time.Time) (alertRoute, error) {
+	route := alertRoute{Team: t.Name}
+	if route.Team == "" {
+		route.Team = t.ID
+	}
+	path, params := teamPath(t)
+	var rules []api.TeamRoutingRuleResponse
+	if err := client.GetWithParams(path+"/routing-rules", params, &rules); err != nil {
+		return route, fmt.Errorf("routing rules for team %s: %w", route.Team, err)
+	}
+	sort.SliceStable(rules, func(i, j int) bool { return rules[i].Order < rules[j].Order })
+
+	for _, r := range rules {
+		name := r.Name
+		if name == "" {
+			name = r.ID
+		}
+		switch {
+		case !routing.Match(r.Criteria, a):
+			route.Skipped = append(route.Skipped, name+": conditions do not match")
+		case !routing.InTime(r.TimeRestriction, created, location(r.Timezone)):
+			route.Skipped = append(route.Skipped, name+": outside its time restriction")
+		default:
+			route.Rule, route.RuleID, route.Notify = name, r.ID, notifyTarget(r.Notify)
+			route.notify = r.Notify
+			return route, nil
+		}
+	}
+	return route, nil
+}
+
+// location loads a time zone by name, falling back to UTC.
+func location(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// applyAlertPolicies evaluates the global alert policies and those of each
+// team against a. Within each scope policies run in order, and evaluation
+// stops at the first match unless that policy sets "continue".
+func applyAlertPolicies(client *api.Client, a api.AlertResponse, teams []api.TeamRef, created time.Time) ([]alertPolicy, error) {
+	scopes := append([]api.TeamRef{{}}, teams...)
+	out := []alertPolicy{}
+	for _, scope := range scopes {
+		params := url.Values{}
+		if scope.ID != "" {
+			params.Set("teamId", scope.ID)
+		} else if scope.Name != "" {
+			// Team policies are addressed by team ID only.
+			continue
+		}
+		var list []map[string]interface{}
+		if err := client.GetWithParams("/v2/policies/alert", params, &list); err != nil {
+			return nil, fmt.Errorf("alert policies: %w", err)
+		}
+		sort.SliceStable(list, func(i, j int) bool {
+			oi, _ := list[i]["order"].(float64)
+			oj, _ := list[j]["order"].(float64)
+			return oi < oj
+		})
+
+		for _, summary := range list {
+			if enabled, ok := summary["enabled"].(bool); ok && !enabled {
+				continue
+			}
+			var p map[string]interface{}
+			if err := client.GetWithParams("/v2/policies/"+url.PathEscape(stringVal(summary, "id")), params, &p); err != nil {
+				return nil, fmt.Errorf("alert policy %s: %w", stringVal(summary, "name"), err)
+			}
+			var rule struct {
+				Filter          *api.Filter          `json:"filter"`
+				TimeRestriction *api.TimeRestriction `json:"timeRestriction"`
+				Continue        bool                 `json:"continue"`
+			}
+			b, _ := json.Marshal(p)
+			if err := json.Unmarshal(b, &rule); err != nil {
+				return nil, fmt.Errorf("alert policy %s: %w", stringVal(p, "name"), err)
+			}
+			if !routing.Match(rule.Filter, a) || !routing.InTime(rule.TimeRestriction, created, time.UTC) {
+				continue
+			}
+			team := scope.Name
+			if team == "" {
+				team = scope.ID
+			}
+			out = append(out, alertPolicy{ID: stringVal(p, "id"), Name: stringVal(p, "name"), Team: team, Changes: policyChanges(p)})
+			if !rule.Continue {
+				break
+			}
+		}
+	}
+	return out, nil
+}
+
+// policyChanges describes what an alert policy does to the alerts it
+// matches. Fields that only pass the original value through (such as
+// "{{message}}") are left out.
+func policyChanges(p map[string]interface{}) []string {
+	changes := []string{}
+	for _, key := range []string{"message", "alias", "description", "entity", "source", "priority"} {
+		if v := stringVal(p, key); v != "" && v != "{{"+key+"}}" {
+			changes = append(changes, key+" = "+v)
+		}
+	}
+	for _, key := range []string{"tags", "actions"} {
+		items, _ := p[key].([]interface{})
+		if len(items) > 0 {
+			vals := make([]string, len(items))
+			for i, v := range items {
+				vals[i] = fmt.Sprint(v)
+			}
+			changes = append(changes, key+" += "+strings.Join(vals, ", "))
+		}
+	}
+	if details, _ := p["details"].(map[string]interface{}); len(details) > 0 {
+		pairs := make([]string, 0, len(details))
+		for k, v := range details {
+			pairs = append(pairs, fmt.Sprintf("%s=%v", k, v))
+		}
+		sort.Strings(pairs)
+		changes = append(changes, "details += "+strings.Join(pairs, ", "))
+	}
+	if responders, _ := p["responders"].([]interface{}); len(responders) > 0 {
+		names := make([]string, len(responders))
+		for i, r := range responders {
+			names[i] = notifyTarget(r)
+		}
+		changes = append(changes, "responders += "+strings.Join(names, ", "))
+	}
+	return changes
+}
+
+// whyDocument renders a routing explanation as plain text.
+func whyDocument(a api.AlertResponse, routes []alertRoute, policies []alertPolicy, timeline []timelineEntry) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Alert #%s: %s (%s)\n", a.TinyID, a.Message, a.ID)
+
+	b.WriteString("\nRouting (current configuration)\n")
+	if len(routes) == 0 {
+		b.WriteString("  The alert has no team, so no routing rules apply.\n")
+	}
+	for _, r := range routes {
+		if r.Rule == "" {
+			fmt.Fprintf(&b, "  %s: no routing rule matches\n", r.Team)
+		} else {
+			fmt.Fprintf(&b, "  %s: rule %q -> %s\n", r.Team, r.Rule, r.Notify)
+		}
+		for _, s := range r.Skipped {
+			fmt.Fprintf(&b, "    skipped %s\n", s)
+		}
+	}
+
+	b.WriteString("\nAlert policies (current configuration)\n")
+	if len(policies) == 0 {
+		b.WriteString("  No alert policy matches.\n")
+	}
+	for _, p := range policies {
+		scope := "global"
+		if p.Team != "" {
+			scope = "team " + p.Team
+		}
+		changes := "no field changes"
+		if len(p.Changes) > 0 {
+			changes = strings.Join(p.Changes, "; ")
+		}
+		fmt.Fprintf(&b, "  %q (%s): %s\n", p.Name, scope, changes)
+	}
+
+	b.WriteString("\nTimeline\n")
+	for _, e := range timeline {
+		actor := ""
+		if e.Actor != "" {
+			actor = " " + e.Actor + ":"
+		}
+		fmt.Fprintf(&b, "  %s  %-10s%s %s\n", e.Time, e.Kind, actor, e.Text)
+	}
+	return b.String()
+}
+
+func init() {
+	alertsCmd.AddCommand(alertsWhyCmd)
+	addOutputFlags(alertsWhyCmd)
+}
@@ -1,13 +1,19 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+	"github.com/roboalchemist/opsgenie-cli/pkg/auth"
 	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/roboalchemist/opsgenie-cli/pkg/refresh"
 	"github.com/spf13/cobra"
 )
 
@@ -25,6 +31,10 @@ var (
 	alertsListQuery  string
 	alertsListSort   string
 	alertsListAll    bool
+	alertsListStream bool
+
+	alertsListAroundDeployment string
+	alertsListWindow           int
 )
 
 var alertsListCmd = &cobra.Command{
@@ -33,18 +43,35 @@ var alertsListCmd = &cobra.Command{
 	Example: `  # List open P1 alerts as JSON
   opsgenie-cli alerts list --query "status:open AND priority:P1" --json
 
+  # The same without the query language, for one team
+  opsgenie-cli alerts list --status open --priority P1 --team platform
+
   # List with field filtering
   opsgenie-cli alerts list --json --fields id,message,status
 
   # Fetch all alerts (paginate)
-  opsgenie-cli alerts list --all --json`,
+  opsgenie-cli alerts list --all --json
+
+  # Stream every alert as one JSON object per line, page by page
+  opsgenie-cli alerts list --all --ndjson | jq -c 'select(.priority == "P1")'
+
+  # Did this deploy cause anything? Alerts for its service's team ±15 minutes
+  opsgenie-cli alerts list --around-deployment <deployment-id> --window 15`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
 		opts := getOutputOpts()
 
+		query, err := alertFilterQuery(client, alertsListQuery)
+		if err != nil {
+			return err
+		}
+		if alertsListAroundDeployment != "" {
+			return alertsAroundDeployment(client, alertsListAroundDeployment, time.Duration(alertsListWindow)*time.Minute, query, opts)
+		}
+
 		params := url.Values{}
 		if alertsListLimit > 0 {
 			params.Set("limit", strconv.Itoa(alertsListLimit))
@@ -54,13 +81,28 @@ var alertsListCmd = &cobra.Command{
 		if alertsListOffset > 0 {
 			params.Set("offset", strconv.Itoa(alertsListOffset))
 		}
-		if alertsListQuery != "" {
-			params.Set("query", alertsListQuery)
+		if query != "" {
+			params.Set("query", query)
 		}
 		if alertsListSort != "" {
 			params.Set("sort", alertsListSort)
 		}
 
+		if alertsListStream {
+			opts.Mode = output.ModeNDJSON
+		}
+		if opts.Mode == output.ModeNDJSON && opts.Template == "" {
+			emit := func(page json.RawMessage) error { return output.RenderJSONLines(page, opts) }
+			if alertsListAll {
+				return client.ListAllPages("/v2/alerts", params, emit)
+			}
+			var page json.RawMessage
+			if err := client.GetWithParams("/v2/alerts", params, &page); err != nil {
+				return err
+			}
+			return emit(page)
+		}
+
 		var alerts []api.AlertResponse
 		if alertsListAll {
 			if err := client.ListAll("/v2/alerts", params, &alerts); err != nil {
@@ -72,30 +114,118 @@ var alertsListCmd = &cobra.Command{
 			}
 		}
 
-		headers := []string{"ID", "Message", "Status", "Priority", "Acknowledged", "CreatedAt"}
-		rows := make([][]string, len(alerts))
+		if err := renderAlerts(alerts, opts); err != nil {
+			return err
+		}
+		ids := make([]string, len(alerts))
 		for i, a := range alerts {
-			rows[i] = []string{
-				a.ID,
-				a.Message,
-				a.Status,
-				a.Priority,
-				strconv.FormatBool(a.Acknowledged),
-				a.CreatedAt,
-			}
+			ids[i] = a.ID
 		}
-		return output.RenderTable(headers, rows, alerts, opts)
+		return copyResult(cmd, client, "alert", ids...)
 	},
 }
 
+// renderAlerts renders an alert list in the standard alerts list layout.
+func renderAlerts(alerts []api.AlertResponse, opts output.Options) error {
+	headers := []string{"ID", "Message", "Status", "Priority", "Acknowledged", "CreatedAt"}
+	rows := make([][]string, len(alerts))
+	for i, a := range alerts {
+		rows[i] = []string{
+			a.ID,
+			a.Message,
+			a.Status,
+			a.Priority,
+			strconv.FormatBool(a.Acknowledged),
+			a.CreatedAt,
+		}
+	}
+	return output.RenderTable(headers, rows, alerts, opts)
+}
+
+// alertsAroundDeployment lists alerts created within window either side of
+// a deployment that belong to the teams owning the deployment's services.
+// Deployments without services match alerts from every team.
+func alertsAroundDeployment(client *api.Client, id string, window time.Duration, query string, opts output.Options) error {
+	var resp struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := client.Get("/v2/deployments/"+id, &resp); err != nil {
+		return err
+	}
+	deployment := resp.Data
+
+	var at time.Time
+	for _, key := range []string{"startedAt", "createdAt", "time"} {
+		if t, err := time.Parse(time.RFC3339Nano, stringVal(deployment, key)); err == nil {
+			at = t
+			break
+		}
+	}
+	if at.IsZero() {
+		return fmt.Errorf("deployment %s has no start time", id)
+	}
+
+	serviceIDs := []string{}
+	if s := stringVal(deployment, "serviceId"); s != "" {
+		serviceIDs = append(serviceIDs, s)
+	}
+	if ids, ok := deployment["serviceIds"].([]interface{}); ok {
+		serviceIDs = append(serviceIDs, interfaceStrings(ids)...)
+	}
+	teams := map[string]bool{}
+	for _, sid := range serviceIDs {
+		var svc struct {
+			Data map[string]interface{} `json:"data"`
+		}
+		if err := client.Get("/v1/services/"+sid, &svc); err != nil {
+			return fmt.Errorf("service %s: %w", sid, err)
+		}
+		if team := stringVal(svc.Data, "teamId"); team != "" {
+			teams[team] = true
+		}
+	}
+
+	params := url.Values{}
+	params.Set("query", alertsCreatedQuery(at.Add(-window), at.Add(window), query))
+	if alertsListSort != "" {
+		params.Set("sort", alertsListSort)
+	}
+	var alerts []api.AlertResponse
+	if err := client.ListAll("/v2/alerts", params, &alerts); err != nil {
+		return err
+	}
+
+	matched := []api.AlertResponse{}
+	for _, a := range alerts {
+		if len(teams) == 0 {
+			matched = append(matched, a)
+			continue
+		}
+		for _, team := range alertTeamNames(a, nil) {
+			if teams[team] {
+				matched = append(matched, a)
+				break
+			}
+		}
+	}
+	if alertsListLimit > 0 && len(matched) > alertsListLimit {
+		matched = matched[:alertsListLimit]
+	}
+	return renderAlerts(matched, opts)
+}
+
 func init() {
 	alertsCmd.AddCommand(alertsListCmd)
 	addOutputFlags(alertsListCmd)
+	addCopyFlag(alertsListCmd)
 	alertsListCmd.Flags().IntVar(&alertsListLimit, "limit", 0, "Maximum number of alerts to return (default 20)")
 	alertsListCmd.Flags().BoolVar(&alertsListAll, "all", false, "Fetch all alerts (paginate through all pages)")
+	alertsListCmd.Flags().BoolVar(&alertsListStream, "stream", false, "Write one JSON object per line as each page arrives (same as --ndjson)")
 	alertsListCmd.Flags().IntVar(&alertsListOffset, "offset", 0, "Start offset for pagination")
 	alertsListCmd.Flags().StringVar(&alertsListQuery, "query", "", "Search query (OpsGenie query syntax)")
 	alertsListCmd.Flags().StringVar(&alertsListSort, "sort", "", "Sort field (e.g. createdAt, updatedAt)")
+	alertsListCmd.Flags().StringVar(&alertsListAroundDeployment, "around-deployment", "", "List alerts for the deployment's service teams created near its start")
+	alertsListCmd.Flags().IntVar(&alertsListWindow, "window", 30, "Minutes either side of the deployment for --around-deployment")
 }
 
 // ─── alerts get ──────────────────────────────────────────────────────────────
@@ -110,14 +240,18 @@ var alertsGetCmd = &cobra.Command{
   opsgenie-cli alerts get abc123 --json --fields id,message,status,priority`,
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
 		opts := getOutputOpts()
 
 		var envelope api.APIResponse[api.AlertResponse]
-		if err := client.Get("/v2/alerts/"+args[0]+"?identifierType=id", &envelope); err != nil {
+		path, err := alertPath(args[0], "")
+		if err != nil {
+			return err
+		}
+		if err := client.Get(path, &envelope); err != nil {
 			return err
 		}
 		a := envelope.Data
@@ -136,18 +270,286 @@ var alertsGetCmd = &cobra.Command{
 			{"Source", a.Source},
 			{"Owner", a.Owner},
 			{"Tags", strings.Join(a.Tags, ", ")},
+			{"Actions", strings.Join(a.Actions, ", ")},
 			{"Count", strconv.Itoa(a.Count)},
 			{"CreatedAt", a.CreatedAt},
 			{"UpdatedAt", a.UpdatedAt},
 			{"ClosedAt", a.ClosedAt},
 		}
-		return output.RenderTable(headers, rows, a, opts)
+		if err := output.RenderTable(headers, rows, a, opts); err != nil {
+			return err
+		}
+		return copyResult(cmd, client, "alert", a.ID)
 	},
 }
 
 func init() {
 	alertsCmd.AddCommand(alertsGetCmd)
 	addOutputFlags(alertsGetCmd)
+	addCopyFlag(alertsGetCmd)
+}
+
+// ─── alerts show ─────────────────────────────────────────────────────────────
+
+var alertsShowNoPager bool
+
+var alertsShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Show an alert with its notes, log and recipients",
+	Long: `Show an alert together with its notes, activity log and notified recipients
+as one document, with a single chronological timeline.
+
+On a terminal the document is shown through $PAGER (default "less -FRX");
+use --no-pager to print it directly. With --json or --yaml the parts are
+returned as one object.`,
+	Example: `  # Everything about an alert in one scrollable view
+  opsgenie-cli alerts show <alert-id>
+
+  # The same data for scripts
+  opsgenie-cli alerts show <alert-id> --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		var envelope api.APIResponse[api.AlertResponse]
+		path, err := alertPath(args[0], "")
+		if err != nil {
+			return err
+		}
+		if err := client.Get(path, &envelope); err != nil {
+			return err
+		}
+		a := envelope.Data
+		base := "/v2/alerts/" + url.PathEscape(a.ID)
+		params := url.Values{"identifierType": {"id"}, "order": {"asc"}}
+
+		notes := []api.AlertNote{}
+		if err := client.ListAll(base+"/notes", params, &notes); err != nil {
+			return fmt.Errorf("notes: %w", err)
+		}
+		logs := []api.AlertLog{}
+		if err := client.ListAll(base+"/logs", params, &logs); err != nil {
+			return fmt.Errorf("logs: %w", err)
+		}
+		var recipients struct {
+			Data []api.AlertRecipient `json:"data"`
+		}
+		if err := client.Get(base+"/recipients?identifierType=id", &recipients); err != nil {
+			return fmt.Errorf("recipients: %w", err)
+		}
+		if recipients.Data == nil {
+			recipients.Data = []api.AlertRecipient{}
+		}
+
+		timeline := alertTimeline(a, notes, logs, recipients.Data)
+		if opts.Structured() || opts.Template != "" || opts.JQExpr != "" {
+			return output.RenderJSON(map[string]interface{}{
+				"alert":      a,
+				"notes":      notes,
+				"logs":       logs,
+				"recipients": recipients.Data,
+				"timeline":   timeline,
+			}, opts)
+		}
+
+		doc := alertDocument(a, timeline)
+		if alertsShowNoPager {
+			fmt.Print(doc)
+			return nil
+		}
+		return output.Page(doc)
+	},
+}
+
+// timelineEntry is one event in an alert's history.
+type timelineEntry struct {
+	Time  string `json:"time"`
+	Kind  string `json:"kind"` // "created", "note", "log" or "recipient"
+	Actor string `json:"actor,omitempty"`
+	Text  string `json:"text"`
+}
+
+// alertTimeline merges an alert's creation, notes, log entries and
+// recipients into one list ordered by time.
+func alertTimeline(a api.AlertResponse, notes []api.AlertNote, logs []api.AlertLog, recipients []api.AlertRecipient) []timelineEntry {
+	entries := []timelineEntry{{Time: a.CreatedAt, Kind: "created", Actor: a.Source, Text: a.Message}}
+	for _, n := range notes {
+		entries = append(entries, timelineEntry{Time: n.CreatedAt, Kind: "note", Actor: n.Owner, Text: n.Note})
+	}
+	for _, l := range logs {
+		entries = append(entries, timelineEntry{Time: l.CreatedAt, Kind: "log", Actor: l.Owner, Text: l.Log})
+	}
+	for _, r := range recipients {
+		text := "notified"
+		if r.Method != "" {
+			text += " via " + r.Method
+		}
+		if r.State != "" {
+			text += " (" + r.State + ")"
+		}
+		entries = append(entries, timelineEntry{Time: r.CreatedAt, Kind: "recipient", Actor: r.User.Username, Text: text})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		ti, _ := time.Parse(time.RFC3339Nano, entries[i].Time)
+		tj, _ := time.Parse(time.RFC3339Nano, entries[j].Time)
+		return ti.Before(tj)
+	})
+	return entries
+}
+
+// alertDocument renders an alert and its timeline as plain text.
+func alertDocument(a api.AlertResponse, timeline []timelineEntry) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Alert #%s: %s\n\n", a.TinyID, a.Message)
+	fields := [][2]string{
+		{"ID", a.ID},
+		{"Status", a.Status},
+		{"Priority", a.Priority},
+		{"Acknowledged", strconv.FormatBool(a.Acknowledged)},
+		{"Owner", a.Owner},
+		{"Source", a.Source},
+		{"Tags", strings.Join(a.Tags, ", ")},
+		{"Count", strconv.Itoa(a.Count)},
+		{"CreatedAt", a.CreatedAt},
+		{"UpdatedAt", a.UpdatedAt},
+	}
+	for _, f := range fields {
+		if f[1] != "" {
+			fmt.Fprintf(&b, "%-13s %s\n", f[0]+":", f[1])
+		}
+	}
+	if a.Description != "" {
+		fmt.Fprintf(&b, "\n%s\n", a.Description)
+	}
+	if len(a.Details) > 0 {
+		b.WriteString("\nDetails\n")
+		keys := make([]string, 0, len(a.Details))
+		for k := range a.Details {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Fprintf(&b, "  %s: %s\n", k, a.Details[k])
+		}
+	}
+
+	b.WriteString("\nTimeline\n")
+	for _, e := range timeline {
+		actor := ""
+		if e.Actor != "" {
+			actor = " " + e.Actor + ":"
+		}
+		fmt.Fprintf(&b, "  %s  %-9s%s %s\n", e.Time, e.Kind, actor, e.Text)
+	}
+	return b.String()
+}
+
+func init() {
+	alertsCmd.AddCommand(alertsShowCmd)
+	addOutputFlags(alertsShowCmd)
+	alertsShowCmd.Flags().BoolVar(&alertsShowNoPager, "no-pager", false, "Print directly instead of through $PAGER")
+}
+
+// ─── alerts watch ────────────────────────────────────────────────────────────
+
+var (
+	alertsWatchQuery     string
+	alertsWatchInterval  = durationFlag(30 * time.Second)
+	alertsWatchFullEvery int
+)
+
+var alertsWatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Keep an alert list up to date until interrupted",
+	Long: `Show alerts matching --query and keep the list current until interrupted.
+
+After the first load only alerts updated since the newest one seen are
+fetched (an updatedAt watermark query), so each refresh costs one small
+request instead of a full reload. Every --full-every refreshes the whole list
+is reloaded so alerts that no longer match the query drop out.
+
+In table mode the screen is redrawn on every change. With --json, --yaml or
+--ndjson only the new and changed alerts of each refresh are written.`,
+	Example: `  # Live view of open P1/P2 alerts
+  opsgenie-cli alerts watch --query "status:open AND priority:(P1 OR P2)"
+
+  # Feed changes to another tool as they happen
+  opsgenie-cli alerts watch --query "status:open" --ndjson --interval 1m`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		fetch := func(ctx context.Context, since time.Time) ([]refresh.Item, error) {
+			params := url.Values{}
+			query := alertsWatchQuery
+			if !since.IsZero() {
+				query = alertsUpdatedSinceQuery(since, query)
+			}
+			if query != "" {
+				params.Set("query", query)
+			}
+			var alerts []api.AlertResponse
+			if err := client.ListAllCtx(ctx, "/v2/alerts", params, &alerts); err != nil {
+				return nil, err
+			}
+			items := make([]refresh.Item, len(alerts))
+			for i, a := range alerts {
+				updated, err := time.Parse(time.RFC3339Nano, a.UpdatedAt)
+				if err != nil {
+					updated, _ = time.Parse(time.RFC3339Nano, a.CreatedAt)
+				}
+				items[i] = refresh.Item{ID: a.ID, UpdatedAt: updated, Data: a}
+			}
+			return items, nil
+		}
+
+		r := &refresh.Refresher{Fetch: fetch, Interval: time.Duration(alertsWatchInterval), FullEvery: alertsWatchFullEvery}
+		return r.Run(cmd.Context(), func(u refresh.Update) error {
+			if opts.Structured() || opts.Template != "" || opts.JQExpr != "" {
+				if len(u.Changed) == 0 {
+					return nil
+				}
+				return renderAlerts(refreshAlerts(u.Changed), opts)
+			}
+			output.ClearScreen()
+			fmt.Printf("%d alert(s), %d changed, updated %s (Ctrl-C to stop)\n\n",
+				len(u.Items), len(u.Changed), time.Now().Format("15:04:05"))
+			return renderAlerts(refreshAlerts(u.Items), opts)
+		})
+	},
+}
+
+// alertsUpdatedSinceQuery narrows query to alerts updated at or after since.
+func alertsUpdatedSinceQuery(since time.Time, query string) string {
+	q := "updatedAt >= " + strconv.FormatInt(since.UnixMilli(), 10)
+	if query != "" {
+		q = "(" + query + ") AND " + q
+	}
+	return q
+}
+
+// refreshAlerts unwraps the alerts held by refresh items.
+func refreshAlerts(items []refresh.Item) []api.AlertResponse {
+	alerts := make([]api.AlertResponse, len(items))
+	for i, it := range items {
+		alerts[i] = it.Data.(api.AlertResponse)
+	}
+	return alerts
+}
+
+func init() {
+	alertsCmd.AddCommand(alertsWatchCmd)
+	addOutputFlags(alertsWatchCmd)
+	alertsWatchCmd.Flags().StringVar(&alertsWatchQuery, "query", "", "Search query (OpsGenie query syntax)")
+	alertsWatchCmd.Flags().Var(&alertsWatchInterval, "interval", "Time between refreshes (e.g. 30s, 5m)")
+	alertsWatchCmd.Flags().IntVar(&alertsWatchFullEvery, "full-every", 10, "Reload the whole list every N refreshes (0 = never)")
 }
 
 // ─── alerts create ───────────────────────────────────────────────────────────
@@ -158,41 +560,116 @@ var (
 	alertCreatePriority    string
 	alertCreateTags        string
 	alertCreateResponders  string
+	alertCreateNoDefaults  bool
+	alertCreateFile        string
+	alertCreateAlias       string
+	alertCreateEntity      string
+	alertCreateSource      string
+	alertCreateUser        string
+	alertCreateNote        string
+	alertCreateActions     string
+	alertCreateDetails     []string
 )
 
 var alertsCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a new alert",
+	Long: `Create a new alert.
+
+The default responders set in ~/.opsgenie-cli-auth.json ("default_responders",
+e.g. ["team:ops"]) or OPSGENIE_DEFAULT_RESPONDERS are added to the
+--responders, so alerts from scripts are never left unrouted. Pass
+--no-default-responders to leave them out.
+
+--alias sets the deduplication key: while an alert with the same alias is
+open, OpsGenie counts a new occurrence instead of creating another alert.
+
+--file (-f) reads the whole request body from a JSON file, or stdin with
+"-f -", for fields the flags do not cover, such as visibleTo and responders
+by ID. Flags given alongside it override the file's fields; --detail adds
+to its details. The body is checked against the API's schema (message
+required, types, lengths, priorities and responder types) before anything
+is sent.`,
 	Example: `  # Create a P1 alert
   opsgenie-cli alerts create --message "Database unreachable" --priority P1
 
+  # Full payload from a file, or from another tool on stdin
+  opsgenie-cli alerts create -f payload.json
+  jq -n '{message: "Disk full", alias: "disk-db1", details: {host: "db1"}}' | opsgenie-cli alerts create -f -
+
   # Create with description and responders
   opsgenie-cli alerts create --message "Disk full" --description "Root volume at 99%" \
-    --priority P2 --responders team:platform`,
+    --priority P2 --responders team:platform
+
+  # Deduplicated by alias, with details and a custom action
+  opsgenie-cli alerts create --message "Disk full on db1" --alias disk-db1 --entity db1 \
+    --source nagios --detail host=db1 --detail mount=/var --actions Restart`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if alertCreateMessage == "" {
-			return fmt.Errorf("--message is required")
-		}
-		client, err := newClient()
-		if err != nil {
-			return err
+		body := map[string]interface{}{}
+		if alertCreateFile != "" {
+			var err error
+			if body, err = readAlertPayload(alertCreateFile); err != nil {
+				return err
+			}
+		} else if alertCreateMessage == "" {
+			return fmt.Errorf("--message (or --file) is required")
 		}
 
-		body := map[string]interface{}{
-			"message": alertCreateMessage,
+		for field, v := range map[string]string{
+			"message":     alertCreateMessage,
+			"description": alertCreateDescription,
+			"priority":    alertCreatePriority,
+			"alias":       alertCreateAlias,
+			"entity":      alertCreateEntity,
+			"source":      alertCreateSource,
+			"user":        alertCreateUser,
+			"note":        alertCreateNote,
+		} {
+			if v != "" {
+				body[field] = v
+			}
 		}
-		if alertCreateDescription != "" {
-			body["description"] = alertCreateDescription
+		if alertCreateTags != "" {
+			body["tags"] = stringList(splitAndTrim(alertCreateTags))
 		}
-		if alertCreatePriority != "" {
-			body["priority"] = alertCreatePriority
+		if alertCreateActions != "" {
+			body["actions"] = stringList(splitAndTrim(alertCreateActions))
 		}
-		if alertCreateTags != "" {
-			tags := splitAndTrim(alertCreateTags)
-			body["tags"] = tags
+		if len(alertCreateDetails) > 0 {
+			details, _ := body["details"].(map[string]interface{})
+			if details == nil {
+				details = map[string]interface{}{}
+			}
+			for _, d := range alertCreateDetails {
+				k, v, ok := strings.Cut(d, "=")
+				if !ok || strings.TrimSpace(k) == "" {
+					return fmt.Errorf("invalid --detail %q (use key=value)", d)
+				}
+				details[strings.TrimSpace(k)] = v
+			}
+			body["details"] = details
 		}
-		if alertCreateResponders != "" {
-			body["responders"] = parseResponders(alertCreateResponders)
+		if err := validateAlertPayload(body); err != nil {
+			if alertCreateFile != "" {
+				return output.Invalid(fmt.Errorf("invalid alert payload in %s:\n%w", alertPayloadSource(alertCreateFile), err))
+			}
+			return output.Invalid(fmt.Errorf("invalid alert:\n%w", err))
+		}
+
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		responders := mergeResponders(parseResponders(alertCreateResponders), payloadResponders(body["responders"]))
+		if !alertCreateNoDefaults {
+			defaults, err := auth.DefaultResponders()
+			if err != nil {
+				return err
+			}
+			responders = mergeResponders(responders, parseResponders(strings.Join(defaults, ",")))
+		}
+		if len(responders) > 0 {
+			body["responders"] = responders
 		}
 
 		var result map[string]interface{}
@@ -216,28 +693,138 @@ func init() {
 	alertsCreateCmd.Flags().StringVar(&alertCreatePriority, "priority", "", "Priority (P1-P5)")
 	alertsCreateCmd.Flags().StringVar(&alertCreateTags, "tags", "", "Comma-separated tags")
 	alertsCreateCmd.Flags().StringVar(&alertCreateResponders, "responders", "", "Comma-separated responders (e.g. team:myteam,user:user@example.com)")
+	alertsCreateCmd.Flags().BoolVar(&alertCreateNoDefaults, "no-default-responders", false, "Do not add the configured default responders")
+	alertsCreateCmd.Flags().StringVarP(&alertCreateFile, "file", "f", "", `JSON file with the full alert body ("-" for stdin); flags override its fields`)
+	alertsCreateCmd.Flags().StringVar(&alertCreateAlias, "alias", "", "Alias used to deduplicate the alert")
+	alertsCreateCmd.Flags().StringVar(&alertCreateEntity, "entity", "", "Entity the alert is about, e.g. a host or service")
+	alertsCreateCmd.Flags().StringVar(&alertCreateSource, "source", "", "Source of the alert (default: the client IP, set by OpsGenie)")
+	alertsCreateCmd.Flags().StringVar(&alertCreateUser, "user", "", "Display name of the alert's creator")
+	alertsCreateCmd.Flags().StringVar(&alertCreateNote, "note", "", "Note added while creating the alert")
+	alertsCreateCmd.Flags().StringVar(&alertCreateActions, "actions", "", "Comma-separated custom actions available on the alert")
+	alertsCreateCmd.Flags().StringArrayVar(&alertCreateDetails, "detail", nil, "Custom detail as key=value (repeatable)")
 }
 
-// ─── alerts delete ───────────────────────────────────────────────────────────
+// ─── alerts update ───────────────────────────────────────────────────────────
 
-var alertsDeleteCmd = &cobra.Command{
-	Use:   "delete <id>",
-	Short: "Delete an alert",
-	Args:  cobra.ExactArgs(1),
+var (
+	alertsUpdateMessage     string
+	alertsUpdateDescription string
+	alertsUpdatePriority    string
+	alertsUpdateDetails     []string
+)
+
+var alertsUpdateCmd = &cobra.Command{
+	Use:   "update <id>",
+	Short: "Update an alert's message, description, priority or details",
+	Long: `Update an alert's message, description, priority or custom details.
+
+Each changed field is a separate API call. --detail adds or overwrites
+details and leaves the others alone.`,
+	Example: `  # Raise the priority and reword the message
+  opsgenie-cli alerts update abc123 --priority P1 --message "Checkout down in eu-west-1"
+
+  # Attach details
+  opsgenie-cli alerts update abc123 --detail runbook=https://wiki/checkout --detail region=eu-west-1`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		flags := cmd.Flags()
+		if !flags.Changed("message") && !flags.Changed("description") && !flags.Changed("priority") && len(alertsUpdateDetails) == 0 {
+			return fmt.Errorf("nothing to update: use --message, --description, --priority or --detail")
+		}
+		if flags.Changed("message") && alertsUpdateMessage == "" {
+			return fmt.Errorf("--message cannot be empty")
+		}
+		if flags.Changed("priority") && !validPriority(alertsUpdatePriority) {
+			return output.Invalid(fmt.Errorf("invalid --priority %q (use P1, P2, P3, P4 or P5)", alertsUpdatePriority))
+		}
+		details := map[string]string{}
+		for _, d := range alertsUpdateDetails {
+			k, v, ok := strings.Cut(d, "=")
+			if !ok || strings.TrimSpace(k) == "" {
+				return fmt.Errorf("invalid --detail %q (use key=value)", d)
+			}
+			details[strings.TrimSpace(k)] = v
+		}
+		idType, err := alertIdentifierType(args[0])
 		if err != nil {
 			return err
 		}
-		if err := client.Delete("/v2/alerts/"+args[0]+"?identifierType=id", nil); err != nil {
+
+		client, err := newClient(cmd.Context())
+		if err != nil {
 			return err
 		}
+		base, query := "/v2/alerts/"+url.PathEscape(args[0]), "?identifierType="+idType
+		if flags.Changed("message") {
+			if err := client.Put(base+"/message"+query, map[string]string{"message": alertsUpdateMessage}, nil); err != nil {
+				return fmt.Errorf("message: %w", err)
+			}
+		}
+		if flags.Changed("description") {
+			if err := client.Put(base+"/description"+query, map[string]string{"description": alertsUpdateDescription}, nil); err != nil {
+				return fmt.Errorf("description: %w", err)
+			}
+		}
+		if flags.Changed("priority") {
+			if err := client.Put(base+"/priority"+query, map[string]string{"priority": strings.ToUpper(alertsUpdatePriority)}, nil); err != nil {
+				return fmt.Errorf("priority: %w", err)
+			}
+		}
+		if len(details) > 0 {
+			if err := client.Post(base+"/details"+query, map[string]interface{}{"details": details}, nil); err != nil {
+				return fmt.Errorf("details: %w", err)
+			}
+		}
+
 		opts := GetOutputOptions()
-		output.Success("Alert deleted", opts)
+		output.Success("Alert updated", opts)
 		return nil
 	},
 }
 
+// validPriority reports whether p is an OpsGenie priority, P1 to P5.
+func validPriority(p string) bool {
+	switch strings.ToUpper(p) {
+	case "P1", "P2", "P3", "P4", "P5":
+		return true
+	}
+	return false
+}
+
+func init() {
+	alertsCmd.AddCommand(alertsUpdateCmd)
+	alertsUpdateCmd.Flags().StringVar(&alertsUpdateMessage, "message", "", "New alert message")
+	alertsUpdateCmd.Flags().StringVar(&alertsUpdateDescription, "description", "", "New alert description")
+	alertsUpdateCmd.Flags().StringVar(&alertsUpdatePriority, "priority", "", "New priority (P1-P5)")
+	alertsUpdateCmd.Flags().StringArrayVar(&alertsUpdateDetails, "detail", nil, "Custom detail as key=value (repeatable)")
+}
+
+// ─── alerts delete ───────────────────────────────────────────────────────────
+
+var alertsDeleteCmd = &cobra.Command{
+	Use:   "delete <id>|-",
+	Short: "Delete an alert, or every alert identified on stdin",
+	Example: `  # Delete an alert
+  opsgenie-cli alerts delete abc123
+
+  # Delete every closed alert from CI
+  opsgenie-cli alerts list --all -q --query "tag:ci AND status:closed" | opsgenie-cli alerts delete -`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		return runAlertAction(cmd, client, args[0], "Alert deleted", "deleted", func(id string) error {
+			path, err := alertPath(id, "")
+			if err != nil {
+				return err
+			}
+			return client.Delete(path, nil)
+		})
+	},
+}
+
 func init() {
 	alertsCmd.AddCommand(alertsDeleteCmd)
 }
@@ -245,30 +832,111 @@ func init() {
 // ─── alerts acknowledge ───────────────────────────────────────────────────────
 
 var alertsAcknowledgeCmd = &cobra.Command{
-	Use:   "acknowledge <id>",
-	Short: "Acknowledge an alert",
+	Use:   "acknowledge <id>|-",
+	Short: "Acknowledge an alert, or every alert identified on stdin",
 	Example: `  # Acknowledge an alert by ID
   opsgenie-cli alerts acknowledge abc123
 
   # Acknowledge and suppress progress output
-  opsgenie-cli alerts acknowledge abc123 --quiet`,
-	Args:  cobra.ExactArgs(1),
+  opsgenie-cli alerts acknowledge abc123 --quiet
+
+  # Acknowledge every open P1, several at a time
+  opsgenie-cli alerts list -q --query "status:open AND priority:P1" | opsgenie-cli alerts acknowledge -`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		return runAlertAction(cmd, client, args[0], "Alert acknowledged", "acknowledged", func(id string) error {
+			path, err := alertPath(id, "/acknowledge")
+			if err != nil {
+				return err
+			}
+			return client.Post(path, map[string]interface{}{}, nil)
+		})
+	},
+}
+
+func init() {
+	alertsCmd.AddCommand(alertsAcknowledgeCmd)
+}
+
+// ─── alerts unacknowledge ─────────────────────────────────────────────────────
+
+var alertsUnacknowledgeCmd = &cobra.Command{
+	Use:     "unacknowledge <id>",
+	Aliases: []string{"unack"},
+	Short:   "Unacknowledge an alert",
+	Example: `  # Hand an alert back to the on-call rotation
+  opsgenie-cli alerts unack abc123`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
-		if err := client.Post("/v2/alerts/"+args[0]+"/acknowledge", map[string]interface{}{}, nil); err != nil {
+		path, err := alertPath(args[0], "/unacknowledge")
+		if err != nil {
+			return err
+		}
+		if err := client.Post(path, map[string]interface{}{}, nil); err != nil {
 			return err
 		}
 		opts := GetOutputOptions()
-		output.Success("Alert acknowledged", opts)
+		output.Success("Alert unacknowledged", opts)
 		return nil
 	},
 }
 
 func init() {
-	alertsCmd.AddCommand(alertsAcknowledgeCmd)
+	alertsCmd.AddCommand(alertsUnacknowledgeCmd)
+}
+
+// ─── alerts execute-action ────────────────────────────────────────────────────
+
+var (
+	alertsExecuteActionAction string
+	alertsExecuteActionNote   string
+)
+
+var alertsExecuteActionCmd = &cobra.Command{
+	Use:   "execute-action <id>",
+	Short: "Run a custom action on an alert",
+	Long: `Run a custom action on an alert. Custom actions are defined on the
+integration that created the alert; "alerts get" lists the ones available.`,
+	Example: `  # Trigger the "Restart" action defined on the alert's integration
+  opsgenie-cli alerts execute-action abc123 --action Restart --note "Restarting from CLI"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if alertsExecuteActionAction == "" {
+			return fmt.Errorf("--action is required")
+		}
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		body := map[string]interface{}{}
+		if alertsExecuteActionNote != "" {
+			body["note"] = alertsExecuteActionNote
+		}
+		path, err := alertPath(args[0], "/actions/"+url.PathEscape(alertsExecuteActionAction))
+		if err != nil {
+			return err
+		}
+		if err := client.Post(path, body, nil); err != nil {
+			return err
+		}
+		opts := GetOutputOptions()
+		output.Success(fmt.Sprintf("Action %q executed", alertsExecuteActionAction), opts)
+		return nil
+	},
+}
+
+func init() {
+	alertsCmd.AddCommand(alertsExecuteActionCmd)
+	alertsExecuteActionCmd.Flags().StringVar(&alertsExecuteActionAction, "action", "", "Name of the custom action (required)")
+	alertsExecuteActionCmd.Flags().StringVar(&alertsExecuteActionNote, "note", "", "Note to add to the alert")
 }
 
 // ─── alerts close ─────────────────────────────────────────────────────────────
@@ -276,24 +944,49 @@ func init() {
 var alertsCloseNote string
 
 var alertsCloseCmd = &cobra.Command{
-	Use:   "close <id>",
-	Short: "Close an alert",
-	Args:  cobra.ExactArgs(1),
+	Use:   "close [<id>|-]",
+	Short: "Close an alert, or every alert matching a query",
+	Long: `Close an alert by ID, or with --query every alert matching a search query.
+With "-" in place of the ID, every alert identified on stdin (one per line)
+is closed, up to --concurrency at a time.
+
+With --query the matching alerts are listed and closed after confirmation
+(or at once with --yes), concurrently. If more than --limit alerts match,
+nothing is closed, so a mistyped query cannot close everything.`,
+	Example: `  # Close one alert
+  opsgenie-cli alerts close 1234 --note "Fixed"
+
+  # Close the open alerts CI raised, after reviewing the list
+  opsgenie-cli alerts close --query "tag:ci AND status:open" --limit 200
+
+  # The same, unattended
+  opsgenie-cli alerts close --query "tag:ci AND status:open" --limit 200 --yes`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		switch {
+		case alertsCloseQuery != "" && len(args) > 0:
+			return output.Invalid(fmt.Errorf("give an alert ID or --query, not both"))
+		case alertsCloseQuery == "" && len(args) == 0:
+			return output.Invalid(fmt.Errorf("an alert ID or --query is required"))
+		}
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
+		if alertsCloseQuery != "" {
+			return closeAlertsByQuery(cmd, client)
+		}
 		body := map[string]interface{}{}
 		if alertsCloseNote != "" {
 			body["note"] = alertsCloseNote
 		}
-		if err := client.Post("/v2/alerts/"+args[0]+"/close", body, nil); err != nil {
-			return err
-		}
-		opts := GetOutputOptions()
-		output.Success("Alert closed", opts)
-		return nil
+		return runAlertAction(cmd, client, args[0], "Alert closed", "closed", func(id string) error {
+			path, err := alertPath(id, "/close")
+			if err != nil {
+				return err
+			}
+			return client.Post(path, body, nil)
+		})
 	},
 }
 
@@ -304,24 +997,78 @@ func init() {
 
 // ─── alerts snooze ───────────────────────────────────────────────────────────
 
-var alertsSnoozeEndTime string
+var (
+	alertsSnoozeEndTime            string
+	alertsSnoozeUntilBusinessHours bool
+	alertsSnoozeTimezone           string
+)
+
+// nextBusinessStart returns the next start of business hours, 09:00 on a
+// weekday, after now in now's location.
+func nextBusinessStart(now time.Time) time.Time {
+	t := time.Date(now.Year(), now.Month(), now.Day(), 9, 0, 0, 0, now.Location())
+	for !t.After(now) || t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t
+}
+
+// snoozeEnd returns the --end-time, --for or --until-business-hours end
+// of a snooze.
+func snoozeEnd(cmd *cobra.Command) (string, error) {
+	if !alertsSnoozeUntilBusinessHours {
+		return endDate(cmd, "end-time", "")
+	}
+	if cmd.Flags().Changed("end-time") || cmd.Flags().Changed("for") {
+		return "", output.Invalid(fmt.Errorf("--until-business-hours cannot be combined with --end-time or --for"))
+	}
+	loc, err := timeLocation()
+	if err != nil {
+		return "", err
+	}
+	if alertsSnoozeTimezone != "" {
+		if loc, err = time.LoadLocation(alertsSnoozeTimezone); err != nil {
+			return "", output.Invalid(fmt.Errorf("invalid --timezone %q: %w", alertsSnoozeTimezone, err))
+		}
+	}
+	return nextBusinessStart(time.Now().In(loc)).UTC().Format(time.RFC3339), nil
+}
 
 var alertsSnoozeCmd = &cobra.Command{
 	Use:   "snooze <id>",
 	Short: "Snooze an alert until a given time",
-	Args:  cobra.ExactArgs(1),
+	Example: `  # Snooze for two and a half hours
+  opsgenie-cli alerts snooze abc123 --for 2h30m
+
+  # Snooze until a fixed time
+  opsgenie-cli alerts snooze abc123 --end-time 2024-01-15T10:00:00Z
+
+  # Snooze until tomorrow morning (in the configured timezone)
+  opsgenie-cli alerts snooze abc123 --end-time "tomorrow 9am"
+
+  # Snooze until the next weekday 09:00 in Berlin
+  opsgenie-cli alerts snooze abc123 --until-business-hours --timezone Europe/Berlin`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if alertsSnoozeEndTime == "" {
-			return fmt.Errorf("--end-time is required (RFC3339, e.g. 2024-01-15T10:00:00Z)")
+		end, err := snoozeEnd(cmd)
+		if err != nil {
+			return err
 		}
-		client, err := newClient()
+		if end == "" {
+			return fmt.Errorf("--end-time (e.g. 2024-01-15T10:00:00Z or \"tomorrow 9am\"), --for (e.g. 2h) or --until-business-hours is required")
+		}
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
 		body := map[string]interface{}{
-			"endTime": alertsSnoozeEndTime,
+			"endTime": end,
 		}
-		if err := client.Post("/v2/alerts/"+args[0]+"/snooze", body, nil); err != nil {
+		path, err := alertPath(args[0], "/snooze")
+		if err != nil {
+			return err
+		}
+		if err := client.Post(path, body, nil); err != nil {
 			return err
 		}
 		opts := GetOutputOptions()
@@ -332,7 +1079,10 @@ var alertsSnoozeCmd = &cobra.Command{
 
 func init() {
 	alertsCmd.AddCommand(alertsSnoozeCmd)
-	alertsSnoozeCmd.Flags().StringVar(&alertsSnoozeEndTime, "end-time", "", "Snooze until this time (RFC3339)")
+	alertsSnoozeCmd.Flags().StringVar(&alertsSnoozeEndTime, "end-time", "", "Snooze until this time ("+timeFormats+")")
+	addForFlag(alertsSnoozeCmd, "end-time", "Snooze for this long")
+	alertsSnoozeCmd.Flags().BoolVar(&alertsSnoozeUntilBusinessHours, "until-business-hours", false, "Snooze until the next weekday 09:00")
+	alertsSnoozeCmd.Flags().StringVar(&alertsSnoozeTimezone, "timezone", "", "Time zone of --until-business-hours (default the configured timezone)")
 }
 
 // ─── alerts escalate ─────────────────────────────────────────────────────────
@@ -347,7 +1097,7 @@ var alertsEscalateCmd = &cobra.Command{
 		if alertsEscalateEscalation == "" {
 			return fmt.Errorf("--escalation is required")
 		}
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -356,7 +1106,11 @@ var alertsEscalateCmd = &cobra.Command{
 				"name": alertsEscalateEscalation,
 			},
 		}
-		if err := client.Post("/v2/alerts/"+args[0]+"/escalate", body, nil); err != nil {
+		path, err := alertPath(args[0], "/escalate")
+		if err != nil {
+			return err
+		}
+		if err := client.Post(path, body, nil); err != nil {
 			return err
 		}
 		opts := GetOutputOptions()
@@ -382,7 +1136,7 @@ var alertsAssignCmd = &cobra.Command{
 		if alertsAssignOwner == "" {
 			return fmt.Errorf("--owner is required")
 		}
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -391,7 +1145,11 @@ var alertsAssignCmd = &cobra.Command{
 				"username": alertsAssignOwner,
 			},
 		}
-		if err := client.Post("/v2/alerts/"+args[0]+"/assign", body, nil); err != nil {
+		path, err := alertPath(args[0], "/assign")
+		if err != nil {
+			return err
+		}
+		if err := client.Post(path, body, nil); err != nil {
 			return err
 		}
 		opts := GetOutputOptions()
@@ -417,14 +1175,18 @@ var alertsAddNoteCmd = &cobra.Command{
 		if alertsAddNoteNote == "" {
 			return fmt.Errorf("--note is required")
 		}
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
 		body := map[string]interface{}{
 			"note": alertsAddNoteNote,
 		}
-		if err := client.Post("/v2/alerts/"+args[0]+"/notes", body, nil); err != nil {
+		path, err := alertPath(args[0], "/notes")
+		if err != nil {
+			return err
+		}
+		if err := client.Post(path, body, nil); err != nil {
 			return err
 		}
 		opts := GetOutputOptions()
@@ -443,26 +1205,32 @@ func init() {
 var alertsAddTagsTags string
 
 var alertsAddTagsCmd = &cobra.Command{
-	Use:   "add-tags <id>",
-	Short: "Add tags to an alert",
-	Args:  cobra.ExactArgs(1),
+	Use:   "add-tags <id>|-",
+	Short: "Add tags to an alert, or every alert identified on stdin",
+	Example: `  # Tag an alert
+  opsgenie-cli alerts add-tags abc123 --tags database,triaged
+
+  # Tag every open alert of a team
+  opsgenie-cli alerts list -q --status open --team platform | opsgenie-cli alerts add-tags - --tags triaged`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if alertsAddTagsTags == "" {
 			return fmt.Errorf("--tags is required")
 		}
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
 		body := map[string]interface{}{
 			"tags": splitAndTrim(alertsAddTagsTags),
 		}
-		if err := client.Post("/v2/alerts/"+args[0]+"/tags", body, nil); err != nil {
-			return err
-		}
-		opts := GetOutputOptions()
-		output.Success("Tags added", opts)
-		return nil
+		return runAlertAction(cmd, client, args[0], "Tags added", "tagged", func(id string) error {
+			path, err := alertPath(id, "/tags")
+			if err != nil {
+				return err
+			}
+			return client.Post(path, body, nil)
+		})
 	},
 }
 
@@ -483,12 +1251,16 @@ var alertsRemoveTagsCmd = &cobra.Command{
 		if alertsRemoveTagsTags == "" {
 			return fmt.Errorf("--tags is required")
 		}
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
 		tagList := splitAndTrim(alertsRemoveTagsTags)
-		path := "/v2/alerts/" + args[0] + "/tags?identifierType=id&tags=" + url.QueryEscape(strings.Join(tagList, ","))
+		path, err := alertPath(args[0], "/tags")
+		if err != nil {
+			return err
+		}
+		path += "&tags=" + url.QueryEscape(strings.Join(tagList, ","))
 		if err := client.Delete(path, nil); err != nil {
 			return err
 		}
@@ -511,7 +1283,7 @@ var alertsCountCmd = &cobra.Command{
 	Use:   "count",
 	Short: "Count alerts matching a query",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -556,6 +1328,17 @@ func splitAndTrim(s string) []string {
 	return result
 }
 
+// alertsCreatedQuery returns an OpsGenie search query matching alerts created
+// in [from, to), optionally narrowed by an extra user-supplied query.
+func alertsCreatedQuery(from, to time.Time, extra string) string {
+	query := "createdAt >= " + strconv.FormatInt(from.UnixMilli(), 10) +
+		" AND createdAt < " + strconv.FormatInt(to.UnixMilli(), 10)
+	if extra != "" {
+		query = "(" + extra + ") AND " + query
+	}
+	return query
+}
+
 // parseResponders parses a comma-separated list of "type:name" pairs into Responder objects.
 // Example: "team:ops,user:alice@example.com"
 func parseResponders(s string) []map[string]string {
@@ -575,6 +1358,24 @@ func parseResponders(s string) []map[string]string {
 	return responders
 }
 
+// mergeResponders appends the extra responders that are not already in
+// responders.
+func mergeResponders(responders, extra []map[string]string) []map[string]string {
+	for _, e := range extra {
+		dup := false
+		for _, r := range responders {
+			if strings.EqualFold(r["type"], e["type"]) && strings.EqualFold(r["name"], e["name"]) {
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			responders = append(responders, e)
+		}
+	}
+	return responders
+}
+
 func init() {
 	rootCmd.AddCommand(alertsCmd)
 }
@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// ─── api ─────────────────────────────────────────────────────────────────────
+
+var (
+	apiFields    []string
+	apiRawFields []string
+	apiInput     string
+	apiPaginate  bool
+)
+
+var apiCmd = &cobra.Command{
+	Use:   "api [method] <path>",
+	Short: "Make an authenticated request to any OpsGenie API endpoint",
+	Long: `Send a request to an OpsGenie API path and print the JSON response, for
+endpoints this CLI has no command for yet. The method defaults to GET.
+
+The request goes through the same client as every other command: the API
+key, region, rate limiting, 429 retries and async (202) polling all apply,
+and the response honours --jq, --fields, --yaml and --template.
+
+--field key=value and --raw-field key=value add parameters. For GET and
+DELETE, and when the body comes from --input, they are query parameters;
+otherwise they make up a JSON object body. --field converts true, false,
+null and numbers to JSON values and reads @file values from a file;
+--raw-field always sends a string. A key ending in [] appends to an array,
+and a dotted key (a.b) nests objects.
+
+--input sends a file ("-" for stdin) as the JSON body as-is.`,
+	Example: `  # Count open alerts
+  opsgenie-cli api GET /v2/alerts/count --field query=status:open
+
+  # Add a tag to an alert
+  opsgenie-cli api POST /v2/alerts/abc123/tags --field 'tags[]=db' --field 'tags[]=prod'
+
+  # Send a prepared body from stdin
+  echo '{"message":"Deploy started"}' | opsgenie-cli api POST /v2/alerts --input -
+
+  # Every page of a listing, reduced with jq
+  opsgenie-cli api /v2/schedules --paginate --jq '.[].name'`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		method, path := http.MethodGet, args[0]
+		if len(args) == 2 {
+			method, path = strings.ToUpper(args[0]), args[1]
+		}
+		if !strings.HasPrefix(path, "/") {
+			path = "/" + path
+		}
+		path, query, _ := strings.Cut(path, "?")
+		params, err := url.ParseQuery(query)
+		if err != nil {
+			return fmt.Errorf("invalid query in path: %w", err)
+		}
+
+		var body interface{}
+		fieldsInQuery := method == http.MethodGet || method == http.MethodDelete || method == http.MethodHead || apiInput != ""
+		if apiInput != "" {
+			raw, err := readFileOrStdin(apiInput)
+			if err != nil {
+				return err
+			}
+			if !json.Valid(raw) {
+				return fmt.Errorf("--input is not valid JSON")
+			}
+			body = json.RawMessage(raw)
+		}
+		fields := map[string]interface{}{}
+		if err := addAPIFields(fields, apiRawFields, false); err != nil {
+			return err
+		}
+		if err := addAPIFields(fields, apiFields, true); err != nil {
+			return err
+		}
+		if fieldsInQuery {
+			for k, v := range fields {
+				if err := addAPIQuery(params, k, v); err != nil {
+					return err
+				}
+			}
+		} else if len(fields) > 0 {
+			body = fields
+		}
+		if apiPaginate && method != http.MethodGet {
+			return fmt.Errorf("--paginate only works with GET")
+		}
+
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		if apiPaginate {
+			var all []json.RawMessage
+			err := client.ListAllPages(path, params, func(page json.RawMessage) error {
+				var items []json.RawMessage
+				if err := json.Unmarshal(page, &items); err != nil {
+					return fmt.Errorf("--paginate: response data is not a list: %w", err)
+				}
+				all = append(all, items...)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			return renderAPIResponse(all, opts)
+		}
+
+		if len(params) > 0 {
+			path += "?" + params.Encode()
+		}
+		var raw json.RawMessage
+		if err := client.Do(method, path, body, &raw); err != nil {
+			return err
+		}
+		if len(raw) == 0 {
+			return nil
+		}
+		return renderAPIResponse(raw, opts)
+	},
+}
+
+// readFileOrStdin reads the named file, or stdin for "-".
+func readFileOrStdin(name string) ([]byte, error) {
+	if name == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(name)
+}
+
+// addAPIFields parses key=value flags into fields. typed converts
+// literals and reads @file values, as for --field.
+func addAPIFields(fields map[string]interface{}, flags []string, typed bool) error {
+	for _, f := range flags {
+		k, v, ok := strings.Cut(f, "=")
+		if !ok || k == "" {
+			return fmt.Errorf("invalid field %q (use key=value)", f)
+		}
+		var val interface{} = v
+		if typed {
+			var err error
+			if val, err = apiFieldValue(v); err != nil {
+				return fmt.Errorf("field %s: %w", k, err)
+			}
+		}
+		if err := setAPIField(fields, k, val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// apiFieldValue converts a --field value: true, false, null and numbers
+// become JSON values, @file the file's content, anything else a string.
+func apiFieldValue(v string) (interface{}, error) {
+	switch v {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	if strings.HasPrefix(v, "@") {
+		b, err := readFileOrStdin(v[1:])
+		if err != nil {
+			return nil, err
+		}
+		return strings.TrimRight(string(b), "\n"), nil
+	}
+	if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return n, nil
+	}
+	if f, err := strconv.ParseFloat(v, 64); err == nil {
+		return f, nil
+	}
+	return v, nil
+}
+
+// setAPIField sets key in fields: "a.b" nests objects and "a[]" appends
+// to an array.
+func setAPIField(fields map[string]interface{}, key string, val interface{}) error {
+	parts := strings.Split(key, ".")
+	m := fields
+	for _, p := range parts[:len(parts)-1] {
+		next, ok := m[p].(map[string]interface{})
+		if !ok {
+			if _, taken := m[p]; taken {
+				return fmt.Errorf("field %s: %s is not an object", key, p)
+			}
+			next = map[string]interface{}{}
+			m[p] = next
+		}
+		m = next
+	}
+	last := parts[len(parts)-1]
+	if name, ok := strings.CutSuffix(last, "[]"); ok {
+		list, _ := m[name].([]interface{})
+		m[name] = append(list, val)
+		return nil
+	}
+	m[last] = val
+	return nil
+}
+
+// addAPIQuery adds a parsed field to the query parameters, repeating the
+// parameter for arrays. Nested objects cannot be expressed in a query.
+func addAPIQuery(params url.Values, key string, val interface{}) error {
+	switch v := val.(type) {
+	case map[string]interface{}:
+		return fmt.Errorf("field %s: nested fields cannot be sent as query parameters", key)
+	case []interface{}:
+		for _, item := range v {
+			if err := addAPIQuery(params, key, item); err != nil {
+				return err
+			}
+		}
+	case nil:
+		params.Add(key, "")
+	default:
+		params.Add(key, fmt.Sprint(v))
+	}
+	return nil
+}
+
+// renderAPIResponse prints a response as JSON, through --jq, --fields and
+// the other output flags. Numbers keep their exact digits.
+func renderAPIResponse(v interface{}, opts output.Options) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var data interface{}
+	if err := dec.Decode(&data); err != nil {
+		return err
+	}
+	return output.RenderJSON(data, opts)
+}
+
+func init() {
+	f := apiCmd.Flags()
+	f.StringArrayVarP(&apiFields, "field", "F", nil, "Typed parameter key=value: true/false/null/numbers as JSON, @file reads a file (repeatable)")
+	f.StringArrayVarP(&apiRawFields, "raw-field", "f", nil, "String parameter key=value (repeatable)")
+	f.StringVar(&apiInput, "input", "", `File with the JSON request body ("-" for stdin)`)
+	f.BoolVar(&apiPaginate, "paginate", false, "Follow pagination and print the data of every page as one list (GET only)")
+	addOutputFlags(apiCmd)
+
+	rootCmd.AddCommand(apiCmd)
+}
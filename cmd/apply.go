@@ -0,0 +1,254 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/roboalchemist/opsgenie-cli/pkg/plan"
+	"github.com/roboalchemist/opsgenie-cli/pkg/snapshot"
+	"github.com/spf13/cobra"
+)
+
+var (
+	applyDir    string
+	applyFile   string
+	applyKinds  []string
+	applyDryRun bool
+)
+
+var applyCmd = &cobra.Command{
+	Use:         "apply",
+	Aliases:     []string{"import"},
+	Short:       "Create or update resources to match local definitions",
+	Annotations: map[string]string{timeoutAnnotation: "2m"},
+	Long: `Create or update OpsGenie resources to match local definitions.
+
+Reads either an export directory (see "export") or a single JSON/YAML file
+mapping each kind to a list of resources. Nested resources in a single file
+name their schedule or team with _parent:
+
+  teams:
+    - name: Platform
+      description: Platform engineering
+  rotations:
+    - _parent: Platform On-Call
+      name: weekly
+      type: weekly
+      startDate: "2024-01-01T09:00:00Z"
+      participants: [{type: user, username: a@example.com}]
+
+Local resources are matched to live ones by ID, then by kind, parent and
+name. Missing resources are created and differing ones updated; live
+resources with no local definition are never deleted. Applying stops at
+the first failed change; a summary of the results follows the list, and
+the command fails if a change did. Use --dry-run to
+print the changes without making them, or --plan/--plan-file for the exact
+API requests, to review and later run with "execute-plan".`,
+	Example: `  # Preview what restoring a backup would change
+  opsgenie-cli apply --dir ./backup --dry-run
+
+  # Save the requests for review; run them later with execute-plan
+  opsgenie-cli apply --dir ./backup --plan-file restore.json
+
+  # Restore only schedules and rotations
+  opsgenie-cli apply --dir ./backup --kinds schedules,rotations
+
+  # Apply a hand-written file
+  opsgenie-cli import -f teams.yaml`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if (applyDir == "") == (applyFile == "") {
+			return fmt.Errorf("exactly one of --dir or --file is required")
+		}
+		source := applyDir
+		if source == "" {
+			source = applyFile
+		}
+
+		local, err := snapshot.Load(source)
+		if err != nil {
+			return err
+		}
+		local, err = snapshot.Filter(local, applyKinds)
+		if err != nil {
+			return err
+		}
+		if len(local) == 0 {
+			return fmt.Errorf("no resource definitions found in %s", source)
+		}
+
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		live, err := collectResources(client, liveKinds(local), "fetch")
+		if err != nil {
+			return err
+		}
+		changes, err := snapshot.PlanChanges(local, live)
+		if err != nil {
+			return err
+		}
+
+		return runApply(cmd, client, changes, applyDryRun, opts)
+	},
+}
+
+// runApply makes the planned changes (unless dryRun), or records them with
+// --plan, and shows what was done.
+func runApply(cmd *cobra.Command, client *api.Client, changes []snapshot.Change, dryRun bool, opts output.Options) error {
+	if rec := planRecorder(cmd); rec != nil {
+		if err := snapshot.Apply(rec, changes); err != nil {
+			return err
+		}
+		return renderPlan(cmd, rec, opts)
+	}
+	var applyErr error
+	cw := &countingWriter{Client: client, phase: "apply"}
+	for _, c := range changes {
+		if c.Action != snapshot.ActionUnchanged {
+			cw.items = append(cw.items, c.Resource.Kind+"/"+c.Resource.Name)
+		}
+	}
+	if !dryRun {
+		applyErr = snapshot.Apply(cw, changes)
+	}
+
+	headers := []string{"Action", "Kind", "Parent", "Name", "Changes"}
+	rows := make([][]string, len(changes))
+	data := make([]map[string]interface{}, len(changes))
+	counts := map[string]int{}
+	for i, c := range changes {
+		r := c.Resource
+		rows[i] = []string{c.Action, r.Kind, r.Parent, r.Name, strings.Join(c.Fields, ", ")}
+		fields := c.Fields
+		if fields == nil {
+			fields = []string{}
+		}
+		data[i] = map[string]interface{}{"action": c.Action, "kind": r.Kind, "parent": r.Parent, "id": r.ID, "name": r.Name, "fields": fields}
+		counts[c.Action]++
+	}
+	if err := output.RenderTable(headers, rows, data, opts); err != nil {
+		return err
+	}
+	if !dryRun {
+		// Apply stops at the first failure: changes after it were not tried.
+		var sum output.Summary
+		written := 0
+		for _, c := range changes {
+			switch {
+			case c.Action == snapshot.ActionUnchanged:
+				sum.Skip()
+				continue
+			case written < cw.done:
+				sum.Succeed()
+			case written == cw.done && applyErr != nil:
+				id := c.Resource.ID
+				if id == "" {
+					id = c.Resource.Kind + "/" + c.Resource.Name
+				}
+				sum.Fail(id)
+			default:
+				sum.Skip()
+			}
+			written++
+		}
+		if err := output.RenderSummary(sum, opts); err != nil {
+			return err
+		}
+		if applyErr != nil {
+			return applyErr
+		}
+	}
+
+	summary := fmt.Sprintf("%d to create, %d to update, %d unchanged (dry run)",
+		counts[snapshot.ActionCreate], counts[snapshot.ActionUpdate], counts[snapshot.ActionUnchanged])
+	if !dryRun {
+		summary = fmt.Sprintf("%d created, %d updated, %d unchanged",
+			counts[snapshot.ActionCreate], counts[snapshot.ActionUpdate], counts[snapshot.ActionUnchanged])
+	}
+	output.Success(summary, opts)
+	return nil
+}
+
+// countingWriter counts the writes that succeeded, to tell how far an
+// apply or plan got, and reports each as --progress of phase. The writes
+// are expected to be one per entry of items, in order.
+type countingWriter struct {
+	plan.Client
+	phase string
+	items []string
+	done  int
+}
+
+func (w *countingWriter) count(err error) error {
+	if err == nil {
+		w.done++
+		item := ""
+		if w.done <= len(w.items) {
+			item = w.items[w.done-1]
+		}
+		output.Progress(w.phase, item, w.done, len(w.items))
+	}
+	return err
+}
+
+func (w *countingWriter) Post(path string, body, result interface{}) error {
+	return w.count(w.Client.Post(path, body, result))
+}
+
+func (w *countingWriter) Put(path string, body, result interface{}) error {
+	return w.count(w.Client.Put(path, body, result))
+}
+
+func (w *countingWriter) Patch(path string, body, result interface{}) error {
+	return w.count(w.Client.Patch(path, body, result))
+}
+
+func (w *countingWriter) Delete(path string, result interface{}) error {
+	return w.count(w.Client.Delete(path, result))
+}
+
+// collectResources is snapshot.Collect reporting each kind fetched as
+// --progress of phase.
+func collectResources(client snapshot.Getter, kinds []string, phase string) ([]snapshot.Resource, error) {
+	return snapshot.CollectProgress(client, kinds, func(kind string, done, total int) {
+		output.Progress(phase, kind, done, total)
+	})
+}
+
+// liveKinds returns the kinds to fetch for planning: those defined locally
+// plus the parent kinds nested resources are resolved against.
+func liveKinds(rs []snapshot.Resource) []string {
+	want := map[string]bool{}
+	for _, r := range rs {
+		want[r.Kind] = true
+		switch r.Kind {
+		case "rotations":
+			want["schedules"] = true
+		case "routing-rules", "policies":
+			want["teams"] = true
+		}
+	}
+	var kinds []string
+	for _, k := range snapshot.Kinds {
+		if want[k] {
+			kinds = append(kinds, k)
+		}
+	}
+	return kinds
+}
+
+func init() {
+	applyCmd.Flags().StringVar(&applyDir, "dir", "", "Export directory to apply")
+	applyCmd.Flags().StringVarP(&applyFile, "file", "f", "", "Single JSON/YAML file to apply")
+	applyCmd.Flags().StringSliceVar(&applyKinds, "kinds", nil, "Comma-separated resource kinds to apply (default all)")
+	applyCmd.Flags().BoolVar(&applyDryRun, "dry-run", false, "Show planned changes without applying them")
+	addPlanFlags(applyCmd)
+	addOutputFlags(applyCmd)
+
+	rootCmd.AddCommand(applyCmd)
+}
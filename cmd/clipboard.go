@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+	"github.com/roboalchemist/opsgenie-cli/pkg/clipboard"
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// addCopyFlag adds --copy to a get or list command. A bare --copy copies
+// IDs; --copy=link copies web UI links instead.
+func addCopyFlag(cmd *cobra.Command) {
+	cmd.Flags().String("copy", "", "Copy the result's ID (or --copy=link for its web link) to the clipboard")
+	cmd.Flags().Lookup("copy").NoOptDefVal = "id"
+}
+
+// copyResult copies the IDs of kind resources, or their web links, to the
+// clipboard when --copy was given. It does nothing otherwise.
+func copyResult(cmd *cobra.Command, client *api.Client, kind string, ids ...string) error {
+	what, _ := cmd.Flags().GetString("copy")
+	if what == "" || len(ids) == 0 {
+		return nil
+	}
+
+	values := ids
+	noun := "ID"
+	switch what {
+	case "id":
+	case "link":
+		noun = "link"
+		values = make([]string, len(ids))
+		for i, id := range ids {
+			link, err := webLink(client, kind, id)
+			if err != nil {
+				return err
+			}
+			values[i] = link
+		}
+	default:
+		return fmt.Errorf("invalid --copy value %q (valid: id, link)", what)
+	}
+
+	if err := clipboard.Write(strings.Join(values, "\n")); err != nil {
+		return fmt.Errorf("copy to clipboard: %w", err)
+	}
+	if len(values) != 1 {
+		noun += "s"
+	}
+	output.Success(fmt.Sprintf("Copied %d %s to clipboard", len(values), noun), getOutputOpts())
+	return nil
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+	"github.com/roboalchemist/opsgenie-cli/pkg/auth"
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// ─── config ──────────────────────────────────────────────────────────────────
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the CLI's configuration",
+	Long: `Inspect the CLI's configuration, read from ~/.opsgenie-cli-auth.json, the
+environment and the global flags.`,
+}
+
+// ─── config regions ──────────────────────────────────────────────────────────
+
+// regionInfo is a row of "config regions".
+type regionInfo struct {
+	Name    string `json:"name"`
+	APIURL  string `json:"apiUrl"`
+	Current bool   `json:"current"`
+}
+
+var configRegionsCmd = &cobra.Command{
+	Use:   "regions",
+	Short: "List the API regions and which one is in use",
+	Long: `List the API hosts commands can talk to: the regions known by name, then
+each api_url set in the config file (at the top level as "config", in a
+profile as "profile:<name>") and OPSGENIE_API_URL when set. The one
+commands would use now is marked current; a URL given as the region is
+listed as "custom".
+
+The host is chosen by OPSGENIE_API_URL, else --region, else the selected
+profile's api_url or region, else the config file's api_url or region,
+else us. --region and the "region" fields take a name below or an API
+URL.`,
+	Example: `  # Which host am I talking to?
+  opsgenie-cli config regions
+
+  # Use the sandbox for one command
+  opsgenie-cli --region sandbox alerts list`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := resolveRegion(); err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+		config, err := auth.Config()
+		if err != nil {
+			return err
+		}
+
+		var regions []regionInfo
+		for _, r := range api.Regions {
+			regions = append(regions, regionInfo{Name: r.Name, APIURL: r.APIURL})
+		}
+		if config.APIURL != "" {
+			regions = append(regions, regionInfo{Name: "config", APIURL: config.APIURL})
+		}
+		names := make([]string, 0, len(config.Profiles))
+		for name := range config.Profiles {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if u := config.Profiles[name].APIURL; u != "" {
+				regions = append(regions, regionInfo{Name: "profile:" + name, APIURL: u})
+			}
+		}
+
+		current, _ := api.RegionURL(flagRegion)
+		if env := os.Getenv("OPSGENIE_API_URL"); env != "" {
+			regions = append(regions, regionInfo{Name: "OPSGENIE_API_URL", APIURL: env})
+			current = env
+		}
+		// The last matching row is the most specific source; a URL given
+		// as a region gets a row of its own.
+		found := false
+		for i := len(regions) - 1; i >= 0 && !found; i-- {
+			if strings.TrimRight(regions[i].APIURL, "/") == strings.TrimRight(current, "/") {
+				regions[i].Current, found = true, true
+			}
+		}
+		if !found {
+			regions = append(regions, regionInfo{Name: "custom", APIURL: current, Current: true})
+		}
+
+		headers := []string{"NAME", "API URL", "CURRENT"}
+		rows := make([][]string, len(regions))
+		for i, r := range regions {
+			mark := ""
+			if r.Current {
+				mark = "*"
+			}
+			rows[i] = []string{r.Name, r.APIURL, mark}
+		}
+		return output.RenderTable(headers, rows, regions, opts)
+	},
+}
+
+func init() {
+	addOutputFlags(configRegionsCmd)
+	configCmd.AddCommand(configRegionsCmd)
+	rootCmd.AddCommand(configCmd)
+}
@@ -60,7 +60,7 @@ var contactsListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List contacts for a user",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -75,7 +75,7 @@ var contactsListCmd = &cobra.Command{
 			return err
 		}
 
-		if opts.Mode == output.ModeJSON {
+		if opts.Structured() {
 			return output.RenderJSON(resp.Data, opts)
 		}
 
@@ -97,7 +97,7 @@ var contactsGetCmd = &cobra.Command{
 	Use:   "get",
 	Short: "Get a contact",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -113,7 +113,7 @@ var contactsGetCmd = &cobra.Command{
 			return err
 		}
 
-		if opts.Mode == output.ModeJSON {
+		if opts.Structured() {
 			return output.RenderJSON(resp.Data, opts)
 		}
 
@@ -132,7 +132,7 @@ var contactsCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a contact for a user",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -161,7 +161,7 @@ var contactsUpdateCmd = &cobra.Command{
 	Use:   "update",
 	Short: "Update a contact",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -190,7 +190,7 @@ var contactsDeleteCmd = &cobra.Command{
 	Use:   "delete",
 	Short: "Delete a contact",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -212,7 +212,7 @@ var contactsEnableCmd = &cobra.Command{
 	Use:   "enable",
 	Short: "Enable a contact",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -234,7 +234,7 @@ var contactsDisableCmd = &cobra.Command{
 	Use:   "disable",
 	Short: "Disable a contact",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// conditionOperations are the operations OpsGenie accepts in policy and rule
+// conditions.
+var conditionOperations = map[string]bool{
+	"matches":                  true,
+	"contains":                 true,
+	"starts-with":              true,
+	"ends-with":                true,
+	"equals":                   true,
+	"contains-key":             true,
+	"contains-value":           true,
+	"greater-than":             true,
+	"less-than":                true,
+	"is-empty":                 true,
+	"equals-ignore-whitespace": true,
+}
+
+// filterTypes maps the user-facing --match values to OpsGenie filter types.
+var filterTypes = map[string]string{
+	"all":            "match-all",
+	"any":            "match-any-condition",
+	"all-conditions": "match-all-conditions",
+}
+
+// parseCondition parses a condition of the form
+//
+//	[not] <field>[:<key>] <operation> [<expected value>]
+//
+// e.g. "message contains disk", "not tags contains test" or
+// "extra-properties:region equals eu-west-1".
+func parseCondition(s string, order int) (map[string]interface{}, error) {
+	parts := strings.Fields(s)
+	cond := map[string]interface{}{"order": order}
+	if len(parts) > 0 && strings.EqualFold(parts[0], "not") {
+		cond["not"] = true
+		parts = parts[1:]
+	}
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("invalid condition %q: expected \"[not] <field> <operation> [value]\"", s)
+	}
+
+	field, key, hasKey := strings.Cut(parts[0], ":")
+	cond["field"] = field
+	if hasKey {
+		cond["key"] = key
+	}
+
+	op := strings.ToLower(parts[1])
+	if !conditionOperations[op] {
+		return nil, fmt.Errorf("invalid condition %q: unknown operation %q", s, parts[1])
+	}
+	cond["operation"] = op
+
+	if len(parts) > 2 {
+		cond["expectedValue"] = strings.Join(parts[2:], " ")
+	} else if op != "is-empty" {
+		return nil, fmt.Errorf("invalid condition %q: operation %q needs a value", s, op)
+	}
+	return cond, nil
+}
+
+// buildFilter builds an OpsGenie filter object from a --match value and a
+// list of --condition strings. With no conditions the filter matches all.
+func buildFilter(match string, conditions []string) (map[string]interface{}, error) {
+	if len(conditions) == 0 {
+		return map[string]interface{}{"type": "match-all"}, nil
+	}
+	filterType, ok := filterTypes[match]
+	if !ok {
+		return nil, fmt.Errorf("--match must be one of all, any, all-conditions, got %q", match)
+	}
+	if filterType == "match-all" {
+		// Conditions only make sense with a conditional filter type.
+		filterType = "match-all-conditions"
+	}
+	conds := make([]map[string]interface{}, 0, len(conditions))
+	for i, c := range conditions {
+		parsed, err := parseCondition(c, i)
+		if err != nil {
+			return nil, err
+		}
+		conds = append(conds, parsed)
+	}
+	return map[string]interface{}{"type": filterType, "conditions": conds}, nil
+}
+
+var weekdays = map[string]string{
+	"mon": "monday", "tue": "tuesday", "wed": "wednesday", "thu": "thursday",
+	"fri": "friday", "sat": "saturday", "sun": "sunday",
+}
+
+// parseClock parses "HH:MM" into hour and minute.
+func parseClock(s string) (int, int, error) {
+	h, m, ok := strings.Cut(s, ":")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid time %q: expected HH:MM", s)
+	}
+	hour, err := strconv.Atoi(h)
+	if err != nil || hour < 0 || hour > 23 {
+		return 0, 0, fmt.Errorf("invalid hour in %q", s)
+	}
+	minute, err := strconv.Atoi(m)
+	if err != nil || minute < 0 || minute > 59 {
+		return 0, 0, fmt.Errorf("invalid minute in %q", s)
+	}
+	return hour, minute, nil
+}
+
+// parseDayClock parses "<weekday> HH:MM", accepting full or three-letter
+// day names.
+func parseDayClock(s string) (string, int, int, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		return "", 0, 0, fmt.Errorf("invalid day/time %q: expected \"<day> HH:MM\"", s)
+	}
+	day := strings.ToLower(fields[0])
+	if full, ok := weekdays[day]; ok {
+		day = full
+	} else if len(day) < 3 || weekdays[day[:3]] != day {
+		return "", 0, 0, fmt.Errorf("invalid weekday %q", fields[0])
+	}
+	hour, minute, err := parseClock(fields[1])
+	return day, hour, minute, err
+}
+
+// parseTimeRestriction parses a --time-restriction value. Two forms are
+// accepted:
+//
+//	09:00-17:00                          every day between the two times
+//	mon 09:00-fri 17:00[,sat 10:00-sat 14:00]
+//	                                     one or more weekday ranges
+func parseTimeRestriction(s string) (map[string]interface{}, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+
+	// time-of-day: a single "HH:MM-HH:MM" range with no day names.
+	if start, end, ok := strings.Cut(s, "-"); ok && !strings.ContainsAny(s, " ,") {
+		sh, sm, err := parseClock(start)
+		if err != nil {
+			return nil, err
+		}
+		eh, em, err := parseClock(end)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{
+			"type": "time-of-day",
+			"restriction": map[string]int{
+				"startHour": sh, "startMin": sm, "endHour": eh, "endMin": em,
+			},
+		}, nil
+	}
+
+	var restrictions []map[string]interface{}
+	for _, r := range splitAndTrim(s) {
+		start, end, ok := strings.Cut(r, "-")
+		if !ok {
+			return nil, fmt.Errorf("invalid time restriction %q: expected \"<day> HH:MM-<day> HH:MM\"", r)
+		}
+		sd, sh, sm, err := parseDayClock(start)
+		if err != nil {
+			return nil, err
+		}
+		ed, eh, em, err := parseDayClock(end)
+		if err != nil {
+			return nil, err
+		}
+		restrictions = append(restrictions, map[string]interface{}{
+			"startDay": sd, "startHour": sh, "startMin": sm,
+			"endDay": ed, "endHour": eh, "endMin": em,
+		})
+	}
+	return map[string]interface{}{
+		"type":         "weekday-and-time-of-day",
+		"restrictions": restrictions,
+	}, nil
+}
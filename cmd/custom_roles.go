@@ -43,7 +43,7 @@ var customRolesListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all custom roles",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -56,7 +56,7 @@ var customRolesListCmd = &cobra.Command{
 			return err
 		}
 
-		if opts.Mode == output.ModeJSON {
+		if opts.Structured() {
 			return output.RenderJSON(resp.Data, opts)
 		}
 
@@ -78,7 +78,7 @@ var customRolesGetCmd = &cobra.Command{
 	Short: "Get a custom role by ID",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -91,7 +91,7 @@ var customRolesGetCmd = &cobra.Command{
 			return err
 		}
 
-		if opts.Mode == output.ModeJSON {
+		if opts.Structured() {
 			return output.RenderJSON(resp.Data, opts)
 		}
 
@@ -109,7 +109,7 @@ var customRolesCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a new custom role",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -142,7 +142,7 @@ var customRolesUpdateCmd = &cobra.Command{
 	Short: "Update a custom role",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -177,7 +177,7 @@ var customRolesDeleteCmd = &cobra.Command{
 	Short: "Delete a custom role",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
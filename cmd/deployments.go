@@ -3,6 +3,8 @@ package cmd
 import (
 	"fmt"
 	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/roboalchemist/opsgenie-cli/pkg/output"
 	"github.com/spf13/cobra"
@@ -15,6 +17,7 @@ func init() {
 	deploymentsCmd.AddCommand(deploymentsCreateCmd)
 	deploymentsCmd.AddCommand(deploymentsUpdateCmd)
 	deploymentsCmd.AddCommand(deploymentsSearchCmd)
+	deploymentsCmd.AddCommand(deploymentsDeleteCmd)
 
 	addOutputFlags(deploymentsListCmd)
 	addOutputFlags(deploymentsGetCmd)
@@ -34,15 +37,15 @@ func init() {
 	deploymentsUpdateCmd.Flags().String("description", "", "Deployment description")
 	deploymentsUpdateCmd.Flags().String("environment", "", "Deployment environment")
 
-	// list flags (list delegates to search endpoint, service is required)
-	deploymentsListCmd.Flags().String("service", "", "Service ID to list deployments for (required)")
-	_ = deploymentsListCmd.MarkFlagRequired("service")
-	deploymentsListCmd.Flags().String("environment", "", "Filter by environment")
-
-	// search flags
-	deploymentsSearchCmd.Flags().String("service", "", "Service ID to search deployments for (required)")
-	_ = deploymentsSearchCmd.MarkFlagRequired("service")
-	deploymentsSearchCmd.Flags().String("environment", "", "Filter by environment")
+	// list and search flags (both use the search endpoint, service is required)
+	for _, c := range []*cobra.Command{deploymentsListCmd, deploymentsSearchCmd} {
+		c.Flags().String("service", "", "Service ID to list deployments for (required)")
+		_ = c.MarkFlagRequired("service")
+		c.Flags().String("environment", "", "Filter by environment")
+		c.Flags().String("status", "", "Filter by deployment status (e.g. successful, failed)")
+		c.Flags().Int("limit", 0, "Maximum number of deployments to return (0 = all)")
+		c.Flags().Int("offset", 0, "Start offset for pagination")
+	}
 }
 
 var deploymentsCmd = &cobra.Command{
@@ -54,47 +57,67 @@ var deploymentsCmd = &cobra.Command{
 var deploymentsListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List deployments for a service",
-	Long:  "List deployments for a service using the OpsGenie search endpoint. --service is required.",
-	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
-		if err != nil {
-			return err
-		}
-		opts := getOutputOpts()
-
-		params := url.Values{}
-		service, _ := cmd.Flags().GetString("service")
-		params.Set("serviceIds", service)
-		if environment, _ := cmd.Flags().GetString("environment"); environment != "" {
-			params.Set("environment", environment)
-		}
-
-		path := "/v2/deployments/search?" + params.Encode()
+	Long: `List deployments for a service using the OpsGenie search endpoint. --service is required.
 
-		var resp struct {
-			Data []map[string]interface{} `json:"data"`
-		}
-		if err := client.Get(path, &resp); err != nil {
-			return err
-		}
-
-		if opts.Mode == output.ModeJSON {
-			return output.RenderJSON(resp.Data, opts)
-		}
+All pages are fetched; --limit caps the deployments shown after --status
+has filtered them.`,
+	Example: `  # The last failed deployments of a service
+  opsgenie-cli deployments list --service <service-id> --status failed --limit 5`,
+	RunE: runDeploymentSearch,
+}
 
-		headers := []string{"ID", "NAME", "ENVIRONMENT", "STATUS", "CREATED_AT"}
-		rows := make([][]string, 0, len(resp.Data))
-		for _, d := range resp.Data {
-			rows = append(rows, []string{
-				stringVal(d, "id"),
-				stringVal(d, "name"),
-				stringVal(d, "environment"),
-				stringVal(d, "status"),
-				stringVal(d, "createdAt"),
-			})
-		}
-		return output.RenderTable(headers, rows, resp.Data, opts)
-	},
+// runDeploymentSearch lists a service's deployments for list and search.
+// The search endpoint has no status filter, so --status is applied here.
+func runDeploymentSearch(cmd *cobra.Command, args []string) error {
+	client, err := newClient(cmd.Context())
+	if err != nil {
+		return err
+	}
+	opts := getOutputOpts()
+
+	params := url.Values{}
+	service, _ := cmd.Flags().GetString("service")
+	params.Set("serviceIds", service)
+	if environment, _ := cmd.Flags().GetString("environment"); environment != "" {
+		params.Set("environment", environment)
+	}
+	if offset, _ := cmd.Flags().GetInt("offset"); offset > 0 {
+		params.Set("offset", strconv.Itoa(offset))
+	}
+
+	var deployments []map[string]interface{}
+	if err := client.ListAll("/v2/deployments/search", params, &deployments); err != nil {
+		return err
+	}
+	if status, _ := cmd.Flags().GetString("status"); status != "" {
+		matched := make([]map[string]interface{}, 0, len(deployments))
+		for _, d := range deployments {
+			if strings.EqualFold(stringVal(d, "status"), status) {
+				matched = append(matched, d)
+			}
+		}
+		deployments = matched
+	}
+	if limit, _ := cmd.Flags().GetInt("limit"); limit > 0 && len(deployments) > limit {
+		deployments = deployments[:limit]
+	}
+
+	if opts.Structured() {
+		return output.RenderJSON(deployments, opts)
+	}
+
+	headers := []string{"ID", "NAME", "ENVIRONMENT", "STATUS", "CREATED_AT"}
+	rows := make([][]string, 0, len(deployments))
+	for _, d := range deployments {
+		rows = append(rows, []string{
+			stringVal(d, "id"),
+			stringVal(d, "name"),
+			stringVal(d, "environment"),
+			stringVal(d, "status"),
+			stringVal(d, "createdAt"),
+		})
+	}
+	return output.RenderTable(headers, rows, deployments, opts)
 }
 
 var deploymentsGetCmd = &cobra.Command{
@@ -102,7 +125,7 @@ var deploymentsGetCmd = &cobra.Command{
 	Short: "Get a deployment by ID",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -115,7 +138,7 @@ var deploymentsGetCmd = &cobra.Command{
 			return err
 		}
 
-		if opts.Mode == output.ModeJSON {
+		if opts.Structured() {
 			return output.RenderJSON(resp.Data, opts)
 		}
 
@@ -136,7 +159,7 @@ var deploymentsCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a deployment",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -171,7 +194,7 @@ var deploymentsUpdateCmd = &cobra.Command{
 	Short: "Update a deployment",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -204,44 +227,26 @@ var deploymentsUpdateCmd = &cobra.Command{
 var deploymentsSearchCmd = &cobra.Command{
 	Use:   "search",
 	Short: "Search deployments",
+	Long:  "Search a service's deployments. The same as list.",
+	RunE:  runDeploymentSearch,
+}
+
+var deploymentsDeleteCmd = &cobra.Command{
+	Use:   "delete <id>",
+	Short: "Delete a deployment",
+	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
-		opts := getOutputOpts()
-
-		params := url.Values{}
-		service, _ := cmd.Flags().GetString("service")
-		params.Set("serviceIds", service)
-		if environment, _ := cmd.Flags().GetString("environment"); environment != "" {
-			params.Set("environment", environment)
-		}
+		opts := GetOutputOptions()
 
-		path := "/v2/deployments/search?" + params.Encode()
-
-		var resp struct {
-			Data []map[string]interface{} `json:"data"`
-		}
-		if err := client.Get(path, &resp); err != nil {
+		if err := client.Delete("/v2/deployments/"+args[0], nil); err != nil {
 			return err
 		}
 
-		if opts.Mode == output.ModeJSON {
-			return output.RenderJSON(resp.Data, opts)
-		}
-
-		headers := []string{"ID", "NAME", "ENVIRONMENT", "STATUS", "CREATED_AT"}
-		rows := make([][]string, 0, len(resp.Data))
-		for _, d := range resp.Data {
-			rows = append(rows, []string{
-				stringVal(d, "id"),
-				stringVal(d, "name"),
-				stringVal(d, "environment"),
-				stringVal(d, "status"),
-				stringVal(d, "createdAt"),
-			})
-		}
-		return output.RenderTable(headers, rows, resp.Data, opts)
+		output.Success(fmt.Sprintf("Deployment %q deleted", args[0]), opts)
+		return nil
 	},
 }
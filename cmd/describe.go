@@ -0,0 +1,405 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// ─── describe ────────────────────────────────────────────────────────────────
+
+// refNamer fills in the names that references leave out: usernames of users
+// (each looked up at most once) and names of teams, schedules and
+// escalations (from the client's listings). A reference that cannot be
+// looked up keeps its ID.
+type refNamer struct {
+	client *api.Client
+	users  map[string]api.UserResponse
+}
+
+func newRefNamer(client *api.Client) *refNamer {
+	return &refNamer{client: client, users: map[string]api.UserResponse{}}
+}
+
+// user returns the user with the given ID, with only the ID set when the
+// lookup failed.
+func (n *refNamer) user(id string) api.UserResponse {
+	if u, ok := n.users[id]; ok {
+		return u
+	}
+	u, ok := lookupUsers(n.client, []string{id})[id]
+	if !ok {
+		u = api.UserResponse{ID: id}
+	}
+	n.users[id] = u
+	return u
+}
+
+func (n *refNamer) name(kind, id string) string {
+	name, err := n.client.ResolveName(kind, id)
+	if err != nil || name == "" {
+		return id
+	}
+	return name
+}
+
+func (n *refNamer) team(t *api.TeamRef) {
+	if t != nil && t.Name == "" && t.ID != "" {
+		t.Name = n.name("team", t.ID)
+	}
+}
+
+func (n *refNamer) responder(r *api.Responder) {
+	if r.ID == "" {
+		return
+	}
+	switch r.Type {
+	case "user":
+		if r.Username == "" {
+			if r.Username = n.user(r.ID).Username; r.Username == "" {
+				r.Username = r.ID
+			}
+		}
+	case "team", "schedule", "escalation":
+		if r.Name == "" {
+			r.Name = n.name(r.Type, r.ID)
+		}
+	}
+}
+
+// responderLabel describes a responder for people, e.g. "schedule Primary".
+func responderLabel(r api.Responder) string {
+	name := r.Name
+	if r.Type == "user" && r.Username != "" {
+		name = r.Username
+	}
+	if name == "" {
+		name = r.ID
+	}
+	if name == "" {
+		return r.Type
+	}
+	return r.Type + " " + name
+}
+
+// describeStructured reports whether describe output should be the JSON
+// document rather than the summary.
+func describeStructured(opts output.Options) bool {
+	return opts.Structured() || opts.Template != "" || opts.JQExpr != "" || opts.Mode == output.ModeID
+}
+
+// writeFields writes label/value lines in the style of "alerts show",
+// skipping empty values.
+func writeFields(b *strings.Builder, fields [][2]string) {
+	for _, f := range fields {
+		if f[1] != "" {
+			fmt.Fprintf(b, "%-13s %s\n", f[0]+":", f[1])
+		}
+	}
+}
+
+// ─── schedules describe ──────────────────────────────────────────────────────
+
+// scheduleDescription is a schedule with its rotations, names filled in.
+type scheduleDescription struct {
+	api.ScheduleResponse
+	Rotations []api.ScheduleRotationResponse `json:"rotations"`
+}
+
+var schedulesDescribeCmd = &cobra.Command{
+	Use:   "describe <id>",
+	Short: "Show a schedule with its rotations and participants by name",
+	Long: `Show a schedule, its owner team and its rotations in one view, with the
+participants of each rotation named rather than given by ID.
+
+With --json (or --jq, --template) the whole schedule is printed, rotations
+included, with the names filled in.`,
+	Example: `  # Who takes part in the primary schedule
+  opsgenie-cli schedules describe "Primary"
+
+  # The same as JSON
+  opsgenie-cli schedules describe "Primary" --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		id, err := client.ResolveID("schedule", args[0])
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		var resp struct {
+			Data api.ScheduleResponse `json:"data"`
+		}
+		if err := client.Get("/v2/schedules/"+id, &resp); err != nil {
+			return err
+		}
+		var rotations struct {
+			Data []api.ScheduleRotationResponse `json:"data"`
+		}
+		if err := client.Get("/v2/schedules/"+resp.Data.ID+"/rotations", &rotations); err != nil {
+			return fmt.Errorf("rotations: %w", err)
+		}
+		s := scheduleDescription{ScheduleResponse: resp.Data, Rotations: rotations.Data}
+		if s.Rotations == nil {
+			s.Rotations = []api.ScheduleRotationResponse{}
+		}
+
+		n := newRefNamer(client)
+		n.team(s.OwnerTeam)
+		for i := range s.Rotations {
+			for j := range s.Rotations[i].Participants {
+				n.responder(&s.Rotations[i].Participants[j])
+			}
+		}
+
+		if describeStructured(opts) {
+			return output.RenderJSON(s, opts)
+		}
+		fmt.Print(scheduleDocument(s))
+		return nil
+	},
+}
+
+func scheduleDocument(s scheduleDescription) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Schedule %s (%s)\n\n", s.Name, s.ID)
+	team := ""
+	if s.OwnerTeam != nil {
+		team = refName(*s.OwnerTeam)
+	}
+	writeFields(&b, [][2]string{
+		{"Team", team},
+		{"Timezone", s.Timezone},
+		{"Enabled", fmt.Sprint(s.Enabled)},
+	})
+	if s.Description != "" {
+		fmt.Fprintf(&b, "\n%s\n", s.Description)
+	}
+
+	b.WriteString("\nRotations\n")
+	if len(s.Rotations) == 0 {
+		b.WriteString("  (none)\n")
+	}
+	for _, r := range s.Rotations {
+		span := "from " + r.StartDate
+		if r.EndDate != "" {
+			span += " to " + r.EndDate
+		}
+		fmt.Fprintf(&b, "  %s (%s, every %d, %s)\n", r.Name, r.Type, max(r.Length, 1), span)
+		for _, p := range r.Participants {
+			fmt.Fprintf(&b, "    %s\n", responderLabel(p))
+		}
+	}
+	return b.String()
+}
+
+// ─── escalations describe ────────────────────────────────────────────────────
+
+var escalationsDescribeCmd = &cobra.Command{
+	Use:   "describe <id>",
+	Short: "Show an escalation with its rules and recipients by name",
+	Long: `Show an escalation, its owner team and its rules in order, with the
+recipient of each rule named rather than given by ID.
+
+With --json (or --jq, --template) the whole escalation is printed with the
+names filled in.`,
+	Example: `  # What happens when nobody acknowledges
+  opsgenie-cli escalations describe "Platform Escalation"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		id, err := client.ResolveID("escalation", args[0])
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		var resp struct {
+			Data api.EscalationResponse `json:"data"`
+		}
+		if err := client.Get("/v2/escalations/"+id, &resp); err != nil {
+			return err
+		}
+		e := resp.Data
+		n := newRefNamer(client)
+		n.team(e.OwnerTeam)
+		for i := range e.Rules {
+			n.responder(&e.Rules[i].Recipient)
+		}
+
+		if describeStructured(opts) {
+			return output.RenderJSON(e, opts)
+		}
+		fmt.Print(escalationDocument(e))
+		return nil
+	},
+}
+
+func escalationDocument(e api.EscalationResponse) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Escalation %s (%s)\n\n", e.Name, e.ID)
+	team := ""
+	if e.OwnerTeam != nil {
+		team = refName(*e.OwnerTeam)
+	}
+	writeFields(&b, [][2]string{{"Team", team}})
+	if e.Description != "" {
+		fmt.Fprintf(&b, "\n%s\n", e.Description)
+	}
+
+	b.WriteString("\nRules\n")
+	if len(e.Rules) == 0 {
+		b.WriteString("  (none)\n")
+	}
+	for i, r := range e.Rules {
+		line := fmt.Sprintf("  %d. after %d %s, %s: notify %s", i+1, r.Delay.TimeAmount, r.Delay.TimeUnit, strings.ReplaceAll(r.Condition, "-", " "), responderLabel(r.Recipient))
+		if r.NotifyType != "" && r.NotifyType != "default" {
+			line += " (" + r.NotifyType + ")"
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
+
+// ─── teams describe ──────────────────────────────────────────────────────────
+
+// teamDescription is a team with its members named and the schedules and
+// escalations it owns.
+type teamDescription struct {
+	api.TeamResponse
+	Members     []teamMemberDetail       `json:"members"`
+	Schedules   []api.ScheduleResponse   `json:"schedules"`
+	Escalations []api.EscalationResponse `json:"escalations"`
+}
+
+var teamsDescribeCmd = &cobra.Command{
+	Use:   "describe <id>",
+	Short: "Show a team with its members, schedules and escalations by name",
+	Long: `Show a team in one view: its members by username and full name, and the
+schedules and escalations it owns, with escalation recipients named rather
+than given by ID.
+
+With --json (or --jq, --template) the whole team is printed with the names
+filled in.`,
+	Example: `  # Everything a team owns
+  opsgenie-cli teams describe platform`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		id, err := client.ResolveID("team", args[0])
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		var resp struct {
+			Data api.TeamResponse `json:"data"`
+		}
+		if err := client.Get("/v2/teams/"+id, &resp); err != nil {
+			return err
+		}
+		t := teamDescription{
+			TeamResponse: resp.Data,
+			Members:      make([]teamMemberDetail, len(resp.Data.Members)),
+		}
+		n := newRefNamer(client)
+		for i, m := range resp.Data.Members {
+			u := n.user(m.User.ID)
+			if m.User.Username == "" {
+				m.User.Username = u.Username
+			}
+			t.Members[i] = teamMemberDetail{TeamMember: m, FullName: u.FullName}
+		}
+		if t.Schedules, err = teamSchedules(client, t.ID, t.Name); err != nil {
+			return err
+		}
+		if t.Escalations, err = teamEscalations(client, t.ID, t.Name); err != nil {
+			return err
+		}
+		for _, e := range t.Escalations {
+			for i := range e.Rules {
+				n.responder(&e.Rules[i].Recipient)
+			}
+		}
+
+		if describeStructured(opts) {
+			return output.RenderJSON(t, opts)
+		}
+		fmt.Print(teamDocument(t))
+		return nil
+	},
+}
+
+func teamDocument(t teamDescription) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Team %s (%s)\n", t.Name, t.ID)
+	if t.Description != "" {
+		fmt.Fprintf(&b, "\n%s\n", t.Description)
+	}
+
+	b.WriteString("\nMembers\n")
+	if len(t.Members) == 0 {
+		b.WriteString("  (none)\n")
+	}
+	for _, m := range t.Members {
+		name := m.User.Username
+		if name == "" {
+			name = m.User.ID
+		}
+		if m.FullName != "" {
+			name += " (" + m.FullName + ")"
+		}
+		role := m.Role
+		if role == "" {
+			role = "user"
+		}
+		fmt.Fprintf(&b, "  %s, %s\n", name, role)
+	}
+
+	b.WriteString("\nSchedules\n")
+	if len(t.Schedules) == 0 {
+		b.WriteString("  (none)\n")
+	}
+	for _, s := range t.Schedules {
+		fmt.Fprintf(&b, "  %s\n", s.Name)
+	}
+
+	b.WriteString("\nEscalations\n")
+	if len(t.Escalations) == 0 {
+		b.WriteString("  (none)\n")
+	}
+	for _, e := range t.Escalations {
+		recipients := make([]string, len(e.Rules))
+		for i, r := range e.Rules {
+			recipients[i] = responderLabel(r.Recipient)
+		}
+		fmt.Fprintf(&b, "  %s", e.Name)
+		if len(recipients) > 0 {
+			fmt.Fprintf(&b, ": %s", strings.Join(recipients, ", then "))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func init() {
+	for _, c := range []*cobra.Command{schedulesDescribeCmd, escalationsDescribeCmd, teamsDescribeCmd} {
+		addOutputFlags(c)
+	}
+	schedulesCmd.AddCommand(schedulesDescribeCmd)
+	escalationsCmd.AddCommand(escalationsDescribeCmd)
+	teamsCmd.AddCommand(teamsDescribeCmd)
+}
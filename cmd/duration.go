@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// durationFlag is a flag value for a positive length of time. It accepts
+// Go durations (90m, 2h30m) plus whole days and weeks (1d, 1d12h, 2w).
+type durationFlag time.Duration
+
+func (d *durationFlag) Set(s string) error {
+	v, err := parseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = durationFlag(v)
+	return nil
+}
+
+// String returns "" when unset so help output shows no default.
+func (d *durationFlag) String() string {
+	if *d == 0 {
+		return ""
+	}
+	return formatDuration(time.Duration(*d))
+}
+
+func (d *durationFlag) Type() string { return "duration" }
+
+// longUnits are the units parseDuration accepts beyond time.ParseDuration.
+// They must lead the value: "1d12h" works, "12h1d" does not.
+var longUnits = map[byte]time.Duration{'w': 7 * 24 * time.Hour, 'd': 24 * time.Hour}
+
+// parseDuration parses a positive duration such as 90m, 2h30m or 1d.
+func parseDuration(s string) (time.Duration, error) {
+	rest := strings.TrimSpace(s)
+	var total time.Duration
+	for {
+		i := 0
+		for i < len(rest) && rest[i] >= '0' && rest[i] <= '9' {
+			i++
+		}
+		unit, ok := time.Duration(0), false
+		if i > 0 && i < len(rest) {
+			unit, ok = longUnits[rest[i]]
+		}
+		if !ok {
+			break
+		}
+		n, _ := strconv.Atoi(rest[:i])
+		total += time.Duration(n) * unit
+		rest = rest[i+1:]
+	}
+	if rest != "" {
+		d, err := time.ParseDuration(rest)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: use a number and unit such as 90m, 2h30m or 1d", s)
+		}
+		total += d
+	}
+	if total <= 0 {
+		return 0, fmt.Errorf("invalid duration %q: must be greater than zero", s)
+	}
+	return total, nil
+}
+
+// formatDuration is the inverse of parseDuration, without zero components:
+// 36h is "1d12h", 90m is "1h30m".
+func formatDuration(d time.Duration) string {
+	var b strings.Builder
+	if days := d / (24 * time.Hour); days > 0 {
+		fmt.Fprintf(&b, "%dd", days)
+		d -= days * 24 * time.Hour
+	}
+	if d > 0 {
+		s := d.String()
+		if strings.HasSuffix(s, "m0s") {
+			s = strings.TrimSuffix(s, "0s")
+		}
+		if strings.HasSuffix(s, "h0m") {
+			s = strings.TrimSuffix(s, "0m")
+		}
+		b.WriteString(s)
+	}
+	return b.String()
+}
+
+// addForFlag adds a --for flag that sets endFlag relative to a start time;
+// see endDate.
+func addForFlag(cmd *cobra.Command, endFlag, usage string) {
+	cmd.Flags().Var(new(durationFlag), "for", usage+" (e.g. 90m, 2h30m, 1d; instead of --"+endFlag+")")
+}
+
+// endDate returns the end time a command should send: --for added to start
+// (an RFC3339 time, or now when empty) when --for is set, otherwise the
+// value of endFlag as read by timeFlag. Setting both is an error.
+func endDate(cmd *cobra.Command, endFlag, start string) (string, error) {
+	if !cmd.Flags().Changed("for") {
+		return timeFlag(cmd, endFlag)
+	}
+	if cmd.Flags().Changed(endFlag) {
+		return "", fmt.Errorf("--for and --%s cannot be combined", endFlag)
+	}
+	from := time.Now().UTC()
+	if start != "" {
+		t, err := time.Parse(time.RFC3339, start)
+		if err != nil {
+			return "", fmt.Errorf("--for needs an RFC3339 start time, got %q", start)
+		}
+		from = t
+	}
+	d := time.Duration(*cmd.Flags().Lookup("for").Value.(*durationFlag))
+	return from.Add(d).Format(time.RFC3339), nil
+}
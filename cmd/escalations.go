@@ -3,6 +3,10 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/roboalchemist/opsgenie-cli/pkg/api"
 	"github.com/roboalchemist/opsgenie-cli/pkg/output"
@@ -18,7 +22,7 @@ var escalationsListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all escalation policies",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -31,7 +35,7 @@ var escalationsListCmd = &cobra.Command{
 			return err
 		}
 
-		if opts.Mode == output.ModeJSON {
+		if opts.Structured() {
 			return output.RenderJSON(resp.Data, opts)
 		}
 
@@ -49,7 +53,11 @@ var escalationsGetCmd = &cobra.Command{
 	Short: "Get an escalation policy by ID or name",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		id, err := client.ResolveID("escalation", args[0])
 		if err != nil {
 			return err
 		}
@@ -58,7 +66,7 @@ var escalationsGetCmd = &cobra.Command{
 		var resp struct {
 			Data api.EscalationResponse `json:"data"`
 		}
-		if err := client.Get("/v2/escalations/"+args[0], &resp); err != nil {
+		if err := client.Get("/v2/escalations/"+id, &resp); err != nil {
 			return err
 		}
 
@@ -70,7 +78,7 @@ var escalationsCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create an escalation policy",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -114,7 +122,11 @@ var escalationsUpdateCmd = &cobra.Command{
 	Short: "Update an escalation policy by ID or name",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		id, err := client.ResolveID("escalation", args[0])
 		if err != nil {
 			return err
 		}
@@ -138,7 +150,7 @@ var escalationsUpdateCmd = &cobra.Command{
 		var resp struct {
 			Data api.EscalationResponse `json:"data"`
 		}
-		if err := client.Put("/v2/escalations/"+args[0], body, &resp); err != nil {
+		if err := client.Put("/v2/escalations/"+id, body, &resp); err != nil {
 			return err
 		}
 
@@ -155,14 +167,18 @@ var escalationsDeleteCmd = &cobra.Command{
 	Short: "Delete an escalation policy by ID or name",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		id, err := client.ResolveID("escalation", args[0])
 		if err != nil {
 			return err
 		}
 		opts := getOutputOpts()
 
 		var result json.RawMessage
-		if err := client.Delete("/v2/escalations/"+args[0], &result); err != nil {
+		if err := client.Delete("/v2/escalations/"+id, &result); err != nil {
 			return err
 		}
 
@@ -171,6 +187,201 @@ var escalationsDeleteCmd = &cobra.Command{
 	},
 }
 
+var (
+	escalationsTestDryRun   bool
+	escalationsTestMessage  string
+	escalationsTestPriority string
+)
+
+var escalationsTestCmd = &cobra.Command{
+	Use:   "test <id>",
+	Short: "Test-fire an escalation policy",
+	Long: `Test-fire an escalation policy.
+
+Shows who each step would notify and when, resolving schedule recipients to
+the people currently on call. Without --dry-run it also creates a test alert
+(tagged escalation-test) with the policy as its responder, so the policy can
+be verified end to end; close the alert once the notifications arrive.`,
+	Example: `  # Explain who would be notified, without creating anything
+  opsgenie-cli escalations test escalation-id --dry-run
+
+  # Fire a real P5 test alert through the policy
+  opsgenie-cli escalations test escalation-id`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !validPriority(escalationsTestPriority) {
+			return output.Invalid(fmt.Errorf("invalid --priority %q (use P1, P2, P3, P4 or P5)", escalationsTestPriority))
+		}
+
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		id, err := client.ResolveID("escalation", args[0])
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		var resp struct {
+			Data api.EscalationResponse `json:"data"`
+		}
+		if err := client.Get("/v2/escalations/"+id, &resp); err != nil {
+			return err
+		}
+		escalation := resp.Data
+		steps, err := escalationPlan(client, escalation)
+		if err != nil {
+			return err
+		}
+
+		if !escalationsTestDryRun {
+			message := escalationsTestMessage
+			if message == "" {
+				message = "[TEST] Escalation policy " + escalation.Name
+			}
+			body := map[string]interface{}{
+				"message":     message,
+				"description": "Test alert created by opsgenie-cli escalations test to verify the escalation policy. It can be closed.",
+				"responders":  []map[string]string{{"type": "escalation", "id": escalation.ID}},
+				"tags":        []string{"escalation-test"},
+				"priority":    escalationsTestPriority,
+			}
+			var result struct {
+				Data      api.RequestResult `json:"data"`
+				RequestID string            `json:"requestId"`
+			}
+			if err := client.Post("/v2/alerts", body, &result); err != nil {
+				return err
+			}
+			if result.Data.AlertID != "" {
+				output.Success(fmt.Sprintf("Test alert %s created; close it with: opsgenie-cli alerts close %s", result.Data.AlertID, result.Data.AlertID), opts)
+			} else if result.RequestID != "" {
+				output.Success(fmt.Sprintf("Test alert requested (request: %s)", result.RequestID), opts)
+			} else {
+				output.Success("Test alert requested", opts)
+			}
+		}
+
+		headers := []string{"Step", "After", "Condition", "Recipient", "Notifies"}
+		rows := make([][]string, len(steps))
+		for i, s := range steps {
+			rows[i] = []string{fmt.Sprint(s.Step), s.After, s.Condition, s.Recipient, strings.Join(s.Notifies, ", ")}
+		}
+		return output.RenderTable(headers, rows, steps, opts)
+	},
+}
+
+// escalationStep is one rule of an escalation policy as escalations test
+// explains it.
+type escalationStep struct {
+	Step      int      `json:"step"`
+	After     string   `json:"after"`
+	Condition string   `json:"condition"`
+	Recipient string   `json:"recipient"`
+	Notifies  []string `json:"notifies"`
+}
+
+// escalationPlan orders the rules of e by delay and resolves who each one
+// would notify now. Schedule recipients are looked up on call.
+func escalationPlan(client *api.Client, e api.EscalationResponse) ([]escalationStep, error) {
+	rules := append([]api.EscalationRule(nil), e.Rules...)
+	sort.SliceStable(rules, func(i, j int) bool {
+		return ruleDelay(rules[i]) < ruleDelay(rules[j])
+	})
+
+	steps := make([]escalationStep, len(rules))
+	for i, r := range rules {
+		after := "immediately"
+		if d := ruleDelay(r); d > 0 {
+			after = formatDuration(d)
+		}
+		condition := r.Condition
+		switch r.Condition {
+		case "if-not-acked":
+			condition = "not acknowledged"
+		case "if-not-closed":
+			condition = "not closed"
+		}
+		name := r.Recipient.Name
+		if r.Recipient.Username != "" {
+			name = r.Recipient.Username
+		}
+		if name == "" {
+			name = r.Recipient.ID
+		}
+		notifies, err := ruleNotifies(client, r, name)
+		if err != nil {
+			return nil, err
+		}
+		steps[i] = escalationStep{
+			Step:      i + 1,
+			After:     after,
+			Condition: condition,
+			Recipient: r.Recipient.Type + ": " + name,
+			Notifies:  notifies,
+		}
+	}
+	return steps, nil
+}
+
+// ruleDelay returns how long after the alert is created a rule fires.
+func ruleDelay(r api.EscalationRule) time.Duration {
+	unit := time.Minute
+	switch r.Delay.TimeUnit {
+	case "hours":
+		unit = time.Hour
+	case "days":
+		unit = 24 * time.Hour
+	}
+	return time.Duration(r.Delay.TimeAmount) * unit
+}
+
+// ruleNotifies describes who a rule reaches. name is the recipient's
+// display name.
+func ruleNotifies(client *api.Client, r api.EscalationRule, name string) ([]string, error) {
+	switch r.Recipient.Type {
+	case "user":
+		return []string{name}, nil
+	case "schedule":
+		path := "/v2/schedules/" + url.PathEscape(name) + "/on-calls"
+		params := url.Values{"flat": {"true"}}
+		if r.Recipient.ID != "" {
+			path = "/v2/schedules/" + r.Recipient.ID + "/on-calls"
+			params.Set("scheduleIdentifierType", "id")
+		}
+		label := "on call now"
+		if r.NotifyType == "next" || r.NotifyType == "previous" {
+			path = strings.TrimSuffix(path, "on-calls") + "next-on-calls"
+			label = "next on call"
+		}
+		var oc api.OnCallResponse
+		if err := client.GetWithParams(path, params, &oc); err != nil {
+			return nil, fmt.Errorf("looking up on-call for schedule %q: %w", name, err)
+		}
+		participants := oc.Participants()
+		if len(participants) == 0 {
+			return []string{"nobody " + label}, nil
+		}
+		out := make([]string, len(participants))
+		for i, p := range participants {
+			out[i] = p.Name + " (" + label + ")"
+		}
+		return out, nil
+	case "team":
+		switch r.NotifyType {
+		case "admins":
+			return []string{"team admins"}, nil
+		case "users", "all":
+			return []string{"all team members"}, nil
+		}
+		return []string{"team routing rules"}, nil
+	case "escalation":
+		return []string{"escalation " + name}, nil
+	}
+	return []string{name}, nil
+}
+
 func init() {
 	escalationsCreateCmd.Flags().String("name", "", "Escalation policy name (required)")
 	escalationsCreateCmd.Flags().String("description", "", "Escalation policy description")
@@ -180,14 +391,20 @@ func init() {
 	escalationsUpdateCmd.Flags().String("description", "", "New description")
 	escalationsUpdateCmd.Flags().String("rules", "", "JSON array of escalation rules")
 
+	escalationsTestCmd.Flags().BoolVar(&escalationsTestDryRun, "dry-run", false, "Explain the notifications without creating a test alert")
+	escalationsTestCmd.Flags().StringVar(&escalationsTestMessage, "message", "", "Test alert message (default \"[TEST] Escalation policy <name>\")")
+	escalationsTestCmd.Flags().StringVar(&escalationsTestPriority, "priority", "P5", "Test alert priority")
+
 	addOutputFlags(escalationsListCmd)
 	addOutputFlags(escalationsGetCmd)
+	addOutputFlags(escalationsTestCmd)
 
 	escalationsCmd.AddCommand(escalationsListCmd)
 	escalationsCmd.AddCommand(escalationsGetCmd)
 	escalationsCmd.AddCommand(escalationsCreateCmd)
 	escalationsCmd.AddCommand(escalationsUpdateCmd)
 	escalationsCmd.AddCommand(escalationsDeleteCmd)
+	escalationsCmd.AddCommand(escalationsTestCmd)
 
 	rootCmd.AddCommand(escalationsCmd)
 }
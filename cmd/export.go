@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/roboalchemist/opsgenie-cli/pkg/snapshot"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportDir     string
+	exportArchive string
+	exportSign    string
+	exportSignKey string
+	exportFormat  string
+	exportKinds   []string
+)
+
+var exportCmd = &cobra.Command{
+	Use:         "export",
+	Short:       "Export OpsGenie configuration to a directory",
+	Annotations: map[string]string{timeoutAnnotation: "2m"},
+	Long: `Export OpsGenie configuration to a directory, one file per resource.
+
+Resources are written as <dir>/<kind>/<name>.<ext>; nested resources
+(rotations, routing rules, team policies) go under <dir>/<kind>/<parent>/.
+Keys and files are written in a stable order and volatile fields such as
+timestamps are dropped, so re-exporting only produces a diff when the
+configuration changed. Files for resources that no longer exist are removed.
+
+With --archive the same files are written to a single gzipped tarball
+instead, together with a SHA256SUMS manifest of every file. Entries are
+sorted and carry fixed timestamps and owners, so an unchanged configuration
+produces a byte-identical archive. The archive's own SHA-256 is written
+next to it as <archive>.sha256, and --sign gpg adds a detached ASCII-armored
+signature as <archive>.asc.
+
+Kinds: teams, schedules, rotations, escalations, integrations, policies,
+services, heartbeats, routing-rules.`,
+	Example: `  # Back up everything as JSON
+  opsgenie-cli export --dir ./backup
+
+  # YAML export of schedules and their rotations only
+  opsgenie-cli export --dir ./backup --format yaml --kinds schedules,rotations
+
+  # Nightly git-backed backup
+  opsgenie-cli export --dir ./opsgenie-config && git -C ./opsgenie-config commit -am "nightly export"
+
+  # Signed snapshot for the compliance archive
+  opsgenie-cli export --archive opsgenie-$(date +%F).tar.gz --sign gpg --sign-key ops@example.com`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if exportFormat != snapshot.FormatJSON && exportFormat != snapshot.FormatYAML {
+			return fmt.Errorf("--format must be json or yaml, got %q", exportFormat)
+		}
+		if (exportDir == "") == (exportArchive == "") {
+			return fmt.Errorf("exactly one of --dir or --archive is required")
+		}
+		if exportSign != "" && exportArchive == "" {
+			return fmt.Errorf("--sign requires --archive")
+		}
+		if exportSign != "" && exportSign != "gpg" {
+			return fmt.Errorf("--sign must be gpg, got %q", exportSign)
+		}
+
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		resources, err := collectResources(client, exportKinds, "export")
+		if err != nil {
+			return err
+		}
+		var paths []string
+		if exportArchive != "" {
+			paths, err = writeExportArchive(exportArchive, resources)
+		} else {
+			paths, err = snapshot.Write(exportDir, resources, exportFormat, exportKinds)
+		}
+		if err != nil {
+			return err
+		}
+
+		headers := []string{"Kind", "Parent", "Name", "Path"}
+		rows := make([][]string, len(resources))
+		data := make([]map[string]string, len(resources))
+		for i, r := range resources {
+			rows[i] = []string{r.Kind, r.Parent, r.Name, paths[i]}
+			data[i] = map[string]string{"kind": r.Kind, "parent": r.Parent, "id": r.ID, "name": r.Name, "path": paths[i]}
+		}
+		if err := output.RenderTable(headers, rows, data, opts); err != nil {
+			return err
+		}
+		if exportArchive == "" {
+			output.Success(fmt.Sprintf("Exported %d resources to %s", len(resources), exportDir), opts)
+			return nil
+		}
+		output.Success(fmt.Sprintf("Exported %d resources to %s (checksum %s.sha256)", len(resources), exportArchive, exportArchive), opts)
+		if exportSign != "" {
+			sig, err := gpgSign(exportArchive, exportSignKey)
+			if err != nil {
+				return err
+			}
+			output.Success("Signed "+exportArchive+" ("+sig+")", opts)
+		}
+		return nil
+	},
+}
+
+// writeExportArchive writes resources to a deterministic tarball at path,
+// via a temporary file so a failed export never leaves a partial archive,
+// and records its SHA-256 in path.sha256.
+func writeExportArchive(path string, resources []snapshot.Resource) ([]string, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".export-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+
+	hash := sha256.New()
+	paths, err := snapshot.WriteArchive(io.MultiWriter(tmp, hash), resources, exportFormat)
+	if err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return nil, err
+	}
+	sum := hex.EncodeToString(hash.Sum(nil)) + "  " + filepath.Base(path) + "\n"
+	return paths, os.WriteFile(path+".sha256", []byte(sum), 0o644)
+}
+
+// gpgSign writes a detached, ASCII-armored signature of file to file.asc
+// and returns its path. key selects the signing key; empty uses gpg's
+// default.
+func gpgSign(file, key string) (string, error) {
+	sig := file + ".asc"
+	args := []string{"--batch", "--yes", "--armor", "--detach-sign", "--output", sig}
+	if key != "" {
+		args = append(args, "--local-user", key)
+	}
+	args = append(args, file)
+	out, err := exec.Command("gpg", args...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("gpg signing failed: %w: %s", err, out)
+	}
+	return sig, nil
+}
+
+func init() {
+	exportCmd.Flags().StringVar(&exportDir, "dir", "", "Directory to write the export to")
+	exportCmd.Flags().StringVar(&exportArchive, "archive", "", "Write a deterministic .tar.gz with a SHA256SUMS manifest instead of a directory")
+	exportCmd.Flags().StringVar(&exportSign, "sign", "", "Sign the archive: gpg")
+	exportCmd.Flags().StringVar(&exportSignKey, "sign-key", "", "Key to sign with (default: gpg's default key)")
+	exportCmd.Flags().StringVar(&exportFormat, "format", "json", "File format: json or yaml")
+	exportCmd.Flags().StringSliceVar(&exportKinds, "kinds", nil, "Comma-separated resource kinds to export (default all)")
+	addOutputFlags(exportCmd)
+
+	rootCmd.AddCommand(exportCmd)
+}
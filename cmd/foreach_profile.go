@@ -0,0 +1,281 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/auth"
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// ─── foreach-profile ─────────────────────────────────────────────────────────
+
+// readCommands are the commands foreach-profile may run, by path below the
+// root: those that only read from the API.
+var readCommands = map[string]bool{
+	"account get": true, "advisor": true, "whoami": true,
+	"alert-policies get": true, "alert-policies list": true,
+	"alerts attachments list": true, "alerts count": true, "alerts get": true,
+	"alerts history": true, "alerts list": true, "alerts request-status": true,
+	"alerts show": true, "alerts top": true, "alerts why": true,
+	"contacts get": true, "contacts list": true,
+	"custom-roles get": true, "custom-roles list": true,
+	"deployments get": true, "deployments list": true, "deployments search": true,
+	"escalations describe": true, "escalations get": true, "escalations list": true,
+	"forwarding-rules get": true, "forwarding-rules list": true,
+	"heartbeats analyze": true, "heartbeats get": true, "heartbeats list": true,
+	"incidents get": true, "incidents list": true, "incidents logs": true,
+	"incidents notes list": true, "incidents timeline": true,
+	"integrations get": true, "integrations keys list": true, "integrations list": true,
+	"lint priority": true, "lint tags": true, "logs list": true,
+	"maintenance get": true, "maintenance list": true,
+	"notification-policies get": true, "notification-policies list": true,
+	"notification-rules get": true, "notification-rules list": true,
+	"notification-rules simulate": true, "notification-rules steps get": true,
+	"notification-rules steps list": true,
+	"on-call for-team":              true, "on-call get": true, "on-call list": true,
+	"on-call next": true, "on-call whoami": true,
+	"policies get": true, "policies list": true, "postmortems get": true,
+	"report api-usage": true, "report digest": true,
+	"schedule-overrides get": true, "schedule-overrides list": true,
+	"schedule-rotations get": true, "schedule-rotations list": true,
+	"schedules describe": true, "schedules get": true, "schedules list": true,
+	"services audiences get": true, "services get": true, "services list": true,
+	"team-routing-rules get": true, "team-routing-rules list": true,
+	"teams describe": true, "teams escalations": true, "teams get": true,
+	"teams list": true, "teams logs": true, "teams schedules": true,
+	"users escalations": true, "users get": true, "users list": true,
+	"users schedules": true,
+}
+
+// sideEffectFlags are flags of read commands that change or send things,
+// which foreach-profile refuses.
+var sideEffectFlags = map[string]bool{
+	"delete-interactively": true, // advisor
+	"notify":               true, // report digest
+	"copy":                 true, // the clipboard, once per profile
+}
+
+// mergedOutputFlags are the global flags that shape foreach-profile's merged
+// output rather than each run; every other global flag given before the
+// "--" is passed on to the runs.
+var mergedOutputFlags = map[string]bool{
+	"json": true, "plaintext": true, "yaml": true, "ndjson": true, "output": true,
+	"no-color": true, "wide": true, "no-trunc": true, "quiet": true, "silent": true,
+}
+
+// profileRun is the outcome of running a command under one profile.
+type profileRun struct {
+	profile string
+	stdout  []byte
+	stderr  []byte
+	err     error
+}
+
+var foreachProfileNames []string
+
+var foreachProfileCmd = &cobra.Command{
+	Use:   "foreach-profile -- <command>...",
+	Short: "Run a read command against every configured profile",
+	Long: `Run a read command against every profile of the config file at once and
+merge the results, with the profile each row came from in a PROFILE column
+(or a "profile" field of JSON output).
+
+Profiles are named accounts under "profiles" in ~/.opsgenie-cli-auth.json:
+
+  {"profiles": {"acme": {"api_key": "..."}, "globex": {"api_key": "...", "region": "eu"}}}
+
+The command runs once per profile, up to --concurrency at a time, as if
+given --profile. Only commands that read (list, get, count, ...) may be
+run, without flags that change or send things (--delete-interactively,
+--notify, --copy). Output flags go before the "--" and apply to the merged
+result; other global flags given there (--region, --timeout, --mock, ...)
+are passed on to every run. A profile that fails is reported on stderr
+and the others are still shown; the exit status is then 1.`,
+	Example: `  # Open P1 alerts across every account
+  opsgenie-cli foreach-profile -- alerts list --query "status:open AND priority:P1"
+
+  # Who is on call everywhere, as JSON
+  opsgenie-cli foreach-profile --json -- oncall list
+
+  # Only two of the accounts
+  opsgenie-cli foreach-profile --profiles acme,globex -- heartbeats list`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		target, _, err := rootCmd.Find(args)
+		if err != nil || target == rootCmd || !target.Runnable() {
+			return output.Invalid(fmt.Errorf("unknown command %q", strings.Join(args, " ")))
+		}
+		if !readCommands[strings.TrimPrefix(target.CommandPath(), rootCmd.Name()+" ")] {
+			return output.Invalid(fmt.Errorf("%q changes things; foreach-profile only runs commands that read", target.CommandPath()))
+		}
+		for _, arg := range args {
+			if arg == "--" {
+				break
+			}
+			name, _, _ := strings.Cut(strings.TrimPrefix(arg, "--"), "=")
+			if strings.HasPrefix(arg, "--") && sideEffectFlags[name] {
+				return output.Invalid(fmt.Errorf("%s changes things; foreach-profile only runs commands that read", arg))
+			}
+		}
+		if cmd.Flags().Changed("profile") {
+			return output.Invalid(fmt.Errorf("--profile does not apply to foreach-profile; use --profiles"))
+		}
+		profiles := foreachProfileNames
+		if len(profiles) == 0 {
+			if profiles, err = auth.ProfileNames(); err != nil {
+				return err
+			}
+		}
+		if len(profiles) == 0 {
+			return fmt.Errorf("no profiles in %s", auth.ConfigPath())
+		}
+		exe, err := os.Executable()
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		// Table-like output merges the TSV of each run; everything else
+		// merges their JSON.
+		merge := mergeProfileJSON
+		format := "json"
+		if opts.Template == "" && opts.JQExpr == "" && !opts.Structured() && opts.Mode != output.ModeID {
+			merge, format = mergeProfileTSV, "tsv"
+		}
+		runs := runProfiles(cmd.Context(), exe, profiles, append(forwardedFlags(cmd), append(args, "-o", format)...))
+
+		failed := 0
+		for _, r := range runs {
+			if r.err != nil {
+				failed++
+				msg := strings.TrimSpace(string(r.stderr))
+				if msg == "" {
+					msg = r.err.Error()
+				}
+				fmt.Fprintf(os.Stderr, "profile %s: %s\n", r.profile, msg)
+			}
+		}
+		if err := merge(runs, opts); err != nil {
+			return err
+		}
+		if failed > 0 {
+			return &exitError{code: 1, err: fmt.Errorf("%d of %d profiles failed", failed, len(runs))}
+		}
+		return nil
+	},
+}
+
+// forwardedFlags returns the global flags given to cmd, such as --region,
+// --timeout or --mock, to pass on to each run.
+func forwardedFlags(cmd *cobra.Command) []string {
+	var flags []string
+	cmd.InheritedFlags().VisitAll(func(f *pflag.Flag) {
+		if f.Changed && !mergedOutputFlags[f.Name] {
+			flags = append(flags, "--"+f.Name+"="+f.Value.String())
+		}
+	})
+	return flags
+}
+
+// runProfiles runs the CLI with args once per profile, up to --concurrency
+// at a time, and returns the runs in the order of profiles.
+func runProfiles(ctx context.Context, exe string, profiles, args []string) []profileRun {
+	runs := make([]profileRun, len(profiles))
+	sem := make(chan struct{}, max(flagConcurrency, 1))
+	var wg sync.WaitGroup
+	for i, name := range profiles {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			var stdout, stderr bytes.Buffer
+			c := exec.CommandContext(ctx, exe, args...)
+			c.Env = append(os.Environ(), "OPSGENIE_PROFILE="+name, "NO_COLOR=1")
+			c.Stdout, c.Stderr = &stdout, &stderr
+			err := c.Run()
+			runs[i] = profileRun{profile: name, stdout: stdout.Bytes(), stderr: stderr.Bytes(), err: err}
+		}(i, name)
+	}
+	wg.Wait()
+	return runs
+}
+
+// mergeProfileTSV renders the TSV output of the successful runs as one
+// table with a leading PROFILE column.
+func mergeProfileTSV(runs []profileRun, opts output.Options) error {
+	var headers []string
+	var rows [][]string
+	for _, r := range runs {
+		if r.err != nil {
+			continue
+		}
+		cr := csv.NewReader(bytes.NewReader(r.stdout))
+		cr.Comma = '\t'
+		cr.FieldsPerRecord = -1
+		cr.LazyQuotes = true
+		records, err := cr.ReadAll()
+		if err != nil {
+			return fmt.Errorf("profile %s: %w", r.profile, err)
+		}
+		if len(records) == 0 {
+			continue
+		}
+		if headers == nil {
+			headers = append([]string{"PROFILE"}, records[0]...)
+		}
+		for _, rec := range records[1:] {
+			rows = append(rows, append([]string{r.profile}, rec...))
+		}
+	}
+	if headers == nil {
+		headers = []string{"PROFILE"}
+	}
+	return output.RenderTable(headers, rows, nil, opts)
+}
+
+// mergeProfileJSON renders the JSON output of the successful runs as one
+// array, adding a "profile" field to each object. A run that printed an
+// array contributes its items; one that printed anything else contributes
+// that value, wrapped in an object when it is not one.
+func mergeProfileJSON(runs []profileRun, opts output.Options) error {
+	merged := []interface{}{}
+	for _, r := range runs {
+		if r.err != nil || len(bytes.TrimSpace(r.stdout)) == 0 {
+			continue
+		}
+		var v interface{}
+		if err := json.Unmarshal(r.stdout, &v); err != nil {
+			return fmt.Errorf("profile %s: parse output: %w", r.profile, err)
+		}
+		items, ok := v.([]interface{})
+		if !ok {
+			items = []interface{}{v}
+		}
+		for _, item := range items {
+			m, ok := item.(map[string]interface{})
+			if !ok {
+				m = map[string]interface{}{"value": item}
+			}
+			m["profile"] = r.profile
+			merged = append(merged, m)
+		}
+	}
+	return output.RenderJSON(merged, opts)
+}
+
+func init() {
+	foreachProfileCmd.Flags().StringSliceVar(&foreachProfileNames, "profiles", nil, "Comma-separated profiles to run against (default all)")
+	addOutputFlags(foreachProfileCmd)
+	rootCmd.AddCommand(foreachProfileCmd)
+}
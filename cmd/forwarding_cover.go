@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/auth"
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// ─── forwarding-rules cover ──────────────────────────────────────────────────
+
+var forwardingRulesCoverCmd = &cobra.Command{
+	Use:   "cover",
+	Short: "Forward your notifications to someone else, starting now",
+	Long: `Forward your notifications to another user for a while, starting now.
+
+This is "forwarding-rules create" for the common case: the rule forwards
+from you, so only the user covering for you and the length of the cover
+are needed. API keys are not tied to a user, so "you" is --from, else the
+OPSGENIE_USER environment variable, else "user" in the config file.`,
+	Example: `  # Bob covers for me for three days
+  opsgenie-cli forwarding-rules cover --to bob@example.com --for 3d
+
+  # Until a fixed time, starting tonight
+  opsgenie-cli forwarding-rules cover --to bob@example.com --start-date "today 18:00" --end-date "mon 9am"`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from, _ := cmd.Flags().GetString("from")
+		if from == "" {
+			var err error
+			if from, err = auth.User(); err != nil {
+				return err
+			}
+		}
+		if from == "" {
+			return fmt.Errorf("--from is required (or set OPSGENIE_USER)")
+		}
+		to, _ := cmd.Flags().GetString("to")
+		if strings.EqualFold(from, to) {
+			return output.Invalid(fmt.Errorf("--to is %s, the user being covered", to))
+		}
+
+		startDate, err := timeFlag(cmd, "start-date")
+		if err != nil {
+			return err
+		}
+		if startDate == "" {
+			startDate = time.Now().UTC().Format(time.RFC3339)
+		}
+		end, err := endDate(cmd, "end-date", startDate)
+		if err != nil {
+			return err
+		}
+		if end == "" {
+			return fmt.Errorf("--for or --end-date is required")
+		}
+
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		body := map[string]interface{}{
+			"fromUser":  map[string]string{"username": from},
+			"toUser":    map[string]string{"username": to},
+			"startDate": startDate,
+			"endDate":   end,
+		}
+		var result map[string]interface{}
+		if err := client.Post("/v2/forwarding-rules", body, &result); err != nil {
+			return err
+		}
+
+		output.Success(fmt.Sprintf("%s is covering for %s until %s", to, from, end), opts)
+		return output.RenderJSON(result, opts)
+	},
+}
+
+func init() {
+	forwardingRulesCmd.AddCommand(forwardingRulesCoverCmd)
+	addOutputFlags(forwardingRulesCoverCmd)
+
+	f := forwardingRulesCoverCmd.Flags()
+	f.String("to", "", "Username to forward to (required)")
+	f.String("from", "", "Username to forward from (default $OPSGENIE_USER, then \"user\" in the config file)")
+	f.String("start-date", "", "Start date ("+timeFormats+"; default now)")
+	f.String("end-date", "", "End date ("+timeFormats+")")
+	addForFlag(forwardingRulesCoverCmd, "end-date", "Cover for this long")
+	_ = forwardingRulesCoverCmd.MarkFlagRequired("to")
+}
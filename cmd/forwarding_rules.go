@@ -23,16 +23,18 @@ func init() {
 	// create flags
 	forwardingRulesCreateCmd.Flags().String("from-user", "", "Username to forward from (required)")
 	forwardingRulesCreateCmd.Flags().String("to-user", "", "Username to forward to (required)")
-	forwardingRulesCreateCmd.Flags().String("start-date", "", "Start date (RFC3339)")
-	forwardingRulesCreateCmd.Flags().String("end-date", "", "End date (RFC3339)")
+	forwardingRulesCreateCmd.Flags().String("start-date", "", "Start date ("+timeFormats+")")
+	forwardingRulesCreateCmd.Flags().String("end-date", "", "End date ("+timeFormats+")")
+	addForFlag(forwardingRulesCreateCmd, "end-date", "Forward for this long from --start-date or now")
 	_ = forwardingRulesCreateCmd.MarkFlagRequired("from-user")
 	_ = forwardingRulesCreateCmd.MarkFlagRequired("to-user")
 
 	// update flags
 	forwardingRulesUpdateCmd.Flags().String("from-user", "", "Username to forward from")
 	forwardingRulesUpdateCmd.Flags().String("to-user", "", "Username to forward to")
-	forwardingRulesUpdateCmd.Flags().String("start-date", "", "Start date (RFC3339)")
-	forwardingRulesUpdateCmd.Flags().String("end-date", "", "End date (RFC3339)")
+	forwardingRulesUpdateCmd.Flags().String("start-date", "", "Start date ("+timeFormats+")")
+	forwardingRulesUpdateCmd.Flags().String("end-date", "", "End date ("+timeFormats+")")
+	addForFlag(forwardingRulesUpdateCmd, "end-date", "Forward for this long from --start-date or now")
 }
 
 var forwardingRulesCmd = &cobra.Command{
@@ -45,7 +47,7 @@ var forwardingRulesListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all forwarding rules",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -58,7 +60,7 @@ var forwardingRulesListCmd = &cobra.Command{
 			return err
 		}
 
-		if opts.Mode == output.ModeJSON {
+		if opts.Structured() {
 			return output.RenderJSON(resp.Data, opts)
 		}
 
@@ -84,7 +86,7 @@ var forwardingRulesGetCmd = &cobra.Command{
 	Short: "Get a forwarding rule by ID",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -97,7 +99,7 @@ var forwardingRulesGetCmd = &cobra.Command{
 			return err
 		}
 
-		if opts.Mode == output.ModeJSON {
+		if opts.Structured() {
 			return output.RenderJSON(resp.Data, opts)
 		}
 
@@ -117,7 +119,7 @@ var forwardingRulesCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a forwarding rule",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -125,14 +127,20 @@ var forwardingRulesCreateCmd = &cobra.Command{
 
 		fromUser, _ := cmd.Flags().GetString("from-user")
 		toUser, _ := cmd.Flags().GetString("to-user")
-		startDate, _ := cmd.Flags().GetString("start-date")
-		endDate, _ := cmd.Flags().GetString("end-date")
+		startDate, err := timeFlag(cmd, "start-date")
+		if err != nil {
+			return err
+		}
+		end, err := endDate(cmd, "end-date", startDate)
+		if err != nil {
+			return err
+		}
 
 		body := map[string]interface{}{
 			"fromUser":  map[string]string{"username": fromUser},
 			"toUser":    map[string]string{"username": toUser},
 			"startDate": startDate,
-			"endDate":   endDate,
+			"endDate":   end,
 		}
 
 		var result map[string]interface{}
@@ -150,7 +158,7 @@ var forwardingRulesUpdateCmd = &cobra.Command{
 	Short: "Update a forwarding rule",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -165,12 +173,18 @@ var forwardingRulesUpdateCmd = &cobra.Command{
 			v, _ := cmd.Flags().GetString("to-user")
 			body["toUser"] = map[string]string{"username": v}
 		}
+		start, err := timeFlag(cmd, "start-date")
+		if err != nil {
+			return err
+		}
 		if cmd.Flags().Changed("start-date") {
-			v, _ := cmd.Flags().GetString("start-date")
-			body["startDate"] = v
+			body["startDate"] = start
 		}
-		if cmd.Flags().Changed("end-date") {
-			v, _ := cmd.Flags().GetString("end-date")
+		if cmd.Flags().Changed("end-date") || cmd.Flags().Changed("for") {
+			v, err := endDate(cmd, "end-date", start)
+			if err != nil {
+				return err
+			}
 			body["endDate"] = v
 		}
 
@@ -189,7 +203,7 @@ var forwardingRulesDeleteCmd = &cobra.Command{
 	Short: "Delete a forwarding rule",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
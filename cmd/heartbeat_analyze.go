@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/roboalchemist/opsgenie-cli/pkg/report"
+	"github.com/spf13/cobra"
+)
+
+// ─── heartbeats analyze ──────────────────────────────────────────────────────
+
+var (
+	heartbeatsAnalyzeWindow   = durationFlag(30 * 24 * time.Hour)
+	heartbeatsAnalyzeCoverage float64
+)
+
+// heartbeatUnits maps heartbeat interval units to durations.
+var heartbeatUnits = map[string]time.Duration{"minutes": time.Minute, "hours": time.Hour, "days": 24 * time.Hour}
+
+var heartbeatsAnalyzeCmd = &cobra.Command{
+	Use:   "analyze <name>",
+	Short: "Recommend a heartbeat interval from its expiry history",
+	Long: `Analyse the alerts a heartbeat raised over --window and recommend an
+interval that would have avoided most false alarms.
+
+OpsGenie raises an expiry alert one interval after the last ping and closes
+it on the next, so the gap between pings is the interval plus how long the
+alert was open. Expiries that recovered within one more interval are
+counted as late pings (the job ran, just late); longer ones as outages.
+The recommendation covers --coverage of the late-ping gaps, in the
+heartbeat's interval unit with one unit of headroom, and is printed as the
+"heartbeats update" command to apply it. A longer interval also delays
+detection of real outages by the difference.
+
+Expiry alerts are found by the heartbeat's alert message.`,
+	Example: `  # Is the backup heartbeat too tight?
+  opsgenie-cli heartbeats analyze nightly-backup
+
+  # Cover every late ping of the last quarter
+  opsgenie-cli heartbeats analyze nightly-backup --window 90d --coverage 1`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if heartbeatsAnalyzeCoverage <= 0 || heartbeatsAnalyzeCoverage > 1 {
+			return fmt.Errorf("--coverage must be greater than 0 and at most 1")
+		}
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		var resp struct {
+			Data api.HeartbeatResponse `json:"data"`
+		}
+		if err := client.Get("/v2/heartbeats/"+url.PathEscape(args[0]), &resp); err != nil {
+			return err
+		}
+		hb := resp.Data
+		unit, ok := heartbeatUnits[hb.IntervalUnit]
+		if !ok || hb.Interval <= 0 {
+			return fmt.Errorf("heartbeat %s has an unsupported interval %d %s", hb.Name, hb.Interval, hb.IntervalUnit)
+		}
+		message := hb.AlertMessage
+		if message == "" {
+			message = hb.Name + " is expired"
+		}
+
+		now := time.Now()
+		query := `message: "` + strings.ReplaceAll(message, `"`, `\"`) + `"`
+		params := url.Values{"query": {alertsCreatedQuery(now.Add(-time.Duration(heartbeatsAnalyzeWindow)), now, query)}}
+		var found []api.AlertResponse
+		if err := client.ListAll("/v2/alerts", params, &found); err != nil {
+			return fmt.Errorf("alerts: %w", err)
+		}
+		// The search matches words; keep only alerts with the exact message.
+		alerts := found[:0]
+		for _, a := range found {
+			if strings.EqualFold(a.Message, message) {
+				alerts = append(alerts, a)
+			}
+		}
+
+		analysis := report.AnalyzeHeartbeat(alerts, time.Duration(hb.Interval)*unit, unit, heartbeatsAnalyzeCoverage)
+		suggested := int(analysis.Recommended / unit)
+		command := ""
+		if analysis.Recommended != analysis.Interval {
+			command = fmt.Sprintf("opsgenie-cli heartbeats update %s --interval %d --interval-unit %s", hb.Name, suggested, hb.IntervalUnit)
+		}
+
+		if opts.Structured() || opts.Template != "" || opts.JQExpr != "" {
+			return output.RenderJSON(map[string]interface{}{
+				"heartbeat":           hb.Name,
+				"window":              formatDuration(time.Duration(heartbeatsAnalyzeWindow)),
+				"analysis":            analysis,
+				"recommendedInterval": suggested,
+				"intervalUnit":        hb.IntervalUnit,
+				"command":             command,
+			}, opts)
+		}
+
+		fmt.Printf("Heartbeat %s: every %d %s\n", hb.Name, hb.Interval, hb.IntervalUnit)
+		fmt.Printf("Last %s: %d expiries: %d late ping(s), %d outage(s), %d still open\n",
+			formatDuration(time.Duration(heartbeatsAnalyzeWindow)), analysis.Expiries, analysis.LatePings, analysis.Outages, analysis.Open)
+		if analysis.LatePings > 0 {
+			fmt.Printf("Longest gap between pings among late pings: %s\n", formatDuration(analysis.LongestGap))
+		}
+		if command == "" {
+			fmt.Println("\nThe current interval is fine; no change recommended.")
+			return nil
+		}
+		fmt.Printf("\nRecommended interval: %d %s (covers %.0f%% of late pings; outages detected %s later)\n",
+			suggested, hb.IntervalUnit, heartbeatsAnalyzeCoverage*100, formatDuration(analysis.Recommended-analysis.Interval))
+		fmt.Printf("  %s\n", command)
+		return nil
+	},
+}
+
+func init() {
+	heartbeatsAnalyzeCmd.Flags().Var(&heartbeatsAnalyzeWindow, "window", "How far back to look (e.g. 30d, 12w)")
+	heartbeatsAnalyzeCmd.Flags().Float64Var(&heartbeatsAnalyzeCoverage, "coverage", 0.9, "Fraction of late pings the recommended interval should cover")
+	addOutputFlags(heartbeatsAnalyzeCmd)
+
+	heartbeatsCmd.AddCommand(heartbeatsAnalyzeCmd)
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/snapshot"
+	"github.com/spf13/cobra"
+)
+
+// ─── heartbeats apply ────────────────────────────────────────────────────────
+
+var (
+	heartbeatsApplyFile   string
+	heartbeatsApplyDryRun bool
+)
+
+var heartbeatsApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Create or update heartbeats from a manifest file",
+	Long: `Create or update heartbeats to match a manifest, so service heartbeats
+can be managed as code. The manifest is a JSON or YAML list of heartbeats
+(or a "heartbeats:" key holding one), with the fields of the heartbeats API:
+
+  - name: payments-cron
+    description: Nightly payment reconciliation
+    interval: 1
+    intervalUnit: days
+    ownerTeam: payments
+    alertPriority: P2
+
+Heartbeats are matched by name. Missing ones are created (intervalUnit
+defaults to minutes and enabled to true) and ones whose listed fields differ
+are updated; fields not in the manifest, and heartbeats not in it, are left
+alone, so applying twice changes nothing. ownerTeam may be a team name.`,
+	Example: `  # Preview, then apply
+  opsgenie-cli heartbeats apply -f heartbeats.yaml --dry-run
+  opsgenie-cli heartbeats apply -f heartbeats.yaml`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if heartbeatsApplyFile == "" {
+			return fmt.Errorf("--file is required")
+		}
+		local, err := snapshot.LoadKind(heartbeatsApplyFile, "heartbeats")
+		if err != nil {
+			return err
+		}
+		if len(local) == 0 {
+			return fmt.Errorf("no heartbeat definitions found in %s", heartbeatsApplyFile)
+		}
+
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		live, err := collectResources(client, []string{"heartbeats"}, "fetch")
+		if err != nil {
+			return err
+		}
+		normalizeHeartbeats(local, live)
+		changes, err := snapshot.PlanChanges(local, live)
+		if err != nil {
+			return err
+		}
+		for _, c := range changes {
+			if c.Action != snapshot.ActionCreate {
+				continue
+			}
+			data := c.Resource.Data
+			if _, ok := data["interval"]; !ok {
+				return fmt.Errorf("heartbeat %q: interval is required to create it", c.Resource.Name)
+			}
+			if _, ok := data["intervalUnit"]; !ok {
+				data["intervalUnit"] = "minutes"
+			}
+			if _, ok := data["enabled"]; !ok {
+				data["enabled"] = true
+			}
+		}
+
+		return runApply(cmd, client, changes, heartbeatsApplyDryRun, opts)
+	},
+}
+
+// normalizeHeartbeats turns a team name given as ownerTeam into the
+// {"name": ...} object the API takes, and uses the live heartbeat's
+// ownerTeam when it names the same team, so it does not show as changed.
+func normalizeHeartbeats(local, live []snapshot.Resource) {
+	liveTeams := map[string]map[string]interface{}{}
+	for _, r := range live {
+		if t, ok := r.Data["ownerTeam"].(map[string]interface{}); ok {
+			liveTeams[r.Name] = t
+		}
+	}
+	for _, r := range local {
+		want, ok := r.Data["ownerTeam"].(map[string]interface{})
+		if name, isName := r.Data["ownerTeam"].(string); isName {
+			want, ok = map[string]interface{}{"name": name}, true
+		}
+		if !ok {
+			continue
+		}
+		r.Data["ownerTeam"] = want
+		have := liveTeams[r.Name]
+		if have == nil {
+			continue
+		}
+		if id := stringVal(want, "id"); id != "" && id == stringVal(have, "id") {
+			r.Data["ownerTeam"] = have
+		} else if name := stringVal(want, "name"); name != "" && strings.EqualFold(name, stringVal(have, "name")) {
+			r.Data["ownerTeam"] = have
+		}
+	}
+}
+
+func init() {
+	heartbeatsApplyCmd.Flags().StringVarP(&heartbeatsApplyFile, "file", "f", "", "Manifest file (JSON or YAML) (required)")
+	heartbeatsApplyCmd.Flags().BoolVar(&heartbeatsApplyDryRun, "dry-run", false, "Show planned changes without applying them")
+	addPlanFlags(heartbeatsApplyCmd)
+	addOutputFlags(heartbeatsApplyCmd)
+
+	heartbeatsCmd.AddCommand(heartbeatsApplyCmd)
+}
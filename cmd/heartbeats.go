@@ -1,8 +1,15 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
 	"strconv"
+	"sync"
+	"time"
 
 	"github.com/roboalchemist/opsgenie-cli/pkg/api"
 	"github.com/roboalchemist/opsgenie-cli/pkg/output"
@@ -33,6 +40,14 @@ func init() {
 	heartbeatsCreateCmd.Flags().Bool("enabled", true, "Whether heartbeat is enabled")
 	_ = heartbeatsCreateCmd.MarkFlagRequired("name")
 
+	// ping flags
+	heartbeatsPingCmd.Flags().IntVar(&heartbeatsPingRetries, "retries", 2, "Retries after a network error, timeout or server error")
+	heartbeatsPingCmd.Flags().Var(&heartbeatsPingTimeout, "timeout", "Time limit for each attempt (e.g. 5s, 1m)")
+	heartbeatsPingCmd.Flags().BoolVar(&heartbeatsPingFailSilently, "fail-silently", false, "Exit 0, printing nothing, if the ping fails")
+	heartbeatsPingCmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return &exitError{code: pingExitPermanent, err: err}
+	})
+
 	// update flags
 	heartbeatsUpdateCmd.Flags().String("description", "", "Heartbeat description")
 	heartbeatsUpdateCmd.Flags().Int("interval", 0, "Ping interval")
@@ -55,7 +70,7 @@ var heartbeatsListCmd = &cobra.Command{
   # List heartbeats as JSON
   opsgenie-cli heartbeats list --json`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -68,7 +83,7 @@ var heartbeatsListCmd = &cobra.Command{
 			return err
 		}
 
-		if opts.Mode == output.ModeJSON {
+		if opts.Structured() {
 			return output.RenderJSON(resp.Data, opts)
 		}
 
@@ -92,7 +107,7 @@ var heartbeatsGetCmd = &cobra.Command{
 	Short: "Get a heartbeat by name",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -105,7 +120,7 @@ var heartbeatsGetCmd = &cobra.Command{
 			return err
 		}
 
-		if opts.Mode == output.ModeJSON {
+		if opts.Structured() {
 			return output.RenderJSON(resp.Data, opts)
 		}
 
@@ -128,7 +143,7 @@ var heartbeatsCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a new heartbeat",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -163,7 +178,7 @@ var heartbeatsUpdateCmd = &cobra.Command{
 	Short: "Update a heartbeat",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -202,7 +217,7 @@ var heartbeatsDeleteCmd = &cobra.Command{
 	Short: "Delete a heartbeat",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -222,7 +237,7 @@ var heartbeatsEnableCmd = &cobra.Command{
 	Short: "Enable a heartbeat",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -242,7 +257,7 @@ var heartbeatsDisableCmd = &cobra.Command{
 	Short: "Disable a heartbeat",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -257,27 +272,182 @@ var heartbeatsDisableCmd = &cobra.Command{
 	},
 }
 
+// Exit statuses of heartbeats ping, besides exitAuth for a missing or
+// rejected API key.
+const (
+	pingExitPermanent = exitGeneric // retrying will not help: unknown heartbeat, bad flags
+	pingExitTransient = 75          // EX_TEMPFAIL: OpsGenie unreachable, timed out or failing after all retries
+)
+
+var (
+	heartbeatsPingRetries      int
+	heartbeatsPingTimeout      = durationFlag(10 * time.Second)
+	heartbeatsPingFailSilently bool
+)
+
 var heartbeatsPingCmd = &cobra.Command{
-	Use:   "ping <name>",
-	Short: "Ping a heartbeat",
+	Use:   "ping <name>...",
+	Short: "Ping one or more heartbeats",
+	Long: `Ping heartbeats, e.g. at the end of a cron job. Several names are pinged
+concurrently, so one cron entry can keep several monitors alive, and each
+is reported as pinged or failed.
+
+Each attempt is limited by --timeout (not the 30s used by other commands),
+and network errors, timeouts, rate limiting (408, 429) and server errors
+are retried --retries times with backoff, or after the wait the API's
+Retry-After asks for if longer, so a flaky network cannot hang a cron slot
+for long.
+
+Exit status (stable, for scripts):
+  0   All pinged, or any failure with --fail-silently
+  1   A ping failed in a way retrying will not fix: unknown heartbeat,
+      bad flags
+  3   Authentication failed: no API key, or the key was rejected
+  75  Otherwise a ping failed because OpsGenie was unreachable, timed out,
+      rate limited or failing after all retries (EX_TEMPFAIL)`,
 	Example: `  # Ping a heartbeat from a cron job
   opsgenie-cli heartbeats ping my-service-heartbeat
 
   # Ping silently (no output on success)
-  opsgenie-cli heartbeats ping my-service-heartbeat --quiet`,
-	Args:  cobra.ExactArgs(1),
+  opsgenie-cli heartbeats ping my-service-heartbeat --quiet
+
+  # Keep several monitors alive from one cron entry
+  opsgenie-cli heartbeats ping db-backup log-rotate cert-check
+
+  # Never fail the job because of the ping
+  backup.sh && opsgenie-cli heartbeats ping nightly-backup --retries 5 --fail-silently`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.MinimumNArgs(1)(cmd, args); err != nil {
+			return &exitError{code: pingExitPermanent, err: err}
+		}
+		return nil
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
-		if err != nil {
-			return err
+		err := pingHeartbeats(cmd, args)
+		if err != nil && heartbeatsPingFailSilently {
+			slog.Info("ping failed", "err", err)
+			return nil
 		}
-		opts := GetOutputOptions()
+		return err
+	},
+}
 
-		if err := client.Get("/v2/heartbeats/"+args[0]+"/ping", nil); err != nil {
+// pingResult is the outcome of pinging one heartbeat.
+type pingResult struct {
+	Name  string `json:"name"`
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// pingHeartbeats pings each of names concurrently. A single name fails
+// with its own error; with several, each failure is reported on stderr
+// (or in the results under --json) and the error names how many failed,
+// with the permanent exit status if any failure was permanent.
+func pingHeartbeats(cmd *cobra.Command, names []string) error {
+	if heartbeatsPingRetries < 0 {
+		return fmt.Errorf("--retries must not be negative")
+	}
+	client, err := newClient(cmd.Context())
+	if err != nil {
+		return &exitError{code: pingPermanentCode(err), err: err}
+	}
+	client.SetTimeout(time.Duration(heartbeatsPingTimeout))
+	opts := GetOutputOptions()
+
+	errs := make([]error, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			errs[i] = pingHeartbeat(cmd.Context(), client, name)
+		}(i, name)
+	}
+	wg.Wait()
+
+	if len(names) == 1 {
+		if errs[0] == nil {
+			output.Success(fmt.Sprintf("Heartbeat %q pinged", names[0]), opts)
+		}
+		return errs[0]
+	}
+
+	results := make([]pingResult, len(names))
+	failed, code := 0, pingExitTransient
+	for i, name := range names {
+		results[i] = pingResult{Name: name, OK: errs[i] == nil}
+		if errs[i] == nil {
+			if !opts.Structured() {
+				output.Success(fmt.Sprintf("Heartbeat %q pinged", name), opts)
+			}
+			continue
+		}
+		failed++
+		results[i].Error = errs[i].Error()
+		var ee *exitError
+		if errors.As(errs[i], &ee) && ee.code != pingExitTransient && code != exitAuth {
+			code = ee.code
+		}
+		if !opts.Structured() && !heartbeatsPingFailSilently {
+			fmt.Fprintf(os.Stderr, "Heartbeat %q failed: %v\n", name, errs[i])
+		}
+	}
+	if opts.Structured() && !(failed > 0 && heartbeatsPingFailSilently) {
+		if err := output.RenderJSON(results, opts); err != nil {
 			return err
 		}
+	}
+	if failed > 0 {
+		return &exitError{code: code, err: fmt.Errorf("%d of %d heartbeats failed to ping", failed, len(names))}
+	}
+	return nil
+}
 
-		output.Success(fmt.Sprintf("Heartbeat %q pinged", args[0]), opts)
-		return nil
-	},
+// pingRetryable reports whether a ping that failed with the HTTP status
+// (0 for no response) may succeed if retried.
+func pingRetryable(status int) bool {
+	switch {
+	case status == 0, status >= http.StatusInternalServerError:
+		return true
+	case status == http.StatusRequestTimeout, status == http.StatusTooManyRequests:
+		return true
+	}
+	return false
+}
+
+// pingPermanentCode returns the exit status for a ping failure retrying
+// will not fix: exitAuth for a missing or rejected API key, as for any
+// command, else pingExitPermanent.
+func pingPermanentCode(err error) int {
+	if ExitCode(err) == exitAuth {
+		return exitAuth
+	}
+	return pingExitPermanent
+}
+
+// pingHeartbeat pings name, retrying transient failures.
+func pingHeartbeat(ctx context.Context, client *api.Client, name string) error {
+	var err error
+	backoff := time.Second
+	for attempt := 0; ; attempt++ {
+		err = client.GetCtx(ctx, "/v2/heartbeats/"+name+"/ping", nil)
+		if err == nil {
+			return nil
+		}
+		if !pingRetryable(api.StatusCode(err)) {
+			return &exitError{code: pingPermanentCode(err), err: err}
+		}
+		if attempt == heartbeatsPingRetries {
+			break
+		}
+		wait := max(backoff, api.RetryAfter(err))
+		slog.Info("ping failed, retrying", "heartbeat", name, "attempt", attempt+1, "backoff", wait, "err", err)
+		select {
+		case <-ctx.Done():
+			return &exitError{code: pingExitTransient, err: ctx.Err()}
+		case <-time.After(wait):
+		}
+		backoff = min(backoff*2, 30*time.Second)
+	}
+	return &exitError{code: pingExitTransient, err: fmt.Errorf("%w (after %d attempt(s))", err, heartbeatsPingRetries+1)}
 }
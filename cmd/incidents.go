@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -40,7 +41,7 @@ var incidentsListCmd = &cobra.Command{
   # List with field filtering
   opsgenie-cli incidents list --json --fields id,message,status,priority`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -80,13 +81,21 @@ var incidentsListCmd = &cobra.Command{
 				inc.CreatedAt,
 			}
 		}
-		return output.RenderTable(headers, rows, incidents, opts)
+		if err := output.RenderTable(headers, rows, incidents, opts); err != nil {
+			return err
+		}
+		ids := make([]string, len(incidents))
+		for i, inc := range incidents {
+			ids[i] = inc.ID
+		}
+		return copyResult(cmd, client, "incident", ids...)
 	},
 }
 
 func init() {
 	incidentsCmd.AddCommand(incidentsListCmd)
 	addOutputFlags(incidentsListCmd)
+	addCopyFlag(incidentsListCmd)
 	incidentsListCmd.Flags().IntVar(&incidentsListLimit, "limit", 0, "Maximum number of incidents to return (0 = all)")
 	incidentsListCmd.Flags().IntVar(&incidentsListOffset, "offset", 0, "Start offset for pagination")
 	incidentsListCmd.Flags().StringVar(&incidentsListQuery, "query", "", "Search query (OpsGenie query syntax)")
@@ -101,7 +110,7 @@ var incidentsGetCmd = &cobra.Command{
 	Short: "Get an incident by ID",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -112,6 +121,10 @@ var incidentsGetCmd = &cobra.Command{
 			return err
 		}
 		inc := envelope.Data
+		detail := incidentDetail{
+			IncidentResponse:     inc,
+			ImpactedServiceNames: serviceNames(client, inc.ImpactedServices),
+		}
 
 		headers := []string{"Field", "Value"}
 		rows := [][]string{
@@ -122,17 +135,31 @@ var incidentsGetCmd = &cobra.Command{
 			{"Status", inc.Status},
 			{"Priority", inc.Priority},
 			{"Owner", inc.Owner},
+			{"ImpactedServices", strings.Join(detail.ImpactedServiceNames, ", ")},
+			{"Actions", strings.Join(inc.Actions, ", ")},
 			{"Tags", strings.Join(inc.Tags, ", ")},
+			{"Link", inc.Links.Web},
 			{"CreatedAt", inc.CreatedAt},
 			{"UpdatedAt", inc.UpdatedAt},
 		}
-		return output.RenderTable(headers, rows, inc, opts)
+		if err := output.RenderTable(headers, rows, detail, opts); err != nil {
+			return err
+		}
+		return copyResult(cmd, client, "incident", inc.ID)
 	},
 }
 
+// incidentDetail is an incident with its impacted services' names, as
+// shown by "incidents get".
+type incidentDetail struct {
+	api.IncidentResponse
+	ImpactedServiceNames []string `json:"impactedServiceNames,omitempty"`
+}
+
 func init() {
 	incidentsCmd.AddCommand(incidentsGetCmd)
 	addOutputFlags(incidentsGetCmd)
+	addCopyFlag(incidentsGetCmd)
 }
 
 // ─── incidents create ─────────────────────────────────────────────────────────
@@ -152,7 +179,7 @@ var incidentsCreateCmd = &cobra.Command{
 		if incidentCreateMessage == "" {
 			return fmt.Errorf("--message is required")
 		}
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -205,7 +232,7 @@ var incidentsCloseCmd = &cobra.Command{
 	Short: "Close an incident",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -236,7 +263,7 @@ var incidentsResolveCmd = &cobra.Command{
 	Short: "Resolve an incident",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -267,7 +294,7 @@ var incidentsReopenCmd = &cobra.Command{
 	Short: "Reopen a closed or resolved incident",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -296,7 +323,7 @@ var incidentsDeleteCmd = &cobra.Command{
 	Short: "Delete an incident",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -325,7 +352,7 @@ var incidentsAddNoteCmd = &cobra.Command{
 		if incidentsAddNoteNote == "" {
 			return fmt.Errorf("--note is required")
 		}
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -358,7 +385,7 @@ var incidentsAddTagsCmd = &cobra.Command{
 		if incidentsAddTagsTags == "" {
 			return fmt.Errorf("--tags is required")
 		}
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -379,6 +406,256 @@ func init() {
 	incidentsAddTagsCmd.Flags().StringVar(&incidentsAddTagsTags, "tags", "", "Comma-separated tags to add (required)")
 }
 
+// ─── incidents add-responder ──────────────────────────────────────────────────
+
+var (
+	incidentsAddResponderResponders string
+	incidentsAddResponderNote       string
+)
+
+var incidentsAddResponderCmd = &cobra.Command{
+	Use:   "add-responder <id>",
+	Short: "Add responders to an incident",
+	Example: `  # Pull the database team and a user into an incident
+  opsgenie-cli incidents add-responder <incident-id> --responders team:database,user:alice@example.com`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if incidentsAddResponderResponders == "" {
+			return fmt.Errorf("--responders is required")
+		}
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		body := map[string]interface{}{
+			"responders": parseResponders(incidentsAddResponderResponders),
+		}
+		if incidentsAddResponderNote != "" {
+			body["note"] = incidentsAddResponderNote
+		}
+		if err := client.Post("/v1/incidents/"+args[0]+"/responders", body, nil); err != nil {
+			return err
+		}
+		opts := GetOutputOptions()
+		output.Success("Responders added", opts)
+		return nil
+	},
+}
+
+func init() {
+	incidentsCmd.AddCommand(incidentsAddResponderCmd)
+	incidentsAddResponderCmd.Flags().StringVar(&incidentsAddResponderResponders, "responders", "", "Comma-separated responders (e.g. team:myteam,user:user@example.com) (required)")
+	incidentsAddResponderCmd.Flags().StringVar(&incidentsAddResponderNote, "note", "", "Note to add with the responders")
+}
+
+// ─── incidents associate-alert / detach-alert ─────────────────────────────────
+
+var incidentsAlertIDs string
+
+// newIncidentAlertsCmd builds associate-alert and detach-alert, which differ
+// only in the endpoint they call.
+func newIncidentAlertsCmd(use, short, action, done string) *cobra.Command {
+	return &cobra.Command{
+		Use:   use + " <id>",
+		Short: short,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if incidentsAlertIDs == "" {
+				return fmt.Errorf("--alerts is required")
+			}
+			client, err := newClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+			ids := splitAndTrim(incidentsAlertIDs)
+			body := map[string]interface{}{"alertIds": ids}
+			if err := client.Post("/v1/incidents/"+args[0]+"/"+action, body, nil); err != nil {
+				return err
+			}
+			opts := GetOutputOptions()
+			output.Success(fmt.Sprintf("%d alert(s) %s", len(ids), done), opts)
+			return nil
+		},
+	}
+}
+
+var (
+	incidentsAssociateAlertCmd = newIncidentAlertsCmd("associate-alert", "Associate alerts with an incident", "associate-alerts", "associated")
+	incidentsDetachAlertCmd    = newIncidentAlertsCmd("detach-alert", "Detach alerts from an incident", "detach-alerts", "detached")
+)
+
+func init() {
+	for _, c := range []*cobra.Command{incidentsAssociateAlertCmd, incidentsDetachAlertCmd} {
+		incidentsCmd.AddCommand(c)
+		c.Flags().StringVar(&incidentsAlertIDs, "alerts", "", "Comma-separated alert IDs (required)")
+	}
+}
+
+// ─── incidents update-priority / update-message ───────────────────────────────
+
+var (
+	incidentsUpdatePriority string
+	incidentsUpdateMessage  string
+)
+
+var incidentsUpdatePriorityCmd = &cobra.Command{
+	Use:   "update-priority <id>",
+	Short: "Change an incident's priority",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !validPriority(incidentsUpdatePriority) {
+			return output.Invalid(fmt.Errorf("invalid --priority %q (use P1, P2, P3, P4 or P5)", incidentsUpdatePriority))
+		}
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		body := map[string]interface{}{"priority": strings.ToUpper(incidentsUpdatePriority)}
+		if err := client.Put("/v1/incidents/"+args[0]+"/priority", body, nil); err != nil {
+			return err
+		}
+		opts := GetOutputOptions()
+		output.Success("Priority updated", opts)
+		return nil
+	},
+}
+
+var incidentsUpdateMessageCmd = &cobra.Command{
+	Use:   "update-message <id>",
+	Short: "Change an incident's message",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if incidentsUpdateMessage == "" {
+			return fmt.Errorf("--message is required")
+		}
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		body := map[string]interface{}{"message": incidentsUpdateMessage}
+		if err := client.Put("/v1/incidents/"+args[0]+"/message", body, nil); err != nil {
+			return err
+		}
+		opts := GetOutputOptions()
+		output.Success("Message updated", opts)
+		return nil
+	},
+}
+
+func init() {
+	incidentsCmd.AddCommand(incidentsUpdatePriorityCmd)
+	incidentsUpdatePriorityCmd.Flags().StringVar(&incidentsUpdatePriority, "priority", "", "New priority (P1-P5) (required)")
+	incidentsCmd.AddCommand(incidentsUpdateMessageCmd)
+	incidentsUpdateMessageCmd.Flags().StringVar(&incidentsUpdateMessage, "message", "", "New message (required)")
+}
+
+// ─── incidents notes list / logs ──────────────────────────────────────────────
+
+var incidentsNotesCmd = &cobra.Command{
+	Use:   "notes",
+	Short: "Read incident notes",
+}
+
+var incidentsNotesListCmd = &cobra.Command{
+	Use:   "list <id>",
+	Short: "List the notes on an incident",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		// Incident notes and logs have the same shape as alert ones.
+		notes := []api.AlertNote{}
+		if err := client.ListAll("/v1/incidents/"+args[0]+"/notes", url.Values{"order": {"asc"}}, &notes); err != nil {
+			return err
+		}
+		headers := []string{"Time", "Owner", "Note"}
+		rows := make([][]string, len(notes))
+		for i, n := range notes {
+			rows[i] = []string{n.CreatedAt, n.Owner, n.Note}
+		}
+		return output.RenderTable(headers, rows, notes, opts)
+	},
+}
+
+var incidentsLogsCmd = &cobra.Command{
+	Use:   "logs <id>",
+	Short: "List an incident's activity log",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		logs := []api.AlertLog{}
+		if err := client.ListAll("/v1/incidents/"+args[0]+"/logs", url.Values{"order": {"asc"}}, &logs); err != nil {
+			return err
+		}
+		headers := []string{"Time", "Owner", "Log"}
+		rows := make([][]string, len(logs))
+		for i, l := range logs {
+			rows[i] = []string{l.CreatedAt, l.Owner, l.Log}
+		}
+		return output.RenderTable(headers, rows, logs, opts)
+	},
+}
+
+func init() {
+	incidentsNotesCmd.AddCommand(incidentsNotesListCmd)
+	incidentsCmd.AddCommand(incidentsNotesCmd)
+	addOutputFlags(incidentsNotesListCmd)
+	incidentsCmd.AddCommand(incidentsLogsCmd)
+	addOutputFlags(incidentsLogsCmd)
+}
+
+// ─── incidents timeline ───────────────────────────────────────────────────────
+
+var incidentsTimelineCmd = &cobra.Command{
+	Use:   "timeline <id>",
+	Short: "Show an incident's timeline",
+	Long: `Show the entries of an incident's timeline: status changes, responder
+and alert activity, notes and status page updates, oldest first.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		var resp struct {
+			Data struct {
+				Entries []api.IncidentTimelineEntry `json:"entries"`
+			} `json:"data"`
+		}
+		if err := client.Get("/v2/incident-timelines/"+args[0]+"/entries", &resp); err != nil {
+			return err
+		}
+		entries := resp.Data.Entries
+		if entries == nil {
+			entries = []api.IncidentTimelineEntry{}
+		}
+		sort.SliceStable(entries, func(i, j int) bool { return entries[i].EventTime < entries[j].EventTime })
+
+		headers := []string{"Time", "Type", "Actor", "Description"}
+		rows := make([][]string, len(entries))
+		for i, e := range entries {
+			rows[i] = []string{e.EventTime, e.Type, e.Actor.Name, e.Description.Content}
+		}
+		return output.RenderTable(headers, rows, entries, opts)
+	},
+}
+
+func init() {
+	incidentsCmd.AddCommand(incidentsTimelineCmd)
+	addOutputFlags(incidentsTimelineCmd)
+}
+
 func init() {
 	rootCmd.AddCommand(incidentsCmd)
 }
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// ─── integrations keys ───────────────────────────────────────────────────────
+
+var (
+	integrationKeysMaxAge    = durationFlag(90 * 24 * time.Hour)
+	integrationKeysType      string
+	integrationKeysStaleOnly bool
+)
+
+var integrationKeysCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "Inventory integration API keys",
+}
+
+var integrationKeysListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List API integrations with key age and owner team",
+	Long: `List integrations whose API key is used to send alerts, with when each
+was created and which team owns it. Keys older than --max-age are marked
+"rotate"; integrations OpsGenie reports no creation date for are marked
+"unknown". Use it as the source of truth for a key rotation programme.
+
+OpsGenie rotates a key only by recreating the integration (see "integrations
+regenerate-key"), so the creation date is the age of the key.`,
+	Example: `  # Keys due for rotation under a 90 day policy
+  opsgenie-cli integrations keys list --stale
+
+  # Every integration type, with a 180 day policy, as CSV
+  opsgenie-cli integrations keys list --type "" --max-age 180d --csv`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		var list struct {
+			Data []api.IntegrationResponse `json:"data"`
+		}
+		if err := client.Get("/v2/integrations", &list); err != nil {
+			return err
+		}
+
+		var teams map[string]string
+		now := time.Now()
+		keys := []integrationKey{}
+		for _, summary := range list.Data {
+			if integrationKeysType != "" && !strings.EqualFold(summary.Type, integrationKeysType) {
+				continue
+			}
+			var resp struct {
+				Data api.IntegrationResponse `json:"data"`
+			}
+			if err := client.Get("/v2/integrations/"+url.PathEscape(summary.ID), &resp); err != nil {
+				return fmt.Errorf("integration %s: %w", summary.Name, err)
+			}
+			i := resp.Data
+
+			team := ""
+			if i.OwnerTeam != nil {
+				team = i.OwnerTeam.Name
+			}
+			if team == "" && i.TeamID != "" {
+				if teams == nil {
+					if teams, err = teamNames(client); err != nil {
+						return err
+					}
+				}
+				team = teams[i.TeamID]
+			}
+
+			k := integrationKey{ID: i.ID, Name: i.Name, Type: i.Type, Enabled: i.Enabled, Team: team, CreatedAt: i.CreatedAt, Status: "unknown"}
+			if created, err := time.Parse(time.RFC3339Nano, i.CreatedAt); err == nil {
+				age := now.Sub(created)
+				k.AgeDays = int(age.Hours() / 24)
+				k.Status = "ok"
+				if age > time.Duration(integrationKeysMaxAge) {
+					k.Status = "rotate"
+				}
+			}
+			if integrationKeysStaleOnly && k.Status != "rotate" {
+				continue
+			}
+			keys = append(keys, k)
+		}
+
+		headers := []string{"ID", "NAME", "TYPE", "TEAM", "CREATED", "AGE", "STATUS"}
+		rows := make([][]string, len(keys))
+		stale := 0
+		for n, k := range keys {
+			age := ""
+			if k.Status != "unknown" {
+				age = fmt.Sprintf("%dd", k.AgeDays)
+			}
+			rows[n] = []string{k.ID, k.Name, k.Type, k.Team, k.CreatedAt, age, k.Status}
+			if k.Status == "rotate" {
+				stale++
+			}
+		}
+		if err := output.RenderTable(headers, rows, keys, opts); err != nil {
+			return err
+		}
+		output.Success(fmt.Sprintf("%d of %d key(s) older than %s", stale, len(keys), formatDuration(time.Duration(integrationKeysMaxAge))), opts)
+		return nil
+	},
+}
+
+// integrationKey is one row of the key inventory.
+type integrationKey struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Enabled   bool   `json:"enabled"`
+	Team      string `json:"team,omitempty"`
+	CreatedAt string `json:"createdAt,omitempty"`
+	AgeDays   int    `json:"ageDays,omitempty"`
+	Status    string `json:"status"` // "ok", "rotate" or "unknown"
+}
+
+// teamNames maps team IDs to names.
+func teamNames(client *api.Client) (map[string]string, error) {
+	var resp struct {
+		Data []api.TeamResponse `json:"data"`
+	}
+	if err := client.Get("/v2/teams", &resp); err != nil {
+		return nil, fmt.Errorf("teams: %w", err)
+	}
+	names := make(map[string]string, len(resp.Data))
+	for _, t := range resp.Data {
+		names[t.ID] = t.Name
+	}
+	return names, nil
+}
+
+func init() {
+	integrationKeysListCmd.Flags().Var(&integrationKeysMaxAge, "max-age", "Mark keys older than this for rotation (e.g. 90d, 26w)")
+	integrationKeysListCmd.Flags().StringVar(&integrationKeysType, "type", "API", "Integration type to include (\"\" for all)")
+	integrationKeysListCmd.Flags().BoolVar(&integrationKeysStaleOnly, "stale", false, "Only list keys due for rotation")
+	addOutputFlags(integrationKeysListCmd)
+
+	integrationKeysCmd.AddCommand(integrationKeysListCmd)
+	integrationsCmd.AddCommand(integrationKeysCmd)
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"net/url"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// ─── integrations regenerate-key ─────────────────────────────────────────────
+
+var (
+	integrationRegenerateKeepOld bool
+	integrationRegenerateYes     bool
+)
+
+var integrationsRegenerateKeyCmd = &cobra.Command{
+	Use:   "regenerate-key <id>",
+	Short: "Rotate an integration's API key",
+	Long: `Rotate an integration's API key and print the new one.
+
+The OpsGenie API cannot regenerate a key in place, so the integration is
+recreated: a copy with the same settings and actions gets a new key, then the
+old integration is deleted (after confirmation unless --yes is given). The
+copy has a new ID; senders must switch to the new key.
+
+--keep-old keeps the old integration and its key working for a cutover. The
+copy is then named "<name> (new key)", since names must be unique; delete the
+old integration and rename the copy once senders have moved.`,
+	Example: `  # Rotate a key found by "integrations keys list --stale"
+  opsgenie-cli integrations regenerate-key <integration-id>
+
+  # Keep the old key working while senders move over
+  opsgenie-cli integrations regenerate-key <integration-id> --keep-old`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		oldPath := "/v2/integrations/" + url.PathEscape(args[0])
+		var current, actions struct {
+			Data map[string]interface{} `json:"data"`
+		}
+		if err := client.Get(oldPath, &current); err != nil {
+			return err
+		}
+		if err := client.Get(oldPath+"/actions", &actions); err != nil {
+			return fmt.Errorf("actions: %w", err)
+		}
+		name := stringVal(current.Data, "name")
+
+		if !integrationRegenerateKeepOld && !integrationRegenerateYes {
+			ok, err := output.Confirm(bufio.NewReader(cmd.InOrStdin()), cmd.ErrOrStderr(),
+				fmt.Sprintf("Recreate integration %q with a new key and delete the old one (ID %s)?", name, args[0]))
+			if err != nil {
+				return fmt.Errorf("%w (use --yes to rotate without confirmation)", err)
+			}
+			if !ok {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Integration %q not changed\n", name)
+				return nil
+			}
+		}
+
+		body := integrationCopy(current.Data)
+		body["name"] = name + " (new key)"
+		var created struct {
+			Data struct {
+				ID     string `json:"id"`
+				APIKey string `json:"apiKey"`
+			} `json:"data"`
+		}
+		if err := client.Post("/v2/integrations", body, &created); err != nil {
+			return fmt.Errorf("creating the copy: %w", err)
+		}
+		newPath := "/v2/integrations/" + url.PathEscape(created.Data.ID)
+		if len(actions.Data) > 0 {
+			if err := client.Put(newPath+"/actions", integrationCopy(actions.Data), nil); err != nil {
+				return fmt.Errorf("copying actions to %s: %w (the old integration was not deleted)", created.Data.ID, err)
+			}
+		}
+		if !integrationRegenerateKeepOld {
+			if err := client.Delete(oldPath, nil); err != nil {
+				return fmt.Errorf("deleting the old integration: %w (the copy is %s)", err, created.Data.ID)
+			}
+			body["name"] = name
+			if err := client.Put(newPath, body, nil); err != nil {
+				return fmt.Errorf("renaming %s to %q: %w", created.Data.ID, name, err)
+			}
+		}
+
+		result := map[string]interface{}{
+			"oldId":      args[0],
+			"id":         created.Data.ID,
+			"name":       body["name"],
+			"apiKey":     created.Data.APIKey,
+			"oldDeleted": !integrationRegenerateKeepOld,
+		}
+		headers := []string{"FIELD", "VALUE"}
+		rows := [][]string{
+			{"Old ID", args[0]},
+			{"ID", created.Data.ID},
+			{"Name", fmt.Sprint(body["name"])},
+			{"API Key", created.Data.APIKey},
+		}
+		if err := output.RenderTable(headers, rows, result, opts); err != nil {
+			return err
+		}
+		if integrationRegenerateKeepOld {
+			output.Success(fmt.Sprintf("New key created; the old integration %s still accepts the old key", args[0]), opts)
+			return nil
+		}
+		output.Success(fmt.Sprintf("Key of integration %q rotated; the old key no longer works", name), opts)
+		return nil
+	},
+}
+
+// integrationCopy returns the settable fields of an integration (or its
+// actions) as read from the API, for creating a copy.
+func integrationCopy(data map[string]interface{}) map[string]interface{} {
+	skip := map[string]bool{"id": true, "apiKey": true, "_readOnly": true}
+	if ro, ok := data["_readOnly"].([]interface{}); ok {
+		for _, f := range ro {
+			if s, ok := f.(string); ok && s != "type" && s != "name" {
+				skip[s] = true
+			}
+		}
+	}
+	out := make(map[string]interface{}, len(data))
+	for k, v := range data {
+		if !skip[k] {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+func init() {
+	integrationsRegenerateKeyCmd.Flags().BoolVar(&integrationRegenerateKeepOld, "keep-old", false, "Keep the old integration and its key working")
+	integrationsRegenerateKeyCmd.Flags().BoolVar(&integrationRegenerateYes, "yes", false, "Delete the old integration without confirmation")
+	addOutputFlags(integrationsRegenerateKeyCmd)
+
+	integrationsCmd.AddCommand(integrationsRegenerateKeyCmd)
+}
@@ -24,6 +24,8 @@ func init() {
 	addOutputFlags(integrationsCreateCmd)
 	addOutputFlags(integrationsUpdateCmd)
 
+	integrationsGetCmd.Flags().Bool("show-secrets", false, "Show the integration's API key")
+
 	// create flags
 	integrationsCreateCmd.Flags().String("name", "", "Integration name (required)")
 	integrationsCreateCmd.Flags().String("type", "", "Integration type (required)")
@@ -47,7 +49,7 @@ var integrationsListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all integrations",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -60,7 +62,7 @@ var integrationsListCmd = &cobra.Command{
 			return err
 		}
 
-		if opts.Mode == output.ModeJSON {
+		if opts.Structured() {
 			return output.RenderJSON(resp.Data, opts)
 		}
 
@@ -83,7 +85,7 @@ var integrationsGetCmd = &cobra.Command{
 	Short: "Get an integration by ID",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -95,8 +97,13 @@ var integrationsGetCmd = &cobra.Command{
 		if err := client.Get("/v2/integrations/"+args[0], &resp); err != nil {
 			return err
 		}
+		key := resp.Data.APIKey
+		if showSecrets, _ := cmd.Flags().GetBool("show-secrets"); !showSecrets && key != "" {
+			resp.Data.APIKey = ""
+			key = "(hidden, use --show-secrets)"
+		}
 
-		if opts.Mode == output.ModeJSON {
+		if opts.Structured() {
 			return output.RenderJSON(resp.Data, opts)
 		}
 
@@ -107,6 +114,9 @@ var integrationsGetCmd = &cobra.Command{
 			{"Type", resp.Data.Type},
 			{"Enabled", strconv.FormatBool(resp.Data.Enabled)},
 		}
+		if key != "" {
+			rows = append(rows, []string{"API Key", key})
+		}
 		return output.RenderTable(headers, rows, resp.Data, opts)
 	},
 }
@@ -115,7 +125,7 @@ var integrationsCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a new integration",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -146,7 +156,7 @@ var integrationsUpdateCmd = &cobra.Command{
 	Short: "Update an integration",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -181,7 +191,7 @@ var integrationsDeleteCmd = &cobra.Command{
 	Short: "Delete an integration",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -201,7 +211,7 @@ var integrationsEnableCmd = &cobra.Command{
 	Short: "Enable an integration",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -221,7 +231,7 @@ var integrationsDisableCmd = &cobra.Command{
 	Short: "Disable an integration",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
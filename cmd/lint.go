@@ -0,0 +1,286 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+	"github.com/roboalchemist/opsgenie-cli/pkg/governance"
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/roboalchemist/opsgenie-cli/pkg/snapshot"
+	"github.com/spf13/cobra"
+)
+
+// lintCmd is the parent command for governance checks. Lint commands exit
+// non-zero when violations are found so they can gate CI jobs.
+var lintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Check OpsGenie resources against local governance policies",
+}
+
+// ─── lint tags ───────────────────────────────────────────────────────────────
+
+var (
+	lintTagsPolicy         string
+	lintTagsDays           int
+	lintTagsQuery          string
+	lintTagsNoIntegrations bool
+)
+
+var lintTagsCmd = &cobra.Command{
+	Use:   "tags",
+	Short: "Check recent alerts and integrations against a tag policy",
+	Long: `Check recent alerts and integrations against a tag policy file.
+
+The policy is a YAML file with three optional rules:
+
+  pattern: '^[a-z0-9][a-z0-9_.-]*(:[a-z0-9_.-]+)?$'   # naming regex
+  allowed: ["env:*", "service:*", "customer-facing"]  # allow list (globs)
+  required:                                           # required tags by team
+    "*": ["env:*"]                                    # "*" applies to all
+    platform: ["service:*"]
+
+Integrations are only checked when their configuration carries tags.
+Exits with status 1 when any violation is found.`,
+	Example: `  # Lint the last week of alerts and all integrations
+  opsgenie-cli lint tags --policy tags.yaml
+
+  # CI gate on a single team's alerts from the last day, as JSON
+  opsgenie-cli lint tags --policy tags.yaml --days 1 --query "teams:platform" --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		policy, err := governance.LoadTagPolicy(lintTagsPolicy)
+		if err != nil {
+			return err
+		}
+
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		teamNames, err := teamNamesByID(client)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		params := url.Values{}
+		params.Set("query", alertsCreatedQuery(now.AddDate(0, 0, -lintTagsDays), now, lintTagsQuery))
+		var alerts []api.AlertResponse
+		if err := client.ListAll("/v2/alerts", params, &alerts); err != nil {
+			return err
+		}
+
+		var violations []governance.Violation
+		for _, a := range alerts {
+			violations = append(violations, policy.Check("alert", a.ID, a.Message, a.Tags, alertTeamNames(a, teamNames))...)
+		}
+
+		if !lintTagsNoIntegrations {
+			integrations, err := snapshot.Collect(client, []string{"integrations"})
+			if err != nil {
+				return err
+			}
+			for _, in := range integrations {
+				tags, ok := in.Data["tags"].([]interface{})
+				if !ok {
+					continue
+				}
+				var teams []string
+				if team := nestedStringVal(in.Data, "ownerTeam", "name"); team != "" {
+					teams = append(teams, team)
+				}
+				violations = append(violations, policy.Check("integration", in.ID, in.Name, interfaceStrings(tags), teams)...)
+			}
+		}
+
+		return renderViolations(violations, opts)
+	},
+}
+
+// ─── lint priority ───────────────────────────────────────────────────────────
+
+var (
+	lintPriorityPolicy         string
+	lintPriorityDays           int
+	lintPriorityQuery          string
+	lintPriorityNoIntegrations bool
+	lintPriorityNoPolicies     bool
+)
+
+var lintPriorityCmd = &cobra.Command{
+	Use:   "priority",
+	Short: "Check that highest-priority paging is reserved and answered",
+	Long: `Check that highest-priority alerts stay meaningful.
+
+Flags integrations and alert policies (global and team) that raise alerts to
+the governed priority without being allow-listed, and recent alerts at that
+priority that waited longer than max_unacked to be acknowledged.
+
+  priority: P1                                      # default P1
+  allowed_integrations: ["Datadog Production", "Pingdom*"]
+  allowed_policies: ["Escalate customer outages"]
+  max_unacked: 15m
+
+Exits with status 1 when any violation is found.`,
+	Example: `  # Weekly P1 hygiene check
+  opsgenie-cli lint priority --policy priority.yaml
+
+  # Only check alert response times for the last day
+  opsgenie-cli lint priority --policy priority.yaml --days 1 --no-integrations --no-policies`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		policy, err := governance.LoadPriorityPolicy(lintPriorityPolicy)
+		if err != nil {
+			return err
+		}
+
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		var kinds []string
+		if !lintPriorityNoIntegrations {
+			kinds = append(kinds, "integrations")
+		}
+		if !lintPriorityNoPolicies {
+			kinds = append(kinds, "policies")
+		}
+
+		var violations []governance.Violation
+		if len(kinds) > 0 {
+			resources, err := snapshot.Collect(client, kinds)
+			if err != nil {
+				return err
+			}
+			for _, r := range resources {
+				switch r.Kind {
+				case "integrations":
+					violations = append(violations, policy.CheckIntegration(r.ID, r.Name, r.Data)...)
+				case "policies":
+					violations = append(violations, policy.CheckAlertPolicy(r.ID, r.Name, r.Data)...)
+				}
+			}
+		}
+
+		now := time.Now()
+		query := "priority: " + policy.Priority
+		if lintPriorityQuery != "" {
+			query = "(" + lintPriorityQuery + ") AND " + query
+		}
+		params := url.Values{}
+		params.Set("query", alertsCreatedQuery(now.AddDate(0, 0, -lintPriorityDays), now, query))
+		var alerts []api.AlertResponse
+		if err := client.ListAll("/v2/alerts", params, &alerts); err != nil {
+			return err
+		}
+		for _, a := range alerts {
+			violations = append(violations, policy.CheckAlert(a, now)...)
+		}
+
+		return renderViolations(violations, opts)
+	},
+}
+
+// renderViolations prints governance violations and returns an error when
+// there are any, so the process exits non-zero.
+func renderViolations(violations []governance.Violation, opts output.Options) error {
+	if violations == nil {
+		violations = []governance.Violation{}
+	}
+	headers := []string{"Kind", "ID", "Name", "Rule", "Detail"}
+	rows := make([][]string, len(violations))
+	for i, v := range violations {
+		rows[i] = []string{v.Kind, v.ID, v.Name, v.Rule, v.Detail}
+	}
+	if opts.Structured() {
+		if err := output.RenderJSON(violations, opts); err != nil {
+			return err
+		}
+	} else if len(violations) > 0 {
+		if err := output.RenderTable(headers, rows, violations, opts); err != nil {
+			return err
+		}
+	}
+	if len(violations) > 0 {
+		return fmt.Errorf("%d policy violation(s) found", len(violations))
+	}
+	output.Success("No policy violations found", opts)
+	return nil
+}
+
+// teamNamesByID returns a map of team ID to team name.
+func teamNamesByID(client *api.Client) (map[string]string, error) {
+	var teams []api.TeamResponse
+	if err := client.GetWithParams("/v2/teams", nil, &teams); err != nil {
+		return nil, err
+	}
+	names := make(map[string]string, len(teams))
+	for _, t := range teams {
+		names[t.ID] = t.Name
+	}
+	return names, nil
+}
+
+// alertTeamNames resolves the teams an alert belongs to into team names.
+// IDs without a known name (all of them when names is nil) are returned as-is.
+func alertTeamNames(a api.AlertResponse, names map[string]string) []string {
+	seen := map[string]bool{}
+	var out []string
+	add := func(id string) {
+		if id == "" || seen[id] {
+			return
+		}
+		seen[id] = true
+		if name, ok := names[id]; ok {
+			out = append(out, name)
+		} else {
+			out = append(out, id)
+		}
+	}
+	add(a.OwnerTeamID)
+	for _, t := range a.Teams {
+		add(t.ID)
+	}
+	for _, r := range a.Responders {
+		if r.Type == "team" {
+			add(r.ID)
+		}
+	}
+	return out
+}
+
+// interfaceStrings converts a decoded JSON array into strings.
+func interfaceStrings(values []interface{}) []string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func init() {
+	lintTagsCmd.Flags().StringVar(&lintTagsPolicy, "policy", "", "Path to the tag policy YAML file (required)")
+	lintTagsCmd.Flags().IntVar(&lintTagsDays, "days", 7, "Check alerts created in the last N days")
+	lintTagsCmd.Flags().StringVar(&lintTagsQuery, "query", "", "Restrict alerts with an OpsGenie search query")
+	lintTagsCmd.Flags().BoolVar(&lintTagsNoIntegrations, "no-integrations", false, "Skip checking integrations")
+	_ = lintTagsCmd.MarkFlagRequired("policy")
+	addOutputFlags(lintTagsCmd)
+
+	lintPriorityCmd.Flags().StringVar(&lintPriorityPolicy, "policy", "", "Path to the priority policy YAML file (required)")
+	lintPriorityCmd.Flags().IntVar(&lintPriorityDays, "days", 7, "Check alerts created in the last N days")
+	lintPriorityCmd.Flags().StringVar(&lintPriorityQuery, "query", "", "Restrict alerts with an OpsGenie search query")
+	lintPriorityCmd.Flags().BoolVar(&lintPriorityNoIntegrations, "no-integrations", false, "Skip checking integrations")
+	lintPriorityCmd.Flags().BoolVar(&lintPriorityNoPolicies, "no-policies", false, "Skip checking alert policies")
+	_ = lintPriorityCmd.MarkFlagRequired("policy")
+	addOutputFlags(lintPriorityCmd)
+
+	lintCmd.AddCommand(lintTagsCmd)
+	lintCmd.AddCommand(lintPriorityCmd)
+	rootCmd.AddCommand(lintCmd)
+}
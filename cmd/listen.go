@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/roboalchemist/opsgenie-cli/pkg/webhook"
+	"github.com/spf13/cobra"
+)
+
+// ─── listen ──────────────────────────────────────────────────────────────────
+
+var (
+	listenAddr    string
+	listenPath    string
+	listenExec    string
+	listenHeaders []string
+	listenActions []string
+	listenCount   int
+)
+
+var listenCmd = &cobra.Command{
+	Use:   "listen",
+	Short: "Receive OpsGenie webhook callbacks and print or handle each event",
+	Long: `Run a local HTTP server for the callbacks of an OpsGenie Webhook
+integration, for developing integrations and ad-hoc automation. Point the
+integration's URL at it, through a tunnel if OpsGenie cannot reach the
+machine directly.
+
+Each callback must be a POST to --path with a JSON body holding an action
+and an alert with an alertId; anything else is rejected with an error
+status and logged to stderr. Accepted events are written to stdout as
+NDJSON, one object per line:
+
+  {"receivedAt":"...","action":"Create","alertId":"...","tinyId":"42",
+   "message":"...","integrationName":"Webhook","payload":{...}}
+
+With --exec each event is instead piped to a shell command, one run per
+event and in the order received, with the event JSON on stdin and
+OPSGENIE_EVENT_ACTION and OPSGENIE_ALERT_ID set. A failing handler is
+reported and the next event still runs.
+
+--require-header rejects callbacks without a header set on the
+integration, e.g. a shared secret.`,
+	Example: `  # Watch callbacks while building an integration
+  opsgenie-cli listen --addr 127.0.0.1:9000
+
+  # Only acknowledgements, checked against a secret header
+  opsgenie-cli listen --action Acknowledge --require-header X-Webhook-Secret=s3cret
+
+  # Run a script for every new alert
+  opsgenie-cli listen --action Create --exec './on-new-alert.sh'
+
+  # Pick fields with jq
+  opsgenie-cli listen | jq -r '[.action, .alertId, .message] | @tsv'`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		headers := map[string]string{}
+		for _, h := range listenHeaders {
+			name, value, ok := strings.Cut(h, "=")
+			if !ok || strings.TrimSpace(name) == "" {
+				return fmt.Errorf("invalid --require-header %q (use Name=value)", h)
+			}
+			headers[strings.TrimSpace(name)] = value
+		}
+		if !strings.HasPrefix(listenPath, "/") {
+			listenPath = "/" + listenPath
+		}
+		opts := GetOutputOptions()
+
+		// Events are handled one at a time, in order; once the command is
+		// done, late callbacks are answered without being handled.
+		events := make(chan webhook.Event, 64)
+		stopped := make(chan struct{})
+		handler := &webhook.Handler{
+			Path:    listenPath,
+			Headers: headers,
+			Actions: listenActions,
+			Deliver: func(ev webhook.Event) {
+				select {
+				case events <- ev:
+				case <-stopped:
+				}
+			},
+			Log: cmd.ErrOrStderr(),
+		}
+		ln, err := net.Listen("tcp", listenAddr)
+		if err != nil {
+			return err
+		}
+		srv := &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+		output.Success(fmt.Sprintf("Listening for webhooks on http://%s%s (Ctrl-C to stop)", ln.Addr(), listenPath), opts)
+
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		done := make(chan error, 1)
+		go func() { done <- srv.Serve(ln) }()
+		shutdown := func() error {
+			close(stopped)
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			err := srv.Shutdown(shutdownCtx)
+			if errors.Is(err, context.DeadlineExceeded) {
+				// A connection the sender opened but never used keeps
+				// Shutdown waiting; every event is handled, so drop it.
+				return srv.Close()
+			}
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		}
+
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		for handled := 0; listenCount == 0 || handled < listenCount; handled++ {
+			select {
+			case err := <-done:
+				return err
+			case <-ctx.Done():
+				return shutdown()
+			case ev := <-events:
+				if listenExec == "" {
+					if err := enc.Encode(ev); err != nil {
+						return err
+					}
+					continue
+				}
+				if err := runEventHandler(ctx, listenExec, ev); err != nil {
+					output.Error(fmt.Sprintf("handler for %s %s: %v", ev.Action, ev.AlertID, err), opts)
+				}
+			}
+		}
+		return shutdown()
+	},
+}
+
+// runEventHandler runs command through the shell with ev as JSON on stdin.
+func runEventHandler(ctx context.Context, command string, ev webhook.Event) error {
+	b, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	c := exec.CommandContext(ctx, "sh", "-c", command)
+	if runtime.GOOS == "windows" {
+		c = exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	c.Stdin = bytes.NewReader(append(b, '\n'))
+	c.Stdout, c.Stderr = os.Stdout, os.Stderr
+	c.Env = append(os.Environ(), "OPSGENIE_EVENT_ACTION="+ev.Action, "OPSGENIE_ALERT_ID="+ev.AlertID)
+	return c.Run()
+}
+
+func init() {
+	f := listenCmd.Flags()
+	f.StringVar(&listenAddr, "addr", "127.0.0.1:8118", "Address to listen on (use :8118 to accept callbacks from other hosts)")
+	f.StringVar(&listenPath, "path", "/", "URL path the callbacks are sent to")
+	f.StringVar(&listenExec, "exec", "", "Shell command run for each event, with the event JSON on stdin (instead of printing it)")
+	f.StringArrayVar(&listenHeaders, "require-header", nil, "Header callbacks must carry, as Name=value (repeatable)")
+	f.StringSliceVar(&listenActions, "action", nil, "Only handle these actions, e.g. Create,Acknowledge,Close (repeatable; default all)")
+	f.IntVar(&listenCount, "count", 0, "Exit after handling this many events (0 = run until interrupted)")
+
+	rootCmd.AddCommand(listenCmd)
+}
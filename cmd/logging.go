@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// ─── diagnostic logging ──────────────────────────────────────────────────────
+
+var (
+	flagLogLevel  string
+	flagLogFormat string
+)
+
+// logLevels are the --log-level values.
+var logLevels = map[string]slog.Level{
+	"debug": slog.LevelDebug,
+	"info":  slog.LevelInfo,
+	"warn":  slog.LevelWarn,
+	"error": slog.LevelError,
+}
+
+// setupLogger makes slog's default logger, which cmd and the API client
+// log through, write to w at --log-level in --log-format. --debug is the
+// same as --log-level debug.
+func setupLogger(w io.Writer) error {
+	level, ok := logLevels[strings.ToLower(flagLogLevel)]
+	if !ok {
+		return fmt.Errorf("invalid --log-level %q (use debug, info, warn or error)", flagLogLevel)
+	}
+	if flagDebug {
+		level = slog.LevelDebug
+	}
+	opts := &slog.HandlerOptions{Level: level}
+	var h slog.Handler
+	switch strings.ToLower(flagLogFormat) {
+	case "text":
+		h = slog.NewTextHandler(w, opts)
+	case "json":
+		h = slog.NewJSONHandler(w, opts)
+	default:
+		return fmt.Errorf("invalid --log-format %q (use text or json)", flagLogFormat)
+	}
+	slog.SetDefault(slog.New(h))
+	return nil
+}
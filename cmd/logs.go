@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// ─── logs ────────────────────────────────────────────────────────────────────
+
+var logsCmd = &cobra.Command{
+	Use:   "logs",
+	Short: "List and download account log files",
+	Long: `List and download the account's log files: the audit trail OpsGenie writes
+for every change and notification, in files of a few minutes each. Reading
+them needs an API key with configuration access.`,
+}
+
+// logMarkerLayout is the form of log markers and of log file names without
+// their extension.
+const logMarkerLayout = "2006-01-02-15-04-05"
+
+var logMarkerRe = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}-\d{2}-\d{2}-\d{2}`)
+
+// logMarker turns a --marker value into the marker the API takes: a log
+// file name or marker is used as is, anything else is read as a time.
+func logMarker(s string) (string, error) {
+	if s == "" {
+		return time.Now().UTC().Add(-24 * time.Hour).Format(logMarkerLayout), nil
+	}
+	if logMarkerRe.MatchString(s) {
+		return s, nil
+	}
+	loc, err := timeLocation()
+	if err != nil {
+		return "", err
+	}
+	t, err := parseTime(s, time.Now().In(loc))
+	if err != nil {
+		return "", output.Invalid(fmt.Errorf("invalid --marker: %w", err))
+	}
+	return t.UTC().Format(logMarkerLayout), nil
+}
+
+// ─── logs list ───────────────────────────────────────────────────────────────
+
+var (
+	logsListMarker string
+	logsListLimit  int
+)
+
+var logsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List account log files written after a marker",
+	Long: `List the account log files written after --marker, oldest first. The
+marker is a log file name, a marker printed by an earlier listing, or a
+time (RFC3339, 2026-03-01, ...); it defaults to 24 hours ago. Pages are
+followed until --limit files have been listed.`,
+	Example: `  # Log files from the last day
+  opsgenie-cli logs list
+
+  # Everything since the start of March, as JSON
+  opsgenie-cli logs list --marker 2026-03-01 --limit 1000 --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		marker, err := logMarker(logsListMarker)
+		if err != nil {
+			return err
+		}
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		files := []api.LogFile{}
+		for len(files) < logsListLimit {
+			params := url.Values{"limit": {strconv.Itoa(min(logsListLimit-len(files), 1000))}}
+			var page api.LogFileList
+			if err := client.Get("/v2/logs/list/"+url.PathEscape(marker)+"?"+params.Encode(), &page); err != nil {
+				return err
+			}
+			files = append(files, page.Data...)
+			if page.Marker == "" || page.Marker == marker || len(page.Data) == 0 {
+				break
+			}
+			marker = page.Marker
+		}
+
+		headers := []string{"FILENAME", "DATE", "SIZE"}
+		rows := make([][]string, len(files))
+		for i, f := range files {
+			rows[i] = []string{f.Filename, time.UnixMilli(f.Date).UTC().Format(time.RFC3339), strconv.FormatInt(f.Size, 10)}
+		}
+		return output.RenderTable(headers, rows, files, opts)
+	},
+}
+
+// ─── logs download ───────────────────────────────────────────────────────────
+
+var logsDownloadDir string
+
+var logsDownloadCmd = &cobra.Command{
+	Use:   "download <filename>...",
+	Short: "Download account log files",
+	Long: `Download account log files by name into --dir (default the current
+directory). Each file is fetched from a short-lived link the API hands
+out, so no credentials are sent with the download itself.`,
+	Example: `  # Fetch one file
+  opsgenie-cli logs download 2026-03-01-10-21-59.json --dir audit/
+
+  # Fetch everything listed for the last day
+  opsgenie-cli logs list --json | jq -r '.[].filename' | xargs opsgenie-cli logs download --dir audit/`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts := GetOutputOptions()
+
+		if err := os.MkdirAll(logsDownloadDir, 0o755); err != nil {
+			return err
+		}
+		for _, name := range args {
+			link, err := client.LogDownloadURL(name)
+			if err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+			dest := filepath.Join(logsDownloadDir, filepath.Base(name))
+			f, err := os.Create(dest)
+			if err != nil {
+				return err
+			}
+			err = client.Download(link, f)
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				_ = os.Remove(dest)
+				return fmt.Errorf("%s: %w", name, err)
+			}
+			output.Success(fmt.Sprintf("Saved %s", dest), opts)
+		}
+		return nil
+	},
+}
+
+func init() {
+	logsListCmd.Flags().StringVar(&logsListMarker, "marker", "", "List files after this log file name, marker or time (default 24 hours ago)")
+	logsListCmd.Flags().IntVar(&logsListLimit, "limit", 100, "Maximum number of files to list")
+	addOutputFlags(logsListCmd)
+
+	logsDownloadCmd.Flags().StringVarP(&logsDownloadDir, "dir", "d", ".", "Directory to save the files in")
+
+	logsCmd.AddCommand(logsListCmd)
+	logsCmd.AddCommand(logsDownloadCmd)
+	rootCmd.AddCommand(logsCmd)
+}
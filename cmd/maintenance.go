@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/roboalchemist/opsgenie-cli/pkg/output"
 	"github.com/spf13/cobra"
@@ -24,9 +26,13 @@ func init() {
 	// create/update flags
 	for _, c := range []*cobra.Command{maintenanceCreateCmd, maintenanceUpdateCmd} {
 		c.Flags().String("description", "", "Maintenance description")
-		c.Flags().String("start-date", "", "Start date (RFC3339)")
-		c.Flags().String("end-date", "", "End date (RFC3339)")
+		c.Flags().String("start-date", "", "Start date ("+timeFormats+")")
+		c.Flags().String("end-date", "", "End date ("+timeFormats+")")
+		addForFlag(c, "end-date", "Length of the window from --start-date or now")
 		c.Flags().String("type", "schedule-based", "Maintenance type (schedule-based)")
+		c.Flags().StringArray("entity", nil, "Integration or policy covered by the window, as integration:<id> or policy:<id> (repeatable)")
+		c.Flags().String("rule-state", "disabled", "State of the --entity integrations and policies during the window (disabled or enabled)")
+		c.Flags().StringArray("rule", nil, "Maintenance rule as entity=<integration|policy>:<id>[,state=disabled|enabled] (repeatable)")
 	}
 }
 
@@ -40,7 +46,7 @@ var maintenanceListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all maintenance windows",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -53,7 +59,7 @@ var maintenanceListCmd = &cobra.Command{
 			return err
 		}
 
-		if opts.Mode == output.ModeJSON {
+		if opts.Structured() {
 			return output.RenderJSON(resp.Data, opts)
 		}
 
@@ -77,7 +83,7 @@ var maintenanceGetCmd = &cobra.Command{
 	Short: "Get a maintenance window by ID",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -90,7 +96,7 @@ var maintenanceGetCmd = &cobra.Command{
 			return err
 		}
 
-		if opts.Mode == output.ModeJSON {
+		if opts.Structured() {
 			return output.RenderJSON(resp.Data, opts)
 		}
 
@@ -107,28 +113,61 @@ var maintenanceGetCmd = &cobra.Command{
 }
 
 var maintenanceCreateCmd = &cobra.Command{
-	Use:   "create",
-	Short: "Create a maintenance window",
+	Use:     "create",
+	Aliases: []string{"start"},
+	Short:   "Create a maintenance window",
+	Long: `Create a maintenance window. The window starts at --start-date, or now
+when it is omitted, and ends at --end-date or after --for.
+
+Each --entity adds a rule for an integration or policy: during the window
+it is put in --rule-state, so "disabled" (the default) silences an
+integration's alerts or switches a policy off. --rule sets the state per
+rule instead, e.g. --rule entity=policy:<id>,state=enabled.`,
+	Example: `  # Silence an integration for the next two hours
+  opsgenie-cli maintenance start --for 2h --entity integration:<integration-id> --description "DB upgrade"
+
+  # Scheduled window covering an integration and a policy
+  opsgenie-cli maintenance create --start-date 2024-06-01T22:00:00Z --for 90m \
+    --entity integration:<integration-id> --entity policy:<policy-id>`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
 		opts := getOutputOpts()
 
 		description, _ := cmd.Flags().GetString("description")
-		startDate, _ := cmd.Flags().GetString("start-date")
-		endDate, _ := cmd.Flags().GetString("end-date")
+		startDate, err := timeFlag(cmd, "start-date")
+		if err != nil {
+			return err
+		}
+		if startDate == "" {
+			startDate = time.Now().UTC().Format(time.RFC3339)
+		}
+		end, err := endDate(cmd, "end-date", startDate)
+		if err != nil {
+			return err
+		}
+		if end == "" {
+			return fmt.Errorf("--end-date or --for is required")
+		}
 		mType, _ := cmd.Flags().GetString("type")
+		rules, err := maintenanceRules(cmd)
+		if err != nil {
+			return err
+		}
 
 		body := map[string]interface{}{
 			"description": description,
 			"time": map[string]interface{}{
 				"type":      mType,
 				"startDate": startDate,
-				"endDate":   endDate,
+				"endDate":   end,
 			},
 		}
+		if len(rules) > 0 {
+			body["rules"] = rules
+		}
 
 		var result map[string]interface{}
 		if err := client.Post("/v1/maintenance", body, &result); err != nil {
@@ -145,7 +184,7 @@ var maintenanceUpdateCmd = &cobra.Command{
 	Short: "Update a maintenance window",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -157,12 +196,18 @@ var maintenanceUpdateCmd = &cobra.Command{
 			body["description"] = v
 		}
 		timeMap := map[string]interface{}{}
+		start, err := timeFlag(cmd, "start-date")
+		if err != nil {
+			return err
+		}
 		if cmd.Flags().Changed("start-date") {
-			v, _ := cmd.Flags().GetString("start-date")
-			timeMap["startDate"] = v
+			timeMap["startDate"] = start
 		}
-		if cmd.Flags().Changed("end-date") {
-			v, _ := cmd.Flags().GetString("end-date")
+		if cmd.Flags().Changed("end-date") || cmd.Flags().Changed("for") {
+			v, err := endDate(cmd, "end-date", start)
+			if err != nil {
+				return err
+			}
 			timeMap["endDate"] = v
 		}
 		if cmd.Flags().Changed("type") {
@@ -172,6 +217,13 @@ var maintenanceUpdateCmd = &cobra.Command{
 		if len(timeMap) > 0 {
 			body["time"] = timeMap
 		}
+		if cmd.Flags().Changed("entity") || cmd.Flags().Changed("rule") {
+			rules, err := maintenanceRules(cmd)
+			if err != nil {
+				return err
+			}
+			body["rules"] = rules
+		}
 
 		var result map[string]interface{}
 		if err := client.Put("/v1/maintenance/"+args[0], body, &result); err != nil {
@@ -188,7 +240,7 @@ var maintenanceDeleteCmd = &cobra.Command{
 	Short: "Delete a maintenance window",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -208,7 +260,7 @@ var maintenanceCancelCmd = &cobra.Command{
 	Short: "Cancel a maintenance window",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -223,6 +275,62 @@ var maintenanceCancelCmd = &cobra.Command{
 	},
 }
 
+// maintenanceRules builds the maintenance rules of --entity, each
+// integration:<id> or policy:<id> in --rule-state, followed by those of
+// --rule, each entity=<type>:<id>[,state=<state>].
+func maintenanceRules(cmd *cobra.Command) ([]map[string]interface{}, error) {
+	entities, _ := cmd.Flags().GetStringArray("entity")
+	specs, _ := cmd.Flags().GetStringArray("rule")
+	defaultState, _ := cmd.Flags().GetString("rule-state")
+	if defaultState != "disabled" && defaultState != "enabled" {
+		return nil, fmt.Errorf("--rule-state must be disabled or enabled, got %q", defaultState)
+	}
+
+	rules := []map[string]interface{}{}
+	add := func(flag, entity, state string) error {
+		typ, id, ok := strings.Cut(entity, ":")
+		typ = strings.ToLower(strings.TrimSpace(typ))
+		id = strings.TrimSpace(id)
+		if !ok || id == "" || (typ != "integration" && typ != "policy") {
+			return fmt.Errorf("invalid --%s entity %q: want integration:<id> or policy:<id>", flag, entity)
+		}
+		if state != "disabled" && state != "enabled" {
+			return fmt.Errorf("invalid --%s state %q: want disabled or enabled", flag, state)
+		}
+		rules = append(rules, map[string]interface{}{
+			"state":  state,
+			"entity": map[string]interface{}{"id": id, "type": typ},
+		})
+		return nil
+	}
+	for _, e := range entities {
+		if err := add("entity", e, defaultState); err != nil {
+			return nil, err
+		}
+	}
+	for _, spec := range specs {
+		entity, state := "", defaultState
+		for _, kv := range splitAndTrim(spec) {
+			k, v, _ := strings.Cut(kv, "=")
+			switch strings.ToLower(strings.TrimSpace(k)) {
+			case "entity":
+				entity = v
+			case "state":
+				state = strings.ToLower(strings.TrimSpace(v))
+			default:
+				return nil, fmt.Errorf("invalid --rule %q: unknown key %q (use entity and state)", spec, k)
+			}
+		}
+		if entity == "" {
+			return nil, fmt.Errorf("invalid --rule %q: entity=<integration|policy>:<id> is required", spec)
+		}
+		if err := add("rule", entity, state); err != nil {
+			return nil, err
+		}
+	}
+	return rules, nil
+}
+
 // stringVal safely extracts a string value from a map[string]interface{}.
 func stringVal(m map[string]interface{}, key string) string {
 	if v, ok := m[key]; ok {
@@ -0,0 +1,279 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+	"github.com/roboalchemist/opsgenie-cli/pkg/migrate"
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/roboalchemist/opsgenie-cli/pkg/snapshot"
+	"github.com/spf13/cobra"
+)
+
+var migrateCmd = &cobra.Command{
+	Use:         "migrate",
+	Short:       "Move on-call configuration between OpsGenie and other tools",
+	Annotations: map[string]string{timeoutAnnotation: "2m"},
+}
+
+// ─── migrate from-pagerduty ──────────────────────────────────────────────────
+
+var (
+	migratePDDir    string
+	migratePDDryRun bool
+)
+
+var migrateFromPagerDutyCmd = &cobra.Command{
+	Use:   "from-pagerduty",
+	Short: "Import users, schedules, escalation policies and services from PagerDuty",
+	Long: `Import a PagerDuty account into OpsGenie.
+
+The export directory holds the responses of PagerDuty's REST API list
+endpoints, one file each: users.json, teams.json, schedules.json,
+escalation_policies.json and services.json. Files may be missing, and each
+may hold a bare array or the API envelope (e.g. {"users": [...]}). Fetch
+schedules with their layers and escalation policies with their rules.
+
+Objects are converted as follows:
+
+  users                users, with their email as username
+  teams                teams
+  schedules            schedules, with one rotation per layer
+  escalation policies  escalations, with one rule per target
+  services             services of the service's first team
+
+Users are created when no OpsGenie user has their username; everything else
+is planned and applied like "apply", matching existing resources by name.
+Nothing is deleted. The mapping report lists every PagerDuty object, what
+it became, and anything that could not be carried over. Use --dry-run to
+review it before changing anything.`,
+	Example: `  # Review the mapping first
+  opsgenie-cli migrate from-pagerduty -f pd-export/ --dry-run
+
+  # Import, keeping the report for the migration ticket
+  opsgenie-cli migrate from-pagerduty -f pd-export/ --csv > mapping.csv`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		imp, err := migrate.LoadPagerDuty(migratePDDir)
+		if err != nil {
+			return err
+		}
+
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		actions := map[string]string{} // kind/parent/name -> action
+		key := func(kind, parent, name string) string { return kind + "/" + parent + "/" + name }
+
+		var users []api.UserResponse
+		if err := client.ListAll("/v2/users", nil, &users); err != nil {
+			return fmt.Errorf("users: %w", err)
+		}
+		existing := map[string]bool{}
+		for _, u := range users {
+			existing[strings.ToLower(u.Username)] = true
+		}
+		for _, u := range imp.Users {
+			if existing[strings.ToLower(u.Username)] {
+				actions[key("users", "", u.Username)] = snapshot.ActionUnchanged
+				continue
+			}
+			if !migratePDDryRun {
+				body := map[string]interface{}{"username": u.Username, "fullName": u.FullName, "role": map[string]string{"name": u.Role}}
+				if err := client.Post("/v2/users", body, nil); err != nil {
+					return fmt.Errorf("create user %s: %w", u.Username, err)
+				}
+			}
+			actions[key("users", "", u.Username)] = snapshot.ActionCreate
+		}
+
+		if len(imp.Resources) > 0 {
+			live, err := snapshot.Collect(client, liveKinds(imp.Resources))
+			if err != nil {
+				return err
+			}
+			changes, err := snapshot.PlanChanges(imp.Resources, live)
+			if err != nil {
+				return err
+			}
+			if !migratePDDryRun {
+				if err := snapshot.Apply(client, changes); err != nil {
+					return err
+				}
+			}
+			for _, c := range changes {
+				actions[key(c.Resource.Kind, c.Resource.Parent, c.Resource.Name)] = c.Action
+			}
+		}
+
+		if len(imp.Services) > 0 {
+			// Teams are read after the apply above so new ones have IDs.
+			live, err := snapshot.Collect(client, []string{"teams", "services"})
+			if err != nil {
+				return err
+			}
+			teamIDs := map[string]string{}
+			for _, r := range live {
+				if r.Kind == "teams" {
+					teamIDs[r.Name] = r.ID
+				}
+			}
+			services := make([]snapshot.Resource, len(imp.Services))
+			for i, s := range imp.Services {
+				id, ok := teamIDs[s.Parent]
+				if !ok && !migratePDDryRun {
+					return fmt.Errorf("service %q: team %q not found", s.Name, s.Parent)
+				}
+				data := map[string]interface{}{}
+				for k, v := range s.Data {
+					data[k] = v
+				}
+				if id != "" {
+					data["teamId"] = id
+				}
+				s.Parent, s.Data = "", data
+				services[i] = s
+			}
+			changes, err := snapshot.PlanChanges(services, live)
+			if err != nil {
+				return err
+			}
+			if !migratePDDryRun {
+				if err := snapshot.Apply(client, changes); err != nil {
+					return err
+				}
+			}
+			for i, c := range changes {
+				actions[key("services", imp.Services[i].Parent, c.Resource.Name)] = c.Action
+			}
+		}
+
+		headers := []string{"PAGERDUTY", "NAME", "OPSGENIE", "NAME", "ACTION", "NOTE"}
+		rows := make([][]string, len(imp.Mappings))
+		report := make([]migrateMapping, len(imp.Mappings))
+		counts := map[string]int{}
+		for i, m := range imp.Mappings {
+			action := "skip"
+			if m.Kind != "" {
+				action = actions[key(m.Kind, m.Parent, m.Name)]
+			}
+			counts[action]++
+			target := m.Kind
+			if m.Parent != "" {
+				target += " (" + m.Parent + ")"
+			}
+			rows[i] = []string{m.Source, m.SourceName, target, m.Name, action, m.Note}
+			report[i] = migrateMapping{Mapping: m, Action: action}
+		}
+		if err := output.RenderTable(headers, rows, report, opts); err != nil {
+			return err
+		}
+
+		summary := fmt.Sprintf("%d to create, %d to update, %d unchanged, %d skipped (dry run)",
+			counts[snapshot.ActionCreate], counts[snapshot.ActionUpdate], counts[snapshot.ActionUnchanged], counts["skip"])
+		if !migratePDDryRun {
+			summary = fmt.Sprintf("%d created, %d updated, %d unchanged, %d skipped",
+				counts[snapshot.ActionCreate], counts[snapshot.ActionUpdate], counts[snapshot.ActionUnchanged], counts["skip"])
+		}
+		output.Success(summary, opts)
+		return nil
+	},
+}
+
+// ─── migrate export ──────────────────────────────────────────────────────────
+
+var (
+	migrateExportFormat string
+	migrateExportDir    string
+)
+
+var migrateExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export schedules and escalations in another on-call tool's format",
+	Long: `Export users, teams, schedules, rotations, escalations and services as the
+API objects of another on-call tool, one JSON file per collection.
+
+  pagerduty        users, teams, schedules (rotations as layers),
+                   escalation_policies and services, in the layout
+                   "migrate from-pagerduty" reads
+  grafana-oncall   users, teams, schedules, on_call_shifts,
+                   escalation_chains and escalation_policies
+
+Objects keep their OpsGenie IDs and reference each other by them; users are
+listed with their email so the target tool's users can be matched. The
+mapping report lists what each OpsGenie object became and anything that
+has no equivalent in the target format.`,
+	Example: `  # Grafana OnCall definitions for an evaluation
+  opsgenie-cli migrate export --format grafana-oncall --dir ./grafana-oncall
+
+  # PagerDuty objects, with the mapping report as JSON
+  opsgenie-cli migrate export --format pagerduty --dir ./pd --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		var list []api.UserResponse
+		if err := client.ListAll("/v2/users", nil, &list); err != nil {
+			return fmt.Errorf("users: %w", err)
+		}
+		users := make([]migrate.User, len(list))
+		for i, u := range list {
+			users[i] = migrate.User{ID: u.ID, Username: u.Username, FullName: u.FullName, Role: u.Role.Name}
+		}
+		resources, err := collectResources(client, migrate.ExportKinds, "export")
+		if err != nil {
+			return err
+		}
+		exp, err := migrate.ExportTo(migrateExportFormat, users, resources)
+		if err != nil {
+			return err
+		}
+		files, err := exp.Write(migrateExportDir)
+		if err != nil {
+			return err
+		}
+
+		headers := []string{"OPSGENIE", "NAME", strings.ToUpper(migrateExportFormat), "NAME", "NOTE"}
+		rows := make([][]string, len(exp.Mappings))
+		for i, m := range exp.Mappings {
+			target := m.Kind
+			if m.Parent != "" {
+				target += " (" + m.Parent + ")"
+			}
+			rows[i] = []string{m.Source, m.SourceName, target, m.Name, m.Note}
+		}
+		if err := output.RenderTable(headers, rows, exp.Mappings, opts); err != nil {
+			return err
+		}
+		output.Success(fmt.Sprintf("Wrote %d file(s) to %s", len(files), migrateExportDir), opts)
+		return nil
+	},
+}
+
+// migrateMapping is one row of a migration report.
+type migrateMapping struct {
+	migrate.Mapping
+	Action string `json:"action"` // a snapshot action, or "skip"
+}
+
+func init() {
+	migrateFromPagerDutyCmd.Flags().StringVarP(&migratePDDir, "file", "f", "", "PagerDuty export directory")
+	migrateFromPagerDutyCmd.Flags().BoolVar(&migratePDDryRun, "dry-run", false, "Show the mapping and planned changes without applying them")
+	_ = migrateFromPagerDutyCmd.MarkFlagRequired("file")
+	addOutputFlags(migrateFromPagerDutyCmd)
+
+	migrateExportCmd.Flags().StringVar(&migrateExportFormat, "format", "", "Target format: "+strings.Join(migrate.Formats, ", "))
+	migrateExportCmd.Flags().StringVar(&migrateExportDir, "dir", "", "Directory to write to")
+	_ = migrateExportCmd.MarkFlagRequired("format")
+	_ = migrateExportCmd.MarkFlagRequired("dir")
+	addOutputFlags(migrateExportCmd)
+
+	migrateCmd.AddCommand(migrateFromPagerDutyCmd, migrateExportCmd)
+	rootCmd.AddCommand(migrateCmd)
+}
@@ -47,10 +47,12 @@ func init() {
 	notificationRulesCreateCmd.Flags().Bool("enabled", true, "Whether rule is enabled")
 	_ = notificationRulesCreateCmd.MarkFlagRequired("name")
 	_ = notificationRulesCreateCmd.MarkFlagRequired("action-type")
+	addNotificationRuleFlags(notificationRulesCreateCmd)
 
 	// update flags
 	notificationRulesUpdateCmd.Flags().String("name", "", "Rule name")
 	notificationRulesUpdateCmd.Flags().Bool("enabled", true, "Whether rule is enabled")
+	addNotificationRuleFlags(notificationRulesUpdateCmd)
 }
 
 var notificationRulesCmd = &cobra.Command{
@@ -63,7 +65,7 @@ var notificationRulesListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List notification rules for a user",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -78,7 +80,7 @@ var notificationRulesListCmd = &cobra.Command{
 			return err
 		}
 
-		if opts.Mode == output.ModeJSON {
+		if opts.Structured() {
 			return output.RenderJSON(resp.Data, opts)
 		}
 
@@ -100,7 +102,7 @@ var notificationRulesGetCmd = &cobra.Command{
 	Use:   "get",
 	Short: "Get a notification rule",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -116,7 +118,7 @@ var notificationRulesGetCmd = &cobra.Command{
 			return err
 		}
 
-		if opts.Mode == output.ModeJSON {
+		if opts.Structured() {
 			return output.RenderJSON(resp.Data, opts)
 		}
 
@@ -134,8 +136,16 @@ var notificationRulesGetCmd = &cobra.Command{
 var notificationRulesCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a notification rule for a user",
+	Example: `  # Email at once, then text after 5 minutes, for P1 alerts; repeat every 15 minutes
+  opsgenie-cli notification-rules create --user alice@example.com --name "P1 pages" \
+    --action-type create-alert --condition "priority equals P1" \
+    --step email:alice@example.com --step sms:+15551234567/5m --repeat 15m
+
+  # Remind me an hour before my on-call shift starts
+  opsgenie-cli notification-rules create --user alice@example.com --name "Shift reminder" \
+    --action-type schedule-start --notification-time 1-hour-ago --step email:alice@example.com`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -151,6 +161,9 @@ var notificationRulesCreateCmd = &cobra.Command{
 			"actionType": actionType,
 			"enabled":    enabled,
 		}
+		if err := applyNotificationRuleFlags(cmd, body); err != nil {
+			return err
+		}
 
 		var result map[string]interface{}
 		if err := client.Post("/v2/users/"+userID+"/notification-rules", body, &result); err != nil {
@@ -166,7 +179,7 @@ var notificationRulesUpdateCmd = &cobra.Command{
 	Use:   "update",
 	Short: "Update a notification rule",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -184,6 +197,9 @@ var notificationRulesUpdateCmd = &cobra.Command{
 			v, _ := cmd.Flags().GetBool("enabled")
 			body["enabled"] = v
 		}
+		if err := applyNotificationRuleFlags(cmd, body); err != nil {
+			return err
+		}
 
 		var result map[string]interface{}
 		if err := client.Patch("/v2/users/"+userID+"/notification-rules/"+ruleID, body, &result); err != nil {
@@ -199,7 +215,7 @@ var notificationRulesDeleteCmd = &cobra.Command{
 	Use:   "delete",
 	Short: "Delete a notification rule",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -221,7 +237,7 @@ var notificationRulesEnableCmd = &cobra.Command{
 	Use:   "enable",
 	Short: "Enable a notification rule",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -243,7 +259,7 @@ var notificationRulesDisableCmd = &cobra.Command{
 	Use:   "disable",
 	Short: "Disable a notification rule",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
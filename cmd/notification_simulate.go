@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/roboalchemist/opsgenie-cli/pkg/routing"
+	"github.com/spf13/cobra"
+)
+
+// ─── notification-rules simulate ─────────────────────────────────────────────
+
+var (
+	nrSimUser     string
+	nrSimAction   string
+	nrSimPriority string
+	nrSimMessage  string
+	nrSimSource   string
+	nrSimEntity   string
+	nrSimTags     []string
+	nrSimDetails  []string
+	nrSimSchedule string
+	nrSimAt       string
+	nrSimTimezone string
+)
+
+var notificationRulesSimulateCmd = &cobra.Command{
+	Use:   "simulate",
+	Short: "Show which notification rule and steps would fire for an alert",
+	Long: `Walk a user's notification rules for a hypothetical alert and show which
+rule would fire and the notifications it would send, in order with their
+delays: will this alert actually wake me up?
+
+Rules for --action are evaluated in order, as OpsGenie does: the first
+enabled rule whose conditions match the alert, whose time restriction
+covers --at in the user's time zone and, for schedule-start and
+schedule-end rules, whose schedules include --schedule fires; the rules
+after it are not reached. Each rule is listed with why it fires or not.
+
+Only the user's own rules are simulated: whether the alert reaches the
+user at all depends on routing and escalations (see "alerts why" and
+"escalations test").`,
+	Example: `  # Would a P1 at 3am reach me, and how?
+  opsgenie-cli notification-rules simulate --user alice@example.com --priority P1 --at 03:00
+
+  # A tagged alert on a Sunday night
+  opsgenie-cli notification-rules simulate --user alice@example.com --tag database --at "sun 23:30"
+
+  # What happens when my on-call shift on Primary starts?
+  opsgenie-cli notification-rules simulate --user alice@example.com --action schedule-start --schedule Primary`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if nrSimUser == "" {
+			return fmt.Errorf("--user is required")
+		}
+		if !validPriority(nrSimPriority) {
+			return output.Invalid(fmt.Errorf("invalid --priority %q (use P1, P2, P3, P4 or P5)", nrSimPriority))
+		}
+		alert := api.AlertResponse{
+			Message:  nrSimMessage,
+			Priority: strings.ToUpper(nrSimPriority),
+			Source:   nrSimSource,
+			Entity:   nrSimEntity,
+			Tags:     nrSimTags,
+			Details:  map[string]string{},
+		}
+		for _, d := range nrSimDetails {
+			k, v, ok := strings.Cut(d, "=")
+			if !ok || strings.TrimSpace(k) == "" {
+				return fmt.Errorf("invalid --detail %q (use key=value)", d)
+			}
+			alert.Details[strings.TrimSpace(k)] = v
+		}
+
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		userPath := "/v2/users/" + url.PathEscape(nrSimUser)
+		var user struct {
+			Data api.UserResponse `json:"data"`
+		}
+		if err := client.Get(userPath, &user); err != nil {
+			return err
+		}
+		tz := nrSimTimezone
+		if tz == "" {
+			tz = user.Data.TimeZone
+		}
+		loc := time.UTC
+		if tz != "" {
+			if loc, err = time.LoadLocation(tz); err != nil {
+				return fmt.Errorf("invalid time zone %q: %w", tz, err)
+			}
+		}
+		at, err := simulationTime(nrSimAt, time.Now().In(loc))
+		if err != nil {
+			return err
+		}
+
+		var list struct {
+			Data []api.NotificationRuleResponse `json:"data"`
+		}
+		if err := client.Get(userPath+"/notification-rules", &list); err != nil {
+			return err
+		}
+		// The list has no criteria or steps: get each rule of the action.
+		var ids []string
+		for _, r := range list.Data {
+			if r.ActionType == nrSimAction {
+				ids = append(ids, r.ID)
+			}
+		}
+		rules := make([]api.NotificationRuleResponse, len(ids))
+		errs := client.Fanout(len(ids), func(i int) error {
+			var resp struct {
+				Data api.NotificationRuleResponse `json:"data"`
+			}
+			err := client.Get(userPath+"/notification-rules/"+url.PathEscape(ids[i]), &resp)
+			rules[i] = resp.Data
+			return err
+		})
+		for i, err := range errs {
+			if err != nil {
+				return fmt.Errorf("notification rule %s: %w", ids[i], err)
+			}
+		}
+
+		outcomes := routing.SimulateNotifications(rules, nrSimAction, alert, nrSimSchedule, at, loc)
+
+		if opts.Structured() || opts.Template != "" || opts.JQExpr != "" {
+			return output.RenderJSON(map[string]interface{}{
+				"user":     user.Data.Username,
+				"action":   nrSimAction,
+				"at":       at.Format(time.RFC3339),
+				"timeZone": loc.String(),
+				"alert":    alert,
+				"rules":    outcomes,
+			}, opts)
+		}
+
+		headers := []string{"Order", "Rule", "Result", "After", "Method", "Contact", "At"}
+		var rows [][]string
+		var fired *routing.RuleOutcome
+		for i, o := range outcomes {
+			if o.Fires {
+				fired = &outcomes[i]
+			}
+			order := strconv.Itoa(o.Order)
+			if len(o.Sends) == 0 {
+				rows = append(rows, []string{order, o.Rule, o.Reason, "", "", "", ""})
+				continue
+			}
+			for _, s := range o.Sends {
+				after := "immediately"
+				if s.After > 0 {
+					after = formatDuration(s.After)
+				}
+				rows = append(rows, []string{order, o.Rule, "fires", after, s.Method, s.To, s.At.Format("Mon 15:04")})
+			}
+		}
+		if err := output.RenderTable(headers, rows, outcomes, opts); err != nil {
+			return err
+		}
+
+		when := at.Format("Mon 15:04 MST")
+		switch {
+		case fired == nil:
+			output.Success(fmt.Sprintf("No %s rule of %s fires at %s: no notification would be sent", nrSimAction, user.Data.Username, when), opts)
+		case len(fired.Sends) == 0:
+			output.Success(fmt.Sprintf("Rule %q fires at %s but sends nothing: it has no enabled steps", fired.Rule, when), opts)
+		default:
+			msg := fmt.Sprintf("Rule %q fires at %s: %d notification(s), the first by %s", fired.Rule, when, len(fired.Sends), fired.Sends[0].Method)
+			if fired.RepeatEvery > 0 {
+				msg += fmt.Sprintf(", repeated every %s until acknowledged", formatDuration(fired.RepeatEvery))
+			}
+			output.Success(msg, opts)
+		}
+		return nil
+	},
+}
+
+// simulationTime parses --at: an RFC3339 time, "HH:MM" today or
+// "<weekday> HH:MM" within the coming week, in now's location. Empty
+// means now.
+func simulationTime(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return now, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.In(now.Location()), nil
+	}
+	day := ""
+	clock := s
+	if strings.Contains(s, " ") {
+		d, h, m, err := parseDayClock(s)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid --at %q: %w", s, err)
+		}
+		day, clock = d, fmt.Sprintf("%02d:%02d", h, m)
+	}
+	h, m, err := parseClock(clock)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --at %q (use RFC3339, HH:MM or \"<day> HH:MM\"): %w", s, err)
+	}
+	t := time.Date(now.Year(), now.Month(), now.Day(), h, m, 0, 0, now.Location())
+	if day != "" {
+		for strings.ToLower(t.Weekday().String()) != day {
+			t = t.AddDate(0, 0, 1)
+		}
+	}
+	return t, nil
+}
+
+func init() {
+	f := notificationRulesSimulateCmd.Flags()
+	f.StringVar(&nrSimUser, "user", "", "User ID or username (required)")
+	f.StringVar(&nrSimAction, "action", "create-alert", "Action to simulate: create-alert, acknowledged-alert, closed-alert, assigned-alert, add-note, schedule-start, schedule-end, ...")
+	f.StringVar(&nrSimPriority, "priority", "P3", "Alert priority")
+	f.StringVar(&nrSimMessage, "message", "", "Alert message")
+	f.StringVar(&nrSimSource, "source", "", "Alert source")
+	f.StringVar(&nrSimEntity, "entity", "", "Alert entity")
+	f.StringSliceVar(&nrSimTags, "tag", nil, "Alert tag (repeatable)")
+	f.StringArrayVar(&nrSimDetails, "detail", nil, "Alert detail as key=value (repeatable)")
+	f.StringVar(&nrSimSchedule, "schedule", "", "Schedule name or ID whose on-call starts or ends, for schedule-start/schedule-end")
+	f.StringVar(&nrSimAt, "at", "", "When the alert is raised: RFC3339, HH:MM or \"<day> HH:MM\" in the user's time zone (default now)")
+	f.StringVar(&nrSimTimezone, "timezone", "", "Time zone for --at and time restrictions (default the user's)")
+	addOutputFlags(notificationRulesSimulateCmd)
+
+	notificationRulesCmd.AddCommand(notificationRulesSimulateCmd)
+}
@@ -0,0 +1,354 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// notificationTimes are the --notification-time values OpsGenie accepts for
+// schedule-start and schedule-end rules.
+var notificationTimes = map[string]bool{
+	"just-before":    true,
+	"15-minutes-ago": true,
+	"1-hour-ago":     true,
+	"1-day-ago":      true,
+}
+
+// parseDelayMinutes parses a step delay or repeat interval such as 0, 5m or
+// 1h into whole minutes.
+func parseDelayMinutes(s string) (int, error) {
+	if strings.TrimSpace(s) == "0" {
+		return 0, nil
+	}
+	d, err := parseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d%time.Minute != 0 {
+		return 0, fmt.Errorf("invalid delay %q: must be whole minutes", s)
+	}
+	return int(d / time.Minute), nil
+}
+
+// parseStep parses a --step value of the form
+//
+//	<method>[:<to>][/<delay>]
+//
+// e.g. "sms:+15551234567/5m" or "email:alice@example.com", into a
+// notification step. The delay defaults to 0.
+func parseStep(s string) (map[string]interface{}, error) {
+	contact, delay := s, "0"
+	if i := strings.LastIndex(s, "/"); i >= 0 {
+		contact, delay = s[:i], s[i+1:]
+	}
+	method, to, _ := strings.Cut(contact, ":")
+	if method == "" {
+		return nil, fmt.Errorf("invalid step %q: expected \"<method>[:<to>][/<delay>]\"", s)
+	}
+	minutes, err := parseDelayMinutes(delay)
+	if err != nil {
+		return nil, fmt.Errorf("invalid step %q: %w", s, err)
+	}
+	return notificationStep(method, to, minutes), nil
+}
+
+func notificationStep(method, to string, minutes int) map[string]interface{} {
+	c := map[string]interface{}{"method": strings.ToLower(method)}
+	if to != "" {
+		c["to"] = to
+	}
+	return map[string]interface{}{
+		"contact":   c,
+		"sendAfter": map[string]interface{}{"timeAmount": minutes, "timeUnit": "minutes"},
+		"enabled":   true,
+	}
+}
+
+// applyNotificationRuleFlags copies the changed rule flags shared by create
+// and update onto body.
+func applyNotificationRuleFlags(cmd *cobra.Command, body map[string]interface{}) error {
+	f := cmd.Flags()
+	if f.Changed("condition") || f.Changed("match") {
+		match, _ := f.GetString("match")
+		conditions, _ := f.GetStringArray("condition")
+		filter, err := buildFilter(match, conditions)
+		if err != nil {
+			return output.Invalid(err)
+		}
+		body["criteria"] = filter
+	}
+	if f.Changed("step") {
+		values, _ := f.GetStringArray("step")
+		steps := make([]map[string]interface{}, 0, len(values))
+		for _, v := range values {
+			step, err := parseStep(v)
+			if err != nil {
+				return output.Invalid(err)
+			}
+			steps = append(steps, step)
+		}
+		body["steps"] = steps
+	}
+	if f.Changed("notification-time") {
+		v, _ := f.GetString("notification-time")
+		times := splitAndTrim(v)
+		for _, t := range times {
+			if !notificationTimes[t] {
+				return output.Invalid(fmt.Errorf("invalid --notification-time %q (use just-before, 15-minutes-ago, 1-hour-ago or 1-day-ago)", t))
+			}
+		}
+		body["notificationTime"] = times
+	}
+	if f.Changed("time-restriction") {
+		v, _ := f.GetString("time-restriction")
+		tr, err := parseTimeRestriction(v)
+		if err != nil {
+			return output.Invalid(err)
+		}
+		body["timeRestriction"] = tr
+	}
+	if f.Changed("repeat") {
+		v, _ := f.GetString("repeat")
+		minutes, err := parseDelayMinutes(v)
+		if err != nil {
+			return output.Invalid(fmt.Errorf("invalid --repeat: %w", err))
+		}
+		if minutes == 0 {
+			body["repeat"] = map[string]interface{}{"enabled": false}
+		} else {
+			body["repeat"] = map[string]interface{}{"loopAfter": minutes, "enabled": true}
+		}
+	}
+	return nil
+}
+
+// addNotificationRuleFlags adds the flags applyNotificationRuleFlags reads.
+func addNotificationRuleFlags(c *cobra.Command) {
+	f := c.Flags()
+	f.StringArray("step", nil, `Notification step "<method>[:<to>][/<delay>]", e.g. sms:+15551234567/5m (repeatable; replaces all steps)`)
+	f.StringArray("condition", nil, `Criteria condition "[not] <field>[:<key>] <operation> [value]" (repeatable)`)
+	f.String("match", "all-conditions", "How conditions combine: all-conditions, any, or all (match every alert)")
+	f.String("notification-time", "", "For schedule-start/end rules: comma-separated just-before, 15-minutes-ago, 1-hour-ago, 1-day-ago")
+	f.String("time-restriction", "", `Only apply during "HH:MM-HH:MM" or "mon 09:00-fri 17:00[,...]"`)
+	f.String("repeat", "", "Repeat the steps this often until acknowledged, e.g. 10m (0 to stop repeating)")
+}
+
+// ─── notification-rules steps ────────────────────────────────────────────────
+
+var notificationStepsCmd = &cobra.Command{
+	Use:   "steps",
+	Short: "Manage the steps of a notification rule",
+	Long: `Manage the steps of a notification rule one at a time: each step sends to
+one contact method a given time after the rule fires.`,
+}
+
+// stepsPath returns the steps path of the rule named by --user and
+// --rule-id, followed by suffix.
+func stepsPath(cmd *cobra.Command, suffix string) string {
+	userID, _ := cmd.Flags().GetString("user")
+	ruleID, _ := cmd.Flags().GetString("rule-id")
+	return "/v2/users/" + userID + "/notification-rules/" + ruleID + "/notification-steps" + suffix
+}
+
+func stepRow(s api.NotificationStep) []string {
+	unit := s.SendAfter.TimeUnit
+	if unit == "" {
+		unit = "minutes"
+	}
+	return []string{
+		s.ID,
+		s.Contact.Method,
+		s.Contact.To,
+		fmt.Sprintf("%d %s", s.SendAfter.TimeAmount, unit),
+		strconv.FormatBool(s.Enabled),
+	}
+}
+
+var notificationStepsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the steps of a notification rule",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		var resp struct {
+			Data []api.NotificationStep `json:"data"`
+		}
+		if err := client.Get(stepsPath(cmd, ""), &resp); err != nil {
+			return err
+		}
+		headers := []string{"ID", "METHOD", "TO", "AFTER", "ENABLED"}
+		rows := make([][]string, len(resp.Data))
+		for i, s := range resp.Data {
+			rows[i] = stepRow(s)
+		}
+		return output.RenderTable(headers, rows, resp.Data, opts)
+	},
+}
+
+var notificationStepsGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Get a notification rule step",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		stepID, _ := cmd.Flags().GetString("step-id")
+		var resp struct {
+			Data api.NotificationStep `json:"data"`
+		}
+		if err := client.Get(stepsPath(cmd, "/"+stepID), &resp); err != nil {
+			return err
+		}
+		headers := []string{"ID", "METHOD", "TO", "AFTER", "ENABLED"}
+		return output.RenderTable(headers, [][]string{stepRow(resp.Data)}, resp.Data, opts)
+	},
+}
+
+var notificationStepsCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Add a step to a notification rule",
+	Example: `  # Text me five minutes after the rule fires
+  opsgenie-cli notification-rules steps create --user alice@example.com --rule-id <rule-id> \
+    --method sms --to +15551234567 --after 5m`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		method, _ := cmd.Flags().GetString("method")
+		to, _ := cmd.Flags().GetString("to")
+		after, _ := cmd.Flags().GetString("after")
+		minutes, err := parseDelayMinutes(after)
+		if err != nil {
+			return output.Invalid(fmt.Errorf("invalid --after: %w", err))
+		}
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		body := notificationStep(method, to, minutes)
+		if cmd.Flags().Changed("enabled") {
+			body["enabled"], _ = cmd.Flags().GetBool("enabled")
+		}
+		var result map[string]interface{}
+		if err := client.Post(stepsPath(cmd, ""), body, &result); err != nil {
+			return err
+		}
+		output.Success(fmt.Sprintf("Step %s added", method), opts)
+		return output.RenderJSON(result, opts)
+	},
+}
+
+var notificationStepsUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update a notification rule step",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		f := cmd.Flags()
+		body := map[string]interface{}{}
+		if f.Changed("method") || f.Changed("to") {
+			contact := map[string]interface{}{}
+			if v, _ := f.GetString("method"); v != "" {
+				contact["method"] = strings.ToLower(v)
+			}
+			if v, _ := f.GetString("to"); v != "" {
+				contact["to"] = v
+			}
+			body["contact"] = contact
+		}
+		if f.Changed("after") {
+			v, _ := f.GetString("after")
+			minutes, err := parseDelayMinutes(v)
+			if err != nil {
+				return output.Invalid(fmt.Errorf("invalid --after: %w", err))
+			}
+			body["sendAfter"] = map[string]interface{}{"timeAmount": minutes, "timeUnit": "minutes"}
+		}
+		if f.Changed("enabled") {
+			body["enabled"], _ = f.GetBool("enabled")
+		}
+		if len(body) == 0 {
+			return fmt.Errorf("nothing to update: give --method, --to, --after or --enabled")
+		}
+
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		stepID, _ := f.GetString("step-id")
+		var result map[string]interface{}
+		if err := client.Patch(stepsPath(cmd, "/"+stepID), body, &result); err != nil {
+			return err
+		}
+		output.Success(fmt.Sprintf("Step %s updated", stepID), opts)
+		return output.RenderJSON(result, opts)
+	},
+}
+
+// newStepActionCmd builds the delete, enable and disable subcommands, which
+// differ only in method, path suffix and message.
+func newStepActionCmd(use, short, method, suffix, done string) *cobra.Command {
+	return &cobra.Command{
+		Use:   use,
+		Short: short,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := newClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+			stepID, _ := cmd.Flags().GetString("step-id")
+			if err := client.Do(method, stepsPath(cmd, "/"+stepID+suffix), nil, nil); err != nil {
+				return err
+			}
+			output.Success(fmt.Sprintf("Step %s %s", stepID, done), GetOutputOptions())
+			return nil
+		},
+	}
+}
+
+func init() {
+	notificationRulesCmd.AddCommand(notificationStepsCmd)
+
+	deleteCmd := newStepActionCmd("delete", "Delete a notification rule step", "DELETE", "", "deleted")
+	enableCmd := newStepActionCmd("enable", "Enable a notification rule step", "POST", "/enable", "enabled")
+	disableCmd := newStepActionCmd("disable", "Disable a notification rule step", "POST", "/disable", "disabled")
+
+	all := []*cobra.Command{
+		notificationStepsListCmd, notificationStepsGetCmd, notificationStepsCreateCmd,
+		notificationStepsUpdateCmd, deleteCmd, enableCmd, disableCmd,
+	}
+	for _, c := range all {
+		c.Flags().String("user", "", "User ID or username (required)")
+		c.Flags().String("rule-id", "", "Notification rule ID (required)")
+		_ = c.MarkFlagRequired("user")
+		_ = c.MarkFlagRequired("rule-id")
+		notificationStepsCmd.AddCommand(c)
+	}
+	for _, c := range []*cobra.Command{notificationStepsGetCmd, notificationStepsUpdateCmd, deleteCmd, enableCmd, disableCmd} {
+		c.Flags().String("step-id", "", "Step ID (required)")
+		_ = c.MarkFlagRequired("step-id")
+	}
+	for _, c := range []*cobra.Command{notificationStepsCreateCmd, notificationStepsUpdateCmd} {
+		c.Flags().String("method", "", "Contact method: email, sms, voice or mobile")
+		c.Flags().String("to", "", "Contact address, e.g. a phone number or email address")
+		c.Flags().String("after", "0", "Send this long after the rule fires, e.g. 5m")
+		c.Flags().Bool("enabled", true, "Whether the step is enabled")
+	}
+	_ = notificationStepsCreateCmd.MarkFlagRequired("method")
+	addOutputFlags(notificationStepsListCmd)
+	addOutputFlags(notificationStepsGetCmd)
+	addOutputFlags(notificationStepsCreateCmd)
+	addOutputFlags(notificationStepsUpdateCmd)
+}
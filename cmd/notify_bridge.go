@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+	"github.com/roboalchemist/opsgenie-cli/pkg/auth"
+	"github.com/roboalchemist/opsgenie-cli/pkg/desktop"
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/roboalchemist/opsgenie-cli/pkg/refresh"
+	"github.com/spf13/cobra"
+)
+
+// ─── notify-bridge ───────────────────────────────────────────────────────────
+
+var (
+	notifyBridgeUser            string
+	notifyBridgeQuery           string
+	notifyBridgeInterval        = durationFlag(30 * time.Second)
+	notifyBridgeSnoozeFor       = durationFlag(30 * time.Minute)
+	notifyBridgeIncludeExisting bool
+	notifyBridgeNoActions       bool
+	notifyBridgeOnce            bool
+)
+
+// errBridgeDone stops the refresher after one round for --once.
+var errBridgeDone = errors.New("done")
+
+var notifyBridgeCmd = &cobra.Command{
+	Use:   "notify-bridge",
+	Short: "Raise desktop notifications for new alerts targeting you",
+	Long: `Poll for open alerts targeting a user and raise a native desktop
+notification for each new one: a lightweight desktop pager for when the
+mobile app is off at your desk. Runs until interrupted.
+
+Where the platform can report a click, the notification has Acknowledge and
+Snooze buttons that act on the alert as the API key's integration:
+
+  Linux    notify-send (libnotify); buttons need a notification daemon
+           with action support
+  macOS    terminal-notifier if installed (buttons), otherwise osascript
+  Windows  PowerShell toast (no buttons)
+
+Alerts that are already open when the bridge starts are not notified unless
+--include-existing is given. Polling uses the same updatedAt watermark as
+"alerts watch", so each round is one small request.`,
+	Example: `  # Page me on the desktop for alerts I'm a responder of
+  OPSGENIE_USER=alice@example.com opsgenie-cli notify-bridge
+
+  # Only P1/P2 alerts for my team, snoozing for an hour
+  opsgenie-cli notify-bridge --user alice@example.com \
+    --query 'status:open AND teams:"Platform" AND priority:(P1 OR P2)' --snooze-for 1h
+
+  # From cron: notify for whatever is open now and exit
+  opsgenie-cli notify-bridge --user alice@example.com --include-existing --once`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		user := notifyBridgeUser
+		if user == "" {
+			var err error
+			if user, err = auth.User(); err != nil {
+				return err
+			}
+		}
+		query := notifyBridgeQuery
+		if query == "" {
+			if user == "" {
+				return fmt.Errorf("--user is required (or set OPSGENIE_USER)")
+			}
+			query = fmt.Sprintf("status:open AND responders:%q", user)
+		}
+		if err := desktop.Check(); err != nil {
+			return err
+		}
+		var actions []desktop.Action
+		if !notifyBridgeNoActions && desktop.SupportsActions() {
+			actions = []desktop.Action{{Key: "ack", Label: "Acknowledge"}, {Key: "snooze", Label: "Snooze"}}
+		}
+
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+		ctx := cmd.Context()
+
+		fetch := func(ctx context.Context, since time.Time) ([]refresh.Item, error) {
+			q := query
+			if !since.IsZero() {
+				q = alertsUpdatedSinceQuery(since, q)
+			}
+			var alerts []api.AlertResponse
+			if err := client.ListAllCtx(ctx, "/v2/alerts", url.Values{"query": {q}}, &alerts); err != nil {
+				return nil, err
+			}
+			items := make([]refresh.Item, len(alerts))
+			for i, a := range alerts {
+				updated, err := time.Parse(time.RFC3339Nano, a.UpdatedAt)
+				if err != nil {
+					updated, _ = time.Parse(time.RFC3339Nano, a.CreatedAt)
+				}
+				items[i] = refresh.Item{ID: a.ID, UpdatedAt: updated, Data: a}
+			}
+			return items, nil
+		}
+
+		var wg sync.WaitGroup
+		seen := map[string]bool{}
+		first := true
+		r := &refresh.Refresher{Fetch: fetch, Interval: time.Duration(notifyBridgeInterval)}
+		err = r.Run(ctx, func(u refresh.Update) error {
+			for _, it := range u.Changed {
+				a := it.Data.(api.AlertResponse)
+				if seen[a.ID] {
+					continue
+				}
+				seen[a.ID] = true
+				if (first && !notifyBridgeIncludeExisting) || a.Status != "open" || a.Acknowledged || a.Snoozed {
+					continue
+				}
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					notifyAlert(ctx, client, a, actions, opts)
+				}()
+			}
+			if first && !notifyBridgeOnce {
+				output.Success(fmt.Sprintf("Watching for new alerts matching %s (Ctrl-C to stop)", query), opts)
+			}
+			first = false
+			if notifyBridgeOnce {
+				return errBridgeDone
+			}
+			return nil
+		})
+		wg.Wait()
+		if errors.Is(err, errBridgeDone) {
+			return nil
+		}
+		return err
+	},
+}
+
+// notifyAlert raises the desktop notification for a and carries out the
+// action clicked, if any. Failures are reported and do not stop the bridge.
+func notifyAlert(ctx context.Context, client *api.Client, a api.AlertResponse, actions []desktop.Action, opts output.Options) {
+	title := "OpsGenie alert"
+	if a.Priority != "" {
+		title = "[" + a.Priority + "] " + title
+	}
+	if a.TinyID != "" {
+		title += " #" + a.TinyID
+	}
+	body := a.Message
+	if a.Source != "" {
+		body += "\nSource: " + a.Source
+	}
+	output.Success(fmt.Sprintf("Notified: %s %s", title, a.Message), opts)
+
+	choice, err := desktop.Show(ctx, desktop.Notification{Title: title, Body: body, Urgent: a.Priority == "P1", Actions: actions})
+	if err != nil {
+		output.Error(fmt.Sprintf("alert %s: %v", a.ID, err), opts)
+		return
+	}
+	path := "/v2/alerts/" + url.PathEscape(a.ID)
+	switch choice {
+	case "ack":
+		err = client.Post(path+"/acknowledge", map[string]interface{}{}, nil)
+		if err == nil {
+			output.Success(fmt.Sprintf("Alert %s acknowledged", a.ID), opts)
+		}
+	case "snooze":
+		end := time.Now().Add(time.Duration(notifyBridgeSnoozeFor)).UTC().Format(time.RFC3339)
+		err = client.Post(path+"/snooze", map[string]interface{}{"endTime": end}, nil)
+		if err == nil {
+			output.Success(fmt.Sprintf("Alert %s snoozed until %s", a.ID, end), opts)
+		}
+	}
+	if err != nil {
+		output.Error(fmt.Sprintf("alert %s: %v", a.ID, err), opts)
+	}
+}
+
+func init() {
+	f := notifyBridgeCmd.Flags()
+	f.StringVar(&notifyBridgeUser, "user", "", "Username (email) whose alerts to notify (default $OPSGENIE_USER)")
+	f.StringVar(&notifyBridgeQuery, "query", "", "Search query for the alerts to notify (default open alerts with --user as a responder)")
+	f.Var(&notifyBridgeInterval, "interval", "Time between polls (e.g. 30s, 1m)")
+	f.Var(&notifyBridgeSnoozeFor, "snooze-for", "How long the Snooze button snoozes an alert")
+	f.BoolVar(&notifyBridgeIncludeExisting, "include-existing", false, "Also notify alerts already open at start")
+	f.BoolVar(&notifyBridgeNoActions, "no-actions", false, "Show notifications without Acknowledge/Snooze buttons")
+	f.BoolVar(&notifyBridgeOnce, "once", false, "Poll once, wait for the notifications raised and exit")
+
+	rootCmd.AddCommand(notifyBridgeCmd)
+}
@@ -2,15 +2,53 @@ package cmd
 
 import (
 	"fmt"
+	"net/url"
+	"os"
+	"strings"
 
 	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+	"github.com/roboalchemist/opsgenie-cli/pkg/auth"
 	"github.com/roboalchemist/opsgenie-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
 
 var onCallCmd = &cobra.Command{
-	Use:   "on-call",
-	Short: "Query on-call schedules",
+	Use:     "on-call",
+	Aliases: []string{"oncall"},
+	Short:   "Query on-call schedules",
+}
+
+// onCallParams builds the flat/date query parameters shared by the on-call
+// subcommands.
+func onCallParams(cmd *cobra.Command) (url.Values, error) {
+	params := url.Values{}
+	if flat, _ := cmd.Flags().GetBool("flat"); flat {
+		params.Set("flat", "true")
+	}
+	date, err := timeFlag(cmd, "date")
+	if err != nil {
+		return nil, err
+	}
+	if date != "" {
+		params.Set("date", date)
+	}
+	return params, nil
+}
+
+// onCallRows flattens on-call responses into Schedule/Start/End/Recipient rows.
+func onCallRows(responses []api.OnCallResponse) [][]string {
+	var rows [][]string
+	for _, r := range responses {
+		for _, p := range r.Participants() {
+			rows = append(rows, []string{
+				r.ScheduleRef.Name,
+				p.OnCallStart,
+				p.OnCallEnd,
+				p.Name,
+			})
+		}
+	}
+	return rows
 }
 
 var onCallGetCmd = &cobra.Command{
@@ -20,107 +58,213 @@ var onCallGetCmd = &cobra.Command{
   opsgenie-cli on-call get --schedule my-schedule
 
   # Get flat list of on-call participants as JSON
-  opsgenie-cli on-call get --schedule my-schedule --flat --json`,
+  opsgenie-cli on-call get --schedule my-schedule --flat --json
+
+  # Who was on-call at a specific time
+  opsgenie-cli on-call get --schedule my-schedule --date 2024-01-15T03:00:00Z`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
 		opts := getOutputOpts()
 
 		scheduleID, _ := cmd.Flags().GetString("schedule")
-		flat, _ := cmd.Flags().GetBool("flat")
 		if scheduleID == "" {
 			return fmt.Errorf("--schedule is required")
 		}
-
-		path := "/v2/schedules/" + scheduleID + "/on-calls"
-		if flat {
-			path += "?flat=true"
+		if scheduleID, err = client.ResolveID("schedule", scheduleID); err != nil {
+			return err
 		}
 
-		var resp struct {
-			Data api.OnCallResponse `json:"data"`
+		params, err := onCallParams(cmd)
+		if err != nil {
+			return err
 		}
-		if err := client.Get(path, &resp); err != nil {
+		var data api.OnCallResponse
+		if err := client.GetWithParams("/v2/schedules/"+scheduleID+"/on-calls", params, &data); err != nil {
 			return err
 		}
 
-		if opts.Mode == output.ModeJSON {
-			return output.RenderJSON(resp.Data, opts)
+		if opts.Structured() {
+			return output.RenderJSON(data, opts)
 		}
 
 		headers := []string{"Schedule", "Start", "End", "Recipient"}
-		var rows [][]string
-		for _, p := range resp.Data.OnCallParticipants {
-			rows = append(rows, []string{
-				resp.Data.ScheduleRef.Name,
-				p.OnCallStart,
-				p.OnCallEnd,
-				p.Name,
-			})
-		}
-		return output.RenderTable(headers, rows, resp.Data, opts)
+		return output.RenderTable(headers, onCallRows([]api.OnCallResponse{data}), data, opts)
 	},
 }
 
 var onCallNextCmd = &cobra.Command{
 	Use:   "next",
 	Short: "Get next on-call participants for a schedule",
+	Example: `  # Who is on-call after the current shift
+  opsgenie-cli on-call next --schedule my-schedule
+
+  # Who is on-call after the shift running at a given time
+  opsgenie-cli oncall next --schedule my-schedule --date 2024-01-15T09:00:00Z --json`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
 		opts := getOutputOpts()
 
 		scheduleID, _ := cmd.Flags().GetString("schedule")
-		flat, _ := cmd.Flags().GetBool("flat")
 		if scheduleID == "" {
 			return fmt.Errorf("--schedule is required")
 		}
+		if scheduleID, err = client.ResolveID("schedule", scheduleID); err != nil {
+			return err
+		}
 
-		path := "/v2/schedules/" + scheduleID + "/next-on-calls"
-		if flat {
-			path += "?flat=true"
+		params, err := onCallParams(cmd)
+		if err != nil {
+			return err
+		}
+		var data api.OnCallResponse
+		if err := client.GetWithParams("/v2/schedules/"+scheduleID+"/next-on-calls", params, &data); err != nil {
+			return err
 		}
 
-		var resp struct {
-			Data api.OnCallResponse `json:"data"`
+		if opts.Structured() {
+			return output.RenderJSON(data, opts)
 		}
-		if err := client.Get(path, &resp); err != nil {
+
+		headers := []string{"Schedule", "Start", "End", "Recipient"}
+		return output.RenderTable(headers, onCallRows([]api.OnCallResponse{data}), data, opts)
+	},
+}
+
+var onCallListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List current on-call participants across all schedules",
+	Example: `  # Everyone on-call right now
+  opsgenie-cli oncall list
+
+  # Flat list of usernames as JSON
+  opsgenie-cli oncall list --flat --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient(cmd.Context())
+		if err != nil {
 			return err
 		}
+		opts := getOutputOpts()
 
-		if opts.Mode == output.ModeJSON {
-			return output.RenderJSON(resp.Data, opts)
+		params, err := onCallParams(cmd)
+		if err != nil {
+			return err
+		}
+		var data []api.OnCallResponse
+		if err := client.GetWithParams("/v2/schedules/on-calls", params, &data); err != nil {
+			return err
+		}
+
+		if opts.Structured() {
+			return output.RenderJSON(data, opts)
 		}
 
 		headers := []string{"Schedule", "Start", "End", "Recipient"}
-		var rows [][]string
-		for _, p := range resp.Data.OnCallParticipants {
-			rows = append(rows, []string{
-				resp.Data.ScheduleRef.Name,
-				p.OnCallStart,
-				p.OnCallEnd,
-				p.Name,
-			})
+		return output.RenderTable(headers, onCallRows(data), data, opts)
+	},
+}
+
+var onCallWhoamiCmd = &cobra.Command{
+	Use:   "whoami",
+	Short: "Show the schedules a user is currently on-call for",
+	Long: `Show the schedules a user is currently on-call for.
+
+The user defaults to the OPSGENIE_USER environment variable, then "user" in
+the config file, since API keys are not tied to a specific user.`,
+	Example: `  # Am I on-call right now?
+  OPSGENIE_USER=alice@example.com opsgenie-cli oncall whoami
+
+  # Check another user at a specific time
+  opsgenie-cli oncall whoami --user bob@example.com --date 2024-01-15T03:00:00Z`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		user, _ := cmd.Flags().GetString("user")
+		if user == "" {
+			var err error
+			if user, err = auth.User(); err != nil {
+				return err
+			}
+		}
+		if user == "" {
+			return fmt.Errorf("--user is required (or set OPSGENIE_USER)")
+		}
+
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		params, err := onCallParams(cmd)
+		if err != nil {
+			return err
 		}
-		return output.RenderTable(headers, rows, resp.Data, opts)
+		params.Set("flat", "true")
+
+		var data []api.OnCallResponse
+		if err := client.GetWithParams("/v2/schedules/on-calls", params, &data); err != nil {
+			return err
+		}
+
+		var matched []api.TeamRef
+		for _, r := range data {
+			for _, p := range r.Participants() {
+				if strings.EqualFold(p.Name, user) {
+					matched = append(matched, r.ScheduleRef)
+					break
+				}
+			}
+		}
+
+		if opts.Structured() {
+			return output.RenderJSON(map[string]interface{}{
+				"user":      user,
+				"onCall":    len(matched) > 0,
+				"schedules": matched,
+			}, opts)
+		}
+
+		if len(matched) == 0 {
+			fmt.Fprintf(os.Stderr, "%s is not on-call\n", user)
+			return nil
+		}
+
+		headers := []string{"Schedule", "ID"}
+		rows := make([][]string, len(matched))
+		for i, s := range matched {
+			rows[i] = []string{s.Name, s.ID}
+		}
+		return output.RenderTable(headers, rows, matched, opts)
 	},
 }
 
 func init() {
 	onCallGetCmd.Flags().String("schedule", "", "Schedule ID or name (required)")
 	onCallGetCmd.Flags().Bool("flat", false, "Return a flat list of on-call participants")
+	onCallGetCmd.Flags().String("date", "", "Point in time to query ("+timeFormats+"; default now)")
 	addOutputFlags(onCallGetCmd)
 
 	onCallNextCmd.Flags().String("schedule", "", "Schedule ID or name (required)")
 	onCallNextCmd.Flags().Bool("flat", false, "Return a flat list of on-call participants")
+	onCallNextCmd.Flags().String("date", "", "Point in time to query ("+timeFormats+"; default now)")
 	addOutputFlags(onCallNextCmd)
 
+	onCallListCmd.Flags().Bool("flat", false, "Return a flat list of on-call participants")
+	onCallListCmd.Flags().String("date", "", "Point in time to query ("+timeFormats+"; default now)")
+	addOutputFlags(onCallListCmd)
+
+	onCallWhoamiCmd.Flags().String("user", "", "Username (email) to check (default $OPSGENIE_USER)")
+	onCallWhoamiCmd.Flags().String("date", "", "Point in time to query ("+timeFormats+"; default now)")
+	addOutputFlags(onCallWhoamiCmd)
+
 	onCallCmd.AddCommand(onCallGetCmd)
 	onCallCmd.AddCommand(onCallNextCmd)
+	onCallCmd.AddCommand(onCallListCmd)
+	onCallCmd.AddCommand(onCallWhoamiCmd)
 
 	rootCmd.AddCommand(onCallCmd)
 }
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// ─── on-call for-team ────────────────────────────────────────────────────────
+
+// teamOnCall is who is on call for one of a team's schedules, and how the
+// schedule was found: through a routing rule or by ownership.
+type teamOnCall struct {
+	Schedule api.TeamRef             `json:"schedule"`
+	Via      []string                `json:"via"`
+	OnCall   []api.OnCallParticipant `json:"onCall"`
+}
+
+var onCallForTeamCmd = &cobra.Command{
+	Use:   "for-team <team>",
+	Short: "Show who is on call for a team",
+	Long: `Show who is on call for a team, given by name or ID.
+
+The team's schedules are those its routing rules notify and those it owns.
+Each is queried for its current on-call participants, and the VIA column
+shows how the schedule was found.`,
+	Example: `  # Who do I page for platform?
+  opsgenie-cli oncall for-team platform
+
+  # Who was on call for platform at 3am, as JSON
+  opsgenie-cli oncall for-team platform --date 2024-01-15T03:00:00Z --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		id, err := client.ResolveID("team", args[0])
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		var team struct {
+			Data api.TeamResponse `json:"data"`
+		}
+		if err := client.Get("/v2/teams/"+url.PathEscape(id), &team); err != nil {
+			return err
+		}
+
+		var found []*teamOnCall
+		byKey := map[string]*teamOnCall{}
+		add := func(ref api.TeamRef, via string) {
+			key := ref.ID
+			if key == "" {
+				key = ref.Name
+			}
+			if key == "" {
+				return
+			}
+			t, ok := byKey[key]
+			if !ok {
+				t = &teamOnCall{Schedule: ref}
+				byKey[key] = t
+				found = append(found, t)
+			}
+			t.Via = append(t.Via, via)
+		}
+
+		var rules struct {
+			Data []api.TeamRoutingRuleResponse `json:"data"`
+		}
+		if err := client.Get("/v2/teams/"+team.Data.ID+"/routing-rules", &rules); err != nil {
+			return fmt.Errorf("routing rules: %w", err)
+		}
+		for _, r := range rules.Data {
+			notify, _ := r.Notify.(map[string]interface{})
+			if stringVal(notify, "type") == "schedule" {
+				add(api.TeamRef{ID: stringVal(notify, "id"), Name: stringVal(notify, "name")}, "rule: "+r.Name)
+			}
+		}
+
+		var schedules struct {
+			Data []api.ScheduleResponse `json:"data"`
+		}
+		if err := client.Get("/v2/schedules", &schedules); err != nil {
+			return fmt.Errorf("schedules: %w", err)
+		}
+		for _, s := range schedules.Data {
+			if s.OwnerTeam != nil && (s.OwnerTeam.ID == team.Data.ID || strings.EqualFold(s.OwnerTeam.Name, team.Data.Name)) {
+				add(api.TeamRef{ID: s.ID, Name: s.Name}, "owner")
+			}
+		}
+		if len(found) == 0 {
+			return fmt.Errorf("team %s has no schedules in its routing rules and owns none", team.Data.Name)
+		}
+
+		params, err := onCallParams(cmd)
+		if err != nil {
+			return err
+		}
+		onCalls := make([]api.OnCallResponse, len(found))
+		errs := client.Fanout(len(found), func(i int) error {
+			id := found[i].Schedule.ID
+			if id == "" {
+				id = found[i].Schedule.Name
+			}
+			return client.GetWithParams("/v2/schedules/"+url.PathEscape(id)+"/on-calls", params, &onCalls[i])
+		})
+		for i, t := range found {
+			if errs[i] != nil {
+				return fmt.Errorf("schedule %s: %w", t.Schedule.Name, errs[i])
+			}
+			data := onCalls[i]
+			if t.Schedule.Name == "" {
+				t.Schedule.Name = data.ScheduleRef.Name
+			}
+			t.OnCall = data.Participants()
+			if t.OnCall == nil {
+				t.OnCall = []api.OnCallParticipant{}
+			}
+		}
+
+		if opts.Structured() || opts.Template != "" || opts.JQExpr != "" {
+			return output.RenderJSON(map[string]interface{}{"team": team.Data.Name, "schedules": found}, opts)
+		}
+
+		headers := []string{"Schedule", "Via", "Start", "End", "Recipient"}
+		var rows [][]string
+		for _, t := range found {
+			via := strings.Join(t.Via, ", ")
+			if len(t.OnCall) == 0 {
+				rows = append(rows, []string{t.Schedule.Name, via, "", "", "(nobody)"})
+			}
+			for _, p := range t.OnCall {
+				rows = append(rows, []string{t.Schedule.Name, via, p.OnCallStart, p.OnCallEnd, p.Name})
+			}
+		}
+		return output.RenderTable(headers, rows, found, opts)
+	},
+}
+
+func init() {
+	onCallForTeamCmd.Flags().String("date", "", "Point in time to query ("+timeFormats+"; default now)")
+	addOutputFlags(onCallForTeamCmd)
+
+	onCallCmd.AddCommand(onCallForTeamCmd)
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/roboalchemist/opsgenie-cli/pkg/weblink"
+	"github.com/spf13/cobra"
+)
+
+var openPrint bool
+
+var openCmd = &cobra.Command{
+	Use:   "open <alert|incident|team|schedule> <id>",
+	Short: "Open a resource in the OpsGenie web UI",
+	Long: `Open a resource in the OpsGenie web UI in the default browser.
+
+The account's web address is derived from the account name and --region;
+set OPSGENIE_WEB_URL to override it (e.g. for a custom domain).`,
+	Example: `  # Jump from triage to the alert page
+  opsgenie-cli open alert <alert-id>
+
+  # Print the link instead, e.g. to paste into Slack
+  opsgenie-cli open incident <incident-id> --print`,
+	Args:      cobra.ExactArgs(2),
+	ValidArgs: weblink.Kinds(),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		link, err := webLink(client, strings.ToLower(args[0]), args[1])
+		if err != nil {
+			return err
+		}
+
+		if opts.Structured() {
+			return output.RenderJSON(map[string]string{"url": link}, opts)
+		}
+		if openPrint {
+			fmt.Println(link)
+			return nil
+		}
+		if err := openBrowser(link); err != nil {
+			return fmt.Errorf("open browser (use --print to show the link): %w", err)
+		}
+		output.Success("Opened "+link, opts)
+		return nil
+	},
+}
+
+// webLink returns the web UI link for a resource, looking up the account
+// name unless OPSGENIE_WEB_URL is set.
+func webLink(client *api.Client, kind, id string) (string, error) {
+	if _, err := weblink.URL("", kind, id); err != nil {
+		return "", err
+	}
+	account := ""
+	if os.Getenv(weblink.EnvBaseURL) == "" {
+		var envelope api.APIResponse[api.AccountResponse]
+		if err := client.Get("/v2/account", &envelope); err != nil {
+			return "", fmt.Errorf("look up account name: %w", err)
+		}
+		account = envelope.Data.Name
+	}
+	return weblink.URL(weblink.BaseURL(account, flagRegion), kind, id)
+}
+
+// openBrowser opens url with the platform's default handler.
+func openBrowser(url string) error {
+	var c *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		c = exec.Command("open", url)
+	case "windows":
+		c = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		c = exec.Command("xdg-open", url)
+	}
+	return c.Start()
+}
+
+func init() {
+	openCmd.Flags().BoolVar(&openPrint, "print", false, "Print the link instead of opening a browser")
+	addOutputFlags(openCmd)
+
+	rootCmd.AddCommand(openCmd)
+}
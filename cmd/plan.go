@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/roboalchemist/opsgenie-cli/pkg/plan"
+	"github.com/spf13/cobra"
+)
+
+// ─── --plan / execute-plan ───────────────────────────────────────────────────
+
+// addPlanFlags adds --plan and --plan-file to a composite command.
+func addPlanFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("plan", false, "Show the API writes as an ordered plan instead of making them")
+	cmd.Flags().String("plan-file", "", "Save the plan to this file for execute-plan (implies --plan)")
+}
+
+// planRecorder returns a recorder to pass in place of the client when
+// --plan or --plan-file is given, and nil otherwise.
+func planRecorder(cmd *cobra.Command) *plan.Recorder {
+	planOnly, _ := cmd.Flags().GetBool("plan")
+	file, _ := cmd.Flags().GetString("plan-file")
+	if !planOnly && file == "" {
+		return nil
+	}
+	return plan.NewRecorder(cmd.CommandPath())
+}
+
+// renderPlan shows a recorded plan and saves it to --plan-file if given.
+func renderPlan(cmd *cobra.Command, rec *plan.Recorder, opts output.Options) error {
+	p := rec.Plan
+	file, _ := cmd.Flags().GetString("plan-file")
+	if file != "" {
+		if err := plan.Write(file, p); err != nil {
+			return err
+		}
+	}
+	if opts.Structured() || opts.Template != "" || opts.JQExpr != "" {
+		if err := output.RenderJSON(p, opts); err != nil {
+			return err
+		}
+	} else {
+		headers := []string{"Step", "Method", "Path", "Summary"}
+		rows := make([][]string, len(p.Steps))
+		for i, s := range p.Steps {
+			rows[i] = []string{strconv.Itoa(i + 1), s.Method, s.Path, s.Summary}
+		}
+		if err := output.RenderTable(headers, rows, p.Steps, opts); err != nil {
+			return err
+		}
+	}
+	if file != "" {
+		output.Success(fmt.Sprintf("Plan with %d step(s) written to %s; review it, then run: opsgenie-cli execute-plan %s", len(p.Steps), file, file), opts)
+	}
+	return nil
+}
+
+var executePlanCmd = &cobra.Command{
+	Use:         "execute-plan <file>",
+	Short:       "Execute a plan saved with --plan-file",
+	Annotations: map[string]string{timeoutAnnotation: "2m"},
+	Long: `Execute a plan saved by a composite command's --plan-file, step by step
+and exactly as written, so a change can be reviewed before it is made.
+
+Placeholders such as {step:2} stand for the ID created by an earlier step
+and are filled in as the plan runs. The live configuration is not checked
+again: execute a plan soon after it was made, or make it again. Execution
+stops at the first failed step; the steps before it are not rolled back.
+
+Commands that accept --plan: apply, heartbeats apply, schedules enable, schedules disable.`,
+	Example: `  # Review, then execute
+  opsgenie-cli apply --dir ./backup --plan-file change-42.json
+  opsgenie-cli execute-plan change-42.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		p, err := plan.Load(args[0])
+		if err != nil {
+			return err
+		}
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		cw := &countingWriter{Client: client, phase: "execute"}
+		for _, s := range p.Steps {
+			cw.items = append(cw.items, s.Summary)
+		}
+		results, execErr := plan.Execute(cw, p)
+		headers := []string{"Step", "Method", "Path", "Summary", "ID"}
+		rows := make([][]string, len(results))
+		for i, r := range results {
+			rows[i] = []string{strconv.Itoa(i + 1), r.Method, r.Path, r.Summary, r.ID}
+		}
+		if err := output.RenderTable(headers, rows, results, opts); err != nil {
+			return err
+		}
+		var sum output.Summary
+		for i, s := range p.Steps {
+			switch {
+			case i < len(results):
+				sum.Succeed()
+			case i == len(results) && execErr != nil:
+				sum.Fail(s.Path)
+			default:
+				sum.Skip()
+			}
+		}
+		if err := output.RenderSummary(sum, opts); err != nil {
+			return err
+		}
+		if execErr != nil {
+			return fmt.Errorf("%w (%d of %d step(s) executed)", execErr, len(results), len(p.Steps))
+		}
+		output.Success(fmt.Sprintf("Executed %d step(s) of %s", len(results), p.Command), opts)
+		return nil
+	},
+}
+
+func init() {
+	addOutputFlags(executePlanCmd)
+
+	rootCmd.AddCommand(executePlanCmd)
+}
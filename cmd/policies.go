@@ -2,7 +2,9 @@ package cmd
 
 import (
 	"fmt"
+	"net/http"
 
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
 	"github.com/roboalchemist/opsgenie-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
@@ -17,6 +19,9 @@ func init() {
 	policiesCmd.AddCommand(policiesEnableCmd)
 	policiesCmd.AddCommand(policiesDisableCmd)
 
+	policiesCmd.PersistentFlags().String("api-version", "auto", "Policy API to use: auto (v1, falling back to v2), v1 or v2")
+	policiesCmd.PersistentFlags().String("team", "", "Team ID or name for team-scoped policies (v2 only)")
+
 	addOutputFlags(policiesListCmd)
 	addOutputFlags(policiesGetCmd)
 	addOutputFlags(policiesCreateCmd)
@@ -37,33 +42,120 @@ func init() {
 var policiesCmd = &cobra.Command{
 	Use:   "policies",
 	Short: "Manage OpsGenie alert and notification policies",
-	Long:  "Create, list, and manage alert and notification policies. Note: v1 endpoints, deprecated but functional.",
+	Long: `Create, list, and manage alert and notification policies.
+
+These commands use the legacy v1 endpoints. Where v1 is not available to the
+account (it answers 403, 404 or 410) they fall back to the v2 endpoints;
+--api-version v1 or v2 skips the fallback. --team works with the team-scoped
+v2 policies and implies v2.`,
+	Deprecated: "use alert-policies or notification-policies, which support team-scoped v2 policies",
+}
+
+// withPolicyAPI runs call against the policy API chosen by --api-version:
+// v1 or v2 as asked, or in auto mode v1, retried on v2 if the account
+// cannot use v1.
+func withPolicyAPI(cmd *cobra.Command, call func(v2 bool) error) error {
+	version, _ := cmd.Flags().GetString("api-version")
+	team, _ := cmd.Flags().GetString("team")
+	switch version {
+	case "v1":
+		if team != "" {
+			return fmt.Errorf("--team needs the v2 policy API (use --api-version v2)")
+		}
+		return call(false)
+	case "v2":
+		return call(true)
+	case "auto":
+		if team != "" {
+			return call(true)
+		}
+	default:
+		return fmt.Errorf("invalid --api-version %q (valid: auto, v1, v2)", version)
+	}
+
+	err := call(false)
+	switch api.StatusCode(err) {
+	case http.StatusForbidden, http.StatusNotFound, http.StatusGone:
+	default:
+		return err
+	}
+	if err2 := call(true); err2 != nil {
+		return fmt.Errorf("%w (v1 policy API: %v)", err2, err)
+	}
+	return nil
+}
+
+// policyPath returns the path of a policy (or of the policies, if id is
+// empty) on the v1 or v2 API.
+func policyPath(cmd *cobra.Command, v2 bool, id, suffix string) string {
+	p := "/v1/policies"
+	if v2 {
+		p = "/v2/policies"
+	}
+	if id != "" {
+		p += "/" + id
+	}
+	p += suffix
+	if v2 {
+		p += policyQuery(cmd)
+	}
+	return p
 }
 
 var policiesListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all policies",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
+		if err := resolveTeamFlag(cmd, client); err != nil {
+			return err
+		}
 		opts := getOutputOpts()
 
-		var resp struct {
-			Data []map[string]interface{} `json:"data"`
-		}
-		if err := client.Get("/v1/policies", &resp); err != nil {
+		var policies []map[string]interface{}
+		err = withPolicyAPI(cmd, func(v2 bool) error {
+			policies = nil
+			if !v2 {
+				var resp struct {
+					Data []map[string]interface{} `json:"data"`
+				}
+				if err := client.Get(policyPath(cmd, false, "", ""), &resp); err != nil {
+					return err
+				}
+				policies = resp.Data
+				return nil
+			}
+			// v2 lists each policy type separately.
+			for _, pType := range []string{"alert", "notification"} {
+				var resp struct {
+					Data []map[string]interface{} `json:"data"`
+				}
+				if err := client.Get(policyPath(cmd, true, pType, ""), &resp); err != nil {
+					return err
+				}
+				for _, p := range resp.Data {
+					if _, ok := p["type"]; !ok {
+						p["type"] = pType
+					}
+					policies = append(policies, p)
+				}
+			}
+			return nil
+		})
+		if err != nil {
 			return err
 		}
 
-		if opts.Mode == output.ModeJSON {
-			return output.RenderJSON(resp.Data, opts)
+		if opts.Structured() {
+			return output.RenderJSON(policies, opts)
 		}
 
 		headers := []string{"ID", "NAME", "TYPE", "ENABLED"}
-		rows := make([][]string, 0, len(resp.Data))
-		for _, p := range resp.Data {
+		rows := make([][]string, 0, len(policies))
+		for _, p := range policies {
 			rows = append(rows, []string{
 				stringVal(p, "id"),
 				stringVal(p, "name"),
@@ -71,7 +163,7 @@ var policiesListCmd = &cobra.Command{
 				stringVal(p, "enabled"),
 			})
 		}
-		return output.RenderTable(headers, rows, resp.Data, opts)
+		return output.RenderTable(headers, rows, policies, opts)
 	},
 }
 
@@ -80,20 +172,26 @@ var policiesGetCmd = &cobra.Command{
 	Short: "Get a policy by ID",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
+		if err := resolveTeamFlag(cmd, client); err != nil {
+			return err
+		}
 		opts := getOutputOpts()
 
 		var resp struct {
 			Data map[string]interface{} `json:"data"`
 		}
-		if err := client.Get("/v1/policies/"+args[0], &resp); err != nil {
+		err = withPolicyAPI(cmd, func(v2 bool) error {
+			return client.Get(policyPath(cmd, v2, args[0], ""), &resp)
+		})
+		if err != nil {
 			return err
 		}
 
-		if opts.Mode == output.ModeJSON {
+		if opts.Structured() {
 			return output.RenderJSON(resp.Data, opts)
 		}
 
@@ -112,10 +210,13 @@ var policiesCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a new policy",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
+		if err := resolveTeamFlag(cmd, client); err != nil {
+			return err
+		}
 		opts := getOutputOpts()
 
 		name, _ := cmd.Flags().GetString("name")
@@ -129,7 +230,18 @@ var policiesCreateCmd = &cobra.Command{
 		}
 
 		var result map[string]interface{}
-		if err := client.Post("/v1/policies", body, &result); err != nil {
+		err = withPolicyAPI(cmd, func(v2 bool) error {
+			if v2 {
+				// v2 requires a filter, and alert policies a message; match
+				// every alert and keep its message, as alert-policies does.
+				body["filter"] = map[string]interface{}{"type": "match-all"}
+				if pType == "alert" {
+					body["message"] = "{{message}}"
+				}
+			}
+			return client.Post(policyPath(cmd, v2, "", ""), body, &result)
+		})
+		if err != nil {
 			return err
 		}
 
@@ -143,28 +255,54 @@ var policiesUpdateCmd = &cobra.Command{
 	Short: "Update a policy",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
+		if err := resolveTeamFlag(cmd, client); err != nil {
+			return err
+		}
 		opts := getOutputOpts()
 
-		body := map[string]interface{}{}
+		changes := map[string]interface{}{}
 		if cmd.Flags().Changed("name") {
 			v, _ := cmd.Flags().GetString("name")
-			body["name"] = v
+			changes["name"] = v
 		}
 		if cmd.Flags().Changed("type") {
 			v, _ := cmd.Flags().GetString("type")
-			body["type"] = v
+			changes["type"] = v
 		}
 		if cmd.Flags().Changed("enabled") {
 			v, _ := cmd.Flags().GetBool("enabled")
-			body["enabled"] = v
+			changes["enabled"] = v
 		}
 
 		var result map[string]interface{}
-		if err := client.Put("/v1/policies/"+args[0], body, &result); err != nil {
+		err = withPolicyAPI(cmd, func(v2 bool) error {
+			path := policyPath(cmd, v2, args[0], "")
+			if !v2 {
+				return client.Put(path, changes, &result)
+			}
+			// v2 replaces the whole policy, so start from the current one.
+			var current struct {
+				Data map[string]interface{} `json:"data"`
+			}
+			if err := client.Get(path, &current); err != nil {
+				return err
+			}
+			body := current.Data
+			if body == nil {
+				body = map[string]interface{}{}
+			}
+			delete(body, "id")
+			delete(body, "order")
+			for k, v := range changes {
+				body[k] = v
+			}
+			return client.Put(path, body, &result)
+		})
+		if err != nil {
 			return err
 		}
 
@@ -178,13 +316,19 @@ var policiesDeleteCmd = &cobra.Command{
 	Short: "Delete a policy",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
+		if err := resolveTeamFlag(cmd, client); err != nil {
+			return err
+		}
 		opts := GetOutputOptions()
 
-		if err := client.Delete("/v1/policies/"+args[0], nil); err != nil {
+		err = withPolicyAPI(cmd, func(v2 bool) error {
+			return client.Delete(policyPath(cmd, v2, args[0], ""), nil)
+		})
+		if err != nil {
 			return err
 		}
 
@@ -198,13 +342,19 @@ var policiesEnableCmd = &cobra.Command{
 	Short: "Enable a policy",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
+		if err := resolveTeamFlag(cmd, client); err != nil {
+			return err
+		}
 		opts := GetOutputOptions()
 
-		if err := client.Post("/v1/policies/"+args[0]+"/enable", nil, nil); err != nil {
+		err = withPolicyAPI(cmd, func(v2 bool) error {
+			return client.Post(policyPath(cmd, v2, args[0], "/enable"), nil, nil)
+		})
+		if err != nil {
 			return err
 		}
 
@@ -218,13 +368,19 @@ var policiesDisableCmd = &cobra.Command{
 	Short: "Disable a policy",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
+		if err := resolveTeamFlag(cmd, client); err != nil {
+			return err
+		}
 		opts := GetOutputOptions()
 
-		if err := client.Post("/v1/policies/"+args[0]+"/disable", nil, nil); err != nil {
+		err = withPolicyAPI(cmd, func(v2 bool) error {
+			return client.Post(policyPath(cmd, v2, args[0], "/disable"), nil, nil)
+		})
+		if err != nil {
 			return err
 		}
 
@@ -38,7 +38,7 @@ var postmortemsGetCmd = &cobra.Command{
 	Short: "Get a postmortem by ID",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -51,7 +51,7 @@ var postmortemsGetCmd = &cobra.Command{
 			return err
 		}
 
-		if opts.Mode == output.ModeJSON {
+		if opts.Structured() {
 			return output.RenderJSON(resp.Data, opts)
 		}
 
@@ -70,7 +70,7 @@ var postmortemsCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a postmortem",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -97,7 +97,7 @@ var postmortemsUpdateCmd = &cobra.Command{
 	Short: "Update a postmortem",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -128,7 +128,7 @@ var postmortemsDeleteCmd = &cobra.Command{
 	Short: "Delete a postmortem",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
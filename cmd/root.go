@@ -1,11 +1,22 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+	"github.com/roboalchemist/opsgenie-cli/pkg/audit"
 	"github.com/roboalchemist/opsgenie-cli/pkg/auth"
+	"github.com/roboalchemist/opsgenie-cli/pkg/mockserver"
 	"github.com/roboalchemist/opsgenie-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
@@ -16,51 +27,169 @@ var appVersion = "dev"
 var (
 	flagJSON      bool
 	flagPlaintext bool
+	flagYAML      bool
+	flagNDJSON    bool
+	flagOutput    string
 	flagNoColor   bool
+	flagWide      bool
 	flagDebug     bool
+	flagDebugFile string
 	flagVerbose   bool
 	flagQuiet     bool
 	flagRegion    string
+	flagProfile   string
+	flagRateLimit float64
+
+	flagNonInteractive bool
+	flagConcurrency    int
+	flagProgress       string
+	flagNoWait         bool
+	flagWaitTimeout    = durationFlag(30 * time.Second)
+	flagTimeout        durationFlag
+	flagCacheTTL       durationFlag
+	flagNoCache        bool
+	flagMock           bool
+	flagProxy          string
+	flagCACert         string
+	flagInsecure       bool
 )
 
+// timeoutAnnotation is the command annotation giving commands that move a
+// lot of data, and their subcommands, a longer default request timeout,
+// e.g. "2m". --timeout and OPSGENIE_TIMEOUT still take precedence.
+const timeoutAnnotation = "timeout"
+
+// commandTimeout is the running command's default request timeout from
+// timeoutAnnotation, or 0 for the client's.
+var commandTimeout time.Duration
+
+// acceptedRequests collects the IDs of async requests not waited for under
+// --no-wait, for Execute to print when the command ends.
+var acceptedRequests struct {
+	sync.Mutex
+	ids []string
+}
+
 var rootCmd = &cobra.Command{
 	Use:           "opsgenie-cli",
 	Short:         "CLI for the OpsGenie REST API v2",
 	Long: `opsgenie-cli — CLI for the OpsGenie REST API v2.
 
 Manage alerts, incidents, teams, schedules, on-call rotations, heartbeats,
-and more. All commands support --json output for scripting and agent use.
+and more. All commands support --json (or --yaml) output for scripting and
+agent use.
 
 Environment Variables:
   OPSGENIE_API_KEY    API key for authentication (required)
   OPSGENIE_API_URL    Override the API base URL (default: https://api.opsgenie.com)
+  OPSGENIE_PROFILE    Profile of the config file to use (same as --profile)
+  OPSGENIE_USER       Your username, for "oncall whoami", "notify-bridge" and
+                      "forwarding-rules cover" (else "user" in the config file)
+  OPSGENIE_WEB_URL    Override the web UI address used by "open"
+  OPSGENIE_SMTP_PASSWORD  SMTP password for --notify smtp://user@host
+  OPSGENIE_AUDIT_LOG  Append every API request to this file (see "report api-usage")
+  OPSGENIE_TEAM       Team label recorded in the audit log
+  OPSGENIE_JOB        Job label recorded in the audit log, e.g. a cron job's name
+  OPSGENIE_NON_INTERACTIVE  Same as --non-interactive when set
+  OPSGENIE_DEFAULT_RESPONDERS  Responders added by "alerts create", e.g. team:ops
+  OPSGENIE_CACHE_TTL  Default for --cache-ttl, e.g. 60s
+  OPSGENIE_TIMEOUT    Default for --timeout, e.g. 10s
+  HTTPS_PROXY, HTTP_PROXY, NO_PROXY  Proxy for API requests, unless --proxy is given
+  OPSGENIE_MOCK       Same as --mock when set: built-in sample data, no API key
+  OPSGENIE_TIMEZONE   Zone for time flags without an offset, e.g. Europe/Berlin
   NO_COLOR            Disable colored output when set
+  OPSGENIE_COLORS     Table cell colors, e.g. priority.P1=magenta,status.open=none
+  COLUMNS             Terminal width tables are truncated to, if not the real one
 
 Files:
   ~/.opsgenie-cli-auth.json    Stored authentication credentials (mode 0600)
+                               and default_responders for "alerts create";
+                               "profiles" names further accounts' keys;
+                               "region" or "api_url" picks the API host;
+                               "colors" sets table cell colors
 
 Exit Status:
   0   Success
-  1   User or correctable error
-  2   Usage error
+  1   Any other error, or any item of a multi-item operation failed
+  2   Usage error: unknown command or flag, or a bad argument or flag value
+  3   Authentication failed: no API key, or the key was rejected (401/403)
+  4   Not found (404)
+  5   Rate limited (429) after all retries
+  6   Async request failed, or did not complete within --wait-timeout
+  75  "heartbeats ping" only: a transient failure worth retrying later
+      (unreachable, timed out, rate limited or failing; see its help)
 
 Report bugs to: https://github.com/roboalchemist/opsgenie-cli/issues
 Home page: https://github.com/roboalchemist/opsgenie-cli`,
 	Version:       appVersion,
 	SilenceUsage:  true,
 	SilenceErrors: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		auditCommand = strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+		for c := cmd; c != nil; c = c.Parent() {
+			if v, ok := c.Annotations[timeoutAnnotation]; ok {
+				d, err := parseDuration(v)
+				if err != nil {
+					return fmt.Errorf("%s: bad %s annotation: %w", c.CommandPath(), timeoutAnnotation, err)
+				}
+				commandTimeout = d
+				break
+			}
+		}
+		output.SetNonInteractive(flagNonInteractive || os.Getenv("OPSGENIE_NON_INTERACTIVE") != "")
+		if err := output.SetProgress(flagProgress); err != nil {
+			return output.Invalid(err)
+		}
+		if flagOutput != "" {
+			if _, err := output.ParseMode(flagOutput); err != nil {
+				return output.Invalid(err)
+			}
+		}
+		if err := setupLogger(os.Stderr); err != nil {
+			return output.Invalid(err)
+		}
+		auth.SetProfile(flagProfile)
+		output.SetCellColorSource(auth.Colors)
+		return nil
+	},
 }
 
 func init() {
 	pf := rootCmd.PersistentFlags()
 	pf.BoolVarP(&flagJSON, "json", "j", false, "JSON output")
 	pf.BoolVarP(&flagPlaintext, "plaintext", "p", false, "Tab-separated output for piping")
+	pf.BoolVar(&flagYAML, "yaml", false, "YAML output")
+	pf.BoolVar(&flagNDJSON, "ndjson", false, "Newline-delimited JSON output, one object per line")
+	pf.StringVarP(&flagOutput, "output", "o", "", "Output format: table, plaintext, json, yaml, csv, tsv, id or ndjson")
 	pf.BoolVar(&flagNoColor, "no-color", false, "Disable colored output")
+	pf.BoolVar(&flagWide, "wide", false, "Show table cells in full instead of truncating them to the terminal width")
+	pf.BoolVar(&flagWide, "no-trunc", false, "Show table cells in full (synonym for --wide)")
 	pf.BoolVarP(&flagVerbose, "verbose", "v", false, "Print more information about progress")
-	pf.BoolVar(&flagDebug, "debug", false, "Verbose debug logging to stderr")
-	pf.BoolVarP(&flagQuiet, "quiet", "q", false, "Suppress progress output")
-	pf.BoolVar(&flagQuiet, "silent", false, "Suppress progress output (synonym for --quiet)")
-	pf.StringVar(&flagRegion, "region", "us", "OpsGenie region (us or eu)")
+	pf.BoolVar(&flagDebug, "debug", false, "Verbose debug logging to stderr (same as --log-level debug)")
+	pf.StringVar(&flagLogLevel, "log-level", "warn", "Log diagnostics to stderr at this level and above: debug, info, warn or error")
+	pf.StringVar(&flagLogFormat, "log-format", "text", "Format of diagnostic logs: text, or json for one object per line")
+	pf.StringVar(&flagDebugFile, "debug-file", "", "Append full request/response transcripts to this file, secrets redacted")
+	pf.BoolVarP(&flagQuiet, "quiet", "q", false, "Suppress progress and success messages; without another output format, print only IDs")
+	pf.BoolVar(&flagQuiet, "silent", false, "Synonym for --quiet")
+	pf.StringVar(&flagRegion, "region", "us", "OpsGenie region (us, eu or sandbox) or API URL; default from the config file")
+	pf.StringVar(&flagProfile, "profile", "", "Use this profile of the config file (default $OPSGENIE_PROFILE)")
+	pf.Float64Var(&flagRateLimit, "rate-limit", 0, "Max API requests per second (0 = follow X-RateLimit headers, -1 = off)")
+	pf.IntVar(&flagConcurrency, "concurrency", 4, "Max concurrent lookups in reports and other fan-out commands (lowered when the rate limit runs low)")
+	pf.StringVar(&flagProgress, "progress", "none", "Progress reporting for long operations: none, or json for NDJSON events on stderr")
+	pf.BoolVar(&flagNonInteractive, "non-interactive", false, "Never prompt, page, redraw or color (for CI and scripts)")
+	pf.BoolVar(&flagNoWait, "no-wait", false, "Don't wait for asynchronous (202 Accepted) requests; print their request IDs as JSON")
+	pf.Var(&flagTimeout, "timeout", "Time limit for each API request, e.g. 10s (default $OPSGENIE_TIMEOUT, else 30s, or longer for export and other bulk commands)")
+	pf.Var(&flagWaitTimeout, "wait-timeout", "How long to wait for an asynchronous request to complete (default 30s)")
+	pf.Var(&flagCacheTTL, "cache-ttl", "Cache GET responses on disk for this long, e.g. 60s (default $OPSGENIE_CACHE_TTL, else off)")
+	pf.BoolVar(&flagNoCache, "no-cache", false, "Don't read or write the response cache")
+	pf.StringVar(&flagProxy, "proxy", "", "Proxy URL for API requests, overriding HTTPS_PROXY/HTTP_PROXY (\"none\" to ignore them)")
+	pf.StringVar(&flagCACert, "ca-cert", "", "PEM file of extra CA certificates to trust, e.g. a TLS-intercepting proxy's")
+	pf.BoolVar(&flagInsecure, "insecure-skip-verify", false, "Don't verify the API's TLS certificate (diagnosis only)")
+	pf.BoolVar(&flagMock, "mock", false, "Answer from built-in sample data instead of the API; no API key needed (also OPSGENIE_MOCK=1)")
+
+	rootCmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return output.Invalid(err)
+	})
 
 	rootCmd.SetVersionTemplate(`{{.Name}} {{.Version}}
 Copyright © 2026 roboalchemist
@@ -72,35 +201,138 @@ License MIT: <https://opensource.org/licenses/MIT>
 func GetOutputOptions() output.Options {
 	opts := output.Options{
 		NoColor: flagNoColor,
+		Wide:    flagWide,
 		Debug:   flagDebug || flagVerbose,
 		Quiet:   flagQuiet,
 	}
 	switch {
+	case flagOutput != "":
+		opts.Mode, _ = output.ParseMode(flagOutput)
 	case flagJSON:
 		opts.Mode = output.ModeJSON
+	case flagYAML:
+		opts.Mode = output.ModeYAML
+	case flagNDJSON:
+		opts.Mode = output.ModeNDJSON
 	case flagPlaintext:
 		opts.Mode = output.ModePlaintext
+	case flagQuiet:
+		// Quiet output is meant for pipelines: identifiers only.
+		opts.Mode = output.ModeID
 	default:
 		opts.Mode = output.ModeTable
 	}
 	return opts
 }
 
-// IsJSON returns true if --json mode is active (used by main.go for structured error output).
-func IsJSON() bool {
-	return flagJSON
-}
-
 // GetRegion returns the configured region flag value.
 func GetRegion() string {
 	return flagRegion
 }
 
-// Execute runs the root command.
+// Execute runs the root command. An interrupt or SIGTERM cancels the
+// command's context, aborting in-flight API requests.
 func Execute() error {
-	return rootCmd.Execute()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	markUsageErrors(rootCmd)
+	err := rootCmd.ExecuteContext(ctx)
+	printAcceptedRequests()
+	if err != nil && strings.HasPrefix(err.Error(), "unknown command ") {
+		err = output.Invalid(err)
+	}
+	if err != nil && ctx.Err() != nil && errors.Is(err, context.Canceled) {
+		return fmt.Errorf("interrupted")
+	}
+	return err
+}
+
+// markUsageErrors makes the argument checks of c and its subcommands
+// return usage errors, for exit status 2.
+func markUsageErrors(c *cobra.Command) {
+	if check := c.Args; check != nil {
+		c.Args = func(cmd *cobra.Command, args []string) error {
+			return output.Invalid(check(cmd, args))
+		}
+	}
+	for _, sub := range c.Commands() {
+		markUsageErrors(sub)
+	}
+}
+
+// printAcceptedRequests writes a JSON object with the request ID of each
+// async request accepted under --no-wait, one per line, and says how to
+// check on them.
+func printAcceptedRequests() {
+	acceptedRequests.Lock()
+	defer acceptedRequests.Unlock()
+	if len(acceptedRequests.ids) == 0 {
+		return
+	}
+	enc := json.NewEncoder(os.Stdout)
+	for _, id := range acceptedRequests.ids {
+		_ = enc.Encode(map[string]string{"requestId": id})
+	}
+	output.Success(fmt.Sprintf("Not waiting for %d accepted request(s); check on them with: opsgenie-cli alerts request-status <requestId>", len(acceptedRequests.ids)), GetOutputOptions())
+	acceptedRequests.ids = nil
+}
+
+// exitError is an error that ends the program with a specific exit status.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+func (e *exitError) Unwrap() error { return e.err }
+
+// Exit statuses by failure class, for commands that do not choose their
+// own with an exitError.
+const (
+	exitGeneric     = 1
+	exitUsage       = 2
+	exitAuth        = 3
+	exitNotFound    = 4
+	exitRateLimited = 5
+	exitAsync       = 6
+)
+
+// ExitCode returns the exit status for an error returned by Execute: the
+// one the command chose, or else the one for the class of failure.
+func ExitCode(err error) int {
+	var e *exitError
+	if errors.As(err, &e) {
+		return e.code
+	}
+	var invalid *output.ValidationError
+	if errors.As(err, &invalid) {
+		return exitUsage
+	}
+	var async *api.AsyncError
+	if errors.As(err, &async) {
+		return exitAsync
+	}
+	switch output.Describe(err).Code {
+	case output.CodeUnauthorized, output.CodeForbidden:
+		return exitAuth
+	case output.CodeNotFound:
+		return exitNotFound
+	case output.CodeRateLimited:
+		return exitRateLimited
+	}
+	return exitGeneric
+}
+
+// authError is a failure to find an API key. Like a rejected key, it is
+// reported with code UNAUTHORIZED and exit status 3.
+type authError struct {
+	err error
 }
 
+func (e *authError) Error() string     { return "authentication failed: " + e.err.Error() }
+func (e *authError) Unwrap() error     { return e.err }
+func (e *authError) ErrorCode() string { return output.CodeUnauthorized }
+
 // SetVersion sets the application version on the root command.
 func SetVersion(v string) {
 	appVersion = v
@@ -112,32 +344,160 @@ func GetRootCmd() *cobra.Command {
 	return rootCmd
 }
 
-// DebugLog logs a debug message to stderr if --debug is set.
-func DebugLog(format string, args ...interface{}) {
-	if flagDebug {
-		fmt.Fprintf(os.Stderr, "[debug] "+format+"\n", args...)
+// auditCommand is the running command's path below the root, recorded in
+// the audit log.
+var auditCommand string
+
+// newClient creates a new OpsGenie API client using the auth chain and global
+// flags. Requests made through it are cancelled when ctx is.
+func newClient(ctx context.Context) (*api.Client, error) {
+	if mockMode() {
+		return newMockClient(ctx)
+	}
+	apiKey, err := auth.GetAPIKey()
+	if err != nil {
+		return nil, &authError{err: err}
+	}
+	if err := resolveRegion(); err != nil {
+		return nil, err
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	client := api.NewClient(apiKey, flagRegion)
+	if flagProxy != "" || flagCACert != "" || flagInsecure {
+		opts := api.TransportOptions{Proxy: flagProxy, CACert: flagCACert, InsecureSkipVerify: flagInsecure}
+		if err := client.SetTransportOptions(opts); err != nil {
+			return nil, output.Invalid(err)
+		}
+	}
+	client.SetRateLimit(flagRateLimit)
+	client.SetConcurrency(flagConcurrency)
+	client.SetWaitTimeout(time.Duration(flagWaitTimeout))
+	if err := applyTimeout(client); err != nil {
+		return nil, err
+	}
+	client.SetDebugFile(flagDebugFile)
+	if flagNoWait {
+		client.SetNoWait(func(requestID string) {
+			acceptedRequests.Lock()
+			acceptedRequests.ids = append(acceptedRequests.ids, requestID)
+			acceptedRequests.Unlock()
+		})
+	}
+	if !flagNoCache {
+		ttl := time.Duration(flagCacheTTL)
+		if env := os.Getenv("OPSGENIE_CACHE_TTL"); ttl == 0 && env != "" {
+			if ttl, err = parseDuration(env); err != nil {
+				return nil, fmt.Errorf("invalid OPSGENIE_CACHE_TTL: %w", err)
+			}
+		}
+		client.SetCache(api.DefaultCacheDir(), ttl)
 	}
+	if path := os.Getenv("OPSGENIE_AUDIT_LOG"); path != "" {
+		team, job := os.Getenv("OPSGENIE_TEAM"), os.Getenv("OPSGENIE_JOB")
+		client.SetObserver(func(method, reqPath string, status int) {
+			e := audit.Entry{Time: time.Now().UTC(), Command: auditCommand, Team: team, Job: job, Method: method, Path: reqPath, Status: status}
+			if err := audit.Append(path, e); err != nil {
+				slog.Warn("cannot write audit log", "path", path, "err", err)
+			}
+		})
+	}
+	return client.WithContext(ctx), nil
 }
 
-// newClient creates a new OpsGenie API client using the auth chain and global flags.
-func newClient() (*api.Client, error) {
-	apiKey, err := auth.GetAPIKey()
+// resolveRegion sets the region from the config file (or the selected
+// profile) unless --region is given, and checks it is a known region or an
+// API URL.
+func resolveRegion() error {
+	if !rootCmd.PersistentFlags().Changed("region") {
+		region, err := auth.Region()
+		if err != nil {
+			return err
+		}
+		if region != "" {
+			flagRegion = region
+		}
+	}
+	if _, err := api.RegionURL(flagRegion); err != nil {
+		return output.Invalid(err)
+	}
+	return nil
+}
+
+// mockMode reports whether requests are answered from built-in fixtures:
+// with --mock, or when OPSGENIE_MOCK is set.
+func mockMode() bool {
+	return flagMock || os.Getenv("OPSGENIE_MOCK") != ""
+}
+
+// newMockClient returns a client whose requests are answered in-process
+// by the fixtures "mock-server" serves. Nothing is cached, so mock
+// responses never mix with real ones.
+func newMockClient(ctx context.Context) (*api.Client, error) {
+	srv, err := mockserver.New(mockserver.Options{})
 	if err != nil {
-		return nil, fmt.Errorf("authentication failed: %w", err)
+		return nil, err
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := resolveRegion(); err != nil {
+		return nil, err
+	}
+	client := api.NewClient("mock", flagRegion)
+	client.SetTransport(srv)
+	client.SetRateLimit(-1)
+	client.SetConcurrency(flagConcurrency)
+	client.SetWaitTimeout(time.Duration(flagWaitTimeout))
+	if err := applyTimeout(client); err != nil {
+		return nil, err
 	}
-	return api.NewClient(apiKey, flagRegion, flagDebug), nil
+	client.SetDebugFile(flagDebugFile)
+	return client.WithContext(ctx), nil
 }
 
-// Global --fields and --jq flags (added to data-returning commands)
+// applyTimeout sets the client's request timeout from --timeout, else
+// OPSGENIE_TIMEOUT, else the running command's timeoutAnnotation, leaving
+// the client's default when none is set.
+func applyTimeout(client *api.Client) error {
+	timeout := time.Duration(flagTimeout)
+	if env := os.Getenv("OPSGENIE_TIMEOUT"); timeout == 0 && env != "" {
+		d, err := parseDuration(env)
+		if err != nil {
+			return fmt.Errorf("invalid OPSGENIE_TIMEOUT: %w", err)
+		}
+		timeout = d
+	}
+	if timeout == 0 {
+		timeout = commandTimeout
+	}
+	if timeout > 0 {
+		client.SetTimeout(timeout)
+	}
+	return nil
+}
+
+// Global --fields, --jq, --template, --columns, --sort-by and --desc flags
+// (added to data-returning commands)
 var (
-	flagFields string
-	flagJQ     string
+	flagFields   string
+	flagJQ       string
+	flagTemplate string
+	flagColumns  string
+	flagSortBy   string
+	flagDesc     bool
 )
 
-// addOutputFlags adds --fields and --jq flags to a command.
+// addOutputFlags adds --fields, --jq, --template, --columns, --sort-by and
+// --desc flags to a command.
 func addOutputFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&flagFields, "fields", "", "Comma-separated list of fields to display (JSON output)")
 	cmd.Flags().StringVar(&flagJQ, "jq", "", "JQ expression to filter JSON output")
+	cmd.Flags().StringVar(&flagTemplate, "template", "", "Go template rendered for each item, e.g. '{{.id}} {{.message}}'")
+	cmd.Flags().StringVar(&flagColumns, "columns", "", "Comma-separated table columns to show, in order (table, CSV and TSV output)")
+	cmd.Flags().StringVar(&flagSortBy, "sort-by", "", "Sort rows by this column or field")
+	cmd.Flags().BoolVar(&flagDesc, "desc", false, "With --sort-by, sort in descending order")
 }
 
 // getOutputOpts returns output options including fields and jq from flags.
@@ -149,6 +509,14 @@ func getOutputOpts() output.Options {
 		}
 	}
 	opts.JQExpr = flagJQ
+	opts.Template = flagTemplate
+	if opts.Mode == output.ModeID && flagOutput == "" && (flagJQ != "" || flagTemplate != "") {
+		// --quiet picked ID output, but --jq and --template ask for more.
+		opts.Mode = output.ModeTable
+	}
+	opts.Columns = splitFields(flagColumns)
+	opts.SortBy = flagSortBy
+	opts.Desc = flagDesc
 	return opts
 }
 
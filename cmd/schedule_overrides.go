@@ -18,7 +18,7 @@ var scheduleOverridesListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List overrides for a schedule",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -28,6 +28,9 @@ var scheduleOverridesListCmd = &cobra.Command{
 		if scheduleID == "" {
 			return fmt.Errorf("--schedule is required")
 		}
+		if scheduleID, err = client.ResolveID("schedule", scheduleID); err != nil {
+			return err
+		}
 
 		var resp struct {
 			Data []api.ScheduleOverrideResponse `json:"data"`
@@ -36,7 +39,7 @@ var scheduleOverridesListCmd = &cobra.Command{
 			return err
 		}
 
-		if opts.Mode == output.ModeJSON {
+		if opts.Structured() {
 			return output.RenderJSON(resp.Data, opts)
 		}
 
@@ -53,7 +56,7 @@ var scheduleOverridesGetCmd = &cobra.Command{
 	Use:   "get",
 	Short: "Get a schedule override by alias",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -64,6 +67,9 @@ var scheduleOverridesGetCmd = &cobra.Command{
 		if scheduleID == "" {
 			return fmt.Errorf("--schedule is required")
 		}
+		if scheduleID, err = client.ResolveID("schedule", scheduleID); err != nil {
+			return err
+		}
 		if alias == "" {
 			return fmt.Errorf("--alias is required")
 		}
@@ -83,7 +89,7 @@ var scheduleOverridesCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create an override for a schedule",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -93,20 +99,29 @@ var scheduleOverridesCreateCmd = &cobra.Command{
 		if scheduleID == "" {
 			return fmt.Errorf("--schedule is required")
 		}
+		if scheduleID, err = client.ResolveID("schedule", scheduleID); err != nil {
+			return err
+		}
 
-		startDate, _ := cmd.Flags().GetString("start-date")
-		endDate, _ := cmd.Flags().GetString("end-date")
+		startDate, err := timeFlag(cmd, "start-date")
+		if err != nil {
+			return err
+		}
 		userID, _ := cmd.Flags().GetString("user")
 		rotationsJSON, _ := cmd.Flags().GetString("rotations")
 
-		if startDate == "" || endDate == "" {
-			return fmt.Errorf("--start-date and --end-date are required")
+		end, err := endDate(cmd, "end-date", startDate)
+		if err != nil {
+			return err
+		}
+		if startDate == "" || end == "" {
+			return fmt.Errorf("--start-date and --end-date (or --for) are required")
 		}
 
 		body := map[string]interface{}{
 			"user":      map[string]string{"id": userID},
 			"startDate": startDate,
-			"endDate":   endDate,
+			"endDate":   end,
 		}
 
 		if rotationsJSON != "" {
@@ -133,7 +148,7 @@ var scheduleOverridesUpdateCmd = &cobra.Command{
 	Use:   "update",
 	Short: "Update a schedule override by alias",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -144,16 +159,27 @@ var scheduleOverridesUpdateCmd = &cobra.Command{
 		if scheduleID == "" {
 			return fmt.Errorf("--schedule is required")
 		}
+		if scheduleID, err = client.ResolveID("schedule", scheduleID); err != nil {
+			return err
+		}
 		if alias == "" {
 			return fmt.Errorf("--alias is required")
 		}
 
 		body := map[string]interface{}{}
-		if startDate, _ := cmd.Flags().GetString("start-date"); startDate != "" {
-			body["startDate"] = startDate
+		start, err := timeFlag(cmd, "start-date")
+		if err != nil {
+			return err
 		}
-		if endDate, _ := cmd.Flags().GetString("end-date"); endDate != "" {
-			body["endDate"] = endDate
+		if start != "" {
+			body["startDate"] = start
+		}
+		end, err := endDate(cmd, "end-date", start)
+		if err != nil {
+			return err
+		}
+		if end != "" {
+			body["endDate"] = end
 		}
 		if userID, _ := cmd.Flags().GetString("user"); userID != "" {
 			body["user"] = map[string]string{"id": userID}
@@ -182,7 +208,7 @@ var scheduleOverridesDeleteCmd = &cobra.Command{
 	Use:   "delete",
 	Short: "Delete a schedule override by alias",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -193,6 +219,9 @@ var scheduleOverridesDeleteCmd = &cobra.Command{
 		if scheduleID == "" {
 			return fmt.Errorf("--schedule is required")
 		}
+		if scheduleID, err = client.ResolveID("schedule", scheduleID); err != nil {
+			return err
+		}
 		if alias == "" {
 			return fmt.Errorf("--alias is required")
 		}
@@ -216,15 +245,17 @@ func init() {
 	addOutputFlags(scheduleOverridesGetCmd)
 
 	scheduleOverridesCreateCmd.Flags().String("schedule", "", "Schedule ID or name (required)")
-	scheduleOverridesCreateCmd.Flags().String("start-date", "", "Override start date (ISO 8601, required)")
-	scheduleOverridesCreateCmd.Flags().String("end-date", "", "Override end date (ISO 8601, required)")
+	scheduleOverridesCreateCmd.Flags().String("start-date", "", "Override start date ("+timeFormats+"; required)")
+	scheduleOverridesCreateCmd.Flags().String("end-date", "", "Override end date ("+timeFormats+"; required)")
+	addForFlag(scheduleOverridesCreateCmd, "end-date", "Override length from --start-date")
 	scheduleOverridesCreateCmd.Flags().String("user", "", "User ID for the override")
 	scheduleOverridesCreateCmd.Flags().String("rotations", "", "JSON array of rotation references")
 
 	scheduleOverridesUpdateCmd.Flags().String("schedule", "", "Schedule ID or name (required)")
 	scheduleOverridesUpdateCmd.Flags().String("alias", "", "Override alias (required)")
-	scheduleOverridesUpdateCmd.Flags().String("start-date", "", "New start date (ISO 8601)")
-	scheduleOverridesUpdateCmd.Flags().String("end-date", "", "New end date (ISO 8601)")
+	scheduleOverridesUpdateCmd.Flags().String("start-date", "", "New start date ("+timeFormats+")")
+	scheduleOverridesUpdateCmd.Flags().String("end-date", "", "New end date ("+timeFormats+")")
+	addForFlag(scheduleOverridesUpdateCmd, "end-date", "New override length from --start-date or now")
 	scheduleOverridesUpdateCmd.Flags().String("user", "", "New user ID")
 	scheduleOverridesUpdateCmd.Flags().String("rotations", "", "JSON array of rotation references")
 
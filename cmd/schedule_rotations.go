@@ -3,6 +3,7 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/roboalchemist/opsgenie-cli/pkg/api"
 	"github.com/roboalchemist/opsgenie-cli/pkg/output"
@@ -18,7 +19,7 @@ var scheduleRotationsListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List rotations for a schedule",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -28,6 +29,9 @@ var scheduleRotationsListCmd = &cobra.Command{
 		if scheduleID == "" {
 			return fmt.Errorf("--schedule is required")
 		}
+		if scheduleID, err = client.ResolveID("schedule", scheduleID); err != nil {
+			return err
+		}
 
 		var resp struct {
 			Data []api.ScheduleRotationResponse `json:"data"`
@@ -36,7 +40,7 @@ var scheduleRotationsListCmd = &cobra.Command{
 			return err
 		}
 
-		if opts.Mode == output.ModeJSON {
+		if opts.Structured() {
 			return output.RenderJSON(resp.Data, opts)
 		}
 
@@ -53,7 +57,7 @@ var scheduleRotationsGetCmd = &cobra.Command{
 	Use:   "get",
 	Short: "Get a schedule rotation by ID",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -64,6 +68,9 @@ var scheduleRotationsGetCmd = &cobra.Command{
 		if scheduleID == "" {
 			return fmt.Errorf("--schedule is required")
 		}
+		if scheduleID, err = client.ResolveID("schedule", scheduleID); err != nil {
+			return err
+		}
 		if rotationID == "" {
 			return fmt.Errorf("--id is required")
 		}
@@ -82,8 +89,17 @@ var scheduleRotationsGetCmd = &cobra.Command{
 var scheduleRotationsCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a rotation for a schedule",
+	Example: `  # Weekly rotation between two users, Monday and Tuesday office hours only
+  opsgenie-cli schedule-rotations create --schedule primary --name office \
+    --start-date 2024-01-15T09:00:00Z \
+    --participant user:alice@example.com --participant user:bob@example.com \
+    --time-restriction "mon 09:00-mon 17:00,tue 09:00-tue 17:00"
+
+  # Nightly cover by a team
+  opsgenie-cli schedule-rotations create --schedule primary --name nights \
+    --type daily --participant team:sre --time-restriction 18:00-08:00`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -93,12 +109,17 @@ var scheduleRotationsCreateCmd = &cobra.Command{
 		if scheduleID == "" {
 			return fmt.Errorf("--schedule is required")
 		}
+		if scheduleID, err = client.ResolveID("schedule", scheduleID); err != nil {
+			return err
+		}
 
 		name, _ := cmd.Flags().GetString("name")
 		rotType, _ := cmd.Flags().GetString("type")
-		startDate, _ := cmd.Flags().GetString("start-date")
+		startDate, err := timeFlag(cmd, "start-date")
+		if err != nil {
+			return err
+		}
 		length, _ := cmd.Flags().GetInt("length")
-		participantsJSON, _ := cmd.Flags().GetString("participants")
 
 		body := map[string]interface{}{
 			"name":      name,
@@ -106,13 +127,8 @@ var scheduleRotationsCreateCmd = &cobra.Command{
 			"startDate": startDate,
 			"length":    length,
 		}
-
-		if participantsJSON != "" {
-			var participants interface{}
-			if err := json.Unmarshal([]byte(participantsJSON), &participants); err != nil {
-				return fmt.Errorf("invalid --participants JSON: %w", err)
-			}
-			body["participants"] = participants
+		if err := applyRotationFlags(cmd, body); err != nil {
+			return err
 		}
 
 		var resp struct {
@@ -131,7 +147,7 @@ var scheduleRotationsUpdateCmd = &cobra.Command{
 	Use:   "update",
 	Short: "Update a schedule rotation",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -142,6 +158,9 @@ var scheduleRotationsUpdateCmd = &cobra.Command{
 		if scheduleID == "" {
 			return fmt.Errorf("--schedule is required")
 		}
+		if scheduleID, err = client.ResolveID("schedule", scheduleID); err != nil {
+			return err
+		}
 		if rotationID == "" {
 			return fmt.Errorf("--id is required")
 		}
@@ -153,19 +172,19 @@ var scheduleRotationsUpdateCmd = &cobra.Command{
 		if rotType, _ := cmd.Flags().GetString("type"); rotType != "" {
 			body["type"] = rotType
 		}
-		if startDate, _ := cmd.Flags().GetString("start-date"); startDate != "" {
+		startDate, err := timeFlag(cmd, "start-date")
+		if err != nil {
+			return err
+		}
+		if startDate != "" {
 			body["startDate"] = startDate
 		}
 		if cmd.Flags().Changed("length") {
 			length, _ := cmd.Flags().GetInt("length")
 			body["length"] = length
 		}
-		if participantsJSON, _ := cmd.Flags().GetString("participants"); participantsJSON != "" {
-			var participants interface{}
-			if err := json.Unmarshal([]byte(participantsJSON), &participants); err != nil {
-				return fmt.Errorf("invalid --participants JSON: %w", err)
-			}
-			body["participants"] = participants
+		if err := applyRotationFlags(cmd, body); err != nil {
+			return err
 		}
 
 		var resp struct {
@@ -184,7 +203,7 @@ var scheduleRotationsDeleteCmd = &cobra.Command{
 	Use:   "delete",
 	Short: "Delete a schedule rotation",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -195,6 +214,9 @@ var scheduleRotationsDeleteCmd = &cobra.Command{
 		if scheduleID == "" {
 			return fmt.Errorf("--schedule is required")
 		}
+		if scheduleID, err = client.ResolveID("schedule", scheduleID); err != nil {
+			return err
+		}
 		if rotationID == "" {
 			return fmt.Errorf("--id is required")
 		}
@@ -209,6 +231,67 @@ var scheduleRotationsDeleteCmd = &cobra.Command{
 	},
 }
 
+// participantTypes are the named participant types a rotation accepts.
+var participantTypes = map[string]bool{"user": true, "team": true, "escalation": true}
+
+// parseParticipant parses a --participant value of the form "<type>:<name>",
+// e.g. "user:alice@example.com" or "team:platform". A bare "none" adds an
+// empty slot to the rotation.
+func parseParticipant(s string) (map[string]string, error) {
+	s = strings.TrimSpace(s)
+	if s == "none" {
+		return map[string]string{"type": "none"}, nil
+	}
+	typ, name, ok := strings.Cut(s, ":")
+	if !ok || name == "" {
+		return nil, fmt.Errorf("invalid participant %q: expected <type>:<name>", s)
+	}
+	if !participantTypes[typ] {
+		return nil, fmt.Errorf("invalid participant type %q (use user, team or escalation)", typ)
+	}
+	if typ == "user" {
+		return map[string]string{"type": typ, "username": name}, nil
+	}
+	return map[string]string{"type": typ, "name": name}, nil
+}
+
+// applyRotationFlags sets participants and timeRestriction on a rotation
+// body from --participant, --participants and --time-restriction.
+func applyRotationFlags(cmd *cobra.Command, body map[string]interface{}) error {
+	f := cmd.Flags()
+	values, _ := f.GetStringArray("participant")
+	participantsJSON, _ := f.GetString("participants")
+	switch {
+	case len(values) > 0 && participantsJSON != "":
+		return fmt.Errorf("use either --participant or --participants, not both")
+	case len(values) > 0:
+		participants := make([]map[string]string, 0, len(values))
+		for _, v := range values {
+			p, err := parseParticipant(v)
+			if err != nil {
+				return err
+			}
+			participants = append(participants, p)
+		}
+		body["participants"] = participants
+	case participantsJSON != "":
+		var participants interface{}
+		if err := json.Unmarshal([]byte(participantsJSON), &participants); err != nil {
+			return fmt.Errorf("invalid --participants JSON: %w", err)
+		}
+		body["participants"] = participants
+	}
+
+	if v, _ := f.GetString("time-restriction"); v != "" {
+		tr, err := parseTimeRestriction(v)
+		if err != nil {
+			return err
+		}
+		body["timeRestriction"] = tr
+	}
+	return nil
+}
+
 func init() {
 	scheduleRotationsListCmd.Flags().String("schedule", "", "Schedule ID or name (required)")
 	addOutputFlags(scheduleRotationsListCmd)
@@ -220,17 +303,21 @@ func init() {
 	scheduleRotationsCreateCmd.Flags().String("schedule", "", "Schedule ID or name (required)")
 	scheduleRotationsCreateCmd.Flags().String("name", "", "Rotation name")
 	scheduleRotationsCreateCmd.Flags().String("type", "weekly", "Rotation type (weekly, daily, hourly)")
-	scheduleRotationsCreateCmd.Flags().String("start-date", "", "Start date (ISO 8601)")
+	scheduleRotationsCreateCmd.Flags().String("start-date", "", "Start date ("+timeFormats+")")
 	scheduleRotationsCreateCmd.Flags().Int("length", 1, "Rotation length")
+	scheduleRotationsCreateCmd.Flags().StringArray("participant", nil, "Participant as <type>:<name>, e.g. user:alice@example.com (repeatable)")
 	scheduleRotationsCreateCmd.Flags().String("participants", "", "JSON array of participant objects")
+	scheduleRotationsCreateCmd.Flags().String("time-restriction", "", `Only on call during "HH:MM-HH:MM" or "mon 09:00-fri 17:00[,...]"`)
 
 	scheduleRotationsUpdateCmd.Flags().String("schedule", "", "Schedule ID or name (required)")
 	scheduleRotationsUpdateCmd.Flags().String("id", "", "Rotation ID (required)")
 	scheduleRotationsUpdateCmd.Flags().String("name", "", "New name")
 	scheduleRotationsUpdateCmd.Flags().String("type", "", "New type")
-	scheduleRotationsUpdateCmd.Flags().String("start-date", "", "New start date (ISO 8601)")
+	scheduleRotationsUpdateCmd.Flags().String("start-date", "", "New start date ("+timeFormats+")")
 	scheduleRotationsUpdateCmd.Flags().Int("length", 0, "New length")
+	scheduleRotationsUpdateCmd.Flags().StringArray("participant", nil, "Participant as <type>:<name>, e.g. user:alice@example.com (repeatable)")
 	scheduleRotationsUpdateCmd.Flags().String("participants", "", "JSON array of participant objects")
+	scheduleRotationsUpdateCmd.Flags().String("time-restriction", "", `Only on call during "HH:MM-HH:MM" or "mon 09:00-fri 17:00[,...]"`)
 
 	scheduleRotationsDeleteCmd.Flags().String("schedule", "", "Schedule ID or name (required)")
 	scheduleRotationsDeleteCmd.Flags().String("id", "", "Rotation ID (required)")
@@ -23,7 +23,7 @@ var schedulesListCmd = &cobra.Command{
   # List schedules as JSON with only id and name fields
   opsgenie-cli schedules list --json --fields id,name`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -36,10 +36,6 @@ var schedulesListCmd = &cobra.Command{
 			return err
 		}
 
-		if opts.Mode == output.ModeJSON {
-			return output.RenderJSON(resp.Data, opts)
-		}
-
 		headers := []string{"ID", "Name", "Timezone", "Enabled"}
 		rows := make([][]string, len(resp.Data))
 		for i, s := range resp.Data {
@@ -49,7 +45,14 @@ var schedulesListCmd = &cobra.Command{
 			}
 			rows[i] = []string{s.ID, s.Name, s.Timezone, enabled}
 		}
-		return output.RenderTable(headers, rows, resp.Data, opts)
+		if err := output.RenderTable(headers, rows, resp.Data, opts); err != nil {
+			return err
+		}
+		ids := make([]string, len(resp.Data))
+		for i, r := range resp.Data {
+			ids[i] = r.ID
+		}
+		return copyResult(cmd, client, "schedule", ids...)
 	},
 }
 
@@ -58,7 +61,11 @@ var schedulesGetCmd = &cobra.Command{
 	Short: "Get a schedule by ID or name",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		id, err := client.ResolveID("schedule", args[0])
 		if err != nil {
 			return err
 		}
@@ -67,11 +74,14 @@ var schedulesGetCmd = &cobra.Command{
 		var resp struct {
 			Data api.ScheduleResponse `json:"data"`
 		}
-		if err := client.Get("/v2/schedules/"+args[0], &resp); err != nil {
+		if err := client.Get("/v2/schedules/"+id, &resp); err != nil {
 			return err
 		}
 
-		return output.RenderJSON(resp.Data, opts)
+		if err := output.RenderJSON(resp.Data, opts); err != nil {
+			return err
+		}
+		return copyResult(cmd, client, "schedule", resp.Data.ID)
 	},
 }
 
@@ -79,7 +89,7 @@ var schedulesCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a new schedule",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -116,7 +126,11 @@ var schedulesUpdateCmd = &cobra.Command{
 	Short: "Update a schedule by ID or name",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		id, err := client.ResolveID("schedule", args[0])
 		if err != nil {
 			return err
 		}
@@ -140,7 +154,7 @@ var schedulesUpdateCmd = &cobra.Command{
 		var resp struct {
 			Data api.ScheduleResponse `json:"data"`
 		}
-		if err := client.Patch("/v2/schedules/"+args[0], body, &resp); err != nil {
+		if err := client.Patch("/v2/schedules/"+id, body, &resp); err != nil {
 			return err
 		}
 
@@ -157,14 +171,18 @@ var schedulesDeleteCmd = &cobra.Command{
 	Short: "Delete a schedule by ID or name",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		id, err := client.ResolveID("schedule", args[0])
 		if err != nil {
 			return err
 		}
 		opts := getOutputOpts()
 
 		var result json.RawMessage
-		if err := client.Delete("/v2/schedules/"+args[0], &result); err != nil {
+		if err := client.Delete("/v2/schedules/"+id, &result); err != nil {
 			return err
 		}
 
@@ -185,6 +203,8 @@ func init() {
 
 	addOutputFlags(schedulesListCmd)
 	addOutputFlags(schedulesGetCmd)
+	addCopyFlag(schedulesListCmd)
+	addCopyFlag(schedulesGetCmd)
 
 	schedulesCmd.AddCommand(schedulesListCmd)
 	schedulesCmd.AddCommand(schedulesGetCmd)
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// ─── schedules enable / disable ──────────────────────────────────────────────
+
+// newSchedulesToggleCmd builds "schedules enable" or "schedules disable",
+// which switch every schedule owned by a team. Without --yes they only show
+// which schedules would change.
+func newSchedulesToggleCmd(enable bool) *cobra.Command {
+	verb, past := "disable", "disabled"
+	if enable {
+		verb, past = "enable", "enabled"
+	}
+	var team string
+	var yes bool
+
+	c := &cobra.Command{
+		Use:   verb,
+		Short: "Bulk-" + verb + " all schedules owned by a team",
+		Long: `Bulk-` + verb + ` all schedules owned by a team (by name or ID), e.g. during
+a reorganisation or when decommissioning a team. Without --yes the affected
+schedules are listed but nothing is changed; --plan-file saves the changes
+for "execute-plan".
+
+With --yes a schedule that fails to change does not stop the others; a
+summary of the results follows the list, and the command fails if any
+schedule did.`,
+		Example: fmt.Sprintf(`  # Preview, then apply
+  opsgenie-cli schedules %[1]s --team legacy-ops
+  opsgenie-cli schedules %[1]s --team legacy-ops --yes`, verb),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if team == "" {
+				return fmt.Errorf("--team is required")
+			}
+			client, err := newClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+			opts := getOutputOpts()
+
+			var resp struct {
+				Data []api.ScheduleResponse `json:"data"`
+			}
+			if err := client.Get("/v2/schedules", &resp); err != nil {
+				return err
+			}
+
+			owned := func(s api.ScheduleResponse) bool {
+				return s.OwnerTeam != nil && (s.OwnerTeam.ID == team || strings.EqualFold(s.OwnerTeam.Name, team))
+			}
+			pending := 0
+			for _, s := range resp.Data {
+				if owned(s) && s.Enabled != enable {
+					pending++
+				}
+			}
+
+			headers := []string{"ID", "Name", "Enabled", "Result"}
+			var rows [][]string
+			data := []map[string]interface{}{}
+			rec := planRecorder(cmd)
+			changed, unchanged := 0, 0
+			var sum output.Summary
+			for _, s := range resp.Data {
+				if !owned(s) {
+					continue
+				}
+				result := "unchanged"
+				if s.Enabled != enable {
+					result = "would " + verb
+					body := map[string]interface{}{"enabled": enable}
+					switch {
+					case rec != nil:
+						_ = rec.Patch("/v2/schedules/"+s.ID, body, nil)
+					case yes:
+						err := client.Patch("/v2/schedules/"+s.ID, body, nil)
+						output.Progress(verb, s.Name, changed+1, pending)
+						if err != nil {
+							output.Error(fmt.Sprintf("schedule %s: %v", s.Name, err), opts)
+							result = "failed"
+							sum.Fail(s.ID)
+							break
+						}
+						result = past
+						sum.Succeed()
+					}
+					changed++
+				} else {
+					unchanged++
+					sum.Skip()
+				}
+				rows = append(rows, []string{s.ID, s.Name, strconv.FormatBool(s.Enabled), result})
+				data = append(data, map[string]interface{}{"id": s.ID, "name": s.Name, "enabled": s.Enabled, "result": result})
+			}
+			if len(rows) == 0 {
+				return fmt.Errorf("no schedules owned by team %q", team)
+			}
+			if rec != nil {
+				return renderPlan(cmd, rec, opts)
+			}
+			if err := output.RenderTable(headers, rows, data, opts); err != nil {
+				return err
+			}
+
+			if !yes {
+				output.Success(fmt.Sprintf("%d schedule(s) of team %s would be %s, %d already %s (dry run; re-run with --yes to apply)",
+					changed, team, past, unchanged, past), opts)
+				return nil
+			}
+			if err := output.RenderSummary(sum, opts); err != nil {
+				return err
+			}
+			if err := sum.Err(); err != nil {
+				return err
+			}
+			output.Success(fmt.Sprintf("%d schedule(s) of team %s %s, %d already %s", changed, team, past, unchanged, past), opts)
+			return nil
+		},
+	}
+	c.Flags().StringVar(&team, "team", "", "Owning team name or ID (required)")
+	c.Flags().BoolVar(&yes, "yes", false, "Apply the change instead of previewing it")
+	addPlanFlags(c)
+	addOutputFlags(c)
+	return c
+}
+
+func init() {
+	schedulesCmd.AddCommand(newSchedulesToggleCmd(false))
+	schedulesCmd.AddCommand(newSchedulesToggleCmd(true))
+}
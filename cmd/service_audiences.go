@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// ─── services audiences ──────────────────────────────────────────────────────
+
+// A service's audience template names who is told about its incidents:
+// responders (teams and users that work them) and stakeholders (users kept
+// informed, picked by hand or by conditions on their profile). The public
+// API has no endpoints for a service's Slack or Microsoft Teams channels,
+// which stay web-UI-only.
+
+// audienceMatchFields are the user profile fields stakeholder conditions
+// can match on.
+var audienceMatchFields = []string{"country", "state", "city", "zipCode", "line", "tag", "customProperty"}
+
+// audienceTemplate is the body of /v1/services/{id}/audience-templates.
+type audienceTemplate struct {
+	Responder struct {
+		Teams       []string `json:"teams"`
+		Individuals []string `json:"individuals"`
+	} `json:"responder"`
+	Stakeholder struct {
+		Individuals        []string            `json:"individuals"`
+		ConditionMatchType string              `json:"conditionMatchType,omitempty"`
+		Conditions         []audienceCondition `json:"conditions"`
+	} `json:"stakeholder"`
+}
+
+// audienceCondition picks stakeholders whose profile field matches value.
+// Key names the property when the field is customProperty.
+type audienceCondition struct {
+	MatchField string `json:"matchField"`
+	Key        string `json:"key,omitempty"`
+	Value      string `json:"value"`
+}
+
+func audiencePath(id string) string {
+	return "/v1/services/" + id + "/audience-templates"
+}
+
+// parseAudienceCondition parses a --stakeholder-condition: field=value, or
+// customProperty.key=value.
+func parseAudienceCondition(s string) (audienceCondition, error) {
+	field, value, ok := strings.Cut(s, "=")
+	if !ok || field == "" || value == "" {
+		return audienceCondition{}, fmt.Errorf("stakeholder condition %q is not field=value", s)
+	}
+	field, key, _ := strings.Cut(field, ".")
+	for _, f := range audienceMatchFields {
+		if strings.EqualFold(field, f) {
+			if (f == "customProperty") != (key != "") {
+				return audienceCondition{}, fmt.Errorf("stakeholder condition %q: only customProperty takes a key, as customProperty.key=value", s)
+			}
+			return audienceCondition{MatchField: f, Key: key, Value: value}, nil
+		}
+	}
+	return audienceCondition{}, fmt.Errorf("stakeholder condition %q: unknown field %q (use %s)", s, field, strings.Join(audienceMatchFields, ", "))
+}
+
+// userIDs looks up users given by username or ID and returns their IDs,
+// which is what audience templates hold. A user that cannot be looked up
+// is kept as given.
+func userIDs(client *api.Client, users []string) []string {
+	found := lookupUsers(client, users)
+	ids := make([]string, len(users))
+	for i, u := range users {
+		ids[i] = u
+		if user, ok := found[u]; ok && user.ID != "" {
+			ids[i] = user.ID
+		}
+	}
+	return ids
+}
+
+var servicesAudiencesCmd = &cobra.Command{
+	Use:   "audiences",
+	Short: "Manage a service's incident audiences",
+	Long: `Manage who is told about a service's incidents: responder teams and users,
+and stakeholders, chosen by hand or by conditions on their user profile.
+
+Slack and Microsoft Teams channels linked to a service are not exposed by
+the OpsGenie API and can only be managed in the web UI.`,
+}
+
+var servicesAudiencesGetCmd = &cobra.Command{
+	Use:   "get <service>",
+	Short: "Show a service's responder and stakeholder audiences",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		id, err := client.ResolveID("service", args[0])
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		var resp struct {
+			Data audienceTemplate `json:"data"`
+		}
+		if err := client.Get(audiencePath(id), &resp); err != nil {
+			return err
+		}
+		if opts.Structured() {
+			return output.RenderJSON(resp.Data, opts)
+		}
+
+		names := newRefNamer(client)
+		username := func(id string) string {
+			if u := names.user(id); u.Username != "" {
+				return u.Username
+			}
+			return id
+		}
+		headers := []string{"AUDIENCE", "TYPE", "VALUE"}
+		var rows [][]string
+		for _, t := range resp.Data.Responder.Teams {
+			rows = append(rows, []string{"responder", "team", names.name("team", t)})
+		}
+		for _, u := range resp.Data.Responder.Individuals {
+			rows = append(rows, []string{"responder", "user", username(u)})
+		}
+		for _, u := range resp.Data.Stakeholder.Individuals {
+			rows = append(rows, []string{"stakeholder", "user", username(u)})
+		}
+		for _, c := range resp.Data.Stakeholder.Conditions {
+			field := c.MatchField
+			if c.Key != "" {
+				field += "." + c.Key
+			}
+			rows = append(rows, []string{"stakeholder", "condition (" + resp.Data.Stakeholder.ConditionMatchType + ")", field + "=" + c.Value})
+		}
+		return output.RenderTable(headers, rows, resp.Data, opts)
+	},
+}
+
+var servicesAudiencesSetCmd = &cobra.Command{
+	Use:   "set <service>",
+	Short: "Change a service's responder and stakeholder audiences",
+	Long: `Change a service's audiences. Only the lists whose flags are given are
+replaced; the rest are kept. Pass a flag with an empty value (e.g.
+--stakeholder-user "") to clear its list.`,
+	Example: `  # The platform team responds; managers in Germany are kept informed
+  opsgenie-cli services audiences set checkout --responder-team platform \
+    --stakeholder-condition country=Germany --stakeholder-condition tag=manager --match all
+
+  # Add a stakeholder by hand and drop the conditions
+  opsgenie-cli services audiences set checkout --stakeholder-user cto@example.com --stakeholder-condition ""`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		f := cmd.Flags()
+		match, _ := f.GetString("match")
+		matchType := map[string]string{"all": "match-all-conditions", "any": "match-any-condition"}[match]
+		if matchType == "" {
+			return output.Invalid(fmt.Errorf("invalid --match %q (use all or any)", match))
+		}
+		var conditions []audienceCondition
+		specs, _ := f.GetStringArray("stakeholder-condition")
+		for _, s := range specs {
+			if s == "" {
+				continue
+			}
+			c, err := parseAudienceCondition(s)
+			if err != nil {
+				return output.Invalid(err)
+			}
+			conditions = append(conditions, c)
+		}
+
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		id, err := client.ResolveID("service", args[0])
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		// The update replaces the whole template, so unchanged lists are
+		// carried over from the current one.
+		var current struct {
+			Data audienceTemplate `json:"data"`
+		}
+		if err := client.Get(audiencePath(id), &current); err != nil {
+			return err
+		}
+		tmpl := current.Data
+		if f.Changed("responder-team") {
+			teams, _ := f.GetStringSlice("responder-team")
+			tmpl.Responder.Teams = []string{}
+			for _, t := range nonEmpty(teams) {
+				tid, err := client.ResolveID("team", t)
+				if err != nil {
+					return err
+				}
+				tmpl.Responder.Teams = append(tmpl.Responder.Teams, tid)
+			}
+		}
+		if f.Changed("responder-user") {
+			users, _ := f.GetStringSlice("responder-user")
+			tmpl.Responder.Individuals = userIDs(client, nonEmpty(users))
+		}
+		if f.Changed("stakeholder-user") {
+			users, _ := f.GetStringSlice("stakeholder-user")
+			tmpl.Stakeholder.Individuals = userIDs(client, nonEmpty(users))
+		}
+		if f.Changed("stakeholder-condition") {
+			tmpl.Stakeholder.Conditions = conditions
+			if tmpl.Stakeholder.Conditions == nil {
+				tmpl.Stakeholder.Conditions = []audienceCondition{}
+			}
+		}
+		if f.Changed("match") || tmpl.Stakeholder.ConditionMatchType == "" {
+			tmpl.Stakeholder.ConditionMatchType = matchType
+		}
+
+		var result map[string]interface{}
+		if err := client.Patch(audiencePath(id), tmpl, &result); err != nil {
+			return err
+		}
+		output.Success(fmt.Sprintf("Audiences of service %q updated", args[0]), opts)
+		return output.RenderJSON(result, opts)
+	},
+}
+
+// nonEmpty drops empty values, so that a flag given as "" clears a list.
+func nonEmpty(values []string) []string {
+	out := []string{}
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func init() {
+	servicesCmd.AddCommand(servicesAudiencesCmd)
+	servicesAudiencesCmd.AddCommand(servicesAudiencesGetCmd)
+	servicesAudiencesCmd.AddCommand(servicesAudiencesSetCmd)
+	addOutputFlags(servicesAudiencesGetCmd)
+	addOutputFlags(servicesAudiencesSetCmd)
+
+	f := servicesAudiencesSetCmd.Flags()
+	f.StringSlice("responder-team", nil, "Responder teams, by name or ID (comma-separated)")
+	f.StringSlice("responder-user", nil, "Responder users, by username or ID (comma-separated)")
+	f.StringSlice("stakeholder-user", nil, "Stakeholder users, by username or ID (comma-separated)")
+	f.StringArray("stakeholder-condition", nil, "Stakeholders whose profile matches field=value (repeatable); fields: "+strings.Join(audienceMatchFields, ", ")+", with customProperty.key=value")
+	f.String("match", "any", "Stakeholders must match all or any of the conditions")
+}
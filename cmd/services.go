@@ -2,7 +2,9 @@ package cmd
 
 import (
 	"fmt"
+	"log/slog"
 
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
 	"github.com/roboalchemist/opsgenie-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
@@ -23,13 +25,13 @@ func init() {
 	// create flags
 	servicesCreateCmd.Flags().String("name", "", "Service name (required)")
 	servicesCreateCmd.Flags().String("description", "", "Service description")
-	servicesCreateCmd.Flags().String("team-id", "", "Team ID that owns this service")
+	servicesCreateCmd.Flags().String("team-id", "", "Team ID or name that owns this service")
 	_ = servicesCreateCmd.MarkFlagRequired("name")
 
 	// update flags
 	servicesUpdateCmd.Flags().String("name", "", "Service name")
 	servicesUpdateCmd.Flags().String("description", "", "Service description")
-	servicesUpdateCmd.Flags().String("team-id", "", "Team ID that owns this service")
+	servicesUpdateCmd.Flags().String("team-id", "", "Team ID or name that owns this service")
 }
 
 var servicesCmd = &cobra.Command{
@@ -42,7 +44,7 @@ var servicesListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all services",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -55,7 +57,7 @@ var servicesListCmd = &cobra.Command{
 			return err
 		}
 
-		if opts.Mode == output.ModeJSON {
+		if opts.Structured() {
 			return output.RenderJSON(resp.Data, opts)
 		}
 
@@ -75,10 +77,14 @@ var servicesListCmd = &cobra.Command{
 
 var servicesGetCmd = &cobra.Command{
 	Use:   "get <id>",
-	Short: "Get a service by ID",
+	Short: "Get a service by ID or name",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		id, err := client.ResolveID("service", args[0])
 		if err != nil {
 			return err
 		}
@@ -87,11 +93,11 @@ var servicesGetCmd = &cobra.Command{
 		var resp struct {
 			Data map[string]interface{} `json:"data"`
 		}
-		if err := client.Get("/v1/services/"+args[0], &resp); err != nil {
+		if err := client.Get("/v1/services/"+id, &resp); err != nil {
 			return err
 		}
 
-		if opts.Mode == output.ModeJSON {
+		if opts.Structured() {
 			return output.RenderJSON(resp.Data, opts)
 		}
 
@@ -110,7 +116,7 @@ var servicesCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a new service",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -125,6 +131,9 @@ var servicesCreateCmd = &cobra.Command{
 			"description": description,
 		}
 		if teamID != "" {
+			if teamID, err = client.ResolveID("team", teamID); err != nil {
+				return err
+			}
 			body["teamId"] = teamID
 		}
 
@@ -143,7 +152,11 @@ var servicesUpdateCmd = &cobra.Command{
 	Short: "Update a service",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		id, err := client.ResolveID("service", args[0])
 		if err != nil {
 			return err
 		}
@@ -160,11 +173,14 @@ var servicesUpdateCmd = &cobra.Command{
 		}
 		if cmd.Flags().Changed("team-id") {
 			v, _ := cmd.Flags().GetString("team-id")
+			if v, err = client.ResolveID("team", v); err != nil {
+				return err
+			}
 			body["teamId"] = v
 		}
 
 		var result map[string]interface{}
-		if err := client.Patch("/v1/services/"+args[0], body, &result); err != nil {
+		if err := client.Patch("/v1/services/"+id, body, &result); err != nil {
 			return err
 		}
 
@@ -178,13 +194,17 @@ var servicesDeleteCmd = &cobra.Command{
 	Short: "Delete a service",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		id, err := client.ResolveID("service", args[0])
 		if err != nil {
 			return err
 		}
 		opts := GetOutputOptions()
 
-		if err := client.Delete("/v1/services/"+args[0], nil); err != nil {
+		if err := client.Delete("/v1/services/"+id, nil); err != nil {
 			return err
 		}
 
@@ -192,3 +212,23 @@ var servicesDeleteCmd = &cobra.Command{
 		return nil
 	},
 }
+
+// serviceNames resolves service IDs to names, keeping the ID of any service
+// that cannot be looked up.
+func serviceNames(client *api.Client, ids []string) []string {
+	names := make([]string, len(ids))
+	for i, id := range ids {
+		names[i] = id
+		var svc struct {
+			Data map[string]interface{} `json:"data"`
+		}
+		if err := client.Get("/v1/services/"+id, &svc); err != nil {
+			slog.Debug("cannot look up service", "id", id, "err", err)
+			continue
+		}
+		if name := stringVal(svc.Data, "name"); name != "" {
+			names[i] = name
+		}
+	}
+	return names
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// ─── teams logs ──────────────────────────────────────────────────────────────
+
+var (
+	teamsLogsLimit int
+	teamsLogsOrder string
+)
+
+var teamsLogsCmd = &cobra.Command{
+	Use:   "logs <id>",
+	Short: "Show a team's audit log",
+	Long: `Show a team's audit log: membership, routing rule, escalation and
+schedule changes made to the team, newest first. Pages are followed until
+--limit entries have been read.`,
+	Example: `  # Recent changes to a team
+  opsgenie-cli teams logs platform
+
+  # The full history, oldest first, as JSON
+  opsgenie-cli teams logs platform --order asc --limit 1000 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if teamsLogsOrder != "asc" && teamsLogsOrder != "desc" {
+			return fmt.Errorf("invalid --order %q (use asc or desc)", teamsLogsOrder)
+		}
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		id, err := client.ResolveID("team", args[0])
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		logs := []api.TeamLog{}
+		offset := ""
+		for len(logs) < teamsLogsLimit {
+			params := url.Values{
+				"order": {teamsLogsOrder},
+				"limit": {strconv.Itoa(min(teamsLogsLimit-len(logs), 100))},
+			}
+			if offset != "" {
+				params.Set("offset", offset)
+			}
+			var page api.TeamLogs
+			if err := client.GetWithParams("/v2/teams/"+url.PathEscape(id)+"/logs", params, &page); err != nil {
+				return err
+			}
+			logs = append(logs, page.Logs...)
+			if page.Offset == "" || page.Offset == offset || len(page.Logs) == 0 {
+				break
+			}
+			offset = page.Offset
+		}
+
+		headers := []string{"CREATED", "OWNER", "LOG"}
+		rows := make([][]string, len(logs))
+		for i, l := range logs {
+			rows[i] = []string{l.CreatedAt, l.Owner, l.Log}
+		}
+		return output.RenderTable(headers, rows, logs, opts)
+	},
+}
+
+func init() {
+	teamsLogsCmd.Flags().IntVar(&teamsLogsLimit, "limit", 100, "Maximum number of entries to show")
+	teamsLogsCmd.Flags().StringVar(&teamsLogsOrder, "order", "desc", "Sort order: asc or desc")
+	addOutputFlags(teamsLogsCmd)
+
+	teamsCmd.AddCommand(teamsLogsCmd)
+}
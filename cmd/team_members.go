@@ -17,7 +17,7 @@ var teamMembersAddCmd = &cobra.Command{
 	Use:   "add",
 	Short: "Add a member to a team",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -30,6 +30,9 @@ var teamMembersAddCmd = &cobra.Command{
 		if teamID == "" {
 			return fmt.Errorf("--team is required")
 		}
+		if teamID, err = client.ResolveID("team", teamID); err != nil {
+			return err
+		}
 		if userID == "" {
 			return fmt.Errorf("--user is required")
 		}
@@ -55,7 +58,7 @@ var teamMembersRemoveCmd = &cobra.Command{
 	Use:   "remove",
 	Short: "Remove a member from a team",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -67,6 +70,9 @@ var teamMembersRemoveCmd = &cobra.Command{
 		if teamID == "" {
 			return fmt.Errorf("--team is required")
 		}
+		if teamID, err = client.ResolveID("team", teamID); err != nil {
+			return err
+		}
 		if memberID == "" {
 			return fmt.Errorf("--member is required")
 		}
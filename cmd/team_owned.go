@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// ─── teams schedules / teams escalations ─────────────────────────────────────
+
+// The API has no per-team listing of schedules or escalations, so both
+// are listed in full and kept when their ownerTeam is the team.
+
+// teamOwns reports whether ref names the team with the given ID and name.
+// Some owner references carry only the name.
+func teamOwns(ref *api.TeamRef, id, name string) bool {
+	return ref != nil && (ref.ID == id || (ref.ID == "" && ref.Name == name))
+}
+
+// teamSchedules returns the schedules owned by the team.
+func teamSchedules(client *api.Client, id, name string) ([]api.ScheduleResponse, error) {
+	var resp struct {
+		Data []api.ScheduleResponse `json:"data"`
+	}
+	if err := client.Get("/v2/schedules", &resp); err != nil {
+		return nil, fmt.Errorf("schedules: %w", err)
+	}
+	owned := []api.ScheduleResponse{}
+	for _, s := range resp.Data {
+		if teamOwns(s.OwnerTeam, id, name) {
+			owned = append(owned, s)
+		}
+	}
+	return owned, nil
+}
+
+// teamEscalations returns the escalations owned by the team.
+func teamEscalations(client *api.Client, id, name string) ([]api.EscalationResponse, error) {
+	var resp struct {
+		Data []api.EscalationResponse `json:"data"`
+	}
+	if err := client.Get("/v2/escalations", &resp); err != nil {
+		return nil, fmt.Errorf("escalations: %w", err)
+	}
+	owned := []api.EscalationResponse{}
+	for _, e := range resp.Data {
+		if teamOwns(e.OwnerTeam, id, name) {
+			owned = append(owned, e)
+		}
+	}
+	return owned, nil
+}
+
+// resolveTeam returns the ID and name of the team given by name or ID.
+func resolveTeam(client *api.Client, team string) (id, name string, err error) {
+	if id, err = client.ResolveID("team", team); err != nil {
+		return "", "", err
+	}
+	if name, err = client.ResolveName("team", id); err != nil {
+		return "", "", err
+	}
+	return id, name, nil
+}
+
+var teamsSchedulesCmd = &cobra.Command{
+	Use:   "schedules <team>",
+	Short: "List the schedules a team owns",
+	Example: `  # A team's schedules, to review its on-call setup
+  opsgenie-cli teams schedules platform`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		id, name, err := resolveTeam(client, args[0])
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		schedules, err := teamSchedules(client, id, name)
+		if err != nil {
+			return err
+		}
+		headers := []string{"ID", "Name", "Timezone", "Enabled"}
+		rows := make([][]string, len(schedules))
+		for i, s := range schedules {
+			rows[i] = []string{s.ID, s.Name, s.Timezone, strconv.FormatBool(s.Enabled)}
+		}
+		return output.RenderTable(headers, rows, schedules, opts)
+	},
+}
+
+var teamsEscalationsCmd = &cobra.Command{
+	Use:   "escalations <team>",
+	Short: "List the escalations a team owns",
+	Example: `  # A team's escalations, with their rules as JSON
+  opsgenie-cli teams escalations platform --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		id, name, err := resolveTeam(client, args[0])
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		escalations, err := teamEscalations(client, id, name)
+		if err != nil {
+			return err
+		}
+		headers := []string{"ID", "Name", "Description", "Rules"}
+		rows := make([][]string, len(escalations))
+		for i, e := range escalations {
+			rows[i] = []string{e.ID, e.Name, e.Description, strconv.Itoa(len(e.Rules))}
+		}
+		return output.RenderTable(headers, rows, escalations, opts)
+	},
+}
+
+func init() {
+	teamsCmd.AddCommand(teamsSchedulesCmd)
+	teamsCmd.AddCommand(teamsEscalationsCmd)
+	addOutputFlags(teamsSchedulesCmd)
+	addOutputFlags(teamsEscalationsCmd)
+}
@@ -0,0 +1,433 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// Alert and notification policies share the /v2/policies endpoints and most
+// of their body, so both command trees are built by newPolicyCmd. Policies
+// are team-scoped when --team is given and global otherwise.
+
+func init() {
+	rootCmd.AddCommand(newPolicyCmd("alert", "alert-policies",
+		"Manage OpsGenie alert policies",
+		"Create, list, order and manage alert policies, which modify alerts (message, priority, tags, responders) as they are created."))
+	rootCmd.AddCommand(newPolicyCmd("notification", "notification-policies",
+		"Manage OpsGenie notification policies",
+		"Create, list, order and manage notification policies, which suppress or delay notifications for matching alerts."))
+}
+
+// resolveTeamFlag replaces a team name given to --team with the team's ID,
+// which is what policyQuery sends.
+func resolveTeamFlag(cmd *cobra.Command, client *api.Client) error {
+	team, _ := cmd.Flags().GetString("team")
+	if team == "" {
+		return nil
+	}
+	id, err := client.ResolveID("team", team)
+	if err != nil {
+		return err
+	}
+	return cmd.Flags().Set("team", id)
+}
+
+// policyQuery returns the teamId query parameter for team-scoped policies.
+func policyQuery(cmd *cobra.Command) string {
+	team, _ := cmd.Flags().GetString("team")
+	if team == "" {
+		return ""
+	}
+	return "?" + url.Values{"teamId": {team}}.Encode()
+}
+
+// applyPolicyFlags copies changed flags onto a policy body. It is shared by
+// create (starting from an empty body) and update (starting from the
+// current policy, since the API replaces the whole policy on PUT).
+func applyPolicyFlags(cmd *cobra.Command, policyType string, body map[string]interface{}) error {
+	f := cmd.Flags()
+	if f.Changed("name") {
+		v, _ := f.GetString("name")
+		body["name"] = v
+	}
+	if f.Changed("description") {
+		v, _ := f.GetString("description")
+		body["policyDescription"] = v
+	}
+	if f.Changed("enabled") {
+		v, _ := f.GetBool("enabled")
+		body["enabled"] = v
+	}
+	if f.Changed("continue") {
+		v, _ := f.GetBool("continue")
+		body["continue"] = v
+	}
+	if f.Changed("condition") || f.Changed("match") {
+		match, _ := f.GetString("match")
+		conditions, _ := f.GetStringArray("condition")
+		filter, err := buildFilter(match, conditions)
+		if err != nil {
+			return err
+		}
+		body["filter"] = filter
+	}
+	if f.Changed("time-restriction") {
+		v, _ := f.GetString("time-restriction")
+		tr, err := parseTimeRestriction(v)
+		if err != nil {
+			return err
+		}
+		if tr == nil {
+			delete(body, "timeRestrictions")
+		} else {
+			body["timeRestrictions"] = tr
+		}
+	}
+
+	switch policyType {
+	case "alert":
+		for flag, field := range map[string]string{
+			"message": "message", "alias": "alias", "entity": "entity",
+			"source": "source", "priority": "priority",
+		} {
+			if f.Changed(flag) {
+				v, _ := f.GetString(flag)
+				body[field] = v
+			}
+		}
+		if f.Changed("tags") {
+			v, _ := f.GetString("tags")
+			body["tags"] = splitAndTrim(v)
+			body["ignoreOriginalTags"] = false
+		}
+		if f.Changed("responders") {
+			v, _ := f.GetString("responders")
+			body["responders"] = parseResponders(v)
+			body["ignoreOriginalResponders"] = false
+		}
+	case "notification":
+		if f.Changed("suppress") {
+			v, _ := f.GetBool("suppress")
+			body["suppress"] = v
+		}
+		if f.Changed("delay") {
+			v, _ := f.GetInt("delay")
+			if v > 0 {
+				body["delayAction"] = map[string]interface{}{"delayOption": "for-duration", "duration": map[string]interface{}{"timeAmount": v, "timeUnit": "minutes"}}
+			} else {
+				delete(body, "delayAction")
+			}
+		}
+	}
+	return nil
+}
+
+// newPolicyCmd builds the command tree for one policy type.
+func newPolicyCmd(policyType, use, short, long string) *cobra.Command {
+	parent := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Long:  long,
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List " + policyType + " policies",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := newClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+			if err := resolveTeamFlag(cmd, client); err != nil {
+				return err
+			}
+			opts := getOutputOpts()
+
+			var resp struct {
+				Data []map[string]interface{} `json:"data"`
+			}
+			if err := client.Get("/v2/policies/"+policyType+policyQuery(cmd), &resp); err != nil {
+				return err
+			}
+
+			if opts.Structured() {
+				return output.RenderJSON(resp.Data, opts)
+			}
+
+			headers := []string{"ID", "NAME", "ORDER", "ENABLED"}
+			rows := make([][]string, 0, len(resp.Data))
+			for _, p := range resp.Data {
+				rows = append(rows, []string{
+					stringVal(p, "id"),
+					stringVal(p, "name"),
+					stringVal(p, "order"),
+					stringVal(p, "enabled"),
+				})
+			}
+			return output.RenderTable(headers, rows, resp.Data, opts)
+		},
+	}
+
+	getCmd := &cobra.Command{
+		Use:   "get <id>",
+		Short: "Get " + policyType + " policy details",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := newClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+			if err := resolveTeamFlag(cmd, client); err != nil {
+				return err
+			}
+			opts := getOutputOpts()
+
+			var resp struct {
+				Data map[string]interface{} `json:"data"`
+			}
+			if err := client.Get("/v2/policies/"+args[0]+policyQuery(cmd), &resp); err != nil {
+				return err
+			}
+
+			if opts.Structured() {
+				return output.RenderJSON(resp.Data, opts)
+			}
+
+			headers := []string{"FIELD", "VALUE"}
+			rows := [][]string{
+				{"ID", stringVal(resp.Data, "id")},
+				{"Name", stringVal(resp.Data, "name")},
+				{"Type", stringVal(resp.Data, "type")},
+				{"Enabled", stringVal(resp.Data, "enabled")},
+				{"Continue", stringVal(resp.Data, "continue")},
+				{"Filter", nestedStringVal(resp.Data, "filter", "type")},
+				{"Time Restriction", nestedStringVal(resp.Data, "timeRestrictions", "type")},
+				{"Description", stringVal(resp.Data, "policyDescription")},
+			}
+			return output.RenderTable(headers, rows, resp.Data, opts)
+		},
+	}
+
+	createCmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create " + policyType + " policy",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := newClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+			if err := resolveTeamFlag(cmd, client); err != nil {
+				return err
+			}
+			opts := getOutputOpts()
+
+			body := map[string]interface{}{
+				"type":    policyType,
+				"enabled": true,
+				"filter":  map[string]interface{}{"type": "match-all"},
+			}
+			if policyType == "alert" {
+				// Alert policies require a message; keep the original by default.
+				body["message"] = "{{message}}"
+			}
+			if err := applyPolicyFlags(cmd, policyType, body); err != nil {
+				return err
+			}
+
+			var result map[string]interface{}
+			if err := client.Post("/v2/policies"+policyQuery(cmd), body, &result); err != nil {
+				return err
+			}
+
+			output.Success(fmt.Sprintf("%s policy %q created", policyType, body["name"]), opts)
+			return output.RenderJSON(result, opts)
+		},
+	}
+
+	updateCmd := &cobra.Command{
+		Use:   "update <id>",
+		Short: "Update " + policyType + " policy",
+		Long: "Update " + policyType + " policy. Only the given flags change; the current policy is fetched\n" +
+			"first because the API replaces the whole policy on update.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := newClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+			if err := resolveTeamFlag(cmd, client); err != nil {
+				return err
+			}
+			opts := getOutputOpts()
+
+			path := "/v2/policies/" + args[0] + policyQuery(cmd)
+			var current struct {
+				Data map[string]interface{} `json:"data"`
+			}
+			if err := client.Get(path, &current); err != nil {
+				return err
+			}
+			body := current.Data
+			if body == nil {
+				body = map[string]interface{}{}
+			}
+			delete(body, "id")
+			delete(body, "order")
+			body["type"] = policyType
+
+			if err := applyPolicyFlags(cmd, policyType, body); err != nil {
+				return err
+			}
+
+			var result map[string]interface{}
+			if err := client.Put(path, body, &result); err != nil {
+				return err
+			}
+
+			output.Success(fmt.Sprintf("%s policy %q updated", policyType, args[0]), opts)
+			return output.RenderJSON(result, opts)
+		},
+	}
+
+	deleteCmd := &cobra.Command{
+		Use:   "delete <id>",
+		Short: "Delete " + policyType + " policy",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := newClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+			if err := resolveTeamFlag(cmd, client); err != nil {
+				return err
+			}
+			opts := GetOutputOptions()
+
+			if err := client.Delete("/v2/policies/"+args[0]+policyQuery(cmd), nil); err != nil {
+				return err
+			}
+
+			output.Success(fmt.Sprintf("%s policy %q deleted", policyType, args[0]), opts)
+			return nil
+		},
+	}
+
+	enableCmd := &cobra.Command{
+		Use:   "enable <id>",
+		Short: "Enable " + policyType + " policy",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := newClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+			if err := resolveTeamFlag(cmd, client); err != nil {
+				return err
+			}
+			opts := GetOutputOptions()
+
+			if err := client.Post("/v2/policies/"+args[0]+"/enable"+policyQuery(cmd), nil, nil); err != nil {
+				return err
+			}
+
+			output.Success(fmt.Sprintf("%s policy %q enabled", policyType, args[0]), opts)
+			return nil
+		},
+	}
+
+	disableCmd := &cobra.Command{
+		Use:   "disable <id>",
+		Short: "Disable " + policyType + " policy",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := newClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+			if err := resolveTeamFlag(cmd, client); err != nil {
+				return err
+			}
+			opts := GetOutputOptions()
+
+			if err := client.Post("/v2/policies/"+args[0]+"/disable"+policyQuery(cmd), nil, nil); err != nil {
+				return err
+			}
+
+			output.Success(fmt.Sprintf("%s policy %q disabled", policyType, args[0]), opts)
+			return nil
+		},
+	}
+
+	changeOrderCmd := &cobra.Command{
+		Use:   "change-order <id>",
+		Short: "Move " + policyType + " policy to a new position",
+		Long:  "Move " + policyType + " policy to a new position. Policies are evaluated in order, starting at index 0.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := newClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+			if err := resolveTeamFlag(cmd, client); err != nil {
+				return err
+			}
+			opts := GetOutputOptions()
+
+			index, _ := cmd.Flags().GetInt("index")
+			if index < 0 {
+				return fmt.Errorf("--index must be 0 or greater")
+			}
+			body := map[string]interface{}{"targetIndex": index}
+			if err := client.Post("/v2/policies/"+args[0]+"/change-order"+policyQuery(cmd), body, nil); err != nil {
+				return err
+			}
+
+			output.Success(fmt.Sprintf("%s policy %q moved to index %d", policyType, args[0], index), opts)
+			return nil
+		},
+	}
+
+	for _, c := range []*cobra.Command{listCmd, getCmd, createCmd, updateCmd, deleteCmd, enableCmd, disableCmd, changeOrderCmd} {
+		c.Flags().String("team", "", "Team ID or name for team-scoped policies (omit for global policies)")
+		parent.AddCommand(c)
+	}
+
+	addOutputFlags(listCmd)
+	addOutputFlags(getCmd)
+	addOutputFlags(createCmd)
+	addOutputFlags(updateCmd)
+
+	for _, c := range []*cobra.Command{createCmd, updateCmd} {
+		c.Flags().String("name", "", "Policy name")
+		c.Flags().String("description", "", "Policy description")
+		c.Flags().Bool("enabled", true, "Whether the policy is enabled")
+		c.Flags().Bool("continue", false, "Continue evaluating later policies after this one matches")
+		c.Flags().StringArray("condition", nil, `Filter condition "[not] <field>[:<key>] <operation> [value]" (repeatable)`)
+		c.Flags().String("match", "all-conditions", "How conditions combine: all-conditions, any, or all (match every alert)")
+		c.Flags().String("time-restriction", "", `Only apply during "HH:MM-HH:MM" or "mon 09:00-fri 17:00[,...]"; empty to clear`)
+
+		switch policyType {
+		case "alert":
+			c.Flags().String("message", "", "Override the alert message ({{message}} keeps the original)")
+			c.Flags().String("alias", "", "Override the alert alias")
+			c.Flags().String("entity", "", "Override the alert entity")
+			c.Flags().String("source", "", "Override the alert source")
+			c.Flags().String("priority", "", "Override the alert priority (P1-P5)")
+			c.Flags().String("tags", "", "Comma-separated tags to add")
+			c.Flags().String("responders", "", "Comma-separated responders (type:name, e.g. team:ops)")
+		case "notification":
+			c.Flags().Bool("suppress", false, "Suppress notifications for matching alerts")
+			c.Flags().Int("delay", 0, "Delay notifications by this many minutes (0 to clear)")
+		}
+	}
+	_ = createCmd.MarkFlagRequired("name")
+
+	changeOrderCmd.Flags().Int("index", 0, "Target position, starting at 0 (required)")
+	_ = changeOrderCmd.MarkFlagRequired("index")
+
+	return parent
+}
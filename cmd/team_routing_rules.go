@@ -18,7 +18,7 @@ var teamRoutingRulesListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List routing rules for a team",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -28,6 +28,9 @@ var teamRoutingRulesListCmd = &cobra.Command{
 		if teamID == "" {
 			return fmt.Errorf("--team is required")
 		}
+		if teamID, err = client.ResolveID("team", teamID); err != nil {
+			return err
+		}
 
 		var resp struct {
 			Data []api.TeamRoutingRuleResponse `json:"data"`
@@ -36,7 +39,7 @@ var teamRoutingRulesListCmd = &cobra.Command{
 			return err
 		}
 
-		if opts.Mode == output.ModeJSON {
+		if opts.Structured() {
 			return output.RenderJSON(resp.Data, opts)
 		}
 
@@ -53,7 +56,7 @@ var teamRoutingRulesGetCmd = &cobra.Command{
 	Use:   "get",
 	Short: "Get a routing rule by ID",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -64,6 +67,9 @@ var teamRoutingRulesGetCmd = &cobra.Command{
 		if teamID == "" {
 			return fmt.Errorf("--team is required")
 		}
+		if teamID, err = client.ResolveID("team", teamID); err != nil {
+			return err
+		}
 		if ruleID == "" {
 			return fmt.Errorf("--id is required")
 		}
@@ -83,7 +89,7 @@ var teamRoutingRulesCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a routing rule for a team",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -97,6 +103,9 @@ var teamRoutingRulesCreateCmd = &cobra.Command{
 		if teamID == "" {
 			return fmt.Errorf("--team is required")
 		}
+		if teamID, err = client.ResolveID("team", teamID); err != nil {
+			return err
+		}
 
 		body := map[string]interface{}{
 			"name": name,
@@ -127,7 +136,7 @@ var teamRoutingRulesUpdateCmd = &cobra.Command{
 	Use:   "update",
 	Short: "Update a routing rule",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -138,6 +147,9 @@ var teamRoutingRulesUpdateCmd = &cobra.Command{
 		if teamID == "" {
 			return fmt.Errorf("--team is required")
 		}
+		if teamID, err = client.ResolveID("team", teamID); err != nil {
+			return err
+		}
 		if ruleID == "" {
 			return fmt.Errorf("--id is required")
 		}
@@ -173,7 +185,7 @@ var teamRoutingRulesDeleteCmd = &cobra.Command{
 	Use:   "delete",
 	Short: "Delete a routing rule",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -184,6 +196,9 @@ var teamRoutingRulesDeleteCmd = &cobra.Command{
 		if teamID == "" {
 			return fmt.Errorf("--team is required")
 		}
+		if teamID, err = client.ResolveID("team", teamID); err != nil {
+			return err
+		}
 		if ruleID == "" {
 			return fmt.Errorf("--id is required")
 		}
@@ -198,6 +213,50 @@ var teamRoutingRulesDeleteCmd = &cobra.Command{
 	},
 }
 
+var teamRoutingRulesChangeOrderCmd = &cobra.Command{
+	Use:   "change-order",
+	Short: "Move a routing rule to a new position",
+	Long: `Move a routing rule to a new position. Rules are evaluated in order,
+starting at 0, and the first matching rule routes the alert; the default
+rule always stays last.`,
+	Example: `  # Evaluate the database rule first
+  opsgenie-cli team-routing-rules change-order --team platform --id <rule-id> --order 0`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		teamID, _ := cmd.Flags().GetString("team")
+		ruleID, _ := cmd.Flags().GetString("id")
+		order, _ := cmd.Flags().GetInt("order")
+		if teamID == "" {
+			return fmt.Errorf("--team is required")
+		}
+		if teamID, err = client.ResolveID("team", teamID); err != nil {
+			return err
+		}
+		if ruleID == "" {
+			return fmt.Errorf("--id is required")
+		}
+		if !cmd.Flags().Changed("order") {
+			return fmt.Errorf("--order is required")
+		}
+		if order < 0 {
+			return fmt.Errorf("--order must be 0 or greater")
+		}
+
+		body := map[string]interface{}{"order": order}
+		if err := client.Post("/v2/teams/"+teamID+"/routing-rules/"+ruleID+"/change-order", body, nil); err != nil {
+			return err
+		}
+
+		output.Success(fmt.Sprintf("Routing rule %s moved to position %d", ruleID, order), opts)
+		return nil
+	},
+}
+
 func init() {
 	teamRoutingRulesListCmd.Flags().String("team", "", "Team ID or name (required)")
 	addOutputFlags(teamRoutingRulesListCmd)
@@ -220,11 +279,16 @@ func init() {
 	teamRoutingRulesDeleteCmd.Flags().String("team", "", "Team ID or name (required)")
 	teamRoutingRulesDeleteCmd.Flags().String("id", "", "Routing rule ID (required)")
 
+	teamRoutingRulesChangeOrderCmd.Flags().String("team", "", "Team ID or name (required)")
+	teamRoutingRulesChangeOrderCmd.Flags().String("id", "", "Routing rule ID (required)")
+	teamRoutingRulesChangeOrderCmd.Flags().Int("order", 0, "New position, starting at 0 (required)")
+
 	teamRoutingRulesCmd.AddCommand(teamRoutingRulesListCmd)
 	teamRoutingRulesCmd.AddCommand(teamRoutingRulesGetCmd)
 	teamRoutingRulesCmd.AddCommand(teamRoutingRulesCreateCmd)
 	teamRoutingRulesCmd.AddCommand(teamRoutingRulesUpdateCmd)
 	teamRoutingRulesCmd.AddCommand(teamRoutingRulesDeleteCmd)
+	teamRoutingRulesCmd.AddCommand(teamRoutingRulesChangeOrderCmd)
 
 	rootCmd.AddCommand(teamRoutingRulesCmd)
 }
@@ -3,6 +3,7 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"github.com/roboalchemist/opsgenie-cli/pkg/api"
 	"github.com/roboalchemist/opsgenie-cli/pkg/output"
@@ -23,7 +24,7 @@ var teamsListCmd = &cobra.Command{
   # List teams as JSON and filter with jq
   opsgenie-cli teams list --json | jq '.[].name'`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -36,25 +37,63 @@ var teamsListCmd = &cobra.Command{
 			return err
 		}
 
-		if opts.Mode == output.ModeJSON {
-			return output.RenderJSON(resp.Data, opts)
-		}
-
 		headers := []string{"ID", "Name", "Description"}
 		rows := make([][]string, len(resp.Data))
 		for i, t := range resp.Data {
 			rows[i] = []string{t.ID, t.Name, t.Description}
 		}
-		return output.RenderTable(headers, rows, resp.Data, opts)
+		if err := output.RenderTable(headers, rows, resp.Data, opts); err != nil {
+			return err
+		}
+		ids := make([]string, len(resp.Data))
+		for i, r := range resp.Data {
+			ids[i] = r.ID
+		}
+		return copyResult(cmd, client, "team", ids...)
 	},
 }
 
+var (
+	teamsGetResolveUsers     bool
+	teamsGetWithRoutingRules bool
+	teamsGetWithEscalations  bool
+)
+
+// teamDetail is a team with the optional additions of teams get.
+type teamDetail struct {
+	api.TeamResponse
+	Members      []teamMemberDetail            `json:"members,omitempty"`
+	RoutingRules []api.TeamRoutingRuleResponse `json:"routingRules,omitempty"`
+	Escalations  []api.EscalationResponse      `json:"escalations,omitempty"`
+}
+
+// teamMemberDetail is a team member, with the user's full name when
+// --resolve-users looked it up.
+type teamMemberDetail struct {
+	api.TeamMember
+	FullName string `json:"fullName,omitempty"`
+}
+
 var teamsGetCmd = &cobra.Command{
 	Use:   "get <id>",
 	Short: "Get a team by ID or name",
-	Args:  cobra.ExactArgs(1),
+	Long: `Get a team and list its members with their roles.
+
+--resolve-users looks up each member to fill in usernames the team omits and
+full names. --with-routing-rules and --with-escalations add the team's routing
+rules and the escalation policies it owns.`,
+	Example: `  # Members of a team
+  opsgenie-cli teams get platform
+
+  # Everything about a team as JSON
+  opsgenie-cli teams get platform --resolve-users --with-routing-rules --with-escalations --json`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		id, err := client.ResolveID("team", args[0])
 		if err != nil {
 			return err
 		}
@@ -63,19 +102,140 @@ var teamsGetCmd = &cobra.Command{
 		var resp struct {
 			Data api.TeamResponse `json:"data"`
 		}
-		if err := client.Get("/v2/teams/"+args[0], &resp); err != nil {
+		if err := client.Get("/v2/teams/"+id, &resp); err != nil {
 			return err
 		}
+		team := teamDetail{TeamResponse: resp.Data, Members: make([]teamMemberDetail, len(resp.Data.Members))}
+		for i, m := range resp.Data.Members {
+			team.Members[i] = teamMemberDetail{TeamMember: m}
+		}
 
-		return output.RenderJSON(resp.Data, opts)
+		if teamsGetResolveUsers {
+			ids := make([]string, len(team.Members))
+			for i, m := range team.Members {
+				ids[i] = m.User.ID
+			}
+			users := lookupUsers(client, ids)
+			for i, m := range team.Members {
+				if u, ok := users[m.User.ID]; ok {
+					team.Members[i].User.Username = u.Username
+					team.Members[i].FullName = u.FullName
+				}
+			}
+		}
+		if teamsGetWithRoutingRules {
+			var rules struct {
+				Data []api.TeamRoutingRuleResponse `json:"data"`
+			}
+			if err := client.Get("/v2/teams/"+team.ID+"/routing-rules", &rules); err != nil {
+				return fmt.Errorf("routing rules: %w", err)
+			}
+			team.RoutingRules = rules.Data
+		}
+		if teamsGetWithEscalations {
+			var escalations struct {
+				Data []api.EscalationResponse `json:"data"`
+			}
+			if err := client.Get("/v2/escalations", &escalations); err != nil {
+				return fmt.Errorf("escalations: %w", err)
+			}
+			for _, e := range escalations.Data {
+				if e.OwnerTeam != nil && (e.OwnerTeam.ID == team.ID || e.OwnerTeam.Name == team.Name) {
+					team.Escalations = append(team.Escalations, e)
+				}
+			}
+		}
+
+		if err := renderTeam(team, opts); err != nil {
+			return err
+		}
+		return copyResult(cmd, client, "team", team.ID)
 	},
 }
 
+// renderTeam writes a team's members as a table. In table and plaintext
+// modes any routing rules and escalations follow as their own sections;
+// structured modes get the whole team.
+func renderTeam(team teamDetail, opts output.Options) error {
+	if opts.Structured() || opts.Template != "" || opts.JQExpr != "" || opts.Mode == output.ModeID {
+		return output.RenderJSON(team, opts)
+	}
+
+	headers := []string{"Username", "Role", "UserID"}
+	if teamsGetResolveUsers {
+		headers = []string{"Username", "FullName", "Role", "UserID"}
+	}
+	rows := make([][]string, len(team.Members))
+	for i, m := range team.Members {
+		role := m.Role
+		if role == "" {
+			role = "user"
+		}
+		rows[i] = []string{m.User.Username, role, m.User.ID}
+		if teamsGetResolveUsers {
+			rows[i] = []string{m.User.Username, m.FullName, role, m.User.ID}
+		}
+	}
+	if opts.Mode == output.ModeTable {
+		fmt.Printf("%s (%s)\n", team.Name, team.ID)
+		if team.Description != "" {
+			fmt.Println(team.Description)
+		}
+		fmt.Println()
+	}
+	if err := output.RenderTable(headers, rows, team.Members, opts); err != nil {
+		return err
+	}
+	if opts.Mode != output.ModeTable && opts.Mode != output.ModePlaintext {
+		return nil
+	}
+
+	if teamsGetWithRoutingRules {
+		fmt.Println("\nRouting rules")
+		rows := make([][]string, len(team.RoutingRules))
+		for i, r := range team.RoutingRules {
+			rows[i] = []string{strconv.Itoa(r.Order), r.Name, notifyTarget(r.Notify), r.ID}
+		}
+		if err := output.RenderTable([]string{"Order", "Name", "Notify", "ID"}, rows, team.RoutingRules, opts); err != nil {
+			return err
+		}
+	}
+	if teamsGetWithEscalations {
+		fmt.Println("\nEscalations")
+		rows := make([][]string, len(team.Escalations))
+		for i, e := range team.Escalations {
+			rows[i] = []string{e.Name, strconv.Itoa(len(e.Rules)), e.ID}
+		}
+		if err := output.RenderTable([]string{"Name", "Rules", "ID"}, rows, team.Escalations, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// notifyTarget describes a routing rule's notify target, e.g.
+// "escalation: Platform Escalation".
+func notifyTarget(notify interface{}) string {
+	m, ok := notify.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	typ := stringVal(m, "type")
+	name := stringVal(m, "name")
+	if name == "" {
+		name = stringVal(m, "id")
+	}
+	if name == "" {
+		return typ
+	}
+	return typ + ": " + name
+}
+
 var teamsCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a new team",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -110,7 +270,11 @@ var teamsUpdateCmd = &cobra.Command{
 	Short: "Update a team by ID or name",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		id, err := client.ResolveID("team", args[0])
 		if err != nil {
 			return err
 		}
@@ -127,7 +291,7 @@ var teamsUpdateCmd = &cobra.Command{
 		var resp struct {
 			Data api.TeamResponse `json:"data"`
 		}
-		if err := client.Patch("/v2/teams/"+args[0], body, &resp); err != nil {
+		if err := client.Patch("/v2/teams/"+id, body, &resp); err != nil {
 			return err
 		}
 
@@ -144,14 +308,18 @@ var teamsDeleteCmd = &cobra.Command{
 	Short: "Delete a team by ID or name",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		id, err := client.ResolveID("team", args[0])
 		if err != nil {
 			return err
 		}
 		opts := getOutputOpts()
 
 		var result json.RawMessage
-		if err := client.Delete("/v2/teams/"+args[0], &result); err != nil {
+		if err := client.Delete("/v2/teams/"+id, &result); err != nil {
 			return err
 		}
 
@@ -169,6 +337,11 @@ func init() {
 
 	addOutputFlags(teamsListCmd)
 	addOutputFlags(teamsGetCmd)
+	addCopyFlag(teamsListCmd)
+	addCopyFlag(teamsGetCmd)
+	teamsGetCmd.Flags().BoolVar(&teamsGetResolveUsers, "resolve-users", false, "Look up members' usernames and full names")
+	teamsGetCmd.Flags().BoolVar(&teamsGetWithRoutingRules, "with-routing-rules", false, "Include the team's routing rules")
+	teamsGetCmd.Flags().BoolVar(&teamsGetWithEscalations, "with-escalations", false, "Include escalation policies owned by the team")
 
 	teamsCmd.AddCommand(teamsListCmd)
 	teamsCmd.AddCommand(teamsGetCmd)
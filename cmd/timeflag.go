@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/auth"
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// timeFormats is the help text shared by the time flags.
+const timeFormats = "RFC3339, +2h, 30m, tomorrow 9am, fri 17:00 or epoch seconds"
+
+// timeLayouts are the absolute forms parseTime accepts besides RFC3339;
+// they carry no offset and are read in now's location.
+var timeLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// parseTime parses a time flag value relative to now. Values without an
+// offset are read in now's location. Accepted forms:
+//
+//	2024-01-15T10:00:00Z    RFC3339
+//	2024-01-15 10:00        date with optional clock
+//	+2h, 30m, 1d            that long from now
+//	1718000000              Unix epoch seconds
+//	now, today, tomorrow    optionally followed by a clock: tomorrow 9am
+//	9am, 17:30, fri 9:30pm  the next time the clock (and weekday) comes round
+func parseTime(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, s, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+	// Shorter numbers are far more likely typos than times before 1973.
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil && len(s) >= 9 {
+		return time.Unix(n, 0), nil
+	}
+	if d, err := parseDuration(strings.TrimPrefix(s, "+")); err == nil {
+		return now.Add(d), nil
+	}
+	if t, ok := parseDayTime(strings.ToLower(s), now); ok {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("unrecognised time %q (use %s)", s, timeFormats)
+}
+
+// parseDayTime handles the word forms of parseTime: an optional day
+// (now, today, tomorrow or a weekday) and an optional clock.
+func parseDayTime(s string, now time.Time) (time.Time, bool) {
+	fields := strings.Fields(s)
+	// "9 am" is the same as "9am".
+	if n := len(fields); n >= 2 && (fields[n-1] == "am" || fields[n-1] == "pm") {
+		fields = append(fields[:n-2], fields[n-2]+fields[n-1])
+	}
+	if len(fields) == 0 || len(fields) > 2 {
+		return time.Time{}, false
+	}
+	if len(fields) == 1 && fields[0] == "now" {
+		return now, true
+	}
+
+	day, weekday := "", ""
+	if w := fields[0]; w == "today" || w == "tomorrow" {
+		day = w
+	} else if full, ok := weekdays[w]; ok {
+		weekday = full
+	} else if len(w) >= 3 && weekdays[w[:3]] == w {
+		weekday = w
+	}
+	if day != "" || weekday != "" {
+		fields = fields[1:]
+	}
+
+	hour, minute := 0, 0
+	if len(fields) == 1 {
+		var err error
+		if hour, minute, err = parseClockWord(fields[0]); err != nil {
+			return time.Time{}, false
+		}
+	} else if day == "" && weekday == "" {
+		return time.Time{}, false
+	}
+
+	t := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location())
+	switch {
+	case day == "today":
+	case day == "tomorrow":
+		t = t.AddDate(0, 0, 1)
+	case weekday != "":
+		for strings.ToLower(t.Weekday().String()) != weekday {
+			t = t.AddDate(0, 0, 1)
+		}
+		if !t.After(now) {
+			t = t.AddDate(0, 0, 7)
+		}
+	default:
+		if !t.After(now) {
+			t = t.AddDate(0, 0, 1)
+		}
+	}
+	return t, true
+}
+
+// parseClockWord parses a clock as "HH:MM" or in 12-hour form: 9am,
+// 9:30pm, 12am.
+func parseClockWord(s string) (int, int, error) {
+	suffix := ""
+	if strings.HasSuffix(s, "am") || strings.HasSuffix(s, "pm") {
+		s, suffix = s[:len(s)-2], s[len(s)-2:]
+	}
+	if suffix == "" {
+		return parseClock(s)
+	}
+	h, m := s, "0"
+	if hh, mm, ok := strings.Cut(s, ":"); ok {
+		h, m = hh, mm
+	}
+	hour, err := strconv.Atoi(h)
+	if err != nil || hour < 1 || hour > 12 {
+		return 0, 0, fmt.Errorf("invalid hour in %q", s+suffix)
+	}
+	minute, err := strconv.Atoi(m)
+	if err != nil || minute < 0 || minute > 59 {
+		return 0, 0, fmt.Errorf("invalid minute in %q", s+suffix)
+	}
+	hour %= 12
+	if suffix == "pm" {
+		hour += 12
+	}
+	return hour, minute, nil
+}
+
+// timeLocation returns the zone time flags are read in: the configured
+// timezone (see auth.Timezone), otherwise the local zone.
+func timeLocation() (*time.Location, error) {
+	name, err := auth.Timezone()
+	if err != nil || name == "" {
+		return time.Local, err
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", name, err)
+	}
+	return loc, nil
+}
+
+// timeFlag returns the named string flag parsed with parseTime in the
+// configured zone and formatted as RFC3339 UTC, or "" when it is empty.
+func timeFlag(cmd *cobra.Command, name string) (string, error) {
+	v, _ := cmd.Flags().GetString(name)
+	if strings.TrimSpace(v) == "" {
+		return "", nil
+	}
+	loc, err := timeLocation()
+	if err != nil {
+		return "", err
+	}
+	t, err := parseTime(v, time.Now().In(loc))
+	if err != nil {
+		return "", output.Invalid(fmt.Errorf("invalid --%s: %w", name, err))
+	}
+	return t.UTC().Format(time.RFC3339), nil
+}
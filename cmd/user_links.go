@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// ─── users schedules / escalations ───────────────────────────────────────────
+
+var usersSchedulesCmd = &cobra.Command{
+	Use:   "schedules <user>",
+	Short: "List the schedules a user is a participant of",
+	Long: `List the schedules a user (ID or username) takes part in through any
+rotation, e.g. to find what needs reassigning before offboarding them.`,
+	Example: `  # What is alice on?
+  opsgenie-cli users schedules alice@example.com`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		var resp struct {
+			Data []api.ScheduleResponse `json:"data"`
+		}
+		if err := client.Get("/v2/users/"+url.PathEscape(args[0])+"/schedules", &resp); err != nil {
+			return err
+		}
+		if resp.Data == nil {
+			resp.Data = []api.ScheduleResponse{}
+		}
+
+		headers := []string{"ID", "Name", "Enabled"}
+		rows := make([][]string, len(resp.Data))
+		for i, s := range resp.Data {
+			rows[i] = []string{s.ID, s.Name, strconv.FormatBool(s.Enabled)}
+		}
+		return output.RenderTable(headers, rows, resp.Data, opts)
+	},
+}
+
+var usersEscalationsCmd = &cobra.Command{
+	Use:   "escalations <user>",
+	Short: "List the escalation policies that notify a user",
+	Long: `List the escalation policies with a rule that notifies a user (ID or
+username) directly, e.g. to find what needs reassigning before offboarding
+them. Policies reaching the user only through a schedule or team are not
+included; see "users schedules".`,
+	Example: `  # Which escalations page alice directly?
+  opsgenie-cli users escalations alice@example.com --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+
+		var resp struct {
+			Data []api.EscalationResponse `json:"data"`
+		}
+		if err := client.Get("/v2/users/"+url.PathEscape(args[0])+"/escalations", &resp); err != nil {
+			return err
+		}
+		if resp.Data == nil {
+			resp.Data = []api.EscalationResponse{}
+		}
+
+		headers := []string{"ID", "Name", "Team"}
+		rows := make([][]string, len(resp.Data))
+		for i, e := range resp.Data {
+			team := ""
+			if e.OwnerTeam != nil {
+				team = e.OwnerTeam.Name
+			}
+			rows[i] = []string{e.ID, e.Name, team}
+		}
+		return output.RenderTable(headers, rows, resp.Data, opts)
+	},
+}
+
+func init() {
+	addOutputFlags(usersSchedulesCmd)
+	addOutputFlags(usersEscalationsCmd)
+
+	usersCmd.AddCommand(usersSchedulesCmd)
+	usersCmd.AddCommand(usersEscalationsCmd)
+}
@@ -1,9 +1,12 @@
 package cmd
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/url"
+	"strings"
 
 	"github.com/roboalchemist/opsgenie-cli/pkg/api"
 	"github.com/roboalchemist/opsgenie-cli/pkg/output"
@@ -18,41 +21,93 @@ var usersCmd = &cobra.Command{
 var usersListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all users (paginated)",
+	Long: `List all users (paginated).
+
+--query is passed to the OpsGenie user search; --role, --unverified and
+--blocked are applied to the results and can be combined.`,
+	Example: `  # Admins, for an access review
+  opsgenie-cli users list --role admin
+
+  # Accounts that never verified their email, as JSON
+  opsgenie-cli users list --unverified --json`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
 		opts := getOutputOpts()
 
+		params := url.Values{}
+		if query, _ := cmd.Flags().GetString("query"); query != "" {
+			params.Set("query", query)
+		}
 		var users []api.UserResponse
-		if err := client.ListAll("/v2/users", url.Values{}, &users); err != nil {
+		if err := client.ListAll("/v2/users", params, &users); err != nil {
 			return err
 		}
 
-		if opts.Mode == output.ModeJSON {
+		role, _ := cmd.Flags().GetString("role")
+		unverified, _ := cmd.Flags().GetBool("unverified")
+		blocked, _ := cmd.Flags().GetBool("blocked")
+		users = filterUsers(users, role, unverified, blocked)
+
+		if opts.Structured() {
 			return output.RenderJSON(users, opts)
 		}
 
-		headers := []string{"ID", "Username", "FullName", "Role", "Verified"}
+		headers := []string{"ID", "Username", "FullName", "Role", "Verified", "Blocked"}
 		rows := make([][]string, len(users))
 		for i, u := range users {
-			verified := "false"
-			if u.Verified {
-				verified = "true"
-			}
-			rows[i] = []string{u.ID, u.Username, u.FullName, u.Role.Name, verified}
+			rows[i] = []string{u.ID, u.Username, u.FullName, u.Role.Name, fmt.Sprintf("%v", u.Verified), fmt.Sprintf("%v", u.Blocked)}
 		}
 		return output.RenderTable(headers, rows, users, opts)
 	},
 }
 
+// filterUsers keeps users matching the role (case-insensitive, empty for
+// any) and, when set, only unverified or only blocked users.
+func filterUsers(users []api.UserResponse, role string, unverified, blocked bool) []api.UserResponse {
+	out := []api.UserResponse{}
+	for _, u := range users {
+		if role != "" && !strings.EqualFold(u.Role.Name, role) && !strings.EqualFold(u.Role.ID, role) {
+			continue
+		}
+		if unverified && u.Verified {
+			continue
+		}
+		if blocked && !u.Blocked {
+			continue
+		}
+		out = append(out, u)
+	}
+	return out
+}
+
+// lookupUsers fetches users by ID, skipping any that cannot be looked up.
+func lookupUsers(client *api.Client, ids []string) map[string]api.UserResponse {
+	users := map[string]api.UserResponse{}
+	for _, id := range ids {
+		if _, ok := users[id]; ok || id == "" {
+			continue
+		}
+		var resp struct {
+			Data api.UserResponse `json:"data"`
+		}
+		if err := client.Get("/v2/users/"+id, &resp); err != nil {
+			slog.Debug("cannot look up user", "id", id, "err", err)
+			continue
+		}
+		users[id] = resp.Data
+	}
+	return users
+}
+
 var usersGetCmd = &cobra.Command{
 	Use:   "get <id>",
 	Short: "Get a user by ID or username",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -72,8 +127,11 @@ var usersGetCmd = &cobra.Command{
 var usersCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a new user",
+	Example: `  # Invite a responder in Berlin
+  opsgenie-cli users create --username alice@example.com --full-name "Alice Doe" \
+    --role user --timezone Europe/Berlin --locale de_DE`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -92,6 +150,15 @@ var usersCreateCmd = &cobra.Command{
 			"fullName": fullName,
 			"role":     map[string]string{"name": role},
 		}
+		if tz, _ := cmd.Flags().GetString("timezone"); tz != "" {
+			body["timeZone"] = tz
+		}
+		if locale, _ := cmd.Flags().GetString("locale"); locale != "" {
+			body["locale"] = locale
+		}
+		if err := applyUserProfileFlags(cmd, body); err != nil {
+			return err
+		}
 
 		var resp struct {
 			Data api.UserResponse `json:"data"`
@@ -105,12 +172,69 @@ var usersCreateCmd = &cobra.Command{
 	},
 }
 
+// userAddressFields are the --address fields, as the API names them.
+var userAddressFields = []string{"country", "state", "city", "line", "zipCode"}
+
+// applyUserProfileFlags copies the --skype-username, --address, --tag and
+// --detail flags given to users create or update onto body. Tags and
+// details replace the user's current ones; a detail key given more than
+// once collects all its values.
+func applyUserProfileFlags(cmd *cobra.Command, body map[string]interface{}) error {
+	f := cmd.Flags()
+	if f.Changed("skype-username") {
+		v, _ := f.GetString("skype-username")
+		body["skypeUsername"] = v
+	}
+	if f.Changed("address") {
+		fields, _ := f.GetStringArray("address")
+		address := map[string]string{}
+		for _, a := range fields {
+			k, v, ok := strings.Cut(a, "=")
+			name := ""
+			for _, field := range userAddressFields {
+				if strings.EqualFold(strings.TrimSpace(k), field) {
+					name = field
+				}
+			}
+			if !ok || name == "" {
+				return output.Invalid(fmt.Errorf("invalid --address %q (use field=value with field one of %s)", a, strings.Join(userAddressFields, ", ")))
+			}
+			address[name] = v
+		}
+		body["userAddress"] = address
+	}
+	if f.Changed("tag") {
+		tags, _ := f.GetStringArray("tag")
+		body["tags"] = stringList(tags)
+	}
+	if f.Changed("detail") {
+		pairs, _ := f.GetStringArray("detail")
+		details := map[string][]string{}
+		for _, d := range pairs {
+			k, v, ok := strings.Cut(d, "=")
+			if !ok || strings.TrimSpace(k) == "" {
+				return output.Invalid(fmt.Errorf("invalid --detail %q (use key=value)", d))
+			}
+			k = strings.TrimSpace(k)
+			details[k] = append(details[k], v)
+		}
+		body["details"] = details
+	}
+	return nil
+}
+
 var usersUpdateCmd = &cobra.Command{
 	Use:   "update <id>",
 	Short: "Update a user by ID or username",
-	Args:  cobra.ExactArgs(1),
+	Long: `Update a user by ID or username. Only the flags given are changed;
+--tag and --detail replace all of the user's tags or details.`,
+	Example: `  # Provision a user's profile
+  opsgenie-cli users update alice@example.com --timezone Europe/Berlin \
+    --address country=Germany --address city=Berlin \
+    --tag sre --tag emea --detail team=platform --detail pager=primary`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -123,6 +247,18 @@ var usersUpdateCmd = &cobra.Command{
 		if role, _ := cmd.Flags().GetString("role"); role != "" {
 			body["role"] = map[string]string{"name": role}
 		}
+		if tz, _ := cmd.Flags().GetString("timezone"); tz != "" {
+			body["timeZone"] = tz
+		}
+		if locale, _ := cmd.Flags().GetString("locale"); locale != "" {
+			body["locale"] = locale
+		}
+		if err := applyUserProfileFlags(cmd, body); err != nil {
+			return err
+		}
+		if len(body) == 0 {
+			return fmt.Errorf("nothing to update: give --full-name, --role, --timezone, --locale, --skype-username, --address, --tag or --detail")
+		}
 
 		var resp struct {
 			Data api.UserResponse `json:"data"`
@@ -142,14 +278,31 @@ var usersUpdateCmd = &cobra.Command{
 var usersDeleteCmd = &cobra.Command{
 	Use:   "delete <id>",
 	Short: "Delete a user by ID or username",
-	Args:  cobra.ExactArgs(1),
+	Long: `Delete a user by ID or username, after confirmation unless --yes is
+given. Check "users schedules" and "users escalations" first: the user is
+removed from every rotation and escalation they are part of.`,
+	Example: `  # Offboard a user
+  opsgenie-cli users schedules alice@example.com
+  opsgenie-cli users delete alice@example.com --yes`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client, err := newClient()
+		client, err := newClient(cmd.Context())
 		if err != nil {
 			return err
 		}
 		opts := getOutputOpts()
 
+		if yes, _ := cmd.Flags().GetBool("yes"); !yes {
+			ok, err := output.Confirm(bufio.NewReader(cmd.InOrStdin()), cmd.ErrOrStderr(), fmt.Sprintf("Delete user %s?", args[0]))
+			if err != nil {
+				return fmt.Errorf("%w (use --yes to delete without confirmation)", err)
+			}
+			if !ok {
+				fmt.Fprintf(cmd.ErrOrStderr(), "User %s not deleted\n", args[0])
+				return nil
+			}
+		}
+
 		var result json.RawMessage
 		if err := client.Delete("/v2/users/"+args[0], &result); err != nil {
 			return err
@@ -161,12 +314,29 @@ var usersDeleteCmd = &cobra.Command{
 }
 
 func init() {
+	usersListCmd.Flags().String("query", "", "OpsGenie user search query")
+	usersListCmd.Flags().String("role", "", "Only users with this role (e.g. admin, user, observer, or a custom role)")
+	usersListCmd.Flags().Bool("unverified", false, "Only users who have not verified their email")
+	usersListCmd.Flags().Bool("blocked", false, "Only blocked users")
+
 	usersCreateCmd.Flags().String("username", "", "User email/username (required)")
 	usersCreateCmd.Flags().String("full-name", "", "Full name")
 	usersCreateCmd.Flags().String("role", "user", "Role name (e.g. admin, user, observer)")
+	usersCreateCmd.Flags().String("timezone", "", "Time zone, e.g. Europe/Berlin (default the account's)")
+	usersCreateCmd.Flags().String("locale", "", "Locale, e.g. en_US (default the account's)")
 
 	usersUpdateCmd.Flags().String("full-name", "", "New full name")
 	usersUpdateCmd.Flags().String("role", "", "New role name")
+	usersUpdateCmd.Flags().String("timezone", "", "New time zone, e.g. Europe/Berlin")
+	usersUpdateCmd.Flags().String("locale", "", "New locale, e.g. en_US")
+	for _, c := range []*cobra.Command{usersCreateCmd, usersUpdateCmd} {
+		c.Flags().String("skype-username", "", "Skype username")
+		c.Flags().StringArray("address", nil, "Address field as field=value: country, state, city, line or zipCode (repeatable)")
+		c.Flags().StringArray("tag", nil, "User tag (repeatable)")
+		c.Flags().StringArray("detail", nil, "Custom property as key=value (repeatable; repeat a key for several values)")
+	}
+
+	usersDeleteCmd.Flags().Bool("yes", false, "Delete without asking for confirmation")
 
 	addOutputFlags(usersListCmd)
 	addOutputFlags(usersGetCmd)
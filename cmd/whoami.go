@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+	"github.com/roboalchemist/opsgenie-cli/pkg/auth"
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// ─── whoami ──────────────────────────────────────────────────────────────────
+
+// keyInfo is what whoami found out about the API key.
+type keyInfo struct {
+	Account   string         `json:"account,omitempty"`
+	Plan      string         `json:"plan,omitempty"`
+	Region    string         `json:"region"`
+	APIURL    string         `json:"apiUrl"`
+	Key       string         `json:"key"`
+	KeySource string         `json:"keySource"`
+	Scope     string         `json:"scope"`
+	Access    keyAccess      `json:"access"`
+	RateLimit *rateLimitInfo `json:"rateLimit,omitempty"`
+}
+
+type keyAccess struct {
+	Read          bool `json:"read"`
+	Create        bool `json:"create"`
+	Configuration bool `json:"configuration"`
+}
+
+type rateLimitInfo struct {
+	Limit     int `json:"limit"`
+	Remaining int `json:"remaining"`
+}
+
+var whoamiCmd = &cobra.Command{
+	Use:   "whoami",
+	Short: "Check the API key: account, region, access and rate-limit headroom",
+	Long: `Check that the API key works and show what it can do: the account and
+region it belongs to, where the key was read from, its apparent scope and
+the rate-limit headroom left.
+
+Three cheap requests are made, none of which changes anything:
+
+  GET /v2/account      the account name; needs configuration access
+  GET /v2/alerts/count read access
+  POST /v2/alerts {}   create access: an invalid, empty alert is rejected
+                       with 422 when the key may create alerts and with
+                       403 when it may not
+
+The scope is "full" when the key can read and create, "read-only" when it
+can only read. Delete rights cannot be probed safely and are not shown.
+
+A rejected key (401) or an unreachable API is an error.`,
+	Example: `  # Is my key valid, and for which account?
+  opsgenie-cli whoami
+
+  # In a script: fail early unless the key can create alerts
+  opsgenie-cli whoami --json --jq .access.create | grep -q true`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts := getOutputOpts()
+		ctx := cmd.Context()
+
+		key, _ := auth.GetAPIKey()
+		info := keyInfo{Region: flagRegion, APIURL: client.BaseURL(), Key: maskKey(key), KeySource: "OPSGENIE_API_KEY"}
+		if mockMode() {
+			info.KeySource = "--mock"
+		} else if name := auth.CurrentProfile(); name != "" {
+			info.KeySource = "profile " + name + " in " + auth.ConfigPath()
+		} else if os.Getenv("OPSGENIE_API_KEY") == "" {
+			info.KeySource = auth.ConfigPath()
+		}
+		observe := func(rl api.RateLimitInfo) {
+			if rl.Limit > 0 {
+				info.RateLimit = &rateLimitInfo{Limit: rl.Limit, Remaining: rl.Remaining}
+			}
+		}
+		probe := func(method, path string, body, result interface{}) (int, error) {
+			status, rl, err := client.Probe(ctx, method, path, body, result)
+			if err != nil {
+				return 0, err
+			}
+			observe(rl)
+			if status == http.StatusUnauthorized {
+				return 0, &authError{err: fmt.Errorf("API key rejected by %s (401): check the key and --region", client.BaseURL())}
+			}
+			return status, nil
+		}
+
+		var account api.APIResponse[api.AccountResponse]
+		status, err := probe(http.MethodGet, "/v2/account", nil, &account)
+		if err != nil {
+			return err
+		}
+		if info.Access.Configuration = status == http.StatusOK; info.Access.Configuration {
+			info.Account, info.Plan = account.Data.Name, account.Data.Plan.Name
+		}
+		if status, err = probe(http.MethodGet, "/v2/alerts/count", nil, nil); err != nil {
+			return err
+		}
+		info.Access.Read = status == http.StatusOK
+		if status, err = probe(http.MethodPost, "/v2/alerts", map[string]interface{}{}, nil); err != nil {
+			return err
+		}
+		info.Access.Create = status == http.StatusUnprocessableEntity || status == http.StatusBadRequest || status/100 == 2
+
+		switch a := info.Access; {
+		case a.Read && a.Create:
+			info.Scope = "full"
+		case a.Read:
+			info.Scope = "read-only"
+		case a.Create:
+			info.Scope = "create-only"
+		default:
+			info.Scope = "none"
+		}
+
+		accountName := info.Account
+		if accountName == "" {
+			accountName = "(unknown: no configuration access)"
+		}
+		yesNo := map[bool]string{true: "yes", false: "no"}
+		rate := "(not announced)"
+		if info.RateLimit != nil {
+			rate = fmt.Sprintf("%d of %d requests remaining", info.RateLimit.Remaining, info.RateLimit.Limit)
+		}
+		headers := []string{"FIELD", "VALUE"}
+		rows := [][]string{
+			{"Account", accountName},
+			{"Plan", info.Plan},
+			{"Region", info.Region},
+			{"API URL", info.APIURL},
+			{"Key", info.Key + " (from " + info.KeySource + ")"},
+			{"Scope", info.Scope},
+			{"Read", yesNo[info.Access.Read]},
+			{"Create", yesNo[info.Access.Create]},
+			{"Configuration", yesNo[info.Access.Configuration]},
+			{"Rate limit", rate},
+		}
+		return output.RenderTable(headers, rows, info, opts)
+	},
+}
+
+// maskKey shows only the last four characters of an API key.
+func maskKey(key string) string {
+	if len(key) <= 4 {
+		return "****"
+	}
+	return "****" + key[len(key)-4:]
+}
+
+func init() {
+	addOutputFlags(whoamiCmd)
+	rootCmd.AddCommand(whoamiCmd)
+}
@@ -3,12 +3,20 @@ package main
 import (
 	"bytes"
 	"encoding/json"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 // ─── Test harness ─────────────────────────────────────────────────────────────
@@ -30,8 +38,15 @@ func TestMain(m *testing.M) {
 // runCLI runs the CLI binary with the given args, injecting the mock server URL
 // and test API key. Returns stdout, stderr, and exit code.
 func runCLI(t *testing.T, serverURL string, args ...string) (string, string, int) {
+	t.Helper()
+	return runCLIWithStdin(t, serverURL, "", args...)
+}
+
+// runCLIWithStdin is runCLI with stdin as the command's standard input.
+func runCLIWithStdin(t *testing.T, serverURL, stdin string, args ...string) (string, string, int) {
 	t.Helper()
 	cmd := exec.Command(binaryPath, args...)
+	cmd.Stdin = strings.NewReader(stdin)
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
@@ -50,6 +65,15 @@ func runCLI(t *testing.T, serverURL string, args ...string) (string, string, int
 
 // ─── Mock OpsGenie data ───────────────────────────────────────────────────────
 
+var mockPolicy = map[string]interface{}{
+	"id":      "policy-id-1",
+	"name":    "Test Policy",
+	"type":    "alert",
+	"order":   0,
+	"enabled": true,
+	"filter":  map[string]interface{}{"type": "match-all"},
+}
+
 var mockAlert = map[string]interface{}{
 	"id":           "alert-id-123",
 	"tinyId":       "42",
@@ -110,9 +134,41 @@ var mockEscalation = map[string]interface{}{
 	"id":          "escalation-id-001",
 	"name":        "Test Escalation",
 	"description": "A test escalation policy",
+	"ownerTeam":   map[string]interface{}{"id": "team-id-456", "name": "Test Team"},
 	"rules":       []interface{}{},
 }
 
+var mockIntegration = map[string]interface{}{
+	"id":      "integration-id-001",
+	"name":    "Test Integration",
+	"type":    "API",
+	"enabled": false,
+}
+
+var mockDeployment = map[string]interface{}{
+	"id":        "deployment-id-1",
+	"name":      "v1.2.3",
+	"createdAt": "2024-01-15T10:05:00Z",
+}
+
+var mockService = map[string]interface{}{
+	"id":     "service-id-1",
+	"name":   "api",
+	"teamId": "team-id-456",
+}
+
+var mockIncident = map[string]interface{}{
+	"id":               "incident-id-001",
+	"tinyId":           "7",
+	"message":          "Checkout is down",
+	"status":           "open",
+	"priority":         "P1",
+	"impactedServices": []string{"service-id-1", "service-id-gone"},
+	"actions":          []string{"Restart"},
+	"links":            map[string]interface{}{"web": "https://acme.app.opsgenie.com/incident/detail/incident-id-001", "api": ""},
+	"createdAt":        "2024-01-15T10:02:00Z",
+}
+
 // ─── Mock server setup ────────────────────────────────────────────────────────
 
 // newMockServer builds an httptest.Server that handles all OpsGenie v2 endpoints.
@@ -136,6 +192,19 @@ func newMockServer(t *testing.T) (*httptest.Server, *methodLog) {
 			})
 			return
 		}
+		if strings.Contains(r.URL.Query().Get("query"), `message: "Heartbeat failed"`) {
+			// Expiry alerts of mockHeartbeat (10 minute interval): three late
+			// pings and one outage.
+			var expiries []interface{}
+			for _, openMin := range []int{4, 7, 9, 120} {
+				expiries = append(expiries, map[string]interface{}{
+					"id": "hb-alert-" + strconv.Itoa(openMin), "message": "Heartbeat failed", "status": "closed",
+					"report": map[string]interface{}{"closeTime": openMin * 60 * 1000},
+				})
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": expiries})
+			return
+		}
 		writeJSON(w, http.StatusOK, map[string]interface{}{
 			"data": []interface{}{mockAlert},
 			"paging": map[string]interface{}{
@@ -169,6 +238,25 @@ func newMockServer(t *testing.T) (*httptest.Server, *methodLog) {
 	mux.HandleFunc("/v2/alerts/", func(w http.ResponseWriter, r *http.Request) {
 		path := r.URL.Path
 		log.record(path, r.Method)
+		switch path {
+		case "/v2/alerts/alert-routed":
+			alert := map[string]interface{}{}
+			for k, v := range mockAlert {
+				alert[k] = v
+			}
+			alert["id"] = "alert-routed"
+			alert["teams"] = []interface{}{map[string]interface{}{"id": "team-night", "name": "Night Team"}}
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": alert})
+			return
+		case "/v2/alerts/alert-routed/logs":
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"data": []interface{}{
+					map[string]interface{}{"log": "Alert is routed to team [Night Team] by routing rule [Business hours]", "type": "system", "createdAt": "2024-01-15T10:00:01Z"},
+					map[string]interface{}{"log": "Escalation [Night Team_escalation] started", "type": "system", "createdAt": "2024-01-15T10:00:02Z"},
+				},
+			})
+			return
+		}
 
 		switch {
 		case strings.HasSuffix(path, "/acknowledge"):
@@ -197,6 +285,41 @@ func newMockServer(t *testing.T) (*httptest.Server, *methodLog) {
 				"requestId": "req-assign-001",
 				"result":    "Request will be processed",
 			})
+		case strings.HasSuffix(path, "/attachments") && r.Method == http.MethodPost:
+			f, hdr, err := r.FormFile("file")
+			if err != nil {
+				writeJSON(w, http.StatusBadRequest, map[string]interface{}{"message": err.Error()})
+				return
+			}
+			_ = f.Close()
+			writeJSON(w, http.StatusOK, map[string]interface{}{"result": "Attached " + hdr.Filename})
+		case strings.HasSuffix(path, "/attachments"):
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"data": []interface{}{map[string]interface{}{"id": "att-1", "name": "dump.log"}},
+			})
+		case strings.Contains(path, "/attachments/"):
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"data": map[string]interface{}{"name": "dump.log", "url": "http://" + r.Host + "/files/dump.log"},
+			})
+		case strings.HasSuffix(path, "/notes") && r.Method == http.MethodGet:
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"data": []interface{}{map[string]interface{}{
+					"note": "Restarted the worker", "owner": "oncall@example.com", "createdAt": "2024-01-15T10:10:00Z",
+				}},
+			})
+		case strings.HasSuffix(path, "/logs"):
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"data": []interface{}{map[string]interface{}{
+					"log": "Alert acknowledged via web", "type": "system", "owner": "oncall@example.com", "createdAt": "2024-01-15T10:05:00Z",
+				}},
+			})
+		case strings.HasSuffix(path, "/recipients"):
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"data": []interface{}{map[string]interface{}{
+					"user":  map[string]interface{}{"id": "user-id-789", "username": "oncall@example.com"},
+					"state": "notified", "method": "email", "createdAt": "2024-01-15T10:01:00Z",
+				}},
+			})
 		case strings.HasSuffix(path, "/notes"):
 			writeJSON(w, http.StatusAccepted, map[string]interface{}{
 				"requestId": "req-note-001",
@@ -227,6 +350,11 @@ func newMockServer(t *testing.T) (*httptest.Server, *methodLog) {
 
 	// ── teams ─────────────────────────────────────────────────────────────────
 
+	mux.HandleFunc("/files/", func(w http.ResponseWriter, r *http.Request) {
+		log.record(r.URL.Path, r.Method)
+		_, _ = w.Write([]byte("log line 1\nlog line 2\n"))
+	})
+
 	mux.HandleFunc("/v2/teams", func(w http.ResponseWriter, r *http.Request) {
 		log.record(r.URL.Path, r.Method)
 		if r.Method == http.MethodPost {
@@ -242,6 +370,56 @@ func newMockServer(t *testing.T) (*httptest.Server, *methodLog) {
 
 	mux.HandleFunc("/v2/teams/", func(w http.ResponseWriter, r *http.Request) {
 		log.record(r.URL.Path, r.Method)
+		switch {
+		case r.URL.Path == "/v2/teams/team-night/routing-rules":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": []interface{}{
+				map[string]interface{}{
+					"id": "rule-night", "name": "Nights", "order": 0, "timezone": "UTC",
+					"timeRestriction": map[string]interface{}{"type": "time-of-day", "restriction": map[string]interface{}{"startHour": 22, "startMin": 0, "endHour": 6, "endMin": 0}},
+					"notify":          map[string]interface{}{"type": "none"},
+				},
+				map[string]interface{}{
+					"id": "rule-tags", "name": "Database", "order": 1,
+					"criteria": map[string]interface{}{"type": "match-all-conditions", "conditions": []interface{}{
+						map[string]interface{}{"field": "tags", "operation": "contains", "expectedValue": "database"},
+					}},
+					"notify": map[string]interface{}{"type": "none"},
+				},
+				map[string]interface{}{
+					"id": "rule-hours", "name": "Business hours", "order": 2,
+					"notify": map[string]interface{}{"type": "schedule", "name": "Test Schedule", "id": "schedule-id-789"},
+				},
+			}})
+			return
+		case strings.HasSuffix(r.URL.Path, "/logs"):
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{
+				"offset": "",
+				"logs": []interface{}{map[string]interface{}{
+					"log": "Routing rule Database moved to order 0", "owner": "admin@example.com", "createdAt": "2024-01-15T10:00:00Z",
+				}},
+			}})
+			return
+		case strings.HasSuffix(r.URL.Path, "/change-order"):
+			writeJSON(w, http.StatusOK, map[string]interface{}{"result": "Changed", "requestId": "req-1"})
+			return
+		case strings.HasSuffix(r.URL.Path, "/routing-rules") && r.Method == http.MethodGet:
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": []interface{}{map[string]interface{}{
+				"id": "rule-id-1", "name": "Default", "order": 0,
+				"notify": map[string]interface{}{"type": "schedule", "name": "Test Schedule", "id": "schedule-id-789"},
+			}}})
+			return
+		case r.URL.Path == "/v2/teams/team-with-members":
+			team := map[string]interface{}{}
+			for k, v := range mockTeam {
+				team[k] = v
+			}
+			team["members"] = []interface{}{
+				map[string]interface{}{"user": map[string]interface{}{"id": "user-id-001"}, "role": "admin"},
+				map[string]interface{}{"user": map[string]interface{}{"id": "user-id-002", "username": "bob@example.com"}},
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": team})
+			return
+		}
 		switch r.Method {
 		case http.MethodDelete:
 			writeJSON(w, http.StatusOK, map[string]interface{}{"result": "deleted"})
@@ -267,6 +445,13 @@ func newMockServer(t *testing.T) (*httptest.Server, *methodLog) {
 
 	mux.HandleFunc("/v2/schedules/", func(w http.ResponseWriter, r *http.Request) {
 		log.record(r.URL.Path, r.Method)
+		if strings.HasSuffix(r.URL.Path, "/on-calls") {
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{
+				"_parent":          map[string]interface{}{"id": "schedule-id-789", "name": "Test Schedule"},
+				"onCallRecipients": []string{"alice@example.com"},
+			}})
+			return
+		}
 		switch r.Method {
 		case http.MethodDelete:
 			writeJSON(w, http.StatusOK, map[string]interface{}{"result": "deleted"})
@@ -277,6 +462,28 @@ func newMockServer(t *testing.T) (*httptest.Server, *methodLog) {
 		}
 	})
 
+	mux.HandleFunc("/v2/schedules/on-calls", func(w http.ResponseWriter, r *http.Request) {
+		log.record(r.URL.Path, r.Method)
+		parent := map[string]interface{}{"id": "schedule-id-789", "name": "Test Schedule"}
+		if r.URL.Query().Get("flat") == "true" {
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"data": []interface{}{map[string]interface{}{
+					"_parent":          parent,
+					"onCallRecipients": []string{"alice@example.com"},
+				}},
+			})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"data": []interface{}{map[string]interface{}{
+				"_parent": parent,
+				"onCallParticipants": []interface{}{map[string]interface{}{
+					"id": "user-id-001", "name": "alice@example.com", "type": "user",
+				}},
+			}},
+		})
+	})
+
 	// ── users ─────────────────────────────────────────────────────────────────
 
 	// users list uses ListAll which follows paging; return a single page with no next.
@@ -294,6 +501,14 @@ func newMockServer(t *testing.T) (*httptest.Server, *methodLog) {
 
 	mux.HandleFunc("/v2/users/", func(w http.ResponseWriter, r *http.Request) {
 		log.record(r.URL.Path, r.Method)
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/schedules"):
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": []interface{}{mockSchedule}})
+			return
+		case strings.HasSuffix(r.URL.Path, "/escalations"):
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": []interface{}{mockEscalation}})
+			return
+		}
 		switch r.Method {
 		case http.MethodDelete:
 			writeJSON(w, http.StatusOK, map[string]interface{}{"result": "deleted"})
@@ -319,6 +534,31 @@ func newMockServer(t *testing.T) (*httptest.Server, *methodLog) {
 
 	mux.HandleFunc("/v2/escalations/", func(w http.ResponseWriter, r *http.Request) {
 		log.record(r.URL.Path, r.Method)
+		if r.URL.Path == "/v2/escalations/escalation-with-rules" {
+			escalation := map[string]interface{}{}
+			for k, v := range mockEscalation {
+				escalation[k] = v
+			}
+			escalation["rules"] = []interface{}{
+				map[string]interface{}{
+					"condition": "if-not-closed", "notifyType": "default",
+					"delay":     map[string]interface{}{"timeAmount": 30, "timeUnit": "minutes"},
+					"recipient": map[string]interface{}{"type": "team", "id": "team-id-456", "name": "Test Team"},
+				},
+				map[string]interface{}{
+					"condition": "if-not-acked", "notifyType": "default",
+					"delay":     map[string]interface{}{"timeAmount": 0, "timeUnit": "minutes"},
+					"recipient": map[string]interface{}{"type": "schedule", "id": "schedule-id-789", "name": "Test Schedule"},
+				},
+				map[string]interface{}{
+					"condition": "if-not-acked", "notifyType": "default",
+					"delay":     map[string]interface{}{"timeAmount": 5, "timeUnit": "minutes"},
+					"recipient": map[string]interface{}{"type": "user", "id": "user-id-001", "username": "testuser@example.com"},
+				},
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": escalation})
+			return
+		}
 		switch r.Method {
 		case http.MethodDelete:
 			writeJSON(w, http.StatusOK, map[string]interface{}{"result": "deleted"})
@@ -362,6 +602,135 @@ func newMockServer(t *testing.T) (*httptest.Server, *methodLog) {
 		}
 	})
 
+	// ── integrations ──────────────────────────────────────────────────────────
+
+	mux.HandleFunc("/v2/integrations", func(w http.ResponseWriter, r *http.Request) {
+		log.record(r.URL.Path, r.Method)
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"data": []interface{}{mockIntegration},
+		})
+	})
+
+	mux.HandleFunc("/v2/integrations/", func(w http.ResponseWriter, r *http.Request) {
+		log.record(r.URL.Path, r.Method)
+		if r.Method == http.MethodDelete {
+			writeJSON(w, http.StatusOK, map[string]interface{}{"result": "deleted"})
+			return
+		}
+		if r.URL.Path == "/v2/integrations/integration-id-001" && r.Method == http.MethodGet {
+			integration := map[string]interface{}{}
+			for k, v := range mockIntegration {
+				integration[k] = v
+			}
+			integration["teamId"] = "team-id-456"
+			integration["createdAt"] = "2024-01-01T00:00:00Z"
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": integration})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": mockIntegration})
+	})
+
+	// ── account ───────────────────────────────────────────────────────────────
+
+	mux.HandleFunc("/v2/account", func(w http.ResponseWriter, r *http.Request) {
+		log.record(r.URL.Path, r.Method)
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"data": map[string]interface{}{"name": "acme", "userCount": 3},
+		})
+	})
+
+	// ── deployments & services ────────────────────────────────────────────────
+
+	// /v2/deployments/with-service returns a deployment linked to mockService.
+	mux.HandleFunc("/v2/deployments/", func(w http.ResponseWriter, r *http.Request) {
+		log.record(r.URL.Path, r.Method)
+		d := map[string]interface{}{}
+		for k, v := range mockDeployment {
+			d[k] = v
+		}
+		if strings.HasSuffix(r.URL.Path, "/with-service") {
+			d["serviceIds"] = []string{"service-id-1"}
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": d})
+	})
+
+	mux.HandleFunc("/v1/services", func(w http.ResponseWriter, r *http.Request) {
+		log.record(r.URL.Path, r.Method)
+		if r.Method == http.MethodPost {
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": mockService})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": []interface{}{mockService}})
+	})
+
+	mux.HandleFunc("/v1/services/", func(w http.ResponseWriter, r *http.Request) {
+		log.record(r.URL.Path, r.Method)
+		if r.URL.Path != "/v1/services/service-id-1" {
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"message": "Service not found"})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": mockService})
+	})
+
+	// ── incidents ─────────────────────────────────────────────────────────────
+
+	mux.HandleFunc("/v1/incidents/", func(w http.ResponseWriter, r *http.Request) {
+		log.record(r.URL.Path, r.Method)
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/notes") && r.Method == http.MethodGet:
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"data": []interface{}{map[string]interface{}{
+					"note": "Rolled back the deploy", "owner": "oncall@example.com", "createdAt": "2024-01-15T10:20:00Z",
+				}},
+			})
+			return
+		case strings.HasSuffix(r.URL.Path, "/logs"):
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"data": []interface{}{map[string]interface{}{
+					"log": "Incident priority changed to P2", "owner": "oncall@example.com", "createdAt": "2024-01-15T10:15:00Z",
+				}},
+			})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": mockIncident})
+	})
+
+	mux.HandleFunc("/v2/incident-timelines/", func(w http.ResponseWriter, r *http.Request) {
+		log.record(r.URL.Path, r.Method)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"entries": []interface{}{
+			map[string]interface{}{
+				"id": "entry-2", "type": "ResponderAdded", "eventTime": "2024-01-15T10:05:00Z",
+				"actor":       map[string]interface{}{"name": "oncall@example.com", "type": "user"},
+				"description": map[string]interface{}{"content": "Database team added as responder"},
+			},
+			map[string]interface{}{
+				"id": "entry-1", "type": "IncidentCreated", "eventTime": "2024-01-15T10:02:00Z",
+				"actor":       map[string]interface{}{"name": "System", "type": "system"},
+				"description": map[string]interface{}{"content": "Incident created"},
+			},
+		}}})
+	})
+
+	// ── policies ──────────────────────────────────────────────────────────────
+
+	mux.HandleFunc("/v2/policies", func(w http.ResponseWriter, r *http.Request) {
+		log.record(r.URL.Path, r.Method)
+		writeJSON(w, http.StatusCreated, map[string]interface{}{
+			"data": map[string]interface{}{"id": "policy-id-1", "name": "Test Policy"},
+		})
+	})
+
+	mux.HandleFunc("/v2/policies/", func(w http.ResponseWriter, r *http.Request) {
+		log.record(r.URL.Path, r.Method)
+		if strings.HasSuffix(r.URL.Path, "/alert") || strings.HasSuffix(r.URL.Path, "/notification") {
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"data": []interface{}{mockPolicy},
+			})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": mockPolicy})
+	})
+
 	// ── 401 handler ───────────────────────────────────────────────────────────
 
 	// used by the "invalid API key" test via a separate server
@@ -388,16 +757,22 @@ func writeJSON(w http.ResponseWriter, status int, v interface{}) {
 	_ = json.NewEncoder(w).Encode(v)
 }
 
-// methodLog records the HTTP method last seen for each path.
+// methodLog records the HTTP method last seen for each path. The server's
+// handlers record while the test reads, so both go through mu.
 type methodLog struct {
+	mu      sync.Mutex
 	methods map[string]string
 }
 
 func (l *methodLog) record(path, method string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
 	l.methods[path] = method
 }
 
 func (l *methodLog) lastMethod(path string) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
 	return l.methods[path]
 }
 
@@ -580,6 +955,29 @@ func TestIntegration_AlertsList_Debug(t *testing.T) {
 	}
 }
 
+func TestIntegration_AlertsList_Stream(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "alerts", "list", "--all", "--stream", "--fields", "id,tinyId")
+	assertExitCode(t, exitCode, 0)
+	if want := `{"id":"alert-id-123","tinyId":"42"}` + "\n"; stdout != want {
+		t.Errorf("stdout = %q, want %q", stdout, want)
+	}
+}
+
+func TestIntegration_TeamsList_NDJSON(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "teams", "list", "-o", "ndjson")
+	assertExitCode(t, exitCode, 0)
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 1 || !strings.HasPrefix(lines[0], `{"`) || !strings.Contains(lines[0], "team-id-456") {
+		t.Errorf("stdout = %q, want one compact JSON object per team", stdout)
+	}
+}
+
 func TestIntegration_AlertsList_WithLimit(t *testing.T) {
 	srv, _ := newMockServer(t)
 	defer srv.Close()
@@ -649,6 +1047,147 @@ func TestIntegration_AlertsGet_NoColor(t *testing.T) {
 	assertNotContains(t, stdout, "\033[")
 }
 
+func TestIntegration_AlertsShow_Timeline(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "alerts", "show", "alert-id-123", "--no-pager")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "Test alert message")
+	order := []string{"notified via email", "Alert acknowledged via web", "Restarted the worker"}
+	last := -1
+	for _, s := range order {
+		i := strings.Index(stdout, s)
+		if i < 0 {
+			t.Fatalf("output missing %q:\n%s", s, stdout)
+		}
+		if i < last {
+			t.Errorf("%q is out of chronological order:\n%s", s, stdout)
+		}
+		last = i
+	}
+}
+
+func TestIntegration_AlertsShow_JSON(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "alerts", "show", "alert-id-123", "--json")
+	assertExitCode(t, exitCode, 0)
+	var got struct {
+		Notes      []map[string]interface{} `json:"notes"`
+		Logs       []map[string]interface{} `json:"logs"`
+		Recipients []map[string]interface{} `json:"recipients"`
+		Timeline   []map[string]interface{} `json:"timeline"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout)
+	}
+	if len(got.Notes) != 1 || len(got.Logs) != 1 || len(got.Recipients) != 1 || len(got.Timeline) != 4 {
+		t.Errorf("unexpected parts: %+v", got)
+	}
+}
+
+func TestIntegration_AlertsShow_EscapesID(t *testing.T) {
+	var mu sync.Mutex
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		got = append(got, r.URL.EscapedPath())
+		mu.Unlock()
+		if strings.HasPrefix(r.URL.Path, "/v2/alerts/a1/") {
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": []interface{}{}})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"id": "a1"}})
+	}))
+	defer srv.Close()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "alerts", "show", "db/backup?failed", "--json")
+	if exitCode != 0 {
+		t.Fatalf("exit %d, stderr: %s", exitCode, stderr)
+	}
+	if len(got) == 0 || got[0] != "/v2/alerts/db%2Fbackup%3Ffailed" {
+		t.Errorf("requests = %v, want the alert looked up at an escaped path", got)
+	}
+}
+
+func TestIntegration_AlertsHistory(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "alerts", "history", "alert-id-123", "--field", "status", "--json")
+	assertExitCode(t, exitCode, 0)
+	var got []map[string]interface{}
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout)
+	}
+	if len(got) != 2 || got[1]["to"] != "acknowledged" || got[1]["actor"] != "oncall@example.com" {
+		t.Errorf("status history = %v, want open then acknowledged by oncall@example.com", got)
+	}
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "alerts", "history", "alert-id-123", "--field", "severity")
+	assertExitCode(t, exitCode, 1)
+	assertContains(t, stderr, "unknown field")
+}
+
+func TestIntegration_AlertsWatch_Incremental(t *testing.T) {
+	var mu sync.Mutex
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries = append(queries, r.URL.Query().Get("query"))
+		n := len(queries)
+		mu.Unlock()
+
+		alert := map[string]interface{}{}
+		for k, v := range mockAlert {
+			alert[k] = v
+		}
+		if n > 1 {
+			alert["status"] = "closed"
+			alert["updatedAt"] = "2024-01-15T10:30:00Z"
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": []interface{}{alert}})
+	}))
+	defer srv.Close()
+
+	cmd := exec.Command(binaryPath, "alerts", "watch", "--query", "status:open", "--interval", "20ms", "--ndjson", "--fields", "id,status")
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Env = append(os.Environ(), "OPSGENIE_API_KEY=test-key", "OPSGENIE_API_URL="+srv.URL)
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; ; i++ {
+		mu.Lock()
+		n := len(queries)
+		mu.Unlock()
+		if n >= 3 {
+			break
+		}
+		if i == 250 {
+			_ = cmd.Process.Kill()
+			t.Fatal("watch did not poll")
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	_ = cmd.Process.Signal(os.Interrupt)
+	if err := cmd.Wait(); err != nil {
+		t.Fatalf("watch exited with %v", err)
+	}
+
+	want := `{"id":"alert-id-123","status":"open"}` + "\n" + `{"id":"alert-id-123","status":"closed"}` + "\n"
+	if stdout.String() != want {
+		t.Errorf("stdout = %q, want %q", stdout.String(), want)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if queries[0] != "status:open" || queries[1] != "(status:open) AND updatedAt >= 1705312860000" {
+		t.Errorf("queries = %q", queries)
+	}
+}
+
 // ─── alerts count ─────────────────────────────────────────────────────────────
 
 func TestIntegration_AlertsCount_DefaultTable(t *testing.T) {
@@ -763,527 +1302,4482 @@ func TestIntegration_AlertsSnooze_SendsPOST(t *testing.T) {
 	}
 }
 
-func TestIntegration_AlertsAssign_SendsPOST(t *testing.T) {
-	srv, log := newMockServer(t)
+func TestIntegration_AlertsSnooze_For(t *testing.T) {
+	var body map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"result": "Request will be processed"})
+	}))
 	defer srv.Close()
 
-	_, stderr, exitCode := runCLI(t, srv.URL, "alerts", "assign", "alert-id-123",
-		"--owner", "newowner@example.com")
+	before := time.Now()
+	_, stderr, exitCode := runCLI(t, srv.URL, "alerts", "snooze", "alert-id-123", "--for", "1d2h")
 	assertExitCode(t, exitCode, 0)
-	assertContains(t, stderr, "assigned")
-
-	found := false
-	for path, method := range log.methods {
-		if strings.Contains(path, "/assign") && method == http.MethodPost {
-			found = true
-			break
-		}
+	assertContains(t, stderr, "snoozed")
+	end, err := time.Parse(time.RFC3339, body["endTime"])
+	if err != nil {
+		t.Fatalf("endTime %q: %v", body["endTime"], err)
 	}
-	if !found {
-		t.Errorf("expected POST to /assign endpoint, got methods: %v", log.methods)
+	if d := end.Sub(before); d < 26*time.Hour-time.Second || d > 26*time.Hour+time.Minute {
+		t.Errorf("endTime %s is %s after the call, want 26h", end, d)
 	}
 }
 
-func TestIntegration_AlertsAddNote_SendsPOST(t *testing.T) {
-	srv, log := newMockServer(t)
+func TestIntegration_AlertsCreate_DefaultResponders(t *testing.T) {
+	var mu sync.Mutex
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var b map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&b)
+		mu.Lock()
+		body = b
+		mu.Unlock()
+		writeJSON(w, http.StatusAccepted, map[string]interface{}{"result": "Request will be processed"})
+	}))
 	defer srv.Close()
 
-	_, stderr, exitCode := runCLI(t, srv.URL, "alerts", "add-note", "alert-id-123",
-		"--note", "this is a test note")
-	assertExitCode(t, exitCode, 0)
-	assertContains(t, stderr, "Note added")
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("OPSGENIE_DEFAULT_RESPONDERS", "")
+	cfg := `{"api_key": "file-key", "default_responders": ["team:ops", "user:alice@example.com"]}`
+	if err := os.WriteFile(filepath.Join(home, ".opsgenie-cli-auth.json"), []byte(cfg), 0600); err != nil {
+		t.Fatal(err)
+	}
 
-	found := false
-	for path, method := range log.methods {
-		if strings.Contains(path, "/notes") && method == http.MethodPost {
-			found = true
-			break
-		}
+	_, _, exitCode := runCLI(t, srv.URL, "alerts", "create", "--message", "Disk full", "--responders", "team:ops,team:sre")
+	assertExitCode(t, exitCode, 0)
+	mu.Lock()
+	b, _ := json.Marshal(body["responders"])
+	body = nil
+	mu.Unlock()
+	if want := `[{"name":"ops","type":"team"},{"name":"sre","type":"team"},{"name":"alice@example.com","type":"user"}]`; string(b) != want {
+		t.Errorf("responders = %s, want %s", b, want)
 	}
-	if !found {
-		t.Errorf("expected POST to /notes endpoint, got methods: %v", log.methods)
+
+	_, _, exitCode = runCLI(t, srv.URL, "alerts", "create", "--message", "Disk full", "--no-default-responders")
+	assertExitCode(t, exitCode, 0)
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := body["responders"]; ok {
+		t.Errorf("expected no responders with --no-default-responders, got %v", body["responders"])
 	}
 }
 
-func TestIntegration_AlertsAddTags_SendsPOST(t *testing.T) {
-	srv, log := newMockServer(t)
+func TestIntegration_AlertsCreate_FromFile(t *testing.T) {
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		writeJSON(w, http.StatusAccepted, map[string]interface{}{"result": "Request will be processed"})
+	}))
 	defer srv.Close()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("OPSGENIE_DEFAULT_RESPONDERS", "")
+
+	payload := filepath.Join(t.TempDir(), "alert.json")
+	err := os.WriteFile(payload, []byte(`{
+		"message": "Disk full", "alias": "disk-db1", "entity": "db1", "user": "monitor",
+		"details": {"host": "db1"}, "actions": ["Restart"],
+		"responders": [{"type": "team", "id": "team-id-456"}]
+	}`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
 
-	_, stderr, exitCode := runCLI(t, srv.URL, "alerts", "add-tags", "alert-id-123",
-		"--tags", "production,critical")
+	_, _, exitCode := runCLI(t, srv.URL, "alerts", "create", "-f", payload, "--priority", "P1")
 	assertExitCode(t, exitCode, 0)
-	assertContains(t, stderr, "Tags added")
-
-	found := false
-	for path, method := range log.methods {
-		if strings.Contains(path, "/tags") && method == http.MethodPost {
-			found = true
-			break
-		}
-	}
-	if !found {
-		t.Errorf("expected POST to /tags endpoint, got methods: %v", log.methods)
+	b, _ := json.Marshal(body)
+	want := `{"actions":["Restart"],"alias":"disk-db1","details":{"host":"db1"},"entity":"db1","message":"Disk full","priority":"P1","responders":[{"id":"team-id-456","type":"team"}],"user":"monitor"}`
+	if string(b) != want {
+		t.Errorf("body = %s\nwant   %s", b, want)
 	}
 }
 
-// ─── teams list ───────────────────────────────────────────────────────────────
+func TestIntegration_AlertsCreate_FieldFlags(t *testing.T) {
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		writeJSON(w, http.StatusAccepted, map[string]interface{}{"result": "Request will be processed"})
+	}))
+	defer srv.Close()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("OPSGENIE_DEFAULT_RESPONDERS", "")
 
-func TestIntegration_TeamsList_DefaultTable(t *testing.T) {
-	srv, _ := newMockServer(t)
+	_, _, exitCode := runCLI(t, srv.URL, "alerts", "create", "--message", "Disk full on db1",
+		"--alias", "disk-db1", "--entity", "db1", "--source", "nagios", "--user", "monitor", "--note", "Paged by check",
+		"--actions", "Restart, Ping", "--detail", "host=db1", "--detail", "mount=/var=data")
+	assertExitCode(t, exitCode, 0)
+	b, _ := json.Marshal(body)
+	want := `{"actions":["Restart","Ping"],"alias":"disk-db1","details":{"host":"db1","mount":"/var=data"},"entity":"db1","message":"Disk full on db1","note":"Paged by check","source":"nagios","user":"monitor"}`
+	if string(b) != want {
+		t.Errorf("body = %s\nwant   %s", b, want)
+	}
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "alerts", "create", "--message", "x", "--source", strings.Repeat("s", 101))
+	assertExitCode(t, exitCode, 2)
+	assertContains(t, stderr, "source is 101 characters, at most 100 are allowed")
+}
+
+func TestIntegration_AlertsCreate_FileValidation(t *testing.T) {
+	srv, log := newMockServer(t)
 	defer srv.Close()
 
-	stdout, _, exitCode := runCLI(t, srv.URL, "teams", "list")
-	assertExitCode(t, exitCode, 0)
-	assertContains(t, stdout, "Test Team")
-	assertContains(t, stdout, "team-id-456")
+	payload := filepath.Join(t.TempDir(), "alert.json")
+	err := os.WriteFile(payload, []byte(`{"priority": "P9", "tags": "db", "responders": [{"type": "group", "name": "ops"}], "detials": {}}`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "alerts", "create", "-f", payload)
+	assertExitCode(t, exitCode, 2)
+	for _, want := range []string{"message is required", `unknown field "detials"`, "priority must be P1", "tags must be a list", "responders[0].type must be one of"} {
+		assertContains(t, stderr, want)
+	}
+	if m := log.lastMethod("/v2/alerts"); m != "" {
+		t.Errorf("invalid payload was sent (%s)", m)
+	}
 }
 
-func TestIntegration_TeamsList_JSON(t *testing.T) {
-	srv, _ := newMockServer(t)
+func TestIntegration_ScheduleOverridesCreate_For(t *testing.T) {
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		writeJSON(w, http.StatusCreated, map[string]interface{}{"data": map[string]interface{}{"alias": "o1"}})
+	}))
 	defer srv.Close()
 
-	stdout, _, exitCode := runCLI(t, srv.URL, "teams", "list", "--json")
+	_, _, exitCode := runCLI(t, srv.URL, "schedule-overrides", "create", "--schedule", "s1", "--user", "u1",
+		"--start-date", "2024-01-15T22:00:00Z", "--for", "90m")
 	assertExitCode(t, exitCode, 0)
-	assertValidJSON(t, stdout)
-	assertContains(t, stdout, "Test Team")
-	assertContains(t, stdout, "team-id-456")
+	if body["endDate"] != "2024-01-15T23:30:00Z" {
+		t.Errorf("endDate = %v, want 2024-01-15T23:30:00Z", body["endDate"])
+	}
 }
 
-func TestIntegration_TeamsList_Plaintext(t *testing.T) {
-	srv, _ := newMockServer(t)
+func TestIntegration_ScheduleRotationsCreate_Participants(t *testing.T) {
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		writeJSON(w, http.StatusCreated, map[string]interface{}{"data": map[string]interface{}{"id": "r1"}})
+	}))
 	defer srv.Close()
 
-	stdout, _, exitCode := runCLI(t, srv.URL, "teams", "list", "--plaintext")
+	_, _, exitCode := runCLI(t, srv.URL, "schedule-rotations", "create", "--schedule", "s1", "--name", "office",
+		"--participant", "user:alice@example.com", "--participant", "team:sre", "--participant", "none",
+		"--time-restriction", "mon 09:00-mon 17:00,tue 09:00-tue 17:00")
 	assertExitCode(t, exitCode, 0)
-	assertContains(t, stdout, "Test Team")
-	assertContains(t, stdout, "\t")
-	assertNotContains(t, stdout, "\033[")
+	b, _ := json.Marshal(body["participants"])
+	if want := `[{"type":"user","username":"alice@example.com"},{"name":"sre","type":"team"},{"type":"none"}]`; string(b) != want {
+		t.Errorf("participants = %s, want %s", b, want)
+	}
+	tr, _ := body["timeRestriction"].(map[string]interface{})
+	if tr["type"] != "weekday-and-time-of-day" {
+		t.Fatalf("timeRestriction = %v", body["timeRestriction"])
+	}
+	if rs, _ := tr["restrictions"].([]interface{}); len(rs) != 2 {
+		t.Errorf("restrictions = %v, want 2", tr["restrictions"])
+	}
 }
 
-func TestIntegration_TeamsList_NoColor(t *testing.T) {
+func TestIntegration_ScheduleRotationsCreate_BadParticipant(t *testing.T) {
 	srv, _ := newMockServer(t)
 	defer srv.Close()
 
-	stdout, _, exitCode := runCLI(t, srv.URL, "teams", "list", "--no-color")
-	assertExitCode(t, exitCode, 0)
-	assertContains(t, stdout, "Test Team")
-	assertNotContains(t, stdout, "\033[")
+	_, stderr, exitCode := runCLI(t, srv.URL, "schedule-rotations", "create", "--schedule", "s1",
+		"--participant", "group:ops")
+	if exitCode == 0 {
+		t.Fatal("expected failure for unknown participant type")
+	}
+	assertContains(t, stderr, "invalid participant type")
+
+	_, stderr, exitCode = runCLI(t, srv.URL, "schedule-rotations", "create", "--schedule", "s1",
+		"--participant", "user:alice@example.com", "--participants", "[]")
+	if exitCode == 0 {
+		t.Fatal("expected failure when mixing --participant and --participants")
+	}
+	assertContains(t, stderr, "not both")
 }
 
-func TestIntegration_TeamsList_Fields(t *testing.T) {
+func TestIntegration_DurationFlag_Invalid(t *testing.T) {
 	srv, _ := newMockServer(t)
 	defer srv.Close()
 
-	stdout, _, exitCode := runCLI(t, srv.URL, "teams", "list", "--json", "--fields", "id,name")
-	assertExitCode(t, exitCode, 0)
-	assertValidJSON(t, stdout)
-	assertContains(t, stdout, "team-id-456")
-	assertContains(t, stdout, "Test Team")
-	assertNotContains(t, stdout, "description")
+	_, stderr, exitCode := runCLI(t, srv.URL, "maintenance", "create", "--for", "soon")
+	if exitCode == 0 {
+		t.Fatal("expected a non-zero exit code")
+	}
+	assertContains(t, stderr, "90m, 2h30m or 1d")
 }
 
-func TestIntegration_TeamsList_JQ(t *testing.T) {
-	srv, _ := newMockServer(t)
+func TestIntegration_MaintenanceStart_ForAndEntities(t *testing.T) {
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body = nil
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		writeJSON(w, http.StatusCreated, map[string]interface{}{"data": map[string]interface{}{"id": "m1"}})
+	}))
 	defer srv.Close()
 
-	stdout, _, exitCode := runCLI(t, srv.URL, "teams", "list", "--json", "--jq", ".[0].name")
+	before := time.Now().UTC().Truncate(time.Second)
+	_, _, exitCode := runCLI(t, srv.URL, "maintenance", "start", "--for", "2h",
+		"--entity", "integration:int-1", "--entity", "policy:pol-1", "--description", "DB upgrade")
 	assertExitCode(t, exitCode, 0)
-	assertContains(t, stdout, "Test Team")
+	tm, _ := body["time"].(map[string]interface{})
+	startDate, _ := tm["startDate"].(string)
+	endDate, _ := tm["endDate"].(string)
+	start, err := time.Parse(time.RFC3339, startDate)
+	if err != nil || start.Before(before) || start.After(time.Now()) {
+		t.Fatalf("startDate = %v, want now", tm["startDate"])
+	}
+	if end, _ := time.Parse(time.RFC3339, endDate); !end.Equal(start.Add(2 * time.Hour)) {
+		t.Errorf("endDate = %v, want startDate + 2h", tm["endDate"])
+	}
+	b, _ := json.Marshal(body["rules"])
+	if want := `[{"entity":{"id":"int-1","type":"integration"},"state":"disabled"},{"entity":{"id":"pol-1","type":"policy"},"state":"disabled"}]`; string(b) != want {
+		t.Errorf("rules = %s, want %s", b, want)
+	}
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "maintenance", "create", "--for", "1h", "--entity", "service:s1")
+	assertExitCode(t, exitCode, 1)
+	assertContains(t, stderr, "integration:<id> or policy:<id>")
+
+	_, stderr, exitCode = runCLI(t, srv.URL, "maintenance", "create")
+	assertExitCode(t, exitCode, 1)
+	assertContains(t, stderr, "--end-date or --for is required")
 }
 
-func TestIntegration_TeamsList_Debug(t *testing.T) {
-	srv, _ := newMockServer(t)
+func TestIntegration_MaintenanceUpdate_Rules(t *testing.T) {
+	var method string
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, body = r.Method, nil
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"id": "m1"}})
+	}))
 	defer srv.Close()
 
-	_, stderr, exitCode := runCLI(t, srv.URL, "teams", "list", "--debug")
+	_, _, exitCode := runCLI(t, srv.URL, "maintenance", "update", "m1",
+		"--rule", "entity=integration:int-1", "--rule", "entity=policy:pol-1, state=enabled")
 	assertExitCode(t, exitCode, 0)
-	if !strings.Contains(stderr, "DEBUG") && !strings.Contains(stderr, "debug") {
-		t.Errorf("expected debug output in stderr, got: %q", stderr)
+	if method != http.MethodPut {
+		t.Errorf("method = %s, want PUT", method)
+	}
+	b, _ := json.Marshal(body["rules"])
+	if want := `[{"entity":{"id":"int-1","type":"integration"},"state":"disabled"},{"entity":{"id":"pol-1","type":"policy"},"state":"enabled"}]`; string(b) != want {
+		t.Errorf("rules = %s, want %s", b, want)
+	}
+	if _, ok := body["time"]; ok {
+		t.Errorf("time should be left alone: %v", body)
 	}
-}
 
-// ─── teams get ────────────────────────────────────────────────────────────────
+	for _, bad := range []string{"state=enabled", "entity=policy:p1,state=paused", "entity=policy:p1,scope=all"} {
+		_, stderr, exitCode := runCLI(t, srv.URL, "maintenance", "update", "m1", "--rule", bad)
+		assertExitCode(t, exitCode, 1)
+		assertContains(t, stderr, "invalid --rule")
+	}
+}
 
-func TestIntegration_TeamsGet_JSON(t *testing.T) {
-	srv, _ := newMockServer(t)
+func TestIntegration_AlertsUpdate(t *testing.T) {
+	srv, log := newMockServer(t)
 	defer srv.Close()
 
-	stdout, _, exitCode := runCLI(t, srv.URL, "teams", "get", "team-id-456", "--json")
+	_, stderr, exitCode := runCLI(t, srv.URL, "alerts", "update", "alert-id-123",
+		"--priority", "p1", "--message", "New message", "--detail", "region=eu-west-1")
 	assertExitCode(t, exitCode, 0)
-	assertValidJSON(t, stdout)
-	assertContains(t, stdout, "Test Team")
-}
+	assertContains(t, stderr, "Alert updated")
 
-// ─── schedules list ───────────────────────────────────────────────────────────
+	want := map[string]string{
+		"/v2/alerts/alert-id-123/priority": http.MethodPut,
+		"/v2/alerts/alert-id-123/message":  http.MethodPut,
+		"/v2/alerts/alert-id-123/details":  http.MethodPost,
+	}
+	for path, method := range want {
+		if log.methods[path] != method {
+			t.Errorf("%s: method %q, want %q (got %v)", path, log.methods[path], method, log.methods)
+		}
+	}
+	if _, ok := log.methods["/v2/alerts/alert-id-123/description"]; ok {
+		t.Error("description was updated without --description")
+	}
+}
 
-func TestIntegration_SchedulesList_DefaultTable(t *testing.T) {
+func TestIntegration_AlertsUpdate_Validation(t *testing.T) {
 	srv, _ := newMockServer(t)
 	defer srv.Close()
 
-	stdout, _, exitCode := runCLI(t, srv.URL, "schedules", "list")
+	for _, tc := range []struct {
+		args []string
+		want string
+	}{
+		{nil, "nothing to update"},
+		{[]string{"--priority", "P9"}, "invalid --priority"},
+		{[]string{"--detail", "novalue"}, "use key=value"},
+	} {
+		args := append([]string{"alerts", "update", "alert-id-123"}, tc.args...)
+		_, stderr, exitCode := runCLI(t, srv.URL, args...)
+		if exitCode == 0 {
+			t.Errorf("%v: expected a non-zero exit code", tc.args)
+		}
+		assertContains(t, stderr, tc.want)
+	}
+}
+
+func TestIntegration_AlertsUnacknowledge_SendsPOST(t *testing.T) {
+	srv, log := newMockServer(t)
+	defer srv.Close()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "alerts", "unack", "alert-id-123")
 	assertExitCode(t, exitCode, 0)
-	assertContains(t, stdout, "Test Schedule")
-	assertContains(t, stdout, "schedule-id-789")
+	assertContains(t, stderr, "unacknowledged")
+	if log.methods["/v2/alerts/alert-id-123/unacknowledge"] != http.MethodPost {
+		t.Errorf("expected POST to /unacknowledge, got methods: %v", log.methods)
+	}
 }
 
-func TestIntegration_SchedulesList_JSON(t *testing.T) {
-	srv, _ := newMockServer(t)
+func TestIntegration_AlertsExecuteAction_SendsPOST(t *testing.T) {
+	srv, log := newMockServer(t)
 	defer srv.Close()
 
-	stdout, _, exitCode := runCLI(t, srv.URL, "schedules", "list", "--json")
+	_, stderr, exitCode := runCLI(t, srv.URL, "alerts", "execute-action", "alert-id-123", "--action", "Restart")
 	assertExitCode(t, exitCode, 0)
-	assertValidJSON(t, stdout)
-	assertContains(t, stdout, "Test Schedule")
-	assertContains(t, stdout, "schedule-id-789")
+	assertContains(t, stderr, `Action "Restart" executed`)
+	if log.methods["/v2/alerts/alert-id-123/actions/Restart"] != http.MethodPost {
+		t.Errorf("expected POST to /actions/Restart, got methods: %v", log.methods)
+	}
+
+	_, stderr, exitCode = runCLI(t, srv.URL, "alerts", "execute-action", "alert-id-123")
+	if exitCode == 0 {
+		t.Error("expected a non-zero exit code without --action")
+	}
+	assertContains(t, stderr, "--action is required")
 }
 
-func TestIntegration_SchedulesList_Plaintext(t *testing.T) {
-	srv, _ := newMockServer(t)
+func TestIntegration_AlertsAttach(t *testing.T) {
+	srv, log := newMockServer(t)
 	defer srv.Close()
 
-	stdout, _, exitCode := runCLI(t, srv.URL, "schedules", "list", "--plaintext")
+	file := writeTempFile(t, "dump.log", "boom\n")
+	_, stderr, exitCode := runCLI(t, srv.URL, "alerts", "attach", "alert-id-123", "--file", file)
 	assertExitCode(t, exitCode, 0)
-	assertContains(t, stdout, "Test Schedule")
-	assertContains(t, stdout, "\t")
-	assertNotContains(t, stdout, "\033[")
+	assertContains(t, stderr, "Attached dump.log (5 bytes)")
+	if log.methods["/v2/alerts/alert-id-123/attachments"] != http.MethodPost {
+		t.Errorf("expected POST to /attachments, got methods: %v", log.methods)
+	}
 }
 
-func TestIntegration_SchedulesList_NoColor(t *testing.T) {
+func TestIntegration_AlertsAttachments_ListAndDownload(t *testing.T) {
 	srv, _ := newMockServer(t)
 	defer srv.Close()
 
-	stdout, _, exitCode := runCLI(t, srv.URL, "schedules", "list", "--no-color")
+	stdout, _, exitCode := runCLI(t, srv.URL, "alerts", "attachments", "list", "alert-id-123")
 	assertExitCode(t, exitCode, 0)
-	assertContains(t, stdout, "Test Schedule")
-	assertNotContains(t, stdout, "\033[")
+	assertContains(t, stdout, "att-1")
+	assertContains(t, stdout, "dump.log")
+
+	dest := filepath.Join(t.TempDir(), "out.log")
+	_, stderr, exitCode := runCLI(t, srv.URL, "alerts", "attachments", "download", "alert-id-123", "att-1", "--file", dest)
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stderr, "Saved "+dest)
+	b, err := os.ReadFile(dest)
+	if err != nil || string(b) != "log line 1\nlog line 2\n" {
+		t.Errorf("downloaded %q, %v", b, err)
+	}
+
+	stdout, _, exitCode = runCLI(t, srv.URL, "alerts", "attachments", "download", "alert-id-123", "att-1", "--file", "-")
+	assertExitCode(t, exitCode, 0)
+	if stdout != "log line 1\nlog line 2\n" {
+		t.Errorf("stdout = %q", stdout)
+	}
 }
 
-func TestIntegration_SchedulesList_Fields(t *testing.T) {
-	srv, _ := newMockServer(t)
+func TestIntegration_AlertsAssign_SendsPOST(t *testing.T) {
+	srv, log := newMockServer(t)
 	defer srv.Close()
 
-	stdout, _, exitCode := runCLI(t, srv.URL, "schedules", "list", "--json", "--fields", "id,name")
+	_, stderr, exitCode := runCLI(t, srv.URL, "alerts", "assign", "alert-id-123",
+		"--owner", "newowner@example.com")
 	assertExitCode(t, exitCode, 0)
-	assertValidJSON(t, stdout)
-	assertContains(t, stdout, "schedule-id-789")
-	assertNotContains(t, stdout, "timezone")
+	assertContains(t, stderr, "assigned")
+
+	found := false
+	for path, method := range log.methods {
+		if strings.Contains(path, "/assign") && method == http.MethodPost {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Errorf("expected POST to /assign endpoint, got methods: %v", log.methods)
+	}
 }
 
-func TestIntegration_SchedulesList_JQ(t *testing.T) {
-	srv, _ := newMockServer(t)
+func TestIntegration_AlertsAddNote_SendsPOST(t *testing.T) {
+	srv, log := newMockServer(t)
 	defer srv.Close()
 
-	stdout, _, exitCode := runCLI(t, srv.URL, "schedules", "list", "--json", "--jq", ".[0].name")
+	_, stderr, exitCode := runCLI(t, srv.URL, "alerts", "add-note", "alert-id-123",
+		"--note", "this is a test note")
 	assertExitCode(t, exitCode, 0)
-	assertContains(t, stdout, "Test Schedule")
+	assertContains(t, stderr, "Note added")
+
+	found := false
+	for path, method := range log.methods {
+		if strings.Contains(path, "/notes") && method == http.MethodPost {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Errorf("expected POST to /notes endpoint, got methods: %v", log.methods)
+	}
 }
 
-func TestIntegration_SchedulesList_Debug(t *testing.T) {
-	srv, _ := newMockServer(t)
+func TestIntegration_AlertsAddTags_SendsPOST(t *testing.T) {
+	srv, log := newMockServer(t)
 	defer srv.Close()
 
-	_, stderr, exitCode := runCLI(t, srv.URL, "schedules", "list", "--debug")
+	_, stderr, exitCode := runCLI(t, srv.URL, "alerts", "add-tags", "alert-id-123",
+		"--tags", "production,critical")
 	assertExitCode(t, exitCode, 0)
-	if !strings.Contains(stderr, "DEBUG") && !strings.Contains(stderr, "debug") {
-		t.Errorf("expected debug output in stderr, got: %q", stderr)
+	assertContains(t, stderr, "Tags added")
+
+	found := false
+	for path, method := range log.methods {
+		if strings.Contains(path, "/tags") && method == http.MethodPost {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Errorf("expected POST to /tags endpoint, got methods: %v", log.methods)
 	}
 }
 
-// ─── users list ───────────────────────────────────────────────────────────────
+// ─── teams list ───────────────────────────────────────────────────────────────
 
-func TestIntegration_UsersList_DefaultTable(t *testing.T) {
+func TestIntegration_TeamsList_DefaultTable(t *testing.T) {
 	srv, _ := newMockServer(t)
 	defer srv.Close()
 
-	stdout, _, exitCode := runCLI(t, srv.URL, "users", "list")
+	stdout, _, exitCode := runCLI(t, srv.URL, "teams", "list")
 	assertExitCode(t, exitCode, 0)
-	assertContains(t, stdout, "testuser@example.com")
-	assertContains(t, stdout, "user-id-001")
+	assertContains(t, stdout, "Test Team")
+	assertContains(t, stdout, "team-id-456")
 }
 
-func TestIntegration_UsersList_JSON(t *testing.T) {
+func TestIntegration_TeamsList_JSON(t *testing.T) {
 	srv, _ := newMockServer(t)
 	defer srv.Close()
 
-	stdout, _, exitCode := runCLI(t, srv.URL, "users", "list", "--json")
+	stdout, _, exitCode := runCLI(t, srv.URL, "teams", "list", "--json")
 	assertExitCode(t, exitCode, 0)
 	assertValidJSON(t, stdout)
-	assertContains(t, stdout, "testuser@example.com")
-	assertContains(t, stdout, "user-id-001")
+	assertContains(t, stdout, "Test Team")
+	assertContains(t, stdout, "team-id-456")
 }
 
-func TestIntegration_UsersList_Plaintext(t *testing.T) {
+func TestIntegration_TeamsList_YAML(t *testing.T) {
 	srv, _ := newMockServer(t)
 	defer srv.Close()
 
-	stdout, _, exitCode := runCLI(t, srv.URL, "users", "list", "--plaintext")
+	for _, args := range [][]string{{"--yaml"}, {"-o", "yaml"}} {
+		stdout, _, exitCode := runCLI(t, srv.URL, append([]string{"teams", "list"}, args...)...)
+		assertExitCode(t, exitCode, 0)
+		assertContains(t, stdout, "  id: team-id-456")
+		assertContains(t, stdout, "name: Test Team")
+	}
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "teams", "list", "-o", "yaml", "--fields", "name")
 	assertExitCode(t, exitCode, 0)
-	assertContains(t, stdout, "testuser@example.com")
-	assertContains(t, stdout, "\t")
-	assertNotContains(t, stdout, "\033[")
+	if strings.TrimSpace(stdout) != "- name: Test Team" {
+		t.Errorf("unexpected filtered YAML: %q", stdout)
+	}
 }
 
-func TestIntegration_UsersList_NoColor(t *testing.T) {
+func TestIntegration_AlertsList_CSV(t *testing.T) {
 	srv, _ := newMockServer(t)
 	defer srv.Close()
 
-	stdout, _, exitCode := runCLI(t, srv.URL, "users", "list", "--no-color")
+	stdout, _, exitCode := runCLI(t, srv.URL, "alerts", "list", "-o", "csv")
 	assertExitCode(t, exitCode, 0)
-	assertContains(t, stdout, "testuser@example.com")
-	assertNotContains(t, stdout, "\033[")
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if lines[0] != "ID,Message,Status,Priority,Acknowledged,CreatedAt" {
+		t.Errorf("unexpected CSV header: %q", lines[0])
+	}
+	assertContains(t, stdout, "alert-id-123,Test alert message,open,P3")
+
+	stdout, _, exitCode = runCLI(t, srv.URL, "alerts", "list", "-o", "tsv", "--fields", "id,priority")
+	assertExitCode(t, exitCode, 0)
+	if stdout != "ID\tPriority\nalert-id-123\tP3\n" {
+		t.Errorf("unexpected TSV: %q", stdout)
+	}
 }
 
-func TestIntegration_UsersList_Fields(t *testing.T) {
+func TestIntegration_AlertsList_TableFields(t *testing.T) {
 	srv, _ := newMockServer(t)
 	defer srv.Close()
 
-	stdout, _, exitCode := runCLI(t, srv.URL, "users", "list", "--json", "--fields", "id,username")
+	stdout, _, exitCode := runCLI(t, srv.URL, "alerts", "list", "-p", "--fields", "tinyid,priority")
 	assertExitCode(t, exitCode, 0)
-	assertValidJSON(t, stdout)
-	assertContains(t, stdout, "testuser@example.com")
-	assertNotContains(t, stdout, "fullName")
+	if stdout != "tinyId\tPriority\n42\tP3\n" {
+		t.Errorf("unexpected plaintext: %q", stdout)
+	}
+
+	stdout, _, exitCode = runCLI(t, srv.URL, "alerts", "get", "alert-id-123", "-p", "--fields", "status")
+	assertExitCode(t, exitCode, 0)
+	if stdout != "Field\tValue\nStatus\topen\n" {
+		t.Errorf("unexpected plaintext: %q", stdout)
+	}
 }
 
-func TestIntegration_UsersList_JQ(t *testing.T) {
+func TestIntegration_AlertsList_IDOutput(t *testing.T) {
 	srv, _ := newMockServer(t)
 	defer srv.Close()
 
-	stdout, _, exitCode := runCLI(t, srv.URL, "users", "list", "--json", "--jq", ".[0].username")
+	stdout, _, exitCode := runCLI(t, srv.URL, "alerts", "list", "-o", "id")
 	assertExitCode(t, exitCode, 0)
-	assertContains(t, stdout, "testuser@example.com")
+	if stdout != "alert-id-123\n" {
+		t.Errorf("unexpected id output: %q", stdout)
+	}
+
+	stdout, _, exitCode = runCLI(t, srv.URL, "teams", "get", "team-id-456", "-o", "id")
+	assertExitCode(t, exitCode, 0)
+	if stdout != "team-id-456\n" {
+		t.Errorf("unexpected id output: %q", stdout)
+	}
 }
 
-func TestIntegration_UsersList_Debug(t *testing.T) {
+func TestIntegration_AlertsList_Template(t *testing.T) {
 	srv, _ := newMockServer(t)
 	defer srv.Close()
 
-	_, stderr, exitCode := runCLI(t, srv.URL, "users", "list", "--debug")
+	stdout, _, exitCode := runCLI(t, srv.URL, "alerts", "list", "--template", "{{.tinyId}} {{.priority}} {{.message}}")
 	assertExitCode(t, exitCode, 0)
-	if !strings.Contains(stderr, "DEBUG") && !strings.Contains(stderr, "debug") {
-		t.Errorf("expected debug output in stderr, got: %q", stderr)
+	if stdout != "42 P3 Test alert message\n" {
+		t.Errorf("unexpected template output: %q", stdout)
 	}
 }
 
-// ─── escalations list ─────────────────────────────────────────────────────────
-
-func TestIntegration_EscalationsList_DefaultTable(t *testing.T) {
+func TestIntegration_Output_UnknownFormat(t *testing.T) {
 	srv, _ := newMockServer(t)
 	defer srv.Close()
 
-	stdout, _, exitCode := runCLI(t, srv.URL, "escalations", "list")
-	assertExitCode(t, exitCode, 0)
-	assertContains(t, stdout, "Test Escalation")
-	assertContains(t, stdout, "escalation-id-001")
+	_, stderr, exitCode := runCLI(t, srv.URL, "teams", "list", "-o", "xml")
+	assertExitCode(t, exitCode, 2)
+	assertContains(t, stderr, "unknown output format")
 }
 
-func TestIntegration_EscalationsList_JSON(t *testing.T) {
+func TestIntegration_TeamsList_Plaintext(t *testing.T) {
 	srv, _ := newMockServer(t)
 	defer srv.Close()
 
-	stdout, _, exitCode := runCLI(t, srv.URL, "escalations", "list", "--json")
+	stdout, _, exitCode := runCLI(t, srv.URL, "teams", "list", "--plaintext")
 	assertExitCode(t, exitCode, 0)
-	assertValidJSON(t, stdout)
-	assertContains(t, stdout, "Test Escalation")
+	assertContains(t, stdout, "Test Team")
+	assertContains(t, stdout, "\t")
+	assertNotContains(t, stdout, "\033[")
 }
 
-func TestIntegration_EscalationsList_Plaintext(t *testing.T) {
+func TestIntegration_TeamsList_NoColor(t *testing.T) {
 	srv, _ := newMockServer(t)
 	defer srv.Close()
 
-	stdout, _, exitCode := runCLI(t, srv.URL, "escalations", "list", "--plaintext")
+	stdout, _, exitCode := runCLI(t, srv.URL, "teams", "list", "--no-color")
 	assertExitCode(t, exitCode, 0)
-	assertContains(t, stdout, "Test Escalation")
-	assertContains(t, stdout, "\t")
+	assertContains(t, stdout, "Test Team")
 	assertNotContains(t, stdout, "\033[")
 }
 
-func TestIntegration_EscalationsList_NoColor(t *testing.T) {
+func TestIntegration_TeamsList_Fields(t *testing.T) {
 	srv, _ := newMockServer(t)
 	defer srv.Close()
 
-	stdout, _, exitCode := runCLI(t, srv.URL, "escalations", "list", "--no-color")
+	stdout, _, exitCode := runCLI(t, srv.URL, "teams", "list", "--json", "--fields", "id,name")
 	assertExitCode(t, exitCode, 0)
-	assertContains(t, stdout, "Test Escalation")
-	assertNotContains(t, stdout, "\033[")
+	assertValidJSON(t, stdout)
+	assertContains(t, stdout, "team-id-456")
+	assertContains(t, stdout, "Test Team")
+	assertNotContains(t, stdout, "description")
 }
 
-func TestIntegration_EscalationsList_Fields(t *testing.T) {
+func TestIntegration_TeamsList_JQ(t *testing.T) {
 	srv, _ := newMockServer(t)
 	defer srv.Close()
 
-	stdout, _, exitCode := runCLI(t, srv.URL, "escalations", "list", "--json", "--fields", "id,name")
+	stdout, _, exitCode := runCLI(t, srv.URL, "teams", "list", "--json", "--jq", ".[0].name")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "Test Team")
+}
+
+func TestIntegration_TeamsList_Debug(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "teams", "list", "--debug")
+	assertExitCode(t, exitCode, 0)
+	if !strings.Contains(stderr, "DEBUG") && !strings.Contains(stderr, "debug") {
+		t.Errorf("expected debug output in stderr, got: %q", stderr)
+	}
+}
+
+// ─── teams get ────────────────────────────────────────────────────────────────
+
+func TestIntegration_TeamsGet_JSON(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "teams", "get", "team-id-456", "--json")
 	assertExitCode(t, exitCode, 0)
 	assertValidJSON(t, stdout)
-	assertContains(t, stdout, "escalation-id-001")
-	assertNotContains(t, stdout, "description")
+	assertContains(t, stdout, "Test Team")
 }
 
-func TestIntegration_EscalationsList_JQ(t *testing.T) {
+func TestIntegration_TeamsGet_Members(t *testing.T) {
+	srv, log := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "teams", "get", "team-with-members", "--plaintext")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "Username\tRole\tUserID")
+	assertContains(t, stdout, "\tadmin\tuser-id-001")
+	assertContains(t, stdout, "bob@example.com\tuser\tuser-id-002")
+	if log.lastMethod("/v2/users/user-id-001") != "" {
+		t.Error("looked up users without --resolve-users")
+	}
+
+	stdout, _, exitCode = runCLI(t, srv.URL, "teams", "get", "team-with-members", "--plaintext", "--resolve-users")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "testuser@example.com\tTest User\tadmin\tuser-id-001")
+}
+
+func TestIntegration_TeamsGet_WithRoutingRulesAndEscalations(t *testing.T) {
 	srv, _ := newMockServer(t)
 	defer srv.Close()
 
-	stdout, _, exitCode := runCLI(t, srv.URL, "escalations", "list", "--json", "--jq", ".[0].name")
+	stdout, _, exitCode := runCLI(t, srv.URL, "teams", "get", "team-id-456", "--with-routing-rules", "--with-escalations")
 	assertExitCode(t, exitCode, 0)
-	assertContains(t, stdout, "Test Escalation")
+	assertContains(t, stdout, "Routing rules")
+	assertContains(t, stdout, "schedule: Test Schedule")
+	assertContains(t, stdout, "Escalations")
+	assertContains(t, stdout, "escalation-id-001")
+
+	stdout, _, exitCode = runCLI(t, srv.URL, "teams", "get", "team-id-456", "--with-routing-rules", "--with-escalations", "--json")
+	assertExitCode(t, exitCode, 0)
+	var got struct {
+		RoutingRules []map[string]interface{} `json:"routingRules"`
+		Escalations  []map[string]interface{} `json:"escalations"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout)
+	}
+	if len(got.RoutingRules) != 1 || len(got.Escalations) != 1 {
+		t.Errorf("routingRules/escalations = %d/%d, want 1/1", len(got.RoutingRules), len(got.Escalations))
+	}
 }
 
-func TestIntegration_EscalationsList_Debug(t *testing.T) {
+// ─── teams logs ───────────────────────────────────────────────────────────────
+
+func TestIntegration_TeamsLogs(t *testing.T) {
+	srv, log := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "teams", "logs", "team-id-456")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "Routing rule Database moved to order 0")
+	assertContains(t, stdout, "admin@example.com")
+	if log.lastMethod("/v2/teams/team-id-456/logs") != http.MethodGet {
+		t.Error("expected GET /v2/teams/team-id-456/logs")
+	}
+}
+
+func TestIntegration_TeamsLogs_InvalidOrder(t *testing.T) {
 	srv, _ := newMockServer(t)
 	defer srv.Close()
 
-	_, stderr, exitCode := runCLI(t, srv.URL, "escalations", "list", "--debug")
+	_, stderr, exitCode := runCLI(t, srv.URL, "teams", "logs", "team-id-456", "--order", "newest")
+	assertExitCode(t, exitCode, 1)
+	assertContains(t, stderr, "invalid --order")
+}
+
+// ─── team-routing-rules change-order ──────────────────────────────────────────
+
+func TestIntegration_TeamRoutingRulesChangeOrder(t *testing.T) {
+	srv, log := newMockServer(t)
+	defer srv.Close()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "team-routing-rules", "change-order", "--team", "team-id-456", "--id", "rule-id-1", "--order", "2")
 	assertExitCode(t, exitCode, 0)
-	if !strings.Contains(stderr, "DEBUG") && !strings.Contains(stderr, "debug") {
-		t.Errorf("expected debug output in stderr, got: %q", stderr)
+	assertContains(t, stderr, "moved to position 2")
+	if log.lastMethod("/v2/teams/team-id-456/routing-rules/rule-id-1/change-order") != http.MethodPost {
+		t.Error("expected POST to change-order")
 	}
 }
 
-// ─── heartbeats list ──────────────────────────────────────────────────────────
+func TestIntegration_TeamRoutingRulesChangeOrder_RequiresOrder(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
 
-func TestIntegration_HeartbeatsList_DefaultTable(t *testing.T) {
+	_, stderr, exitCode := runCLI(t, srv.URL, "team-routing-rules", "change-order", "--team", "team-id-456", "--id", "rule-id-1")
+	assertExitCode(t, exitCode, 1)
+	assertContains(t, stderr, "--order is required")
+}
+
+// ─── schedules list ───────────────────────────────────────────────────────────
+
+func TestIntegration_SchedulesList_DefaultTable(t *testing.T) {
 	srv, _ := newMockServer(t)
 	defer srv.Close()
 
-	stdout, _, exitCode := runCLI(t, srv.URL, "heartbeats", "list")
+	stdout, _, exitCode := runCLI(t, srv.URL, "schedules", "list")
 	assertExitCode(t, exitCode, 0)
-	assertContains(t, stdout, "test-heartbeat")
+	assertContains(t, stdout, "Test Schedule")
+	assertContains(t, stdout, "schedule-id-789")
 }
 
-func TestIntegration_HeartbeatsList_JSON(t *testing.T) {
+func TestIntegration_SchedulesList_JSON(t *testing.T) {
 	srv, _ := newMockServer(t)
 	defer srv.Close()
 
-	stdout, _, exitCode := runCLI(t, srv.URL, "heartbeats", "list", "--json")
+	stdout, _, exitCode := runCLI(t, srv.URL, "schedules", "list", "--json")
 	assertExitCode(t, exitCode, 0)
 	assertValidJSON(t, stdout)
-	assertContains(t, stdout, "test-heartbeat")
+	assertContains(t, stdout, "Test Schedule")
+	assertContains(t, stdout, "schedule-id-789")
 }
 
-func TestIntegration_HeartbeatsList_Plaintext(t *testing.T) {
+func TestIntegration_SchedulesList_Plaintext(t *testing.T) {
 	srv, _ := newMockServer(t)
 	defer srv.Close()
 
-	stdout, _, exitCode := runCLI(t, srv.URL, "heartbeats", "list", "--plaintext")
+	stdout, _, exitCode := runCLI(t, srv.URL, "schedules", "list", "--plaintext")
 	assertExitCode(t, exitCode, 0)
-	assertContains(t, stdout, "test-heartbeat")
+	assertContains(t, stdout, "Test Schedule")
 	assertContains(t, stdout, "\t")
 	assertNotContains(t, stdout, "\033[")
 }
 
-func TestIntegration_HeartbeatsList_NoColor(t *testing.T) {
+func TestIntegration_SchedulesList_NoColor(t *testing.T) {
 	srv, _ := newMockServer(t)
 	defer srv.Close()
 
-	stdout, _, exitCode := runCLI(t, srv.URL, "heartbeats", "list", "--no-color")
+	stdout, _, exitCode := runCLI(t, srv.URL, "schedules", "list", "--no-color")
 	assertExitCode(t, exitCode, 0)
-	assertContains(t, stdout, "test-heartbeat")
+	assertContains(t, stdout, "Test Schedule")
 	assertNotContains(t, stdout, "\033[")
 }
 
-func TestIntegration_HeartbeatsList_Fields(t *testing.T) {
+func TestIntegration_SchedulesList_Fields(t *testing.T) {
 	srv, _ := newMockServer(t)
 	defer srv.Close()
 
-	stdout, _, exitCode := runCLI(t, srv.URL, "heartbeats", "list", "--json", "--fields", "name,enabled")
+	stdout, _, exitCode := runCLI(t, srv.URL, "schedules", "list", "--json", "--fields", "id,name")
 	assertExitCode(t, exitCode, 0)
 	assertValidJSON(t, stdout)
-	assertContains(t, stdout, "test-heartbeat")
-	assertNotContains(t, stdout, "intervalUnit")
+	assertContains(t, stdout, "schedule-id-789")
+	assertNotContains(t, stdout, "timezone")
 }
 
-func TestIntegration_HeartbeatsList_JQ(t *testing.T) {
+func TestIntegration_SchedulesList_JQ(t *testing.T) {
 	srv, _ := newMockServer(t)
 	defer srv.Close()
 
-	stdout, _, exitCode := runCLI(t, srv.URL, "heartbeats", "list", "--json", "--jq", ".[0].name")
+	stdout, _, exitCode := runCLI(t, srv.URL, "schedules", "list", "--json", "--jq", ".[0].name")
 	assertExitCode(t, exitCode, 0)
-	assertContains(t, stdout, "test-heartbeat")
+	assertContains(t, stdout, "Test Schedule")
 }
 
-func TestIntegration_HeartbeatsList_Debug(t *testing.T) {
+func TestIntegration_SchedulesList_Debug(t *testing.T) {
 	srv, _ := newMockServer(t)
 	defer srv.Close()
 
-	_, stderr, exitCode := runCLI(t, srv.URL, "heartbeats", "list", "--debug")
+	_, stderr, exitCode := runCLI(t, srv.URL, "schedules", "list", "--debug")
 	assertExitCode(t, exitCode, 0)
 	if !strings.Contains(stderr, "DEBUG") && !strings.Contains(stderr, "debug") {
 		t.Errorf("expected debug output in stderr, got: %q", stderr)
 	}
 }
 
-// ─── heartbeats get ───────────────────────────────────────────────────────────
+// ─── oncall ────────────────────────────────────────────────────────────────────
 
-func TestIntegration_HeartbeatsGet_DefaultTable(t *testing.T) {
+func TestIntegration_OnCallList_DefaultTable(t *testing.T) {
 	srv, _ := newMockServer(t)
 	defer srv.Close()
 
-	stdout, _, exitCode := runCLI(t, srv.URL, "heartbeats", "get", "test-heartbeat")
+	stdout, _, exitCode := runCLI(t, srv.URL, "oncall", "list")
 	assertExitCode(t, exitCode, 0)
-	assertContains(t, stdout, "test-heartbeat")
+	assertContains(t, stdout, "Test Schedule")
+	assertContains(t, stdout, "alice@example.com")
 }
 
-func TestIntegration_HeartbeatsGet_JSON(t *testing.T) {
+func TestIntegration_OnCallWhoami_JSON(t *testing.T) {
 	srv, _ := newMockServer(t)
 	defer srv.Close()
 
-	stdout, _, exitCode := runCLI(t, srv.URL, "heartbeats", "get", "test-heartbeat", "--json")
+	stdout, _, exitCode := runCLI(t, srv.URL, "oncall", "whoami", "--user", "Alice@example.com", "--json")
 	assertExitCode(t, exitCode, 0)
 	assertValidJSON(t, stdout)
-	assertContains(t, stdout, "test-heartbeat")
+	assertContains(t, stdout, `"onCall": true`)
+	assertContains(t, stdout, "schedule-id-789")
 }
 
-func TestIntegration_HeartbeatsGet_Plaintext(t *testing.T) {
+func TestIntegration_OnCallWhoami_RequiresUser(t *testing.T) {
 	srv, _ := newMockServer(t)
 	defer srv.Close()
 
-	stdout, _, exitCode := runCLI(t, srv.URL, "heartbeats", "get", "test-heartbeat", "--plaintext")
+	_, stderr, exitCode := runCLI(t, srv.URL, "oncall", "whoami")
+	assertExitCode(t, exitCode, 1)
+	assertContains(t, stderr, "OPSGENIE_USER")
+}
+
+func TestIntegration_OnCallForTeam(t *testing.T) {
+	srv, log := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "oncall", "for-team", "team-id-456")
 	assertExitCode(t, exitCode, 0)
-	assertContains(t, stdout, "test-heartbeat")
-	assertNotContains(t, stdout, "\033[")
+	assertContains(t, stdout, "Test Schedule")
+	assertContains(t, stdout, "rule: Default")
+	assertContains(t, stdout, "alice@example.com")
+	if log.lastMethod("/v2/schedules/schedule-id-789/on-calls") != http.MethodGet {
+		t.Error("expected GET /v2/schedules/schedule-id-789/on-calls")
+	}
 }
 
-func TestIntegration_HeartbeatsGet_NoColor(t *testing.T) {
+func TestIntegration_OnCallForTeam_OwnedSchedules(t *testing.T) {
+	platform := map[string]interface{}{"id": "team-platform", "name": "Platform"}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/teams":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": []interface{}{platform}})
+		case "/v2/teams/team-platform":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": platform})
+		case "/v2/teams/team-platform/routing-rules":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": []interface{}{map[string]interface{}{
+				"id": "r1", "name": "Default", "notify": map[string]interface{}{"type": "schedule", "id": "s1", "name": "primary"},
+			}}})
+		case "/v2/schedules":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": []interface{}{
+				map[string]interface{}{"id": "s1", "name": "primary", "ownerTeam": platform},
+				map[string]interface{}{"id": "s2", "name": "secondary", "ownerTeam": platform},
+				map[string]interface{}{"id": "s3", "name": "other"},
+			}})
+		case "/v2/schedules/s1/on-calls":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"onCallRecipients": []string{"alice@example.com"}}})
+		case "/v2/schedules/s2/on-calls":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"onCallRecipients": []string{}}})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"message": "not found"})
+		}
+	}))
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "oncall", "for-team", "platform", "--json")
+	assertExitCode(t, exitCode, 0)
+	var got struct {
+		Team      string
+		Schedules []struct {
+			Schedule struct{ Name string }
+			Via      []string
+			OnCall   []struct{ Name string }
+		}
+	}
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout)
+	}
+	if got.Team != "Platform" || len(got.Schedules) != 2 {
+		t.Fatalf("got %+v, want two schedules of Platform", got)
+	}
+	if s := got.Schedules[0]; s.Schedule.Name != "primary" || strings.Join(s.Via, ",") != "rule: Default,owner" || len(s.OnCall) != 1 {
+		t.Errorf("primary = %+v", s)
+	}
+	if s := got.Schedules[1]; s.Schedule.Name != "secondary" || len(s.OnCall) != 0 {
+		t.Errorf("secondary = %+v", s)
+	}
+}
+
+// ─── alert-policies ───────────────────────────────────────────────────────────
+
+func TestIntegration_AlertPoliciesList_DefaultTable(t *testing.T) {
 	srv, _ := newMockServer(t)
 	defer srv.Close()
 
-	stdout, _, exitCode := runCLI(t, srv.URL, "heartbeats", "get", "test-heartbeat", "--no-color")
+	stdout, _, exitCode := runCLI(t, srv.URL, "alert-policies", "list", "--team", "team-id-456")
 	assertExitCode(t, exitCode, 0)
-	assertContains(t, stdout, "test-heartbeat")
-	assertNotContains(t, stdout, "\033[")
+	assertContains(t, stdout, "policy-id-1")
+	assertContains(t, stdout, "Test Policy")
 }
 
-// ─── escalations get ─────────────────────────────────────────────────────────
+func TestIntegration_AlertPoliciesCreate_SendsPOST(t *testing.T) {
+	srv, log := newMockServer(t)
+	defer srv.Close()
 
-func TestIntegration_EscalationsGet_JSON(t *testing.T) {
+	_, stderr, exitCode := runCLI(t, srv.URL, "alert-policies", "create",
+		"--name", "Raise disk alerts",
+		"--condition", "message contains disk",
+		"--time-restriction", "mon 09:00-fri 17:00",
+		"--priority", "P2")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stderr, "created")
+	if log.lastMethod("/v2/policies") != http.MethodPost {
+		t.Errorf("expected POST to /v2/policies, got methods: %v", log.methods)
+	}
+}
+
+func TestIntegration_AlertPoliciesCreate_InvalidCondition(t *testing.T) {
 	srv, _ := newMockServer(t)
 	defer srv.Close()
 
-	stdout, _, exitCode := runCLI(t, srv.URL, "escalations", "get", "escalation-id-001", "--json")
+	_, stderr, exitCode := runCLI(t, srv.URL, "alert-policies", "create",
+		"--name", "bad", "--condition", "message frobnicates disk")
+	assertExitCode(t, exitCode, 1)
+	assertContains(t, stderr, "unknown operation")
+}
+
+func TestIntegration_NotificationPoliciesChangeOrder_SendsPOST(t *testing.T) {
+	srv, log := newMockServer(t)
+	defer srv.Close()
+
+	_, _, exitCode := runCLI(t, srv.URL, "notification-policies", "change-order", "policy-id-1", "--index", "2")
 	assertExitCode(t, exitCode, 0)
-	assertValidJSON(t, stdout)
-	assertContains(t, stdout, "Test Escalation")
+	if log.lastMethod("/v2/policies/policy-id-1/change-order") != http.MethodPost {
+		t.Errorf("expected POST to change-order, got methods: %v", log.methods)
+	}
 }
 
-// ─── schedules get ────────────────────────────────────────────────────────────
+// ─── policies (legacy) ────────────────────────────────────────────────────────
 
-func TestIntegration_SchedulesGet_JSON(t *testing.T) {
+func TestIntegration_PoliciesList_FallsBackToV2(t *testing.T) {
+	srv, log := newMockServer(t)
+	defer srv.Close()
+
+	// The mock has no /v1/policies, like an account where v1 is disabled.
+	stdout, _, exitCode := runCLI(t, srv.URL, "policies", "list")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "Test Policy")
+	if log.lastMethod("/v2/policies/notification") != http.MethodGet {
+		t.Errorf("expected v2 list of notification policies, got methods: %v", log.methods)
+	}
+}
+
+func TestIntegration_PoliciesList_APIVersionV1NoFallback(t *testing.T) {
+	srv, log := newMockServer(t)
+	defer srv.Close()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "policies", "list", "--api-version", "v1")
+	assertExitCode(t, exitCode, 4)
+	assertContains(t, stderr, "404")
+	if log.lastMethod("/v2/policies/alert") != "" {
+		t.Errorf("expected no v2 request, got methods: %v", log.methods)
+	}
+}
+
+func TestIntegration_PoliciesEnable_TeamUsesV2(t *testing.T) {
+	srv, log := newMockServer(t)
+	defer srv.Close()
+
+	_, _, exitCode := runCLI(t, srv.URL, "policies", "enable", "policy-id-1", "--team", "team-id-456")
+	assertExitCode(t, exitCode, 0)
+	if log.lastMethod("/v2/policies/policy-id-1/enable") != http.MethodPost {
+		t.Errorf("expected POST to v2 enable, got methods: %v", log.methods)
+	}
+	if log.lastMethod("/v1/policies/policy-id-1/enable") != "" {
+		t.Errorf("expected no v1 request with --team, got methods: %v", log.methods)
+	}
+}
+
+// ─── lint tags ────────────────────────────────────────────────────────────────
+
+func writeTempFile(t *testing.T, name, content string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+	return file
+}
+
+func TestIntegration_LintTags_Violations(t *testing.T) {
 	srv, _ := newMockServer(t)
 	defer srv.Close()
 
-	stdout, _, exitCode := runCLI(t, srv.URL, "schedules", "get", "schedule-id-789", "--json")
+	policy := writeTempFile(t, "tags.yaml", "required:\n  \"*\": [\"env:*\"]\n")
+	stdout, stderr, exitCode := runCLI(t, srv.URL, "lint", "tags", "--policy", policy, "--no-integrations")
+	assertExitCode(t, exitCode, 1)
+	assertContains(t, stdout, "alert-id-123")
+	assertContains(t, stdout, "missing required tag")
+	assertContains(t, stderr, "violation")
+}
+
+func TestIntegration_LintTags_Clean(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	policy := writeTempFile(t, "tags.yaml", "allowed: [\"tag*\"]\n")
+	stdout, _, exitCode := runCLI(t, srv.URL, "lint", "tags", "--policy", policy, "--no-integrations", "--json")
 	assertExitCode(t, exitCode, 0)
 	assertValidJSON(t, stdout)
-	assertContains(t, stdout, "Test Schedule")
 }
 
-// ─── users get ────────────────────────────────────────────────────────────────
+func TestIntegration_LintPriority_Clean(t *testing.T) {
+	srv, log := newMockServer(t)
+	defer srv.Close()
 
-func TestIntegration_UsersGet_JSON(t *testing.T) {
+	policy := writeTempFile(t, "priority.yaml", "max_unacked: 15m\n")
+	_, stderr, exitCode := runCLI(t, srv.URL, "lint", "priority", "--policy", policy, "--no-integrations", "--no-policies")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stderr, "No policy violations found")
+	if log.lastMethod("/v2/alerts") != http.MethodGet {
+		t.Error("expected alerts to be fetched")
+	}
+}
+
+func TestIntegration_LintPriority_InvalidPolicy(t *testing.T) {
 	srv, _ := newMockServer(t)
 	defer srv.Close()
 
-	stdout, _, exitCode := runCLI(t, srv.URL, "users", "get", "user-id-001", "--json")
+	policy := writeTempFile(t, "priority.yaml", "max_unacked: soon\n")
+	_, stderr, exitCode := runCLI(t, srv.URL, "lint", "priority", "--policy", policy)
+	assertExitCode(t, exitCode, 1)
+	assertContains(t, stderr, "max_unacked")
+}
+
+// ─── alerts list --around-deployment ──────────────────────────────────────────
+
+func TestIntegration_AlertsList_AroundDeployment(t *testing.T) {
+	srv, log := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "alerts", "list", "--around-deployment", "deployment-id-1", "--json")
 	assertExitCode(t, exitCode, 0)
-	assertValidJSON(t, stdout)
-	assertContains(t, stdout, "testuser@example.com")
+	assertContains(t, stdout, "alert-id-123")
+	if log.lastMethod("/v2/deployments/deployment-id-1") != http.MethodGet {
+		t.Error("expected deployment lookup")
+	}
+}
+
+func TestIntegration_AlertsList_AroundDeploymentFiltersByServiceTeam(t *testing.T) {
+	srv, log := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "alerts", "list", "--around-deployment", "with-service", "--window", "10", "--json")
+	assertExitCode(t, exitCode, 0)
+	if strings.Contains(stdout, "alert-id-123") {
+		t.Error("alert without the service's team should be filtered out")
+	}
+	if log.lastMethod("/v1/services/service-id-1") != http.MethodGet {
+		t.Error("expected service lookup")
+	}
+}
+
+// ─── open ─────────────────────────────────────────────────────────────────────
+
+func TestIntegration_Open_Print(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "open", "alert", "alert-id-123", "--print")
+	assertExitCode(t, exitCode, 0)
+	if strings.TrimSpace(stdout) != "https://acme.app.opsgenie.com/alert/detail/alert-id-123/details" {
+		t.Errorf("unexpected link: %q", stdout)
+	}
+
+	stdout, _, exitCode = runCLI(t, srv.URL, "--region", "eu", "open", "schedule", "schedule-id-789", "--json")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, `"url": "https://acme.app.eu.opsgenie.com/settings/schedule/detail/schedule-id-789"`)
+}
+
+func TestIntegration_Open_UnknownKind(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "open", "widget", "1", "--print")
+	assertExitCode(t, exitCode, 1)
+	assertContains(t, stderr, "unknown resource kind")
+}
+
+// ─── mock-server ──────────────────────────────────────────────────────────────
+
+// startMockServerCommand runs "mock-server" with extra args on a free port
+// and returns its URL once it accepts connections.
+func startMockServerCommand(t *testing.T, args ...string) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	port := strconv.Itoa(ln.Addr().(*net.TCPAddr).Port)
+	_ = ln.Close()
+
+	server := exec.Command(binaryPath, append([]string{"mock-server", "--port", port}, args...)...)
+	var serverErr bytes.Buffer
+	server.Stderr = &serverErr
+	if err := server.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = server.Process.Kill()
+		_ = server.Wait()
+	})
+
+	for i := 0; ; i++ {
+		conn, err := net.Dial("tcp", addr)
+		if err == nil {
+			_ = conn.Close()
+			return "http://" + addr
+		}
+		if i == 50 {
+			t.Fatalf("mock server did not start: %s", serverErr.String())
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+func TestIntegration_MockServer_ServesCLI(t *testing.T) {
+	fixtures := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(fixtures, "v2"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(fixtures, "v2", "teams.json"),
+		[]byte(`{"data": [{"id": "fixture-team", "name": "From Fixture"}]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	url := startMockServerCommand(t, "--fixtures", fixtures)
+
+	stdout, _, exitCode := runCLI(t, url, "alerts", "list", "-o", "id")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "alert-id-123")
+
+	stdout, _, exitCode = runCLI(t, url, "teams", "list", "--json")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "From Fixture")
+
+	_, _, exitCode = runCLI(t, url, "alerts", "acknowledge", "alert-id-123")
+	assertExitCode(t, exitCode, 0)
+}
+
+func TestIntegration_MockServer_ScriptedRetry(t *testing.T) {
+	script := writeTempFile(t, "script.yaml", `
+- path: /v2/teams
+  responses:
+    - status: 429
+    - status: 200
+`)
+	url := startMockServerCommand(t, "--script", script)
+
+	stdout, _, exitCode := runCLI(t, url, "teams", "list", "-o", "id")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "team-id-456")
+}
+
+// ─── incidents ────────────────────────────────────────────────────────────────
+
+func TestIntegration_IncidentsGet_ImpactedServices(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "incidents", "get", "incident-id-001", "-p")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "ImpactedServices\tapi, service-id-gone")
+	assertContains(t, stdout, "Actions\tRestart")
+	assertContains(t, stdout, "Link\thttps://acme.app.opsgenie.com/incident/detail/incident-id-001")
+
+	stdout, _, exitCode = runCLI(t, srv.URL, "incidents", "get", "incident-id-001", "--json")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, `"impactedServices": [`)
+	assertContains(t, stdout, `"impactedServiceNames": [`)
+}
+
+func TestIntegration_IncidentsAddResponder(t *testing.T) {
+	srv, log := newMockServer(t)
+	defer srv.Close()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "incidents", "add-responder", "incident-id-001", "--responders", "team:database")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stderr, "Responders added")
+	if log.methods["/v1/incidents/incident-id-001/responders"] != http.MethodPost {
+		t.Errorf("expected POST to /responders, got methods: %v", log.methods)
+	}
+}
+
+func TestIntegration_IncidentsAssociateAndDetachAlert(t *testing.T) {
+	srv, log := newMockServer(t)
+	defer srv.Close()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "incidents", "associate-alert", "incident-id-001", "--alerts", "alert-id-123,alert-id-456")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stderr, "2 alert(s) associated")
+
+	_, stderr, exitCode = runCLI(t, srv.URL, "incidents", "detach-alert", "incident-id-001", "--alerts", "alert-id-123")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stderr, "1 alert(s) detached")
+	for _, path := range []string{"/v1/incidents/incident-id-001/associate-alerts", "/v1/incidents/incident-id-001/detach-alerts"} {
+		if log.methods[path] != http.MethodPost {
+			t.Errorf("expected POST to %s, got methods: %v", path, log.methods)
+		}
+	}
+}
+
+func TestIntegration_IncidentsUpdatePriorityAndMessage(t *testing.T) {
+	srv, log := newMockServer(t)
+	defer srv.Close()
+
+	_, _, exitCode := runCLI(t, srv.URL, "incidents", "update-priority", "incident-id-001", "--priority", "p2")
+	assertExitCode(t, exitCode, 0)
+	_, _, exitCode = runCLI(t, srv.URL, "incidents", "update-message", "incident-id-001", "--message", "Checkout degraded")
+	assertExitCode(t, exitCode, 0)
+	for _, path := range []string{"/v1/incidents/incident-id-001/priority", "/v1/incidents/incident-id-001/message"} {
+		if log.methods[path] != http.MethodPut {
+			t.Errorf("expected PUT to %s, got methods: %v", path, log.methods)
+		}
+	}
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "incidents", "update-priority", "incident-id-001", "--priority", "urgent")
+	assertExitCode(t, exitCode, 2)
+	assertContains(t, stderr, "invalid --priority")
+}
+
+func TestIntegration_IncidentsNotesAndLogs(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "incidents", "notes", "list", "incident-id-001")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "Rolled back the deploy")
+
+	stdout, _, exitCode = runCLI(t, srv.URL, "incidents", "logs", "incident-id-001", "--json")
+	assertExitCode(t, exitCode, 0)
+	assertValidJSON(t, stdout)
+	assertContains(t, stdout, "Incident priority changed to P2")
+}
+
+func TestIntegration_IncidentsTimeline(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "incidents", "timeline", "incident-id-001", "-p")
+	assertExitCode(t, exitCode, 0)
+	created := strings.Index(stdout, "IncidentCreated")
+	added := strings.Index(stdout, "ResponderAdded")
+	if created < 0 || added < 0 || created > added {
+		t.Errorf("expected entries oldest first, got:\n%s", stdout)
+	}
+	assertContains(t, stdout, "Database team added as responder")
+}
+
+// ─── --copy ───────────────────────────────────────────────────────────────────
+
+// fakeClipboard puts an xclip on PATH that writes its input to the returned
+// file, so --copy can be tested without a display.
+func fakeClipboard(t *testing.T) string {
+	t.Helper()
+	if runtime.GOOS != "linux" {
+		t.Skip("fake xclip is a shell script")
+	}
+	cat, err := exec.LookPath("cat")
+	if err != nil {
+		t.Skip("cat not found")
+	}
+	dir := t.TempDir()
+	out := filepath.Join(dir, "clipboard")
+	script := "#!/bin/sh\n" + cat + " > " + out + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "xclip"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir)
+	t.Setenv("WAYLAND_DISPLAY", "")
+	return out
+}
+
+func TestIntegration_Copy_ID(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+	clip := fakeClipboard(t)
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "alerts", "get", "alert-id-123", "--copy")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stderr, "Copied 1 ID to clipboard")
+	b, err := os.ReadFile(clip)
+	if err != nil {
+		t.Fatalf("clipboard not written: %v", err)
+	}
+	if string(b) != "alert-id-123" {
+		t.Errorf("clipboard = %q", b)
+	}
+}
+
+func TestIntegration_Copy_Link(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+	clip := fakeClipboard(t)
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "teams", "list", "--copy=link")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stderr, "Copied 1 link to clipboard")
+	b, err := os.ReadFile(clip)
+	if err != nil {
+		t.Fatalf("clipboard not written: %v", err)
+	}
+	if string(b) != "https://acme.app.opsgenie.com/teams/dashboard/team-id-456/main" {
+		t.Errorf("clipboard = %q", b)
+	}
+}
+
+func TestIntegration_Copy_InvalidValue(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "teams", "get", "team-id-456", "--copy=name")
+	assertExitCode(t, exitCode, 1)
+	assertContains(t, stderr, "invalid --copy value")
+}
+
+// ─── export ───────────────────────────────────────────────────────────────────
+
+func TestIntegration_Export_WritesFiles(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	dir := t.TempDir()
+	_, stderr, exitCode := runCLI(t, srv.URL, "export", "--dir", dir, "--kinds", "teams,schedules", "--format", "yaml")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stderr, "Exported 2 resources")
+
+	b, err := os.ReadFile(filepath.Join(dir, "schedules", "Test-Schedule.yaml"))
+	if err != nil {
+		t.Fatalf("expected schedule file: %v", err)
+	}
+	assertContains(t, string(b), "schedule-id-789")
+}
+
+func TestIntegration_Export_ProgressJSON(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "export", "--dir", t.TempDir(), "--kinds", "teams,schedules", "--progress", "json")
+	assertExitCode(t, exitCode, 0)
+	want := []string{
+		`{"type":"progress","phase":"export","item":"teams","done":1,"total":2,"percent":50}`,
+		`{"type":"progress","phase":"export","item":"schedules","done":2,"total":2,"percent":100}`,
+	}
+	for _, w := range want {
+		assertContains(t, stderr, w+"\n")
+	}
+
+	_, stderr, exitCode = runCLI(t, srv.URL, "export", "--dir", t.TempDir(), "--progress", "bars")
+	assertExitCode(t, exitCode, 2)
+	assertContains(t, stderr, "invalid --progress")
+}
+
+func TestIntegration_Export_SignedArchive(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("fake gpg is a shell script")
+	}
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	// A fake gpg that records its arguments and writes a signature file.
+	bin := t.TempDir()
+	script := "#!/bin/sh\necho \"$@\" > " + filepath.Join(bin, "args") + "\nwhile [ \"$1\" != --output ]; do shift; done\necho sig > \"$2\"\n"
+	if err := os.WriteFile(filepath.Join(bin, "gpg"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	archive := filepath.Join(t.TempDir(), "snapshot.tar.gz")
+	_, stderr, exitCode := runCLI(t, srv.URL, "export", "--archive", archive, "--kinds", "teams", "--sign", "gpg", "--sign-key", "ops@example.com")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stderr, "Exported 1 resources to "+archive)
+	assertContains(t, stderr, "Signed "+archive)
+
+	first, err := os.ReadFile(archive)
+	if err != nil {
+		t.Fatalf("archive not written: %v", err)
+	}
+	sum, err := os.ReadFile(archive + ".sha256")
+	if err != nil {
+		t.Fatalf("checksum not written: %v", err)
+	}
+	assertContains(t, string(sum), "  snapshot.tar.gz")
+	args, _ := os.ReadFile(filepath.Join(bin, "args"))
+	assertContains(t, string(args), "--local-user ops@example.com")
+	if _, err := os.Stat(archive + ".asc"); err != nil {
+		t.Errorf("signature not written: %v", err)
+	}
+
+	_, _, exitCode = runCLI(t, srv.URL, "export", "--archive", archive, "--kinds", "teams")
+	assertExitCode(t, exitCode, 0)
+	second, _ := os.ReadFile(archive)
+	if !bytes.Equal(first, second) {
+		t.Error("re-exporting an unchanged configuration changed the archive")
+	}
+}
+
+func TestIntegration_Export_NeedsOneDestination(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "export")
+	assertExitCode(t, exitCode, 1)
+	assertContains(t, stderr, "exactly one of --dir or --archive")
+}
+
+// ─── apply ────────────────────────────────────────────────────────────────────
+
+func writeApplyDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	_ = os.MkdirAll(filepath.Join(dir, "teams"), 0o755)
+	_ = os.WriteFile(filepath.Join(dir, "teams", "Test-Team.json"), []byte(`{"id":"team-id-456","name":"Test Team","description":"Changed"}`), 0o644)
+	_ = os.WriteFile(filepath.Join(dir, "teams", "New-Team.yaml"), []byte("name: New Team\n"), 0o644)
+	return dir
+}
+
+func TestIntegration_Apply_DryRun(t *testing.T) {
+	srv, log := newMockServer(t)
+	defer srv.Close()
+
+	stdout, stderr, exitCode := runCLI(t, srv.URL, "apply", "--dir", writeApplyDir(t), "--dry-run")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "create")
+	assertContains(t, stdout, "description")
+	assertContains(t, stderr, "1 to create, 1 to update, 0 unchanged (dry run)")
+	if m := log.lastMethod("/v2/teams"); m != http.MethodGet {
+		t.Errorf("dry run must not write, last /v2/teams method = %s", m)
+	}
+}
+
+func TestIntegration_Apply_Writes(t *testing.T) {
+	srv, log := newMockServer(t)
+	defer srv.Close()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "apply", "--dir", writeApplyDir(t))
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stderr, "1 created, 1 updated")
+	if log.lastMethod("/v2/teams") != http.MethodPost {
+		t.Error("expected POST /v2/teams")
+	}
+	if log.lastMethod("/v2/teams/team-id-456") != http.MethodPatch {
+		t.Error("expected PATCH /v2/teams/team-id-456")
+	}
+}
+
+func TestIntegration_Apply_PlanFileThenExecute(t *testing.T) {
+	srv, log := newMockServer(t)
+	defer srv.Close()
+
+	planFile := filepath.Join(t.TempDir(), "plan.json")
+	stdout, stderr, exitCode := runCLI(t, srv.URL, "apply", "--dir", writeApplyDir(t), "--plan-file", planFile)
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "POST")
+	assertContains(t, stdout, "/v2/teams/team-id-456")
+	assertContains(t, stderr, "Plan with 2 step(s) written")
+	if m := log.lastMethod("/v2/teams"); m != http.MethodGet {
+		t.Errorf("--plan-file must not write, last /v2/teams method = %s", m)
+	}
+
+	_, stderr, exitCode = runCLI(t, srv.URL, "execute-plan", planFile)
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stderr, "Executed 2 step(s)")
+	if log.lastMethod("/v2/teams") != http.MethodPost {
+		t.Error("expected POST /v2/teams")
+	}
+	if log.lastMethod("/v2/teams/team-id-456") != http.MethodPatch {
+		t.Error("expected PATCH /v2/teams/team-id-456")
+	}
+}
+
+func TestIntegration_SchedulesDisable_Plan(t *testing.T) {
+	var patched []string
+	srv := teamSchedulesServer(t, &patched)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "schedules", "disable", "--team", "platform", "--plan", "--json")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, `"path": "/v2/schedules/s1"`)
+	assertContains(t, stdout, "update schedules s1 (enabled)")
+	if len(patched) != 0 {
+		t.Errorf("patched %v with --plan", patched)
+	}
+}
+
+func TestIntegration_Apply_RequiresSource(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "apply")
+	assertExitCode(t, exitCode, 1)
+	assertContains(t, stderr, "--dir or --file")
+}
+
+// ─── migrate ──────────────────────────────────────────────────────────────────
+
+func writePagerDutyExport(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"users.json": `{"users": [{"id": "PU1", "name": "Test User", "email": "testuser@example.com"},
+			{"id": "PU2", "name": "New Person", "email": "new@example.com"}]}`,
+		"teams.json": `[{"id": "PT1", "name": "Test Team"}]`,
+		"schedules.json": `[{"id": "PS1", "name": "PD Primary", "time_zone": "UTC",
+			"schedule_layers": [{"name": "Weekly", "rotation_virtual_start": "2024-01-01T09:00:00Z", "rotation_turn_length_seconds": 604800,
+				"users": [{"user": {"id": "PU1"}}, {"user": {"id": "PU2"}}]}]}]`,
+		"services.json": `[{"id": "PSV1", "name": "checkout", "teams": [{"id": "PT1"}]}, {"id": "PSV2", "name": "orphan"}]`,
+	}
+	for name, body := range files {
+		_ = os.WriteFile(filepath.Join(dir, name), []byte(body), 0o644)
+	}
+	return dir
+}
+
+func TestIntegration_MigrateFromPagerDuty_DryRun(t *testing.T) {
+	srv, log := newMockServer(t)
+	defer srv.Close()
+
+	stdout, stderr, exitCode := runCLI(t, srv.URL, "migrate", "from-pagerduty", "-f", writePagerDutyExport(t), "--dry-run")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "new@example.com")
+	assertContains(t, stdout, "PD Primary / Weekly")
+	assertContains(t, stdout, "OpsGenie services need a team")
+	assertContains(t, stderr, "4 to create, 0 to update, 2 unchanged, 1 skipped (dry run)")
+	for _, path := range []string{"/v2/users", "/v2/schedules", "/v1/services"} {
+		if m := log.lastMethod(path); m == http.MethodPost {
+			t.Errorf("dry run must not write, got POST %s", path)
+		}
+	}
+}
+
+func TestIntegration_MigrateFromPagerDuty_Writes(t *testing.T) {
+	srv, log := newMockServer(t)
+	defer srv.Close()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "migrate", "from-pagerduty", "-f", writePagerDutyExport(t), "--json")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stderr, "4 created")
+	for _, path := range []string{"/v2/users", "/v2/schedules", "/v1/services"} {
+		if log.lastMethod(path) != http.MethodPost {
+			t.Errorf("expected POST %s", path)
+		}
+	}
+}
+
+func TestIntegration_MigrateExport_GrafanaOnCall(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	dir := t.TempDir()
+	stdout, stderr, exitCode := runCLI(t, srv.URL, "migrate", "export", "--format", "grafana-oncall", "--dir", dir)
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "escalation_chains")
+	assertContains(t, stdout, "Grafana OnCall has no services")
+	assertContains(t, stderr, "Wrote 6 file(s)")
+	b, err := os.ReadFile(filepath.Join(dir, "schedules.json"))
+	if err != nil {
+		t.Fatalf("schedules.json: %v", err)
+	}
+	assertValidJSON(t, string(b))
+	assertContains(t, string(b), `"id": "schedule-id-789"`)
+}
+
+func TestIntegration_MigrateExport_UnknownFormat(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "migrate", "export", "--format", "victorops", "--dir", t.TempDir())
+	assertExitCode(t, exitCode, 1)
+	assertContains(t, stderr, "unknown format")
+}
+
+// ─── report digest ────────────────────────────────────────────────────────────
+
+func TestIntegration_ReportDigest_SkipsFailingSchedule(t *testing.T) {
+	var mu sync.Mutex
+	timelineCalls := map[string]int{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/v2/alerts":
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		case strings.HasSuffix(r.URL.Path, "/timeline"):
+			id := strings.Split(r.URL.Path, "/")[3]
+			mu.Lock()
+			timelineCalls[id]++
+			mu.Unlock()
+			if id == "broken" {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			_, _ = w.Write([]byte(`{"data":{"_parent":{"id":"` + id + `","name":"Primary"},"finalTimeline":{"rotations":[]}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	stdout, stderr, exitCode := runCLI(t, srv.URL, "report", "digest", "--schedule", "primary", "--schedule", "broken", "--json")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, `"totalAlerts"`)
+	assertContains(t, stderr, "skipping schedule broken")
+	mu.Lock()
+	defer mu.Unlock()
+	if timelineCalls["broken"] != 2 || timelineCalls["primary"] != 1 {
+		t.Errorf("timeline calls = %v, want the failing schedule retried once", timelineCalls)
+	}
+}
+
+// ─── report api-usage ─────────────────────────────────────────────────────────
+
+func TestIntegration_ReportAPIUsage_FromAuditLog(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	logPath := filepath.Join(t.TempDir(), "audit.jsonl")
+	t.Setenv("OPSGENIE_AUDIT_LOG", logPath)
+	t.Setenv("OPSGENIE_JOB", "nightly-export")
+	_, _, exitCode := runCLI(t, srv.URL, "teams", "list")
+	assertExitCode(t, exitCode, 0)
+	t.Setenv("OPSGENIE_JOB", "")
+	_, _, exitCode = runCLI(t, srv.URL, "users", "list")
+	assertExitCode(t, exitCode, 0)
+
+	stdout, stderr, exitCode := runCLI(t, srv.URL, "report", "api-usage", "--by", "job")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "nightly-export")
+	assertContains(t, stdout, "(unlabelled)")
+	assertContains(t, stderr, "2 request(s) in the last 7d")
+
+	stdout, _, exitCode = runCLI(t, srv.URL, "report", "api-usage", "--log", logPath, "--json")
+	assertExitCode(t, exitCode, 0)
+	assertValidJSON(t, stdout)
+	assertContains(t, stdout, `"key": "teams list"`)
+}
+
+func TestIntegration_ReportAPIUsage_NeedsLog(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	t.Setenv("OPSGENIE_AUDIT_LOG", "")
+	_, stderr, exitCode := runCLI(t, srv.URL, "report", "api-usage")
+	assertExitCode(t, exitCode, 1)
+	assertContains(t, stderr, "OPSGENIE_AUDIT_LOG")
+}
+
+// ─── advisor ──────────────────────────────────────────────────────────────────
+
+func TestIntegration_Advisor_Findings(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, stderr, exitCode := runCLI(t, srv.URL, "advisor")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "team has no members")
+	assertContains(t, stdout, "schedule has no rotations")
+	assertContains(t, stdout, "Test Escalation")
+	assertContains(t, stdout, "integration is disabled")
+	assertContains(t, stderr, "4 cleanup candidate(s) found")
+}
+
+func TestIntegration_Advisor_DeleteInteractivelyNeedsTerminal(t *testing.T) {
+	srv, log := newMockServer(t)
+	defer srv.Close()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "advisor", "--delete-interactively")
+	assertExitCode(t, exitCode, 1)
+	assertContains(t, stderr, "stdin is not a terminal")
+	if strings.Contains(stderr, "[y/N]") {
+		t.Errorf("prompted without a terminal:\n%s", stderr)
+	}
+	if log.lastMethod("/v2/teams/team-id-456") == http.MethodDelete {
+		t.Error("nothing should be deleted without confirmation")
+	}
+}
+
+func TestIntegration_Advisor_NonInteractiveRefusesToPrompt(t *testing.T) {
+	srv, log := newMockServer(t)
+	defer srv.Close()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "advisor", "--delete-interactively", "--non-interactive")
+	assertExitCode(t, exitCode, 1)
+	assertContains(t, stderr, "--non-interactive")
+	if strings.Contains(stderr, "[y/N]") {
+		t.Errorf("prompted despite --non-interactive:\n%s", stderr)
+	}
+
+	t.Setenv("OPSGENIE_NON_INTERACTIVE", "1")
+	_, _, exitCode = runCLI(t, srv.URL, "advisor", "--delete-interactively")
+	assertExitCode(t, exitCode, 1)
+	if log.lastMethod("/v2/teams") != "" {
+		t.Error("no API requests should be made before refusing")
+	}
+}
+
+// ─── users list ───────────────────────────────────────────────────────────────
+
+func TestIntegration_UsersList_DefaultTable(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "users", "list")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "testuser@example.com")
+	assertContains(t, stdout, "user-id-001")
+}
+
+func TestIntegration_UsersList_JSON(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "users", "list", "--json")
+	assertExitCode(t, exitCode, 0)
+	assertValidJSON(t, stdout)
+	assertContains(t, stdout, "testuser@example.com")
+	assertContains(t, stdout, "user-id-001")
+}
+
+func TestIntegration_UsersList_Filters(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "users", "list", "--role", "USER", "--json")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "user-id-001")
+
+	for _, args := range [][]string{{"--role", "admin"}, {"--unverified"}, {"--blocked"}} {
+		stdout, _, exitCode := runCLI(t, srv.URL, append([]string{"users", "list", "--json"}, args...)...)
+		assertExitCode(t, exitCode, 0)
+		if strings.Contains(stdout, "user-id-001") {
+			t.Errorf("users list %v should filter out the verified, unblocked user", args)
+		}
+	}
+}
+
+func TestIntegration_UsersList_Plaintext(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "users", "list", "--plaintext")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "testuser@example.com")
+	assertContains(t, stdout, "\t")
+	assertNotContains(t, stdout, "\033[")
+}
+
+func TestIntegration_UsersList_NoColor(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "users", "list", "--no-color")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "testuser@example.com")
+	assertNotContains(t, stdout, "\033[")
+}
+
+func TestIntegration_UsersList_Fields(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "users", "list", "--json", "--fields", "id,username")
+	assertExitCode(t, exitCode, 0)
+	assertValidJSON(t, stdout)
+	assertContains(t, stdout, "testuser@example.com")
+	assertNotContains(t, stdout, "fullName")
+}
+
+func TestIntegration_UsersList_JQ(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "users", "list", "--json", "--jq", ".[0].username")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "testuser@example.com")
+}
+
+func TestIntegration_UsersList_Debug(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "users", "list", "--debug")
+	assertExitCode(t, exitCode, 0)
+	if !strings.Contains(stderr, "DEBUG") && !strings.Contains(stderr, "debug") {
+		t.Errorf("expected debug output in stderr, got: %q", stderr)
+	}
+}
+
+// ─── escalations list ─────────────────────────────────────────────────────────
+
+func TestIntegration_EscalationsList_DefaultTable(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "escalations", "list")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "Test Escalation")
+	assertContains(t, stdout, "escalation-id-001")
+}
+
+func TestIntegration_EscalationsList_JSON(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "escalations", "list", "--json")
+	assertExitCode(t, exitCode, 0)
+	assertValidJSON(t, stdout)
+	assertContains(t, stdout, "Test Escalation")
+}
+
+func TestIntegration_EscalationsList_Plaintext(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "escalations", "list", "--plaintext")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "Test Escalation")
+	assertContains(t, stdout, "\t")
+	assertNotContains(t, stdout, "\033[")
+}
+
+func TestIntegration_EscalationsList_NoColor(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "escalations", "list", "--no-color")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "Test Escalation")
+	assertNotContains(t, stdout, "\033[")
+}
+
+func TestIntegration_EscalationsList_Fields(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "escalations", "list", "--json", "--fields", "id,name")
+	assertExitCode(t, exitCode, 0)
+	assertValidJSON(t, stdout)
+	assertContains(t, stdout, "escalation-id-001")
+	assertNotContains(t, stdout, "description")
+}
+
+func TestIntegration_EscalationsList_JQ(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "escalations", "list", "--json", "--jq", ".[0].name")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "Test Escalation")
+}
+
+func TestIntegration_EscalationsList_Debug(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "escalations", "list", "--debug")
+	assertExitCode(t, exitCode, 0)
+	if !strings.Contains(stderr, "DEBUG") && !strings.Contains(stderr, "debug") {
+		t.Errorf("expected debug output in stderr, got: %q", stderr)
+	}
+}
+
+// ─── heartbeats list ──────────────────────────────────────────────────────────
+
+func TestIntegration_HeartbeatsList_DefaultTable(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "heartbeats", "list")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "test-heartbeat")
+}
+
+func TestIntegration_HeartbeatsList_JSON(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "heartbeats", "list", "--json")
+	assertExitCode(t, exitCode, 0)
+	assertValidJSON(t, stdout)
+	assertContains(t, stdout, "test-heartbeat")
+}
+
+func TestIntegration_HeartbeatsList_Plaintext(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "heartbeats", "list", "--plaintext")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "test-heartbeat")
+	assertContains(t, stdout, "\t")
+	assertNotContains(t, stdout, "\033[")
+}
+
+func TestIntegration_HeartbeatsList_NoColor(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "heartbeats", "list", "--no-color")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "test-heartbeat")
+	assertNotContains(t, stdout, "\033[")
+}
+
+func TestIntegration_HeartbeatsList_Fields(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "heartbeats", "list", "--json", "--fields", "name,enabled")
+	assertExitCode(t, exitCode, 0)
+	assertValidJSON(t, stdout)
+	assertContains(t, stdout, "test-heartbeat")
+	assertNotContains(t, stdout, "intervalUnit")
+}
+
+func TestIntegration_HeartbeatsList_JQ(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "heartbeats", "list", "--json", "--jq", ".[0].name")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "test-heartbeat")
+}
+
+func TestIntegration_HeartbeatsList_Debug(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "heartbeats", "list", "--debug")
+	assertExitCode(t, exitCode, 0)
+	if !strings.Contains(stderr, "DEBUG") && !strings.Contains(stderr, "debug") {
+		t.Errorf("expected debug output in stderr, got: %q", stderr)
+	}
+}
+
+// ─── heartbeats analyze ───────────────────────────────────────────────────────
+
+func TestIntegration_HeartbeatsAnalyze(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "heartbeats", "analyze", "test-heartbeat")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "4 expiries: 3 late ping(s), 1 outage(s), 0 still open")
+	assertContains(t, stdout, "Recommended interval: 20 minutes")
+	assertContains(t, stdout, "opsgenie-cli heartbeats update test-heartbeat --interval 20 --interval-unit minutes")
+}
+
+func TestIntegration_HeartbeatsAnalyze_JSON(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "heartbeats", "analyze", "test-heartbeat", "--coverage", "0.5", "--json")
+	assertExitCode(t, exitCode, 0)
+	var got struct {
+		RecommendedInterval int `json:"recommendedInterval"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout)
+	}
+	if got.RecommendedInterval != 18 {
+		t.Errorf("recommendedInterval = %d, want 18", got.RecommendedInterval)
+	}
+}
+
+// ─── heartbeats apply ─────────────────────────────────────────────────────────
+
+func writeHeartbeatManifest(t *testing.T) string {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), "heartbeats.yaml")
+	manifest := `- name: test-heartbeat
+  interval: 10
+  intervalUnit: minutes
+  alertPriority: P2
+- name: payments-cron
+  interval: 1
+  intervalUnit: days
+`
+	if err := os.WriteFile(p, []byte(manifest), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestIntegration_HeartbeatsApply(t *testing.T) {
+	srv, log := newMockServer(t)
+	defer srv.Close()
+
+	stdout, stderr, exitCode := runCLI(t, srv.URL, "heartbeats", "apply", "-f", writeHeartbeatManifest(t), "--dry-run")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "payments-cron")
+	assertContains(t, stdout, "alertPriority")
+	assertContains(t, stderr, "1 to create, 1 to update, 0 unchanged (dry run)")
+	if m := log.lastMethod("/v2/heartbeats"); m != http.MethodGet {
+		t.Errorf("dry run must not write, last /v2/heartbeats method = %s", m)
+	}
+
+	_, stderr, exitCode = runCLI(t, srv.URL, "heartbeats", "apply", "-f", writeHeartbeatManifest(t))
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stderr, "1 created, 1 updated")
+	if log.lastMethod("/v2/heartbeats") != http.MethodPost {
+		t.Error("expected POST /v2/heartbeats")
+	}
+	if log.lastMethod("/v2/heartbeats/test-heartbeat") != http.MethodPatch {
+		t.Error("expected PATCH /v2/heartbeats/test-heartbeat")
+	}
+}
+
+func TestIntegration_HeartbeatsApply_CreateNeedsInterval(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	p := filepath.Join(t.TempDir(), "heartbeats.yaml")
+	_ = os.WriteFile(p, []byte("- name: new-one\n"), 0o644)
+	_, stderr, exitCode := runCLI(t, srv.URL, "heartbeats", "apply", "-f", p)
+	assertExitCode(t, exitCode, 1)
+	assertContains(t, stderr, "interval is required")
+}
+
+// ─── heartbeats ping ──────────────────────────────────────────────────────────
+
+// pingServer answers heartbeat pings with the given statuses in turn
+// (200 once they run out), after delay, and counts the pings.
+func pingServer(t *testing.T, pings *int, delay time.Duration, statuses ...int) *httptest.Server {
+	t.Helper()
+	var mu sync.Mutex
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		n := *pings
+		*pings++
+		mu.Unlock()
+		time.Sleep(delay)
+		if n < len(statuses) {
+			writeJSON(w, statuses[n], map[string]interface{}{"message": http.StatusText(statuses[n]), "code": statuses[n]})
+			return
+		}
+		writeJSON(w, http.StatusAccepted, map[string]interface{}{"result": "PONG - Heartbeat received"})
+	}))
+}
+
+func TestIntegration_HeartbeatsPing_RetriesServerErrors(t *testing.T) {
+	var pings int
+	srv := pingServer(t, &pings, 0, http.StatusBadGateway)
+	defer srv.Close()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "heartbeats", "ping", "nightly", "--retries", "1")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stderr, "pinged")
+	if pings != 2 {
+		t.Errorf("pings = %d, want 2", pings)
+	}
+}
+
+func TestIntegration_HeartbeatsPing_HonoursRetryAfter(t *testing.T) {
+	var pings atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if pings.Add(1) == 1 {
+			w.Header().Set("Retry-After", "2")
+			writeJSON(w, http.StatusRequestTimeout, map[string]interface{}{"message": "Request Timeout"})
+			return
+		}
+		writeJSON(w, http.StatusAccepted, map[string]interface{}{"result": "PONG - Heartbeat received"})
+	}))
+	defer srv.Close()
+
+	start := time.Now()
+	_, stderr, exitCode := runCLI(t, srv.URL, "heartbeats", "ping", "nightly", "--retries", "1")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stderr, "pinged")
+	if pings.Load() != 2 {
+		t.Errorf("pings = %d, want a 408 retried once", pings.Load())
+	}
+	if elapsed := time.Since(start); elapsed < 2*time.Second {
+		t.Errorf("retried after %s, want the 2s Retry-After honoured", elapsed)
+	}
+}
+
+func TestIntegration_HeartbeatsPing_ExitCodes(t *testing.T) {
+	var pings int
+	srv := pingServer(t, &pings, 0, http.StatusNotFound)
+	_, stderr, exitCode := runCLI(t, srv.URL, "heartbeats", "ping", "missing")
+	srv.Close()
+	assertExitCode(t, exitCode, 1)
+	assertContains(t, stderr, "Not Found")
+	if pings != 1 {
+		t.Errorf("a 404 must not be retried, pings = %d", pings)
+	}
+
+	pings = 0
+	srv = pingServer(t, &pings, 0, http.StatusUnauthorized)
+	_, _, exitCode = runCLI(t, srv.URL, "heartbeats", "ping", "nightly")
+	srv.Close()
+	assertExitCode(t, exitCode, 3)
+	if pings != 1 {
+		t.Errorf("a 401 must not be retried, pings = %d", pings)
+	}
+
+	pings = 0
+	srv = pingServer(t, &pings, 0, http.StatusInternalServerError)
+	_, _, exitCode = runCLI(t, srv.URL, "heartbeats", "ping", "nightly", "--retries", "0")
+	srv.Close()
+	assertExitCode(t, exitCode, 75)
+
+	pings = 0
+	srv = pingServer(t, &pings, 500*time.Millisecond)
+	start := time.Now()
+	_, _, exitCode = runCLI(t, srv.URL, "heartbeats", "ping", "nightly", "--retries", "0", "--timeout", "100ms")
+	elapsed := time.Since(start)
+	srv.Close()
+	assertExitCode(t, exitCode, 75)
+	if elapsed > 5*time.Second {
+		t.Errorf("--timeout not applied, took %s", elapsed)
+	}
+}
+
+func TestIntegration_HeartbeatsPing_FailSilently(t *testing.T) {
+	var pings int
+	srv := pingServer(t, &pings, 0, http.StatusInternalServerError)
+	defer srv.Close()
+
+	stdout, stderr, exitCode := runCLI(t, srv.URL, "heartbeats", "ping", "nightly", "--retries", "0", "--fail-silently")
+	assertExitCode(t, exitCode, 0)
+	if stdout != "" || stderr != "" {
+		t.Errorf("expected no output, got stdout %q, stderr %q", stdout, stderr)
+	}
+}
+
+func TestIntegration_HeartbeatsPing_Several(t *testing.T) {
+	var inflight, peak int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inflight, 1)
+		defer atomic.AddInt32(&inflight, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(100 * time.Millisecond)
+		switch strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v2/heartbeats/"), "/ping") {
+		case "missing":
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"message": "Heartbeat not found"})
+		case "flaky":
+			writeJSON(w, http.StatusBadGateway, map[string]interface{}{"message": "Bad Gateway"})
+		default:
+			writeJSON(w, http.StatusAccepted, map[string]interface{}{"result": "PONG - Heartbeat received"})
+		}
+	}))
+	defer srv.Close()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "heartbeats", "ping", "db-backup", "log-rotate", "cert-check")
+	assertExitCode(t, exitCode, 0)
+	for _, name := range []string{"db-backup", "log-rotate", "cert-check"} {
+		assertContains(t, stderr, `Heartbeat "`+name+`" pinged`)
+	}
+	if atomic.LoadInt32(&peak) < 2 {
+		t.Errorf("pings were not concurrent (peak %d in flight)", peak)
+	}
+
+	_, stderr, exitCode = runCLI(t, srv.URL, "heartbeats", "ping", "db-backup", "flaky", "--retries", "0")
+	assertExitCode(t, exitCode, 75)
+	assertContains(t, stderr, `Heartbeat "db-backup" pinged`)
+	assertContains(t, stderr, `Heartbeat "flaky" failed`)
+	assertContains(t, stderr, "1 of 2 heartbeats failed to ping")
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "heartbeats", "ping", "db-backup", "flaky", "missing", "--retries", "0", "--json")
+	assertExitCode(t, exitCode, 1)
+	var results []map[string]interface{}
+	if err := json.Unmarshal([]byte(stdout), &results); err != nil {
+		t.Fatalf("parse %q: %v", stdout, err)
+	}
+	if len(results) != 3 || results[0]["ok"] != true || results[1]["ok"] != false || results[2]["error"] == nil {
+		t.Errorf("results = %v", results)
+	}
+}
+
+// ─── heartbeats get ───────────────────────────────────────────────────────────
+
+func TestIntegration_HeartbeatsGet_DefaultTable(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "heartbeats", "get", "test-heartbeat")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "test-heartbeat")
+}
+
+func TestIntegration_HeartbeatsGet_JSON(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "heartbeats", "get", "test-heartbeat", "--json")
+	assertExitCode(t, exitCode, 0)
+	assertValidJSON(t, stdout)
+	assertContains(t, stdout, "test-heartbeat")
+}
+
+func TestIntegration_HeartbeatsGet_Plaintext(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "heartbeats", "get", "test-heartbeat", "--plaintext")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "test-heartbeat")
+	assertNotContains(t, stdout, "\033[")
+}
+
+func TestIntegration_HeartbeatsGet_NoColor(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "heartbeats", "get", "test-heartbeat", "--no-color")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "test-heartbeat")
+	assertNotContains(t, stdout, "\033[")
+}
+
+// ─── escalations get ─────────────────────────────────────────────────────────
+
+func TestIntegration_EscalationsGet_JSON(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "escalations", "get", "escalation-id-001", "--json")
+	assertExitCode(t, exitCode, 0)
+	assertValidJSON(t, stdout)
+	assertContains(t, stdout, "Test Escalation")
+}
+
+// ─── alerts why ───────────────────────────────────────────────────────────────
+
+func TestIntegration_AlertsWhy(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "alerts", "why", "alert-routed")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, `Night Team: rule "Business hours" -> schedule: Test Schedule`)
+	assertContains(t, stdout, "skipped Nights: outside its time restriction")
+	assertContains(t, stdout, "skipped Database: conditions do not match")
+	assertContains(t, stdout, `"Test Policy" (global)`)
+	assertContains(t, stdout, "routing    Alert is routed to team [Night Team]")
+	assertContains(t, stdout, "escalation Escalation [Night Team_escalation] started")
+}
+
+func TestIntegration_AlertsWhy_JSON(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "alerts", "why", "alert-routed", "--json")
+	assertExitCode(t, exitCode, 0)
+	var got struct {
+		Routing []struct {
+			Team    string   `json:"team"`
+			RuleID  string   `json:"ruleId"`
+			Skipped []string `json:"skipped"`
+		} `json:"routing"`
+		Policies []map[string]interface{} `json:"policies"`
+		Timeline []map[string]interface{} `json:"timeline"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout)
+	}
+	if len(got.Routing) != 1 || got.Routing[0].RuleID != "rule-hours" || len(got.Routing[0].Skipped) != 2 {
+		t.Errorf("routing = %+v", got.Routing)
+	}
+	if len(got.Timeline) != 3 {
+		t.Errorf("timeline has %d entries, want 3", len(got.Timeline))
+	}
+}
+
+// ─── integrations keys ────────────────────────────────────────────────────────
+
+func TestIntegration_IntegrationKeysList(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, stderr, exitCode := runCLI(t, srv.URL, "integrations", "keys", "list", "--plaintext")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "Test Integration")
+	assertContains(t, stdout, "Test Team")
+	assertContains(t, stdout, "rotate")
+	assertContains(t, stderr, "1 of 1 key(s) older than 90d")
+}
+
+func TestIntegration_IntegrationKeysList_TypeFilter(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "integrations", "keys", "list", "--type", "Email", "--json")
+	assertExitCode(t, exitCode, 0)
+	if strings.TrimSpace(stdout) != "[]" {
+		t.Errorf("expected no keys, got %s", stdout)
+	}
+}
+
+// rotationServer serves integration int-1, with a secret key and one
+// action, and records the writes a key rotation makes.
+func rotationServer(t *testing.T, writes *[]string, bodies map[string]map[string]interface{}) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			*writes = append(*writes, r.Method+" "+r.URL.Path)
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			bodies[r.Method+" "+r.URL.Path] = body
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/integrations/int-1":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{
+				"id": "int-1", "name": "Datadog", "type": "API", "enabled": true,
+				"apiKey": "old-secret", "allowWriteAccess": true, "_readOnly": []string{"id", "type", "apiKey"},
+			}})
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/integrations/int-1/actions":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{
+				"create": []interface{}{map[string]interface{}{"name": "Create", "type": "create"}},
+			}})
+		case r.Method == http.MethodPost && r.URL.Path == "/v2/integrations":
+			writeJSON(w, http.StatusCreated, map[string]interface{}{"data": map[string]interface{}{
+				"id": "int-2", "name": "Datadog (new key)", "apiKey": "new-secret",
+			}})
+		default:
+			writeJSON(w, http.StatusOK, map[string]interface{}{"result": "ok"})
+		}
+	}))
+}
+
+func TestIntegration_IntegrationsGet_HidesKey(t *testing.T) {
+	srv := rotationServer(t, new([]string), map[string]map[string]interface{}{})
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "integrations", "get", "int-1", "--json")
+	assertExitCode(t, exitCode, 0)
+	if strings.Contains(stdout, "old-secret") {
+		t.Errorf("expected the key to be hidden, got %s", stdout)
+	}
+
+	stdout, _, exitCode = runCLI(t, srv.URL, "integrations", "get", "int-1", "--show-secrets", "--plaintext")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "old-secret")
+}
+
+func TestIntegration_IntegrationsRegenerateKey(t *testing.T) {
+	var writes []string
+	bodies := map[string]map[string]interface{}{}
+	srv := rotationServer(t, &writes, bodies)
+	defer srv.Close()
+
+	stdout, stderr, exitCode := runCLI(t, srv.URL, "integrations", "regenerate-key", "int-1", "--yes", "--plaintext")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "new-secret")
+	assertContains(t, stderr, "rotated")
+	want := []string{
+		"POST /v2/integrations",
+		"PUT /v2/integrations/int-2/actions",
+		"DELETE /v2/integrations/int-1",
+		"PUT /v2/integrations/int-2",
+	}
+	if strings.Join(writes, "\n") != strings.Join(want, "\n") {
+		t.Errorf("writes = %v, want %v", writes, want)
+	}
+	created := bodies["POST /v2/integrations"]
+	if created["name"] != "Datadog (new key)" || created["type"] != "API" || created["allowWriteAccess"] != true {
+		t.Errorf("copy body = %v", created)
+	}
+	if _, ok := created["apiKey"]; ok {
+		t.Errorf("copy body has the old key: %v", created)
+	}
+	if bodies["PUT /v2/integrations/int-2"]["name"] != "Datadog" {
+		t.Errorf("copy not renamed: %v", bodies["PUT /v2/integrations/int-2"])
+	}
+}
+
+func TestIntegration_IntegrationsRegenerateKey_KeepOld(t *testing.T) {
+	var writes []string
+	srv := rotationServer(t, &writes, map[string]map[string]interface{}{})
+	defer srv.Close()
+
+	_, _, exitCode := runCLI(t, srv.URL, "integrations", "regenerate-key", "int-1", "--keep-old")
+	assertExitCode(t, exitCode, 0)
+	for _, w := range writes {
+		if strings.HasPrefix(w, "DELETE") {
+			t.Errorf("expected the old integration to be kept, got %v", writes)
+		}
+	}
+}
+
+// ─── notification-rules simulate ──────────────────────────────────────────────
+
+func notificationRulesServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	rules := map[string]interface{}{
+		"r1": map[string]interface{}{
+			"id": "r1", "name": "Business hours", "actionType": "create-alert", "order": 1, "enabled": true,
+			"timeRestriction": map[string]interface{}{"type": "time-of-day", "restriction": map[string]interface{}{"startHour": 9, "startMin": 0, "endHour": 17, "endMin": 0}},
+			"steps":           []interface{}{map[string]interface{}{"contact": map[string]interface{}{"method": "email", "to": "alice@example.com"}, "sendAfter": map[string]interface{}{"timeAmount": 0}, "enabled": true}},
+		},
+		"r2": map[string]interface{}{
+			"id": "r2", "name": "Night P1", "actionType": "create-alert", "order": 2, "enabled": true,
+			"criteria": map[string]interface{}{"type": "match-all-conditions", "conditions": []interface{}{map[string]interface{}{"field": "priority", "operation": "equals", "expectedValue": "P1"}}},
+			"steps": []interface{}{
+				map[string]interface{}{"contact": map[string]interface{}{"method": "voice", "to": "+15550100"}, "sendAfter": map[string]interface{}{"timeAmount": 5}, "enabled": true},
+				map[string]interface{}{"contact": map[string]interface{}{"method": "sms", "to": "+15550100"}, "sendAfter": map[string]interface{}{"timeAmount": 0}, "enabled": true},
+			},
+			"repeat": map[string]interface{}{"loopAfter": 15, "enabled": true},
+		},
+		"r3": map[string]interface{}{"id": "r3", "name": "On close", "actionType": "closed-alert", "order": 1, "enabled": true},
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch p := r.URL.Path; {
+		case p == "/v2/users/alice@example.com":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"id": "u1", "username": "alice@example.com", "timeZone": "UTC"}})
+		case p == "/v2/users/alice@example.com/notification-rules":
+			var list []interface{}
+			for _, id := range []string{"r1", "r2", "r3"} {
+				rule := rules[id].(map[string]interface{})
+				list = append(list, map[string]interface{}{"id": id, "name": rule["name"], "actionType": rule["actionType"], "order": rule["order"], "enabled": true})
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": list})
+		case strings.HasPrefix(p, "/v2/users/alice@example.com/notification-rules/"):
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": rules[p[strings.LastIndex(p, "/")+1:]]})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestIntegration_NotificationRulesSimulate(t *testing.T) {
+	srv := notificationRulesServer(t)
+	defer srv.Close()
+
+	stdout, stderr, exitCode := runCLI(t, srv.URL, "notification-rules", "simulate", "--user", "alice@example.com",
+		"--priority", "P1", "--at", "mon 03:00")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "outside its time restriction")
+	assertContains(t, stdout, "+15550100")
+	if strings.Index(stdout, "sms") > strings.Index(stdout, "voice") {
+		t.Errorf("sms (immediately) should come before voice (5m):\n%s", stdout)
+	}
+	if strings.Contains(stdout, "On close") {
+		t.Error("rules for other actions should be left out")
+	}
+	assertContains(t, stderr, `Rule "Night P1" fires at Mon 03:00 UTC: 2 notification(s), the first by sms, repeated every 15m until acknowledged`)
+
+	_, stderr, exitCode = runCLI(t, srv.URL, "notification-rules", "simulate", "--user", "alice@example.com",
+		"--priority", "P3", "--at", "mon 03:00")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stderr, "no notification would be sent")
+
+	stdout, _, exitCode = runCLI(t, srv.URL, "notification-rules", "simulate", "--user", "alice@example.com",
+		"--at", "2024-06-03T10:00:00Z", "--json")
+	assertExitCode(t, exitCode, 0)
+	var got struct {
+		Rules []struct {
+			Rule   string `json:"rule"`
+			Fires  bool   `json:"fires"`
+			Reason string `json:"reason"`
+		} `json:"rules"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout)
+	}
+	if len(got.Rules) != 2 || !got.Rules[0].Fires || got.Rules[1].Reason != "not reached: Business hours applies" {
+		t.Errorf("rules = %+v", got.Rules)
+	}
+}
+
+// ─── escalations test ─────────────────────────────────────────────────────────
+
+func TestIntegration_EscalationsTest_DryRun(t *testing.T) {
+	srv, log := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "escalations", "test", "escalation-with-rules", "--dry-run", "--plaintext")
+	assertExitCode(t, exitCode, 0)
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")[1:]
+	if len(lines) != 3 {
+		t.Fatalf("expected a header and 3 steps, got:\n%s", stdout)
+	}
+	assertContains(t, lines[0], "immediately")
+	assertContains(t, lines[0], "alice@example.com (on call now)")
+	assertContains(t, lines[1], "5m")
+	assertContains(t, lines[1], "testuser@example.com")
+	assertContains(t, lines[2], "30m")
+	assertContains(t, lines[2], "not closed")
+	if _, ok := log.methods["/v2/alerts"]; ok {
+		t.Errorf("dry run should not create an alert, got methods: %v", log.methods)
+	}
+}
+
+func TestIntegration_EscalationsTest_CreatesAlert(t *testing.T) {
+	srv, log := newMockServer(t)
+	defer srv.Close()
+
+	stdout, stderr, exitCode := runCLI(t, srv.URL, "escalations", "test", "escalation-with-rules", "--json")
+	assertExitCode(t, exitCode, 0)
+	assertValidJSON(t, stdout)
+	assertContains(t, stdout, `"notifies"`)
+	assertContains(t, stderr, "Test alert")
+	if log.methods["/v2/alerts"] != http.MethodPost {
+		t.Errorf("expected POST to /v2/alerts, got methods: %v", log.methods)
+	}
+}
+
+func TestIntegration_EscalationsTest_InvalidPriority(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "escalations", "test", "escalation-id-001", "--priority", "P9")
+	assertExitCode(t, exitCode, 2)
+	assertContains(t, stderr, "invalid --priority")
+}
+
+// ─── schedules get ────────────────────────────────────────────────────────────
+
+func TestIntegration_SchedulesGet_JSON(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "schedules", "get", "schedule-id-789", "--json")
+	assertExitCode(t, exitCode, 0)
+	assertValidJSON(t, stdout)
+	assertContains(t, stdout, "Test Schedule")
+}
+
+// ─── schedules enable / disable ───────────────────────────────────────────────
+
+// teamSchedulesServer serves two schedules owned by Platform (one already
+// disabled) and one owned by another team, recording the paths patched.
+// Patching the disabled one fails.
+func teamSchedulesServer(t *testing.T, patched *[]string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			*patched = append(*patched, r.URL.Path)
+			if r.URL.Path == "/v2/schedules/s2" {
+				writeJSON(w, http.StatusInternalServerError, map[string]interface{}{"message": "Internal error", "code": 500})
+				return
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{}})
+			return
+		}
+		platform := map[string]interface{}{"id": "team-platform", "name": "Platform"}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": []interface{}{
+			map[string]interface{}{"id": "s1", "name": "platform-primary", "enabled": true, "ownerTeam": platform},
+			map[string]interface{}{"id": "s2", "name": "platform-legacy", "enabled": false, "ownerTeam": platform},
+			map[string]interface{}{"id": "s3", "name": "data-primary", "enabled": true,
+				"ownerTeam": map[string]interface{}{"id": "team-data", "name": "Data"}},
+		}})
+	}))
+}
+
+func TestIntegration_SchedulesDisable_Preview(t *testing.T) {
+	var patched []string
+	srv := teamSchedulesServer(t, &patched)
+	defer srv.Close()
+
+	stdout, stderr, exitCode := runCLI(t, srv.URL, "schedules", "disable", "--team", "platform")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "would disable")
+	assertContains(t, stderr, "1 schedule(s) of team platform would be disabled, 1 already disabled")
+	if strings.Contains(stdout, "data-primary") {
+		t.Errorf("schedule of another team listed:\n%s", stdout)
+	}
+	if len(patched) != 0 {
+		t.Errorf("patched %v without --yes", patched)
+	}
+}
+
+func TestIntegration_SchedulesDisable_Yes(t *testing.T) {
+	var patched []string
+	srv := teamSchedulesServer(t, &patched)
+	defer srv.Close()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "schedules", "disable", "--team", "team-platform", "--yes")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stderr, "1 schedule(s) of team team-platform disabled")
+	if len(patched) != 1 || patched[0] != "/v2/schedules/s1" {
+		t.Errorf("patched = %v, want [/v2/schedules/s1]", patched)
+	}
+
+	_, stderr, exitCode = runCLI(t, srv.URL, "schedules", "enable", "--team", "nobody", "--yes")
+	if exitCode == 0 {
+		t.Fatal("expected failure for a team without schedules")
+	}
+	assertContains(t, stderr, "no schedules owned by team")
+}
+
+func TestIntegration_SchedulesEnable_SummaryAndExitCode(t *testing.T) {
+	var patched []string
+	srv := teamSchedulesServer(t, &patched)
+	defer srv.Close()
+
+	stdout, stderr, exitCode := runCLI(t, srv.URL, "schedules", "enable", "--team", "platform", "--yes", "--json")
+	assertExitCode(t, exitCode, 1)
+	assertContains(t, stderr, "1 of 2 item(s) failed: s2")
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	var last struct {
+		Summary struct {
+			Processed, Succeeded, Failed, Skipped int
+			FailedIDs                             []string `json:"failedIds"`
+		} `json:"summary"`
+	}
+	if err := json.Unmarshal([]byte(lines[len(lines)-1]), &last); err != nil {
+		t.Fatalf("last line is not a summary: %v\n%s", err, stdout)
+	}
+	if s := last.Summary; s.Processed != 2 || s.Skipped != 1 || s.Failed != 1 || len(s.FailedIDs) != 1 || s.FailedIDs[0] != "s2" {
+		t.Errorf("summary = %+v", s)
+	}
+}
+
+// ─── users get ────────────────────────────────────────────────────────────────
+
+func TestIntegration_UsersGet_JSON(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "users", "get", "user-id-001", "--json")
+	assertExitCode(t, exitCode, 0)
+	assertValidJSON(t, stdout)
+	assertContains(t, stdout, "testuser@example.com")
+}
+
+func TestIntegration_UsersCreate_TimezoneLocale(t *testing.T) {
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		writeJSON(w, http.StatusCreated, map[string]interface{}{"data": map[string]interface{}{"id": "u1", "username": "alice@example.com"}})
+	}))
+	defer srv.Close()
+
+	_, _, exitCode := runCLI(t, srv.URL, "users", "create", "--username", "alice@example.com",
+		"--role", "admin", "--timezone", "Europe/Berlin", "--locale", "de_DE")
+	assertExitCode(t, exitCode, 0)
+	if body["timeZone"] != "Europe/Berlin" || body["locale"] != "de_DE" {
+		t.Errorf("body = %v, want timeZone and locale", body)
+	}
+	if role, _ := body["role"].(map[string]interface{}); role["name"] != "admin" {
+		t.Errorf("role = %v, want admin", body["role"])
+	}
+}
+
+func TestIntegration_UsersUpdate_ProfileFields(t *testing.T) {
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/v2/users/alice@example.com" {
+			t.Errorf("%s %s, want PATCH of the user", r.Method, r.URL.Path)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"result": "Updated"})
+	}))
+	defer srv.Close()
+
+	_, _, exitCode := runCLI(t, srv.URL, "users", "update", "alice@example.com", "--skype-username", "alice.doe",
+		"--address", "country=Germany", "--address", "zipcode=10115", "--tag", "sre", "--tag", "emea",
+		"--detail", "team=platform", "--detail", "pager=primary", "--detail", "pager=backup")
+	assertExitCode(t, exitCode, 0)
+	got, _ := json.Marshal(body)
+	want := `{"details":{"pager":["primary","backup"],"team":["platform"]},"skypeUsername":"alice.doe","tags":["sre","emea"],"userAddress":{"country":"Germany","zipCode":"10115"}}`
+	if string(got) != want {
+		t.Errorf("body = %s\nwant %s", got, want)
+	}
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "users", "update", "alice@example.com", "--address", "planet=Mars")
+	assertExitCode(t, exitCode, 2)
+	assertContains(t, stderr, "zipCode")
+}
+
+func TestIntegration_UsersUpdate_NothingToUpdate(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "users", "update", "user-id-001")
+	assertExitCode(t, exitCode, 1)
+	assertContains(t, stderr, "nothing to update")
+}
+
+func TestIntegration_UsersDelete_Confirmation(t *testing.T) {
+	srv, log := newMockServer(t)
+	defer srv.Close()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "users", "delete", "user-id-001")
+	assertExitCode(t, exitCode, 1)
+	assertContains(t, stderr, "stdin is not a terminal")
+	assertContains(t, stderr, "--yes")
+	if log.lastMethod("/v2/users/user-id-001") == http.MethodDelete {
+		t.Fatal("user deleted without confirmation")
+	}
+
+	_, stderr, exitCode = runCLI(t, srv.URL, "users", "delete", "user-id-001", "--non-interactive")
+	assertExitCode(t, exitCode, 1)
+	assertContains(t, stderr, "--yes")
+
+	_, stderr, exitCode = runCLI(t, srv.URL, "users", "delete", "user-id-001", "--yes")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stderr, "User user-id-001 deleted")
+	if log.lastMethod("/v2/users/user-id-001") != http.MethodDelete {
+		t.Error("expected DELETE /v2/users/user-id-001")
+	}
+}
+
+func TestIntegration_UsersSchedules(t *testing.T) {
+	srv, log := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "users", "schedules", "testuser@example.com")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "Test Schedule")
+	if log.lastMethod("/v2/users/testuser@example.com/schedules") != http.MethodGet {
+		t.Error("expected GET /v2/users/testuser@example.com/schedules")
+	}
+}
+
+func TestIntegration_UsersEscalations_JSON(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "users", "escalations", "testuser@example.com", "--json")
+	assertExitCode(t, exitCode, 0)
+	assertValidJSON(t, stdout)
+	assertContains(t, stdout, "Test Escalation")
+}
+
+// ─── notify-bridge ────────────────────────────────────────────────────────────
+
+func TestIntegration_NotifyBridge_AckButton(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("fake notify-send is a shell script")
+	}
+	srv, log := newMockServer(t)
+	defer srv.Close()
+
+	// The fake notify-send records its arguments and "clicks" Acknowledge.
+	dir := t.TempDir()
+	argsFile := filepath.Join(dir, "args")
+	script := "#!/bin/sh\nfor a in \"$@\"; do echo \"$a\"; done > " + argsFile + "\necho ack\n"
+	if err := os.WriteFile(filepath.Join(dir, "notify-send"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir)
+	t.Setenv("OPSGENIE_USER", "alice@example.com")
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "notify-bridge", "--once", "--include-existing")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stderr, "Alert alert-id-123 acknowledged")
+
+	b, err := os.ReadFile(argsFile)
+	if err != nil {
+		t.Fatalf("notify-send not run: %v", err)
+	}
+	assertContains(t, string(b), "[P3] OpsGenie alert #42")
+	assertContains(t, string(b), "--action=ack=Acknowledge")
+	if m := log.lastMethod("/v2/alerts/alert-id-123/acknowledge"); m != http.MethodPost {
+		t.Errorf("acknowledge method = %q, want POST", m)
+	}
+}
+
+func TestIntegration_NotifyBridge_SkipsExisting(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("fake notify-send is a shell script")
+	}
+	srv, log := newMockServer(t)
+	defer srv.Close()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "notify-send"), []byte("#!/bin/sh\necho ack\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir)
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "notify-bridge", "--once", "--user", "alice@example.com")
+	assertExitCode(t, exitCode, 0)
+	if strings.Contains(stderr, "Notified") {
+		t.Errorf("alert open at start should not be notified: %s", stderr)
+	}
+	if m := log.lastMethod("/v2/alerts/alert-id-123/acknowledge"); m != "" {
+		t.Errorf("unexpected acknowledge %s", m)
+	}
+}
+
+// ─── async requests ───────────────────────────────────────────────────────────
+
+func TestIntegration_NoWait_PrintsRequestID(t *testing.T) {
+	srv, log := newMockServer(t)
+	defer srv.Close()
+
+	stdout, stderr, exitCode := runCLI(t, srv.URL, "alerts", "acknowledge", "alert-id-123", "--no-wait")
+	assertExitCode(t, exitCode, 0)
+	if strings.TrimSpace(stdout) != `{"requestId":"req-ack-001"}` {
+		t.Errorf("stdout = %q, want the request ID as JSON", stdout)
+	}
+	assertContains(t, stderr, "alerts request-status")
+	if m := log.lastMethod("/v2/alerts/requests/"); m != "" {
+		t.Errorf("request was polled (%s) despite --no-wait", m)
+	}
+}
+
+func TestIntegration_AlertsRequestStatus(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "alerts", "request-status", "req-ack-001", "--json")
+	assertExitCode(t, exitCode, 0)
+	var got map[string]interface{}
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout)
+	}
+	if got["isSuccess"] != true || got["alertId"] != "alert-id-123" {
+		t.Errorf("status = %v", got)
+	}
+}
+
+// ─── api ──────────────────────────────────────────────────────────────────────
+
+// echoServer answers every request with what it received.
+func echoServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"method": r.Method,
+			"path":   r.URL.Path,
+			"query":  r.URL.Query(),
+			"body":   body,
+			"count":  json.Number("12345678901234567"),
+		})
+	}))
+}
+
+func TestIntegration_API_GetWithFields(t *testing.T) {
+	srv := echoServer(t)
+	defer srv.Close()
+
+	stdout, stderr, exitCode := runCLI(t, srv.URL, "api", "GET", "v2/alerts/count", "--field", "query=status:open", "--jq", "[.method, .path, .query.query[0], .count]")
+	assertExitCode(t, exitCode, 0)
+	if stderr != "" {
+		t.Errorf("stderr = %q", stderr)
+	}
+	var got []interface{}
+	dec := json.NewDecoder(strings.NewReader(stdout))
+	dec.UseNumber()
+	if err := dec.Decode(&got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout)
+	}
+	if got[0] != "GET" || got[1] != "/v2/alerts/count" || got[2] != "status:open" || got[3] != json.Number("12345678901234567") {
+		t.Errorf("got %v", got)
+	}
+}
+
+func TestIntegration_API_PostFieldsBody(t *testing.T) {
+	srv := echoServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "api", "post", "/v2/alerts/abc/tags",
+		"-F", "tags[]=db", "-F", "tags[]=prod", "-F", "details.retries=3", "-f", "note=42", "-F", "silent=true", "--jq", ".body")
+	assertExitCode(t, exitCode, 0)
+	var body map[string]interface{}
+	if err := json.Unmarshal([]byte(stdout), &body); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout)
+	}
+	want := map[string]interface{}{
+		"tags":    []interface{}{"db", "prod"},
+		"details": map[string]interface{}{"retries": float64(3)},
+		"note":    "42",
+		"silent":  true,
+	}
+	got, _ := json.Marshal(body)
+	wantJSON, _ := json.Marshal(want)
+	if string(got) != string(wantJSON) {
+		t.Errorf("body = %s, want %s", got, wantJSON)
+	}
+}
+
+func TestIntegration_API_InputFileAndQueryFields(t *testing.T) {
+	srv := echoServer(t)
+	defer srv.Close()
+	input := filepath.Join(t.TempDir(), "body.json")
+	if err := os.WriteFile(input, []byte(`{"message":"Deploy started"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "api", "PUT", "/v2/things?a=1", "--input", input, "-f", "b=2", "--json", "--jq", "[.body.message, .query.a[0], .query.b[0]]")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, `"Deploy started"`)
+	assertContains(t, stdout, `"1"`)
+	assertContains(t, stdout, `"2"`)
+}
+
+func TestIntegration_API_ErrorStatus(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "api", "/v2/does-not-exist")
+	assertExitCode(t, exitCode, 4)
+	assertContains(t, stderr, "404")
+}
+
+// ─── listen ───────────────────────────────────────────────────────────────────
+
+// startListen runs "listen" on a free port and returns its URL and a
+// function that waits for it to exit, returning stdout and stderr.
+func startListen(t *testing.T, args ...string) (string, func() (string, string)) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	cmd := exec.Command(binaryPath, append([]string{"listen", "--addr", addr}, args...)...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = cmd.Process.Kill() })
+	for i := 0; i < 50; i++ {
+		if c, err := net.Dial("tcp", addr); err == nil {
+			c.Close()
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	wait := func() (string, string) {
+		done := make(chan error, 1)
+		go func() { done <- cmd.Wait() }()
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Errorf("listen exited with %v\n%s", err, stderr.String())
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatalf("listen did not exit\n%s", stderr.String())
+		}
+		return stdout.String(), stderr.String()
+	}
+	return "http://" + addr, wait
+}
+
+func postWebhook(t *testing.T, url, body string, header ...string) int {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	if len(header) == 2 {
+		req.Header.Set(header[0], header[1])
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestIntegration_Listen_PrintsEvents(t *testing.T) {
+	url, wait := startListen(t, "--path", "/hook", "--count", "1", "--action", "Create", "--require-header", "X-Secret=s3cret")
+
+	if code := postWebhook(t, url+"/hook", `{"action":"Create","alert":{"alertId":"a1"}}`); code != http.StatusUnauthorized {
+		t.Errorf("without secret: status %d, want 401", code)
+	}
+	if code := postWebhook(t, url+"/hook", `{"action":"Create"}`, "X-Secret", "s3cret"); code != http.StatusBadRequest {
+		t.Errorf("invalid payload: status %d, want 400", code)
+	}
+	if code := postWebhook(t, url+"/hook", `{"action":"Close","alert":{"alertId":"a0"}}`, "X-Secret", "s3cret"); code != http.StatusOK {
+		t.Errorf("filtered action: status %d, want 200", code)
+	}
+	if code := postWebhook(t, url+"/hook", `{"action":"Create","alert":{"alertId":"a1","message":"Disk full"}}`, "X-Secret", "s3cret"); code != http.StatusOK {
+		t.Errorf("valid event: status %d, want 200", code)
+	}
+
+	stdout, stderr := wait()
+	var ev map[string]interface{}
+	if err := json.Unmarshal([]byte(stdout), &ev); err != nil {
+		t.Fatalf("stdout is not one JSON event: %v\n%s", err, stdout)
+	}
+	if ev["action"] != "Create" || ev["alertId"] != "a1" || ev["message"] != "Disk full" {
+		t.Errorf("event = %v", ev)
+	}
+	assertContains(t, stderr, "payload has no alert")
+}
+
+func TestIntegration_Listen_Exec(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("handler is a shell command")
+	}
+	out := filepath.Join(t.TempDir(), "events")
+	url, wait := startListen(t, "--count", "2", "--exec", `echo "$OPSGENIE_EVENT_ACTION $OPSGENIE_ALERT_ID $(cat | wc -l)" >> `+out)
+
+	postWebhook(t, url, `{"action":"Create","alert":{"alertId":"a1"}}`)
+	postWebhook(t, url, `{"action":"Close","alert":{"alertId":"a1"}}`)
+	stdout, _ := wait()
+	if stdout != "" {
+		t.Errorf("events printed with --exec: %q", stdout)
+	}
+	b, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Fields(strings.ReplaceAll(string(b), "\n", " | "))
+	if got := strings.Join(lines, " "); got != "Create a1 1 | Close a1 1 |" {
+		t.Errorf("handler runs = %q", got)
+	}
+}
+
+// ─── whoami ───────────────────────────────────────────────────────────────────
+
+// keyScopeServer answers like OpsGenie for a key with the given rights.
+func keyScopeServer(t *testing.T, valid, configuration, create bool) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "600")
+		w.Header().Set("X-RateLimit-Remaining", "597")
+		switch {
+		case !valid:
+			writeJSON(w, http.StatusUnauthorized, map[string]interface{}{"message": "Could not authenticate"})
+		case r.URL.Path == "/v2/account" && !configuration, r.Method == http.MethodPost && !create:
+			writeJSON(w, http.StatusForbidden, map[string]interface{}{"message": "You are not authorized"})
+		case r.URL.Path == "/v2/account":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"name": "acme", "plan": map[string]interface{}{"name": "Enterprise"}}})
+		case r.Method == http.MethodPost:
+			writeJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{"message": "Message can not be empty"})
+		default:
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"count": 0}})
+		}
+	}))
+}
+
+func TestIntegration_Whoami_FullKey(t *testing.T) {
+	srv := keyScopeServer(t, true, true, true)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "whoami", "--json")
+	assertExitCode(t, exitCode, 0)
+	var info map[string]interface{}
+	if err := json.Unmarshal([]byte(stdout), &info); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout)
+	}
+	if info["account"] != "acme" || info["scope"] != "full" || info["key"] != "****-key" || info["keySource"] != "OPSGENIE_API_KEY" {
+		t.Errorf("info = %v", info)
+	}
+	rl, _ := info["rateLimit"].(map[string]interface{})
+	if rl["limit"] != float64(600) || rl["remaining"] != float64(597) {
+		t.Errorf("rateLimit = %v", info["rateLimit"])
+	}
+}
+
+func TestIntegration_Whoami_ReadOnlyKey(t *testing.T) {
+	srv := keyScopeServer(t, true, false, false)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "whoami")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "read-only")
+	assertContains(t, stdout, "no configuration access")
+	assertContains(t, stdout, "597 of 600 requests remaining")
+}
+
+func TestIntegration_Whoami_InvalidKey(t *testing.T) {
+	srv := keyScopeServer(t, false, false, false)
+	defer srv.Close()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "whoami")
+	assertExitCode(t, exitCode, 3)
+	assertContains(t, stderr, "API key rejected")
+}
+
+// ── response cache ──────────────────────────────────────────────────────────
+
+func TestIntegration_CacheTTL(t *testing.T) {
+	var gets int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			atomic.AddInt32(&gets, 1)
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"data": []map[string]interface{}{{"id": "t1", "name": "ops"}},
+		})
+	}))
+	defer srv.Close()
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	for i := 0; i < 2; i++ {
+		stdout, _, exitCode := runCLI(t, srv.URL, "teams", "list", "--cache-ttl", "60s", "--json")
+		assertExitCode(t, exitCode, 0)
+		assertContains(t, stdout, `"ops"`)
+	}
+	if n := atomic.LoadInt32(&gets); n != 1 {
+		t.Errorf("sent %d GETs with --cache-ttl, want 1", n)
+	}
+
+	_, _, exitCode := runCLI(t, srv.URL, "teams", "list", "--cache-ttl", "60s", "--no-cache")
+	assertExitCode(t, exitCode, 0)
+	if n := atomic.LoadInt32(&gets); n != 2 {
+		t.Errorf("sent %d GETs after --no-cache, want 2", n)
+	}
+}
+
+// ── name resolution ─────────────────────────────────────────────────────────
+
+// namedResourcesServer lists one schedule and two teams sharing a name, and
+// serves the schedule's rotations by ID only.
+func namedResourcesServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/schedules":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": []interface{}{
+				map[string]interface{}{"id": "sched-1", "name": "Primary On-Call"},
+			}})
+		case "/v2/schedules/sched-1/rotations":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": []interface{}{
+				map[string]interface{}{"id": "rot-1", "name": "weekly", "type": "weekly", "length": 1},
+			}})
+		case "/v2/teams":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": []interface{}{
+				map[string]interface{}{"id": "team-a", "name": "Platform Team"},
+				map[string]interface{}{"id": "team-b", "name": "Platform Team"},
+			}})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"message": "not found"})
+		}
+	}))
+}
+
+func TestIntegration_ResolveScheduleName(t *testing.T) {
+	srv := namedResourcesServer(t)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "schedule-rotations", "list", "--schedule", "primary on-call")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "rot-1")
+}
+
+func TestIntegration_ResolveAmbiguousTeamName(t *testing.T) {
+	srv := namedResourcesServer(t)
+	defer srv.Close()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "teams", "get", "Platform Team")
+	assertExitCode(t, exitCode, 1)
+	assertContains(t, stderr, `team name "Platform Team" is ambiguous`)
+	assertContains(t, stderr, "team-a, team-b")
+}
+
+// ── structured errors ───────────────────────────────────────────────────────
+
+func TestIntegration_StructuredError_APIStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{"message": "Alert with id [nope] not found.", "requestId": "req-404"})
+	}))
+	defer srv.Close()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "alerts", "get", "nope", "--json")
+	assertExitCode(t, exitCode, 4)
+	var got map[string]interface{}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(stderr)), &got); err != nil {
+		t.Fatalf("stderr is not one JSON object: %v\n%s", err, stderr)
+	}
+	if got["code"] != "NOT_FOUND" || got["httpStatus"] != float64(404) || got["requestId"] != "req-404" || got["recoverable"] != false {
+		t.Errorf("error = %v", got)
+	}
+}
+
+func TestIntegration_StructuredError_Validation(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "alerts", "update", "alert-id-123", "--priority", "P9", "--json")
+	assertExitCode(t, exitCode, 2)
+	assertContains(t, stderr, `{"code":"VALIDATION","message":"invalid --priority \"P9\" (use P1, P2, P3, P4 or P5)","recoverable":false}`)
+
+	_, stderr, exitCode = runCLI(t, srv.URL, "alerts", "list", "--json", "--no-such-flag")
+	assertExitCode(t, exitCode, 2)
+	assertContains(t, stderr, `"code":"VALIDATION"`)
+
+	_, stderr, _ = runCLI(t, srv.URL, "alerts", "update", "alert-id-123", "--priority", "P9")
+	assertContains(t, stderr, `Error: invalid --priority "P9"`)
+}
+
+// ── exit statuses ───────────────────────────────────────────────────────────
+
+func TestIntegration_ExitCodes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v2/account":
+			writeJSON(w, http.StatusForbidden, map[string]interface{}{"message": "You are not authorized"})
+		case r.URL.Path == "/v2/alerts" && r.Method == http.MethodPost:
+			writeJSON(w, http.StatusAccepted, map[string]interface{}{"result": "Request will be processed", "requestId": "req-1"})
+		case r.URL.Path == "/v2/alerts/requests/req-1":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"success": false, "status": "failed"}})
+		default:
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"message": "not found"})
+		}
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"unknown command", []string{"alertz"}, 2},
+		{"missing argument", []string{"alerts", "get"}, 2},
+		{"forbidden", []string{"account", "get"}, 3},
+		{"not found", []string{"alerts", "get", "nope"}, 4},
+		{"async failed", []string{"alerts", "create", "--message", "x"}, 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, stderr, exitCode := runCLI(t, srv.URL, tt.args...)
+			if exitCode != tt.want {
+				t.Errorf("exit code %d, want %d; stderr: %s", exitCode, tt.want, stderr)
+			}
+		})
+	}
+
+	cmd := exec.Command(binaryPath, "alerts", "list")
+	cmd.Env = []string{"HOME=" + t.TempDir(), "OPSGENIE_API_URL=" + srv.URL}
+	err := cmd.Run()
+	if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 3 {
+		t.Errorf("without an API key: err = %v, want exit status 3", err)
+	}
+}
+
+// ── alert identifier types ──────────────────────────────────────────────────
+
+func TestIntegration_AlertIDType(t *testing.T) {
+	var mu sync.Mutex
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		got = append(got, r.Method+" "+r.URL.EscapedPath()+"?"+r.URL.RawQuery)
+		mu.Unlock()
+		if strings.HasSuffix(r.URL.Path, "/attachments") {
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": []interface{}{}})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"id": "a1"}})
+	}))
+	defer srv.Close()
+
+	runs := [][]string{
+		{"alerts", "acknowledge", "1234"},
+		{"alerts", "close", "bff3ccbf-c7dd-4d96-8a2e-0e1f5d7a9b6c-1772089369305"},
+		{"alerts", "snooze", "db backup failed", "--end-time", "2026-01-01T00:00:00Z"},
+		{"alerts", "add-note", "1234", "--note", "x", "--id-type", "alias"},
+		{"alerts", "attachments", "list", "77"},
+	}
+	for _, args := range runs {
+		_, stderr, exitCode := runCLI(t, srv.URL, args...)
+		if exitCode != 0 {
+			t.Fatalf("%v: exit %d, stderr: %s", args, exitCode, stderr)
+		}
+	}
+	want := []string{
+		"POST /v2/alerts/1234/acknowledge?identifierType=tiny",
+		"POST /v2/alerts/bff3ccbf-c7dd-4d96-8a2e-0e1f5d7a9b6c-1772089369305/close?identifierType=id",
+		"POST /v2/alerts/db%20backup%20failed/snooze?identifierType=alias",
+		"POST /v2/alerts/1234/notes?identifierType=alias",
+		"GET /v2/alerts/77/attachments?alertIdentifierType=tiny",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("requests:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "alerts", "get", "1234", "--id-type", "short")
+	assertExitCode(t, exitCode, 2)
+	assertContains(t, stderr, `invalid --id-type "short"`)
+}
+
+// ── time flags ──────────────────────────────────────────────────────────────
+
+func TestIntegration_TimeFlags(t *testing.T) {
+	t.Setenv("OPSGENIE_TIMEZONE", "Asia/Tokyo")
+	var mu sync.Mutex
+	var bodies []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		bodies = append(bodies, body)
+		mu.Unlock()
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"id": "m1"}})
+	}))
+	defer srv.Close()
+
+	// Times without an offset are read in the configured zone (UTC+9).
+	_, stderr, exitCode := runCLI(t, srv.URL, "maintenance", "create",
+		"--start-date", "2026-03-01 09:00", "--end-date", "1772330400", "--entity", "integration:i1")
+	if exitCode != 0 {
+		t.Fatalf("maintenance create: exit %d, stderr: %s", exitCode, stderr)
+	}
+	mu.Lock()
+	tm, _ := bodies[0]["time"].(map[string]interface{})
+	mu.Unlock()
+	if tm["startDate"] != "2026-03-01T00:00:00Z" || tm["endDate"] != "2026-03-01T02:00:00Z" {
+		t.Errorf("maintenance time = %v", tm)
+	}
+
+	before := time.Now()
+	_, stderr, exitCode = runCLI(t, srv.URL, "alerts", "snooze", "a1", "--end-time", "+2h")
+	if exitCode != 0 {
+		t.Fatalf("alerts snooze: exit %d, stderr: %s", exitCode, stderr)
+	}
+	mu.Lock()
+	endTime, _ := bodies[1]["endTime"].(string)
+	mu.Unlock()
+	end, err := time.Parse(time.RFC3339, endTime)
+	if err != nil {
+		t.Fatalf("endTime: %v", err)
+	}
+	if d := end.Sub(before); d < 2*time.Hour-time.Second || d > 2*time.Hour+time.Minute {
+		t.Errorf("endTime %v is %v from now, want about 2h", end, d)
+	}
+
+	_, stderr, exitCode = runCLI(t, srv.URL, "alerts", "snooze", "a1", "--end-time", "next blue moon")
+	assertExitCode(t, exitCode, 2)
+	assertContains(t, stderr, `invalid --end-time: unrecognised time "next blue moon"`)
+}
+
+// ── forwarding-rules cover ──────────────────────────────────────────────────
+
+func TestIntegration_ForwardingRulesCover(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v2/forwarding-rules" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		writeJSON(w, http.StatusCreated, map[string]interface{}{"data": map[string]interface{}{"id": "fr-1"}})
+	}))
+	defer srv.Close()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "forwarding-rules", "cover", "--to", "bob@example.com", "--for", "3d")
+	assertExitCode(t, exitCode, 1)
+	assertContains(t, stderr, "OPSGENIE_USER")
+
+	t.Setenv("OPSGENIE_USER", "alice@example.com")
+	_, stderr, exitCode = runCLI(t, srv.URL, "forwarding-rules", "cover", "--to", "bob@example.com", "--for", "3d")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stderr, "bob@example.com is covering for alice@example.com")
+
+	from, _ := body["fromUser"].(map[string]interface{})
+	to, _ := body["toUser"].(map[string]interface{})
+	if from["username"] != "alice@example.com" || to["username"] != "bob@example.com" {
+		t.Errorf("users = %v -> %v", from, to)
+	}
+	start, err1 := time.Parse(time.RFC3339, body["startDate"].(string))
+	end, err2 := time.Parse(time.RFC3339, body["endDate"].(string))
+	if err1 != nil || err2 != nil || end.Sub(start) != 72*time.Hour || time.Since(start) > time.Minute {
+		t.Errorf("window = %v .. %v", body["startDate"], body["endDate"])
+	}
+
+	_, stderr, exitCode = runCLI(t, srv.URL, "forwarding-rules", "cover", "--to", "Alice@example.com", "--for", "1h")
+	assertExitCode(t, exitCode, 2)
+	assertContains(t, stderr, "the user being covered")
+}
+
+// ── schedule-overrides takeover ─────────────────────────────────────────────
+
+func TestIntegration_ScheduleOverridesTakeover(t *testing.T) {
+	var body map[string]interface{}
+	var onCallDate string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/schedules":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": []interface{}{
+				map[string]interface{}{"id": "sched-1", "name": "Primary"},
+			}})
+		case "/v2/users/alice@example.com":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{
+				"id": "user-alice", "username": "alice@example.com",
+			}})
+		case "/v2/schedules/sched-1/on-calls":
+			onCallDate = r.URL.Query().Get("date")
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{
+				"_parent":          map[string]interface{}{"id": "sched-1", "name": "Primary"},
+				"onCallRecipients": []string{"bob@example.com"},
+			}})
+		case "/v2/schedules/sched-1/overrides":
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			writeJSON(w, http.StatusCreated, map[string]interface{}{"data": map[string]interface{}{"alias": "ov-1"}})
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	stdout, stderr, exitCode := runCLI(t, srv.URL, "schedule-overrides", "takeover",
+		"--schedule", "Primary", "--user", "alice@example.com",
+		"--from", "2026-03-01T09:00:00Z", "--for", "8h", "--json")
+	if exitCode != 0 {
+		t.Fatalf("exit %d, stderr: %s", exitCode, stderr)
+	}
+	assertContains(t, stderr, "alice@example.com takes over Primary from bob@example.com until 2026-03-01T17:00:00Z")
+	assertContains(t, stdout, `"alias": "ov-1"`)
+	assertContains(t, stdout, `"displaced": [`)
+
+	if onCallDate != "2026-03-01T09:00:00Z" {
+		t.Errorf("on-call lookup date = %q", onCallDate)
+	}
+	user, _ := body["user"].(map[string]interface{})
+	if user["id"] != "user-alice" || body["startDate"] != "2026-03-01T09:00:00Z" || body["endDate"] != "2026-03-01T17:00:00Z" {
+		t.Errorf("override body = %v", body)
+	}
+}
+
+// ── logs ────────────────────────────────────────────────────────────────────
+
+func TestIntegration_Logs(t *testing.T) {
+	var mu sync.Mutex
+	var markers []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/v2/logs/list/"):
+			marker := strings.TrimPrefix(r.URL.Path, "/v2/logs/list/")
+			mu.Lock()
+			markers = append(markers, marker)
+			mu.Unlock()
+			if marker == "2026-03-01-00-00-00" {
+				writeJSON(w, http.StatusOK, map[string]interface{}{
+					"data":   []interface{}{map[string]interface{}{"filename": "2026-03-01-00-05-00.json", "date": 1772323500000, "size": 120}},
+					"marker": "2026-03-01-00-05-00",
+				})
+				return
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"data": []interface{}{map[string]interface{}{"filename": "2026-03-01-00-10-00.json", "date": 1772323800000, "size": 80}},
+			})
+		case strings.HasPrefix(r.URL.Path, "/v2/logs/download/"):
+			if r.Header.Get("Authorization") == "" {
+				t.Error("log link requested without credentials")
+			}
+			_, _ = w.Write([]byte("http://" + r.Host + "/signed/" + strings.TrimPrefix(r.URL.Path, "/v2/logs/download/")))
+		case strings.HasPrefix(r.URL.Path, "/signed/"):
+			if r.Header.Get("Authorization") != "" {
+				t.Error("credentials sent to the signed link")
+			}
+			_, _ = w.Write([]byte(`{"log":"` + strings.TrimPrefix(r.URL.Path, "/signed/") + `"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	stdout, stderr, exitCode := runCLI(t, srv.URL, "logs", "list", "--marker", "2026-03-01T00:00:00Z", "--json")
+	if exitCode != 0 {
+		t.Fatalf("logs list: exit %d, stderr: %s", exitCode, stderr)
+	}
+	mu.Lock()
+	if strings.Join(markers, ",") != "2026-03-01-00-00-00,2026-03-01-00-05-00" {
+		t.Errorf("markers = %v", markers)
+	}
+	mu.Unlock()
+	assertContains(t, stdout, "2026-03-01-00-05-00.json")
+	assertContains(t, stdout, "2026-03-01-00-10-00.json")
+
+	dir := filepath.Join(t.TempDir(), "audit")
+	_, stderr, exitCode = runCLI(t, srv.URL, "logs", "download", "2026-03-01-00-05-00.json", "2026-03-01-00-10-00.json", "--dir", dir)
+	if exitCode != 0 {
+		t.Fatalf("logs download: exit %d, stderr: %s", exitCode, stderr)
+	}
+	for _, name := range []string{"2026-03-01-00-05-00.json", "2026-03-01-00-10-00.json"} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil || !strings.Contains(string(data), name) {
+			t.Errorf("%s: got %q, %v", name, data, err)
+		}
+	}
+}
+
+// ── notification rule steps and criteria ────────────────────────────────────
+
+func TestIntegration_NotificationRuleStepsAndCriteria(t *testing.T) {
+	var mu sync.Mutex
+	var reqs []string
+	var bodies []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		reqs = append(reqs, r.Method+" "+r.URL.Path)
+		bodies = append(bodies, body)
+		mu.Unlock()
+		if r.Method == http.MethodGet {
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": []interface{}{
+				map[string]interface{}{"id": "st-1", "contact": map[string]interface{}{"method": "sms", "to": "+15551234567"},
+					"sendAfter": map[string]interface{}{"timeAmount": 5}, "enabled": true},
+			}})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"id": "nr-1"}})
+	}))
+	defer srv.Close()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "notification-rules", "create", "--user", "alice@example.com",
+		"--name", "P1 pages", "--action-type", "create-alert", "--condition", "priority equals P1",
+		"--step", "email:alice@example.com", "--step", "sms:+15551234567/5m", "--repeat", "15m",
+		"--time-restriction", "09:00-17:00")
+	if exitCode != 0 {
+		t.Fatalf("create: exit %d, stderr: %s", exitCode, stderr)
+	}
+	mu.Lock()
+	body, _ := json.Marshal(bodies[0])
+	mu.Unlock()
+	for _, want := range []string{
+		`"criteria":{"conditions":[{"expectedValue":"P1","field":"priority","operation":"equals","order":0}],"type":"match-all-conditions"}`,
+		`"steps":[{"contact":{"method":"email","to":"alice@example.com"},"enabled":true,"sendAfter":{"timeAmount":0,"timeUnit":"minutes"}},{"contact":{"method":"sms","to":"+15551234567"},"enabled":true,"sendAfter":{"timeAmount":5,"timeUnit":"minutes"}}]`,
+		`"repeat":{"enabled":true,"loopAfter":15}`,
+		`"timeRestriction":{"restriction":{"endHour":17,"endMin":0,"startHour":9,"startMin":0},"type":"time-of-day"}`,
+	} {
+		assertContains(t, string(body), want)
+	}
+
+	_, stderr, exitCode = runCLI(t, srv.URL, "notification-rules", "create", "--user", "alice@example.com",
+		"--name", "x", "--action-type", "schedule-start", "--notification-time", "2-hours-ago")
+	assertExitCode(t, exitCode, 2)
+	assertContains(t, stderr, "invalid --notification-time")
+
+	stdout, stderr, exitCode := runCLI(t, srv.URL, "notification-rules", "steps", "list", "--user", "alice@example.com", "--rule-id", "nr-1")
+	if exitCode != 0 {
+		t.Fatalf("steps list: exit %d, stderr: %s", exitCode, stderr)
+	}
+	assertContains(t, stdout, "+15551234567")
+	assertContains(t, stdout, "5 minutes")
+
+	_, stderr, exitCode = runCLI(t, srv.URL, "notification-rules", "steps", "create", "--user", "alice@example.com", "--rule-id", "nr-1",
+		"--method", "voice", "--to", "+15550000000", "--after", "10m")
+	if exitCode != 0 {
+		t.Fatalf("steps create: exit %d, stderr: %s", exitCode, stderr)
+	}
+	_, stderr, exitCode = runCLI(t, srv.URL, "notification-rules", "steps", "disable", "--user", "alice@example.com", "--rule-id", "nr-1", "--step-id", "st-1")
+	if exitCode != 0 {
+		t.Fatalf("steps disable: exit %d, stderr: %s", exitCode, stderr)
+	}
+
+	want := []string{
+		"POST /v2/users/alice@example.com/notification-rules",
+		"GET /v2/users/alice@example.com/notification-rules/nr-1/notification-steps",
+		"POST /v2/users/alice@example.com/notification-rules/nr-1/notification-steps",
+		"POST /v2/users/alice@example.com/notification-rules/nr-1/notification-steps/st-1/disable",
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if strings.Join(reqs, "\n") != strings.Join(want, "\n") {
+		t.Errorf("requests:\n%s", strings.Join(reqs, "\n"))
+	}
+	step, _ := json.Marshal(bodies[2])
+	assertContains(t, string(step), `"sendAfter":{"timeAmount":10,"timeUnit":"minutes"}`)
+}
+
+// ── alerts escalate-next / snooze --until-business-hours ────────────────────
+
+func TestIntegration_AlertsEscalateNext(t *testing.T) {
+	var escalated map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/alerts/1234":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{
+				"id": "alert-1", "message": "disk full", "createdAt": "2026-03-02T10:00:00Z",
+				"teams": []interface{}{map[string]interface{}{"id": "team-1"}},
+			}})
+		case "/v2/teams/team-1/routing-rules":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": []interface{}{
+				map[string]interface{}{"id": "rr-1", "name": "Default", "isDefault": true,
+					"criteria": map[string]interface{}{"type": "match-all"},
+					"notify":   map[string]interface{}{"type": "escalation", "id": "esc-1", "name": "Platform_escalation"}},
+			}})
+		case "/v2/alerts/alert-1/escalate":
+			_ = json.NewDecoder(r.Body).Decode(&escalated)
+			writeJSON(w, http.StatusAccepted, map[string]interface{}{"result": "Request will be processed", "requestId": "req-1"})
+		case "/v2/alerts/requests/req-1":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"success": true, "isSuccess": true}})
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "alerts", "escalate-next", "1234")
+	if exitCode != 0 {
+		t.Fatalf("exit %d, stderr: %s", exitCode, stderr)
+	}
+	assertContains(t, stderr, "next rule of Platform_escalation")
+	if e, _ := escalated["escalation"].(map[string]interface{}); e["id"] != "esc-1" {
+		t.Errorf("escalate body = %v", escalated)
+	}
+}
+
+func TestIntegration_AlertsSnoozeUntilBusinessHours(t *testing.T) {
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{}})
+	}))
+	defer srv.Close()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "alerts", "snooze", "a1", "--until-business-hours", "--timezone", "Europe/Berlin")
+	if exitCode != 0 {
+		t.Fatalf("exit %d, stderr: %s", exitCode, stderr)
+	}
+	end, err := time.Parse(time.RFC3339, body["endTime"].(string))
+	if err != nil {
+		t.Fatal(err)
+	}
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	local := end.In(berlin)
+	if local.Hour() != 9 || local.Minute() != 0 || local.Weekday() == time.Saturday || local.Weekday() == time.Sunday ||
+		!end.After(time.Now()) || end.Sub(time.Now()) > 4*24*time.Hour {
+		t.Errorf("endTime %s (%s in Berlin) is not the next weekday 09:00", end, local)
+	}
+
+	_, stderr, exitCode = runCLI(t, srv.URL, "alerts", "snooze", "a1", "--until-business-hours", "--for", "1h")
+	assertExitCode(t, exitCode, 2)
+	assertContains(t, stderr, "cannot be combined")
+}
+
+// ── foreach-profile ─────────────────────────────────────────────────────────
+
+func TestIntegration_ForeachProfile(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		team := map[string]string{"GenieKey acme-key": "Acme Ops", "GenieKey globex-key": "Globex SRE"}[r.Header.Get("Authorization")]
+		if team == "" {
+			writeJSON(w, http.StatusUnauthorized, map[string]interface{}{"message": "Key is invalid"})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": []interface{}{
+			map[string]interface{}{"id": strings.ToLower(strings.Fields(team)[0]) + "-team", "name": team},
+		}})
+	}))
+	defer srv.Close()
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	writeProfiles := func(profiles map[string]interface{}) {
+		data, _ := json.Marshal(map[string]interface{}{"profiles": profiles})
+		if err := os.WriteFile(filepath.Join(home, ".opsgenie-cli-auth.json"), data, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeProfiles(map[string]interface{}{
+		"acme":   map[string]string{"api_key": "acme-key"},
+		"globex": map[string]string{"api_key": "globex-key"},
+	})
+
+	stdout, stderr, exitCode := runCLI(t, srv.URL, "foreach-profile", "--", "teams", "list")
+	if exitCode != 0 {
+		t.Fatalf("table: exit %d, stderr: %s", exitCode, stderr)
+	}
+	assertContains(t, stdout, "PROFILE")
+	assertContains(t, stdout, "acme")
+	assertContains(t, stdout, "Globex SRE")
+
+	stdout, stderr, exitCode = runCLI(t, srv.URL, "foreach-profile", "--json", "--", "teams", "list")
+	if exitCode != 0 {
+		t.Fatalf("json: exit %d, stderr: %s", exitCode, stderr)
+	}
+	var merged []map[string]interface{}
+	if err := json.Unmarshal([]byte(stdout), &merged); err != nil {
+		t.Fatalf("parse %q: %v", stdout, err)
+	}
+	if len(merged) != 2 || merged[0]["profile"] != "acme" || merged[1]["profile"] != "globex" || merged[1]["name"] != "Globex SRE" {
+		t.Errorf("merged = %v", merged)
+	}
+
+	// --profile picks one account for any command.
+	stdout, stderr, exitCode = runCLI(t, srv.URL, "teams", "list", "--profile", "globex", "--json")
+	if exitCode != 0 {
+		t.Fatalf("--profile: exit %d, stderr: %s", exitCode, stderr)
+	}
+	assertContains(t, stdout, "globex-team")
+
+	_, stderr, exitCode = runCLI(t, srv.URL, "foreach-profile", "--", "teams", "delete", "x")
+	assertExitCode(t, exitCode, 2)
+	assertContains(t, stderr, "only runs commands that read")
+
+	for _, args := range [][]string{
+		{"advisor", "--delete-interactively"},
+		{"report", "digest", "--notify=stdout"},
+	} {
+		_, stderr, exitCode = runCLI(t, srv.URL, append([]string{"foreach-profile", "--"}, args...)...)
+		assertExitCode(t, exitCode, 2)
+		assertContains(t, stderr, "only runs commands that read")
+	}
+	_, stderr, exitCode = runCLI(t, srv.URL, "foreach-profile", "--profile", "acme", "--", "teams", "list")
+	assertExitCode(t, exitCode, 2)
+	assertContains(t, stderr, "--profiles")
+
+	// Global flags before the "--" reach every run.
+	stdout, stderr, exitCode = runCLI(t, srv.URL, "foreach-profile", "--mock", "--", "teams", "list")
+	if exitCode != 0 {
+		t.Fatalf("--mock: exit %d, stderr: %s", exitCode, stderr)
+	}
+	assertContains(t, stdout, "Platform")
+	if strings.Contains(stdout, "Acme Ops") {
+		t.Errorf("--mock was not passed on to the runs:\n%s", stdout)
+	}
+
+	// A failing account is reported; the others are still listed.
+	writeProfiles(map[string]interface{}{
+		"acme":   map[string]string{"api_key": "acme-key"},
+		"broken": map[string]string{"api_key": "revoked-key"},
+	})
+	stdout, stderr, exitCode = runCLI(t, srv.URL, "foreach-profile", "--", "teams", "list")
+	assertExitCode(t, exitCode, 1)
+	assertContains(t, stdout, "Acme Ops")
+	assertContains(t, stderr, "profile broken:")
+	assertContains(t, stderr, "1 of 2 profiles failed")
+}
+
+// ── mock mode ───────────────────────────────────────────────────────────────
+
+func TestIntegration_MockMode(t *testing.T) {
+	run := func(env []string, args ...string) (string, string, int) {
+		t.Helper()
+		cmd := exec.Command(binaryPath, args...)
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout, cmd.Stderr = &stdout, &stderr
+		// No API key, and an API address nothing listens on.
+		cmd.Env = append([]string{"HOME=" + t.TempDir(), "OPSGENIE_API_URL=http://127.0.0.1:1", "NO_COLOR=1"}, env...)
+		err := cmd.Run()
+		exitCode := 0
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			exitCode = exitErr.ExitCode()
+		}
+		return stdout.String(), stderr.String(), exitCode
+	}
+
+	stdout, stderr, exitCode := run(nil, "alerts", "list", "--mock")
+	if exitCode != 0 {
+		t.Fatalf("--mock: exit %d, stderr: %s", exitCode, stderr)
+	}
+	assertContains(t, stdout, "alert-id-123")
+
+	stdout, stderr, exitCode = run([]string{"OPSGENIE_MOCK=1"}, "alerts", "create", "--message", "demo", "--json")
+	if exitCode != 0 {
+		t.Fatalf("OPSGENIE_MOCK create: exit %d, stderr: %s", exitCode, stderr)
+	}
+	assertContains(t, stdout, `"isSuccess": true`)
+
+	_, _, exitCode = run(nil, "alerts", "list")
+	assertExitCode(t, exitCode, 3)
+}
+
+// ── proxy and TLS ───────────────────────────────────────────────────────────
+
+func TestIntegration_TLSFlags(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"name": "acme"}})
+	}))
+	defer srv.Close()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "account", "get")
+	if exitCode == 0 || !strings.Contains(stderr, "certificate") {
+		t.Errorf("self-signed server: exit %d, stderr: %s", exitCode, stderr)
+	}
+	stdout, stderr, exitCode := runCLI(t, srv.URL, "account", "get", "--insecure-skip-verify")
+	if exitCode != 0 {
+		t.Fatalf("--insecure-skip-verify: exit %d, stderr: %s", exitCode, stderr)
+	}
+	assertContains(t, stdout, "acme")
+
+	_, stderr, exitCode = runCLI(t, srv.URL, "account", "get", "--ca-cert", filepath.Join(t.TempDir(), "missing.pem"))
+	assertExitCode(t, exitCode, 2)
+	assertContains(t, stderr, "read CA certificate")
+}
+
+// ── regions ─────────────────────────────────────────────────────────────────
+
+func TestIntegration_ConfigRegions(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"name": "gov-account"}})
+	}))
+	defer srv.Close()
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	data, _ := json.Marshal(map[string]interface{}{"api_key": "k", "api_url": srv.URL})
+	if err := os.WriteFile(filepath.Join(home, ".opsgenie-cli-auth.json"), data, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// No OPSGENIE_API_URL: the host comes from the config file.
+	stdout, stderr, exitCode := runCLI(t, "", "account", "get")
+	if exitCode != 0 {
+		t.Fatalf("account get: exit %d, stderr: %s", exitCode, stderr)
+	}
+	assertContains(t, stdout, "gov-account")
+	if atomic.LoadInt32(&hits) != 1 {
+		t.Errorf("config api_url server got %d requests, want 1", hits)
+	}
+
+	stdout, stderr, exitCode = runCLI(t, "", "config", "regions", "--json")
+	if exitCode != 0 {
+		t.Fatalf("config regions: exit %d, stderr: %s", exitCode, stderr)
+	}
+	var regions []map[string]interface{}
+	if err := json.Unmarshal([]byte(stdout), &regions); err != nil {
+		t.Fatalf("parse %q: %v", stdout, err)
+	}
+	var current []string
+	for _, r := range regions {
+		if r["current"] == true {
+			current = append(current, r["name"].(string))
+		}
+	}
+	if len(regions) != 4 || strings.Join(current, ",") != "config" {
+		t.Errorf("regions = %v", regions)
+	}
+
+	_, stderr, exitCode = runCLI(t, "", "account", "get", "--region", "ap")
+	assertExitCode(t, exitCode, 2)
+	assertContains(t, stderr, `unknown region "ap"`)
+}
+
+// ── alerts close --query ────────────────────────────────────────────────────
+
+func TestIntegration_AlertsCloseQuery(t *testing.T) {
+	var mu sync.Mutex
+	var closedIDs []string
+	var lastQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && r.URL.Path == "/v2/alerts" {
+			mu.Lock()
+			lastQuery = r.URL.Query().Get("query")
+			mu.Unlock()
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": []interface{}{
+				map[string]interface{}{"id": "a1", "message": "CI flake 1", "status": "open"},
+				map[string]interface{}{"id": "a2", "message": "CI flake 2", "status": "open"},
+				map[string]interface{}{"id": "a3", "message": "CI flake 3", "status": "open"},
+			}})
+			return
+		}
+		if r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/close") {
+			mu.Lock()
+			closedIDs = append(closedIDs, strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v2/alerts/"), "/close"))
+			mu.Unlock()
+			writeJSON(w, http.StatusOK, map[string]interface{}{"result": "Request processed"})
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+	// requests returns what the server has seen so far.
+	requests := func() (closed []string, query string) {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), closedIDs...), lastQuery
+	}
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "alerts", "close", "--query", "tag:ci AND status:open", "--limit", "2", "--yes")
+	assertExitCode(t, exitCode, 1)
+	assertContains(t, stderr, "more than 2 alerts match")
+	if closed, _ := requests(); len(closed) != 0 {
+		t.Fatalf("closed %v despite --limit", closed)
+	}
+
+	_, stderr, exitCode = runCLI(t, srv.URL, "alerts", "close", "--query", "tag:ci AND status:open", "--non-interactive")
+	assertExitCode(t, exitCode, 1)
+	assertContains(t, stderr, "use --yes")
+	if closed, _ := requests(); len(closed) != 0 {
+		t.Fatalf("closed %v without confirmation", closed)
+	}
+
+	// Without a terminal on stdin there is no one to answer, as in CI.
+	_, stderr, exitCode = runCLI(t, srv.URL, "alerts", "close", "--query", "tag:ci AND status:open")
+	assertExitCode(t, exitCode, 1)
+	assertContains(t, stderr, "stdin is not a terminal")
+	if closed, _ := requests(); strings.Contains(stderr, "No alerts closed") || len(closed) != 0 {
+		t.Fatalf("closed %v or declined silently without a terminal:\n%s", closed, stderr)
+	}
+
+	stdout, stderr, exitCode := runCLI(t, srv.URL, "alerts", "close", "--query", "tag:ci AND status:open", "--yes", "--json")
+	if exitCode != 0 {
+		t.Fatalf("close --yes: exit %d, stderr: %s", exitCode, stderr)
+	}
+	closed, query := requests()
+	if query != "tag:ci AND status:open" {
+		t.Errorf("query = %q", query)
+	}
+	sort.Strings(closed)
+	if strings.Join(closed, ",") != "a1,a2,a3" {
+		t.Errorf("closed = %v", closed)
+	}
+	assertContains(t, stdout, `"result": "closed"`)
+	assertContains(t, stdout, `"succeeded":3`)
+
+	_, _, exitCode = runCLI(t, srv.URL, "alerts", "close", "a1", "--query", "x")
+	assertExitCode(t, exitCode, 2)
+	_, _, exitCode = runCLI(t, srv.URL, "alerts", "close")
+	assertExitCode(t, exitCode, 2)
+}
+
+func TestIntegration_Describe(t *testing.T) {
+	platform := map[string]interface{}{"id": "team-platform"}
+	escalation := map[string]interface{}{
+		"id": "esc-1", "name": "Platform Escalation", "ownerTeam": platform,
+		"rules": []interface{}{
+			map[string]interface{}{
+				"condition": "if-not-acked", "notifyType": "default",
+				"delay":     map[string]interface{}{"timeAmount": 0, "timeUnit": "minutes"},
+				"recipient": map[string]interface{}{"type": "schedule", "id": "s1"},
+			},
+			map[string]interface{}{
+				"condition": "if-not-acked", "notifyType": "default",
+				"delay":     map[string]interface{}{"timeAmount": 10, "timeUnit": "minutes"},
+				"recipient": map[string]interface{}{"type": "user", "id": "u-bob"},
+			},
+		},
+	}
+	var userLookups int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/teams":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": []interface{}{
+				map[string]interface{}{"id": "team-platform", "name": "Platform"},
+			}})
+		case "/v2/teams/team-platform":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{
+				"id": "team-platform", "name": "Platform",
+				"members": []interface{}{map[string]interface{}{"user": map[string]interface{}{"id": "u-alice"}, "role": "admin"}},
+			}})
+		case "/v2/schedules":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": []interface{}{
+				map[string]interface{}{"id": "s1", "name": "Primary", "ownerTeam": platform},
+				map[string]interface{}{"id": "s2", "name": "Other"},
+			}})
+		case "/v2/schedules/s1":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{
+				"id": "s1", "name": "Primary", "timezone": "UTC", "enabled": true, "ownerTeam": platform,
+			}})
+		case "/v2/schedules/s1/rotations":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": []interface{}{map[string]interface{}{
+				"id": "r1", "name": "Weekly", "type": "weekly", "length": 1, "startDate": "2026-01-05T09:00:00Z",
+				"participants": []interface{}{
+					map[string]interface{}{"type": "user", "id": "u-alice"},
+					map[string]interface{}{"type": "user", "id": "u-bob"},
+					map[string]interface{}{"type": "user", "id": "u-alice"},
+					map[string]interface{}{"type": "escalation", "id": "esc-1"},
+				},
+			}}})
+		case "/v2/escalations":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": []interface{}{escalation}})
+		case "/v2/escalations/esc-1":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": escalation})
+		case "/v2/users/u-alice":
+			atomic.AddInt32(&userLookups, 1)
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"id": "u-alice", "username": "alice@example.com", "fullName": "Alice"}})
+		case "/v2/users/u-bob":
+			atomic.AddInt32(&userLookups, 1)
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"id": "u-bob", "username": "bob@example.com"}})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"message": "not found"})
+		}
+	}))
+	defer srv.Close()
+
+	stdout, stderr, exitCode := runCLI(t, srv.URL, "schedules", "describe", "Primary")
+	assertExitCode(t, exitCode, 0)
+	if stderr != "" {
+		t.Errorf("unexpected stderr: %s", stderr)
+	}
+	assertContains(t, stdout, "Schedule Primary (s1)")
+	assertContains(t, stdout, "Team:         Platform")
+	assertContains(t, stdout, "Weekly (weekly, every 1, from 2026-01-05T09:00:00Z)")
+	assertContains(t, stdout, "user alice@example.com")
+	assertContains(t, stdout, "user bob@example.com")
+	assertContains(t, stdout, "escalation Platform Escalation")
+	if n := atomic.LoadInt32(&userLookups); n != 2 {
+		t.Errorf("looked up users %d times, want 2", n)
+	}
+
+	stdout, _, exitCode = runCLI(t, srv.URL, "schedules", "describe", "s1", "--json")
+	assertExitCode(t, exitCode, 0)
+	var schedule struct {
+		Name      string
+		OwnerTeam struct{ Name string }
+		Rotations []struct {
+			Participants []struct{ Username, Name string }
+		}
+	}
+	if err := json.Unmarshal([]byte(stdout), &schedule); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout)
+	}
+	if schedule.OwnerTeam.Name != "Platform" || len(schedule.Rotations) != 1 || schedule.Rotations[0].Participants[1].Username != "bob@example.com" {
+		t.Errorf("unexpected schedule: %+v", schedule)
+	}
+
+	stdout, _, exitCode = runCLI(t, srv.URL, "escalations", "describe", "esc-1")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "1. after 0 minutes, if not acked: notify schedule Primary")
+	assertContains(t, stdout, "2. after 10 minutes, if not acked: notify user bob@example.com")
+
+	stdout, _, exitCode = runCLI(t, srv.URL, "teams", "describe", "Platform")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, "alice@example.com (Alice), admin")
+	assertContains(t, stdout, "  Primary\n")
+	assertContains(t, stdout, "Platform Escalation: schedule Primary, then user bob@example.com")
+	if strings.Contains(stdout, "Other") {
+		t.Errorf("schedule of another team listed:\n%s", stdout)
+	}
+}
+
+func TestIntegration_AlertsListFilters(t *testing.T) {
+	var mu sync.Mutex
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/teams":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": []interface{}{
+				map[string]interface{}{"id": "4513b7ea-3b91-438f-b7e4-e3e54af9147c", "name": "Site Reliability"},
+			}})
+		case "/v2/alerts":
+			mu.Lock()
+			queries = append(queries, r.URL.Query().Get("query"))
+			mu.Unlock()
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": []interface{}{}})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"message": "not found"})
+		}
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--priority", "p1"}, "priority:P1"},
+		{[]string{"--priority", "P1,P2", "--status", "open"}, "(priority:P1 OR priority:P2) AND status:open"},
+		{[]string{"--status", "unacked", "--tag", "db", "--tag", "needs review"}, `acknowledged:false AND tag:db AND tag:"needs review"`},
+		{[]string{"--team", "platform,4513b7ea-3b91-438f-b7e4-e3e54af9147c"}, `(teams:platform OR teams:"Site Reliability")`},
+		{[]string{"--query", "source:ci OR source:cd", "--status", "closed"}, "(source:ci OR source:cd) AND status:closed"},
+	}
+	for _, tt := range tests {
+		mu.Lock()
+		queries = nil
+		mu.Unlock()
+		_, stderr, exitCode := runCLI(t, srv.URL, append([]string{"alerts", "list", "--json"}, tt.args...)...)
+		assertExitCode(t, exitCode, 0)
+		mu.Lock()
+		got := queries
+		mu.Unlock()
+		if len(got) != 1 || got[0] != tt.want {
+			t.Errorf("%v: query = %q, want %q (stderr: %s)", tt.args, got, tt.want, stderr)
+		}
+	}
+
+	for _, args := range [][]string{{"--priority", "P6"}, {"--status", "pending"}} {
+		_, _, exitCode := runCLI(t, srv.URL, append([]string{"alerts", "list"}, args...)...)
+		assertExitCode(t, exitCode, 2)
+	}
+}
+
+func TestIntegration_ColumnsAndSortBy(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": []interface{}{
+			map[string]interface{}{"id": "a1", "message": "disk full", "status": "open", "priority": "P3"},
+			map[string]interface{}{"id": "a2", "message": "db down", "status": "open", "priority": "P1"},
+			map[string]interface{}{"id": "a3", "message": "cpu high", "status": "closed", "priority": "P2"},
+		}})
+	}))
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "alerts", "list", "-o", "tsv", "--columns", "priority,id,message", "--sort-by", "priority")
+	assertExitCode(t, exitCode, 0)
+	if want := "Priority\tID\tMessage\nP1\ta2\tdb down\nP2\ta3\tcpu high\nP3\ta1\tdisk full\n"; stdout != want {
+		t.Errorf("stdout = %q, want %q", stdout, want)
+	}
+
+	stdout, _, exitCode = runCLI(t, srv.URL, "alerts", "list", "-o", "id", "--sort-by", "message", "--desc")
+	assertExitCode(t, exitCode, 0)
+	if stdout != "a1\na2\na3\n" {
+		t.Errorf("ids = %q", stdout)
+	}
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "alerts", "list", "--sort-by", "nope")
+	assertExitCode(t, exitCode, 1)
+	assertContains(t, stderr, `unknown sort column "nope"`)
+}
+
+func TestIntegration_CellColors(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	t.Setenv("OPSGENIE_COLORS", "priority.P1=magenta")
+	_, _, exitCode := runCLI(t, srv.URL, "alerts", "list")
+	assertExitCode(t, exitCode, 0)
+
+	// Bad colors never fail a command; they are only read to color a table.
+	t.Setenv("OPSGENIE_COLORS", "priority.P1=purple")
+	_, _, exitCode = runCLI(t, srv.URL, "alerts", "list")
+	assertExitCode(t, exitCode, 0)
+
+	t.Setenv("OPSGENIE_COLORS", "")
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.WriteFile(filepath.Join(home, ".opsgenie-cli-auth.json"), []byte(`{"colors": [`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	_, stderr, exitCode := runCLI(t, srv.URL, "completion", "bash")
+	assertExitCode(t, exitCode, 0)
+	assertNotContains(t, stderr, "colors")
+}
+
+func TestIntegration_QuietPrintsIDs(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	stdout, stderr, exitCode := runCLI(t, srv.URL, "alerts", "list", "-q")
+	assertExitCode(t, exitCode, 0)
+	if stdout != "alert-id-123\n" || stderr != "" {
+		t.Errorf("stdout = %q, stderr = %q; want only the ID", stdout, stderr)
+	}
+
+	stdout, stderr, exitCode = runCLI(t, srv.URL, "alerts", "acknowledge", "alert-id-123", "--quiet")
+	assertExitCode(t, exitCode, 0)
+	if stdout != "" || stderr != "" {
+		t.Errorf("acknowledge -q printed stdout %q, stderr %q", stdout, stderr)
+	}
+
+	// An explicit format, --jq or --template wins over the ID output.
+	stdout, _, exitCode = runCLI(t, srv.URL, "alerts", "list", "-q", "--json")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stdout, `"message"`)
+	stdout, _, exitCode = runCLI(t, srv.URL, "alerts", "list", "-q", "--jq", ".[0].message")
+	assertExitCode(t, exitCode, 0)
+	if strings.Contains(stdout, "alert-id-123") {
+		t.Errorf("-q --jq printed IDs: %q", stdout)
+	}
+}
+
+func TestIntegration_AlertActionsFromStdin(t *testing.T) {
+	var mu sync.Mutex
+	calls := map[string]string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls[r.Method+" "+r.URL.Path] = r.URL.Query().Get("identifierType")
+		mu.Unlock()
+		if strings.HasPrefix(r.URL.Path, "/v2/alerts/bad") {
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"message": "Alert does not exist"})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"result": "ok"})
+	}))
+	defer srv.Close()
+
+	stdout, stderr, exitCode := runCLIWithStdin(t, srv.URL, "a1\n\n  a2  \n1234\n", "alerts", "acknowledge", "-")
+	assertExitCode(t, exitCode, 0)
+	for _, want := range []string{"POST /v2/alerts/a1/acknowledge", "POST /v2/alerts/a2/acknowledge", "POST /v2/alerts/1234/acknowledge"} {
+		if _, ok := calls[want]; !ok {
+			t.Errorf("missing %s; calls = %v", want, calls)
+		}
+	}
+	if calls["POST /v2/alerts/1234/acknowledge"] != "tiny" {
+		t.Errorf("tiny ID sent as %q", calls["POST /v2/alerts/1234/acknowledge"])
+	}
+	assertContains(t, stdout, "Processed")
+	assertContains(t, stderr, "3 alert(s) acknowledged")
+
+	calls = map[string]string{}
+	_, stderr, exitCode = runCLIWithStdin(t, srv.URL, "a1\nbad-1\n", "alerts", "delete", "-")
+	assertExitCode(t, exitCode, 1)
+	assertContains(t, stderr, "alert bad-1:")
+	assertContains(t, stderr, "1 of 2 item(s) failed: bad-1")
+	if _, ok := calls["DELETE /v2/alerts/a1"]; !ok {
+		t.Errorf("a1 not deleted; calls = %v", calls)
+	}
+
+	stdout, stderr, exitCode = runCLIWithStdin(t, srv.URL, "a1\na2\n", "alerts", "add-tags", "-", "--tags", "triaged", "-q")
+	assertExitCode(t, exitCode, 0)
+	if stdout != "" || stderr != "" {
+		t.Errorf("add-tags - -q printed stdout %q, stderr %q", stdout, stderr)
+	}
+
+	_, stderr, exitCode = runCLIWithStdin(t, srv.URL, "\n", "alerts", "close", "-")
+	assertExitCode(t, exitCode, 2)
+	assertContains(t, stderr, "no alert IDs on stdin")
+}
+
+func TestIntegration_AlertsTop(t *testing.T) {
+	var mu sync.Mutex
+	var query string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		query = r.URL.Query().Get("query")
+		mu.Unlock()
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": []interface{}{
+			map[string]interface{}{"id": "a1", "alias": "disk-db1", "source": "prometheus", "message": "Disk full", "status": "open", "count": 40, "createdAt": "2026-10-14T08:00:00Z"},
+			map[string]interface{}{"id": "a2", "alias": "cpu-web", "source": "prometheus", "message": "CPU high", "status": "closed", "count": 3, "createdAt": "2026-10-14T09:00:00Z"},
+			map[string]interface{}{"id": "a3", "alias": "cpu-web", "source": "datadog", "message": "CPU high again", "status": "open", "count": 2, "createdAt": "2026-10-14T10:00:00Z"},
+			map[string]interface{}{"id": "a4", "source": "datadog", "message": "Ping", "status": "closed", "createdAt": "2026-10-14T11:00:00Z"},
+		}})
+	}))
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "alerts", "top", "--json", "--since", "2d", "--query", "teams:platform")
+	assertExitCode(t, exitCode, 0)
+	mu.Lock()
+	if !strings.HasPrefix(query, "(teams:platform) AND createdAt >= ") {
+		t.Errorf("query = %q", query)
+	}
+	mu.Unlock()
+	var groups []struct {
+		Key                       string
+		Alerts, Occurrences, Open int
+		Message                   string
+	}
+	if err := json.Unmarshal([]byte(stdout), &groups); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout)
+	}
+	if len(groups) != 3 || groups[0].Key != "disk-db1" || groups[0].Occurrences != 40 ||
+		groups[1].Key != "cpu-web" || groups[1].Alerts != 2 || groups[1].Occurrences != 5 || groups[1].Open != 1 || groups[1].Message != "CPU high again" ||
+		groups[2].Key != "(none)" {
+		t.Errorf("unexpected groups: %+v", groups)
+	}
+
+	stdout, _, exitCode = runCLI(t, srv.URL, "alerts", "top", "--group-by", "source", "--limit", "1", "-o", "tsv")
+	assertExitCode(t, exitCode, 0)
+	if !strings.HasPrefix(stdout, "Source\tAlerts\tOccurrences\tOpen\tLastSeen\tMessage\nprometheus\t2\t43\t1\t") || strings.Contains(stdout, "datadog") {
+		t.Errorf("stdout = %q", stdout)
+	}
+
+	_, _, exitCode = runCLI(t, srv.URL, "alerts", "top", "--group-by", "owner")
+	assertExitCode(t, exitCode, 2)
+}
+
+func TestIntegration_ServiceAudiences(t *testing.T) {
+	const svc = "11111111-2222-3333-4444-555555555555"
+	var mu sync.Mutex
+	var patched map[string]interface{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/services/"+svc+"/audience-templates", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			mu.Lock()
+			_ = json.NewDecoder(r.Body).Decode(&patched)
+			mu.Unlock()
+			writeJSON(w, http.StatusOK, map[string]interface{}{"result": "Updated", "requestId": "req-1"})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{
+			"responder": map[string]interface{}{"teams": []string{"team-a"}, "individuals": []string{"user-1"}},
+			"stakeholder": map[string]interface{}{
+				"individuals":        []string{"user-2"},
+				"conditionMatchType": "match-any-condition",
+				"conditions":         []interface{}{map[string]interface{}{"matchField": "country", "value": "Germany"}},
+			},
+		}})
+	})
+	mux.HandleFunc("/v2/teams", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": []interface{}{
+			map[string]interface{}{"id": "team-a", "name": "platform"},
+			map[string]interface{}{"id": "team-b", "name": "database"},
+		}})
+	})
+	mux.HandleFunc("/v2/users/", func(w http.ResponseWriter, r *http.Request) {
+		users := map[string]map[string]interface{}{
+			"user-1":          {"id": "user-1", "username": "alice@example.com"},
+			"user-2":          {"id": "user-2", "username": "bob@example.com"},
+			"cto@example.com": {"id": "user-3", "username": "cto@example.com"},
+		}
+		u, ok := users[strings.TrimPrefix(r.URL.Path, "/v2/users/")]
+		if !ok {
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"message": "User not found"})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": u})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "services", "audiences", "get", svc, "-o", "tsv")
+	assertExitCode(t, exitCode, 0)
+	for _, want := range []string{
+		"responder\tteam\tplatform", "responder\tuser\talice@example.com",
+		"stakeholder\tuser\tbob@example.com", "stakeholder\tcondition (match-any-condition)\tcountry=Germany",
+	} {
+		assertContains(t, stdout, want)
+	}
+
+	_, _, exitCode = runCLI(t, srv.URL, "services", "audiences", "set", svc,
+		"--responder-team", "database", "--stakeholder-user", "cto@example.com",
+		"--stakeholder-condition", "customProperty.region=emea", "--stakeholder-condition", "tag=manager", "--match", "all")
+	assertExitCode(t, exitCode, 0)
+	mu.Lock()
+	got, _ := json.Marshal(patched)
+	mu.Unlock()
+	want := `{"responder":{"individuals":["user-1"],"teams":["team-b"]},"stakeholder":{"conditionMatchType":"match-all-conditions","conditions":[{"key":"region","matchField":"customProperty","value":"emea"},{"matchField":"tag","value":"manager"}],"individuals":["user-3"]}}`
+	if string(got) != want {
+		t.Errorf("PATCH body = %s\nwant %s", got, want)
+	}
+
+	_, _, exitCode = runCLI(t, srv.URL, "services", "audiences", "set", svc, "--stakeholder-condition", "planet=mars")
+	assertExitCode(t, exitCode, 2)
+}
+
+func TestIntegration_DeploymentsListAndDelete(t *testing.T) {
+	var mu sync.Mutex
+	var offsets []string
+	var deleted string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/deployments/search", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("serviceIds") != "service-id-1" {
+			t.Errorf("serviceIds = %q", r.URL.Query().Get("serviceIds"))
+		}
+		mu.Lock()
+		offsets = append(offsets, r.URL.Query().Get("offset"))
+		mu.Unlock()
+		if r.URL.Query().Get("page") == "2" {
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": []interface{}{
+				map[string]interface{}{"id": "d3", "name": "v3", "status": "FAILED"},
+			}})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"data": []interface{}{
+				map[string]interface{}{"id": "d1", "name": "v1", "status": "SUCCESSFUL"},
+				map[string]interface{}{"id": "d2", "name": "v2", "status": "FAILED"},
+			},
+			"paging": map[string]interface{}{"next": "http://" + r.Host + "/v2/deployments/search?serviceIds=service-id-1&page=2"},
+		})
+	})
+	mux.HandleFunc("/v2/deployments/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			mu.Lock()
+			deleted = strings.TrimPrefix(r.URL.Path, "/v2/deployments/")
+			mu.Unlock()
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"result": "Deleted"})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "deployments", "list", "--service", "service-id-1", "-o", "id")
+	assertExitCode(t, exitCode, 0)
+	if stdout != "d1\nd2\nd3\n" {
+		t.Errorf("all pages: stdout = %q", stdout)
+	}
+
+	stdout, _, exitCode = runCLI(t, srv.URL, "deployments", "search", "--service", "service-id-1", "--status", "failed", "--limit", "1", "--offset", "5", "-o", "id")
+	assertExitCode(t, exitCode, 0)
+	if stdout != "d2\n" {
+		t.Errorf("--status failed --limit 1: stdout = %q", stdout)
+	}
+	mu.Lock()
+	if offsets[len(offsets)-2] != "5" {
+		t.Errorf("offsets = %q, want the first page of the search at 5", offsets)
+	}
+	mu.Unlock()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "deployments", "delete", "d1")
+	assertExitCode(t, exitCode, 0)
+	assertContains(t, stderr, `Deployment "d1" deleted`)
+	mu.Lock()
+	defer mu.Unlock()
+	if deleted != "d1" {
+		t.Errorf("deleted = %q", deleted)
+	}
+}
+
+func TestIntegration_TeamSchedulesAndEscalations(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/teams":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": []interface{}{
+				map[string]interface{}{"id": "team-platform", "name": "Platform"},
+				map[string]interface{}{"id": "team-db", "name": "Database"},
+			}})
+		case "/v2/schedules":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": []interface{}{
+				map[string]interface{}{"id": "s1", "name": "Platform Primary", "timezone": "UTC", "enabled": true, "ownerTeam": map[string]interface{}{"id": "team-platform"}},
+				map[string]interface{}{"id": "s2", "name": "DB Primary", "ownerTeam": map[string]interface{}{"id": "team-db"}},
+				map[string]interface{}{"id": "s3", "name": "Platform Secondary", "ownerTeam": map[string]interface{}{"name": "Platform"}},
+				map[string]interface{}{"id": "s4", "name": "Unowned"},
+			}})
+		case "/v2/escalations":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": []interface{}{
+				map[string]interface{}{"id": "e1", "name": "DB Escalation", "ownerTeam": map[string]interface{}{"id": "team-db"}},
+				map[string]interface{}{"id": "e2", "name": "Platform Escalation", "ownerTeam": map[string]interface{}{"id": "team-platform"},
+					"rules": []interface{}{map[string]interface{}{"condition": "if-not-acked"}, map[string]interface{}{"condition": "if-not-closed"}}},
+			}})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"message": "not found"})
+		}
+	}))
+	defer srv.Close()
+
+	stdout, _, exitCode := runCLI(t, srv.URL, "teams", "schedules", "Platform", "-o", "id")
+	assertExitCode(t, exitCode, 0)
+	if stdout != "s1\ns3\n" {
+		t.Errorf("teams schedules: stdout = %q", stdout)
+	}
+
+	stdout, _, exitCode = runCLI(t, srv.URL, "teams", "escalations", "Platform", "-o", "tsv")
+	assertExitCode(t, exitCode, 0)
+	if stdout != "ID\tName\tDescription\tRules\ne2\tPlatform Escalation\t\t2\n" {
+		t.Errorf("teams escalations: stdout = %q", stdout)
+	}
+}
+
+func TestIntegration_Timeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(300 * time.Millisecond)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": mockTeam})
+	}))
+	defer srv.Close()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "teams", "get", "team-id-456", "--timeout", "50ms")
+	assertExitCode(t, exitCode, 1)
+	assertContains(t, stderr, "Timeout")
+
+	_, _, exitCode = runCLI(t, srv.URL, "teams", "get", "team-id-456", "--json")
+	assertExitCode(t, exitCode, 0)
+
+	t.Setenv("OPSGENIE_TIMEOUT", "50ms")
+	_, _, exitCode = runCLI(t, srv.URL, "teams", "get", "team-id-456")
+	assertExitCode(t, exitCode, 1)
+
+	_, _, exitCode = runCLI(t, srv.URL, "teams", "get", "team-id-456", "--timeout", "5s", "--json")
+	assertExitCode(t, exitCode, 0)
+
+	t.Setenv("OPSGENIE_TIMEOUT", "soon")
+	_, stderr, exitCode = runCLI(t, srv.URL, "teams", "get", "team-id-456")
+	assertExitCode(t, exitCode, 1)
+	assertContains(t, stderr, "invalid OPSGENIE_TIMEOUT")
+}
+
+func TestIntegration_LogLevelAndFormat(t *testing.T) {
+	srv, _ := newMockServer(t)
+	defer srv.Close()
+
+	_, stderr, exitCode := runCLI(t, srv.URL, "teams", "list", "--log-level", "debug", "--log-format", "json")
+	assertExitCode(t, exitCode, 0)
+	var requests int
+	for _, line := range strings.Split(strings.TrimSpace(stderr), "\n") {
+		var rec map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("stderr line is not JSON: %v\n%s", err, line)
+		}
+		if rec["msg"] == "request" && rec["method"] == "GET" && strings.HasSuffix(rec["url"].(string), "/v2/teams") {
+			requests++
+		}
+	}
+	if requests != 1 {
+		t.Errorf("want one request record for /v2/teams, stderr:\n%s", stderr)
+	}
+
+	_, stderr, exitCode = runCLI(t, srv.URL, "teams", "list", "--log-level", "info")
+	assertExitCode(t, exitCode, 0)
+	if strings.Contains(stderr, "level=DEBUG") {
+		t.Errorf("debug records logged at --log-level info:\n%s", stderr)
+	}
+
+	_, stderr, exitCode = runCLI(t, srv.URL, "teams", "list", "--log-level", "trace")
+	assertExitCode(t, exitCode, 2)
+	assertContains(t, stderr, "invalid --log-level")
+
+	_, _, exitCode = runCLI(t, srv.URL, "teams", "list", "--log-format", "xml")
+	assertExitCode(t, exitCode, 2)
 }
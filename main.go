@@ -2,11 +2,10 @@ package main
 
 import (
 	"embed"
-	"encoding/json"
-	"fmt"
 	"os"
 
 	"github.com/roboalchemist/opsgenie-cli/cmd"
+	"github.com/roboalchemist/opsgenie-cli/pkg/output"
 )
 
 // version is set via ldflags at build time: -X main.version=x.y.z
@@ -29,16 +28,7 @@ func main() {
 	cmd.SetReadmeContents(readmeContents)
 	cmd.SetSkillData(skillMD, commandsRef, skillFS)
 	if err := cmd.Execute(); err != nil {
-		if cmd.IsJSON() {
-			errJSON, _ := json.Marshal(map[string]interface{}{
-				"code":        "ERROR",
-				"message":     err.Error(),
-				"recoverable": false,
-			})
-			fmt.Fprintln(os.Stderr, string(errJSON))
-		} else {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		}
-		os.Exit(1)
+		output.ErrorFrom(err, cmd.GetOutputOptions())
+		os.Exit(cmd.ExitCode(err))
 	}
 }
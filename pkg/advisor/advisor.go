@@ -0,0 +1,126 @@
+// Package advisor flags OpsGenie resources that look unused, as candidates
+// for account cleanup. The checks are heuristics; review findings before
+// deleting anything.
+package advisor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/snapshot"
+)
+
+// Kinds lists the snapshot kinds Analyze needs. Integrations, policies and
+// routing rules are only read for references to escalations.
+var Kinds = []string{"teams", "schedules", "rotations", "escalations", "integrations", "policies", "routing-rules"}
+
+// Finding is a resource that looks unused.
+type Finding struct {
+	Kind   string `json:"kind"`
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// Options tunes the heuristics.
+type Options struct {
+	// DisabledDays is how long an integration must have been disabled (by
+	// its last update time) before it is flagged. Integrations without a
+	// timestamp are always flagged.
+	DisabledDays int
+	Now          time.Time
+}
+
+// Analyze inspects resources collected with snapshot.CollectRaw for Kinds
+// and returns findings sorted by kind and name.
+func Analyze(rs []snapshot.Resource, opts Options) []Finding {
+	rotations := map[string]int{}
+	refs := map[string]bool{}
+	for _, r := range rs {
+		switch r.Kind {
+		case "rotations":
+			rotations[r.ParentID]++
+		case "integrations", "policies", "routing-rules":
+			escalationRefs(r.Data, refs)
+		}
+	}
+
+	var out []Finding
+	for _, r := range rs {
+		f := Finding{Kind: strings.TrimSuffix(r.Kind, "s"), ID: r.ID, Name: r.Name}
+		switch r.Kind {
+		case "teams":
+			if members, _ := r.Data["members"].([]interface{}); len(members) == 0 {
+				f.Reason = "team has no members"
+			}
+		case "schedules":
+			if embedded, _ := r.Data["rotations"].([]interface{}); rotations[r.ID] == 0 && len(embedded) == 0 {
+				f.Reason = "schedule has no rotations"
+			}
+		case "escalations":
+			if !refs["id:"+r.ID] && !refs["name:"+strings.ToLower(r.Name)] {
+				f.Reason = "escalation is not used by any integration, policy or routing rule"
+			}
+		case "integrations":
+			f.Reason = disabledReason(r.Data, opts)
+		}
+		if f.Reason != "" {
+			out = append(out, f)
+		}
+	}
+
+	order := map[string]int{"team": 0, "schedule": 1, "escalation": 2, "integration": 3}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Kind != out[j].Kind {
+			return order[out[i].Kind] < order[out[j].Kind]
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+// disabledReason returns why a disabled integration is flagged, or "" when
+// it is enabled or was changed within opts.DisabledDays.
+func disabledReason(data map[string]interface{}, opts Options) string {
+	if enabled, ok := data["enabled"].(bool); !ok || enabled {
+		return ""
+	}
+	for _, key := range []string{"updatedAt", "createdAt"} {
+		s, _ := data[key].(string)
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			continue
+		}
+		days := int(opts.Now.Sub(t).Hours() / 24)
+		if days < opts.DisabledDays {
+			return ""
+		}
+		return fmt.Sprintf("integration is disabled and unchanged for %d days", days)
+	}
+	return "integration is disabled"
+}
+
+// escalationRefs records every {"type": "escalation"} recipient found at
+// any depth of v, keyed by "id:<id>" and "name:<lowercased name>".
+func escalationRefs(v interface{}, refs map[string]bool) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if typ, _ := t["type"].(string); typ == "escalation" {
+			if id, _ := t["id"].(string); id != "" {
+				refs["id:"+id] = true
+			}
+			if name, _ := t["name"].(string); name != "" {
+				refs["name:"+strings.ToLower(name)] = true
+			}
+		}
+		for _, val := range t {
+			escalationRefs(val, refs)
+		}
+	case []interface{}:
+		for _, val := range t {
+			escalationRefs(val, refs)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package advisor
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/snapshot"
+)
+
+func res(kind, id, name, parentID string, data map[string]interface{}) snapshot.Resource {
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	return snapshot.Resource{Kind: kind, ID: id, Name: name, ParentID: parentID, Data: data}
+}
+
+func TestAnalyze(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	rs := []snapshot.Resource{
+		res("teams", "t1", "Platform", "", map[string]interface{}{"members": []interface{}{map[string]interface{}{"user": "a"}}}),
+		res("teams", "t2", "Ghost", "", nil),
+		res("schedules", "s1", "Primary", "", nil),
+		res("schedules", "s2", "Empty", "", nil),
+		res("schedules", "s3", "Embedded", "", map[string]interface{}{"rotations": []interface{}{map[string]interface{}{"id": "r9"}}}),
+		res("rotations", "r1", "weekly", "s1", nil),
+		res("escalations", "e1", "Used By ID", "", nil),
+		res("escalations", "e2", "Used By Name", "", nil),
+		res("escalations", "e3", "Orphan", "", nil),
+		res("integrations", "i1", "Datadog", "", map[string]interface{}{
+			"enabled":    true,
+			"responders": []interface{}{map[string]interface{}{"type": "escalation", "id": "e1"}},
+		}),
+		res("integrations", "i2", "Old Email", "", map[string]interface{}{"enabled": false, "updatedAt": "2024-01-01T00:00:00Z"}),
+		res("integrations", "i3", "Recent Email", "", map[string]interface{}{"enabled": false, "updatedAt": "2024-05-30T00:00:00Z"}),
+		res("integrations", "i4", "Undated", "", map[string]interface{}{"enabled": false}),
+		res("routing-rules", "rr1", "Default", "t1", map[string]interface{}{
+			"notify": map[string]interface{}{"type": "escalation", "name": "used by name"},
+		}),
+	}
+
+	got := Analyze(rs, Options{DisabledDays: 30, Now: now})
+	var names []string
+	for _, f := range got {
+		names = append(names, f.Kind+":"+f.Name)
+	}
+	want := []string{
+		"team:Ghost",
+		"schedule:Empty",
+		"escalation:Orphan",
+		"integration:Old Email",
+		"integration:Undated",
+	}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("findings = %v, want %v", names, want)
+	}
+	if got[3].Reason != "integration is disabled and unchanged for 152 days" {
+		t.Errorf("reason = %q", got[3].Reason)
+	}
+}
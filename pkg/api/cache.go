@@ -0,0 +1,121 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// diskCache keeps the bodies of successful GET responses in files named
+// after a hash of the API key and URL, so a response is never served to
+// another key. Entries older than ttl are ignored and overwritten.
+type diskCache struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+}
+
+// DefaultCacheDir returns the cache directory under the user's cache
+// directory ($XDG_CACHE_HOME or ~/.cache on Linux).
+func DefaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "opsgenie-cli")
+}
+
+// SetCache caches the responses to GET requests in dir for ttl, so
+// repeated lookups within ttl do not reach the API. Any other successful
+// request clears the cache, as it may have changed what was cached. Async
+// request polls and heartbeat pings are never cached. A zero ttl turns
+// caching off.
+func (c *Client) SetCache(dir string, ttl time.Duration) {
+	c.cache = nil
+	if ttl > 0 {
+		c.cache = &diskCache{dir: dir, ttl: ttl, now: time.Now}
+	}
+}
+
+// cacheable reports whether a GET of path may be answered from the cache.
+// Heartbeat pings are GETs with an effect.
+func cacheable(method, path string) bool {
+	return method == http.MethodGet && !strings.HasSuffix(strings.SplitN(path, "?", 2)[0], "/ping")
+}
+
+func (dc *diskCache) file(apiKey, url string) string {
+	sum := sha256.Sum256([]byte(apiKey + "\x00" + url))
+	return filepath.Join(dc.dir, hex.EncodeToString(sum[:]))
+}
+
+// get returns the cached body for url, if there is a fresh one.
+func (dc *diskCache) get(apiKey, url string) ([]byte, bool) {
+	name := dc.file(apiKey, url)
+	info, err := os.Stat(name)
+	if err != nil || dc.now().Sub(info.ModTime()) > dc.ttl {
+		return nil, false
+	}
+	body, err := os.ReadFile(name)
+	return body, err == nil
+}
+
+// put stores body for url. Failures only cost a later cache miss.
+func (dc *diskCache) put(apiKey, url string, body []byte) {
+	if err := os.MkdirAll(dc.dir, 0o700); err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(dc.dir, ".tmp-*")
+	if err != nil {
+		return
+	}
+	_, werr := tmp.Write(body)
+	cerr := tmp.Close()
+	if werr != nil || cerr != nil || os.Rename(tmp.Name(), dc.file(apiKey, url)) != nil {
+		_ = os.Remove(tmp.Name())
+	}
+}
+
+// clear removes every cached response.
+func (dc *diskCache) clear() {
+	entries, err := os.ReadDir(dc.dir)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		_ = os.Remove(filepath.Join(dc.dir, e.Name()))
+	}
+}
+
+// send is doRequest through the cache, when one is set.
+func (c *Client) send(ctx context.Context, method, path string, body interface{}) (*http.Response, []byte, error) {
+	if c.cache == nil {
+		return c.doRequest(ctx, method, path, body)
+	}
+	url := c.buildURL(path)
+	if cacheable(method, path) {
+		if cached, ok := c.cache.get(c.apiKey, url); ok {
+			c.logger().Debug("request", "method", method, "url", url, "cached", true)
+			resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(bytes.NewReader(cached))}
+			return resp, cached, nil
+		}
+	}
+	resp, respBody, err := c.doRequest(ctx, method, path, body)
+	if err != nil || resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp, respBody, err
+	}
+	if cacheable(method, path) {
+		if resp.StatusCode == http.StatusOK {
+			c.cache.put(c.apiKey, url, respBody)
+		}
+	} else if method != http.MethodGet {
+		c.cache.clear()
+	}
+	return resp, respBody, nil
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingServer answers every request with a JSON body and counts the
+// requests per method.
+func countingServer(t *testing.T) (*httptest.Server, map[string]*int32) {
+	t.Helper()
+	counts := map[string]*int32{http.MethodGet: new(int32), http.MethodPost: new(int32)}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(counts[r.Method], 1)
+		_, _ = w.Write([]byte(`{"data":{"name":"acme"}}`))
+	}))
+	t.Cleanup(ts.Close)
+	return ts, counts
+}
+
+func TestCache_HitWithinTTL(t *testing.T) {
+	ts, counts := countingServer(t)
+	c := newTestClient(t, ts.URL)
+	c.SetCache(t.TempDir(), time.Minute)
+
+	for i := 0; i < 3; i++ {
+		var out struct {
+			Data AccountResponse `json:"data"`
+		}
+		if err := c.Get("/v2/account", &out); err != nil {
+			t.Fatal(err)
+		}
+		if out.Data.Name != "acme" {
+			t.Fatalf("call %d: name = %q", i, out.Data.Name)
+		}
+	}
+	if n := atomic.LoadInt32(counts[http.MethodGet]); n != 1 {
+		t.Errorf("sent %d GETs, want 1", n)
+	}
+}
+
+func TestCache_KeyedByAPIKey(t *testing.T) {
+	ts, counts := countingServer(t)
+	dir := t.TempDir()
+	c := newTestClient(t, ts.URL)
+	c.SetCache(dir, time.Minute)
+	other := NewClient("other-key", "us")
+	other.SetCache(dir, time.Minute)
+
+	if err := c.Get("/v2/account", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := other.Get("/v2/account", nil); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(counts[http.MethodGet]); n != 2 {
+		t.Errorf("sent %d GETs, want 2 (one per API key)", n)
+	}
+}
+
+func TestCache_ExpiredEntryRefetched(t *testing.T) {
+	ts, counts := countingServer(t)
+	dir := t.TempDir()
+	c := newTestClient(t, ts.URL)
+	c.SetCache(dir, time.Minute)
+
+	if err := c.Get("/v2/account", nil); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-2 * time.Minute)
+	entries, _ := os.ReadDir(dir)
+	for _, e := range entries {
+		if err := os.Chtimes(filepath.Join(dir, e.Name()), old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := c.Get("/v2/account", nil); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(counts[http.MethodGet]); n != 2 {
+		t.Errorf("sent %d GETs, want 2", n)
+	}
+}
+
+func TestCache_WriteClearsCache(t *testing.T) {
+	ts, counts := countingServer(t)
+	c := newTestClient(t, ts.URL)
+	c.SetCache(t.TempDir(), time.Minute)
+
+	_ = c.Get("/v2/teams", nil)
+	if err := c.Post("/v2/teams", map[string]string{"name": "ops"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	_ = c.Get("/v2/teams", nil)
+	if n := atomic.LoadInt32(counts[http.MethodGet]); n != 2 {
+		t.Errorf("sent %d GETs, want 2: the POST should clear the cache", n)
+	}
+}
+
+func TestCache_PingNotCached(t *testing.T) {
+	ts, counts := countingServer(t)
+	c := newTestClient(t, ts.URL)
+	c.SetCache(t.TempDir(), time.Minute)
+
+	_ = c.Get("/v2/heartbeats/web/ping", nil)
+	_ = c.Get("/v2/heartbeats/web/ping", nil)
+	if n := atomic.LoadInt32(counts[http.MethodGet]); n != 2 {
+		t.Errorf("sent %d GETs, want 2", n)
+	}
+}
+
+func TestCache_OffByDefault(t *testing.T) {
+	ts, counts := countingServer(t)
+	c := newTestClient(t, ts.URL)
+
+	_ = c.Get("/v2/account", nil)
+	_ = c.Get("/v2/account", nil)
+	if n := atomic.LoadInt32(counts[http.MethodGet]); n != 2 {
+		t.Errorf("sent %d GETs, want 2", n)
+	}
+}
@@ -2,9 +2,11 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"os"
@@ -17,6 +19,7 @@ var version = "dev"
 const (
 	baseURLUS       = "https://api.opsgenie.com"
 	baseURLEU       = "https://api.eu.opsgenie.com"
+	baseURLSandbox  = "https://api.sandbox.opsgenie.com"
 	defaultTimeout  = 30 * time.Second
 	maxRetries      = 3
 	pollInterval    = time.Second
@@ -28,33 +31,139 @@ type Client struct {
 	httpClient *http.Client
 	apiKey     string
 	baseURL    string
-	debug      bool
+	// ctx is used by the methods without a Ctx suffix; see WithContext.
+	ctx       context.Context
+	limiter   *rateLimiter
+	scheduler *scheduler
+	// observer, when set, is told about every request sent; see SetObserver.
+	observer func(method, path string, status int)
+	// waitTimeout bounds async polling; see SetWaitTimeout.
+	waitTimeout time.Duration
+	// onAccepted, when set, replaces async polling; see SetNoWait.
+	onAccepted func(requestID string)
+	// cache, when set, answers repeated GETs; see SetCache.
+	cache *diskCache
+	// names holds the listings behind ResolveID.
+	names *nameCache
+	// debugFile, when set, receives request transcripts; see SetDebugFile.
+	debugFile string
+	// log, when set, replaces slog's default logger; see SetLogger.
+	log *slog.Logger
 }
 
 // NewClient creates a new OpsGenie API client.
-// region should be "us" or "eu". The OPSGENIE_API_URL env var overrides the base URL.
-func NewClient(apiKey, region string, debug bool) *Client {
-	baseURL := baseURLUS
-	if region == "eu" {
-		baseURL = baseURLEU
+// region is a name from Regions or an API URL (see RegionURL); anything
+// else means "us". The OPSGENIE_API_URL env var overrides the base URL.
+// Requests are logged to slog's default logger at debug level; see
+// SetLogger.
+func NewClient(apiKey, region string) *Client {
+	baseURL, err := RegionURL(region)
+	if err != nil {
+		baseURL = baseURLUS
 	}
 	if override := os.Getenv("OPSGENIE_API_URL"); override != "" {
 		baseURL = override
 	}
 
-	return &Client{
+	c := &Client{
 		httpClient: &http.Client{
 			Timeout: defaultTimeout,
 		},
-		apiKey:  apiKey,
-		baseURL: baseURL,
-		debug:   debug,
+		apiKey:      apiKey,
+		baseURL:     baseURL,
+		ctx:         context.Background(),
+		limiter:     newRateLimiter(),
+		waitTimeout: maxPollDuration,
+		names:       &nameCache{},
 	}
+	c.scheduler = newScheduler(c.limiter)
+	return c
+}
+
+// SetLogger logs through l instead of slog's default logger.
+func (c *Client) SetLogger(l *slog.Logger) {
+	c.log = l
 }
 
-func (c *Client) debugLog(format string, args ...interface{}) {
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "[DEBUG] "+format+"\n", args...)
+// logger is the logger set by SetLogger or NewClient, else slog's default.
+func (c *Client) logger() *slog.Logger {
+	if c.log != nil {
+		return c.log
+	}
+	return slog.Default()
+}
+
+// SetRateLimit throttles requests to rps per second. Zero (the default)
+// follows the rate announced by the API's X-RateLimit-* headers, and a
+// negative value disables throttling. The limit is shared with clients
+// derived through WithContext.
+func (c *Client) SetRateLimit(rps float64) {
+	c.limiter.set(rps)
+}
+
+// SetTimeout limits each HTTP request, including reading the response, to
+// d instead of the default 30s.
+func (c *Client) SetTimeout(d time.Duration) {
+	hc := *c.httpClient
+	hc.Timeout = d
+	c.httpClient = &hc
+}
+
+// SetTransport sends requests through rt instead of the network, e.g. to
+// answer them from fixtures in-process.
+func (c *Client) SetTransport(rt http.RoundTripper) {
+	hc := *c.httpClient
+	hc.Transport = rt
+	c.httpClient = &hc
+}
+
+// SetWaitTimeout sets how long a request answered 202 Accepted is polled
+// for completion before giving up, instead of the default 30s.
+func (c *Client) SetWaitTimeout(d time.Duration) {
+	c.waitTimeout = d
+}
+
+// SetNoWait stops the client polling requests answered 202 Accepted: the
+// call succeeds at once and fn is given the request ID, which can be
+// looked up later at /v2/alerts/requests/<id>. A nil fn restores polling.
+func (c *Client) SetNoWait(fn func(requestID string)) {
+	c.onAccepted = fn
+}
+
+// SetObserver registers fn to be called after every HTTP request the client
+// sends, including retries and async polls, with the response status (0
+// when no response was received). It is shared with clients derived
+// through WithContext created afterwards.
+func (c *Client) SetObserver(fn func(method, path string, status int)) {
+	c.observer = fn
+}
+
+// WithContext returns a copy of the client whose Get, Post, Put, Patch,
+// Delete, GetWithParams and ListAll calls use ctx. Cancelling ctx aborts
+// in-flight requests, retries, pagination and async polling.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	c2 := *c
+	c2.ctx = ctx
+	return &c2
+}
+
+// defaultCtx returns the context used by methods without a Ctx suffix.
+func (c *Client) defaultCtx() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+// sleep waits for d or until ctx is done, whichever comes first.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
 	}
 }
 
@@ -64,81 +173,105 @@ func (c *Client) buildURL(path string) string {
 }
 
 // doRequest performs a single HTTP request with auth headers and returns the raw response.
-func (c *Client) doRequest(method, path string, body interface{}) (*http.Response, []byte, error) {
+func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}) (*http.Response, []byte, error) {
 	fullURL := c.buildURL(path)
 
 	var reqBody io.Reader
-	if body != nil {
-		jsonBody, err := json.Marshal(body)
+	var jsonBody []byte
+	contentType := "application/json"
+	if m, ok := body.(*Multipart); ok {
+		form, ct, err := m.encode()
 		if err != nil {
+			return nil, nil, fmt.Errorf("encode form: %w", err)
+		}
+		c.logger().Debug("request", "method", method, "url", fullURL, "file", m.FileName, "bytes", len(m.Content))
+		reqBody, contentType = form, ct
+	} else if body != nil {
+		var err error
+		if jsonBody, err = json.Marshal(body); err != nil {
 			return nil, nil, fmt.Errorf("marshal request: %w", err)
 		}
-		c.debugLog("%s %s body=%s", method, fullURL, string(jsonBody))
+		c.logger().Debug("request", "method", method, "url", fullURL, "body", string(jsonBody))
 		reqBody = bytes.NewBuffer(jsonBody)
 	} else {
-		c.debugLog("%s %s", method, fullURL)
+		c.logger().Debug("request", "method", method, "url", fullURL)
 	}
 
-	req, err := http.NewRequest(method, fullURL, reqBody)
+	req, err := http.NewRequestWithContext(ctx, method, fullURL, reqBody)
 	if err != nil {
 		return nil, nil, fmt.Errorf("create request: %w", err)
 	}
 
-	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Type", contentType)
 	req.Header.Set("Authorization", "GenieKey "+c.apiKey)
 	req.Header.Set("User-Agent", "opsgenie-cli/"+version)
 
+	if err := c.limiter.wait(ctx); err != nil {
+		return nil, nil, err
+	}
+	start := time.Now()
 	resp, err := c.httpClient.Do(req)
+	if c.observer != nil {
+		status := 0
+		if err == nil {
+			status = resp.StatusCode
+		}
+		c.observer(method, path, status)
+	}
 	if err != nil {
+		c.writeTranscript(req, jsonBody, nil, nil, time.Since(start), err)
 		return nil, nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	respBody, err := io.ReadAll(resp.Body)
+	c.writeTranscript(req, jsonBody, resp, respBody, time.Since(start), err)
 	if err != nil {
 		return nil, nil, fmt.Errorf("read response: %w", err)
 	}
 
-	c.debugLog("Response status: %d", resp.StatusCode)
-	c.debugLog("X-RateLimit-Remaining: %s / %s",
-		resp.Header.Get("X-RateLimit-Remaining"),
-		resp.Header.Get("X-RateLimit-Limit"))
-	c.debugLog("Response body: %s", truncate(string(respBody), 2000))
+	c.limiter.observe(resp.Header)
+	c.logger().Debug("response", "method", method, "url", fullURL, "status", resp.StatusCode,
+		"rateLimitRemaining", resp.Header.Get("X-RateLimit-Remaining"),
+		"rateLimit", resp.Header.Get("X-RateLimit-Limit"),
+		"body", truncate(string(respBody), 2000))
 
 	return resp, respBody, nil
 }
 
 // do executes an HTTP request with rate-limit retry logic.
-func (c *Client) do(method, path string, body, result interface{}) error {
+func (c *Client) do(ctx context.Context, method, path string, body, result interface{}) error {
 	var lastErr error
 	backoff := time.Second
 
 	for attempt := 0; attempt <= maxRetries; attempt++ {
 		if attempt > 0 {
-			c.debugLog("Retry %d/%d after %s (rate limited)", attempt, maxRetries, backoff)
-			time.Sleep(backoff)
+			c.logger().Info("retrying rate-limited request", "path", path, "attempt", attempt, "maxRetries", maxRetries, "backoff", backoff, "err", lastErr)
+			if err := sleep(ctx, backoff); err != nil {
+				return err
+			}
 			backoff *= 2
 		}
 
-		resp, respBody, err := c.doRequest(method, path, body)
+		resp, respBody, err := c.send(ctx, method, path, body)
 		if err != nil {
 			return err
 		}
 
 		// Rate limited — backoff and retry
 		if resp.StatusCode == http.StatusTooManyRequests {
-			lastErr = fmt.Errorf("rate limited (429)")
+			lastErr = parseErrorResponse(resp, respBody)
 			continue
 		}
 
 		// Async accepted — poll for completion
 		if resp.StatusCode == http.StatusAccepted {
-			return c.pollRequestResult(respBody, result)
+			return c.pollRequestResult(ctx, respBody, result)
 		}
 
 		// Error response
 		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return parseErrorResponse(resp.StatusCode, respBody)
+			return parseErrorResponse(resp, respBody)
 		}
 
 		// Success — no body expected
@@ -157,32 +290,97 @@ func (c *Client) do(method, path string, body, result interface{}) error {
 
 // Get performs a GET request and decodes the response into result.
 func (c *Client) Get(path string, result interface{}) error {
-	return c.do(http.MethodGet, path, nil, result)
+	return c.GetCtx(c.defaultCtx(), path, result)
+}
+
+// GetCtx is Get with an explicit context.
+func (c *Client) GetCtx(ctx context.Context, path string, result interface{}) error {
+	return c.do(ctx, http.MethodGet, path, nil, result)
 }
 
 // Post performs a POST request with a body and decodes the response into result.
+// A *Multipart body is sent as multipart/form-data, anything else as JSON.
 func (c *Client) Post(path string, body, result interface{}) error {
-	return c.do(http.MethodPost, path, body, result)
+	return c.PostCtx(c.defaultCtx(), path, body, result)
+}
+
+// PostCtx is Post with an explicit context.
+func (c *Client) PostCtx(ctx context.Context, path string, body, result interface{}) error {
+	return c.do(ctx, http.MethodPost, path, body, result)
 }
 
 // Put performs a PUT request with a body and decodes the response into result.
 func (c *Client) Put(path string, body, result interface{}) error {
-	return c.do(http.MethodPut, path, body, result)
+	return c.PutCtx(c.defaultCtx(), path, body, result)
+}
+
+// PutCtx is Put with an explicit context.
+func (c *Client) PutCtx(ctx context.Context, path string, body, result interface{}) error {
+	return c.do(ctx, http.MethodPut, path, body, result)
 }
 
 // Patch performs a PATCH request with a body and decodes the response into result.
 func (c *Client) Patch(path string, body, result interface{}) error {
-	return c.do(http.MethodPatch, path, body, result)
+	return c.PatchCtx(c.defaultCtx(), path, body, result)
+}
+
+// PatchCtx is Patch with an explicit context.
+func (c *Client) PatchCtx(ctx context.Context, path string, body, result interface{}) error {
+	return c.do(ctx, http.MethodPatch, path, body, result)
 }
 
 // Delete performs a DELETE request and decodes the response into result (may be nil).
 func (c *Client) Delete(path string, result interface{}) error {
-	return c.do(http.MethodDelete, path, nil, result)
+	return c.DeleteCtx(c.defaultCtx(), path, result)
+}
+
+// DeleteCtx is Delete with an explicit context.
+func (c *Client) DeleteCtx(ctx context.Context, path string, result interface{}) error {
+	return c.do(ctx, http.MethodDelete, path, nil, result)
+}
+
+// Do performs a request with any method, for endpoints without a wrapper
+// of their own. A non-nil body is sent as JSON; the response is decoded
+// into result as by Get.
+func (c *Client) Do(method, path string, body, result interface{}) error {
+	return c.DoCtx(c.defaultCtx(), method, path, body, result)
+}
+
+// DoCtx is Do with an explicit context.
+func (c *Client) DoCtx(ctx context.Context, method, path string, body, result interface{}) error {
+	return c.do(ctx, method, path, body, result)
+}
+
+// Probe sends a single request, without 429 retries or async polling, and
+// returns the response status and the rate limit it announced. A 2xx body
+// is decoded into result when result is not nil; error statuses are
+// returned as a status, not an error, so callers can tell them apart.
+func (c *Client) Probe(ctx context.Context, method, path string, body, result interface{}) (int, RateLimitInfo, error) {
+	resp, respBody, err := c.doRequest(ctx, method, path, body)
+	if err != nil {
+		return 0, RateLimitInfo{}, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 && result != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, result); err != nil {
+			return resp.StatusCode, ParseRateLimit(resp), fmt.Errorf("parse response: %w", err)
+		}
+	}
+	return resp.StatusCode, ParseRateLimit(resp), nil
+}
+
+// BaseURL returns the API address requests are sent to.
+func (c *Client) BaseURL() string {
+	return c.baseURL
 }
 
 // GetWithParams performs a GET with query parameters and decodes a single page response.
 // The response data field is unmarshalled into result (unwraps the "data" envelope).
 func (c *Client) GetWithParams(path string, params url.Values, result interface{}) error {
+	return c.GetWithParamsCtx(c.defaultCtx(), path, params, result)
+}
+
+// GetWithParamsCtx is GetWithParams with an explicit context.
+func (c *Client) GetWithParamsCtx(ctx context.Context, path string, params url.Values, result interface{}) error {
 	fullPath := path
 	if len(params) > 0 {
 		fullPath = path + "?" + params.Encode()
@@ -193,22 +391,24 @@ func (c *Client) GetWithParams(path string, params url.Values, result interface{
 
 	for attempt := 0; attempt <= maxRetries; attempt++ {
 		if attempt > 0 {
-			c.debugLog("Retry %d/%d after %s", attempt, maxRetries, backoff)
-			time.Sleep(backoff)
+			c.logger().Info("retrying request", "path", path, "attempt", attempt, "maxRetries", maxRetries, "backoff", backoff, "err", lastErr)
+			if err := sleep(ctx, backoff); err != nil {
+				return err
+			}
 			backoff *= 2
 		}
 
-		resp, respBody, err := c.doRequest(http.MethodGet, fullPath, nil)
+		resp, respBody, err := c.send(ctx, http.MethodGet, fullPath, nil)
 		if err != nil {
 			return err
 		}
 
 		if resp.StatusCode == http.StatusTooManyRequests {
-			lastErr = fmt.Errorf("rate limited (429)")
+			lastErr = parseErrorResponse(resp, respBody)
 			continue
 		}
 		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return parseErrorResponse(resp.StatusCode, respBody)
+			return parseErrorResponse(resp, respBody)
 		}
 
 		// Unwrap the "data" field from the envelope
@@ -234,6 +434,51 @@ func (c *Client) GetWithParams(path string, params url.Values, result interface{
 // Each page's raw "data" JSON is appended to a combined JSON array in result.
 // result must be a pointer to a json.RawMessage or slice that can accept unmarshalled arrays.
 func (c *Client) ListAll(path string, params url.Values, result interface{}) error {
+	return c.ListAllCtx(c.defaultCtx(), path, params, result)
+}
+
+// ListAllCtx is ListAll with an explicit context. Cancelling ctx stops
+// pagination between pages as well as the request in flight.
+func (c *Client) ListAllCtx(ctx context.Context, path string, params url.Values, result interface{}) error {
+	var allItems []json.RawMessage
+	err := c.ListAllPagesCtx(ctx, path, params, func(page json.RawMessage) error {
+		// page may be an array or a single object
+		if page[0] != '[' {
+			allItems = append(allItems, page)
+			return nil
+		}
+		var items []json.RawMessage
+		if err := json.Unmarshal(page, &items); err != nil {
+			return fmt.Errorf("unmarshal page data: %w", err)
+		}
+		allItems = append(allItems, items...)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Marshal combined array and unmarshal into result
+	combined, err := json.Marshal(allItems)
+	if err != nil {
+		return fmt.Errorf("marshal combined results: %w", err)
+	}
+	if err := json.Unmarshal(combined, result); err != nil {
+		return fmt.Errorf("decode combined results: %w", err)
+	}
+	return nil
+}
+
+// ListAllPages follows offset-based pagination like ListAll but hands each
+// page's raw "data" to fn as soon as it arrives instead of collecting them,
+// so callers can process very large listings in constant memory. Empty pages
+// are skipped. An error from fn stops pagination and is returned.
+func (c *Client) ListAllPages(path string, params url.Values, fn func(page json.RawMessage) error) error {
+	return c.ListAllPagesCtx(c.defaultCtx(), path, params, fn)
+}
+
+// ListAllPagesCtx is ListAllPages with an explicit context.
+func (c *Client) ListAllPagesCtx(ctx context.Context, path string, params url.Values, fn func(page json.RawMessage) error) error {
 	if params == nil {
 		params = url.Values{}
 	}
@@ -248,40 +493,32 @@ func (c *Client) ListAll(path string, params url.Values, result interface{}) err
 		Paging *Paging         `json:"paging,omitempty"`
 	}
 
-	var allItems []json.RawMessage
 	nextPath := path + "?" + params.Encode()
-
 	for nextPath != "" {
-		c.debugLog("ListAll fetching: %s", nextPath)
+		c.logger().Debug("fetching page", "path", nextPath)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 
-		resp, respBody, err := c.doRequest(http.MethodGet, nextPath, nil)
+		resp, respBody, err := c.send(ctx, http.MethodGet, nextPath, nil)
 		if err != nil {
 			return err
 		}
 
 		if resp.StatusCode == http.StatusTooManyRequests {
-			return fmt.Errorf("rate limited during pagination")
+			return fmt.Errorf("rate limited during pagination: %w", parseErrorResponse(resp, respBody))
 		}
 		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return parseErrorResponse(resp.StatusCode, respBody)
+			return parseErrorResponse(resp, respBody)
 		}
 
 		var page pageEnvelope
 		if err := json.Unmarshal(respBody, &page); err != nil {
 			return fmt.Errorf("parse page: %w", err)
 		}
-
-		// page.Data may be an array or a single object
-		if len(page.Data) > 0 {
-			// If it's an array, unwrap and extend; otherwise append as single item
-			if page.Data[0] == '[' {
-				var items []json.RawMessage
-				if err := json.Unmarshal(page.Data, &items); err != nil {
-					return fmt.Errorf("unmarshal page data: %w", err)
-				}
-				allItems = append(allItems, items...)
-			} else {
-				allItems = append(allItems, page.Data)
+		if len(page.Data) > 0 && string(page.Data) != "null" {
+			if err := fn(page.Data); err != nil {
+				return err
 			}
 		}
 
@@ -295,20 +532,11 @@ func (c *Client) ListAll(path string, params url.Values, result interface{}) err
 			}
 		}
 	}
-
-	// Marshal combined array and unmarshal into result
-	combined, err := json.Marshal(allItems)
-	if err != nil {
-		return fmt.Errorf("marshal combined results: %w", err)
-	}
-	if err := json.Unmarshal(combined, result); err != nil {
-		return fmt.Errorf("decode combined results: %w", err)
-	}
 	return nil
 }
 
 // pollRequestResult extracts a requestId from a 202 response body and polls until completion.
-func (c *Client) pollRequestResult(body []byte, result interface{}) error {
+func (c *Client) pollRequestResult(ctx context.Context, body []byte, result interface{}) error {
 	var asyncResp struct {
 		RequestID string `json:"requestId"`
 		Result    string `json:"result,omitempty"`
@@ -321,23 +549,30 @@ func (c *Client) pollRequestResult(body []byte, result interface{}) error {
 		return nil
 	}
 
-	c.debugLog("Async request accepted, polling requestId=%s", asyncResp.RequestID)
+	if c.onAccepted != nil {
+		c.logger().Debug("async request accepted, not waiting", "requestId", asyncResp.RequestID)
+		c.onAccepted(asyncResp.RequestID)
+		return nil
+	}
+	c.logger().Debug("async request accepted, polling", "requestId", asyncResp.RequestID)
 
-	deadline := time.Now().Add(maxPollDuration)
+	deadline := time.Now().Add(c.waitTimeout)
 	pollPath := "/v2/alerts/requests/" + asyncResp.RequestID
 
 	for time.Now().Before(deadline) {
-		time.Sleep(pollInterval)
+		if err := sleep(ctx, pollInterval); err != nil {
+			return fmt.Errorf("poll request %s: %w", asyncResp.RequestID, err)
+		}
 
 		var statusEnvelope struct {
 			Data RequestResult `json:"data"`
 		}
-		resp, respBody, err := c.doRequest(http.MethodGet, pollPath, nil)
+		resp, respBody, err := c.doRequest(ctx, http.MethodGet, pollPath, nil)
 		if err != nil {
 			return fmt.Errorf("poll request %s: %w", asyncResp.RequestID, err)
 		}
 		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return parseErrorResponse(resp.StatusCode, respBody)
+			return parseErrorResponse(resp, respBody)
 		}
 
 		if err := json.Unmarshal(respBody, &statusEnvelope); err != nil {
@@ -345,7 +580,7 @@ func (c *Client) pollRequestResult(body []byte, result interface{}) error {
 		}
 
 		status := statusEnvelope.Data
-		c.debugLog("Poll result: isSuccess=%v status=%s", status.IsSuccess, status.Status)
+		c.logger().Debug("poll result", "requestId", asyncResp.RequestID, "isSuccess", status.IsSuccess, "status", status.Status)
 
 		if status.IsSuccess {
 			if result != nil {
@@ -358,21 +593,27 @@ func (c *Client) pollRequestResult(body []byte, result interface{}) error {
 		// Terminal failure states
 		switch status.Status {
 		case "failed", "cancelled":
-			return fmt.Errorf("async request %s %s", asyncResp.RequestID, status.Status)
+			return &AsyncError{RequestID: asyncResp.RequestID, Status: status.Status}
 		}
 	}
 
-	return fmt.Errorf("timed out waiting for async request %s after %s", asyncResp.RequestID, maxPollDuration)
+	return &AsyncError{RequestID: asyncResp.RequestID, Timeout: c.waitTimeout}
 }
 
-// parseErrorResponse constructs a structured error from an API error response body.
-func parseErrorResponse(statusCode int, body []byte) error {
+// parseErrorResponse constructs a structured error from an API error
+// response, with the wait its Retry-After header asks for, if any.
+func parseErrorResponse(resp *http.Response, body []byte) error {
+	var retryAfter time.Duration
+	if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs > 0 {
+		retryAfter = time.Duration(secs) * time.Second
+	}
 	var errResp ErrorResponse
 	if err := json.Unmarshal(body, &errResp); err == nil && errResp.Message != "" {
-		errResp.Code = statusCode
+		errResp.Code = resp.StatusCode
+		errResp.RetryAfter = retryAfter
 		return &errResp
 	}
-	return fmt.Errorf("API error (status %d): %s", statusCode, truncate(string(body), 500))
+	return &StatusError{Code: resp.StatusCode, Body: truncate(string(body), 500), RetryAfter: retryAfter}
 }
 
 // RateLimitInfo parses rate limit headers from an HTTP response.
@@ -1,14 +1,20 @@
 package api
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"strings"
 	"sync/atomic"
 	"testing"
+	"time"
 )
 
 // --- Helpers ---
@@ -17,7 +23,7 @@ import (
 func newTestClient(t *testing.T, serverURL string) *Client {
 	t.Helper()
 	t.Setenv("OPSGENIE_API_URL", serverURL)
-	return NewClient("test-key", "us", false)
+	return NewClient("test-key", "us")
 }
 
 // jsonEncode encodes v to JSON, panicking on error (test helper only).
@@ -35,7 +41,7 @@ func TestNewClient_USRegion(t *testing.T) {
 	// Ensure env override is cleared so region logic is exercised.
 	t.Setenv("OPSGENIE_API_URL", "")
 
-	c := NewClient("key", "us", false)
+	c := NewClient("key", "us")
 	if c.baseURL != baseURLUS {
 		t.Errorf("expected baseURL=%q for US region, got %q", baseURLUS, c.baseURL)
 	}
@@ -44,7 +50,7 @@ func TestNewClient_USRegion(t *testing.T) {
 func TestNewClient_EURegion(t *testing.T) {
 	t.Setenv("OPSGENIE_API_URL", "")
 
-	c := NewClient("key", "eu", false)
+	c := NewClient("key", "eu")
 	if c.baseURL != baseURLEU {
 		t.Errorf("expected baseURL=%q for EU region, got %q", baseURLEU, c.baseURL)
 	}
@@ -53,7 +59,7 @@ func TestNewClient_EURegion(t *testing.T) {
 func TestNewClient_UnknownRegionDefaultsToUS(t *testing.T) {
 	t.Setenv("OPSGENIE_API_URL", "")
 
-	c := NewClient("key", "ap", false)
+	c := NewClient("key", "ap")
 	if c.baseURL != baseURLUS {
 		t.Errorf("expected US base URL for unknown region, got %q", c.baseURL)
 	}
@@ -63,7 +69,7 @@ func TestNewClient_EnvOverride(t *testing.T) {
 	override := "http://custom.example.com"
 	t.Setenv("OPSGENIE_API_URL", override)
 
-	c := NewClient("key", "us", false)
+	c := NewClient("key", "us")
 	if c.baseURL != override {
 		t.Errorf("expected baseURL=%q from env override, got %q", override, c.baseURL)
 	}
@@ -72,7 +78,7 @@ func TestNewClient_EnvOverride(t *testing.T) {
 func TestNewClient_APIKeyStored(t *testing.T) {
 	t.Setenv("OPSGENIE_API_URL", "")
 
-	c := NewClient("my-secret-key", "us", false)
+	c := NewClient("my-secret-key", "us")
 	if c.apiKey != "my-secret-key" {
 		t.Errorf("expected apiKey=%q, got %q", "my-secret-key", c.apiKey)
 	}
@@ -262,6 +268,9 @@ func TestGet_NonJSONErrorBody(t *testing.T) {
 	if !strings.Contains(err.Error(), "500") {
 		t.Errorf("expected error to mention status 500, got %q", err.Error())
 	}
+	if got := StatusCode(err); got != http.StatusInternalServerError {
+		t.Errorf("StatusCode = %d, want 500", got)
+	}
 }
 
 // --- Rate limiting retry ---
@@ -462,6 +471,54 @@ func TestPost_Async_NoRequestID_TreatedAsSuccess(t *testing.T) {
 	}
 }
 
+func TestPost_Async_NoWait(t *testing.T) {
+	var polls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/v2/alerts/requests/") {
+			atomic.AddInt32(&polls, 1)
+		}
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write(jsonEncode(map[string]string{"requestId": "req-nowait"}))
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts.URL)
+	var accepted []string
+	c.SetNoWait(func(id string) { accepted = append(accepted, id) })
+	if err := c.Post("/v2/alerts", map[string]string{"message": "test"}, nil); err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+	if len(accepted) != 1 || accepted[0] != "req-nowait" {
+		t.Errorf("accepted = %v, want [req-nowait]", accepted)
+	}
+	if n := atomic.LoadInt32(&polls); n != 0 {
+		t.Errorf("polled %d times with no-wait", n)
+	}
+}
+
+func TestPost_Async_WaitTimeout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write(jsonEncode(map[string]string{"requestId": "req-slow"}))
+			return
+		}
+		_, _ = w.Write(jsonEncode(map[string]interface{}{"data": map[string]interface{}{"isSuccess": false, "status": "Processing"}}))
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts.URL)
+	c.SetWaitTimeout(500 * time.Millisecond)
+	start := time.Now()
+	err := c.Post("/v2/alerts", map[string]string{"message": "test"}, nil)
+	if err == nil || !strings.Contains(err.Error(), "timed out waiting for async request req-slow after 500ms") {
+		t.Fatalf("err = %v, want a 500ms timeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("took %s, want about one poll", elapsed)
+	}
+}
+
 // --- ListAll pagination ---
 
 func TestListAll_SinglePage(t *testing.T) {
@@ -536,6 +593,46 @@ func TestListAll_MultiplePages(t *testing.T) {
 	}
 }
 
+func TestListAllPages_Streams(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Query().Get("offset") == "" {
+			_, _ = w.Write(jsonEncode(map[string]interface{}{
+				"data":   []map[string]string{{"id": "1"}, {"id": "2"}},
+				"paging": map[string]string{"next": fmt.Sprintf("http://%s/v2/alerts?offset=2", r.Host)},
+			}))
+			return
+		}
+		_, _ = w.Write(jsonEncode(map[string]interface{}{
+			"data": []map[string]string{{"id": "3"}},
+		}))
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts.URL)
+	var pages []string
+	err := c.ListAllPages("/v2/alerts", nil, func(page json.RawMessage) error {
+		pages = append(pages, string(page))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ListAllPages error: %v", err)
+	}
+	if len(pages) != 2 || pages[1] != `[{"id":"3"}]` {
+		t.Errorf("pages = %v", pages)
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	err = c.ListAllPages("/v2/alerts", nil, func(json.RawMessage) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("err = %v after %d calls, want stop after 1", err, calls)
+	}
+}
+
 func TestListAll_EmptyDataField(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -833,6 +930,63 @@ func TestDelete_NilResult(t *testing.T) {
 	}
 }
 
+func TestDo_AnyMethodWithRawBody(t *testing.T) {
+	var gotMethod, gotBody string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		_, _ = w.Write([]byte(`{"data":{"count":3}}`))
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts.URL)
+	var result json.RawMessage
+	if err := c.Do(http.MethodPatch, "/v2/things/1", json.RawMessage(`{"a":1}`), &result); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if gotMethod != http.MethodPatch || gotBody != `{"a":1}` {
+		t.Errorf("sent %s %s", gotMethod, gotBody)
+	}
+	if string(result) != `{"data":{"count":3}}` {
+		t.Errorf("result = %s", result)
+	}
+}
+
+func TestProbe_StatusAndRateLimit(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("X-RateLimit-Limit", "600")
+		w.Header().Set("X-RateLimit-Remaining", "598")
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"name":"acme"}}`))
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts.URL)
+	var acct struct {
+		Data AccountResponse `json:"data"`
+	}
+	status, rl, err := c.Probe(context.Background(), http.MethodGet, "/v2/account", nil, &acct)
+	if err != nil || status != http.StatusOK || acct.Data.Name != "acme" {
+		t.Fatalf("GET: status %d, account %+v, err %v", status, acct.Data, err)
+	}
+	if rl.Limit != 600 || rl.Remaining != 598 {
+		t.Errorf("rate limit = %+v", rl)
+	}
+	status, _, err = c.Probe(context.Background(), http.MethodPost, "/v2/alerts", map[string]string{}, nil)
+	if err != nil || status != http.StatusTooManyRequests {
+		t.Errorf("POST: status %d, err %v; want 429 without retries", status, err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Errorf("sent %d requests, want 2", n)
+	}
+}
+
 // --- ParseRateLimit ---
 
 func TestParseRateLimit_Full(t *testing.T) {
@@ -930,7 +1084,7 @@ func TestTruncate_ExactLength(t *testing.T) {
 
 func TestBuildURL(t *testing.T) {
 	t.Setenv("OPSGENIE_API_URL", "")
-	c := NewClient("key", "us", false)
+	c := NewClient("key", "us")
 	got := c.buildURL("/v2/alerts")
 	expected := baseURLUS + "/v2/alerts"
 	if got != expected {
@@ -938,20 +1092,47 @@ func TestBuildURL(t *testing.T) {
 	}
 }
 
-// --- debugLog (coverage only — no assertion on output) ---
+// --- logging ---
 
-func TestDebugLog_NoOutput_WhenDisabled(t *testing.T) {
+func TestLogger_DefaultsToSlogDefault(t *testing.T) {
 	t.Setenv("OPSGENIE_API_URL", "")
-	c := NewClient("key", "us", false) // debug=false
-	// Should not panic or produce visible output; just exercise the code path.
-	c.debugLog("test message %s", "arg")
+	c := NewClient("key", "us")
+	if c.logger() != slog.Default() {
+		t.Error("without SetLogger, the client should log to slog's default logger")
+	}
 }
 
-func TestDebugLog_WithDebugEnabled(t *testing.T) {
-	t.Setenv("OPSGENIE_API_URL", "")
-	c := NewClient("key", "us", true) // debug=true
-	// Exercise the debug path; we don't capture stderr here, just ensure no panic.
-	c.debugLog("debug: %d items", 5)
+func TestSetLogger_LogsRequestsAndResponses(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "99")
+		_, _ = w.Write([]byte(`{"data":{"id":"1"}}`))
+	}))
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	c := newTestClient(t, ts.URL)
+	c.SetLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	if err := c.Get("/v2/teams/1", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	var msgs []string
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var rec map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("log line is not JSON: %v\n%s", err, line)
+		}
+		if rec["level"] != "DEBUG" {
+			t.Errorf("level = %v, want DEBUG", rec["level"])
+		}
+		msgs = append(msgs, rec["msg"].(string))
+		if rec["msg"] == "response" && (rec["status"] != float64(200) || rec["rateLimitRemaining"] != "99") {
+			t.Errorf("response record = %v", rec)
+		}
+	}
+	if strings.Join(msgs, ",") != "request,response" {
+		t.Errorf("messages = %q, want request then response", msgs)
+	}
 }
 
 // --- Get_NilResult (no body parsing) ---
@@ -1225,7 +1406,7 @@ func TestPost_AsyncPoll_ErrorResponse(t *testing.T) {
 
 func TestPost_MarshalError(t *testing.T) {
 	t.Setenv("OPSGENIE_API_URL", "http://localhost:1") // unreachable, but we fail before connecting
-	c := NewClient("key", "us", false)
+	c := NewClient("key", "us")
 
 	// A channel cannot be marshaled to JSON
 	type unmarshalable struct {
@@ -1241,6 +1422,126 @@ func TestPost_MarshalError(t *testing.T) {
 	}
 }
 
+// --- Context cancellation ---
+
+func TestGetCtx_CancelAbortsInFlightRequest(t *testing.T) {
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer ts.Close()
+	defer close(release)
+
+	c := newTestClient(t, ts.URL)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := c.GetCtx(ctx, "/v2/alerts", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Errorf("request was not aborted promptly")
+	}
+}
+
+func TestWithContext_RetryBackoffCancelled(t *testing.T) {
+	var callCount int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&callCount, 1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	base := newTestClient(t, ts.URL)
+	c := base.WithContext(ctx)
+	if base.defaultCtx() != context.Background() {
+		t.Error("WithContext modified the original client")
+	}
+
+	err := c.Get("/v2/alerts", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded during backoff, got %v", err)
+	}
+	if n := atomic.LoadInt32(&callCount); n != 1 {
+		t.Errorf("expected 1 call before cancellation, got %d", n)
+	}
+}
+
+func TestListAllCtx_CancelledStopsPagination(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var ts *httptest.Server
+	var callCount int32
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&callCount, 1)
+		cancel() // cancel after serving the first page
+		_, _ = w.Write(jsonEncode(map[string]interface{}{
+			"data":   []map[string]string{{"id": "1"}},
+			"paging": map[string]string{"next": ts.URL + "/v2/alerts?offset=1"},
+		}))
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts.URL)
+	var items []map[string]string
+	err := c.ListAllCtx(ctx, "/v2/alerts", nil, &items)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context canceled, got %v", err)
+	}
+	if n := atomic.LoadInt32(&callCount); n != 1 {
+		t.Errorf("expected pagination to stop after 1 page, got %d calls", n)
+	}
+}
+
+func TestPostCtx_CancelStopsPolling(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write(jsonEncode(map[string]string{"requestId": "req-1"}))
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts.URL)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := c.PostCtx(ctx, "/v2/alerts", map[string]string{"message": "test"}, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected polling to stop on deadline, got %v", err)
+	}
+}
+
+// --- On-call participant decoding ---
+
+func TestOnCallResponse_FlatRecipients(t *testing.T) {
+	var r OnCallResponse
+	body := `{"_parent":{"id":"s1","name":"Primary"},"onCallRecipients":["alice@example.com","bob@example.com"]}`
+	if err := json.Unmarshal([]byte(body), &r); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	p := r.Participants()
+	if len(p) != 2 || p[0].Name != "alice@example.com" || p[0].Type != "user" {
+		t.Errorf("unexpected participants: %+v", p)
+	}
+}
+
+func TestOnCallResponse_NestedParticipants(t *testing.T) {
+	var r OnCallResponse
+	body := `{"_parent":{"name":"Primary"},"nextOnCallRecipients":[{"id":"u1","name":"carol@example.com","type":"user","onCallStart":"2024-01-01T09:00:00Z"}]}`
+	if err := json.Unmarshal([]byte(body), &r); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	p := r.Participants()
+	if len(p) != 1 || p[0].ID != "u1" || p[0].OnCallStart != "2024-01-01T09:00:00Z" {
+		t.Errorf("unexpected participants: %+v", p)
+	}
+}
+
 // --- readAll helper ---
 
 func readAll(r *http.Request) ([]byte, error) {
@@ -1258,3 +1559,107 @@ func readAll(r *http.Request) ([]byte, error) {
 	}
 	return buf, nil
 }
+
+func TestPost_Multipart(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Errorf("ParseMultipartForm: %v", err)
+		}
+		if r.FormValue("user") != "a@example.com" {
+			t.Errorf("user = %q", r.FormValue("user"))
+		}
+		f, hdr, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("FormFile: %v", err)
+		}
+		defer f.Close()
+		b := new(strings.Builder)
+		_, _ = io.Copy(b, f)
+		if hdr.Filename != "dump.log" || b.String() != "hello" {
+			t.Errorf("file %q = %q", hdr.Filename, b.String())
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"result":"Attached"}`))
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts.URL)
+	body := &Multipart{Fields: map[string]string{"user": "a@example.com"}, FileField: "file", FileName: "dump.log", Content: []byte("hello")}
+	var result map[string]string
+	if err := c.Post("/v2/alerts/x/attachments", body, &result); err != nil {
+		t.Fatalf("Post error: %v", err)
+	}
+	if result["result"] != "Attached" {
+		t.Errorf("result = %v", result)
+	}
+}
+
+func TestDownload(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Error("download sent API credentials")
+		}
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("contents"))
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts.URL)
+	var b strings.Builder
+	if err := c.Download(ts.URL+"/file", &b); err != nil {
+		t.Fatalf("Download error: %v", err)
+	}
+	if b.String() != "contents" {
+		t.Errorf("downloaded %q", b.String())
+	}
+	if err := c.Download(ts.URL+"/missing", &b); err == nil {
+		t.Error("expected an error for HTTP 404")
+	}
+}
+
+func TestSetObserver_SeesEveryAttempt(t *testing.T) {
+	var callCount int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&callCount, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{}}`))
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts.URL)
+	var seen []string
+	c.SetObserver(func(method, path string, status int) {
+		seen = append(seen, fmt.Sprintf("%s %s %d", method, path, status))
+	})
+	if err := c.WithContext(context.Background()).Get("/v2/teams", nil); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	want := []string{"GET /v2/teams 429", "GET /v2/teams 200"}
+	if strings.Join(seen, ",") != strings.Join(want, ",") {
+		t.Errorf("observed %v, want %v", seen, want)
+	}
+}
+
+func TestErrorResponse_RequestIDAndRetryAfter(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"message":"try later","took":0.001,"requestId":"req-42"}`))
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts.URL)
+	err := c.Get("/v2/alerts", nil)
+	var er *ErrorResponse
+	if !errors.As(err, &er) {
+		t.Fatalf("err = %v, want *ErrorResponse", err)
+	}
+	if er.Code != 503 || er.RequestID != "req-42" || er.RetryAfter != 7*time.Second {
+		t.Errorf("got %+v", er)
+	}
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// LogFile is an account log file as listed by /v2/logs/list.
+type LogFile struct {
+	Filename string `json:"filename"`
+	// Date is when the file was written, in epoch milliseconds.
+	Date int64 `json:"date"`
+	Size int64 `json:"size"`
+}
+
+// LogFileList is a page of the account log listing. Marker is the
+// filename to pass as the next page's marker; it is empty on the last page.
+type LogFileList struct {
+	Data   []LogFile `json:"data"`
+	Marker string    `json:"marker,omitempty"`
+}
+
+// LogDownloadURL returns the pre-signed URL of the named account log file.
+func (c *Client) LogDownloadURL(filename string) (string, error) {
+	return c.LogDownloadURLCtx(c.defaultCtx(), filename)
+}
+
+// LogDownloadURLCtx is LogDownloadURL with an explicit context. The
+// endpoint answers with the bare URL as plain text rather than JSON, and
+// the URL expires within minutes, so the response is never cached.
+func (c *Client) LogDownloadURLCtx(ctx context.Context, filename string) (string, error) {
+	resp, body, err := c.doRequest(ctx, http.MethodGet, "/v2/logs/download/"+url.PathEscape(filename), nil)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", parseErrorResponse(resp, body)
+	}
+	link := strings.TrimSpace(string(body))
+	if strings.HasPrefix(link, "{") {
+		var wrapped struct {
+			Data string `json:"data"`
+		}
+		if err := json.Unmarshal(body, &wrapped); err != nil {
+			return "", fmt.Errorf("parse response: %w", err)
+		}
+		link = wrapped.Data
+	}
+	if link == "" {
+		return "", fmt.Errorf("no download URL for log file %s", filename)
+	}
+	return link, nil
+}
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLogDownloadURL(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/logs/download/plain.json":
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte("https://logs.example.com/plain.json?sig=abc\n"))
+		case "/v2/logs/download/wrapped.json":
+			_, _ = w.Write([]byte(`{"data":"https://logs.example.com/wrapped.json"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Log file not found"}`))
+		}
+	}))
+	defer ts.Close()
+	c := newTestClient(t, ts.URL)
+
+	if got, err := c.LogDownloadURL("plain.json"); err != nil || got != "https://logs.example.com/plain.json?sig=abc" {
+		t.Errorf("plain: got %q, %v", got, err)
+	}
+	if got, err := c.LogDownloadURL("wrapped.json"); err != nil || got != "https://logs.example.com/wrapped.json" {
+		t.Errorf("wrapped: got %q, %v", got, err)
+	}
+	_, err := c.LogDownloadURL("missing.json")
+	var er *ErrorResponse
+	if !errors.As(err, &er) || er.Code != http.StatusNotFound {
+		t.Errorf("missing: got %v, want a 404 ErrorResponse", err)
+	}
+}
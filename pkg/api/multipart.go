@@ -0,0 +1,72 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+)
+
+// Multipart is a request body sent as multipart/form-data instead of JSON,
+// for endpoints that take file uploads. Pass it as the body of Post.
+type Multipart struct {
+	// Fields are plain form fields sent before the file.
+	Fields map[string]string
+	// FileField is the form field holding the file, usually "file".
+	FileField string
+	FileName  string
+	Content   []byte
+}
+
+// encode returns the form and its Content-Type header.
+func (m *Multipart) encode() (*bytes.Buffer, string, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	for k, v := range m.Fields {
+		if err := w.WriteField(k, v); err != nil {
+			return nil, "", err
+		}
+	}
+	part, err := w.CreateFormFile(m.FileField, m.FileName)
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err := part.Write(m.Content); err != nil {
+		return nil, "", err
+	}
+	if err := w.Close(); err != nil {
+		return nil, "", err
+	}
+	return &buf, w.FormDataContentType(), nil
+}
+
+// Download fetches rawURL without API credentials and copies the body to w.
+// It is meant for the pre-signed URLs the API hands out for attachments.
+func (c *Client) Download(rawURL string, w io.Writer) error {
+	return c.DownloadCtx(c.defaultCtx(), rawURL, w)
+}
+
+// DownloadCtx is Download with an explicit context.
+func (c *Client) DownloadCtx(ctx context.Context, rawURL string, w io.Writer) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "opsgenie-cli/"+version)
+	c.logger().Debug("request", "method", http.MethodGet, "url", rawURL)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("download failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("download failed: HTTP %d", resp.StatusCode)
+	}
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("download failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// defaultRateLimitPeriod is assumed when a response announces a limit
+// without X-RateLimit-Period-In-Sec.
+const defaultRateLimitPeriod = 60
+
+// rateLimiter is a token bucket shared by all requests of a client. In
+// adaptive mode its rate follows the X-RateLimit-* headers of responses and
+// it does not throttle until a limit has been seen.
+type rateLimiter struct {
+	mu       sync.Mutex
+	rate     float64 // tokens per second; 0 means unlimited
+	burst    float64
+	tokens   float64
+	last     time.Time
+	adaptive bool
+	disabled bool
+	// remaining is the last X-RateLimit-Remaining seen, or -1.
+	remaining int
+	now       func() time.Time
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{adaptive: true, remaining: -1, now: time.Now}
+}
+
+// set configures the limiter; see Client.SetRateLimit.
+func (l *rateLimiter) set(rps float64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.adaptive, l.disabled = rps == 0, rps < 0
+	l.rate, l.burst, l.tokens = 0, 0, 0
+	if rps > 0 {
+		l.rate, l.burst = rps, 1
+		l.tokens, l.last = 1, l.now()
+	}
+}
+
+// refill adds the tokens earned since the last call. l.mu must be held.
+func (l *rateLimiter) refill() {
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+}
+
+// reserve takes a token and returns how long the caller must wait before
+// using it.
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.disabled || l.rate <= 0 {
+		return 0
+	}
+	l.refill()
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// wait blocks until a request may be sent or ctx is done.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	d := l.reserve()
+	if d <= 0 {
+		return nil
+	}
+	return sleep(ctx, d)
+}
+
+// observe adapts the rate to a response's X-RateLimit-Limit,
+// X-RateLimit-Period-In-Sec and X-RateLimit-Remaining headers.
+func (l *rateLimiter) observe(h http.Header) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if remaining, err := strconv.Atoi(h.Get("X-RateLimit-Remaining")); err == nil {
+		l.remaining = remaining
+	}
+	if !l.adaptive {
+		return
+	}
+	limit, err := strconv.Atoi(h.Get("X-RateLimit-Limit"))
+	if err != nil || limit <= 0 {
+		return
+	}
+	period, err := strconv.Atoi(h.Get("X-RateLimit-Period-In-Sec"))
+	if err != nil || period <= 0 {
+		period = defaultRateLimitPeriod
+	}
+	if l.rate == 0 {
+		l.tokens, l.last = float64(limit), l.now()
+	}
+	l.rate = float64(limit) / float64(period)
+	l.burst = float64(limit)
+	l.refill()
+	if remaining, err := strconv.Atoi(h.Get("X-RateLimit-Remaining")); err == nil && float64(remaining) < l.tokens {
+		l.tokens = float64(remaining)
+	}
+}
+
+// lastRemaining returns the last X-RateLimit-Remaining seen, or -1 if no
+// response has announced one.
+func (l *rateLimiter) lastRemaining() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.remaining
+}
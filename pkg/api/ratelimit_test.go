@@ -0,0 +1,83 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced clock for limiter tests.
+type fakeClock struct{ t time.Time }
+
+func (f *fakeClock) now() time.Time          { return f.t }
+func (f *fakeClock) advance(d time.Duration) { f.t = f.t.Add(d) }
+
+func TestRateLimiter_Fixed(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	l := newRateLimiter()
+	l.now = clock.now
+	l.set(2)
+
+	if d := l.reserve(); d != 0 {
+		t.Errorf("first request waited %s", d)
+	}
+	if d := l.reserve(); d != 500*time.Millisecond {
+		t.Errorf("second request wait = %s, want 500ms", d)
+	}
+	clock.advance(time.Second)
+	if d := l.reserve(); d != 0 {
+		t.Errorf("request after refill waited %s", d)
+	}
+}
+
+func TestRateLimiter_AdaptiveFollowsHeaders(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	l := newRateLimiter()
+	l.now = clock.now
+
+	if d := l.reserve(); d != 0 {
+		t.Errorf("unlimited before headers, waited %s", d)
+	}
+
+	h := http.Header{}
+	h.Set("X-RateLimit-Limit", "10")
+	h.Set("X-RateLimit-Period-In-Sec", "1")
+	h.Set("X-RateLimit-Remaining", "0")
+	l.observe(h)
+	if d := l.reserve(); d != 100*time.Millisecond {
+		t.Errorf("wait with no remaining = %s, want 100ms", d)
+	}
+
+	// Fixed and disabled limiters ignore headers.
+	l.set(-1)
+	l.observe(h)
+	if d := l.reserve(); d != 0 {
+		t.Errorf("disabled limiter waited %s", d)
+	}
+}
+
+func TestSetRateLimit_ThrottlesRequests(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts.URL)
+	c.SetRateLimit(20)
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := c.Get("/v2/alerts", nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("3 requests at 20/s took %s, want >= 100ms", elapsed)
+	}
+	if atomic.LoadInt32(&calls) != 3 {
+		t.Errorf("calls = %d", calls)
+	}
+}
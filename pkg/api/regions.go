@@ -0,0 +1,43 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Region is a named OpsGenie API host.
+type Region struct {
+	Name   string `json:"name"`
+	APIURL string `json:"apiUrl"`
+}
+
+// Regions are the API hosts known by name, in the order they are listed.
+var Regions = []Region{
+	{Name: "us", APIURL: baseURLUS},
+	{Name: "eu", APIURL: baseURLEU},
+	{Name: "sandbox", APIURL: baseURLSandbox},
+}
+
+// RegionURL returns the API base URL for region: the URL of a region known
+// by name (case-insensitively), or region itself when it is an http(s) URL,
+// for hosts without a name.
+func RegionURL(region string) (string, error) {
+	for _, r := range Regions {
+		if strings.EqualFold(region, r.Name) {
+			return r.APIURL, nil
+		}
+	}
+	if strings.Contains(region, "://") {
+		u, err := url.Parse(region)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return "", fmt.Errorf("invalid API URL %q: want http(s)://host", region)
+		}
+		return strings.TrimRight(region, "/"), nil
+	}
+	names := make([]string, len(Regions))
+	for i, r := range Regions {
+		names[i] = r.Name
+	}
+	return "", fmt.Errorf("unknown region %q (use %s, or an API URL)", region, strings.Join(names, ", "))
+}
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRegionURL(t *testing.T) {
+	tests := []struct {
+		region, want, wantErr string
+	}{
+		{region: "us", want: baseURLUS},
+		{region: "EU", want: baseURLEU},
+		{region: "sandbox", want: baseURLSandbox},
+		{region: "https://opsgenie.internal.example.com/", want: "https://opsgenie.internal.example.com"},
+		{region: "http://127.0.0.1:8117", want: "http://127.0.0.1:8117"},
+		{region: "ap", wantErr: `unknown region "ap" (use us, eu, sandbox, or an API URL)`},
+		{region: "ftp://host", wantErr: "invalid API URL"},
+		{region: "https://", wantErr: "invalid API URL"},
+	}
+	for _, tt := range tests {
+		got, err := RegionURL(tt.region)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("RegionURL(%q): err = %v, want %q", tt.region, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("RegionURL(%q) = %q, %v; want %q", tt.region, got, err, tt.want)
+		}
+	}
+}
+
+func TestNewClient_SandboxAndCustomRegions(t *testing.T) {
+	t.Setenv("OPSGENIE_API_URL", "")
+
+	if c := NewClient("key", "sandbox"); c.baseURL != baseURLSandbox {
+		t.Errorf("sandbox: baseURL = %q", c.baseURL)
+	}
+	if c := NewClient("key", "https://og.example.com"); c.baseURL != "https://og.example.com" {
+		t.Errorf("custom: baseURL = %q", c.baseURL)
+	}
+}
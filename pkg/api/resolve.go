@@ -0,0 +1,151 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// listPaths are the listings ResolveID searches, by resource kind.
+var listPaths = map[string]string{
+	"team":       "/v2/teams",
+	"schedule":   "/v2/schedules",
+	"escalation": "/v2/escalations",
+	"service":    "/v1/services",
+}
+
+// uuidPattern matches the IDs OpsGenie gives teams, schedules, escalations
+// and services, which need no lookup.
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// namedResource is the part of a listed resource ResolveID matches on.
+type namedResource struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// nameCache holds the listings fetched by ResolveID, by kind. It is shared
+// by the copies WithContext makes.
+type nameCache struct {
+	sync.Mutex
+	lists map[string][]namedResource
+}
+
+// AmbiguousNameError is returned by ResolveID when more than one resource
+// of a kind has the name given.
+type AmbiguousNameError struct {
+	Kind string
+	Name string
+	IDs  []string
+}
+
+func (e *AmbiguousNameError) Error() string {
+	return fmt.Sprintf("%s name %q is ambiguous: it matches %d %ss (%s); pass the ID instead",
+		e.Kind, e.Name, len(e.IDs), e.Kind, strings.Join(e.IDs, ", "))
+}
+
+// ResolveID returns the ID of the resource of kind ("team", "schedule",
+// "escalation" or "service") that nameOrID names, so commands can take a
+// name wherever they take an ID. UUIDs and the IDs of listed resources are
+// returned unchanged. Names match exactly, or else ignoring case; several
+// matches are an *AmbiguousNameError. A value that matches nothing, or that
+// cannot be looked up, is returned unchanged for the API to judge.
+//
+// Each kind is listed at most once per client.
+func (c *Client) ResolveID(kind, nameOrID string) (string, error) {
+	return c.ResolveIDCtx(c.defaultCtx(), kind, nameOrID)
+}
+
+// ResolveIDCtx is ResolveID with an explicit context.
+func (c *Client) ResolveIDCtx(ctx context.Context, kind, nameOrID string) (string, error) {
+	if _, ok := listPaths[kind]; !ok {
+		return "", fmt.Errorf("cannot resolve %s names", kind)
+	}
+	if nameOrID == "" || uuidPattern.MatchString(nameOrID) {
+		return nameOrID, nil
+	}
+	list, err := c.namedList(ctx, kind)
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", err
+		}
+		c.logger().Debug("cannot resolve name", "kind", kind, "name", nameOrID, "err", err)
+		return nameOrID, nil
+	}
+
+	var exact, folded []namedResource
+	for _, r := range list {
+		if r.ID == nameOrID {
+			return r.ID, nil
+		}
+		if r.Name == nameOrID {
+			exact = append(exact, r)
+		} else if strings.EqualFold(r.Name, nameOrID) {
+			folded = append(folded, r)
+		}
+	}
+	matches := exact
+	if len(matches) == 0 {
+		matches = folded
+	}
+	switch len(matches) {
+	case 0:
+		return nameOrID, nil
+	case 1:
+		c.logger().Debug("resolved name", "kind", kind, "name", nameOrID, "id", matches[0].ID)
+		return matches[0].ID, nil
+	}
+	ids := make([]string, len(matches))
+	for i, m := range matches {
+		ids[i] = m.ID
+	}
+	return "", &AmbiguousNameError{Kind: kind, Name: nameOrID, IDs: ids}
+}
+
+// ResolveName is the reverse of ResolveID: it returns the name of the
+// resource of kind with the given ID, from the same listings. An ID that is
+// not listed, or that cannot be looked up, is returned unchanged.
+func (c *Client) ResolveName(kind, id string) (string, error) {
+	if _, ok := listPaths[kind]; !ok {
+		return "", fmt.Errorf("cannot resolve %s names", kind)
+	}
+	if id == "" {
+		return "", nil
+	}
+	ctx := c.defaultCtx()
+	list, err := c.namedList(ctx, kind)
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", err
+		}
+		c.logger().Debug("cannot resolve name", "kind", kind, "id", id, "err", err)
+		return id, nil
+	}
+	for _, r := range list {
+		if r.ID == id && r.Name != "" {
+			return r.Name, nil
+		}
+	}
+	return id, nil
+}
+
+// namedList returns the listing of kind, fetching it on first use.
+func (c *Client) namedList(ctx context.Context, kind string) ([]namedResource, error) {
+	c.names.Lock()
+	defer c.names.Unlock()
+	if list, ok := c.names.lists[kind]; ok {
+		return list, nil
+	}
+	var list []namedResource
+	if err := c.ListAllCtx(ctx, listPaths[kind], url.Values{}, &list); err != nil {
+		return nil, err
+	}
+	if c.names.lists == nil {
+		c.names.lists = map[string][]namedResource{}
+	}
+	c.names.lists[kind] = list
+	return list, nil
+}
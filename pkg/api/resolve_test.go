@@ -0,0 +1,143 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// teamsServer lists three teams, two of them named "Ops" in different
+// case, and counts the listings.
+func teamsServer(t *testing.T) (*httptest.Server, *int32) {
+	t.Helper()
+	var lists int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/teams" {
+			http.NotFound(w, r)
+			return
+		}
+		atomic.AddInt32(&lists, 1)
+		_, _ = w.Write([]byte(`{"data":[
+			{"id":"t-platform","name":"Platform Team"},
+			{"id":"t-ops-1","name":"Ops"},
+			{"id":"t-ops-2","name":"ops"},
+			{"id":"t-db-1","name":"DB"},
+			{"id":"t-db-2","name":"db"},
+			{"id":"t-db-3","name":"db"}
+		]}`))
+	}))
+	t.Cleanup(ts.Close)
+	return ts, &lists
+}
+
+func TestResolveID_Names(t *testing.T) {
+	ts, lists := teamsServer(t)
+	c := newTestClient(t, ts.URL)
+
+	tests := []struct {
+		in, want string
+	}{
+		{"Platform Team", "t-platform"},
+		{"platform team", "t-platform"},
+		{"Ops", "t-ops-1"},
+		{"ops", "t-ops-2"},
+		{"DB", "t-db-1"},
+		{"t-platform", "t-platform"},
+		{"unknown", "unknown"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		got, err := c.ResolveID("team", tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ResolveID(%q) = %q, %v; want %q", tt.in, got, err, tt.want)
+		}
+	}
+	if n := atomic.LoadInt32(lists); n != 1 {
+		t.Errorf("listed teams %d times, want 1", n)
+	}
+}
+
+func TestResolveID_Ambiguous(t *testing.T) {
+	ts, _ := teamsServer(t)
+	c := newTestClient(t, ts.URL)
+
+	_, err := c.ResolveID("team", "Db")
+	var amb *AmbiguousNameError
+	if !errors.As(err, &amb) {
+		t.Fatalf("err = %v, want *AmbiguousNameError", err)
+	}
+	if strings.Join(amb.IDs, ",") != "t-db-1,t-db-2,t-db-3" {
+		t.Errorf("IDs = %v", amb.IDs)
+	}
+	if !strings.Contains(err.Error(), `team name "Db" is ambiguous`) {
+		t.Errorf("message = %q", err)
+	}
+
+	// An exact match wins over matches that differ in case, but two exact
+	// matches are still ambiguous.
+	if _, err := c.ResolveID("team", "db"); !errors.As(err, &amb) || len(amb.IDs) != 2 {
+		t.Errorf("ResolveID(db) err = %v, want two exact matches", err)
+	}
+}
+
+func TestResolveID_UUIDSkipsLookup(t *testing.T) {
+	ts, lists := teamsServer(t)
+	c := newTestClient(t, ts.URL)
+
+	const id = "4513b7ea-3b91-438f-b7e4-e3e54af9147c"
+	if got, err := c.ResolveID("team", id); err != nil || got != id {
+		t.Errorf("ResolveID(uuid) = %q, %v", got, err)
+	}
+	if n := atomic.LoadInt32(lists); n != 0 {
+		t.Errorf("listed teams %d times, want 0", n)
+	}
+}
+
+func TestResolveID_ListErrorPassesThrough(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"forbidden"}`))
+	}))
+	defer ts.Close()
+	c := newTestClient(t, ts.URL)
+
+	if got, err := c.ResolveID("schedule", "Primary"); err != nil || got != "Primary" {
+		t.Errorf("ResolveID = %q, %v; want the name unchanged", got, err)
+	}
+}
+
+func TestResolveID_UnknownKind(t *testing.T) {
+	c := NewClient("test-key", "us")
+	if _, err := c.ResolveID("widget", "x"); err == nil {
+		t.Error("expected an error for an unknown kind")
+	}
+}
+
+func TestResolveName(t *testing.T) {
+	ts, lists := teamsServer(t)
+	c := newTestClient(t, ts.URL)
+
+	tests := []struct {
+		in, want string
+	}{
+		{"t-platform", "Platform Team"},
+		{"t-ops-2", "ops"},
+		{"t-unknown", "t-unknown"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		got, err := c.ResolveName("team", tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ResolveName(%q) = %q, %v; want %q", tt.in, got, err, tt.want)
+		}
+	}
+	if n := atomic.LoadInt32(lists); n != 1 {
+		t.Errorf("listed teams %d times, want 1", n)
+	}
+	if _, err := c.ResolveName("widget", "x"); err == nil {
+		t.Error("expected an error for an unknown kind")
+	}
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"sync"
+	"time"
+)
+
+// defaultConcurrency is how many Fanout tasks run at once unless
+// SetConcurrency says otherwise.
+const defaultConcurrency = 4
+
+// fanoutRetryDelay is the wait before a failed Fanout task is retried.
+var fanoutRetryDelay = time.Second
+
+// scheduler caps the Fanout tasks in flight across a client and the clients
+// derived from it. The cap shrinks as the API's X-RateLimit-Remaining runs
+// low, so a report's fan-out slows down before it is throttled.
+type scheduler struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	inflight int
+	max      int
+	limiter  *rateLimiter
+}
+
+func newScheduler(l *rateLimiter) *scheduler {
+	s := &scheduler{max: defaultConcurrency, limiter: l}
+	s.cond = sync.NewCond(&s.mu)
+	return s
+}
+
+// limit returns how many tasks may be in flight: the maximum until a
+// response announces the remaining requests, then at most half of those,
+// and never less than one.
+func (s *scheduler) limit() int {
+	r := s.limiter.lastRemaining()
+	if r < 0 {
+		return s.max
+	}
+	return max(1, min(s.max, r/2))
+}
+
+func (s *scheduler) acquire() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for s.inflight >= s.limit() {
+		s.cond.Wait()
+	}
+	s.inflight++
+}
+
+func (s *scheduler) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.inflight--
+	s.cond.Broadcast()
+}
+
+// SetConcurrency sets how many Fanout tasks may run at once (default 4; 1
+// runs them one by one). Values below 1 restore the default. The setting is
+// shared with clients derived through WithContext.
+func (c *Client) SetConcurrency(n int) {
+	if n < 1 {
+		n = defaultConcurrency
+	}
+	c.scheduler.mu.Lock()
+	defer c.scheduler.mu.Unlock()
+	c.scheduler.max = n
+	c.scheduler.cond.Broadcast()
+}
+
+// Fanout runs task(0) to task(n-1), the independent lookups of a composite
+// command, concurrently and returns their errors by index. Tasks start in
+// index order; how many run at once is capped by SetConcurrency and, once
+// the API reports it, by the remaining rate limit. A task that fails with a
+// network error, a 5xx or exhausted 429 retries is run once more; a failed
+// task does not stop the others. After the client's context is cancelled,
+// tasks not yet started fail with its error.
+//
+// Tasks may call the client but not Fanout.
+func (c *Client) Fanout(n int, task func(i int) error) []error {
+	ctx := c.defaultCtx()
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		c.scheduler.acquire()
+		if err := ctx.Err(); err != nil {
+			c.scheduler.release()
+			for ; i < n; i++ {
+				errs[i] = err
+			}
+			break
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer c.scheduler.release()
+			err := task(i)
+			if err != nil && retryable(err) && ctx.Err() == nil {
+				c.logger().Info("retrying fan-out task", "task", i, "backoff", fanoutRetryDelay, "err", err)
+				if sleep(ctx, fanoutRetryDelay) == nil {
+					err = task(i)
+				}
+			}
+			errs[i] = err
+		}(i)
+	}
+	wg.Wait()
+	return errs
+}
+
+// retryable reports whether err may go away on its own: no HTTP status
+// (network errors, timeouts, exhausted 429 retries) or a server error.
+func retryable(err error) bool {
+	code := StatusCode(err)
+	return code == 0 || code >= 500
+}
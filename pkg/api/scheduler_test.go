@@ -0,0 +1,106 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// concurrencyServer answers every request after a short delay and records
+// the most requests it handled at once. Paths ending in /fail answer 500
+// the first time and /missing always 404.
+func concurrencyServer(t *testing.T, remaining int) (*httptest.Server, *int32) {
+	t.Helper()
+	var inflight, peak int32
+	var mu sync.Mutex
+	seen := map[string]bool{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inflight, 1)
+		defer atomic.AddInt32(&inflight, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		if remaining >= 0 {
+			w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		}
+		mu.Lock()
+		first := !seen[r.URL.Path]
+		seen[r.URL.Path] = true
+		mu.Unlock()
+		switch {
+		case r.URL.Path == "/v2/fail" && first:
+			w.WriteHeader(http.StatusInternalServerError)
+		case r.URL.Path == "/v2/missing":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	t.Cleanup(ts.Close)
+	return ts, &peak
+}
+
+func TestFanout_CapsConcurrency(t *testing.T) {
+	ts, peak := concurrencyServer(t, -1)
+	c := newTestClient(t, ts.URL)
+	c.SetConcurrency(3)
+
+	errs := c.Fanout(9, func(i int) error { return c.Get("/v2/schedules/"+strconv.Itoa(i), nil) })
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("task %d: %v", i, err)
+		}
+	}
+	if p := atomic.LoadInt32(peak); p != 3 {
+		t.Errorf("peak concurrency = %d, want 3", p)
+	}
+}
+
+func TestFanout_SlowsDownWhenRemainingIsLow(t *testing.T) {
+	ts, peak := concurrencyServer(t, 1)
+	c := newTestClient(t, ts.URL)
+	c.SetRateLimit(-1)
+
+	// The first response announces one remaining request; the rest run
+	// one at a time.
+	if err := c.Get("/v2/account", nil); err != nil {
+		t.Fatal(err)
+	}
+	atomic.StoreInt32(peak, 0)
+	c.Fanout(4, func(i int) error { return c.Get("/v2/schedules/"+strconv.Itoa(i), nil) })
+	if p := atomic.LoadInt32(peak); p != 1 {
+		t.Errorf("peak concurrency = %d, want 1", p)
+	}
+}
+
+func TestFanout_RetriesTransientFailures(t *testing.T) {
+	defer func(d time.Duration) { fanoutRetryDelay = d }(fanoutRetryDelay)
+	fanoutRetryDelay = time.Millisecond
+
+	ts, _ := concurrencyServer(t, -1)
+	c := newTestClient(t, ts.URL)
+	paths := []string{"/v2/fail", "/v2/missing", "/v2/ok"}
+	var calls [3]int32
+	errs := c.Fanout(len(paths), func(i int) error {
+		atomic.AddInt32(&calls[i], 1)
+		return c.Get(paths[i], nil)
+	})
+
+	if errs[0] != nil || calls[0] != 2 {
+		t.Errorf("500 then 200: err = %v after %d call(s), want success after 2", errs[0], calls[0])
+	}
+	if StatusCode(errs[1]) != http.StatusNotFound || calls[1] != 1 {
+		t.Errorf("404: err = %v after %d call(s), want 404 without retry", errs[1], calls[1])
+	}
+	if errs[2] != nil {
+		t.Errorf("200: %v", errs[2])
+	}
+}
@@ -0,0 +1,172 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// redacted replaces secrets in debug transcripts.
+const redacted = "[REDACTED]"
+
+// secretNames are the fragments of header, field and query parameter names
+// whose values are redacted from transcripts, matched against the name in
+// lower case without "_" and "-".
+var secretNames = []string{"authorization", "apikey", "password", "secret", "token", "privatekey", "accesskey", "credential", "signature", "cookie"}
+
+// SetDebugFile appends a transcript of every request and response to the
+// file at path: method, URL, headers and bodies, with the Authorization
+// header and known secret fields redacted so the file can be attached to a
+// bug report. An empty path turns it off.
+func (c *Client) SetDebugFile(path string) {
+	c.debugFile = path
+}
+
+// isSecret reports whether a header, field or parameter named name holds
+// a secret.
+func isSecret(name string) bool {
+	n := strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(name))
+	if n == "sig" {
+		return true
+	}
+	for _, s := range secretNames {
+		if strings.Contains(n, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactURL returns rawURL with the values of secret query parameters
+// redacted.
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.RawQuery == "" {
+		return rawURL
+	}
+	q := u.Query()
+	changed := false
+	for k := range q {
+		if isSecret(k) {
+			q.Set(k, redacted)
+			changed = true
+		}
+	}
+	if !changed {
+		return rawURL
+	}
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// redactBody returns body with the values of secret fields redacted when
+// it is JSON, and with secret query parameters redacted when it is a URL
+// (such as a pre-signed download link). Anything else is returned as is.
+func redactBody(body []byte) []byte {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return nil
+	}
+	if trimmed[0] != '{' && trimmed[0] != '[' {
+		if s := string(trimmed); strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://") {
+			return []byte(redactURL(s))
+		}
+		return body
+	}
+	dec := json.NewDecoder(bytes.NewReader(trimmed))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return body
+	}
+	b, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return body
+	}
+	return b
+}
+
+func redactValue(v interface{}) interface{} {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		for k, val := range x {
+			if isSecret(k) {
+				if _, nested := val.(map[string]interface{}); !nested {
+					x[k] = redacted
+					continue
+				}
+			}
+			x[k] = redactValue(val)
+		}
+	case []interface{}:
+		for i := range x {
+			x[i] = redactValue(x[i])
+		}
+	}
+	return v
+}
+
+// writeHeaders writes h to b in sorted order, one line per value, with
+// each line prefixed and secret values redacted.
+func writeHeaders(b *bytes.Buffer, prefix string, h http.Header) {
+	names := make([]string, 0, len(h))
+	for k := range h {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, k := range names {
+		for _, v := range h[k] {
+			if isSecret(k) {
+				v = redacted
+			}
+			fmt.Fprintf(b, "%s %s: %s\n", prefix, k, v)
+		}
+	}
+}
+
+// writeTranscript appends one request and its response (or the error that
+// took its place) to the debug file. reqBody is nil for bodiless and
+// multipart requests; multipart file contents are never written. Each
+// exchange is a single write to a file opened for appending, so
+// concurrent requests do not interleave.
+func (c *Client) writeTranscript(req *http.Request, reqBody []byte, resp *http.Response, respBody []byte, took time.Duration, err error) {
+	if c.debugFile == "" {
+		return
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "=== %s %s %s\n", time.Now().UTC().Format(time.RFC3339Nano), req.Method, redactURL(req.URL.String()))
+	writeHeaders(&b, ">", req.Header)
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "multipart/") {
+		fmt.Fprintf(&b, ">\n> [multipart form, %d bytes]\n", req.ContentLength)
+	} else if body := redactBody(reqBody); len(body) > 0 {
+		fmt.Fprintf(&b, ">\n> %s\n", body)
+	}
+	switch {
+	case err != nil:
+		fmt.Fprintf(&b, "< error after %s: %v\n", took.Round(time.Millisecond), err)
+	default:
+		fmt.Fprintf(&b, "< %s (%s)\n", resp.Status, took.Round(time.Millisecond))
+		writeHeaders(&b, "<", resp.Header)
+		if body := redactBody(respBody); len(body) > 0 {
+			fmt.Fprintf(&b, "<\n< %s\n", body)
+		}
+	}
+	b.WriteString("\n")
+
+	f, ferr := os.OpenFile(c.debugFile, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if ferr == nil {
+		_, ferr = f.Write(b.Bytes())
+		if cerr := f.Close(); ferr == nil {
+			ferr = cerr
+		}
+	}
+	if ferr != nil {
+		c.logger().Warn("cannot write debug file", "path", c.debugFile, "err", ferr)
+	}
+}
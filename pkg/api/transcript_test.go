@@ -0,0 +1,51 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDebugFile_RedactsSecrets(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "session=s3cr3t")
+		_, _ = w.Write([]byte(`{"data":{"id":"i1","name":"Datadog","apiKey":"integration-key","owner":{"password":"pw","name":"alice"}}}`))
+	}))
+	defer ts.Close()
+	c := newTestClient(t, ts.URL)
+	path := filepath.Join(t.TempDir(), "debug.log")
+	c.SetDebugFile(path)
+
+	body := map[string]interface{}{"name": "Datadog", "token": "tok-123", "count": 12345678901234567}
+	if err := c.Post("/v2/integrations?apiKey=query-key&limit=5", body, nil); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(b)
+	for _, secret := range []string{"test-key", "integration-key", "tok-123", "query-key", "s3cr3t", `"pw"`} {
+		if strings.Contains(got, secret) {
+			t.Errorf("transcript leaks %q:\n%s", secret, got)
+		}
+	}
+	for _, want := range []string{"POST " + ts.URL + "/v2/integrations?apiKey=%5BREDACTED%5D&limit=5", "> Authorization: [REDACTED]", `"count":12345678901234567`, "< 200 OK", `"name":"alice"`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("transcript lacks %q:\n%s", want, got)
+		}
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("debug file mode = %v, %v; want 0600", info.Mode().Perm(), err)
+	}
+}
+
+func TestRedactBody_SignedURL(t *testing.T) {
+	got := string(redactBody([]byte("https://logs.example.com/a.json?X-Amz-Signature=abc&sig=def&x=1\n")))
+	if strings.Contains(got, "abc") || strings.Contains(got, "def") || !strings.Contains(got, "x=1") {
+		t.Errorf("redactBody = %q", got)
+	}
+}
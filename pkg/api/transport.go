@@ -0,0 +1,64 @@
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// TransportOptions configures how the client reaches the API, for networks
+// that route egress through a proxy or intercept TLS.
+type TransportOptions struct {
+	// Proxy is the URL of the proxy to send requests through, overriding
+	// HTTPS_PROXY, HTTP_PROXY and NO_PROXY. "none" connects directly even
+	// when those are set; empty follows them.
+	Proxy string
+	// CACert is a PEM file of certificates to trust in addition to the
+	// system roots, such as a TLS-intercepting proxy's CA.
+	CACert string
+	// InsecureSkipVerify accepts any server certificate. It defeats TLS
+	// and is meant for diagnosing certificate problems only.
+	InsecureSkipVerify bool
+}
+
+// SetTransportOptions rebuilds the client's HTTP transport from o.
+func (c *Client) SetTransportOptions(o TransportOptions) error {
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	switch {
+	case strings.EqualFold(o.Proxy, "none"):
+		tr.Proxy = nil
+	case o.Proxy != "":
+		u, err := url.Parse(o.Proxy)
+		if err != nil || u.Host == "" {
+			return fmt.Errorf("invalid proxy URL %q", o.Proxy)
+		}
+		tr.Proxy = http.ProxyURL(u)
+	}
+
+	if o.CACert != "" || o.InsecureSkipVerify {
+		tr.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12, InsecureSkipVerify: o.InsecureSkipVerify}
+	}
+	if o.CACert != "" {
+		pem, err := os.ReadFile(o.CACert)
+		if err != nil {
+			return fmt.Errorf("read CA certificate: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no PEM certificates in %s", o.CACert)
+		}
+		tr.TLSClientConfig.RootCAs = pool
+	}
+
+	hc := *c.httpClient
+	hc.Transport = tr
+	c.httpClient = &hc
+	return nil
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSetTransportOptions_TLS(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"name":"acme"}}`))
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts.URL)
+	if err := c.SetTransportOptions(TransportOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Get("/v2/account", nil); err == nil || !strings.Contains(err.Error(), "certificate") {
+		t.Errorf("untrusted certificate: got %v", err)
+	}
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+	if err := os.WriteFile(caFile, caPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetTransportOptions(TransportOptions{CACert: caFile}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Get("/v2/account", nil); err != nil {
+		t.Errorf("with --ca-cert: %v", err)
+	}
+
+	c = newTestClient(t, ts.URL)
+	if err := c.SetTransportOptions(TransportOptions{InsecureSkipVerify: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Get("/v2/account", nil); err != nil {
+		t.Errorf("with --insecure-skip-verify: %v", err)
+	}
+
+	if err := c.SetTransportOptions(TransportOptions{CACert: filepath.Join(t.TempDir(), "missing.pem")}); err == nil {
+		t.Error("missing CA file: want error")
+	}
+	if err := c.SetTransportOptions(TransportOptions{CACert: os.Args[0]}); err == nil || !strings.Contains(err.Error(), "no PEM certificates") {
+		t.Errorf("non-PEM CA file: got %v", err)
+	}
+}
+
+func TestSetTransportOptions_Proxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		_, _ = w.Write([]byte(`{"data":{}}`))
+	}))
+	defer proxy.Close()
+
+	t.Setenv("HTTP_PROXY", "http://127.0.0.1:1")
+	c := newTestClient(t, "http://api.example.invalid")
+	if err := c.SetTransportOptions(TransportOptions{Proxy: proxy.URL}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Get("/v2/account", nil); err != nil {
+		t.Fatalf("through --proxy: %v", err)
+	}
+	if proxied != "http://api.example.invalid/v2/account" {
+		t.Errorf("proxy saw %q", proxied)
+	}
+
+	if err := c.SetTransportOptions(TransportOptions{Proxy: "::bad"}); err == nil {
+		t.Error("invalid proxy URL: want error")
+	}
+}
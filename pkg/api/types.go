@@ -1,6 +1,11 @@
 package api
 
-import "fmt"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
 
 // APIResponse is the generic OpsGenie API response wrapper.
 type APIResponse[T any] struct {
@@ -21,9 +26,12 @@ type Paging struct {
 
 // ErrorResponse represents an OpsGenie API error.
 type ErrorResponse struct {
-	Message string   `json:"message"`
-	Code    int      `json:"code"`
-	Errors  []string `json:"errors,omitempty"`
+	Message   string   `json:"message"`
+	Code      int      `json:"code"`
+	Errors    []string `json:"errors,omitempty"`
+	RequestID string   `json:"requestId,omitempty"`
+	// RetryAfter is the wait asked for by the Retry-After header, if any.
+	RetryAfter time.Duration `json:"-"`
 }
 
 func (e *ErrorResponse) Error() string {
@@ -33,12 +41,70 @@ func (e *ErrorResponse) Error() string {
 	return fmt.Sprintf("OpsGenie API error %d: %s", e.Code, e.Message)
 }
 
+// StatusError is an API error whose body is not an OpsGenie error message.
+type StatusError struct {
+	Code       int
+	Body       string
+	RetryAfter time.Duration
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("API error (status %d): %s", e.Code, e.Body)
+}
+
+// StatusCode returns the HTTP status of an API error, or 0 if err is not
+// one.
+func StatusCode(err error) int {
+	var er *ErrorResponse
+	if errors.As(err, &er) {
+		return er.Code
+	}
+	var se *StatusError
+	if errors.As(err, &se) {
+		return se.Code
+	}
+	return 0
+}
+
+// RetryAfter returns the wait an API error's Retry-After header asked
+// for, or 0 if err is not an API error or asked for none.
+func RetryAfter(err error) time.Duration {
+	var er *ErrorResponse
+	if errors.As(err, &er) {
+		return er.RetryAfter
+	}
+	var se *StatusError
+	if errors.As(err, &se) {
+		return se.RetryAfter
+	}
+	return 0
+}
+
+// AsyncError is returned when an async (202 Accepted) request fails, or is
+// still pending when the wait for it times out.
+type AsyncError struct {
+	RequestID string
+	// Status is "failed" or "cancelled", or empty after a timeout.
+	Status  string
+	Timeout time.Duration
+}
+
+func (e *AsyncError) Error() string {
+	if e.Status == "" {
+		return fmt.Sprintf("timed out waiting for async request %s after %s", e.RequestID, e.Timeout)
+	}
+	return fmt.Sprintf("async request %s %s", e.RequestID, e.Status)
+}
+
 // RequestResult is the response from polling an async request.
 type RequestResult struct {
-	IsSuccess bool   `json:"isSuccess"`
-	Status    string `json:"status"`
-	AlertID   string `json:"alertId,omitempty"`
-	Alias     string `json:"alias,omitempty"`
+	IsSuccess     bool   `json:"isSuccess"`
+	Status        string `json:"status"`
+	Action        string `json:"action,omitempty"`
+	ProcessedAt   string `json:"processedAt,omitempty"`
+	IntegrationID string `json:"integrationId,omitempty"`
+	AlertID       string `json:"alertId,omitempty"`
+	Alias         string `json:"alias,omitempty"`
 }
 
 // AlertResponse represents a single alert.
@@ -47,6 +113,7 @@ type AlertResponse struct {
 	TinyID      string            `json:"tinyId,omitempty"`
 	Alias       string            `json:"alias,omitempty"`
 	Message     string            `json:"message"`
+	Description string            `json:"description,omitempty"`
 	Status      string            `json:"status"`
 	Acknowledged bool             `json:"acknowledged"`
 	Snoozed     bool              `json:"snoozed,omitempty"`
@@ -54,35 +121,111 @@ type AlertResponse struct {
 	Tags        []string          `json:"tags,omitempty"`
 	Count       int               `json:"count,omitempty"`
 	Source      string            `json:"source,omitempty"`
+	Entity      string            `json:"entity,omitempty"`
 	Owner       string            `json:"owner,omitempty"`
 	Priority    string            `json:"priority,omitempty"`
 	Responders  []Responder       `json:"responders,omitempty"`
+	Teams       []TeamRef         `json:"teams,omitempty"`
+	OwnerTeamID string            `json:"ownerTeamId,omitempty"`
 	Details     map[string]string `json:"details,omitempty"`
+	Actions     []string          `json:"actions,omitempty"` // custom actions available on the alert
 	CreatedAt   string            `json:"createdAt,omitempty"`
 	UpdatedAt   string            `json:"updatedAt,omitempty"`
 	ClosedAt    string            `json:"closedAt,omitempty"`
+	Report      *AlertReport      `json:"report,omitempty"`
+}
+
+// AlertNote is a note added to an alert.
+type AlertNote struct {
+	Note      string `json:"note"`
+	Owner     string `json:"owner,omitempty"`
+	CreatedAt string `json:"createdAt"`
+	Offset    string `json:"offset,omitempty"`
+}
+
+// AlertLog is an entry of an alert's activity log.
+type AlertLog struct {
+	Log       string `json:"log"`
+	Type      string `json:"type,omitempty"`
+	Owner     string `json:"owner,omitempty"`
+	CreatedAt string `json:"createdAt"`
+	Offset    string `json:"offset,omitempty"`
+}
+
+// AlertRecipient is a user notified about an alert, with the state of the
+// notification.
+type AlertRecipient struct {
+	User      UserRef `json:"user"`
+	State     string  `json:"state,omitempty"`
+	Method    string  `json:"method,omitempty"`
+	CreatedAt string  `json:"createdAt,omitempty"`
+	UpdatedAt string  `json:"updatedAt,omitempty"`
+}
+
+// AlertAttachment is a file attached to an alert. URL is only set when a
+// single attachment is fetched, and expires after a short time.
+type AlertAttachment struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+// AlertReport holds response-time statistics for an alert. AckTime and
+// CloseTime are milliseconds elapsed since the alert was created.
+type AlertReport struct {
+	AckTime        int64  `json:"ackTime,omitempty"`
+	CloseTime      int64  `json:"closeTime,omitempty"`
+	AcknowledgedBy string `json:"acknowledgedBy,omitempty"`
+	ClosedBy       string `json:"closedBy,omitempty"`
 }
 
 // Responder is a team or user assigned to an alert or incident.
 type Responder struct {
-	ID   string `json:"id,omitempty"`
-	Name string `json:"name,omitempty"`
-	Type string `json:"type"` // "team" or "user"
+	ID       string `json:"id,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Username string `json:"username,omitempty"`
+	Type     string `json:"type"` // "team" or "user"
 }
 
 // IncidentResponse represents a single incident.
 type IncidentResponse struct {
-	ID          string      `json:"id"`
-	TinyID      string      `json:"tinyId,omitempty"`
-	Message     string      `json:"message"`
-	Status      string      `json:"status"`
-	Tags        []string    `json:"tags,omitempty"`
-	Owner       string      `json:"owner,omitempty"`
-	Priority    string      `json:"priority,omitempty"`
-	Responders  []Responder `json:"responders,omitempty"`
-	Description string      `json:"description,omitempty"`
-	CreatedAt   string      `json:"createdAt,omitempty"`
-	UpdatedAt   string      `json:"updatedAt,omitempty"`
+	ID               string        `json:"id"`
+	TinyID           string        `json:"tinyId,omitempty"`
+	Message          string        `json:"message"`
+	Status           string        `json:"status"`
+	Tags             []string      `json:"tags,omitempty"`
+	Owner            string        `json:"owner,omitempty"`
+	Priority         string        `json:"priority,omitempty"`
+	Responders       []Responder   `json:"responders,omitempty"`
+	Description      string        `json:"description,omitempty"`
+	ImpactedServices []string      `json:"impactedServices,omitempty"` // service IDs
+	Actions          []string      `json:"actions,omitempty"`          // custom actions available on the incident
+	Links            IncidentLinks `json:"links,omitempty"`
+	CreatedAt        string        `json:"createdAt,omitempty"`
+	UpdatedAt        string        `json:"updatedAt,omitempty"`
+}
+
+// IncidentLinks contains hypermedia links for an incident.
+type IncidentLinks struct {
+	Web string `json:"web,omitempty"`
+	API string `json:"api,omitempty"`
+}
+
+// IncidentTimelineEntry is one entry of an incident's timeline.
+type IncidentTimelineEntry struct {
+	ID        string `json:"id,omitempty"`
+	Group     string `json:"group,omitempty"`
+	Type      string `json:"type,omitempty"`
+	EventTime string `json:"eventTime,omitempty"`
+	Hidden    bool   `json:"hidden,omitempty"`
+	Actor     struct {
+		Name string `json:"name,omitempty"`
+		Type string `json:"type,omitempty"`
+	} `json:"actor,omitempty"`
+	Description struct {
+		Name    string `json:"name,omitempty"`
+		Content string `json:"content,omitempty"`
+	} `json:"description,omitempty"`
 }
 
 // TeamResponse represents a single team.
@@ -94,6 +237,20 @@ type TeamResponse struct {
 	Links       TeamLinks    `json:"links,omitempty"`
 }
 
+// TeamLogs is one page of a team's audit log. Offset is passed back to
+// fetch the next page; it is empty on the last page.
+type TeamLogs struct {
+	Offset string    `json:"offset,omitempty"`
+	Logs   []TeamLog `json:"logs"`
+}
+
+// TeamLog is an entry in a team's audit log.
+type TeamLog struct {
+	Log       string `json:"log"`
+	Owner     string `json:"owner,omitempty"`
+	CreatedAt string `json:"createdAt"`
+}
+
 // TeamMember is a member of a team.
 type TeamMember struct {
 	User UserRef `json:"user"`
@@ -134,9 +291,25 @@ type UserResponse struct {
 	Username  string   `json:"username"`
 	FullName  string   `json:"fullName,omitempty"`
 	Role      UserRole `json:"role,omitempty"`
+	TimeZone  string   `json:"timeZone,omitempty"`
+	Locale    string   `json:"locale,omitempty"`
 	Blocked   bool     `json:"blocked,omitempty"`
 	Verified  bool     `json:"verified,omitempty"`
 	CreatedAt string   `json:"createdAt,omitempty"`
+
+	SkypeUsername string              `json:"skypeUsername,omitempty"`
+	UserAddress   *UserAddress        `json:"userAddress,omitempty"`
+	Tags          []string            `json:"tags,omitempty"`
+	Details       map[string][]string `json:"details,omitempty"`
+}
+
+// UserAddress is a user's postal address.
+type UserAddress struct {
+	Country string `json:"country,omitempty"`
+	State   string `json:"state,omitempty"`
+	City    string `json:"city,omitempty"`
+	Line    string `json:"line,omitempty"`
+	ZipCode string `json:"zipCode,omitempty"`
 }
 
 // UserRole is the role assigned to a user.
@@ -184,10 +357,14 @@ type DelayInfo struct {
 
 // IntegrationResponse represents a single integration.
 type IntegrationResponse struct {
-	ID      string `json:"id"`
-	Name    string `json:"name"`
-	Type    string `json:"type,omitempty"`
-	Enabled bool   `json:"enabled"`
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Type      string   `json:"type,omitempty"`
+	Enabled   bool     `json:"enabled"`
+	TeamID    string   `json:"teamId,omitempty"`
+	OwnerTeam *TeamRef `json:"ownerTeam,omitempty"`
+	CreatedAt string   `json:"createdAt,omitempty"`
+	APIKey    string   `json:"apiKey,omitempty"`
 }
 
 // AccountResponse represents the OpsGenie account info.
@@ -212,11 +389,91 @@ type AlertCountResponse struct {
 
 // TeamRoutingRuleResponse represents a single team routing rule.
 type TeamRoutingRuleResponse struct {
-	ID     string      `json:"id"`
-	Name   string      `json:"name,omitempty"`
-	Order  int         `json:"order,omitempty"`
-	Type   string      `json:"type,omitempty"`
-	Notify interface{} `json:"notify,omitempty"`
+	ID              string           `json:"id"`
+	Name            string           `json:"name,omitempty"`
+	IsDefault       bool             `json:"isDefault,omitempty"`
+	Order           int              `json:"order,omitempty"`
+	Type            string           `json:"type,omitempty"`
+	Timezone        string           `json:"timezone,omitempty"`
+	Criteria        *Filter          `json:"criteria,omitempty"`
+	TimeRestriction *TimeRestriction `json:"timeRestriction,omitempty"`
+	Notify          interface{}      `json:"notify,omitempty"`
+}
+
+// Filter selects alerts in routing rules and policies. Type is
+// "match-all", "match-any-condition" or "match-all-conditions".
+type Filter struct {
+	Type       string      `json:"type"`
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// Condition is one test of a Filter. Key names the detail for the
+// "details" and "extra-properties" fields.
+type Condition struct {
+	Field         string `json:"field"`
+	Key           string `json:"key,omitempty"`
+	Not           bool   `json:"not,omitempty"`
+	Operation     string `json:"operation"`
+	ExpectedValue string `json:"expectedValue,omitempty"`
+	Order         int    `json:"order,omitempty"`
+}
+
+// TimeRestriction limits when a rule applies. Type "time-of-day" uses
+// Restriction; "weekday-and-time-of-day" uses Restrictions.
+type TimeRestriction struct {
+	Type         string      `json:"type"`
+	Restriction  *TimeRange  `json:"restriction,omitempty"`
+	Restrictions []TimeRange `json:"restrictions,omitempty"`
+}
+
+// TimeRange is a window of a TimeRestriction. The day fields are only set
+// for weekday ranges.
+type TimeRange struct {
+	StartDay  string `json:"startDay,omitempty"`
+	StartHour int    `json:"startHour"`
+	StartMin  int    `json:"startMin"`
+	EndDay    string `json:"endDay,omitempty"`
+	EndHour   int    `json:"endHour"`
+	EndMin    int    `json:"endMin"`
+}
+
+// NotificationRuleResponse is one of a user's notification rules, as
+// returned when getting it by ID. Schedules limit schedule-start and
+// schedule-end rules to those schedules.
+type NotificationRuleResponse struct {
+	ID              string              `json:"id"`
+	Name            string              `json:"name,omitempty"`
+	ActionType      string              `json:"actionType,omitempty"`
+	Order           int                 `json:"order,omitempty"`
+	Enabled         bool                `json:"enabled"`
+	Criteria        *Filter             `json:"criteria,omitempty"`
+	TimeRestriction *TimeRestriction    `json:"timeRestriction,omitempty"`
+	Schedules       []TeamRef           `json:"schedules,omitempty"`
+	Steps           []NotificationStep  `json:"steps,omitempty"`
+	Repeat          *NotificationRepeat `json:"repeat,omitempty"`
+}
+
+// NotificationStep is one contact a notification rule sends to, SendAfter
+// the rule fires.
+type NotificationStep struct {
+	ID        string              `json:"id,omitempty"`
+	Contact   NotificationContact `json:"contact"`
+	SendAfter DelayInfo           `json:"sendAfter,omitempty"`
+	Enabled   bool                `json:"enabled"`
+}
+
+// NotificationContact is a contact method and address, e.g. sms and a
+// phone number.
+type NotificationContact struct {
+	Method string `json:"method"`
+	To     string `json:"to,omitempty"`
+}
+
+// NotificationRepeat makes a rule send its steps again every LoopAfter
+// minutes until the alert is acknowledged.
+type NotificationRepeat struct {
+	LoopAfter int  `json:"loopAfter,omitempty"`
+	Enabled   bool `json:"enabled"`
 }
 
 // ScheduleRotationResponse represents a single schedule rotation.
@@ -248,8 +505,79 @@ type OnCallParticipant struct {
 	OnCallEnd   string `json:"onCallEnd,omitempty"`
 }
 
+// OnCallParticipants is a list of on-call participants. It decodes both the
+// nested form (participant objects) and the flat form (plain usernames) that
+// the on-call endpoints return depending on the flat parameter.
+type OnCallParticipants []OnCallParticipant
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *OnCallParticipants) UnmarshalJSON(data []byte) error {
+	var names []string
+	if err := json.Unmarshal(data, &names); err == nil {
+		out := make(OnCallParticipants, len(names))
+		for i, n := range names {
+			out[i] = OnCallParticipant{Name: n, Type: "user"}
+		}
+		*p = out
+		return nil
+	}
+	var objs []OnCallParticipant
+	if err := json.Unmarshal(data, &objs); err != nil {
+		return err
+	}
+	*p = objs
+	return nil
+}
+
 // OnCallResponse is the response from the on-call schedule endpoints.
 type OnCallResponse struct {
-	ScheduleRef        TeamRef             `json:"_parent,omitempty"`
-	OnCallParticipants []OnCallParticipant `json:"onCallParticipants,omitempty"`
+	ScheduleRef               TeamRef            `json:"_parent,omitempty"`
+	OnCallParticipants        OnCallParticipants `json:"onCallParticipants,omitempty"`
+	OnCallRecipients          OnCallParticipants `json:"onCallRecipients,omitempty"`
+	NextOnCallRecipients      OnCallParticipants `json:"nextOnCallRecipients,omitempty"`
+	ExactNextOnCallRecipients OnCallParticipants `json:"exactNextOnCallRecipients,omitempty"`
+}
+
+// Participants returns whichever participant list the endpoint populated.
+func (r OnCallResponse) Participants() []OnCallParticipant {
+	for _, list := range []OnCallParticipants{
+		r.OnCallParticipants,
+		r.OnCallRecipients,
+		r.ExactNextOnCallRecipients,
+		r.NextOnCallRecipients,
+	} {
+		if len(list) > 0 {
+			return list
+		}
+	}
+	return nil
+}
+
+// ScheduleTimeline is the response from the schedule timeline endpoint.
+type ScheduleTimeline struct {
+	ScheduleRef   TeamRef       `json:"_parent,omitempty"`
+	StartDate     string        `json:"startDate,omitempty"`
+	EndDate       string        `json:"endDate,omitempty"`
+	FinalTimeline TimelineLayer `json:"finalTimeline,omitempty"`
+}
+
+// TimelineLayer is one layer (base, override or final) of a schedule timeline.
+type TimelineLayer struct {
+	Rotations []TimelineRotation `json:"rotations,omitempty"`
+}
+
+// TimelineRotation is a rotation and its on-call periods within a timeline.
+type TimelineRotation struct {
+	ID      string           `json:"id,omitempty"`
+	Name    string           `json:"name,omitempty"`
+	Order   float64          `json:"order,omitempty"`
+	Periods []TimelinePeriod `json:"periods,omitempty"`
+}
+
+// TimelinePeriod is a single on-call shift within a timeline rotation.
+type TimelinePeriod struct {
+	StartDate string    `json:"startDate,omitempty"`
+	EndDate   string    `json:"endDate,omitempty"`
+	Type      string    `json:"type,omitempty"`
+	Recipient Responder `json:"recipient,omitempty"`
 }
@@ -0,0 +1,167 @@
+// Package audit keeps a local log of the API requests the CLI makes, so
+// request volume can be attributed to the commands, teams and scheduled
+// jobs that share an account's rate limit.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Entry is one API request in the log. Retries and async polls are
+// entries of their own, since they count against the rate limit too.
+type Entry struct {
+	Time    time.Time `json:"time"`
+	Command string    `json:"command"`        // e.g. "alerts list"
+	Team    string    `json:"team,omitempty"` // label from the environment
+	Job     string    `json:"job,omitempty"`  // label from the environment
+	Method  string    `json:"method"`
+	Path    string    `json:"path"` // without the query string
+	Status  int       `json:"status"`
+}
+
+// Append adds e to the log at path as one JSON line, creating the file if
+// needed. Each entry is a single write to a file opened for appending, so
+// concurrent processes can share a log.
+func Append(path string, e Entry) error {
+	if i := strings.IndexByte(e.Path, '?'); i >= 0 {
+		e.Path = e.Path[:i]
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Read returns the entries logged at or after since, in file order.
+// Lines that cannot be parsed, such as one cut short by a crash, are
+// skipped.
+func Read(path string, since time.Time) ([]Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	var out []Entry
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for sc.Scan() {
+		var e Entry
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil || e.Time.IsZero() {
+			continue
+		}
+		if !e.Time.Before(since) {
+			out = append(out, e)
+		}
+	}
+	return out, sc.Err()
+}
+
+// Groupings accepted by Summarize.
+var Groupings = []string{"command", "team", "job", "path"}
+
+// Unlabelled is the key of requests made without a team or job label.
+const Unlabelled = "(unlabelled)"
+
+// Usage is the request volume of one command, team, job or path.
+type Usage struct {
+	Key       string  `json:"key"`
+	Requests  int     `json:"requests"`
+	Share     float64 `json:"share"`     // fraction of all requests
+	Throttled int     `json:"throttled"` // requests answered with 429
+	// PeakPerMinute is the most requests seen in one clock minute, to
+	// compare with the account's per-minute limit.
+	PeakPerMinute int      `json:"peakPerMinute"`
+	Buckets       []Bucket `json:"buckets"`
+}
+
+// Bucket is the number of requests in one interval.
+type Bucket struct {
+	Start    time.Time `json:"start"`
+	Requests int       `json:"requests"`
+}
+
+// Summarize groups entries by command, team, job or path and counts them
+// per bucket (e.g. 24h for daily volume). Groups are sorted by volume,
+// largest first.
+func Summarize(entries []Entry, by string, bucket time.Duration) ([]Usage, error) {
+	key := map[string]func(Entry) string{
+		"command": func(e Entry) string { return e.Command },
+		"team":    func(e Entry) string { return e.Team },
+		"job":     func(e Entry) string { return e.Job },
+		"path":    func(e Entry) string { return e.Method + " " + e.Path },
+	}[by]
+	if key == nil {
+		return nil, fmt.Errorf("unknown grouping %q (valid: %s)", by, strings.Join(Groupings, ", "))
+	}
+	if bucket <= 0 {
+		bucket = 24 * time.Hour
+	}
+
+	type group struct {
+		usage   Usage
+		minutes map[int64]int
+		buckets map[int64]int
+	}
+	groups := map[string]*group{}
+	for _, e := range entries {
+		k := key(e)
+		if k == "" {
+			k = Unlabelled
+		}
+		g, ok := groups[k]
+		if !ok {
+			g = &group{usage: Usage{Key: k}, minutes: map[int64]int{}, buckets: map[int64]int{}}
+			groups[k] = g
+		}
+		g.usage.Requests++
+		if e.Status == 429 {
+			g.usage.Throttled++
+		}
+		t := e.Time.UTC()
+		g.minutes[t.Truncate(time.Minute).Unix()]++
+		g.buckets[t.Truncate(bucket).Unix()]++
+	}
+
+	out := make([]Usage, 0, len(groups))
+	for _, g := range groups {
+		u := g.usage
+		u.Share = float64(u.Requests) / float64(len(entries))
+		for _, n := range g.minutes {
+			if n > u.PeakPerMinute {
+				u.PeakPerMinute = n
+			}
+		}
+		starts := make([]int64, 0, len(g.buckets))
+		for s := range g.buckets {
+			starts = append(starts, s)
+		}
+		sort.Slice(starts, func(i, j int) bool { return starts[i] < starts[j] })
+		for _, s := range starts {
+			u.Buckets = append(u.Buckets, Bucket{Start: time.Unix(s, 0).UTC(), Requests: g.buckets[s]})
+		}
+		out = append(out, u)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Requests != out[j].Requests {
+			return out[i].Requests > out[j].Requests
+		}
+		return out[i].Key < out[j].Key
+	})
+	return out, nil
+}
@@ -0,0 +1,62 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAppendAndRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	base := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	for i, cmd := range []string{"alerts list", "alerts list", "teams list"} {
+		e := Entry{Time: base.Add(time.Duration(i) * time.Hour), Command: cmd, Method: "GET", Path: "/v2/alerts?query=status%3Aopen", Status: 200}
+		if err := Append(path, e); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+	f, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	_, _ = f.WriteString("{\"time\": \"2024-01-15T1") // truncated line
+	_ = f.Close()
+
+	got, err := Read(path, base.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d entries, want 2 at or after since", len(got))
+	}
+	if got[0].Path != "/v2/alerts" {
+		t.Errorf("path = %q, query should be dropped", got[0].Path)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	base := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	entries := []Entry{
+		{Time: base, Job: "sync", Status: 200},
+		{Time: base.Add(10 * time.Second), Job: "sync", Status: 429},
+		{Time: base.Add(20 * time.Second), Job: "sync", Status: 200},
+		{Time: base.Add(26 * time.Hour), Job: "sync", Status: 200},
+		{Time: base, Status: 200},
+	}
+	usage, err := Summarize(entries, "job", 24*time.Hour)
+	if err != nil {
+		t.Fatalf("Summarize: %v", err)
+	}
+	if len(usage) != 2 || usage[0].Key != "sync" || usage[1].Key != Unlabelled {
+		t.Fatalf("usage = %+v", usage)
+	}
+	sync := usage[0]
+	if sync.Requests != 4 || sync.Throttled != 1 || sync.PeakPerMinute != 3 || sync.Share != 0.8 {
+		t.Errorf("sync = %+v", sync)
+	}
+	if len(sync.Buckets) != 2 || sync.Buckets[0].Requests != 3 {
+		t.Errorf("buckets = %+v", sync.Buckets)
+	}
+
+	if _, err := Summarize(entries, "user", 0); err == nil {
+		t.Error("expected error for unknown grouping")
+	}
+}
@@ -2,14 +2,96 @@ package auth
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 )
 
 // AuthConfig holds the authentication configuration.
 type AuthConfig struct {
 	APIKey string `json:"api_key"`
+	// Region is the account's region name (us, eu, sandbox); APIURL,
+	// when set, is its API base URL instead, for hosts without a name.
+	Region string `json:"region,omitempty"`
+	APIURL string `json:"api_url,omitempty"`
+	// DefaultResponders are added to every alert created with "alerts
+	// create", as "type:name" strings like its --responders flag takes.
+	DefaultResponders []string `json:"default_responders,omitempty"`
+	// Timezone is the IANA zone (e.g. "Europe/Berlin") in which time flags
+	// without an explicit offset, like "tomorrow 9am", are read.
+	Timezone string `json:"timezone,omitempty"`
+	// User is the username (email) commands act for when they need a
+	// user, since API keys are not tied to one.
+	User string `json:"user,omitempty"`
+	// Colors overrides the colors of table cells, by "column.value" (e.g.
+	// "priority.P1") to a color like "red+bold", or "none".
+	Colors map[string]string `json:"colors,omitempty"`
+	// Profiles are further accounts, by name, for --profile and
+	// "foreach-profile".
+	Profiles map[string]Profile `json:"profiles,omitempty"`
+}
+
+// Profile is the API key and region of one of several OpsGenie accounts.
+type Profile struct {
+	APIKey string `json:"api_key"`
+	Region string `json:"region,omitempty"`
+	APIURL string `json:"api_url,omitempty"`
+}
+
+// profile is the profile selected with SetProfile.
+var profile string
+
+// SetProfile selects the named profile of the config file for GetAPIKey.
+// An empty name leaves the choice to OPSGENIE_PROFILE.
+func SetProfile(name string) {
+	profile = name
+}
+
+// CurrentProfile returns the name of the selected profile, or "" when
+// none is: the one given to SetProfile, else OPSGENIE_PROFILE.
+func CurrentProfile() string {
+	if profile != "" {
+		return profile
+	}
+	return os.Getenv("OPSGENIE_PROFILE")
+}
+
+// GetProfile returns the named profile of the config file.
+func GetProfile(name string) (Profile, error) {
+	config, err := loadAuth()
+	if err != nil {
+		return Profile{}, fmt.Errorf("profile %q: %s: %w", name, ConfigPath(), err)
+	}
+	p, ok := config.Profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("no profile %q in %s", name, ConfigPath())
+	}
+	if p.APIKey == "" {
+		return Profile{}, fmt.Errorf("profile %q in %s has no api_key", name, ConfigPath())
+	}
+	return p, nil
+}
+
+// ProfileNames returns the names of the profiles in the config file,
+// sorted. A missing config file means none.
+func ProfileNames() ([]string, error) {
+	config, err := loadAuth()
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ConfigPath(), err)
+	}
+	names := make([]string, 0, len(config.Profiles))
+	for name := range config.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
 }
 
 // ConfigPath returns the path to the auth config file (~/.opsgenie-cli-auth.json).
@@ -22,8 +104,13 @@ func ConfigPath() string {
 }
 
 // GetAPIKey returns the OpsGenie API key from env var or config file.
-// Priority: OPSGENIE_API_KEY env var → ~/.opsgenie-cli-auth.json
+// Priority: selected profile → OPSGENIE_API_KEY env var →
+// ~/.opsgenie-cli-auth.json
 func GetAPIKey() (string, error) {
+	if name := CurrentProfile(); name != "" {
+		p, err := GetProfile(name)
+		return p.APIKey, err
+	}
 	if key := os.Getenv("OPSGENIE_API_KEY"); key != "" {
 		return key, nil
 	}
@@ -38,9 +125,146 @@ func GetAPIKey() (string, error) {
 	return config.APIKey, nil
 }
 
-// SaveAPIKey writes the API key to the config file with mode 0600.
+// Region returns the configured region name or API URL: api_url, else
+// region, of the selected profile or, without one, of
+// ~/.opsgenie-cli-auth.json. An empty result means none is configured.
+func Region() (string, error) {
+	if name := CurrentProfile(); name != "" {
+		p, err := GetProfile(name)
+		if err != nil {
+			return "", err
+		}
+		if p.APIURL != "" {
+			return p.APIURL, nil
+		}
+		return p.Region, nil
+	}
+
+	config, err := loadAuth()
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", ConfigPath(), err)
+	}
+	if config.APIURL != "" {
+		return config.APIURL, nil
+	}
+	return config.Region, nil
+}
+
+// DefaultResponders returns the responders to add to created alerts.
+// Priority: OPSGENIE_DEFAULT_RESPONDERS env var (comma-separated) →
+// default_responders in ~/.opsgenie-cli-auth.json. A missing config file
+// means no defaults.
+func DefaultResponders() ([]string, error) {
+	if v := os.Getenv("OPSGENIE_DEFAULT_RESPONDERS"); v != "" {
+		var out []string
+		for _, r := range strings.Split(v, ",") {
+			if r = strings.TrimSpace(r); r != "" {
+				out = append(out, r)
+			}
+		}
+		return out, nil
+	}
+
+	config, err := loadAuth()
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ConfigPath(), err)
+	}
+	return config.DefaultResponders, nil
+}
+
+// Timezone returns the zone name time flags are read in.
+// Priority: OPSGENIE_TIMEZONE env var → timezone in
+// ~/.opsgenie-cli-auth.json. An empty result means the local zone.
+func Timezone() (string, error) {
+	if v := os.Getenv("OPSGENIE_TIMEZONE"); v != "" {
+		return v, nil
+	}
+
+	config, err := loadAuth()
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", ConfigPath(), err)
+	}
+	return config.Timezone, nil
+}
+
+// User returns the default username for commands that act for a user.
+// Priority: OPSGENIE_USER env var → user in ~/.opsgenie-cli-auth.json.
+// An empty result means none is configured.
+func User() (string, error) {
+	if v := os.Getenv("OPSGENIE_USER"); v != "" {
+		return v, nil
+	}
+
+	config, err := loadAuth()
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", ConfigPath(), err)
+	}
+	return config.User, nil
+}
+
+// Colors returns the table cell color overrides, by "column.value".
+// Priority: OPSGENIE_COLORS env var (comma-separated column.value=color
+// pairs) → colors in ~/.opsgenie-cli-auth.json.
+func Colors() (map[string]string, error) {
+	if v := os.Getenv("OPSGENIE_COLORS"); v != "" {
+		out := map[string]string{}
+		for _, pair := range strings.Split(v, ",") {
+			if pair = strings.TrimSpace(pair); pair == "" {
+				continue
+			}
+			key, color, ok := strings.Cut(pair, "=")
+			if !ok {
+				return nil, fmt.Errorf("OPSGENIE_COLORS: %q is not column.value=color", pair)
+			}
+			out[strings.TrimSpace(key)] = strings.TrimSpace(color)
+		}
+		return out, nil
+	}
+
+	config, err := loadAuth()
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ConfigPath(), err)
+	}
+	return config.Colors, nil
+}
+
+// Config returns the contents of ~/.opsgenie-cli-auth.json. A missing
+// file reads as an empty config.
+func Config() (AuthConfig, error) {
+	config, err := loadAuth()
+	if errors.Is(err, fs.ErrNotExist) {
+		return AuthConfig{}, nil
+	}
+	if err != nil {
+		return AuthConfig{}, fmt.Errorf("%s: %w", ConfigPath(), err)
+	}
+	return *config, nil
+}
+
+// SaveAPIKey writes the API key to the config file with mode 0600, keeping
+// the file's other settings.
 func SaveAPIKey(key string) error {
-	return SaveAuth(AuthConfig{APIKey: key})
+	config, err := loadAuth()
+	if err != nil {
+		config = &AuthConfig{}
+	}
+	config.APIKey = key
+	return SaveAuth(*config)
 }
 
 // SaveAuth writes authentication config to the config file with mode 0600.
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -180,6 +181,46 @@ func TestSaveAuth_RoundTrip(t *testing.T) {
 	}
 }
 
+func TestDefaultResponders_ConfigFile(t *testing.T) {
+	t.Setenv("OPSGENIE_DEFAULT_RESPONDERS", "")
+	setHome(t, t.TempDir())
+
+	if got, err := DefaultResponders(); err != nil || got != nil {
+		t.Fatalf("without config: got %v, %v; want none", got, err)
+	}
+
+	if err := SaveAuth(AuthConfig{APIKey: "k", DefaultResponders: []string{"team:ops"}}); err != nil {
+		t.Fatalf("SaveAuth: %v", err)
+	}
+	// Saving a new key keeps the defaults.
+	if err := SaveAPIKey("k2"); err != nil {
+		t.Fatalf("SaveAPIKey: %v", err)
+	}
+	got, err := DefaultResponders()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0] != "team:ops" {
+		t.Errorf("expected [team:ops], got %v", got)
+	}
+}
+
+func TestDefaultResponders_EnvVarTakesPrecedence(t *testing.T) {
+	t.Setenv("OPSGENIE_DEFAULT_RESPONDERS", "team:sre, user:bob@example.com")
+	setHome(t, t.TempDir())
+	if err := SaveAuth(AuthConfig{DefaultResponders: []string{"team:ops"}}); err != nil {
+		t.Fatalf("SaveAuth: %v", err)
+	}
+
+	got, err := DefaultResponders()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0] != "team:sre" || got[1] != "user:bob@example.com" {
+		t.Errorf("expected [team:sre user:bob@example.com], got %v", got)
+	}
+}
+
 // contains is a simple string containment helper for test assertions.
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 ||
@@ -192,3 +233,131 @@ func contains(s, substr string) bool {
 			return false
 		}())
 }
+
+func TestTimezone(t *testing.T) {
+	t.Setenv("OPSGENIE_TIMEZONE", "")
+	setHome(t, t.TempDir())
+
+	if got, err := Timezone(); err != nil || got != "" {
+		t.Fatalf("without config: got %q, %v; want empty", got, err)
+	}
+	if err := SaveAuth(AuthConfig{APIKey: "k", Timezone: "Europe/Berlin"}); err != nil {
+		t.Fatalf("SaveAuth: %v", err)
+	}
+	if got, err := Timezone(); err != nil || got != "Europe/Berlin" {
+		t.Errorf("from config: got %q, %v; want Europe/Berlin", got, err)
+	}
+
+	t.Setenv("OPSGENIE_TIMEZONE", "Asia/Tokyo")
+	if got, err := Timezone(); err != nil || got != "Asia/Tokyo" {
+		t.Errorf("from env: got %q, %v; want Asia/Tokyo", got, err)
+	}
+}
+
+func TestUser(t *testing.T) {
+	t.Setenv("OPSGENIE_USER", "")
+	setHome(t, t.TempDir())
+
+	if got, err := User(); err != nil || got != "" {
+		t.Fatalf("without config: got %q, %v; want empty", got, err)
+	}
+	if err := SaveAuth(AuthConfig{APIKey: "k", User: "alice@example.com"}); err != nil {
+		t.Fatalf("SaveAuth: %v", err)
+	}
+	if got, err := User(); err != nil || got != "alice@example.com" {
+		t.Errorf("from config: got %q, %v", got, err)
+	}
+
+	t.Setenv("OPSGENIE_USER", "bob@example.com")
+	if got, err := User(); err != nil || got != "bob@example.com" {
+		t.Errorf("from env: got %q, %v", got, err)
+	}
+}
+
+func TestColors(t *testing.T) {
+	t.Setenv("OPSGENIE_COLORS", "")
+	setHome(t, t.TempDir())
+
+	if got, err := Colors(); err != nil || got != nil {
+		t.Fatalf("without config: got %v, %v; want none", got, err)
+	}
+	if err := SaveAuth(AuthConfig{APIKey: "k", Colors: map[string]string{"priority.P1": "magenta"}}); err != nil {
+		t.Fatalf("SaveAuth: %v", err)
+	}
+	if got, err := Colors(); err != nil || got["priority.P1"] != "magenta" {
+		t.Errorf("from config: got %v, %v", got, err)
+	}
+
+	t.Setenv("OPSGENIE_COLORS", "status.open=none, priority.P2 = blue+bold")
+	got, err := Colors()
+	if err != nil || len(got) != 2 || got["status.open"] != "none" || got["priority.P2"] != "blue+bold" {
+		t.Errorf("from env: got %v, %v", got, err)
+	}
+	t.Setenv("OPSGENIE_COLORS", "priority.P1")
+	if _, err := Colors(); err == nil {
+		t.Error("expected an error for a pair without =")
+	}
+}
+
+func TestProfiles(t *testing.T) {
+	t.Setenv("OPSGENIE_API_KEY", "env-key")
+	t.Setenv("OPSGENIE_PROFILE", "")
+	setHome(t, t.TempDir())
+
+	if names, err := ProfileNames(); err != nil || len(names) != 0 {
+		t.Fatalf("without config: got %v, %v; want none", names, err)
+	}
+	cfg := AuthConfig{APIKey: "k", Profiles: map[string]Profile{
+		"zeta":  {APIKey: "zeta-key", Region: "eu"},
+		"alpha": {APIKey: "alpha-key"},
+	}}
+	if err := SaveAuth(cfg); err != nil {
+		t.Fatalf("SaveAuth: %v", err)
+	}
+	if names, err := ProfileNames(); err != nil || strings.Join(names, ",") != "alpha,zeta" {
+		t.Errorf("ProfileNames: got %v, %v; want [alpha zeta]", names, err)
+	}
+
+	// The selected profile's key wins over the env var.
+	t.Setenv("OPSGENIE_PROFILE", "zeta")
+	if key, err := GetAPIKey(); err != nil || key != "zeta-key" {
+		t.Errorf("OPSGENIE_PROFILE: got %q, %v; want zeta-key", key, err)
+	}
+	SetProfile("alpha")
+	t.Cleanup(func() { SetProfile("") })
+	if key, err := GetAPIKey(); err != nil || key != "alpha-key" {
+		t.Errorf("SetProfile: got %q, %v; want alpha-key", key, err)
+	}
+
+	SetProfile("missing")
+	if _, err := GetAPIKey(); err == nil || !strings.Contains(err.Error(), `no profile "missing"`) {
+		t.Errorf("unknown profile: got %v", err)
+	}
+}
+
+func TestRegion(t *testing.T) {
+	t.Setenv("OPSGENIE_PROFILE", "")
+	setHome(t, t.TempDir())
+
+	if got, err := Region(); err != nil || got != "" {
+		t.Fatalf("without config: got %q, %v; want empty", got, err)
+	}
+	cfg := AuthConfig{APIKey: "k", Region: "eu", Profiles: map[string]Profile{
+		"gov":   {APIKey: "g", Region: "sandbox", APIURL: "https://og.gov.example.com"},
+		"plain": {APIKey: "p"},
+	}}
+	if err := SaveAuth(cfg); err != nil {
+		t.Fatalf("SaveAuth: %v", err)
+	}
+	if got, err := Region(); err != nil || got != "eu" {
+		t.Errorf("from config: got %q, %v; want eu", got, err)
+	}
+	t.Setenv("OPSGENIE_PROFILE", "gov")
+	if got, err := Region(); err != nil || got != "https://og.gov.example.com" {
+		t.Errorf("profile api_url: got %q, %v", got, err)
+	}
+	t.Setenv("OPSGENIE_PROFILE", "plain")
+	if got, err := Region(); err != nil || got != "" {
+		t.Errorf("profile without region: got %q, %v; want empty", got, err)
+	}
+}
@@ -0,0 +1,51 @@
+// Package clipboard writes text to the system clipboard by piping it to the
+// platform's clipboard tool.
+package clipboard
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// tools returns the candidate clipboard commands for goos, in order of
+// preference. wayland reports whether a Wayland session is running.
+func tools(goos string, wayland bool) [][]string {
+	switch goos {
+	case "darwin":
+		return [][]string{{"pbcopy"}}
+	case "windows":
+		return [][]string{{"clip"}}
+	}
+	var out [][]string
+	if wayland {
+		out = append(out, []string{"wl-copy"})
+	}
+	return append(out,
+		[]string{"xclip", "-selection", "clipboard"},
+		[]string{"xsel", "--clipboard", "--input"},
+	)
+}
+
+// Write copies text to the clipboard using the first available tool: pbcopy
+// on macOS, clip on Windows, and wl-copy, xclip or xsel elsewhere.
+func Write(text string) error {
+	candidates := tools(runtime.GOOS, os.Getenv("WAYLAND_DISPLAY") != "")
+	var names []string
+	for _, args := range candidates {
+		names = append(names, args[0])
+		path, err := exec.LookPath(args[0])
+		if err != nil {
+			continue
+		}
+		c := exec.Command(path, args[1:]...)
+		c.Stdin = strings.NewReader(text)
+		if out, err := c.CombinedOutput(); err != nil {
+			return fmt.Errorf("%s: %w: %s", args[0], err, strings.TrimSpace(string(out)))
+		}
+		return nil
+	}
+	return fmt.Errorf("no clipboard tool found (install one of: %s)", strings.Join(names, ", "))
+}
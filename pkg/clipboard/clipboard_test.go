@@ -0,0 +1,75 @@
+package clipboard
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+func TestTools(t *testing.T) {
+	first := func(goos string, wayland bool) []string {
+		var names []string
+		for _, args := range tools(goos, wayland) {
+			names = append(names, args[0])
+		}
+		return names
+	}
+	cases := []struct {
+		goos    string
+		wayland bool
+		want    []string
+	}{
+		{"darwin", false, []string{"pbcopy"}},
+		{"windows", false, []string{"clip"}},
+		{"linux", false, []string{"xclip", "xsel"}},
+		{"linux", true, []string{"wl-copy", "xclip", "xsel"}},
+	}
+	for _, tc := range cases {
+		if got := first(tc.goos, tc.wayland); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("tools(%q, %v) = %v, want %v", tc.goos, tc.wayland, got, tc.want)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("fake xclip is a shell script")
+	}
+	dir := t.TempDir()
+	out := filepath.Join(dir, "clipboard")
+	cat, err := exec.LookPath("cat")
+	if err != nil {
+		t.Skip("cat not found")
+	}
+	script := "#!/bin/sh\n" + cat + " > " + out + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "xclip"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir)
+	t.Setenv("WAYLAND_DISPLAY", "")
+
+	if err := Write("alert-id-123"); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "alert-id-123" {
+		t.Errorf("clipboard = %q", got)
+	}
+}
+
+func TestWriteNoTool(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("tool names differ per platform")
+	}
+	t.Setenv("PATH", t.TempDir())
+	t.Setenv("WAYLAND_DISPLAY", "")
+	if err := Write("x"); err == nil {
+		t.Fatal("expected error without a clipboard tool")
+	}
+}
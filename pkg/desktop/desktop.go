@@ -0,0 +1,153 @@
+// Package desktop raises native desktop notifications by running the
+// platform's notification tool, with action buttons where the tool can
+// report which one was clicked.
+package desktop
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Action is a button on a notification. Key is returned by Show when the
+// button is clicked; Label is shown.
+type Action struct {
+	Key   string
+	Label string
+}
+
+// Notification is one desktop notification.
+type Notification struct {
+	Title   string
+	Body    string
+	Urgent  bool
+	Actions []Action
+}
+
+// tool is a notification command: the arguments to show n, and whether
+// it waits for a click and prints the chosen action.
+type tool struct {
+	name    string
+	actions bool
+	args    func(n Notification) []string
+}
+
+// tools returns the candidate notification tools for goos, in order of
+// preference.
+func tools(goos string) []tool {
+	switch goos {
+	case "darwin":
+		return []tool{
+			{"terminal-notifier", true, func(n Notification) []string {
+				args := []string{"-title", n.Title, "-message", n.Body, "-group", "opsgenie-cli"}
+				if len(n.Actions) > 0 {
+					labels := make([]string, len(n.Actions))
+					for i, a := range n.Actions {
+						labels[i] = a.Label
+					}
+					args = append(args, "-actions", strings.Join(labels, ","))
+				}
+				return args
+			}},
+			{"osascript", false, func(n Notification) []string {
+				return []string{"-e", "display notification " + appleScriptQuote(n.Body) + " with title " + appleScriptQuote(n.Title)}
+			}},
+		}
+	case "windows":
+		return []tool{{"powershell", false, func(n Notification) []string {
+			return []string{"-NoProfile", "-NonInteractive", "-Command", windowsToast(n)}
+		}}}
+	}
+	return []tool{{"notify-send", true, func(n Notification) []string {
+		urgency := "normal"
+		if n.Urgent {
+			urgency = "critical"
+		}
+		args := []string{"--app-name=opsgenie-cli", "--urgency=" + urgency}
+		for _, a := range n.Actions {
+			args = append(args, "--action="+a.Key+"="+a.Label)
+		}
+		if len(n.Actions) > 0 {
+			args = append(args, "--wait")
+		}
+		return append(args, "--", n.Title, n.Body)
+	}}}
+}
+
+// find returns the first tool of goos on the PATH and its path.
+func find(goos string) (tool, string, error) {
+	var names []string
+	for _, t := range tools(goos) {
+		names = append(names, t.name)
+		if path, err := exec.LookPath(t.name); err == nil {
+			return t, path, nil
+		}
+	}
+	return tool{}, "", fmt.Errorf("no desktop notification tool found (install one of: %s)", strings.Join(names, ", "))
+}
+
+// Check returns an error naming the tools to install when notifications
+// cannot be shown on this system.
+func Check() error {
+	_, _, err := find(runtime.GOOS)
+	return err
+}
+
+// SupportsActions reports whether Show can offer action buttons: with
+// terminal-notifier on macOS and notify-send on Linux, not on Windows.
+func SupportsActions() bool {
+	t, _, err := find(runtime.GOOS)
+	return err == nil && t.actions
+}
+
+// Show raises n. Where the tool supports actions, it waits until the
+// notification is clicked or dismissed and returns the Key of the action
+// chosen, or "" for none; elsewhere the actions are dropped and it returns
+// "" at once. Cancelling ctx closes a notification still waiting.
+func Show(ctx context.Context, n Notification) (string, error) {
+	t, path, err := find(runtime.GOOS)
+	if err != nil {
+		return "", err
+	}
+	if !t.actions {
+		n.Actions = nil
+	}
+	out, err := exec.CommandContext(ctx, path, t.args(n)...).Output()
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", nil
+		}
+		return "", fmt.Errorf("%s: %w", t.name, err)
+	}
+	return chosen(n.Actions, string(out)), nil
+}
+
+// chosen maps a tool's output, the key (notify-send) or label
+// (terminal-notifier) of the clicked action, to the action's Key.
+func chosen(actions []Action, out string) string {
+	out = strings.TrimSpace(out)
+	for _, a := range actions {
+		if out == a.Key || out == a.Label {
+			return a.Key
+		}
+	}
+	return ""
+}
+
+// appleScriptQuote quotes s as an AppleScript string literal.
+func appleScriptQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// windowsToast returns a PowerShell script that shows n as a toast.
+func windowsToast(n Notification) string {
+	q := func(s string) string { return "'" + strings.ReplaceAll(s, "'", "''") + "'" }
+	return `[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] > $null
+$xml = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)
+$text = $xml.GetElementsByTagName('text')
+$text.Item(0).AppendChild($xml.CreateTextNode(` + q(n.Title) + `)) > $null
+$text.Item(1).AppendChild($xml.CreateTextNode(` + q(n.Body) + `)) > $null
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier('opsgenie-cli').Show([Windows.UI.Notifications.ToastNotification]::new($xml))`
+}
@@ -0,0 +1,92 @@
+package desktop
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+var ackSnooze = []Action{{"ack", "Acknowledge"}, {"snooze", "Snooze"}}
+
+func TestTools(t *testing.T) {
+	names := func(goos string) []string {
+		var out []string
+		for _, tl := range tools(goos) {
+			out = append(out, tl.name)
+		}
+		return out
+	}
+	cases := map[string][]string{
+		"darwin":  {"terminal-notifier", "osascript"},
+		"windows": {"powershell"},
+		"linux":   {"notify-send"},
+	}
+	for goos, want := range cases {
+		if got := names(goos); !reflect.DeepEqual(got, want) {
+			t.Errorf("tools(%q) = %v, want %v", goos, got, want)
+		}
+	}
+}
+
+func TestToolArgs(t *testing.T) {
+	n := Notification{Title: "[P1] opsgenie", Body: `Disk "full"`, Urgent: true, Actions: ackSnooze}
+
+	got := tools("linux")[0].args(n)
+	want := []string{"--app-name=opsgenie-cli", "--urgency=critical", "--action=ack=Acknowledge", "--action=snooze=Snooze", "--wait", "--", "[P1] opsgenie", `Disk "full"`}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("notify-send args = %q, want %q", got, want)
+	}
+
+	got = tools("darwin")[0].args(n)
+	if !strings.Contains(strings.Join(got, " "), "-actions Acknowledge,Snooze") {
+		t.Errorf("terminal-notifier args = %q", got)
+	}
+	got = tools("darwin")[1].args(n)
+	if want := `display notification "Disk \"full\"" with title "[P1] opsgenie"`; got[1] != want {
+		t.Errorf("osascript script = %q, want %q", got[1], want)
+	}
+}
+
+func TestChosen(t *testing.T) {
+	for out, want := range map[string]string{"ack\n": "ack", "Snooze": "snooze", "@CLOSED": "", "": ""} {
+		if got := chosen(ackSnooze, out); got != want {
+			t.Errorf("chosen(%q) = %q, want %q", out, got, want)
+		}
+	}
+}
+
+func TestShow(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("fake notify-send is a shell script")
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "notify-send"), []byte("#!/bin/sh\necho snooze\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir)
+
+	if !SupportsActions() {
+		t.Fatal("notify-send should support actions")
+	}
+	got, err := Show(context.Background(), Notification{Title: "t", Body: "b", Actions: ackSnooze})
+	if err != nil {
+		t.Fatalf("Show: %v", err)
+	}
+	if got != "snooze" {
+		t.Errorf("Show = %q, want snooze", got)
+	}
+}
+
+func TestShowNoTool(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("tool names differ per platform")
+	}
+	t.Setenv("PATH", t.TempDir())
+	if err := Check(); err == nil || !strings.Contains(err.Error(), "notify-send") {
+		t.Fatalf("Check() = %v, want an error naming notify-send", err)
+	}
+}
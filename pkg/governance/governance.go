@@ -0,0 +1,51 @@
+// Package governance checks OpsGenie resources against locally defined
+// hygiene policies (tag conventions, priority usage) so they can be enforced
+// from CI or cron.
+package governance
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Violation is a single policy breach found on a resource.
+type Violation struct {
+	Kind   string `json:"kind"` // "alert", "integration", "policy", ...
+	ID     string `json:"id"`
+	Name   string `json:"name,omitempty"`
+	Rule   string `json:"rule"`
+	Detail string `json:"detail"`
+}
+
+// loadYAML reads a policy file and decodes it into v, rejecting unknown
+// keys so typos in the policy do not silently disable a rule.
+func loadYAML(file string, v interface{}) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return fmt.Errorf("failed to read policy file: %w", err)
+	}
+	defer f.Close()
+
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("failed to parse policy file %s: %w", file, err)
+	}
+	return nil
+}
+
+// matchAny reports whether s matches any of the glob patterns. Matching is
+// case-insensitive, following OpsGenie's own tag handling.
+func matchAny(patterns []string, s string) bool {
+	s = strings.ToLower(s)
+	for _, p := range patterns {
+		if ok, _ := path.Match(strings.ToLower(p), s); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+package governance
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+)
+
+// PriorityPolicy keeps highest-severity paging meaningful. Example
+// priority.yaml:
+//
+//	# Priority being governed (default P1).
+//	priority: P1
+//	# Integrations and alert policies (glob patterns on name) allowed to
+//	# raise alerts at that priority.
+//	allowed_integrations: ["Datadog Production", "Pingdom*"]
+//	allowed_policies: ["Escalate customer outages"]
+//	# Alerts at that priority must be acknowledged within this time.
+//	max_unacked: 15m
+type PriorityPolicy struct {
+	Priority            string   `yaml:"priority" json:"priority"`
+	AllowedIntegrations []string `yaml:"allowed_integrations" json:"allowedIntegrations,omitempty"`
+	AllowedPolicies     []string `yaml:"allowed_policies" json:"allowedPolicies,omitempty"`
+	MaxUnacked          string   `yaml:"max_unacked" json:"maxUnacked,omitempty"`
+
+	maxUnacked time.Duration
+}
+
+// LoadPriorityPolicy reads and validates a priority policy file.
+func LoadPriorityPolicy(file string) (*PriorityPolicy, error) {
+	var p PriorityPolicy
+	if err := loadYAML(file, &p); err != nil {
+		return nil, err
+	}
+	if err := p.init(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (p *PriorityPolicy) init() error {
+	if p.Priority == "" {
+		p.Priority = "P1"
+	}
+	p.Priority = strings.ToUpper(p.Priority)
+	if p.MaxUnacked != "" {
+		d, err := time.ParseDuration(p.MaxUnacked)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("invalid max_unacked %q: use a duration such as 15m", p.MaxUnacked)
+		}
+		p.maxUnacked = d
+	}
+	return nil
+}
+
+// CheckIntegration flags an integration whose configuration sets the
+// governed priority anywhere (alert actions, filters) without being
+// allow-listed.
+func (p *PriorityPolicy) CheckIntegration(id, name string, config map[string]interface{}) []Violation {
+	if !setsPriority(config, p.Priority) || matchAny(p.AllowedIntegrations, name) {
+		return nil
+	}
+	return []Violation{{
+		Kind: "integration", ID: id, Name: name, Rule: "integration-priority",
+		Detail: fmt.Sprintf("creates %s alerts but is not in allowed_integrations", p.Priority),
+	}}
+}
+
+// CheckAlertPolicy flags an alert policy that raises alerts to the governed
+// priority without being allow-listed.
+func (p *PriorityPolicy) CheckAlertPolicy(id, name string, policy map[string]interface{}) []Violation {
+	if t, _ := policy["type"].(string); t != "" && t != "alert" {
+		return nil
+	}
+	prio, _ := policy["priority"].(string)
+	if !strings.EqualFold(prio, p.Priority) || matchAny(p.AllowedPolicies, name) {
+		return nil
+	}
+	return []Violation{{
+		Kind: "policy", ID: id, Name: name, Rule: "policy-priority",
+		Detail: fmt.Sprintf("sets priority %s but is not in allowed_policies", p.Priority),
+	}}
+}
+
+// CheckAlert flags an alert at the governed priority that was, or still is,
+// unacknowledged for longer than max_unacked.
+func (p *PriorityPolicy) CheckAlert(a api.AlertResponse, now time.Time) []Violation {
+	if p.maxUnacked == 0 || !strings.EqualFold(a.Priority, p.Priority) {
+		return nil
+	}
+
+	var waited time.Duration
+	switch {
+	case a.Report != nil && a.Report.AckTime > 0:
+		waited = time.Duration(a.Report.AckTime) * time.Millisecond
+	case a.Acknowledged:
+		// Acknowledged but no timing report; nothing to measure.
+		return nil
+	case a.Status == "closed":
+		if a.Report == nil || a.Report.CloseTime == 0 {
+			return nil
+		}
+		waited = time.Duration(a.Report.CloseTime) * time.Millisecond
+	default:
+		created, err := time.Parse(time.RFC3339Nano, a.CreatedAt)
+		if err != nil {
+			return nil
+		}
+		waited = now.Sub(created)
+	}
+
+	if waited <= p.maxUnacked {
+		return nil
+	}
+	state := "acknowledged after"
+	if !a.Acknowledged {
+		state = "unacknowledged for"
+	}
+	return []Violation{{
+		Kind: "alert", ID: a.ID, Name: a.Message, Rule: "unacked",
+		Detail: fmt.Sprintf("%s %s %s (limit %s)", p.Priority, state, waited.Round(time.Second), p.maxUnacked),
+	}}
+}
+
+// setsPriority reports whether a decoded JSON document contains a
+// "priority" field equal to prio at any depth.
+func setsPriority(v interface{}, prio string) bool {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, val := range t {
+			if s, ok := val.(string); ok && k == "priority" && strings.EqualFold(s, prio) {
+				return true
+			}
+			if setsPriority(val, prio) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, val := range t {
+			if setsPriority(val, prio) {
+				return true
+			}
+		}
+	}
+	return false
+}
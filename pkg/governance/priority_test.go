@@ -0,0 +1,89 @@
+package governance
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+)
+
+func TestLoadPriorityPolicy(t *testing.T) {
+	p, err := LoadPriorityPolicy(writePolicy(t, "allowed_integrations: [\"Datadog*\"]\nmax_unacked: 15m\n"))
+	if err != nil {
+		t.Fatalf("LoadPriorityPolicy: %v", err)
+	}
+	if p.Priority != "P1" {
+		t.Errorf("Priority default = %q, want P1", p.Priority)
+	}
+	if p.maxUnacked != 15*time.Minute {
+		t.Errorf("maxUnacked = %s", p.maxUnacked)
+	}
+
+	if _, err := LoadPriorityPolicy(writePolicy(t, "max_unacked: soon\n")); err == nil || !strings.Contains(err.Error(), "max_unacked") {
+		t.Errorf("expected max_unacked error, got %v", err)
+	}
+}
+
+func TestPriorityPolicy_CheckIntegration(t *testing.T) {
+	p := &PriorityPolicy{AllowedIntegrations: []string{"Datadog*"}}
+	_ = p.init()
+
+	p1Config := map[string]interface{}{
+		"actions": []interface{}{map[string]interface{}{"type": "create", "priority": "P1"}},
+	}
+	if vs := p.CheckIntegration("i1", "Datadog Prod", p1Config); len(vs) != 0 {
+		t.Errorf("allow-listed integration flagged: %+v", vs)
+	}
+	if vs := p.CheckIntegration("i2", "Email", p1Config); len(vs) != 1 || vs[0].Rule != "integration-priority" {
+		t.Errorf("expected violation, got %+v", vs)
+	}
+	if vs := p.CheckIntegration("i3", "Email", map[string]interface{}{"priority": "P3"}); len(vs) != 0 {
+		t.Errorf("P3 integration flagged: %+v", vs)
+	}
+}
+
+func TestPriorityPolicy_CheckAlertPolicy(t *testing.T) {
+	p := &PriorityPolicy{AllowedPolicies: []string{"Outages"}}
+	_ = p.init()
+
+	if vs := p.CheckAlertPolicy("p1", "Outages", map[string]interface{}{"type": "alert", "priority": "P1"}); len(vs) != 0 {
+		t.Errorf("allow-listed policy flagged: %+v", vs)
+	}
+	if vs := p.CheckAlertPolicy("p2", "Bump", map[string]interface{}{"type": "alert", "priority": "p1"}); len(vs) != 1 {
+		t.Errorf("expected violation, got %+v", vs)
+	}
+	if vs := p.CheckAlertPolicy("p3", "Quiet", map[string]interface{}{"type": "notification", "priority": "P1"}); len(vs) != 0 {
+		t.Errorf("notification policy flagged: %+v", vs)
+	}
+}
+
+func TestPriorityPolicy_CheckAlert(t *testing.T) {
+	p := &PriorityPolicy{MaxUnacked: "15m"}
+	if err := p.init(); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	created := now.Add(-time.Hour).Format(time.RFC3339)
+
+	cases := []struct {
+		name  string
+		alert api.AlertResponse
+		want  int
+	}{
+		{"open and old", api.AlertResponse{ID: "a", Priority: "P1", Status: "open", CreatedAt: created}, 1},
+		{"open and fresh", api.AlertResponse{ID: "b", Priority: "P1", Status: "open", CreatedAt: now.Add(-5 * time.Minute).Format(time.RFC3339)}, 0},
+		{"slow ack", api.AlertResponse{ID: "c", Priority: "P1", Acknowledged: true, Report: &api.AlertReport{AckTime: 20 * 60 * 1000}}, 1},
+		{"fast ack", api.AlertResponse{ID: "d", Priority: "P1", Acknowledged: true, Report: &api.AlertReport{AckTime: 60 * 1000}}, 0},
+		{"closed without ack, slow", api.AlertResponse{ID: "e", Priority: "P1", Status: "closed", Report: &api.AlertReport{CloseTime: 30 * 60 * 1000}}, 1},
+		{"other priority", api.AlertResponse{ID: "f", Priority: "P3", Status: "open", CreatedAt: created}, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			vs := p.CheckAlert(tc.alert, now)
+			if len(vs) != tc.want {
+				t.Errorf("got %d violations (%+v), want %d", len(vs), vs, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package governance
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// TagPolicy describes the tag conventions alerts and integrations must
+// follow. Example tags.yaml:
+//
+//	# Every tag must match this regular expression.
+//	pattern: '^[a-z0-9][a-z0-9_.-]*(:[a-z0-9_.-]+)?$'
+//	# Only these tags (glob patterns) may be used. Empty allows any tag.
+//	allowed: ["env:*", "service:*", "customer-facing"]
+//	# Tags (glob patterns) that must be present, keyed by team name.
+//	# "*" applies to every resource regardless of team.
+//	required:
+//	  "*": ["env:*"]
+//	  platform: ["service:*"]
+type TagPolicy struct {
+	Pattern  string              `yaml:"pattern" json:"pattern,omitempty"`
+	Allowed  []string            `yaml:"allowed" json:"allowed,omitempty"`
+	Required map[string][]string `yaml:"required" json:"required,omitempty"`
+
+	re *regexp.Regexp
+}
+
+// LoadTagPolicy reads and validates a tag policy file.
+func LoadTagPolicy(file string) (*TagPolicy, error) {
+	var p TagPolicy
+	if err := loadYAML(file, &p); err != nil {
+		return nil, err
+	}
+	if err := p.compile(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (p *TagPolicy) compile() error {
+	if p.Pattern == "" {
+		return nil
+	}
+	re, err := regexp.Compile(p.Pattern)
+	if err != nil {
+		return fmt.Errorf("invalid tag pattern %q: %w", p.Pattern, err)
+	}
+	p.re = re
+	return nil
+}
+
+// Check returns the tag violations for a resource owned by the given teams.
+// Rules are "pattern" (tag fails the naming regex), "allowed" (tag is not
+// in the allow list) and "required" (a required tag is missing).
+func (p *TagPolicy) Check(kind, id, name string, tags, teams []string) []Violation {
+	if p.re == nil && p.Pattern != "" {
+		if err := p.compile(); err != nil {
+			return []Violation{{Kind: kind, ID: id, Name: name, Rule: "pattern", Detail: err.Error()}}
+		}
+	}
+
+	var out []Violation
+	add := func(rule, detail string) {
+		out = append(out, Violation{Kind: kind, ID: id, Name: name, Rule: rule, Detail: detail})
+	}
+
+	for _, tag := range tags {
+		if p.re != nil && !p.re.MatchString(tag) {
+			add("pattern", fmt.Sprintf("tag %q does not match %s", tag, p.Pattern))
+		}
+		if len(p.Allowed) > 0 && !matchAny(p.Allowed, tag) {
+			add("allowed", fmt.Sprintf("tag %q is not in the allowed list", tag))
+		}
+	}
+
+	for _, want := range p.requiredFor(teams) {
+		found := false
+		for _, tag := range tags {
+			if matchAny([]string{want}, tag) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			add("required", fmt.Sprintf("missing required tag %q", want))
+		}
+	}
+	return out
+}
+
+// requiredFor returns the de-duplicated, sorted required tag patterns that
+// apply to a resource owned by teams.
+func (p *TagPolicy) requiredFor(teams []string) []string {
+	seen := map[string]bool{}
+	collect := func(key string) {
+		for k, patterns := range p.Required {
+			if strings.EqualFold(k, key) {
+				for _, pat := range patterns {
+					seen[pat] = true
+				}
+			}
+		}
+	}
+	collect("*")
+	for _, t := range teams {
+		collect(t)
+	}
+	out := make([]string, 0, len(seen))
+	for pat := range seen {
+		out = append(out, pat)
+	}
+	sort.Strings(out)
+	return out
+}
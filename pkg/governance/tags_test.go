@@ -0,0 +1,92 @@
+package governance
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writePolicy(t *testing.T, content string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatalf("write policy: %v", err)
+	}
+	return file
+}
+
+const testTagPolicy = `
+pattern: '^[a-z0-9][a-z0-9_.-]*(:[a-z0-9_.-]+)?$'
+allowed: ["env:*", "service:*", "customer-facing"]
+required:
+  "*": ["env:*"]
+  Platform: ["service:*"]
+`
+
+func rulesOf(vs []Violation) string {
+	var rules []string
+	for _, v := range vs {
+		rules = append(rules, v.Rule)
+	}
+	return strings.Join(rules, ",")
+}
+
+func TestLoadTagPolicy(t *testing.T) {
+	p, err := LoadTagPolicy(writePolicy(t, testTagPolicy))
+	if err != nil {
+		t.Fatalf("LoadTagPolicy: %v", err)
+	}
+	if len(p.Allowed) != 3 || len(p.Required["*"]) != 1 {
+		t.Errorf("unexpected policy: %+v", p)
+	}
+}
+
+func TestLoadTagPolicy_Errors(t *testing.T) {
+	if _, err := LoadTagPolicy(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("expected error for missing file")
+	}
+	if _, err := LoadTagPolicy(writePolicy(t, "pattern: '['\n")); err == nil || !strings.Contains(err.Error(), "invalid tag pattern") {
+		t.Errorf("expected invalid pattern error, got %v", err)
+	}
+	if _, err := LoadTagPolicy(writePolicy(t, "alowed: [x]\n")); err == nil {
+		t.Error("expected error for unknown key")
+	}
+}
+
+func TestTagPolicy_Check(t *testing.T) {
+	p, err := LoadTagPolicy(writePolicy(t, testTagPolicy))
+	if err != nil {
+		t.Fatalf("LoadTagPolicy: %v", err)
+	}
+
+	cases := []struct {
+		name  string
+		tags  []string
+		teams []string
+		want  string
+	}{
+		{"compliant", []string{"env:prod"}, nil, ""},
+		{"missing global required", []string{"customer-facing"}, nil, "required"},
+		{"team rule applies case-insensitively", []string{"env:prod"}, []string{"platform"}, "required"},
+		{"team rule satisfied", []string{"env:prod", "service:api"}, []string{"platform"}, ""},
+		{"bad pattern and not allowed", []string{"env:prod", "Bad Tag"}, nil, "pattern,allowed"},
+		{"not allowed", []string{"env:prod", "misc"}, nil, "allowed"},
+		{"allowed glob matches case-insensitively", []string{"ENV:prod"}, nil, "pattern"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := rulesOf(p.Check("alert", "a1", "msg", tc.tags, tc.teams))
+			if got != tc.want {
+				t.Errorf("rules = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTagPolicy_EmptyPolicyAllowsEverything(t *testing.T) {
+	p := &TagPolicy{}
+	if vs := p.Check("alert", "a1", "", []string{"Anything Goes"}, []string{"x"}); len(vs) != 0 {
+		t.Errorf("expected no violations, got %+v", vs)
+	}
+}
@@ -0,0 +1,524 @@
+package migrate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/snapshot"
+)
+
+// Export formats.
+const (
+	FormatPagerDuty     = "pagerduty"
+	FormatGrafanaOnCall = "grafana-oncall"
+)
+
+// Formats lists the supported export formats.
+var Formats = []string{FormatPagerDuty, FormatGrafanaOnCall}
+
+// exportCollections lists the files of each format.
+var exportCollections = map[string][]string{
+	FormatPagerDuty:     PagerDutyFiles,
+	FormatGrafanaOnCall: {"users", "teams", "schedules", "on_call_shifts", "escalation_chains", "escalation_policies"},
+}
+
+// ExportKinds are the snapshot kinds an export reads.
+var ExportKinds = []string{"teams", "schedules", "rotations", "escalations", "services"}
+
+// Export is OpsGenie configuration converted to another tool's API
+// objects, grouped by the collection (API endpoint) they belong to.
+// Objects keep their OpsGenie IDs and reference each other by them.
+type Export struct {
+	Format   string
+	Files    map[string][]interface{}
+	Mappings []Mapping
+}
+
+// ExportTo converts users and snapshot resources (see ExportKinds) to
+// format.
+func ExportTo(format string, users []User, resources []snapshot.Resource) (*Export, error) {
+	collections, ok := exportCollections[format]
+	if !ok {
+		return nil, fmt.Errorf("unknown format %q (valid: %s)", format, strings.Join(Formats, ", "))
+	}
+	// Every collection is written, empty or not.
+	e := &Export{Format: format, Files: map[string][]interface{}{}}
+	for _, name := range collections {
+		e.Files[name] = []interface{}{}
+	}
+	c := newConverter(users, resources)
+	if format == FormatPagerDuty {
+		c.pagerDuty(e)
+	} else {
+		c.grafanaOnCall(e)
+	}
+	return e, nil
+}
+
+// Write writes each collection to dir/<collection>.json, in the layout
+// LoadPagerDuty reads, and returns the files written.
+func (e *Export) Write(dir string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(e.Files))
+	for name := range e.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var written []string
+	for _, name := range names {
+		b, err := json.MarshalIndent(e.Files[name], "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("encoding %s: %w", name, err)
+		}
+		path := filepath.Join(dir, name+".json")
+		if err := os.WriteFile(path, append(b, '\n'), 0o644); err != nil {
+			return nil, err
+		}
+		written = append(written, path)
+	}
+	return written, nil
+}
+
+func (e *Export) add(collection string, m Mapping, obj map[string]interface{}) {
+	m.Kind = collection
+	e.Mappings = append(e.Mappings, m)
+	e.Files[collection] = append(e.Files[collection], obj)
+}
+
+// record adds a mapping for an object written as part of another (a
+// layer of a schedule) or not written at all.
+func (e *Export) record(m Mapping) {
+	e.Mappings = append(e.Mappings, m)
+}
+
+// converter indexes the OpsGenie configuration being exported.
+type converter struct {
+	users     []User
+	userIDs   map[string]string // lower-case username -> ID
+	teams     []snapshot.Resource
+	schedules []snapshot.Resource
+	rotations map[string][]snapshot.Resource // schedule ID -> rotations
+	escs      []snapshot.Resource
+	services  []snapshot.Resource
+	schedIDs  map[string]string // schedule name -> ID
+}
+
+func newConverter(users []User, resources []snapshot.Resource) *converter {
+	c := &converter{users: users, userIDs: map[string]string{}, rotations: map[string][]snapshot.Resource{}, schedIDs: map[string]string{}}
+	for _, u := range users {
+		c.userIDs[strings.ToLower(u.Username)] = u.ID
+	}
+	for _, r := range resources {
+		switch r.Kind {
+		case "teams":
+			c.teams = append(c.teams, r)
+		case "schedules":
+			c.schedules = append(c.schedules, r)
+			c.schedIDs[r.Name] = r.ID
+		case "rotations":
+			c.rotations[r.ParentID] = append(c.rotations[r.ParentID], r)
+		case "escalations":
+			c.escs = append(c.escs, r)
+		case "services":
+			c.services = append(c.services, r)
+		}
+	}
+	return c
+}
+
+// user resolves a participant or recipient to a user ID.
+func (c *converter) user(ref map[string]interface{}) string {
+	if id := str(ref, "id"); id != "" {
+		return id
+	}
+	return c.userIDs[strings.ToLower(str(ref, "username"))]
+}
+
+// schedule resolves a recipient to a schedule ID.
+func (c *converter) schedule(ref map[string]interface{}) string {
+	if id := str(ref, "id"); id != "" {
+		return id
+	}
+	return c.schedIDs[str(ref, "name")]
+}
+
+// ─── PagerDuty ───────────────────────────────────────────────────────────────
+
+func (c *converter) pagerDuty(e *Export) {
+	for _, u := range c.users {
+		role := "user"
+		if strings.EqualFold(u.Role, "admin") || strings.EqualFold(u.Role, "owner") {
+			role = "admin"
+		}
+		e.add("users", Mapping{Source: "user", SourceID: u.ID, SourceName: u.Username, Name: u.Username},
+			map[string]interface{}{"id": u.ID, "type": "user", "name": u.FullName, "email": u.Username, "role": role})
+	}
+
+	for _, t := range c.teams {
+		e.add("teams", Mapping{Source: "team", SourceID: t.ID, SourceName: t.Name, Name: t.Name},
+			map[string]interface{}{"id": t.ID, "type": "team", "name": t.Name, "description": str(t.Data, "description")})
+	}
+
+	for _, s := range c.schedules {
+		layers := []interface{}{}
+		var layerMappings []Mapping
+		for _, r := range c.rotations[s.ID] {
+			layer, note := c.pagerDutyLayer(r)
+			layers = append(layers, layer)
+			layerMappings = append(layerMappings, Mapping{Source: "rotation", SourceID: r.ID, SourceName: s.Name + " / " + r.Name, Kind: "schedules", Parent: s.Name, Name: r.Name, Note: note})
+		}
+		e.add("schedules", Mapping{Source: "schedule", SourceID: s.ID, SourceName: s.Name, Name: s.Name},
+			map[string]interface{}{
+				"id": s.ID, "type": "schedule", "name": s.Name, "description": str(s.Data, "description"),
+				"time_zone": str(s.Data, "timezone"), "teams": teamRefs(s.Data), "schedule_layers": layers,
+			})
+		for _, m := range layerMappings {
+			e.record(m)
+		}
+	}
+
+	for _, esc := range c.escs {
+		rules, notes := c.pagerDutyRules(esc.Data)
+		policy := map[string]interface{}{
+			"id": esc.ID, "type": "escalation_policy", "name": esc.Name, "description": str(esc.Data, "description"),
+			"teams": teamRefs(esc.Data), "escalation_rules": rules,
+		}
+		if repeat, ok := esc.Data["repeat"].(map[string]interface{}); ok {
+			policy["num_loops"] = num(repeat["count"])
+		}
+		e.add("escalation_policies", Mapping{Source: "escalation", SourceID: esc.ID, SourceName: esc.Name, Name: esc.Name, Note: strings.Join(notes, "; ")}, policy)
+	}
+
+	for _, svc := range c.services {
+		service := map[string]interface{}{"id": svc.ID, "type": "service", "name": svc.Name, "description": str(svc.Data, "description")}
+		if team := str(svc.Data, "teamId"); team != "" {
+			service["teams"] = []interface{}{map[string]interface{}{"id": team, "type": "team_reference"}}
+		}
+		e.add("services", Mapping{Source: "service", SourceID: svc.ID, SourceName: svc.Name, Name: svc.Name,
+			Note: "assign an escalation policy; OpsGenie routes by team routing rules"}, service)
+	}
+}
+
+func (c *converter) pagerDutyLayer(r snapshot.Resource) (map[string]interface{}, string) {
+	var notes []string
+	turn := num(r.Data["length"])
+	if turn < 1 {
+		turn = 1
+	}
+	switch str(r.Data, "type") {
+	case "weekly":
+		turn *= 604800
+	case "daily":
+		turn *= 86400
+	default:
+		turn *= 3600
+	}
+	users := []interface{}{}
+	for _, p := range list(r.Data["participants"]) {
+		if str(p, "type") != "user" {
+			notes = append(notes, str(p, "type")+" participant left out")
+			continue
+		}
+		users = append(users, map[string]interface{}{"user": map[string]interface{}{"id": c.user(p), "type": "user_reference"}})
+	}
+	layer := map[string]interface{}{
+		"name": r.Name, "start": str(r.Data, "startDate"), "rotation_virtual_start": str(r.Data, "startDate"),
+		"rotation_turn_length_seconds": turn, "users": users,
+	}
+	if end := str(r.Data, "endDate"); end != "" {
+		layer["end"] = end
+	}
+	if tr, ok := r.Data["timeRestriction"].(map[string]interface{}); ok {
+		layer["restrictions"] = pagerDutyRestrictions(tr)
+	}
+	return layer, strings.Join(notes, "; ")
+}
+
+// pagerDutyRestrictions converts a rotation time restriction.
+func pagerDutyRestrictions(tr map[string]interface{}) []interface{} {
+	window := func(startDay, endDay int, w map[string]interface{}) (string, int) {
+		start := startDay*24*60 + num(w["startHour"])*60 + num(w["startMin"])
+		end := endDay*24*60 + num(w["endHour"])*60 + num(w["endMin"])
+		span := 24 * 60
+		if startDay >= 0 {
+			span = 7 * 24 * 60
+		}
+		minutes := ((end-start)%span + span) % span
+		if minutes == 0 {
+			minutes = span
+		}
+		return fmt.Sprintf("%02d:%02d:00", num(w["startHour"]), num(w["startMin"])), minutes * 60
+	}
+	out := []interface{}{}
+	if str(tr, "type") == "time-of-day" {
+		w, _ := tr["restriction"].(map[string]interface{})
+		start, secs := window(-1, -1, w)
+		return append(out, map[string]interface{}{"type": "daily_restriction", "start_time_of_day": start, "duration_seconds": secs})
+	}
+	for _, w := range list(tr["restrictions"]) {
+		startDay, endDay := weekday(str(w, "startDay")), weekday(str(w, "endDay"))
+		if startDay < 1 || endDay < 1 {
+			continue
+		}
+		start, secs := window(startDay, endDay, w)
+		out = append(out, map[string]interface{}{
+			"type": "weekly_restriction", "start_time_of_day": start, "duration_seconds": secs, "start_day_of_week": startDay,
+		})
+	}
+	return out
+}
+
+// pagerDutyRules groups escalation rules by delay. PagerDuty delays are
+// the wait before the next rule, so each is the gap to the next group; the
+// last rule waits 30 minutes, PagerDuty's default.
+func (c *converter) pagerDutyRules(esc map[string]interface{}) ([]interface{}, []string) {
+	var notes []string
+	groups := map[int][]interface{}{}
+	var delays []int
+	for _, r := range list(esc["rules"]) {
+		recipient, _ := r["recipient"].(map[string]interface{})
+		var target map[string]interface{}
+		switch str(recipient, "type") {
+		case "user":
+			target = map[string]interface{}{"id": c.user(recipient), "type": "user_reference"}
+		case "schedule":
+			target = map[string]interface{}{"id": c.schedule(recipient), "type": "schedule_reference"}
+		default:
+			notes = append(notes, str(recipient, "type")+" recipient left out")
+			continue
+		}
+		if cond := str(r, "condition"); cond != "" && cond != "if-not-acked" {
+			notes = append(notes, cond+" treated as if-not-acked")
+		}
+		delay, _ := r["delay"].(map[string]interface{})
+		minutes := num(delay["timeAmount"])
+		if str(delay, "timeUnit") == "hours" {
+			minutes *= 60
+		}
+		if _, ok := groups[minutes]; !ok {
+			delays = append(delays, minutes)
+		}
+		groups[minutes] = append(groups[minutes], target)
+	}
+	sort.Ints(delays)
+	rules := []interface{}{}
+	for i, d := range delays {
+		wait := 30
+		if i+1 < len(delays) {
+			wait = delays[i+1] - d
+		}
+		rules = append(rules, map[string]interface{}{"escalation_delay_in_minutes": wait, "targets": groups[d]})
+	}
+	sort.Strings(notes)
+	return rules, notes
+}
+
+// ─── Grafana OnCall ──────────────────────────────────────────────────────────
+
+func (c *converter) grafanaOnCall(e *Export) {
+	for _, u := range c.users {
+		e.add("users", Mapping{Source: "user", SourceID: u.ID, SourceName: u.Username, Name: u.Username},
+			map[string]interface{}{"id": u.ID, "email": u.Username, "username": u.Username, "name": u.FullName})
+	}
+
+	for _, t := range c.teams {
+		e.add("teams", Mapping{Source: "team", SourceID: t.ID, SourceName: t.Name, Name: t.Name},
+			map[string]interface{}{"id": t.ID, "name": t.Name})
+	}
+
+	for _, s := range c.schedules {
+		team := ownerTeamID(s.Data)
+		shifts := []interface{}{}
+		for _, r := range c.rotations[s.ID] {
+			shift, note := c.grafanaShift(r, team, str(s.Data, "timezone"))
+			shifts = append(shifts, r.ID)
+			e.add("on_call_shifts", Mapping{Source: "rotation", SourceID: r.ID, SourceName: s.Name + " / " + r.Name, Parent: s.Name, Name: r.Name, Note: note}, shift)
+		}
+		e.add("schedules", Mapping{Source: "schedule", SourceID: s.ID, SourceName: s.Name, Name: s.Name},
+			map[string]interface{}{"id": s.ID, "name": s.Name, "type": "web", "time_zone": str(s.Data, "timezone"), "team_id": team, "shifts": shifts})
+	}
+
+	for _, esc := range c.escs {
+		e.add("escalation_chains", Mapping{Source: "escalation", SourceID: esc.ID, SourceName: esc.Name, Name: esc.Name},
+			map[string]interface{}{"id": esc.ID, "name": esc.Name, "team_id": ownerTeamID(esc.Data)})
+		steps, notes := c.grafanaSteps(esc)
+		for _, step := range steps {
+			e.Files["escalation_policies"] = append(e.Files["escalation_policies"], step)
+		}
+		e.record(Mapping{Source: "escalation rules", SourceID: esc.ID, SourceName: esc.Name, Kind: "escalation_policies", Parent: esc.Name,
+			Name: fmt.Sprintf("%d step(s)", len(steps)), Note: strings.Join(notes, "; ")})
+	}
+
+	for _, svc := range c.services {
+		e.record(Mapping{Source: "service", SourceID: svc.ID, SourceName: svc.Name, Note: "Grafana OnCall has no services; use an integration per service"})
+	}
+}
+
+func (c *converter) grafanaShift(r snapshot.Resource, team, tz string) (map[string]interface{}, string) {
+	var notes []string
+	interval := num(r.Data["length"])
+	if interval < 1 {
+		interval = 1
+	}
+	frequency, unit := "hourly", time.Hour
+	switch str(r.Data, "type") {
+	case "weekly":
+		frequency, unit = "weekly", 7*24*time.Hour
+	case "daily":
+		frequency, unit = "daily", 24*time.Hour
+	}
+	groups := []interface{}{}
+	for _, p := range list(r.Data["participants"]) {
+		if str(p, "type") != "user" {
+			notes = append(notes, str(p, "type")+" participant left out")
+			continue
+		}
+		groups = append(groups, []interface{}{c.user(p)})
+	}
+	if _, ok := r.Data["timeRestriction"]; ok {
+		notes = append(notes, "time restriction not converted")
+	}
+
+	// Grafana OnCall times are local to the shift's time zone.
+	start := str(r.Data, "startDate")
+	if t, err := time.Parse(time.RFC3339, start); err == nil {
+		start = t.In(tzLocation(tz)).Format("2006-01-02T15:04:05")
+	}
+	shift := map[string]interface{}{
+		"id": r.ID, "name": r.Name, "type": "rolling_users", "team_id": team, "time_zone": tz, "level": 1,
+		"start": start, "duration": int((time.Duration(interval) * unit).Seconds()),
+		"frequency": frequency, "interval": interval, "rolling_users": groups,
+	}
+	if frequency == "weekly" {
+		shift["week_start"] = "MO"
+	}
+	if end := str(r.Data, "endDate"); end != "" {
+		if t, err := time.Parse(time.RFC3339, end); err == nil {
+			end = t.In(tzLocation(tz)).Format("2006-01-02T15:04:05")
+		}
+		shift["until"] = end
+	}
+	return shift, strings.Join(notes, "; ")
+}
+
+// grafanaSteps converts escalation rules to ordered escalation policy
+// steps, with a wait step wherever the delay grows.
+func (c *converter) grafanaSteps(esc snapshot.Resource) ([]map[string]interface{}, []string) {
+	type rule struct {
+		minutes   int
+		recipient map[string]interface{}
+	}
+	var rules []rule
+	for _, r := range list(esc.Data["rules"]) {
+		delay, _ := r["delay"].(map[string]interface{})
+		minutes := num(delay["timeAmount"])
+		if str(delay, "timeUnit") == "hours" {
+			minutes *= 60
+		}
+		recipient, _ := r["recipient"].(map[string]interface{})
+		rules = append(rules, rule{minutes, recipient})
+	}
+	sort.SliceStable(rules, func(i, j int) bool { return rules[i].minutes < rules[j].minutes })
+
+	var notes []string
+	var steps []map[string]interface{}
+	step := func(typ string, fields map[string]interface{}) {
+		s := map[string]interface{}{
+			"id": fmt.Sprintf("%s-%d", esc.ID, len(steps)+1), "escalation_chain_id": esc.ID,
+			"position": len(steps), "type": typ, "important": false,
+		}
+		for k, v := range fields {
+			s[k] = v
+		}
+		steps = append(steps, s)
+	}
+	waited := 0
+	for _, r := range rules {
+		if r.minutes > waited {
+			step("wait", map[string]interface{}{"duration": (r.minutes - waited) * 60})
+			waited = r.minutes
+		}
+		switch str(r.recipient, "type") {
+		case "user":
+			step("notify_persons", map[string]interface{}{"persons_to_notify": []interface{}{c.user(r.recipient)}})
+		case "schedule":
+			step("notify_on_call_from_schedule", map[string]interface{}{"notify_on_call_from_schedule": c.schedule(r.recipient)})
+		case "team":
+			step("notify_team_members", map[string]interface{}{"notify_to_team_members": str(r.recipient, "id")})
+		default:
+			notes = append(notes, str(r.recipient, "type")+" recipient left out")
+		}
+	}
+	if repeat, ok := esc.Data["repeat"].(map[string]interface{}); ok && num(repeat["count"]) > 0 {
+		step("repeat_escalation", nil)
+		if num(repeat["count"]) > 5 {
+			notes = append(notes, "Grafana OnCall repeats at most 5 times")
+		}
+	}
+	return steps, notes
+}
+
+// ─── helpers ─────────────────────────────────────────────────────────────────
+
+func str(m map[string]interface{}, key string) string {
+	s, _ := m[key].(string)
+	return s
+}
+
+// num reads a JSON number, which may have been decoded as float64.
+func num(v interface{}) int {
+	switch n := v.(type) {
+	case float64:
+		return int(n)
+	case int:
+		return n
+	}
+	return 0
+}
+
+func list(v interface{}) []map[string]interface{} {
+	items, _ := v.([]interface{})
+	out := make([]map[string]interface{}, 0, len(items))
+	for _, it := range items {
+		if m, ok := it.(map[string]interface{}); ok {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+func ownerTeamID(data map[string]interface{}) string {
+	owner, _ := data["ownerTeam"].(map[string]interface{})
+	return str(owner, "id")
+}
+
+func teamRefs(data map[string]interface{}) []interface{} {
+	if id := ownerTeamID(data); id != "" {
+		return []interface{}{map[string]interface{}{"id": id, "type": "team_reference"}}
+	}
+	return []interface{}{}
+}
+
+func weekday(name string) int {
+	for i, d := range pdWeekdays {
+		if i > 0 && d == strings.ToLower(name) {
+			return i
+		}
+	}
+	return 0
+}
+
+func tzLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
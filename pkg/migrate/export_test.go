@@ -0,0 +1,129 @@
+package migrate
+
+import (
+	"testing"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/snapshot"
+)
+
+func exportFixture() ([]User, []snapshot.Resource) {
+	users := []User{
+		{ID: "u1", Username: "alice@example.com", FullName: "Alice", Role: "Admin"},
+		{ID: "u2", Username: "bob@example.com", FullName: "Bob", Role: "User"},
+	}
+	team := map[string]interface{}{"id": "t1", "name": "Platform"}
+	resources := []snapshot.Resource{
+		{Kind: "teams", ID: "t1", Name: "Platform", Data: team},
+		{Kind: "schedules", ID: "s1", Name: "Primary", Data: map[string]interface{}{
+			"id": "s1", "name": "Primary", "timezone": "Europe/Berlin", "ownerTeam": team,
+		}},
+		{Kind: "rotations", Parent: "Primary", ParentID: "s1", ID: "r1", Name: "Weekly", Data: map[string]interface{}{
+			"name": "Weekly", "type": "weekly", "length": float64(1), "startDate": "2024-01-01T08:00:00Z",
+			"participants": []interface{}{
+				map[string]interface{}{"type": "user", "username": "alice@example.com"},
+				map[string]interface{}{"type": "user", "id": "u2", "username": "bob@example.com"},
+			},
+			"timeRestriction": map[string]interface{}{"type": "time-of-day", "restriction": map[string]interface{}{
+				"startHour": float64(9), "startMin": float64(0), "endHour": float64(17), "endMin": float64(0),
+			}},
+		}},
+		{Kind: "escalations", ID: "e1", Name: "Platform EP", Data: map[string]interface{}{
+			"name": "Platform EP", "ownerTeam": team,
+			"rules": []interface{}{
+				map[string]interface{}{"condition": "if-not-acked", "delay": map[string]interface{}{"timeAmount": float64(0)},
+					"recipient": map[string]interface{}{"type": "schedule", "name": "Primary"}},
+				map[string]interface{}{"condition": "if-not-acked", "delay": map[string]interface{}{"timeAmount": float64(10)},
+					"recipient": map[string]interface{}{"type": "user", "username": "bob@example.com"}},
+			},
+			"repeat": map[string]interface{}{"count": float64(2)},
+		}},
+		{Kind: "services", ID: "sv1", Name: "api", Data: map[string]interface{}{"name": "api", "teamId": "t1"}},
+	}
+	return users, resources
+}
+
+func TestExportTo_PagerDutyRoundTrip(t *testing.T) {
+	users, resources := exportFixture()
+	exp, err := ExportTo(FormatPagerDuty, users, resources)
+	if err != nil {
+		t.Fatalf("ExportTo: %v", err)
+	}
+	dir := t.TempDir()
+	if _, err := exp.Write(dir); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	imp, err := LoadPagerDuty(dir)
+	if err != nil {
+		t.Fatalf("LoadPagerDuty: %v", err)
+	}
+	if len(imp.Users) != 2 || imp.Users[0].Role != "Admin" {
+		t.Errorf("users = %+v", imp.Users)
+	}
+	byKind := map[string]map[string]interface{}{}
+	for _, r := range imp.Resources {
+		byKind[r.Kind] = r.Data
+	}
+	rot := byKind["rotations"]
+	if rot["type"] != "weekly" || len(rot["participants"].([]interface{})) != 2 {
+		t.Errorf("rotation = %v", rot)
+	}
+	tr := rot["timeRestriction"].(map[string]interface{})["restriction"].(map[string]interface{})
+	if tr["startHour"] != 9 || tr["endHour"] != 17 {
+		t.Errorf("time restriction = %v", tr)
+	}
+	rules := byKind["escalations"]["rules"].([]interface{})
+	if len(rules) != 2 || rules[1].(map[string]interface{})["delay"].(map[string]interface{})["timeAmount"] != 10 {
+		t.Errorf("rules = %v", rules)
+	}
+	if byKind["escalations"]["repeat"].(map[string]interface{})["count"] != 2 {
+		t.Errorf("repeat = %v", byKind["escalations"]["repeat"])
+	}
+	if len(imp.Services) != 1 || imp.Services[0].Parent != "Platform" {
+		t.Errorf("services = %+v", imp.Services)
+	}
+}
+
+func TestExportTo_GrafanaOnCall(t *testing.T) {
+	users, resources := exportFixture()
+	exp, err := ExportTo(FormatGrafanaOnCall, users, resources)
+	if err != nil {
+		t.Fatalf("ExportTo: %v", err)
+	}
+
+	shift := exp.Files["on_call_shifts"][0].(map[string]interface{})
+	if shift["start"] != "2024-01-01T09:00:00" || shift["frequency"] != "weekly" || shift["duration"] != 604800 {
+		t.Errorf("shift = %v", shift)
+	}
+	if got := shift["rolling_users"].([]interface{}); len(got) != 2 || got[0].([]interface{})[0] != "u1" {
+		t.Errorf("rolling_users = %v", got)
+	}
+
+	var types []string
+	for _, s := range exp.Files["escalation_policies"] {
+		types = append(types, s.(map[string]interface{})["type"].(string))
+	}
+	want := []string{"notify_on_call_from_schedule", "wait", "notify_persons", "repeat_escalation"}
+	if len(types) != len(want) {
+		t.Fatalf("steps = %v, want %v", types, want)
+	}
+	for i := range want {
+		if types[i] != want[i] {
+			t.Errorf("step %d = %s, want %s", i, types[i], want[i])
+		}
+	}
+
+	notes := map[string]string{}
+	for _, m := range exp.Mappings {
+		notes[m.SourceName] = m.Note
+	}
+	if notes["Primary / Weekly"] != "time restriction not converted" {
+		t.Errorf("rotation note = %q", notes["Primary / Weekly"])
+	}
+}
+
+func TestExportTo_UnknownFormat(t *testing.T) {
+	if _, err := ExportTo("victorops", nil, nil); err == nil {
+		t.Fatal("expected error for unknown format")
+	}
+}
@@ -0,0 +1,432 @@
+// Package migrate converts on-call configuration between OpsGenie and other
+// tools. Imports produce snapshot resources, so they are planned and
+// applied like any other local definitions; exports read snapshot
+// resources and produce the other tool's API objects.
+package migrate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/snapshot"
+)
+
+// User is an OpsGenie user. Users are not a snapshot kind, so they are
+// passed separately.
+type User struct {
+	ID       string `json:"id,omitempty"`
+	Username string `json:"username"`
+	FullName string `json:"fullName"`
+	Role     string `json:"role"`
+}
+
+// Mapping records what one source object became.
+type Mapping struct {
+	Source     string `json:"source"` // source object type, e.g. "escalation policy"
+	SourceID   string `json:"sourceId"`
+	SourceName string `json:"sourceName"`
+	// Kind, Parent and Name identify the resulting object: on import the
+	// OpsGenie resource ("users" for users), on export the collection it
+	// was written to. Kind is empty when the object was skipped.
+	Kind   string `json:"kind,omitempty"`
+	Parent string `json:"parent,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Note   string `json:"note,omitempty"`
+}
+
+// Import is the result of converting another tool's configuration.
+type Import struct {
+	Users     []User
+	Resources []snapshot.Resource
+	// Services hold the name of their owning team in Parent. OpsGenie
+	// services are top-level but need a teamId, which may not exist until
+	// Resources are applied; callers set it and clear Parent before
+	// planning.
+	Services []snapshot.Resource
+	Mappings []Mapping
+}
+
+// PagerDutyFiles are the files LoadPagerDuty reads from an export
+// directory. Each holds the objects of one PagerDuty REST API list
+// endpoint, either as a bare array or in the endpoint's envelope (e.g.
+// {"users": [...]}). Missing files are skipped.
+var PagerDutyFiles = []string{"users", "teams", "schedules", "escalation_policies", "services"}
+
+type pdRef struct {
+	ID      string `json:"id"`
+	Type    string `json:"type,omitempty"`
+	Summary string `json:"summary,omitempty"`
+}
+
+type pdUser struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+type pdTeam struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type pdSchedule struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	TimeZone    string    `json:"time_zone"`
+	Teams       []pdRef   `json:"teams"`
+	Layers      []pdLayer `json:"schedule_layers"`
+}
+
+type pdLayer struct {
+	Name              string `json:"name"`
+	Start             string `json:"start"`
+	End               string `json:"end"`
+	VirtualStart      string `json:"rotation_virtual_start"`
+	TurnLengthSeconds int    `json:"rotation_turn_length_seconds"`
+	Users             []struct {
+		User pdRef `json:"user"`
+	} `json:"users"`
+	Restrictions []pdRestriction `json:"restrictions"`
+}
+
+type pdRestriction struct {
+	Type            string `json:"type"` // daily_restriction or weekly_restriction
+	StartTimeOfDay  string `json:"start_time_of_day"`
+	DurationSeconds int    `json:"duration_seconds"`
+	StartDayOfWeek  int    `json:"start_day_of_week"` // 1 = Monday
+}
+
+type pdEscalationPolicy struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	NumLoops    int     `json:"num_loops"`
+	Teams       []pdRef `json:"teams"`
+	Rules       []struct {
+		DelayMinutes int     `json:"escalation_delay_in_minutes"`
+		Targets      []pdRef `json:"targets"`
+	} `json:"escalation_rules"`
+}
+
+type pdService struct {
+	ID               string  `json:"id"`
+	Name             string  `json:"name"`
+	Description      string  `json:"description"`
+	EscalationPolicy pdRef   `json:"escalation_policy"`
+	Teams            []pdRef `json:"teams"`
+}
+
+// LoadPagerDuty reads a PagerDuty export directory (see PagerDutyFiles)
+// and converts it:
+//
+//	users               -> users (email as username; admins and owners as Admin)
+//	teams               -> teams
+//	schedules           -> schedules, one rotation per layer
+//	escalation policies -> escalations, one rule per target
+//	services            -> services of their first team
+func LoadPagerDuty(dir string) (*Import, error) {
+	var (
+		users    []pdUser
+		teams    []pdTeam
+		scheds   []pdSchedule
+		policies []pdEscalationPolicy
+		services []pdService
+	)
+	found := 0
+	for _, f := range []struct {
+		name string
+		v    interface{}
+	}{
+		{"users", &users}, {"teams", &teams}, {"schedules", &scheds},
+		{"escalation_policies", &policies}, {"services", &services},
+	} {
+		ok, err := readPagerDutyFile(dir, f.name, f.v)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			found++
+		}
+	}
+	if found == 0 {
+		return nil, fmt.Errorf("no PagerDuty export files in %s (expected %s.json)", dir, strings.Join(PagerDutyFiles, ".json, "))
+	}
+
+	imp := &Import{}
+	usernames := map[string]string{}
+	for _, u := range users {
+		m := Mapping{Source: "user", SourceID: u.ID, SourceName: u.Name}
+		if u.Email == "" {
+			m.Note = "no email address to use as username"
+			imp.Mappings = append(imp.Mappings, m)
+			continue
+		}
+		role := "User"
+		switch u.Role {
+		case "admin", "owner", "account_owner":
+			role = "Admin"
+		}
+		usernames[u.ID] = u.Email
+		imp.Users = append(imp.Users, User{Username: u.Email, FullName: u.Name, Role: role})
+		m.Kind, m.Name = "users", u.Email
+		imp.Mappings = append(imp.Mappings, m)
+	}
+
+	teamNames := map[string]string{}
+	for _, t := range teams {
+		teamNames[t.ID] = t.Name
+		data := map[string]interface{}{"name": t.Name}
+		if t.Description != "" {
+			data["description"] = t.Description
+		}
+		imp.add(Mapping{Source: "team", SourceID: t.ID, SourceName: t.Name}, snapshot.Resource{Kind: "teams", Name: t.Name, Data: data})
+	}
+	owner := func(refs []pdRef) string {
+		if len(refs) == 0 {
+			return ""
+		}
+		return teamNames[refs[0].ID]
+	}
+
+	scheduleNames := map[string]string{}
+	for _, s := range scheds {
+		scheduleNames[s.ID] = s.Name
+		data := map[string]interface{}{"name": s.Name, "enabled": true}
+		if s.Description != "" {
+			data["description"] = s.Description
+		}
+		if s.TimeZone != "" {
+			data["timezone"] = s.TimeZone
+		}
+		if t := owner(s.Teams); t != "" {
+			data["ownerTeam"] = map[string]interface{}{"name": t}
+		}
+		imp.add(Mapping{Source: "schedule", SourceID: s.ID, SourceName: s.Name}, snapshot.Resource{Kind: "schedules", Name: s.Name, Data: data})
+
+		for i, l := range s.Layers {
+			name := l.Name
+			if name == "" {
+				name = "Layer " + strconv.Itoa(i+1)
+			}
+			rotation, note := layerRotation(l, name, usernames)
+			imp.add(Mapping{Source: "schedule layer", SourceID: s.ID, SourceName: s.Name + " / " + name, Note: note},
+				snapshot.Resource{Kind: "rotations", Parent: s.Name, Name: name, Data: rotation})
+		}
+	}
+
+	for _, p := range policies {
+		rules, note := escalationRules(p, usernames, scheduleNames)
+		data := map[string]interface{}{"name": p.Name, "rules": rules}
+		if p.Description != "" {
+			data["description"] = p.Description
+		}
+		if t := owner(p.Teams); t != "" {
+			data["ownerTeam"] = map[string]interface{}{"name": t}
+		}
+		if p.NumLoops > 0 {
+			data["repeat"] = map[string]interface{}{"waitInterval": 0, "count": p.NumLoops, "resetRecipientStates": false, "closeAlertAfterAll": false}
+		}
+		imp.add(Mapping{Source: "escalation policy", SourceID: p.ID, SourceName: p.Name, Note: note},
+			snapshot.Resource{Kind: "escalations", Name: p.Name, Data: data})
+	}
+
+	for _, s := range services {
+		m := Mapping{Source: "service", SourceID: s.ID, SourceName: s.Name}
+		team := owner(s.Teams)
+		if team == "" {
+			m.Note = "OpsGenie services need a team; the service has none"
+			imp.Mappings = append(imp.Mappings, m)
+			continue
+		}
+		data := map[string]interface{}{"name": s.Name}
+		if s.Description != "" {
+			data["description"] = s.Description
+		}
+		m.Kind, m.Parent, m.Name = "services", team, s.Name
+		if s.EscalationPolicy.ID != "" {
+			m.Note = "route to escalation " + strconv.Quote(s.EscalationPolicy.Summary) + " with a team routing rule"
+		}
+		imp.Mappings = append(imp.Mappings, m)
+		imp.Services = append(imp.Services, snapshot.Resource{Kind: "services", Parent: team, Name: s.Name, Data: data})
+	}
+	return imp, nil
+}
+
+func (imp *Import) add(m Mapping, r snapshot.Resource) {
+	m.Kind, m.Parent, m.Name = r.Kind, r.Parent, r.Name
+	imp.Mappings = append(imp.Mappings, m)
+	imp.Resources = append(imp.Resources, r)
+}
+
+// readPagerDutyFile decodes dir/name.json into v, unwrapping the list
+// envelope when present. It reports false when the file does not exist.
+func readPagerDutyFile(dir, name string, v interface{}) (bool, error) {
+	b, err := os.ReadFile(filepath.Join(dir, name+".json"))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	trimmed := strings.TrimSpace(string(b))
+	if strings.HasPrefix(trimmed, "{") {
+		var envelope map[string]json.RawMessage
+		if err := json.Unmarshal(b, &envelope); err != nil {
+			return false, fmt.Errorf("%s.json: %w", name, err)
+		}
+		list, ok := envelope[name]
+		if !ok {
+			return false, fmt.Errorf("%s.json: expected a %q list", name, name)
+		}
+		b = list
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return false, fmt.Errorf("%s.json: %w", name, err)
+	}
+	return true, nil
+}
+
+// layerRotation converts a schedule layer to rotation data. The note
+// explains anything that could not be carried over.
+func layerRotation(l pdLayer, name string, usernames map[string]string) (map[string]interface{}, string) {
+	var notes []string
+	typ, length := "hourly", l.TurnLengthSeconds/3600
+	switch {
+	case l.TurnLengthSeconds > 0 && l.TurnLengthSeconds%604800 == 0:
+		typ, length = "weekly", l.TurnLengthSeconds/604800
+	case l.TurnLengthSeconds > 0 && l.TurnLengthSeconds%86400 == 0:
+		typ, length = "daily", l.TurnLengthSeconds/86400
+	case l.TurnLengthSeconds%3600 != 0:
+		notes = append(notes, "turn length rounded to whole hours")
+	}
+	if length < 1 {
+		length = 1
+	}
+
+	start := l.VirtualStart
+	if start == "" {
+		start = l.Start
+	}
+	participants := []interface{}{}
+	for _, u := range l.Users {
+		username, ok := usernames[u.User.ID]
+		if !ok {
+			notes = append(notes, "unknown user "+u.User.ID+" left out")
+			continue
+		}
+		participants = append(participants, map[string]interface{}{"type": "user", "username": username})
+	}
+
+	data := map[string]interface{}{
+		"name":         name,
+		"type":         typ,
+		"length":       length,
+		"startDate":    start,
+		"participants": participants,
+	}
+	if l.End != "" {
+		data["endDate"] = l.End
+	}
+	if tr, note := timeRestriction(l.Restrictions); tr != nil {
+		data["timeRestriction"] = tr
+		if note != "" {
+			notes = append(notes, note)
+		}
+	}
+	return data, strings.Join(notes, "; ")
+}
+
+var pdWeekdays = []string{"", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+
+// timeRestriction converts layer restrictions. OpsGenie allows a single
+// daily window, so only the first daily restriction is kept.
+func timeRestriction(rs []pdRestriction) (map[string]interface{}, string) {
+	if len(rs) == 0 {
+		return nil, ""
+	}
+	clock := func(s string) (int, int) {
+		parts := strings.Split(s, ":")
+		h, _ := strconv.Atoi(parts[0])
+		m := 0
+		if len(parts) > 1 {
+			m, _ = strconv.Atoi(parts[1])
+		}
+		return h, m
+	}
+
+	if rs[0].Type == "daily_restriction" {
+		h, m := clock(rs[0].StartTimeOfDay)
+		end := (h*60 + m + rs[0].DurationSeconds/60) % (24 * 60)
+		note := ""
+		if len(rs) > 1 {
+			note = "only the first daily restriction kept"
+		}
+		return map[string]interface{}{
+			"type":        "time-of-day",
+			"restriction": map[string]interface{}{"startHour": h, "startMin": m, "endHour": end / 60, "endMin": end % 60},
+		}, note
+	}
+
+	var windows []interface{}
+	for _, r := range rs {
+		if r.Type != "weekly_restriction" || r.StartDayOfWeek < 1 || r.StartDayOfWeek > 7 {
+			continue
+		}
+		h, m := clock(r.StartTimeOfDay)
+		startMin := (r.StartDayOfWeek-1)*24*60 + h*60 + m
+		endMin := (startMin + r.DurationSeconds/60) % (7 * 24 * 60)
+		windows = append(windows, map[string]interface{}{
+			"startDay": pdWeekdays[r.StartDayOfWeek], "startHour": h, "startMin": m,
+			"endDay": pdWeekdays[endMin/(24*60)+1], "endHour": endMin % (24 * 60) / 60, "endMin": endMin % 60,
+		})
+	}
+	if len(windows) == 0 {
+		return nil, ""
+	}
+	return map[string]interface{}{"type": "weekday-and-time-of-day", "restrictions": windows}, ""
+}
+
+// escalationRules converts escalation rules to OpsGenie rules. PagerDuty
+// delays are relative to the previous rule, OpsGenie delays to the alert,
+// so they accumulate.
+func escalationRules(p pdEscalationPolicy, usernames, schedules map[string]string) ([]interface{}, string) {
+	var notes []string
+	rules := []interface{}{}
+	delay := 0
+	for _, r := range p.Rules {
+		for _, t := range r.Targets {
+			var recipient map[string]interface{}
+			switch t.Type {
+			case "user_reference", "user":
+				if u, ok := usernames[t.ID]; ok {
+					recipient = map[string]interface{}{"type": "user", "username": u}
+				}
+			case "schedule_reference", "schedule":
+				if s, ok := schedules[t.ID]; ok {
+					recipient = map[string]interface{}{"type": "schedule", "name": s}
+				}
+			}
+			if recipient == nil {
+				notes = append(notes, "unknown "+strings.TrimSuffix(t.Type, "_reference")+" "+t.ID+" left out")
+				continue
+			}
+			rules = append(rules, map[string]interface{}{
+				"condition":  "if-not-acked",
+				"notifyType": "default",
+				"delay":      map[string]interface{}{"timeAmount": delay, "timeUnit": "minutes"},
+				"recipient":  recipient,
+			})
+		}
+		delay += r.DelayMinutes
+	}
+	sort.Strings(notes)
+	return rules, strings.Join(notes, "; ")
+}
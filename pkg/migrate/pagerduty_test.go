@@ -0,0 +1,113 @@
+package migrate
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writePagerDuty(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, body := range files {
+		if err := os.WriteFile(filepath.Join(dir, name+".json"), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestLoadPagerDuty(t *testing.T) {
+	dir := writePagerDuty(t, map[string]string{
+		"users": `{"users": [
+			{"id": "PU1", "name": "Alice", "email": "alice@example.com", "role": "admin"},
+			{"id": "PU2", "name": "Bob", "email": "bob@example.com", "role": "user"},
+			{"id": "PU3", "name": "No Mail"}
+		]}`,
+		"teams": `[{"id": "PT1", "name": "Platform"}]`,
+		"schedules": `[{"id": "PS1", "name": "Primary", "time_zone": "Europe/Berlin", "teams": [{"id": "PT1"}],
+			"schedule_layers": [{"name": "Weekly", "rotation_virtual_start": "2024-01-01T09:00:00Z", "rotation_turn_length_seconds": 604800,
+				"users": [{"user": {"id": "PU1"}}, {"user": {"id": "PU9"}}],
+				"restrictions": [{"type": "daily_restriction", "start_time_of_day": "09:00:00", "duration_seconds": 28800}]}]}]`,
+		"escalation_policies": `[{"id": "PE1", "name": "Platform EP", "num_loops": 2, "teams": [{"id": "PT1"}],
+			"escalation_rules": [
+				{"escalation_delay_in_minutes": 10, "targets": [{"id": "PS1", "type": "schedule_reference"}]},
+				{"escalation_delay_in_minutes": 15, "targets": [{"id": "PU2", "type": "user_reference"}]}
+			]}]`,
+		"services": `[{"id": "PSV1", "name": "api", "teams": [{"id": "PT1"}], "escalation_policy": {"id": "PE1", "summary": "Platform EP"}},
+			{"id": "PSV2", "name": "orphan"}]`,
+	})
+	imp, err := LoadPagerDuty(dir)
+	if err != nil {
+		t.Fatalf("LoadPagerDuty: %v", err)
+	}
+
+	wantUsers := []User{
+		{Username: "alice@example.com", FullName: "Alice", Role: "Admin"},
+		{Username: "bob@example.com", FullName: "Bob", Role: "User"},
+	}
+	if !reflect.DeepEqual(imp.Users, wantUsers) {
+		t.Errorf("users = %+v", imp.Users)
+	}
+
+	byKind := map[string]map[string]interface{}{}
+	for _, r := range imp.Resources {
+		byKind[r.Kind] = r.Data
+	}
+	if byKind["schedules"]["timezone"] != "Europe/Berlin" {
+		t.Errorf("schedule = %v", byKind["schedules"])
+	}
+	rot := byKind["rotations"]
+	if rot["type"] != "weekly" || rot["length"] != 1 || len(rot["participants"].([]interface{})) != 1 {
+		t.Errorf("rotation = %v", rot)
+	}
+	tr := rot["timeRestriction"].(map[string]interface{})["restriction"].(map[string]interface{})
+	if tr["startHour"] != 9 || tr["endHour"] != 17 {
+		t.Errorf("time restriction = %v", tr)
+	}
+
+	rules := byKind["escalations"]["rules"].([]interface{})
+	if len(rules) != 2 {
+		t.Fatalf("rules = %v", rules)
+	}
+	second := rules[1].(map[string]interface{})
+	if second["delay"].(map[string]interface{})["timeAmount"] != 10 {
+		t.Errorf("second rule delay = %v, want cumulative 10", second["delay"])
+	}
+	if first := rules[0].(map[string]interface{})["recipient"].(map[string]interface{}); first["name"] != "Primary" {
+		t.Errorf("first recipient = %v", first)
+	}
+
+	if len(imp.Services) != 1 || imp.Services[0].Parent != "Platform" {
+		t.Errorf("services = %+v", imp.Services)
+	}
+
+	notes := map[string]string{}
+	for _, m := range imp.Mappings {
+		notes[m.SourceName] = m.Note
+	}
+	for name, want := range map[string]string{
+		"No Mail":          "no email address to use as username",
+		"Primary / Weekly": "unknown user PU9 left out",
+		"orphan":           "OpsGenie services need a team; the service has none",
+	} {
+		if notes[name] != want {
+			t.Errorf("note for %s = %q, want %q", name, notes[name], want)
+		}
+	}
+}
+
+func TestLoadPagerDuty_Empty(t *testing.T) {
+	if _, err := LoadPagerDuty(t.TempDir()); err == nil {
+		t.Fatal("expected error for a directory without export files")
+	}
+}
+
+func TestTimeRestriction_Weekly(t *testing.T) {
+	tr, _ := timeRestriction([]pdRestriction{{Type: "weekly_restriction", StartDayOfWeek: 5, StartTimeOfDay: "18:00:00", DurationSeconds: 3 * 24 * 3600}})
+	w := tr["restrictions"].([]interface{})[0].(map[string]interface{})
+	if w["startDay"] != "friday" || w["endDay"] != "monday" || w["endHour"] != 18 {
+		t.Errorf("window = %v", w)
+	}
+}
@@ -0,0 +1,317 @@
+// Package mockserver serves canned OpsGenie API responses from fixture
+// files, for developing and testing scripts against the CLI offline.
+//
+// A fixture is a JSON file holding a response body, named after the request
+// path it answers:
+//
+//	v2/alerts.json               GET /v2/alerts
+//	v2/alerts/alert-id-123.json  GET /v2/alerts/alert-id-123
+//	v2/alerts/_.json             GET /v2/alerts/<any id>
+//	v2/alerts.POST.json          POST /v2/alerts
+//
+// A path segment named "_" matches any single segment; exact segments win
+// over wildcards. Files without a method suffix answer GET. A top-level
+// "_status" key sets the HTTP status (default 200) and is not sent.
+// Query strings are ignored.
+//
+// Scripts (see LoadScripts) take precedence over fixtures and play a
+// sequence of responses for an endpoint, e.g. two 429s and then success.
+package mockserver
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:embed all:fixtures
+var builtin embed.FS
+
+// statusKey names the fixture field holding the response status.
+const statusKey = "_status"
+
+// Options configures a Server.
+type Options struct {
+	// Fixtures, if set, holds fixture files that take precedence over the
+	// built-in ones.
+	Fixtures fs.FS
+	// Latency delays every response. If LatencyMax is greater, each
+	// delay is picked at random between the two.
+	Latency    time.Duration
+	LatencyMax time.Duration
+	// FailRate is the fraction of requests (0 to 1) answered with an
+	// injected FailStatus error instead of their fixture.
+	FailRate float64
+	// FailStatus is the status of injected failures (default 500). 429
+	// failures carry the headers OpsGenie sends when throttling.
+	FailStatus int
+	// Scripts play scripted responses for matching requests.
+	Scripts []Script
+	// Log, if set, receives one line per request.
+	Log io.Writer
+}
+
+type fixture struct {
+	status int
+	body   []byte
+}
+
+// Server is an http.Handler answering OpsGenie API requests from fixtures.
+type Server struct {
+	opts     Options
+	fixtures map[string]fixture // "METHOD /path/with/_" -> response
+	rand     func() float64
+
+	mu    sync.Mutex
+	plays []int // responses played per script
+}
+
+// New loads the built-in fixtures, overlays opts.Fixtures and returns a
+// server.
+func New(opts Options) (*Server, error) {
+	if opts.FailRate < 0 || opts.FailRate > 1 {
+		return nil, fmt.Errorf("fail rate must be between 0 and 1, got %v", opts.FailRate)
+	}
+	if opts.FailStatus == 0 {
+		opts.FailStatus = http.StatusInternalServerError
+	}
+	if opts.FailStatus < 400 || opts.FailStatus > 599 {
+		return nil, fmt.Errorf("fail status must be an HTTP error status, got %d", opts.FailStatus)
+	}
+	if opts.LatencyMax != 0 && opts.LatencyMax < opts.Latency {
+		return nil, fmt.Errorf("maximum latency %s is below minimum %s", opts.LatencyMax, opts.Latency)
+	}
+	opts.Scripts = append([]Script(nil), opts.Scripts...)
+	for i := range opts.Scripts {
+		if err := opts.Scripts[i].validate(); err != nil {
+			return nil, fmt.Errorf("script %d: %w", i+1, err)
+		}
+	}
+	s := &Server{opts: opts, fixtures: map[string]fixture{}, rand: rand.Float64, plays: make([]int, len(opts.Scripts))}
+	sub, err := fs.Sub(builtin, "fixtures")
+	if err != nil {
+		return nil, err
+	}
+	if err := s.load(sub); err != nil {
+		return nil, fmt.Errorf("built-in fixtures: %w", err)
+	}
+	if opts.Fixtures != nil {
+		if err := s.load(opts.Fixtures); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// load reads every .json file in fsys, replacing fixtures already loaded
+// for the same method and path.
+func (s *Server) load(fsys fs.FS) error {
+	return fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || path.Ext(p) != ".json" {
+			return err
+		}
+		b, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+		f, err := parseFixture(b)
+		if err != nil {
+			return fmt.Errorf("%s: %w", p, err)
+		}
+		s.fixtures[fixtureKey(p)] = f
+		return nil
+	})
+}
+
+// fixtureKey turns a fixture file path into "METHOD /path".
+func fixtureKey(p string) string {
+	p = strings.TrimSuffix(p, ".json")
+	method := http.MethodGet
+	if i := strings.LastIndex(p, "."); i > strings.LastIndex(p, "/") {
+		if m := strings.ToUpper(p[i+1:]); isMethod(m) {
+			method, p = m, p[:i]
+		}
+	}
+	return method + " /" + p
+}
+
+func isMethod(m string) bool {
+	switch m {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func parseFixture(b []byte) (fixture, error) {
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(b, &body); err != nil {
+		return fixture{}, err
+	}
+	f := fixture{status: http.StatusOK, body: b}
+	if raw, ok := body[statusKey]; ok {
+		if err := json.Unmarshal(raw, &f.status); err != nil {
+			return fixture{}, fmt.Errorf("%s: %w", statusKey, err)
+		}
+		delete(body, statusKey)
+		out, err := json.Marshal(body)
+		if err != nil {
+			return fixture{}, err
+		}
+		f.body = out
+	}
+	return f, nil
+}
+
+// match returns the fixture for method and urlPath, preferring the pattern
+// with the most exact segments, leftmost first.
+func (s *Server) match(method, urlPath string) (fixture, bool) {
+	var best fixture
+	bestScore := -1
+	for key, f := range s.fixtures {
+		m, pattern, _ := strings.Cut(key, " ")
+		if m != method {
+			continue
+		}
+		if score := matchScore(pattern, urlPath); score > bestScore {
+			best, bestScore = f, score
+		}
+	}
+	return best, bestScore >= 0
+}
+
+// matchScore scores how well urlPath matches pattern, in which a "_"
+// segment matches any single segment. Each exact segment adds to the
+// score, leftmost ones most; -1 means no match.
+func matchScore(pattern, urlPath string) int {
+	segs := strings.Split(strings.Trim(urlPath, "/"), "/")
+	pSegs := strings.Split(strings.Trim(pattern, "/"), "/")
+	if len(pSegs) != len(segs) {
+		return -1
+	}
+	score := 0
+	for i, ps := range pSegs {
+		score <<= 1
+		switch ps {
+		case segs[i]:
+			score |= 1
+		case "_":
+		default:
+			return -1
+		}
+	}
+	return score
+}
+
+// ServeHTTP answers r from the matching fixture. Writes without a fixture
+// succeed and echo the request body as data; reads without one are 404.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	status, body := s.respond(w.Header(), r)
+	if s.opts.Log != nil {
+		fmt.Fprintf(s.opts.Log, "%s %s %d\n", r.Method, r.URL.RequestURI(), status)
+	}
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+// RoundTrip answers r in-process, so the server can be an http.Client's
+// transport and serve the CLI without listening on a port. The request's
+// host is ignored.
+func (s *Server) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.Body == nil {
+		r = r.Clone(r.Context())
+		r.Body = http.NoBody
+	}
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, r)
+	resp := rec.Result()
+	resp.Request = r
+	return resp, nil
+}
+
+func (s *Server) respond(h http.Header, r *http.Request) (int, []byte) {
+	scripted, ok := s.nextScripted(r)
+	delay := s.latency()
+	if ok && scripted.Latency != "" {
+		delay = scripted.latency
+	}
+	if delay > 0 {
+		t := time.NewTimer(delay)
+		select {
+		case <-r.Context().Done():
+			t.Stop()
+		case <-t.C:
+		}
+	}
+
+	if ok {
+		for k, v := range scripted.Headers {
+			h.Set(k, v)
+		}
+		if scripted.Status >= 400 && len(scripted.Body) == 0 {
+			return errorBody(scripted.Status, http.StatusText(scripted.Status))
+		}
+		if len(scripted.Body) > 0 {
+			if scripted.Status == 0 {
+				return http.StatusOK, scripted.Body
+			}
+			return scripted.Status, scripted.Body
+		}
+		// No body: answer from the fixtures, with the scripted status.
+		status, body := s.fixtureResponse(r)
+		if scripted.Status != 0 {
+			status = scripted.Status
+		}
+		return status, body
+	}
+
+	if s.opts.FailRate > 0 && s.rand() < s.opts.FailRate {
+		if s.opts.FailStatus == http.StatusTooManyRequests {
+			h.Set("X-RateLimit-State", "THROTTLED")
+			h.Set("Retry-After", "1")
+		}
+		return errorBody(s.opts.FailStatus, "Injected failure")
+	}
+	return s.fixtureResponse(r)
+}
+
+// latency returns the delay for a response without a scripted one.
+func (s *Server) latency() time.Duration {
+	if s.opts.LatencyMax <= s.opts.Latency {
+		return s.opts.Latency
+	}
+	spread := s.opts.LatencyMax - s.opts.Latency
+	return s.opts.Latency + time.Duration(s.rand()*float64(spread))
+}
+
+// fixtureResponse answers r from the fixtures alone.
+func (s *Server) fixtureResponse(r *http.Request) (int, []byte) {
+	if f, ok := s.match(r.Method, r.URL.Path); ok {
+		return f.status, f.body
+	}
+	if r.Method == http.MethodGet {
+		return errorBody(http.StatusNotFound, "No fixture for "+r.URL.Path)
+	}
+
+	var data interface{} = map[string]interface{}{}
+	if b, err := io.ReadAll(r.Body); err == nil && len(b) > 0 {
+		_ = json.Unmarshal(b, &data)
+	}
+	b, _ := json.Marshal(map[string]interface{}{"result": "Request processed", "took": 0.01, "data": data})
+	return http.StatusOK, b
+}
+
+func errorBody(status int, msg string) (int, []byte) {
+	b, _ := json.Marshal(map[string]interface{}{"message": msg, "took": 0.01})
+	return status, b
+}
@@ -0,0 +1,262 @@
+package mockserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func get(t *testing.T, s *Server, method, path, body string) (int, map[string]interface{}) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+	var out map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &out); err != nil {
+		t.Fatalf("%s %s: invalid JSON %q: %v", method, path, rec.Body.String(), err)
+	}
+	return rec.Code, out
+}
+
+func dataID(body map[string]interface{}) string {
+	d, _ := body["data"].(map[string]interface{})
+	id, _ := d["id"].(string)
+	return id
+}
+
+func TestBuiltinFixtures(t *testing.T) {
+	s, err := New(Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	code, body := get(t, s, http.MethodGet, "/v2/alerts?limit=20", "")
+	if code != http.StatusOK {
+		t.Fatalf("list status = %d", code)
+	}
+	if alerts, _ := body["data"].([]interface{}); len(alerts) == 0 {
+		t.Errorf("expected alerts, got %v", body)
+	}
+
+	// Wildcard segment matches any ID; the exact "count" path wins over it.
+	if _, body := get(t, s, http.MethodGet, "/v2/alerts/anything", ""); dataID(body) != "alert-id-123" {
+		t.Errorf("wildcard get = %v", body)
+	}
+	if _, body := get(t, s, http.MethodGet, "/v2/alerts/count", ""); body["data"].(map[string]interface{})["count"] == nil {
+		t.Errorf("count = %v", body)
+	}
+
+	code, body = get(t, s, http.MethodPost, "/v2/alerts/alert-id-123/acknowledge", "{}")
+	if code != http.StatusAccepted || body["requestId"] == nil || body[statusKey] != nil {
+		t.Errorf("acknowledge = %d %v", code, body)
+	}
+}
+
+func TestUserFixturesOverride(t *testing.T) {
+	fsys := fstest.MapFS{
+		"v2/teams/_.json":             {Data: []byte(`{"data": {"id": "custom"}}`)},
+		"v2/teams/missing.json":       {Data: []byte(`{"_status": 404, "message": "Team not found"}`)},
+		"v2/widgets.PATCH.json":       {Data: []byte(`{"result": "patched"}`)},
+		"v2/heartbeats/db.check.json": {Data: []byte(`{"data": {"name": "db.check"}}`)},
+	}
+	s, err := New(Options{Fixtures: fsys})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, body := get(t, s, http.MethodGet, "/v2/teams/team-id-456", ""); dataID(body) != "custom" {
+		t.Errorf("override = %v", body)
+	}
+	if code, body := get(t, s, http.MethodGet, "/v2/teams/missing", ""); code != http.StatusNotFound || body["message"] != "Team not found" {
+		t.Errorf("status fixture = %d %v", code, body)
+	}
+	if _, body := get(t, s, http.MethodPatch, "/v2/widgets", "{}"); body["result"] != "patched" {
+		t.Errorf("method fixture = %v", body)
+	}
+	if _, body := get(t, s, http.MethodGet, "/v2/heartbeats/db.check", ""); body["data"].(map[string]interface{})["name"] != "db.check" {
+		t.Errorf("dotted name = %v", body)
+	}
+}
+
+func TestFallbacks(t *testing.T) {
+	s, err := New(Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code, _ := get(t, s, http.MethodGet, "/v2/nothing-here", ""); code != http.StatusNotFound {
+		t.Errorf("unknown GET status = %d", code)
+	}
+	code, body := get(t, s, http.MethodPost, "/v2/teams", `{"name": "New"}`)
+	if code != http.StatusOK || body["data"].(map[string]interface{})["name"] != "New" {
+		t.Errorf("write echo = %d %v", code, body)
+	}
+}
+
+func TestFailRateAndLatency(t *testing.T) {
+	if _, err := New(Options{FailRate: 2}); err == nil {
+		t.Error("expected error for fail rate above 1")
+	}
+
+	s, err := New(Options{FailRate: 1, Latency: 20 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var log strings.Builder
+	s.opts.Log = &log
+	start := time.Now()
+	code, body := get(t, s, http.MethodGet, "/v2/teams", "")
+	if code != http.StatusInternalServerError || body["message"] != "Injected failure" {
+		t.Errorf("injected failure = %d %v", code, body)
+	}
+	if time.Since(start) < 20*time.Millisecond {
+		t.Error("latency not applied")
+	}
+	if log.String() != "GET /v2/teams 500\n" {
+		t.Errorf("log = %q", log.String())
+	}
+}
+
+func TestServeOverHTTP(t *testing.T) {
+	s, err := New(Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+	resp, err := http.Get(ts.URL + "/v2/account")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	b, _ := io.ReadAll(resp.Body)
+	if resp.Header.Get("Content-Type") != "application/json" || !strings.Contains(string(b), `"name": "mock"`) {
+		t.Errorf("account = %s %q", resp.Header.Get("Content-Type"), b)
+	}
+}
+
+func TestScripts(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "script.yaml")
+	script := `
+- path: /v2/alerts
+  method: get
+  responses:
+    - status: 429
+      headers: {Retry-After: "1"}
+    - status: 200
+      body: {data: [{id: scripted}]}
+    - status: 201
+- path: /v2/teams/_
+  responses:
+    - status: 503
+      latency: 1ms
+`
+	if err := os.WriteFile(file, []byte(script), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	scripts, err := LoadScripts(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := New(Options{Scripts: scripts})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v2/alerts", nil))
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") != "1" {
+		t.Errorf("first = %d %v", rec.Code, rec.Header())
+	}
+	if _, body := get(t, s, http.MethodGet, "/v2/alerts", ""); !strings.Contains(fmt.Sprint(body), "scripted") {
+		t.Errorf("second = %v", body)
+	}
+	// The last response repeats and, without a body, uses the fixture.
+	for i := 0; i < 2; i++ {
+		code, body := get(t, s, http.MethodGet, "/v2/alerts", "")
+		if code != http.StatusCreated || body["data"] == nil {
+			t.Errorf("repeat %d = %d %v", i, code, body)
+		}
+	}
+	// Other methods fall through to fixtures.
+	if code, _ := get(t, s, http.MethodPost, "/v2/alerts", "{}"); code != http.StatusAccepted {
+		t.Errorf("POST = %d", code)
+	}
+	if code, body := get(t, s, http.MethodGet, "/v2/teams/x", ""); code != http.StatusServiceUnavailable || body["message"] != "Service Unavailable" {
+		t.Errorf("wildcard script = %d %v", code, body)
+	}
+}
+
+func TestLoadScripts_Invalid(t *testing.T) {
+	for _, script := range []string{
+		`[{path: v2/alerts, responses: [{status: 200}]}]`,
+		`[{path: /v2/alerts}]`,
+		`[{path: /v2/alerts, method: FETCH, responses: [{status: 200}]}]`,
+		`[{path: /v2/alerts, responses: [{status: 999}]}]`,
+		`[{path: /v2/alerts, responses: [{latency: soon}]}]`,
+	} {
+		file := filepath.Join(t.TempDir(), "script.yaml")
+		if err := os.WriteFile(file, []byte(script), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadScripts(file); err == nil {
+			t.Errorf("expected error for %s", script)
+		}
+	}
+}
+
+func TestFailStatusAndLatencyRange(t *testing.T) {
+	if _, err := New(Options{FailStatus: 200}); err == nil {
+		t.Error("expected error for non-error fail status")
+	}
+	if _, err := New(Options{Latency: time.Second, LatencyMax: time.Millisecond}); err == nil {
+		t.Error("expected error for inverted latency range")
+	}
+
+	s, err := New(Options{FailRate: 1, FailStatus: http.StatusTooManyRequests, Latency: time.Millisecond, LatencyMax: 5 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.rand = func() float64 { return 0.5 }
+	if d := s.latency(); d != 3*time.Millisecond {
+		t.Errorf("latency = %s, want 3ms", d)
+	}
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v2/alerts", nil))
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("X-RateLimit-State") != "THROTTLED" {
+		t.Errorf("injected 429 = %d %v", rec.Code, rec.Header())
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	s, err := New(Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: s}
+	resp, err := client.Get("https://api.opsgenie.com/v2/account")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	b, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(b), `"name": "mock"`) {
+		t.Errorf("account = %d %q", resp.StatusCode, b)
+	}
+
+	resp, err = client.Post("https://api.opsgenie.com/v2/teams", "application/json", strings.NewReader(`{"name":"ops"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	b, _ = io.ReadAll(resp.Body)
+	if !strings.Contains(string(b), `"name":"ops"`) {
+		t.Errorf("echoed write = %q", b)
+	}
+}
@@ -0,0 +1,117 @@
+package mockserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Script plays a sequence of responses for requests matching Method (any
+// when empty) and Path, which may use "_" segments like fixture names. Each
+// matching request gets the next response; the last one repeats.
+type Script struct {
+	Method    string             `json:"method"`
+	Path      string             `json:"path"`
+	Responses []ScriptedResponse `json:"responses"`
+}
+
+// ScriptedResponse is one step of a Script. A response without a body uses
+// the fixture for the request (error statuses get a generic error body).
+type ScriptedResponse struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty"`
+	// Latency overrides the server's latency for this response, e.g. "2s".
+	Latency string `json:"latency,omitempty"`
+
+	latency time.Duration
+}
+
+// LoadScripts reads a JSON or YAML list of scripts, e.g.
+//
+//   - path: /v2/alerts
+//     responses:
+//   - status: 429
+//     headers: {Retry-After: "1"}
+//   - status: 200
+func LoadScripts(file string) ([]Script, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var raw interface{}
+	if err := yaml.Unmarshal(b, &raw); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	j, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	var scripts []Script
+	if err := json.Unmarshal(j, &scripts); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	for i := range scripts {
+		if err := scripts[i].validate(); err != nil {
+			return nil, fmt.Errorf("%s: script %d: %w", file, i+1, err)
+		}
+	}
+	return scripts, nil
+}
+
+func (sc *Script) validate() error {
+	sc.Method = strings.ToUpper(sc.Method)
+	if sc.Method != "" && !isMethod(sc.Method) {
+		return fmt.Errorf("unknown method %q", sc.Method)
+	}
+	if !strings.HasPrefix(sc.Path, "/") {
+		return fmt.Errorf("path %q must start with /", sc.Path)
+	}
+	if len(sc.Responses) == 0 {
+		return fmt.Errorf("%s has no responses", sc.Path)
+	}
+	for i := range sc.Responses {
+		resp := &sc.Responses[i]
+		if resp.Status != 0 && http.StatusText(resp.Status) == "" {
+			return fmt.Errorf("response %d: unknown status %d", i+1, resp.Status)
+		}
+		if resp.Latency != "" {
+			d, err := time.ParseDuration(resp.Latency)
+			if err != nil {
+				return fmt.Errorf("response %d: %w", i+1, err)
+			}
+			resp.latency = d
+		}
+	}
+	return nil
+}
+
+// nextScripted returns the next scripted response for r from the best
+// matching script, advancing that script.
+func (s *Server) nextScripted(r *http.Request) (ScriptedResponse, bool) {
+	best, bestScore := -1, -1
+	for i, sc := range s.opts.Scripts {
+		if sc.Method != "" && sc.Method != r.Method {
+			continue
+		}
+		if score := matchScore(sc.Path, r.URL.Path); score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	if best < 0 {
+		return ScriptedResponse{}, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sc := s.opts.Scripts[best]
+	n := s.plays[best]
+	if n < len(sc.Responses)-1 {
+		s.plays[best]++
+	}
+	return sc.Responses[n], true
+}
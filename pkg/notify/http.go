@@ -0,0 +1,86 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const httpTimeout = 15 * time.Second
+
+// Slack posts the message text to a Slack incoming webhook.
+type Slack struct {
+	URL        string
+	httpClient *http.Client
+}
+
+// NewSlack returns a notifier for the given Slack incoming webhook URL.
+func NewSlack(webhookURL string) *Slack {
+	return &Slack{URL: webhookURL, httpClient: &http.Client{Timeout: httpTimeout}}
+}
+
+// Notify implements Notifier.
+func (s *Slack) Notify(msg Message) error {
+	text := msg.Text
+	if text == "" {
+		text = msg.Subject
+	}
+	return postJSON(s.httpClient, s.URL, map[string]string{"text": text})
+}
+
+func (s *Slack) String() string { return "slack:" + redactURL(s.URL) }
+
+// Webhook posts the full message as JSON to an arbitrary URL.
+type Webhook struct {
+	URL        string
+	httpClient *http.Client
+}
+
+// NewWebhook returns a notifier that POSTs messages to webhookURL.
+func NewWebhook(webhookURL string) *Webhook {
+	return &Webhook{URL: webhookURL, httpClient: &http.Client{Timeout: httpTimeout}}
+}
+
+// Notify implements Notifier.
+func (w *Webhook) Notify(msg Message) error {
+	return postJSON(w.httpClient, w.URL, msg)
+}
+
+func (w *Webhook) String() string { return "webhook:" + redactURL(w.URL) }
+
+func postJSON(client *http.Client, target string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	resp, err := client.Post(target, "application/json", bytes.NewReader(body))
+	if err != nil {
+		// A *url.Error quotes the full URL, secret path included.
+		var uerr *url.Error
+		if errors.As(err, &uerr) {
+			return fmt.Errorf("%s %s: %w", uerr.Op, redactURL(target), uerr.Err)
+		}
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, bytes.TrimSpace(respBody))
+	}
+	return nil
+}
+
+// redactURL keeps only the scheme and host, since webhook paths usually
+// embed the secret token.
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return "(invalid URL)"
+	}
+	return u.Scheme + "://" + u.Host + "/…"
+}
@@ -0,0 +1,30 @@
+package notify
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// Stdout writes the message text to a writer, os.Stdout by default. It is
+// useful for previewing a report or piping it into another tool.
+type Stdout struct {
+	W io.Writer
+}
+
+// NewStdout returns a notifier that writes to os.Stdout.
+func NewStdout() *Stdout {
+	return &Stdout{W: os.Stdout}
+}
+
+// Notify implements Notifier.
+func (s *Stdout) Notify(msg Message) error {
+	text := msg.Text
+	if len(text) > 0 && text[len(text)-1] != '\n' {
+		text += "\n"
+	}
+	_, err := fmt.Fprint(s.W, text)
+	return err
+}
+
+func (s *Stdout) String() string { return "stdout" }
@@ -0,0 +1,144 @@
+package output
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/fatih/color"
+)
+
+// defaultCellColors are the colors of table cells, by lower-case column
+// header and then lower-case cell value, so urgent alerts stand out.
+var defaultCellColors = map[string]map[string]string{
+	"priority": {"p1": "red+bold", "p2": "red", "p3": "yellow"},
+	"status":   {"open": "yellow", "unacked": "yellow+bold", "closed": "green", "resolved": "green"},
+}
+
+// cellColors are the colors in use: the defaults with SetCellColors'
+// overrides applied.
+var cellColors = defaultCellColors
+
+// cellColorSource loads the color overrides the first time a table is
+// colored; see SetCellColorSource.
+var (
+	cellColorSource func() (map[string]string, error)
+	cellColorsOnce  sync.Once
+)
+
+// colorAttributes are the words a color spec is made of.
+var colorAttributes = map[string]color.Attribute{
+	"black":     color.FgBlack,
+	"red":       color.FgRed,
+	"green":     color.FgGreen,
+	"yellow":    color.FgYellow,
+	"blue":      color.FgBlue,
+	"magenta":   color.FgMagenta,
+	"cyan":      color.FgCyan,
+	"white":     color.FgWhite,
+	"bold":      color.Bold,
+	"faint":     color.Faint,
+	"underline": color.Underline,
+}
+
+// parseColor parses a color spec: attribute words joined by "+", such as
+// "red+bold". "none" is no color and returns nil.
+func parseColor(spec string) (*color.Color, error) {
+	spec = strings.ToLower(strings.TrimSpace(spec))
+	if spec == "none" || spec == "" {
+		return nil, nil
+	}
+	var attrs []color.Attribute
+	for _, word := range strings.Split(spec, "+") {
+		a, ok := colorAttributes[strings.TrimSpace(word)]
+		if !ok {
+			return nil, fmt.Errorf("unknown color %q (use none, or red, green, yellow, blue, magenta, cyan, white or black, optionally +bold, +faint or +underline)", word)
+		}
+		attrs = append(attrs, a)
+	}
+	return color.New(attrs...), nil
+}
+
+// SetCellColors overrides the colors of table cells. Keys are
+// "column.value", matched ignoring case (e.g. "priority.P1" or
+// "status.open"); values are color specs like "magenta+bold", or "none" to
+// leave such cells plain.
+func SetCellColors(overrides map[string]string) error {
+	merged := map[string]map[string]string{}
+	for col, values := range defaultCellColors {
+		merged[col] = map[string]string{}
+		for v, spec := range values {
+			merged[col][v] = spec
+		}
+	}
+	for key, spec := range overrides {
+		col, value, ok := strings.Cut(strings.ToLower(key), ".")
+		if !ok || col == "" || value == "" {
+			return fmt.Errorf("color key %q is not column.value, e.g. priority.P1", key)
+		}
+		if _, err := parseColor(spec); err != nil {
+			return fmt.Errorf("color for %s: %w", key, err)
+		}
+		if merged[col] == nil {
+			merged[col] = map[string]string{}
+		}
+		merged[col][value] = spec
+	}
+	cellColors = merged
+	return nil
+}
+
+// SetCellColorSource makes the first colored table load its color
+// overrides from load, so commands that never color a table never read
+// them. Overrides that cannot be loaded or are invalid are logged as a
+// warning and tables are left uncolored.
+func SetCellColorSource(load func() (map[string]string, error)) {
+	cellColorSource = load
+	cellColorsOnce = sync.Once{}
+}
+
+// loadCellColors applies the overrides from cellColorSource, once.
+func loadCellColors() {
+	cellColorsOnce.Do(func() {
+		if cellColorSource == nil {
+			return
+		}
+		overrides, err := cellColorSource()
+		if err == nil {
+			err = SetCellColors(overrides)
+		}
+		if err != nil {
+			slog.Warn("table colors disabled", "err", err)
+			cellColors = map[string]map[string]string{}
+		}
+	})
+}
+
+// colorCells returns rows with the cells of colored columns wrapped in
+// their colors. rows itself is not modified.
+func colorCells(headers []string, rows [][]string) [][]string {
+	loadCellColors()
+	cols := map[int]map[string]string{}
+	for i, h := range headers {
+		if values, ok := cellColors[strings.ToLower(h)]; ok {
+			cols[i] = values
+		}
+	}
+	if len(cols) == 0 {
+		return rows
+	}
+	out := make([][]string, len(rows))
+	for r, row := range rows {
+		out[r] = append([]string(nil), row...)
+		for i, values := range cols {
+			if i >= len(row) {
+				continue
+			}
+			if c, _ := parseColor(values[strings.ToLower(row[i])]); c != nil {
+				out[r][i] = c.Sprint(row[i])
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,94 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+)
+
+func TestColorCells(t *testing.T) {
+	old := color.NoColor
+	color.NoColor = false
+	t.Cleanup(func() {
+		color.NoColor = old
+		cellColors = defaultCellColors
+	})
+
+	headers := []string{"ID", "Priority", "Status"}
+	rows := [][]string{{"a1", "P1", "open"}, {"a2", "P5", "closed"}}
+	got := colorCells(headers, rows)
+	if want := color.New(color.FgRed, color.Bold).Sprint("P1"); got[0][1] != want {
+		t.Errorf("P1 = %q, want %q", got[0][1], want)
+	}
+	if got[1][1] != "P5" || got[0][0] != "a1" {
+		t.Errorf("uncolored cells changed: %q", got)
+	}
+	if !strings.Contains(got[0][2], "\x1b[") || rows[0][2] != "open" {
+		t.Errorf("status = %q, input = %q", got[0][2], rows[0][2])
+	}
+
+	if err := SetCellColors(map[string]string{"Priority.p5": "blue", "status.open": "none"}); err != nil {
+		t.Fatal(err)
+	}
+	got = colorCells(headers, rows)
+	if want := color.New(color.FgBlue).Sprint("P5"); got[1][1] != want {
+		t.Errorf("P5 = %q, want %q", got[1][1], want)
+	}
+	if got[0][2] != "open" {
+		t.Errorf("open = %q, want no color", got[0][2])
+	}
+	if defaultCellColors["status"]["open"] != "yellow" {
+		t.Error("SetCellColors changed the defaults")
+	}
+
+	for _, bad := range []map[string]string{{"priority": "red"}, {"priority.P1": "purple"}} {
+		if err := SetCellColors(bad); err == nil {
+			t.Errorf("SetCellColors(%v) succeeded", bad)
+		}
+	}
+}
+
+func TestCellColorSource(t *testing.T) {
+	old, oldLog := color.NoColor, slog.Default()
+	color.NoColor = false
+	t.Cleanup(func() {
+		color.NoColor = old
+		slog.SetDefault(oldLog)
+		SetCellColorSource(nil)
+		cellColors = defaultCellColors
+	})
+	var logs bytes.Buffer
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+
+	loads := 0
+	SetCellColorSource(func() (map[string]string, error) {
+		loads++
+		return map[string]string{"priority.P5": "blue"}, nil
+	})
+	if loads != 0 {
+		t.Fatal("colors loaded before a table was colored")
+	}
+	headers, rows := []string{"Priority"}, [][]string{{"P5"}}
+	colorCells(headers, rows)
+	if got := colorCells(headers, rows); got[0][0] != color.New(color.FgBlue).Sprint("P5") || loads != 1 {
+		t.Errorf("P5 = %q after %d load(s), want blue after 1", got[0][0], loads)
+	}
+
+	for _, load := range []func() (map[string]string, error){
+		func() (map[string]string, error) { return nil, errors.New("invalid character 'b'") },
+		func() (map[string]string, error) { return map[string]string{"priority.P1": "purple"}, nil },
+	} {
+		logs.Reset()
+		SetCellColorSource(load)
+		if got := colorCells([]string{"Priority"}, [][]string{{"P1"}}); got[0][0] != "P1" {
+			t.Errorf("P1 = %q, want no color when the overrides are bad", got[0][0])
+		}
+		if !strings.Contains(logs.String(), "table colors disabled") {
+			t.Errorf("no warning logged: %q", logs.String())
+		}
+	}
+}
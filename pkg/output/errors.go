@@ -0,0 +1,154 @@
+package output
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+)
+
+// Error codes of ErrorInfo.
+const (
+	CodeError        = "ERROR"
+	CodeValidation   = "VALIDATION"
+	CodeUnauthorized = "UNAUTHORIZED"
+	CodeForbidden    = "FORBIDDEN"
+	CodeNotFound     = "NOT_FOUND"
+	CodeConflict     = "CONFLICT"
+	CodeRateLimited  = "RATE_LIMITED"
+	CodeServer       = "SERVER_ERROR"
+	CodeTimeout      = "TIMEOUT"
+	CodeNetwork      = "NETWORK"
+	CodeAsyncFailed  = "ASYNC_FAILED"
+)
+
+// ErrorInfo is the machine-readable form of an error. In JSON and NDJSON
+// modes errors are written to stderr as one ErrorInfo per line, so scripts
+// can tell a bad request from one worth retrying: Recoverable is set for
+// rate limiting, server errors, timeouts and network failures, and
+// RetryAfter is the wait in seconds the API asked for, if any. HTTPStatus
+// and RequestID are set for errors returned by the API.
+type ErrorInfo struct {
+	Code        string `json:"code"`
+	Message     string `json:"message"`
+	HTTPStatus  int    `json:"httpStatus,omitempty"`
+	RequestID   string `json:"requestId,omitempty"`
+	Recoverable bool   `json:"recoverable"`
+	RetryAfter  int    `json:"retryAfter,omitempty"`
+}
+
+// ValidationError marks an error in the user's input, found before any
+// request was sent. Its ErrorInfo code is VALIDATION.
+type ValidationError struct {
+	Err error
+}
+
+func (e *ValidationError) Error() string { return e.Err.Error() }
+func (e *ValidationError) Unwrap() error { return e.Err }
+
+// Invalid wraps err as a *ValidationError. It returns nil for nil.
+func Invalid(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &ValidationError{Err: err}
+}
+
+// Describe classifies err for machine-readable output. An error with an
+// ErrorCode() string method chooses its own code.
+func Describe(err error) ErrorInfo {
+	info := ErrorInfo{Code: CodeError, Message: err.Error()}
+	var (
+		coded      interface{ ErrorCode() string }
+		validation *ValidationError
+		apiErr     *api.ErrorResponse
+		statusErr  *api.StatusError
+		asyncErr   *api.AsyncError
+		netErr     net.Error
+		retryAfter float64
+	)
+	switch {
+	case errors.As(err, &coded):
+		info.Code = coded.ErrorCode()
+	case errors.As(err, &validation):
+		info.Code = CodeValidation
+	case errors.As(err, &apiErr):
+		info.HTTPStatus, info.RequestID = apiErr.Code, apiErr.RequestID
+		retryAfter = apiErr.RetryAfter.Seconds()
+	case errors.As(err, &statusErr):
+		info.HTTPStatus = statusErr.Code
+		retryAfter = statusErr.RetryAfter.Seconds()
+	case errors.As(err, &asyncErr):
+		info.RequestID = asyncErr.RequestID
+		info.Code = CodeAsyncFailed
+		if asyncErr.Status == "" {
+			info.Code, info.Recoverable = CodeTimeout, true
+		}
+	case errors.Is(err, context.DeadlineExceeded):
+		info.Code, info.Recoverable = CodeTimeout, true
+	case errors.As(err, &netErr):
+		info.Code, info.Recoverable = CodeNetwork, true
+	}
+	if info.HTTPStatus != 0 {
+		info.Code, info.Recoverable = statusCode(info.HTTPStatus)
+	}
+	info.RetryAfter = int(math.Ceil(retryAfter))
+	return info
+}
+
+// statusCode returns the error code for an HTTP status, and whether
+// retrying the request later may succeed.
+func statusCode(status int) (string, bool) {
+	switch {
+	case status == http.StatusBadRequest || status == http.StatusUnprocessableEntity:
+		return CodeValidation, false
+	case status == http.StatusUnauthorized:
+		return CodeUnauthorized, false
+	case status == http.StatusForbidden:
+		return CodeForbidden, false
+	case status == http.StatusNotFound:
+		return CodeNotFound, false
+	case status == http.StatusConflict:
+		return CodeConflict, false
+	case status == http.StatusTooManyRequests:
+		return CodeRateLimited, true
+	case status >= 500:
+		return CodeServer, true
+	}
+	return CodeError, false
+}
+
+// Error writes an error message to stderr: in red (unless NoColor is set),
+// or as an ErrorInfo line with code ERROR in JSON and NDJSON modes.
+func Error(msg string, opts ...Options) {
+	writeError(ErrorInfo{Code: CodeError, Message: msg}, opts)
+}
+
+// ErrorFrom writes err to stderr like Error, with the code, HTTP status,
+// request ID and retry advice Describe finds in JSON and NDJSON modes.
+func ErrorFrom(err error, opts ...Options) {
+	writeError(Describe(err), opts)
+}
+
+func writeError(info ErrorInfo, opts []Options) {
+	var o Options
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	switch {
+	case o.Mode == ModeJSON || o.Mode == ModeNDJSON:
+		line, _ := json.Marshal(info)
+		fmt.Fprintln(os.Stderr, string(line))
+	case !colorAllowed(o.NoColor):
+		fmt.Fprintf(os.Stderr, "Error: %s\n", info.Message)
+	default:
+		fmt.Fprintf(os.Stderr, "%s %s\n", color.New(color.FgRed).Sprint("Error:"), info.Message)
+	}
+}
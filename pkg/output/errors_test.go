@@ -0,0 +1,72 @@
+package output
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+)
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want ErrorInfo
+	}{
+		{"plain", errors.New("boom"), ErrorInfo{Code: CodeError, Message: "boom"}},
+		{"validation", Invalid(errors.New("bad priority")), ErrorInfo{Code: CodeValidation, Message: "bad priority"}},
+		{"api 422", &api.ErrorResponse{Code: 422, Message: "Message can not be empty.", RequestID: "r1"},
+			ErrorInfo{Code: CodeValidation, Message: "OpsGenie API error 422: Message can not be empty.", HTTPStatus: 422, RequestID: "r1"}},
+		{"api 404 wrapped", fmt.Errorf("get alert: %w", &api.ErrorResponse{Code: 404, Message: "not found", RequestID: "r2"}),
+			ErrorInfo{Code: CodeNotFound, Message: "get alert: OpsGenie API error 404: not found", HTTPStatus: 404, RequestID: "r2"}},
+		{"rate limited", fmt.Errorf("exceeded 3 retries: %w", &api.ErrorResponse{Code: 429, Message: "slow down", RetryAfter: 1500 * time.Millisecond}),
+			ErrorInfo{Code: CodeRateLimited, Message: "exceeded 3 retries: OpsGenie API error 429: slow down", HTTPStatus: 429, Recoverable: true, RetryAfter: 2}},
+		{"server", &api.StatusError{Code: 503, Body: "unavailable"},
+			ErrorInfo{Code: CodeServer, Message: "API error (status 503): unavailable", HTTPStatus: 503, Recoverable: true}},
+		{"async timeout", &api.AsyncError{RequestID: "req-9", Timeout: 30 * time.Second},
+			ErrorInfo{Code: CodeTimeout, Message: "timed out waiting for async request req-9 after 30s", RequestID: "req-9", Recoverable: true}},
+		{"async failed", &api.AsyncError{RequestID: "req-9", Status: "failed"},
+			ErrorInfo{Code: CodeAsyncFailed, Message: "async request req-9 failed", RequestID: "req-9"}},
+		{"deadline", fmt.Errorf("request: %w", context.DeadlineExceeded),
+			ErrorInfo{Code: CodeTimeout, Message: "request: context deadline exceeded", Recoverable: true}},
+		{"network", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")},
+			ErrorInfo{Code: CodeNetwork, Message: "dial tcp: connection refused", Recoverable: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Describe(tt.err); got != tt.want {
+				t.Errorf("Describe = %+v\nwant       %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorFrom_JSON(t *testing.T) {
+	out, err := captureStderr(func() {
+		ErrorFrom(&api.ErrorResponse{Code: 429, Message: "slow down", RequestID: "r1", RetryAfter: 10 * time.Second}, Options{Mode: ModeJSON})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"code":"RATE_LIMITED","message":"OpsGenie API error 429: slow down","httpStatus":429,"requestId":"r1","recoverable":true,"retryAfter":10}` + "\n"
+	if out != want {
+		t.Errorf("got  %s\nwant %s", out, want)
+	}
+}
+
+func TestError_JSONMode(t *testing.T) {
+	out, err := captureStderr(func() {
+		Error("skipping schedule s1", Options{Mode: ModeNDJSON})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"code":"ERROR","message":"skipping schedule s1","recoverable":false}` + "\n"
+	if out != want {
+		t.Errorf("got  %s\nwant %s", out, want)
+	}
+}
@@ -0,0 +1,78 @@
+package output
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// nonInteractive is set by --non-interactive (or OPSGENIE_NON_INTERACTIVE).
+// It is the one switch every interactive behaviour consults: prompts, the
+// pager, screen redraws and color.
+var nonInteractive bool
+
+// SetNonInteractive turns off all interactive behaviour for the rest of the
+// process.
+func SetNonInteractive(v bool) {
+	nonInteractive = v
+}
+
+// NonInteractive reports whether interactive behaviour has been turned off.
+func NonInteractive() bool {
+	return nonInteractive
+}
+
+// Interactive reports whether output may be shaped for a person at a
+// terminal: paged, redrawn in place or colored. It is false when
+// interactivity is off or stdout is not a terminal.
+func Interactive() bool {
+	return !nonInteractive && isTerminal(os.Stdout)
+}
+
+// stdinIsTerminal reports whether stdin is a terminal; tests replace it.
+var stdinIsTerminal = func() bool { return isTerminal(os.Stdin) }
+
+// RequireInteractive returns an error naming feature when interactivity is
+// off or stdin is not a terminal. Commands call it before prompting, so a
+// run in CI or a pipeline fails instead of hanging or reading end of input
+// as an answer.
+func RequireInteractive(feature string) error {
+	if nonInteractive {
+		return fmt.Errorf("%s needs an interactive session; not available with --non-interactive", feature)
+	}
+	if !stdinIsTerminal() {
+		return fmt.Errorf("%s needs an interactive session; stdin is not a terminal", feature)
+	}
+	return nil
+}
+
+// Confirm asks a yes/no question on w and reads the answer from in.
+// Anything but "y" or "yes", including end of input, means no. It fails
+// without asking when the session is not interactive (see
+// RequireInteractive).
+func Confirm(in *bufio.Reader, w io.Writer, question string) (bool, error) {
+	if err := RequireInteractive("prompting"); err != nil {
+		return false, err
+	}
+	fmt.Fprintf(w, "%s [y/N] ", question)
+	answer, err := in.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+	if err == io.EOF {
+		fmt.Fprintln(w)
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	}
+	return false, nil
+}
+
+// colorAllowed reports whether color may be used at all: not disabled by
+// the caller, NO_COLOR or --non-interactive.
+func colorAllowed(noColor bool) bool {
+	return !noColor && !nonInteractive && os.Getenv("NO_COLOR") == ""
+}
@@ -0,0 +1,67 @@
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// withTerminalStdin makes stdin count as a terminal for the test.
+func withTerminalStdin(t *testing.T, terminal bool) {
+	t.Helper()
+	orig := stdinIsTerminal
+	stdinIsTerminal = func() bool { return terminal }
+	t.Cleanup(func() { stdinIsTerminal = orig })
+}
+
+func TestNonInteractive(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	withTerminalStdin(t, true)
+	if err := RequireInteractive("prompting"); err != nil {
+		t.Fatalf("RequireInteractive: %v", err)
+	}
+	if !colorAllowed(false) {
+		t.Error("color should be allowed by default")
+	}
+
+	SetNonInteractive(true)
+	defer SetNonInteractive(false)
+	if err := RequireInteractive("prompting"); err == nil {
+		t.Error("expected an error when non-interactive")
+	}
+	if Interactive() || colorAllowed(false) {
+		t.Error("non-interactive must disable paging, redraws and color")
+	}
+}
+
+func TestRequireInteractive_StdinNotTerminal(t *testing.T) {
+	withTerminalStdin(t, false)
+	err := RequireInteractive("prompting")
+	if err == nil || !strings.Contains(err.Error(), "stdin is not a terminal") {
+		t.Errorf("err = %v, want stdin is not a terminal", err)
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	withTerminalStdin(t, true)
+	for answer, want := range map[string]bool{"y\n": true, "YES\n": true, "n\n": false, "": false, "maybe\n": false} {
+		var w bytes.Buffer
+		got, err := Confirm(bufio.NewReader(strings.NewReader(answer)), &w, "Delete?")
+		if err != nil {
+			t.Fatalf("%q: %v", answer, err)
+		}
+		if got != want {
+			t.Errorf("%q: got %v, want %v", answer, got, want)
+		}
+		if !strings.HasPrefix(w.String(), "Delete? [y/N] ") {
+			t.Errorf("%q: prompt = %q", answer, w.String())
+		}
+	}
+
+	withTerminalStdin(t, false)
+	var w bytes.Buffer
+	if _, err := Confirm(bufio.NewReader(strings.NewReader("y\n")), &w, "Delete?"); err == nil || w.Len() != 0 {
+		t.Errorf("err = %v, prompt = %q; want an error without asking", err, w.String())
+	}
+}
@@ -0,0 +1,50 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// RenderJSONLines writes data as newline-delimited JSON: each element of an
+// array on its own compact line, or a single line for any other value.
+// --fields and --jq apply to each element separately, so a list can be
+// written page by page as it is fetched.
+func RenderJSONLines(data interface{}, opts Options) error {
+	return renderJSONLinesTo(os.Stdout, data, opts)
+}
+
+func renderJSONLinesTo(w io.Writer, data interface{}, opts Options) error {
+	normalized, err := toJSONValue(data)
+	if err != nil {
+		return fmt.Errorf("normalize for json lines: %w", err)
+	}
+	items, ok := normalized.([]interface{})
+	if !ok {
+		items = []interface{}{normalized}
+	}
+
+	for _, item := range items {
+		if len(opts.Fields) > 0 {
+			item = applyFieldFilter(item, opts.Fields)
+		}
+		if opts.JQExpr != "" {
+			item, err = applyJQ(item, opts.JQExpr)
+			if err != nil {
+				return fmt.Errorf("jq expression: %w", err)
+			}
+			if item == nil {
+				continue
+			}
+		}
+		line, err := json.Marshal(item)
+		if err != nil {
+			return fmt.Errorf("json marshal: %w", err)
+		}
+		if _, err := fmt.Fprintln(w, string(line)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
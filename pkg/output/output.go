@@ -1,6 +1,7 @@
 package output
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/olekukonko/tablewriter"
+	"go.yaml.in/yaml/v3"
 )
 
 // Mode represents the output rendering mode.
@@ -18,43 +20,136 @@ const (
 	ModeTable     Mode = iota // Default: colored table
 	ModePlaintext             // Tab-separated, no colors
 	ModeJSON                  // Pretty-printed JSON
+	ModeYAML                  // YAML, same data as JSON
+	ModeCSV                   // RFC 4180 CSV with a header row
+	ModeTSV                   // Tab-separated, quoted like CSV where needed
+	ModeID                    // Only IDs, one per line
+	ModeNDJSON                // Newline-delimited JSON, one compact object per line
 )
 
+// modeNames maps --output values to modes.
+var modeNames = map[string]Mode{
+	"table":     ModeTable,
+	"plaintext": ModePlaintext,
+	"json":      ModeJSON,
+	"yaml":      ModeYAML,
+	"csv":       ModeCSV,
+	"tsv":       ModeTSV,
+	"id":        ModeID,
+	"ndjson":    ModeNDJSON,
+}
+
+// ParseMode returns the mode for an --output value.
+func ParseMode(s string) (Mode, error) {
+	if m, ok := modeNames[strings.ToLower(s)]; ok {
+		return m, nil
+	}
+	return ModeTable, fmt.Errorf("unknown output format %q (use table, plaintext, json, yaml, csv, tsv, id or ndjson)", s)
+}
+
 // Options controls output rendering behavior.
 type Options struct {
 	Mode    Mode
 	NoColor bool
 	Debug   bool
-	Quiet   bool     // If set, suppress progress/success messages to stderr
-	Fields  []string // If set, filter JSON output to only these fields
+	Quiet   bool     // If set, suppress progress/success messages to stderr (--quiet also picks ModeID)
+	Fields  []string // If set, filter JSON output to these fields, or select table columns
 	JQExpr  string   // If set, apply this jq expression to JSON output
+	// Template, if set, renders each item through a Go text/template
+	// instead of any other format (after --fields and --jq).
+	Template string
+	// Columns, if set, selects table, CSV and TSV columns like Fields
+	// without filtering structured output; it wins over Fields there.
+	Columns []string
+	// SortBy, if set, sorts table rows (and list rawData) by this column
+	// or rawData key, descending when Desc is set.
+	SortBy string
+	Desc   bool
+	// Wide, if set, shows table cells in full instead of truncating them
+	// to fit the terminal.
+	Wide bool
+}
+
+// tableColumns returns the columns selected for tabular output.
+func (o Options) tableColumns() []string {
+	if len(o.Columns) > 0 {
+		return o.Columns
+	}
+	return o.Fields
+}
+
+// Structured reports whether output is a data document (JSON, YAML or
+// NDJSON) rather than a human-oriented table.
+func (o Options) Structured() bool {
+	return o.Mode == ModeJSON || o.Mode == ModeYAML || o.Mode == ModeNDJSON
 }
 
 // RenderTable renders data in the appropriate output mode.
-// headers and rows are used for table/plaintext modes; rawData is used for JSON mode.
-// If --jq or --fields is specified, JSON mode is implicitly enabled.
+// headers and rows are used for table/plaintext/CSV/TSV modes; rawData is used for JSON mode.
+// If --jq is specified, JSON mode is implicitly enabled (except in CSV/TSV
+// mode, where it is an error). In the tabular modes --fields (or --columns)
+// selects columns by header name or rawData key. --sort-by orders the rows
+// and rawData in every mode.
 func RenderTable(headers []string, rows [][]string, rawData interface{}, opts Options) error {
-	// Implicitly enable JSON mode when --jq or --fields is used
-	if opts.JQExpr != "" || len(opts.Fields) > 0 {
+	if opts.SortBy != "" {
+		var err error
+		if rows, rawData, err = sortRows(headers, rows, rawData, opts.SortBy, opts.Desc); err != nil {
+			return err
+		}
+	}
+	if opts.Template != "" {
+		return RenderJSON(rawData, opts)
+	}
+	if opts.Mode == ModeID && rawData == nil {
+		return renderIDColumn(os.Stdout, headers, rows)
+	}
+	if opts.Mode == ModeID {
+		return RenderJSON(rawData, opts)
+	}
+	if opts.Mode == ModeCSV || opts.Mode == ModeTSV {
+		if opts.JQExpr != "" {
+			return fmt.Errorf("--jq cannot be combined with csv or tsv output")
+		}
+		comma := ','
+		if opts.Mode == ModeTSV {
+			comma = '\t'
+		}
+		headers, rows, err := selectColumns(headers, rows, rawData, opts.tableColumns())
+		if err != nil {
+			return err
+		}
+		return renderDelimited(os.Stdout, comma, headers, rows)
+	}
+	// Implicitly enable JSON mode when --jq is used
+	if opts.JQExpr != "" && opts.Mode != ModeYAML && opts.Mode != ModeNDJSON {
 		opts.Mode = ModeJSON
 	}
-	switch opts.Mode {
-	case ModeJSON:
+	if opts.Structured() {
 		return RenderJSON(rawData, opts)
-	case ModePlaintext:
+	}
+	headers, rows, err := selectColumns(headers, rows, rawData, opts.tableColumns())
+	if err != nil {
+		return err
+	}
+	if opts.Mode == ModePlaintext {
 		return renderPlaintext(os.Stdout, headers, rows)
-	default:
-		return renderTable(os.Stdout, headers, rows, opts)
 	}
+	return renderTable(os.Stdout, headers, rows, opts)
 }
 
 // RenderJSON outputs data as JSON with optional fields filtering and jq evaluation.
+// In ModeYAML the filtered result is written as YAML instead, and in
+// ModeNDJSON as one line per array element (see RenderJSONLines).
 func RenderJSON(data interface{}, opts Options) error {
 	return renderJSONTo(os.Stdout, data, opts)
 }
 
 // renderJSONTo writes JSON output to w, applying fields filtering and jq expressions.
 func renderJSONTo(w io.Writer, data interface{}, opts Options) error {
+	if opts.Mode == ModeNDJSON && opts.Template == "" {
+		return renderJSONLinesTo(w, data, opts)
+	}
+
 	// Apply fields filtering if specified
 	if len(opts.Fields) > 0 {
 		filtered, err := filterFields(data, opts.Fields)
@@ -73,6 +168,16 @@ func renderJSONTo(w io.Writer, data interface{}, opts Options) error {
 		data = result
 	}
 
+	if opts.Template != "" {
+		return renderTemplate(w, data, opts.Template)
+	}
+	if opts.Mode == ModeYAML {
+		return renderYAMLTo(w, data)
+	}
+	if opts.Mode == ModeID {
+		return renderIDsTo(w, data)
+	}
+
 	out, err := json.MarshalIndent(data, "", "  ")
 	if err != nil {
 		return fmt.Errorf("json marshal: %w", err)
@@ -81,6 +186,21 @@ func renderJSONTo(w io.Writer, data interface{}, opts Options) error {
 	return err
 }
 
+// renderYAMLTo writes data as YAML. Data is normalized through JSON first so
+// field names follow the same json tags as JSON output.
+func renderYAMLTo(w io.Writer, data interface{}) error {
+	normalized, err := toJSONValue(data)
+	if err != nil {
+		return fmt.Errorf("normalize for yaml: %w", err)
+	}
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(normalized); err != nil {
+		return fmt.Errorf("yaml marshal: %w", err)
+	}
+	return enc.Close()
+}
+
 func renderPlaintext(w io.Writer, headers []string, rows [][]string) error {
 	if len(headers) > 0 {
 		fmt.Fprintln(w, strings.Join(headers, "\t"))
@@ -91,10 +211,214 @@ func renderPlaintext(w io.Writer, headers []string, rows [][]string) error {
 	return nil
 }
 
+// renderIDsTo writes the "id" of each item in data (or of data itself when
+// it is a single object), one per line. Items without an id fall back to
+// their name, as heartbeats are identified by name.
+func renderIDsTo(w io.Writer, data interface{}) error {
+	normalized, err := toJSONValue(data)
+	if err != nil {
+		return fmt.Errorf("normalize for id output: %w", err)
+	}
+	items, ok := normalized.([]interface{})
+	if !ok {
+		items = []interface{}{normalized}
+	}
+	for _, it := range items {
+		var id string
+		switch v := it.(type) {
+		case map[string]interface{}:
+			id = cellString(v["id"])
+			if id == "" {
+				id = cellString(v["name"])
+			}
+		case string:
+			id = v
+		}
+		if id != "" {
+			fmt.Fprintln(w, id)
+		}
+	}
+	return nil
+}
+
+// renderIDColumn writes the ID column of a table, for callers without raw
+// data.
+func renderIDColumn(w io.Writer, headers []string, rows [][]string) error {
+	j := indexFold(headers, "ID")
+	if j < 0 {
+		return fmt.Errorf("no ID column to print")
+	}
+	for _, row := range rows {
+		if j < len(row) && row[j] != "" {
+			fmt.Fprintln(w, row[j])
+		}
+	}
+	return nil
+}
+
+// renderDelimited writes headers and rows as CSV (RFC 4180) using comma as
+// the separator. Fields containing the separator, quotes or newlines are
+// quoted.
+func renderDelimited(w io.Writer, comma rune, headers []string, rows [][]string) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	if err := cw.Write(headers); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// selectColumns keeps the columns named by fields, in the order given.
+// Fields match headers case-insensitively; a field that is not a header is
+// read from the matching key of each rawData item instead, so any JSON
+// field can be shown as a column. Key/value tables (headers "Field",
+// "Value") select rows rather than columns. All columns are kept when
+// fields is empty.
+func selectColumns(headers []string, rows [][]string, rawData interface{}, fields []string) ([]string, [][]string, error) {
+	if len(fields) == 0 {
+		return headers, rows, nil
+	}
+	raw, _ := toJSONValue(rawData)
+	if len(headers) == 2 && headers[0] == "Field" && headers[1] == "Value" {
+		return selectFieldRows(headers, rows, raw, fields)
+	}
+
+	items, _ := raw.([]interface{})
+	if len(items) != len(rows) {
+		items = nil
+	}
+	selHeaders := make([]string, len(fields))
+	cols := make([]func(i int, row []string) string, len(fields))
+	for n, f := range fields {
+		if j := indexFold(headers, f); j >= 0 {
+			selHeaders[n] = headers[j]
+			cols[n] = func(_ int, row []string) string {
+				if j < len(row) {
+					return row[j]
+				}
+				return ""
+			}
+			continue
+		}
+		key, ok := itemsKey(items, f)
+		if !ok {
+			return nil, nil, fmt.Errorf("unknown column %q (available: %s)", f, strings.Join(headers, ", "))
+		}
+		selHeaders[n] = key
+		cols[n] = func(i int, _ []string) string {
+			m, _ := items[i].(map[string]interface{})
+			return cellString(m[key])
+		}
+	}
+
+	selected := make([][]string, len(rows))
+	for i, row := range rows {
+		out := make([]string, len(cols))
+		for n, col := range cols {
+			out[n] = col(i, row)
+		}
+		selected[i] = out
+	}
+	return selHeaders, selected, nil
+}
+
+// selectFieldRows is selectColumns for key/value tables: it keeps the rows
+// named by fields, falling back to keys of the raw object.
+func selectFieldRows(headers []string, rows [][]string, raw interface{}, fields []string) ([]string, [][]string, error) {
+	obj, _ := raw.(map[string]interface{})
+	names := make([]string, len(rows))
+	for i, row := range rows {
+		if len(row) > 0 {
+			names[i] = row[0]
+		}
+	}
+	selected := make([][]string, 0, len(fields))
+	for _, f := range fields {
+		if i := indexFold(names, f); i >= 0 {
+			selected = append(selected, rows[i])
+			continue
+		}
+		key, ok := mapKeyFold(obj, f)
+		if !ok {
+			return nil, nil, fmt.Errorf("unknown field %q (available: %s)", f, strings.Join(names, ", "))
+		}
+		selected = append(selected, []string{key, cellString(obj[key])})
+	}
+	return headers, selected, nil
+}
+
+// itemsKey returns the key matching f case-insensitively in the first item
+// that has it.
+func itemsKey(items []interface{}, f string) (string, bool) {
+	for _, it := range items {
+		if m, ok := it.(map[string]interface{}); ok {
+			if key, ok := mapKeyFold(m, f); ok {
+				return key, true
+			}
+		}
+	}
+	return "", false
+}
+
+func mapKeyFold(m map[string]interface{}, f string) (string, bool) {
+	if _, ok := m[f]; ok {
+		return f, true
+	}
+	for k := range m {
+		if strings.EqualFold(k, f) {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+func indexFold(list []string, s string) int {
+	for i, v := range list {
+		if strings.EqualFold(v, s) {
+			return i
+		}
+	}
+	return -1
+}
+
+// cellString formats a JSON value for a table cell: strings as-is, lists of
+// scalars comma-separated, anything else as compact JSON.
+func cellString(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case []interface{}:
+		parts := make([]string, len(t))
+		for i, e := range t {
+			switch e.(type) {
+			case map[string]interface{}, []interface{}:
+				b, _ := json.Marshal(t)
+				return string(b)
+			}
+			parts[i] = cellString(e)
+		}
+		return strings.Join(parts, ", ")
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
 func renderTable(w io.Writer, headers []string, rows [][]string, opts Options) error {
+	if !opts.Wide {
+		rows = fitColumns(headers, rows, terminalWidth())
+	}
 	table := tablewriter.NewWriter(w)
 
 	if !opts.NoColor && shouldColor() {
+		rows = colorCells(headers, rows)
 		colored := make([]string, len(headers))
 		for i, h := range headers {
 			colored[i] = color.New(color.FgCyan, color.Bold).Sprint(h)
@@ -124,27 +448,7 @@ func renderTable(w io.Writer, headers []string, rows [][]string, opts Options) e
 }
 
 func shouldColor() bool {
-	if os.Getenv("NO_COLOR") != "" {
-		return false
-	}
-	fi, err := os.Stdout.Stat()
-	if err != nil {
-		return false
-	}
-	return fi.Mode()&os.ModeCharDevice != 0
-}
-
-// Error outputs an error message to stderr in red (unless NoColor is set).
-func Error(msg string, opts ...Options) {
-	noColor := false
-	if len(opts) > 0 {
-		noColor = opts[0].NoColor
-	}
-	if noColor || os.Getenv("NO_COLOR") != "" {
-		fmt.Fprintf(os.Stderr, "Error: %s\n", msg)
-	} else {
-		fmt.Fprintf(os.Stderr, "%s %s\n", color.New(color.FgRed).Sprint("Error:"), msg)
-	}
+	return colorAllowed(false) && isTerminal(os.Stdout)
 }
 
 // Success outputs a success message to stderr in green (unless NoColor or Quiet is set).
@@ -158,7 +462,7 @@ func Success(msg string, opts ...Options) {
 	if quiet {
 		return
 	}
-	if noColor || os.Getenv("NO_COLOR") != "" {
+	if !colorAllowed(noColor) {
 		fmt.Fprintf(os.Stderr, "OK: %s\n", msg)
 	} else {
 		fmt.Fprintf(os.Stderr, "%s %s\n", color.New(color.FgGreen).Sprint("OK:"), msg)
@@ -843,5 +843,221 @@ func TestApplyJQ_MultipleOutputs(t *testing.T) {
 	}
 }
 
+// --- YAML mode ---
+
+func TestRenderTable_YAMLModeKeepsFieldFilter(t *testing.T) {
+	type item struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+		Note string `json:"note"`
+	}
+	opts := Options{Mode: ModeYAML, Fields: []string{"id", "name"}}
+	out, err := captureStdout(func() {
+		if err := RenderTable([]string{"ID"}, [][]string{{"1"}}, []item{{"1", "alpha", "x"}}, opts); err != nil {
+			t.Errorf("RenderTable error: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "- id: \"1\"\n  name: alpha\n" {
+		t.Errorf("unexpected YAML:\n%s", out)
+	}
+}
+
+func TestRenderJSON_YAMLModeWithJQ(t *testing.T) {
+	var buf bytes.Buffer
+	opts := Options{Mode: ModeYAML, JQExpr: ".name"}
+	if err := renderJSONTo(&buf, map[string]interface{}{"name": "alpha"}, opts); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "alpha\n" {
+		t.Errorf("unexpected YAML: %q", buf.String())
+	}
+}
+
+// --- CSV / TSV modes ---
+
+func TestRenderTable_CSVQuoting(t *testing.T) {
+	headers := []string{"ID", "Message"}
+	rows := [][]string{{"1", "disk full, \"sda\""}, {"2", "line1\nline2"}}
+	out, err := captureStdout(func() {
+		if err := RenderTable(headers, rows, nil, Options{Mode: ModeCSV}); err != nil {
+			t.Errorf("RenderTable error: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "ID,Message\n1,\"disk full, \"\"sda\"\"\"\n2,\"line1\nline2\"\n"
+	if out != want {
+		t.Errorf("CSV =\n%q\nwant\n%q", out, want)
+	}
+}
+
+func TestRenderTable_TSVWithFields(t *testing.T) {
+	headers := []string{"ID", "Message", "Status"}
+	rows := [][]string{{"1", "a\tb", "open"}}
+	out, err := captureStdout(func() {
+		if err := RenderTable(headers, rows, nil, Options{Mode: ModeTSV, Fields: []string{"status", "message"}}); err != nil {
+			t.Errorf("RenderTable error: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "Status\tMessage\nopen\t\"a\tb\"\n" {
+		t.Errorf("TSV = %q", out)
+	}
+}
+
+func TestRenderTable_CSVErrors(t *testing.T) {
+	if err := RenderTable([]string{"ID"}, nil, nil, Options{Mode: ModeCSV, Fields: []string{"nope"}}); err == nil {
+		t.Error("expected unknown column error")
+	}
+	if err := RenderTable([]string{"ID"}, nil, nil, Options{Mode: ModeCSV, JQExpr: "."}); err == nil {
+		t.Error("expected --jq error")
+	}
+}
+
+func TestRenderTable_PlaintextWithFields(t *testing.T) {
+	headers := []string{"ID", "Message"}
+	rows := [][]string{{"1", "disk full"}, {"2", "cpu"}}
+	raw := []map[string]interface{}{
+		{"id": "1", "message": "disk full", "tinyId": "7", "tags": []string{"db", "prod"}},
+		{"id": "2", "message": "cpu", "tinyId": "8"},
+	}
+	out, err := captureStdout(func() {
+		if err := RenderTable(headers, rows, raw, Options{Mode: ModePlaintext, Fields: []string{"tinyid", "id", "tags"}}); err != nil {
+			t.Errorf("RenderTable error: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "tinyId\tID\ttags\n7\t1\tdb, prod\n8\t2\t\n" {
+		t.Errorf("plaintext = %q", out)
+	}
+}
+
+func TestRenderTable_KeyValueWithFields(t *testing.T) {
+	headers := []string{"Field", "Value"}
+	rows := [][]string{{"ID", "1"}, {"Message", "disk full"}, {"Status", "open"}}
+	raw := map[string]interface{}{"id": "1", "message": "disk full", "status": "open", "count": 3}
+	out, err := captureStdout(func() {
+		if err := RenderTable(headers, rows, raw, Options{Mode: ModePlaintext, Fields: []string{"status", "count"}}); err != nil {
+			t.Errorf("RenderTable error: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "Field\tValue\nStatus\topen\ncount\t3\n" {
+		t.Errorf("plaintext = %q", out)
+	}
+}
+
+func TestRenderTable_TableWithUnknownField(t *testing.T) {
+	err := RenderTable([]string{"ID"}, [][]string{{"1"}}, []map[string]string{{"id": "1"}}, Options{Mode: ModeTable, Fields: []string{"nope"}})
+	if err == nil || !strings.Contains(err.Error(), "unknown column") {
+		t.Errorf("expected unknown column error, got %v", err)
+	}
+}
+
+func TestRenderTable_IDMode(t *testing.T) {
+	raw := []map[string]interface{}{{"id": "a1", "message": "x"}, {"name": "db-heartbeat"}, {"id": "a2"}}
+	out, err := captureStdout(func() {
+		if err := RenderTable([]string{"ID"}, nil, raw, Options{Mode: ModeID}); err != nil {
+			t.Errorf("RenderTable error: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "a1\ndb-heartbeat\na2\n" {
+		t.Errorf("id output = %q", out)
+	}
+
+	out, err = captureStdout(func() {
+		if err := RenderJSON(map[string]string{"id": "t1", "name": "Team"}, Options{Mode: ModeID}); err != nil {
+			t.Errorf("RenderJSON error: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "t1\n" {
+		t.Errorf("single id output = %q", out)
+	}
+}
+
+func TestRenderTable_IDModeWithoutRawData(t *testing.T) {
+	out, err := captureStdout(func() {
+		if err := RenderTable([]string{"Name", "ID"}, [][]string{{"x", "1"}, {"y", "2"}}, nil, Options{Mode: ModeID}); err != nil {
+			t.Errorf("RenderTable error: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "1\n2\n" {
+		t.Errorf("id output = %q", out)
+	}
+	if err := RenderTable([]string{"Name"}, nil, nil, Options{Mode: ModeID}); err == nil {
+		t.Error("expected error without an ID column")
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	for in, want := range map[string]Mode{"json": ModeJSON, "YAML": ModeYAML, "plaintext": ModePlaintext, "table": ModeTable, "csv": ModeCSV, "tsv": ModeTSV, "id": ModeID, "ndjson": ModeNDJSON} {
+		if got, err := ParseMode(in); err != nil || got != want {
+			t.Errorf("ParseMode(%q) = %v, %v", in, got, err)
+		}
+	}
+	if _, err := ParseMode("xml"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+	if !(Options{Mode: ModeYAML}).Structured() || (Options{Mode: ModePlaintext}).Structured() {
+		t.Error("Structured is wrong")
+	}
+}
+
 // Prevent unused import warning
 var _ = fmt.Sprintf
+
+func TestRenderJSONLines(t *testing.T) {
+	page := json.RawMessage(`[{"id":"1","name":"alpha"},{"id":"2","name":"beta"}]`)
+	var b strings.Builder
+	if err := renderJSONLinesTo(&b, page, Options{Fields: []string{"id"}}); err != nil {
+		t.Fatal(err)
+	}
+	if want := "{\"id\":\"1\"}\n{\"id\":\"2\"}\n"; b.String() != want {
+		t.Errorf("output = %q, want %q", b.String(), want)
+	}
+
+	b.Reset()
+	if err := renderJSONLinesTo(&b, page, Options{JQExpr: `select(.id == "2") | .name`}); err != nil {
+		t.Fatal(err)
+	}
+	if want := "\"beta\"\n"; b.String() != want {
+		t.Errorf("jq output = %q, want %q", b.String(), want)
+	}
+}
+
+func TestRenderTable_NDJSONMode(t *testing.T) {
+	raw := []map[string]interface{}{
+		{"id": "1", "name": "alpha"},
+		{"id": "2", "name": "beta"},
+	}
+	out, err := captureStdout(func() {
+		if err := RenderTable([]string{"ID"}, nil, raw, Options{Mode: ModeNDJSON, JQExpr: ".name"}); err != nil {
+			t.Errorf("RenderTable error: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "\"alpha\"\n\"beta\"\n"; out != want {
+		t.Errorf("output = %q, want %q", out, want)
+	}
+}
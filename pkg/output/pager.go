@@ -0,0 +1,57 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// defaultPager is used when $PAGER is unset. -F exits when the text fits on
+// one screen, so short documents behave like plain output.
+const defaultPager = "less -FRX"
+
+// Page shows text through the user's pager ($PAGER, default "less -FRX")
+// when the session is interactive, and writes it to stdout otherwise or
+// when the pager cannot be started.
+func Page(text string) error {
+	pager := os.Getenv("PAGER")
+	if pager == "" {
+		pager = defaultPager
+	}
+	args := strings.Fields(pager)
+	if !Interactive() || len(args) == 0 || args[0] == "cat" {
+		_, err := fmt.Fprint(os.Stdout, text)
+		return err
+	}
+
+	c := exec.Command(args[0], args[1:]...)
+	c.Stdin = strings.NewReader(text)
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	err := c.Run()
+	var execErr *exec.Error
+	if errors.As(err, &execErr) {
+		_, err = fmt.Fprint(os.Stdout, text)
+	}
+	return err
+}
+
+// isTerminal reports whether f is a character device such as a terminal.
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// ClearScreen clears the terminal before a view is redrawn. It does nothing
+// when the session is not interactive, so redirected output keeps every
+// redraw.
+func ClearScreen() {
+	if Interactive() {
+		fmt.Fprint(os.Stdout, "\033[H\033[2J")
+	}
+}
@@ -0,0 +1,18 @@
+package output
+
+import "testing"
+
+func TestPage_NotTerminal(t *testing.T) {
+	t.Setenv("PAGER", "false")
+	out, err := captureStdout(func() {
+		if err := Page("hello\n"); err != nil {
+			t.Errorf("Page: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "hello\n" {
+		t.Errorf("output = %q, want %q", out, "hello\n")
+	}
+}
@@ -0,0 +1,71 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sync"
+)
+
+// Progress modes accepted by SetProgress.
+const (
+	ProgressNone = "none"
+	ProgressJSON = "json"
+)
+
+// ProgressEvent is one step of a long operation, written as a line of JSON
+// to stderr with --progress json. Done counts the items of Phase finished
+// so far, Item is the one just finished, and Total is the number of items
+// in the phase, or 0 when it is not known in advance (Percent is then
+// left out).
+type ProgressEvent struct {
+	Type    string   `json:"type"`
+	Phase   string   `json:"phase"`
+	Item    string   `json:"item,omitempty"`
+	Done    int      `json:"done"`
+	Total   int      `json:"total,omitempty"`
+	Percent *float64 `json:"percent,omitempty"`
+}
+
+var (
+	progressMu   sync.Mutex
+	progressJSON bool
+	progressOut  io.Writer = os.Stderr
+)
+
+// SetProgress sets how progress is reported for the rest of the process:
+// "json" writes a ProgressEvent per Progress call to stderr, "none" (or
+// empty) writes nothing.
+func SetProgress(mode string) error {
+	switch mode {
+	case "", ProgressNone:
+		progressJSON = false
+	case ProgressJSON:
+		progressJSON = true
+	default:
+		return fmt.Errorf("invalid --progress %q (valid: %s, %s)", mode, ProgressNone, ProgressJSON)
+	}
+	return nil
+}
+
+// Progress reports that item, the done-th of total items of phase, has
+// finished. It is safe to call from several goroutines.
+func Progress(phase, item string, done, total int) {
+	if !progressJSON {
+		return
+	}
+	e := ProgressEvent{Type: "progress", Phase: phase, Item: item, Done: done, Total: total}
+	if total > 0 {
+		p := math.Round(float64(done)*1000/float64(total)) / 10
+		e.Percent = &p
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	progressMu.Lock()
+	defer progressMu.Unlock()
+	_, _ = progressOut.Write(append(b, '\n'))
+}
@@ -0,0 +1,37 @@
+package output
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestProgress_JSONEvents(t *testing.T) {
+	var buf bytes.Buffer
+	defer func(w io.Writer) {
+		progressOut = w
+		_ = SetProgress("")
+	}(progressOut)
+	progressOut = &buf
+
+	Progress("export", "teams", 1, 3) // off by default
+	if buf.Len() != 0 {
+		t.Fatalf("progress written while off: %q", buf.String())
+	}
+
+	if err := SetProgress("json"); err != nil {
+		t.Fatal(err)
+	}
+	Progress("export", "teams", 1, 3)
+	Progress("fetch", "", 7, 0)
+	want := `{"type":"progress","phase":"export","item":"teams","done":1,"total":3,"percent":33.3}
+{"type":"progress","phase":"fetch","done":7}
+`
+	if buf.String() != want {
+		t.Errorf("events =\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	if err := SetProgress("bars"); err == nil {
+		t.Error("expected an error for an unknown mode")
+	}
+}
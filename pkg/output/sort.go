@@ -0,0 +1,89 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// sortRows orders rows by the column named by, matched like --fields: a
+// header, or else a key of the rawData items. rawData is reordered along
+// with the rows when it is a list of the same length, so structured output
+// follows the same order; the reordered list is returned.
+//
+// Cells compare as numbers when both are numbers and as case-insensitive
+// strings otherwise. Empty cells sort last in either direction, and equal
+// cells keep their order.
+func sortRows(headers []string, rows [][]string, rawData interface{}, by string, desc bool) ([][]string, interface{}, error) {
+	raw, _ := toJSONValue(rawData)
+	items, _ := raw.([]interface{})
+	if len(items) != len(rows) {
+		items = nil
+	}
+
+	var key func(i int) string
+	if j := indexFold(headers, by); j >= 0 {
+		key = func(i int) string {
+			if j < len(rows[i]) {
+				return rows[i][j]
+			}
+			return ""
+		}
+	} else if k, ok := itemsKey(items, by); ok {
+		key = func(i int) string {
+			m, _ := items[i].(map[string]interface{})
+			return cellString(m[k])
+		}
+	} else {
+		return nil, nil, fmt.Errorf("unknown sort column %q (available: %s)", by, strings.Join(headers, ", "))
+	}
+
+	keys := make([]string, len(rows))
+	order := make([]int, len(rows))
+	for i := range rows {
+		keys[i] = key(i)
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		x, y := keys[order[a]], keys[order[b]]
+		if x == "" || y == "" {
+			return x != "" && y == ""
+		}
+		c := compareCells(x, y)
+		if desc {
+			return c > 0
+		}
+		return c < 0
+	})
+
+	sorted := make([][]string, len(rows))
+	for n, i := range order {
+		sorted[n] = rows[i]
+	}
+	if items == nil {
+		return sorted, rawData, nil
+	}
+	sortedItems := make([]interface{}, len(items))
+	for n, i := range order {
+		sortedItems[n] = items[i]
+	}
+	return sorted, sortedItems, nil
+}
+
+// compareCells compares two table cells, numerically when both parse as
+// numbers.
+func compareCells(x, y string) int {
+	fx, errx := strconv.ParseFloat(x, 64)
+	fy, erry := strconv.ParseFloat(y, 64)
+	if errx == nil && erry == nil {
+		switch {
+		case fx < fy:
+			return -1
+		case fx > fy:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(strings.ToLower(x), strings.ToLower(y))
+}
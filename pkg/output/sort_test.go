@@ -0,0 +1,98 @@
+package output
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSortRows(t *testing.T) {
+	headers := []string{"ID", "Priority", "Count"}
+	rows := [][]string{
+		{"a", "P3", "10"},
+		{"b", "P1", "9"},
+		{"c", "", "100"},
+		{"d", "P1", ""},
+	}
+	raw := []map[string]interface{}{
+		{"id": "a", "owner": "zoe"},
+		{"id": "b", "owner": "Adam"},
+		{"id": "c", "owner": "bob"},
+		{"id": "d"},
+	}
+	ids := func(rows [][]string) string {
+		var out []string
+		for _, r := range rows {
+			out = append(out, r[0])
+		}
+		return strings.Join(out, "")
+	}
+
+	tests := []struct {
+		by   string
+		desc bool
+		want string
+	}{
+		{"priority", false, "bdac"},
+		{"Priority", true, "abdc"},
+		{"count", false, "bacd"},
+		{"count", true, "cabd"},
+		{"owner", false, "bcad"},
+	}
+	for _, tt := range tests {
+		got, _, err := sortRows(headers, rows, raw, tt.by, tt.desc)
+		if err != nil {
+			t.Fatalf("sortRows(%s): %v", tt.by, err)
+		}
+		if ids(got) != tt.want {
+			t.Errorf("sortRows(%s, desc=%v) = %s, want %s", tt.by, tt.desc, ids(got), tt.want)
+		}
+	}
+
+	_, sortedRaw, err := sortRows(headers, rows, raw, "count", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rawIDs []string
+	for _, it := range sortedRaw.([]interface{}) {
+		rawIDs = append(rawIDs, it.(map[string]interface{})["id"].(string))
+	}
+	if !reflect.DeepEqual(rawIDs, []string{"c", "a", "b", "d"}) {
+		t.Errorf("raw data order = %v", rawIDs)
+	}
+
+	if _, _, err := sortRows(headers, rows, raw, "nope", false); err == nil || !strings.Contains(err.Error(), "unknown sort column") {
+		t.Errorf("err = %v, want unknown sort column", err)
+	}
+}
+
+func TestRenderTable_ColumnsAndSort(t *testing.T) {
+	headers := []string{"ID", "Message", "Priority"}
+	rows := [][]string{{"1", "disk full", "P3"}, {"2", "db down", "P1"}}
+	raw := []map[string]interface{}{{"id": "1", "message": "disk full"}, {"id": "2", "message": "db down"}}
+
+	got, err := captureStdout(func() {
+		if err := RenderTable(headers, rows, raw, Options{Mode: ModeTSV, Columns: []string{"priority", "id"}, SortBy: "priority"}); err != nil {
+			t.Error(err)
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Priority\tID\nP1\t2\nP3\t1\n"; got != want {
+		t.Errorf("tsv = %q, want %q", got, want)
+	}
+
+	// --columns leaves JSON alone; --sort-by orders it.
+	got, err = captureStdout(func() {
+		if err := RenderTable(headers, rows, raw, Options{Mode: ModeJSON, Columns: []string{"id"}, SortBy: "priority"}); err != nil {
+			t.Error(err)
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(got, `"message": "db down"`) || strings.Index(got, `"2"`) > strings.Index(got, `"1"`) {
+		t.Errorf("json = %s", got)
+	}
+}
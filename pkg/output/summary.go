@@ -0,0 +1,91 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Summary counts the items of a multi-item operation, such as a bulk
+// change, apply or cleanup. Every item is processed once and ends up
+// succeeded, failed or skipped.
+type Summary struct {
+	Processed int      `json:"processed"`
+	Succeeded int      `json:"succeeded"`
+	Failed    int      `json:"failed"`
+	Skipped   int      `json:"skipped"`
+	FailedIDs []string `json:"failedIds"`
+}
+
+// Succeed counts an item that succeeded.
+func (s *Summary) Succeed() {
+	s.Processed++
+	s.Succeeded++
+}
+
+// Fail counts an item that failed.
+func (s *Summary) Fail(id string) {
+	s.Processed++
+	s.Failed++
+	s.FailedIDs = append(s.FailedIDs, id)
+}
+
+// Skip counts an item that was left alone.
+func (s *Summary) Skip() {
+	s.Processed++
+	s.Skipped++
+}
+
+// Err returns an error naming the failed items, or nil if none failed, so
+// the exit status tells whether the whole operation succeeded.
+func (s Summary) Err() error {
+	if s.Failed == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d of %d item(s) failed: %s", s.Failed, s.Processed, strings.Join(s.FailedIDs, ", "))
+}
+
+// RenderSummary writes s after a command's other output: as a one-line
+// {"summary": ...} JSON object in JSON and NDJSON mode (and with --jq), as a
+// separate YAML document in YAML mode, and as a one-row table otherwise.
+// Where extra rows would break the output (CSV, TSV, ID and template
+// modes) the table goes to stderr instead.
+func RenderSummary(s Summary, opts Options) error {
+	return renderSummaryTo(os.Stdout, os.Stderr, s, opts)
+}
+
+func renderSummaryTo(stdout, stderr io.Writer, s Summary, opts Options) error {
+	if s.FailedIDs == nil {
+		s.FailedIDs = []string{}
+	}
+	headers := []string{"Processed", "Succeeded", "Failed", "Skipped", "Failed IDs"}
+	rows := [][]string{{
+		strconv.Itoa(s.Processed), strconv.Itoa(s.Succeeded), strconv.Itoa(s.Failed),
+		strconv.Itoa(s.Skipped), strings.Join(s.FailedIDs, ","),
+	}}
+	doc := map[string]Summary{"summary": s}
+
+	switch {
+	case opts.Template != "" || opts.Mode == ModeID || opts.Mode == ModeCSV || opts.Mode == ModeTSV:
+		if opts.Quiet {
+			return nil
+		}
+		return renderPlaintext(stderr, headers, rows)
+	case opts.Mode == ModeYAML:
+		fmt.Fprintln(stdout, "---")
+		return renderYAMLTo(stdout, doc)
+	case opts.Structured() || opts.JQExpr != "":
+		b, err := json.Marshal(doc)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(stdout, string(b))
+		return err
+	case opts.Mode == ModePlaintext:
+		return renderPlaintext(stdout, headers, rows)
+	}
+	return renderTable(stdout, headers, rows, opts)
+}
@@ -0,0 +1,50 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSummary_CountsAndErr(t *testing.T) {
+	var s Summary
+	s.Succeed()
+	s.Skip()
+	if err := s.Err(); err != nil {
+		t.Fatalf("unexpected error without failures: %v", err)
+	}
+	s.Fail("s2")
+	if s.Processed != 3 || s.Succeeded != 1 || s.Failed != 1 || s.Skipped != 1 {
+		t.Errorf("unexpected counts: %+v", s)
+	}
+	err := s.Err()
+	if err == nil || !strings.Contains(err.Error(), "1 of 3 item(s) failed: s2") {
+		t.Errorf("Err() = %v", err)
+	}
+}
+
+func TestRenderSummary_Modes(t *testing.T) {
+	s := Summary{Processed: 2, Succeeded: 1, Failed: 1, FailedIDs: []string{"s2"}}
+	tests := []struct {
+		opts        Options
+		out, errOut string
+	}{
+		{Options{Mode: ModeJSON}, `{"summary":{"processed":2,"succeeded":1,"failed":1,"skipped":0,"failedIds":["s2"]}}` + "\n", ""},
+		{Options{Mode: ModePlaintext}, "Processed\tSucceeded\tFailed\tSkipped\tFailed IDs\n2\t1\t1\t0\ts2\n", ""},
+		{Options{Mode: ModeYAML}, "---\nsummary:\n", ""},
+		{Options{Mode: ModeCSV}, "", "2\t1\t1\t0\ts2\n"},
+		{Options{Mode: ModeCSV, Quiet: true}, "", ""},
+	}
+	for _, tt := range tests {
+		var out, errOut bytes.Buffer
+		if err := renderSummaryTo(&out, &errOut, s, tt.opts); err != nil {
+			t.Fatalf("mode %d: %v", tt.opts.Mode, err)
+		}
+		if !strings.HasPrefix(out.String(), tt.out) || (tt.out == "" && out.Len() > 0) {
+			t.Errorf("mode %d: stdout = %q, want %q", tt.opts.Mode, out.String(), tt.out)
+		}
+		if !strings.HasSuffix(errOut.String(), tt.errOut) || (tt.errOut == "" && errOut.Len() > 0) {
+			t.Errorf("mode %d: stderr = %q, want %q", tt.opts.Mode, errOut.String(), tt.errOut)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+)
+
+// templateFuncs are available to --template in addition to the text/template
+// builtins.
+var templateFuncs = template.FuncMap{
+	"join":  joinValues,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// renderTemplate executes a Go text/template against data. Arrays are
+// rendered one item at a time; each rendering is terminated by a newline
+// unless the template already ends with one.
+func renderTemplate(w io.Writer, data interface{}, text string) error {
+	tmpl, err := template.New("output").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return fmt.Errorf("parse template: %w", err)
+	}
+	normalized, err := toJSONValue(data)
+	if err != nil {
+		return fmt.Errorf("normalize for template: %w", err)
+	}
+
+	items := []interface{}{normalized}
+	if arr, ok := normalized.([]interface{}); ok {
+		items = arr
+	}
+	for _, item := range items {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, item); err != nil {
+			return fmt.Errorf("execute template: %w", err)
+		}
+		if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+			buf.WriteByte('\n')
+		}
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// joinValues joins a decoded JSON array (or a single value) with sep.
+func joinValues(sep string, v interface{}) string {
+	arr, ok := v.([]interface{})
+	if !ok {
+		if v == nil {
+			return ""
+		}
+		return fmt.Sprint(v)
+	}
+	parts := make([]string, len(arr))
+	for i, item := range arr {
+		parts[i] = fmt.Sprint(item)
+	}
+	return strings.Join(parts, sep)
+}
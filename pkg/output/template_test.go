@@ -0,0 +1,54 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRenderTemplate_PerItem(t *testing.T) {
+	type alert struct {
+		ID   string   `json:"id"`
+		Tags []string `json:"tags"`
+	}
+	var buf bytes.Buffer
+	data := []alert{{"a1", []string{"x", "y"}}, {"a2", nil}}
+	if err := renderTemplate(&buf, data, `{{.id}} [{{join "," .tags}}]`); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "a1 [x,y]\na2 []\n" {
+		t.Errorf("got %q", buf.String())
+	}
+}
+
+func TestRenderTemplate_SingleObjectKeepsTrailingNewline(t *testing.T) {
+	var buf bytes.Buffer
+	if err := renderTemplate(&buf, map[string]interface{}{"name": "ops"}, "{{upper .name}}\n"); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "OPS\n" {
+		t.Errorf("got %q", buf.String())
+	}
+}
+
+func TestRenderTemplate_ParseError(t *testing.T) {
+	var buf bytes.Buffer
+	if err := renderTemplate(&buf, nil, "{{.id"); err == nil {
+		t.Error("expected parse error")
+	}
+}
+
+func TestRenderTable_TemplateOverridesModeAndRunsAfterJQ(t *testing.T) {
+	opts := Options{Mode: ModeCSV, JQExpr: "map(select(.id == \"2\"))", Template: "{{.name}}"}
+	out, err := captureStdout(func() {
+		data := []map[string]string{{"id": "1", "name": "alpha"}, {"id": "2", "name": "beta"}}
+		if err := RenderTable([]string{"ID"}, nil, data, opts); err != nil {
+			t.Errorf("RenderTable error: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "beta\n" {
+		t.Errorf("got %q", out)
+	}
+}
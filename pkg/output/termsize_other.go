@@ -0,0 +1,10 @@
+//go:build !unix
+
+package output
+
+import "os"
+
+// termSize returns 0: the terminal width is only known from $COLUMNS here.
+func termSize(f *os.File) int {
+	return 0
+}
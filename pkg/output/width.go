@@ -0,0 +1,86 @@
+package output
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// minColumnWidth is the narrowest a column is truncated to, unless its
+// header is wider.
+const minColumnWidth = 8
+
+// columnGap is the space between table columns (see renderTable).
+const columnGap = 3
+
+// terminalWidth returns the width in columns of the terminal on stdout, or
+// 0 when output is not interactive or the width is unknown. $COLUMNS
+// overrides the width the terminal reports.
+var terminalWidth = func() int {
+	if !Interactive() {
+		return 0
+	}
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		return n
+	}
+	return termSize(os.Stdout)
+}
+
+// fitColumns truncates the widest cells of rows, with an ellipsis, so the
+// table fits in width columns. The widest columns are narrowed first, to a
+// common width, and no column is made narrower than its header or
+// minColumnWidth; when even that does not fit, the table is left to wrap.
+// rows itself is not modified.
+func fitColumns(headers []string, rows [][]string, width int) [][]string {
+	n := len(headers)
+	if n == 0 || width <= 0 {
+		return rows
+	}
+	widths := make([]int, n)
+	for i, h := range headers {
+		widths[i] = runewidth.StringWidth(h)
+	}
+	for _, row := range rows {
+		for i, c := range row {
+			if i < n {
+				widths[i] = max(widths[i], runewidth.StringWidth(c))
+			}
+		}
+	}
+	floors := make([]int, n)
+	limit := 0
+	for i, h := range headers {
+		floors[i] = min(max(runewidth.StringWidth(h), minColumnWidth), widths[i])
+		limit = max(limit, widths[i])
+	}
+
+	budget := width - columnGap*(n-1)
+	fitted := func(limit int) int {
+		total := 0
+		for i := range widths {
+			total += max(min(widths[i], limit), floors[i])
+		}
+		return total
+	}
+	if fitted(limit) <= budget {
+		return rows
+	}
+	for limit > 0 && fitted(limit) > budget {
+		limit--
+	}
+
+	out := make([][]string, len(rows))
+	for r, row := range rows {
+		out[r] = make([]string, len(row))
+		for i, c := range row {
+			if i < n {
+				if w := max(min(widths[i], limit), floors[i]); runewidth.StringWidth(c) > w {
+					c = runewidth.Truncate(c, w, "…")
+				}
+			}
+			out[r][i] = c
+		}
+	}
+	return out
+}
@@ -0,0 +1,79 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mattn/go-runewidth"
+)
+
+func TestFitColumns(t *testing.T) {
+	headers := []string{"ID", "Message", "Status"}
+	rows := [][]string{
+		{"4513b7ea", "Disk usage above 95% on db-primary-01 in eu-west-1", "open"},
+		{"77ad01c2", "CPU high", "closed"},
+	}
+
+	// Wide enough: nothing changes.
+	if got := fitColumns(headers, rows, 200); got[0][1] != rows[0][1] {
+		t.Errorf("truncated at width 200: %q", got[0][1])
+	}
+
+	got := fitColumns(headers, rows, 40)
+	total := 0
+	for i := range headers {
+		w := 0
+		for _, row := range got {
+			w = max(w, runewidth.StringWidth(row[i]))
+		}
+		total += w
+	}
+	if total+columnGap*2 > 40 {
+		t.Errorf("table is %d wide, want at most 40: %q", total+columnGap*2, got)
+	}
+	if !strings.HasSuffix(got[0][1], "…") || !strings.HasPrefix(got[0][1], "Disk usage") {
+		t.Errorf("message = %q, want it truncated with an ellipsis", got[0][1])
+	}
+	if got[0][0] != "4513b7ea" || got[1][2] != "closed" || got[1][1] != "CPU high" {
+		t.Errorf("short cells changed: %q", got)
+	}
+	if rows[0][1] != "Disk usage above 95% on db-primary-01 in eu-west-1" {
+		t.Error("fitColumns modified its input")
+	}
+
+	// Too narrow for even the minimum widths: columns stop at their floor.
+	got = fitColumns(headers, rows, 10)
+	if w := runewidth.StringWidth(got[0][1]); w != minColumnWidth {
+		t.Errorf("message width at width 10 = %d, want %d", w, minColumnWidth)
+	}
+
+	// Not a terminal.
+	if got := fitColumns(headers, rows, 0); got[0][1] != rows[0][1] {
+		t.Error("truncated without a terminal width")
+	}
+}
+
+func TestRenderTable_TruncatesToTerminal(t *testing.T) {
+	old := terminalWidth
+	terminalWidth = func() int { return 30 }
+	t.Cleanup(func() { terminalWidth = old })
+
+	headers := []string{"ID", "Message"}
+	rows := [][]string{{"a1", strings.Repeat("x", 60)}}
+	var buf bytes.Buffer
+	if err := renderTable(&buf, headers, rows, Options{NoColor: true}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "…") || strings.Contains(buf.String(), strings.Repeat("x", 60)) {
+		t.Errorf("table not truncated:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := renderTable(&buf, headers, rows, Options{NoColor: true, Wide: true}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), strings.Repeat("x", 60)) {
+		t.Errorf("--wide truncated:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,232 @@
+// Package plan records the API writes a composite command would make as an
+// ordered list of steps, so the plan can be reviewed (and saved to a file)
+// separately from executing it.
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Step is one API write.
+type Step struct {
+	Method  string          `json:"method"`
+	Path    string          `json:"path"`
+	Body    json.RawMessage `json:"body,omitempty"`
+	Summary string          `json:"summary"`
+}
+
+// Plan is the ordered steps of one command run.
+type Plan struct {
+	Command   string    `json:"command"`
+	CreatedAt time.Time `json:"createdAt"`
+	Steps     []Step    `json:"steps"`
+}
+
+// Ref is the placeholder for the ID created by step n (1-based). Later
+// steps use it where they need that ID, e.g. a rotation of a new schedule.
+func Ref(n int) string {
+	return "{step:" + strconv.Itoa(n) + "}"
+}
+
+// Recorder has the write methods of api.Client but appends a step to Plan
+// instead of sending the request. Creates answer with Ref of their step as
+// the new resource's ID, so commands that chain writes record them as they
+// would run.
+type Recorder struct {
+	Plan *Plan
+}
+
+// NewRecorder returns a Recorder for a new plan of command.
+func NewRecorder(command string) *Recorder {
+	return &Recorder{Plan: &Plan{Command: command, CreatedAt: time.Now().UTC(), Steps: []Step{}}}
+}
+
+// Post records a POST.
+func (r *Recorder) Post(path string, body, result interface{}) error {
+	return r.record("POST", path, body, result)
+}
+
+// Put records a PUT.
+func (r *Recorder) Put(path string, body, result interface{}) error {
+	return r.record("PUT", path, body, result)
+}
+
+// Patch records a PATCH.
+func (r *Recorder) Patch(path string, body, result interface{}) error {
+	return r.record("PATCH", path, body, result)
+}
+
+// Delete records a DELETE.
+func (r *Recorder) Delete(path string, result interface{}) error {
+	return r.record("DELETE", path, nil, result)
+}
+
+func (r *Recorder) record(method, path string, body, result interface{}) error {
+	var raw json.RawMessage
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		raw = b
+	}
+	r.Plan.Steps = append(r.Plan.Steps, Step{Method: method, Path: path, Body: raw, Summary: summarize(method, path, raw)})
+	if method != "POST" || result == nil {
+		return nil
+	}
+	// Best effort: results that have no data.id to fill are left alone.
+	fake, _ := json.Marshal(map[string]interface{}{"data": map[string]string{"id": Ref(len(r.Plan.Steps))}})
+	_ = json.Unmarshal(fake, result)
+	return nil
+}
+
+// summarize describes a step as "<verb> <collection> <name or ID>", with
+// the fields an update sets, e.g. `update schedules s1 (enabled)`.
+func summarize(method, path string, body json.RawMessage) string {
+	verb := map[string]string{"POST": "create", "PUT": "update", "PATCH": "update", "DELETE": "delete"}[method]
+	p, _, _ := strings.Cut(path, "?")
+	segs := strings.Split(strings.Trim(p, "/"), "/")
+	if len(segs) > 0 && (segs[0] == "v1" || segs[0] == "v2") {
+		segs = segs[1:]
+	}
+
+	var fields map[string]interface{}
+	_ = json.Unmarshal(body, &fields)
+	target := ""
+	for _, k := range []string{"name", "username"} {
+		if s, ok := fields[k].(string); ok && s != "" {
+			target = strconv.Quote(s)
+			break
+		}
+	}
+
+	kind := ""
+	switch {
+	case method == "POST" && len(segs) > 0:
+		kind = segs[len(segs)-1]
+	case len(segs) >= 2:
+		kind = segs[len(segs)-2]
+		if target == "" {
+			target, _ = url.PathUnescape(segs[len(segs)-1])
+		}
+	}
+	s := strings.Join(strings.Fields(verb+" "+kind+" "+target), " ")
+	if method == "PUT" || method == "PATCH" {
+		keys := make([]string, 0, len(fields))
+		for k := range fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		if len(keys) > 0 {
+			s += " (" + strings.Join(keys, ", ") + ")"
+		}
+	}
+	return s
+}
+
+// Write saves p to path as indented JSON.
+func Write(path string, p *Plan) error {
+	b, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(b, '\n'), 0o644)
+}
+
+// Load reads a plan written by Write and checks every step is a write to
+// an API path.
+func Load(path string) (*Plan, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var p Plan
+	if err := json.Unmarshal(b, &p); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for i, s := range p.Steps {
+		switch s.Method {
+		case "POST", "PUT", "PATCH", "DELETE":
+		default:
+			return nil, fmt.Errorf("%s: step %d: unsupported method %q", path, i+1, s.Method)
+		}
+		if !strings.HasPrefix(s.Path, "/") {
+			return nil, fmt.Errorf("%s: step %d: path %q is not an API path", path, i+1, s.Path)
+		}
+	}
+	return &p, nil
+}
+
+// Client is the subset of api.Client used to execute a plan.
+type Client interface {
+	Post(path string, body, result interface{}) error
+	Put(path string, body, result interface{}) error
+	Patch(path string, body, result interface{}) error
+	Delete(path string, result interface{}) error
+}
+
+// Result is an executed step, with its path after placeholders were
+// replaced and the ID of the resource it created, if any.
+type Result struct {
+	Step
+	ID string `json:"id,omitempty"`
+}
+
+// Execute runs the steps of p in order, stopping at the first error. Step
+// placeholders (see Ref) in paths and bodies are replaced with the IDs
+// earlier steps created. The results of the steps that ran are returned
+// even on error.
+func Execute(c Client, p *Plan) ([]Result, error) {
+	var replacements []string
+	results := make([]Result, 0, len(p.Steps))
+	for i, s := range p.Steps {
+		r := strings.NewReplacer(replacements...)
+		s.Path = r.Replace(s.Path)
+		if s.Body != nil {
+			s.Body = json.RawMessage(r.Replace(string(s.Body)))
+		}
+		if strings.Contains(s.Path, "{step:") || strings.Contains(s.Path, url.QueryEscape("{step:")) ||
+			strings.Contains(string(s.Body), "{step:") {
+			return results, fmt.Errorf("step %d (%s) refers to a step that created no ID", i+1, s.Summary)
+		}
+
+		var resp struct {
+			Data struct {
+				ID string `json:"id"`
+			} `json:"data"`
+		}
+		var body interface{}
+		if s.Body != nil {
+			body = s.Body
+		}
+		var err error
+		switch s.Method {
+		case "POST":
+			err = c.Post(s.Path, body, &resp)
+		case "PUT":
+			err = c.Put(s.Path, body, &resp)
+		case "PATCH":
+			err = c.Patch(s.Path, body, &resp)
+		case "DELETE":
+			err = c.Delete(s.Path, &resp)
+		default:
+			err = fmt.Errorf("unsupported method %q", s.Method)
+		}
+		if err != nil {
+			return results, fmt.Errorf("step %d (%s): %w", i+1, s.Summary, err)
+		}
+		results = append(results, Result{Step: s, ID: resp.Data.ID})
+		if id := resp.Data.ID; id != "" && s.Method == "POST" {
+			ref := Ref(i + 1)
+			replacements = append(replacements, ref, id, url.QueryEscape(ref), url.QueryEscape(id))
+		}
+	}
+	return results, nil
+}
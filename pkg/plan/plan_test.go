@@ -0,0 +1,118 @@
+package plan
+
+import (
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// fakeClient answers every POST with a new ID and records the requests.
+type fakeClient struct {
+	requests []string
+	fail     string
+}
+
+func (f *fakeClient) do(method, path string, body, result interface{}) error {
+	b, _ := json.Marshal(body)
+	f.requests = append(f.requests, method+" "+path+" "+string(b))
+	if f.fail != "" && strings.Contains(path, f.fail) {
+		return errFail
+	}
+	if method == "POST" {
+		resp, _ := json.Marshal(map[string]interface{}{"data": map[string]string{"id": "new-" + strconv.Itoa(len(f.requests))}})
+		return json.Unmarshal(resp, result)
+	}
+	return nil
+}
+
+var errFail = errors.New("boom")
+
+func (f *fakeClient) Post(path string, body, result interface{}) error {
+	return f.do("POST", path, body, result)
+}
+func (f *fakeClient) Put(path string, body, result interface{}) error {
+	return f.do("PUT", path, body, result)
+}
+func (f *fakeClient) Patch(path string, body, result interface{}) error {
+	return f.do("PATCH", path, body, result)
+}
+func (f *fakeClient) Delete(path string, result interface{}) error {
+	return f.do("DELETE", path, nil, result)
+}
+
+func TestRecordWriteLoadExecute(t *testing.T) {
+	rec := NewRecorder("opsgenie-cli apply")
+	var resp struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := rec.Post("/v2/schedules", map[string]string{"name": "Primary"}, &resp); err != nil {
+		t.Fatal(err)
+	}
+	id, _ := resp.Data["id"].(string)
+	if id != Ref(1) {
+		t.Fatalf("recorded create returned id %q, want %q", id, Ref(1))
+	}
+	_ = rec.Post("/v2/schedules/"+id+"/rotations", map[string]string{"name": "weekly"}, nil)
+	_ = rec.Patch("/v2/schedules/s9", map[string]bool{"enabled": false}, nil)
+
+	want := []string{`create schedules "Primary"`, `create rotations "weekly"`, `update schedules s9 (enabled)`}
+	for i, s := range rec.Plan.Steps {
+		if s.Summary != want[i] {
+			t.Errorf("step %d summary = %q, want %q", i+1, s.Summary, want[i])
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "plan.json")
+	if err := Write(path, rec.Plan); err != nil {
+		t.Fatal(err)
+	}
+	p, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := &fakeClient{}
+	results, err := Execute(c, p)
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if len(results) != 3 || results[0].ID != "new-1" {
+		t.Fatalf("results = %+v", results)
+	}
+	if c.requests[1] != `POST /v2/schedules/new-1/rotations {"name":"weekly"}` {
+		t.Errorf("placeholder not replaced: %s", c.requests[1])
+	}
+}
+
+func TestExecute_StopsAtFailure(t *testing.T) {
+	p := &Plan{Steps: []Step{
+		{Method: "PATCH", Path: "/v2/teams/a"},
+		{Method: "PATCH", Path: "/v2/teams/b"},
+		{Method: "PATCH", Path: "/v2/teams/c"},
+	}}
+	results, err := Execute(&fakeClient{fail: "/b"}, p)
+	if err == nil || !strings.Contains(err.Error(), "step 2") {
+		t.Fatalf("err = %v, want failure at step 2", err)
+	}
+	if len(results) != 1 {
+		t.Errorf("got %d results, want the 1 step before the failure", len(results))
+	}
+
+	_, err = Execute(&fakeClient{}, &Plan{Steps: []Step{{Method: "POST", Path: "/v2/schedules/" + Ref(1) + "/rotations"}}})
+	if err == nil {
+		t.Error("expected error for a placeholder no step filled")
+	}
+}
+
+func TestLoad_RejectsReads(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plan.json")
+	if err := Write(path, &Plan{Steps: []Step{{Method: "GET", Path: "/v2/alerts"}}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("expected error for a GET step")
+	}
+}
@@ -0,0 +1,122 @@
+// Package refresh keeps a list of API records current for long-running
+// views by polling only for records updated since the last fetch, with a
+// periodic full reload to notice records that stopped matching.
+package refresh
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// Item is one tracked record.
+type Item struct {
+	ID        string
+	UpdatedAt time.Time
+	Data      interface{}
+}
+
+// FetchFunc returns the records updated at or after since, or every record
+// when since is zero.
+type FetchFunc func(ctx context.Context, since time.Time) ([]Item, error)
+
+// Update is delivered after each fetch that changed something.
+type Update struct {
+	// Items is every tracked record, most recently updated first.
+	Items []Item
+	// Changed holds the records added or updated by this fetch. After a full
+	// reload it is every record.
+	Changed []Item
+	// Full reports whether this update came from a full reload.
+	Full bool
+}
+
+// Refresher polls a FetchFunc. Requests go through the API client, so its
+// rate limiter applies; Interval only sets how often to ask.
+type Refresher struct {
+	Fetch    FetchFunc
+	Interval time.Duration
+	// FullEvery forces a full reload every N polls so records that no
+	// longer match the query drop out. Zero means never.
+	FullEvery int
+
+	items     map[string]Item
+	watermark time.Time
+}
+
+// Run loads every record, then polls every Interval until ctx is cancelled,
+// calling fn with the first load and with every poll that changed something.
+// It returns nil when ctx is cancelled, or the first error from Fetch or fn.
+func (r *Refresher) Run(ctx context.Context, fn func(Update) error) error {
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+
+	for polls := 0; ; polls++ {
+		full := polls == 0 || (r.FullEvery > 0 && polls%r.FullEvery == 0)
+		u, err := r.poll(ctx, full)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if polls == 0 || len(u.Changed) > 0 || full {
+			if err := fn(u); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll fetches one round and merges it into the tracked records.
+func (r *Refresher) poll(ctx context.Context, full bool) (Update, error) {
+	since := r.watermark
+	if full {
+		since = time.Time{}
+	}
+	fetched, err := r.Fetch(ctx, since)
+	if err != nil {
+		return Update{}, err
+	}
+
+	if full {
+		r.items = map[string]Item{}
+	}
+	var changed []Item
+	for _, it := range fetched {
+		if old, ok := r.items[it.ID]; ok && !full && !it.UpdatedAt.After(old.UpdatedAt) {
+			// The watermark is inclusive, so records updated exactly at it
+			// come back on the next poll; skip them when unchanged.
+			continue
+		}
+		r.items[it.ID] = it
+		changed = append(changed, it)
+		if it.UpdatedAt.After(r.watermark) {
+			r.watermark = it.UpdatedAt
+		}
+	}
+
+	items := make([]Item, 0, len(r.items))
+	for _, it := range r.items {
+		items = append(items, it)
+	}
+	sortItems(items)
+	sortItems(changed)
+	return Update{Items: items, Changed: changed, Full: full}, nil
+}
+
+// sortItems orders items most recently updated first, then by ID.
+func sortItems(items []Item) {
+	sort.Slice(items, func(i, j int) bool {
+		if !items[i].UpdatedAt.Equal(items[j].UpdatedAt) {
+			return items[i].UpdatedAt.After(items[j].UpdatedAt)
+		}
+		return items[i].ID < items[j].ID
+	})
+}
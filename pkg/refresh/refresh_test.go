@@ -0,0 +1,71 @@
+package refresh
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRefresherIncremental(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	rounds := [][]Item{
+		{{ID: "a", UpdatedAt: t0}, {ID: "b", UpdatedAt: t0.Add(time.Minute)}},
+		{{ID: "b", UpdatedAt: t0.Add(time.Minute)}}, // unchanged at the watermark
+		{{ID: "b", UpdatedAt: t0.Add(time.Minute)}, {ID: "a", UpdatedAt: t0.Add(2 * time.Minute)}},
+		{{ID: "c", UpdatedAt: t0.Add(3 * time.Minute)}}, // full reload: a and b drop out
+	}
+	var sinces []time.Time
+	calls := 0
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r := &Refresher{
+		Interval:  time.Millisecond,
+		FullEvery: 3,
+		Fetch: func(_ context.Context, since time.Time) ([]Item, error) {
+			sinces = append(sinces, since)
+			items := rounds[calls]
+			calls++
+			return items, nil
+		},
+	}
+
+	var updates []Update
+	err := r.Run(ctx, func(u Update) error {
+		updates = append(updates, u)
+		if calls == len(rounds) {
+			cancel()
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	wantSince := []time.Time{{}, t0.Add(time.Minute), t0.Add(time.Minute), {}}
+	for i, s := range sinces {
+		if !s.Equal(wantSince[i]) {
+			t.Errorf("fetch %d since = %v, want %v", i, s, wantSince[i])
+		}
+	}
+	if len(updates) != 3 {
+		t.Fatalf("got %d updates, want 3 (the unchanged poll is skipped)", len(updates))
+	}
+	if u := updates[1]; len(u.Changed) != 1 || u.Changed[0].ID != "a" || u.Items[0].ID != "a" || len(u.Items) != 2 {
+		t.Errorf("incremental update = %+v", u)
+	}
+	if u := updates[2]; !u.Full || len(u.Items) != 1 || u.Items[0].ID != "c" {
+		t.Errorf("full update = %+v", u)
+	}
+}
+
+func TestRefresherFetchError(t *testing.T) {
+	boom := errors.New("boom")
+	r := &Refresher{
+		Interval: time.Millisecond,
+		Fetch:    func(context.Context, time.Time) ([]Item, error) { return nil, boom },
+	}
+	if err := r.Run(context.Background(), func(Update) error { return nil }); !errors.Is(err, boom) {
+		t.Errorf("err = %v, want boom", err)
+	}
+}
@@ -0,0 +1,171 @@
+// Package report builds periodic summaries of OpsGenie activity.
+package report
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+)
+
+// DayCount is the number of alerts created on a single calendar day.
+type DayCount struct {
+	Date  string `json:"date"`
+	Count int    `json:"count"`
+}
+
+// ServiceCount is the number of alerts raised for a single service.
+type ServiceCount struct {
+	Service string `json:"service"`
+	Count   int    `json:"count"`
+}
+
+// NoService groups alerts without a service tag.
+const NoService = "(no service)"
+
+// Shift is an upcoming on-call period for a schedule.
+type Shift struct {
+	Schedule  string `json:"schedule"`
+	Rotation  string `json:"rotation,omitempty"`
+	Recipient string `json:"recipient"`
+	Start     string `json:"start"`
+	End       string `json:"end"`
+}
+
+// Digest summarises alert activity over a reporting window.
+type Digest struct {
+	Start         time.Time      `json:"start"`
+	End           time.Time      `json:"end"`
+	TotalAlerts   int            `json:"totalAlerts"`
+	PreviousTotal int            `json:"previousTotal"`
+	Daily         []DayCount     `json:"daily"`
+	TopServices   []ServiceCount `json:"topServices"`
+	Acknowledged  int            `json:"acknowledged"`
+	Closed        int            `json:"closed"`
+	MTTA          time.Duration  `json:"-"`
+	MTTR          time.Duration  `json:"-"`
+	MTTASeconds   float64        `json:"mttaSeconds"`
+	MTTRSeconds   float64        `json:"mttrSeconds"`
+	OnCall        []Shift        `json:"onCall,omitempty"`
+}
+
+// Build aggregates alerts created in [start, end) into a Digest. Alerts
+// created in the equally long window immediately before start are counted
+// into PreviousTotal so the digest can show a week-over-week trend.
+//
+// Alerts carry no reference to the OpsGenie services they impact, so an
+// alert's service is the value of its serviceTag:<name> tag (e.g.
+// service:checkout). At most top services are kept; top <= 0 keeps all of
+// them.
+func Build(alerts []api.AlertResponse, start, end time.Time, top int, serviceTag string) Digest {
+	d := Digest{Start: start, End: end}
+	prevStart := start.Add(-end.Sub(start))
+
+	perDay := map[string]int{}
+	perService := map[string]int{}
+	var ackTotal, closeTotal time.Duration
+
+	for _, a := range alerts {
+		created, err := time.Parse(time.RFC3339Nano, a.CreatedAt)
+		if err != nil {
+			continue
+		}
+		if !created.Before(prevStart) && created.Before(start) {
+			d.PreviousTotal++
+			continue
+		}
+		if created.Before(start) || !created.Before(end) {
+			continue
+		}
+
+		d.TotalAlerts++
+		perDay[created.In(start.Location()).Format("2006-01-02")]++
+
+		perService[alertService(a, serviceTag)]++
+
+		if a.Report != nil {
+			if a.Report.AckTime > 0 {
+				d.Acknowledged++
+				ackTotal += time.Duration(a.Report.AckTime) * time.Millisecond
+			}
+			if a.Report.CloseTime > 0 {
+				d.Closed++
+				closeTotal += time.Duration(a.Report.CloseTime) * time.Millisecond
+			}
+		}
+	}
+
+	// Emit every day in the window, including quiet ones, so the trend
+	// reads as a continuous series.
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		key := day.Format("2006-01-02")
+		d.Daily = append(d.Daily, DayCount{Date: key, Count: perDay[key]})
+	}
+
+	for service, count := range perService {
+		d.TopServices = append(d.TopServices, ServiceCount{Service: service, Count: count})
+	}
+	sort.Slice(d.TopServices, func(i, j int) bool {
+		if d.TopServices[i].Count != d.TopServices[j].Count {
+			return d.TopServices[i].Count > d.TopServices[j].Count
+		}
+		return d.TopServices[i].Service < d.TopServices[j].Service
+	})
+	if top > 0 && len(d.TopServices) > top {
+		d.TopServices = d.TopServices[:top]
+	}
+
+	if d.Acknowledged > 0 {
+		d.MTTA = (ackTotal / time.Duration(d.Acknowledged)).Round(time.Second)
+		d.MTTASeconds = d.MTTA.Seconds()
+	}
+	if d.Closed > 0 {
+		d.MTTR = (closeTotal / time.Duration(d.Closed)).Round(time.Second)
+		d.MTTRSeconds = d.MTTR.Seconds()
+	}
+	return d
+}
+
+// alertService returns the value of the alert's first key:<name> tag, or
+// NoService.
+func alertService(a api.AlertResponse, key string) string {
+	for _, t := range a.Tags {
+		if name, ok := strings.CutPrefix(t, key+":"); ok && name != "" {
+			return name
+		}
+	}
+	return NoService
+}
+
+// ShiftsFromTimeline flattens a schedule timeline into upcoming shifts,
+// ordered by start time.
+func ShiftsFromTimeline(schedule string, tl api.ScheduleTimeline) []Shift {
+	var shifts []Shift
+	for _, rot := range tl.FinalTimeline.Rotations {
+		for _, p := range rot.Periods {
+			recipient := p.Recipient.Name
+			if recipient == "" {
+				recipient = p.Recipient.ID
+			}
+			shifts = append(shifts, Shift{
+				Schedule:  schedule,
+				Rotation:  rot.Name,
+				Recipient: recipient,
+				Start:     p.StartDate,
+				End:       p.EndDate,
+			})
+		}
+	}
+	sort.SliceStable(shifts, func(i, j int) bool { return shifts[i].Start < shifts[j].Start })
+	return shifts
+}
+
+// Trend returns the percentage change in alert volume versus the previous
+// window, and false when there is no previous data to compare against.
+func (d Digest) Trend() (float64, bool) {
+	if d.PreviousTotal == 0 {
+		return 0, false
+	}
+	return float64(d.TotalAlerts-d.PreviousTotal) / float64(d.PreviousTotal) * 100, true
+}
@@ -0,0 +1,101 @@
+package report
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+)
+
+// HeartbeatAnalysis summarises the expiry alerts of a heartbeat and
+// recommends an interval that would have avoided most of the false alarms.
+type HeartbeatAnalysis struct {
+	Interval time.Duration `json:"-"`
+	Expiries int           `json:"expiries"`
+	// LatePings are expiries that recovered within one more interval: the
+	// job ran, just later than the heartbeat allowed.
+	LatePings int `json:"latePings"`
+	// Outages are expiries that lasted longer, and Open those not yet
+	// recovered.
+	Outages int `json:"outages"`
+	Open    int `json:"open"`
+	// LongestGap is the longest time between pings among late pings.
+	LongestGap  time.Duration `json:"-"`
+	Recommended time.Duration `json:"-"`
+
+	IntervalSeconds    float64 `json:"intervalSeconds"`
+	LongestGapSeconds  float64 `json:"longestGapSeconds"`
+	RecommendedSeconds float64 `json:"recommendedSeconds"`
+}
+
+// AnalyzeHeartbeat classifies the expiry alerts of a heartbeat pinged
+// every interval. An expiry alert is created one interval after the last
+// ping and closed by the next one, so the gap between the two pings is
+// the interval plus how long the alert was open.
+//
+// The recommendation is the smallest multiple of unit that covers the
+// given fraction (0-1] of late-ping gaps, or the current interval if there
+// were no late pings.
+func AnalyzeHeartbeat(alerts []api.AlertResponse, interval, unit time.Duration, coverage float64) HeartbeatAnalysis {
+	a := HeartbeatAnalysis{Interval: interval, Expiries: len(alerts), Recommended: interval}
+	var gaps []time.Duration
+	for _, al := range alerts {
+		open, ok := alertOpenFor(al)
+		if !ok {
+			a.Open++
+			continue
+		}
+		if open > interval {
+			a.Outages++
+			continue
+		}
+		a.LatePings++
+		gaps = append(gaps, interval+open)
+	}
+
+	if len(gaps) > 0 {
+		sort.Slice(gaps, func(i, j int) bool { return gaps[i] < gaps[j] })
+		a.LongestGap = gaps[len(gaps)-1]
+		if coverage <= 0 || coverage > 1 {
+			coverage = 1
+		}
+		gap := gaps[int(math.Ceil(coverage*float64(len(gaps))))-1]
+		if unit <= 0 {
+			unit = time.Minute
+		}
+		// One unit of headroom beyond the covered gap.
+		a.Recommended = (gap/unit + 1) * unit
+		if a.Recommended < interval {
+			a.Recommended = interval
+		}
+	}
+
+	a.IntervalSeconds = a.Interval.Seconds()
+	a.LongestGapSeconds = a.LongestGap.Seconds()
+	a.RecommendedSeconds = a.Recommended.Seconds()
+	return a
+}
+
+// alertOpenFor returns how long a closed alert was open.
+func alertOpenFor(a api.AlertResponse) (time.Duration, bool) {
+	if a.Status != "closed" {
+		return 0, false
+	}
+	if a.Report != nil && a.Report.CloseTime > 0 {
+		return time.Duration(a.Report.CloseTime) * time.Millisecond, true
+	}
+	created, err := time.Parse(time.RFC3339Nano, a.CreatedAt)
+	if err != nil {
+		return 0, false
+	}
+	closed := a.ClosedAt
+	if closed == "" {
+		closed = a.UpdatedAt
+	}
+	end, err := time.Parse(time.RFC3339Nano, closed)
+	if err != nil {
+		return 0, false
+	}
+	return end.Sub(created), true
+}
@@ -0,0 +1,200 @@
+package report
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+)
+
+// History fields.
+const (
+	FieldStatus   = "status"
+	FieldPriority = "priority"
+	FieldOwner    = "owner"
+	FieldTags     = "tags"
+)
+
+// HistoryFields are the fields AlertHistory tracks.
+var HistoryFields = []string{FieldStatus, FieldPriority, FieldOwner, FieldTags}
+
+// FieldChange is one change of an alert field, with the log entry it was
+// read from. From is empty when the earlier value is unknown.
+type FieldChange struct {
+	Time  string `json:"time"`
+	Actor string `json:"actor,omitempty"`
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Log   string `json:"log,omitempty"`
+}
+
+var (
+	priorityFromTo = regexp.MustCompile(`(?i)priority\b.*?\b(P[1-5])\b.*?\b(P[1-5])\b`)
+	priorityTo     = regexp.MustCompile(`(?i)priority\b.*?\b(P[1-5])\b`)
+	ownerTo        = regexp.MustCompile(`(?i)(?:assigned to|owner(?:ship)?(?: changed)? to|owner is now)\s*\[?([^\]\s,]+)`)
+	tagList        = regexp.MustCompile(`(?i)tags?\s*\[([^\]]*)\]`)
+)
+
+// statusChange returns the status a log entry moves the alert to, if any.
+func statusChange(log string) string {
+	l := strings.ToLower(log)
+	switch {
+	case strings.Contains(l, "unacknowledged"):
+		return "open"
+	case strings.Contains(l, "unsnoozed") || strings.Contains(l, "un-snoozed") || strings.Contains(l, "snooze ended"):
+		return "open"
+	case strings.Contains(l, "reopened"):
+		return "open"
+	case strings.Contains(l, "acknowledged"):
+		return "acknowledged"
+	case strings.Contains(l, "snoozed"):
+		return "snoozed"
+	case strings.Contains(l, "closed"):
+		return "closed"
+	}
+	return ""
+}
+
+// AlertHistory reconstructs how an alert's status, priority, owner and
+// tags changed from its activity log. Log entries are free text, so
+// changes are recognised by their wording ("priority changed from P3 to
+// P1", "assigned to bob", "added tags [db]", "acknowledged", "closed");
+// entries that change nothing tracked are skipped.
+//
+// The first changes are the alert's values at creation: status open, and
+// priority and tags worked back from the alert's current values. Changes
+// are ordered by time.
+func AlertHistory(a api.AlertResponse, logs []api.AlertLog) []FieldChange {
+	sorted := make([]api.AlertLog, len(logs))
+	copy(sorted, logs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		ti, _ := time.Parse(time.RFC3339Nano, sorted[i].CreatedAt)
+		tj, _ := time.Parse(time.RFC3339Nano, sorted[j].CreatedAt)
+		return ti.Before(tj)
+	})
+
+	type tagEdit struct {
+		add  bool
+		tags []string
+	}
+	type parsed struct {
+		log      api.AlertLog
+		status   string
+		priority [2]string // from, to
+		owner    string
+		tags     *tagEdit
+	}
+	var entries []parsed
+	for _, l := range sorted {
+		p := parsed{log: l, status: statusChange(l.Log)}
+		if m := priorityFromTo.FindStringSubmatch(l.Log); m != nil {
+			p.priority = [2]string{strings.ToUpper(m[1]), strings.ToUpper(m[2])}
+		} else if m := priorityTo.FindStringSubmatch(l.Log); m != nil {
+			p.priority = [2]string{"", strings.ToUpper(m[1])}
+		}
+		if p.priority[1] != "" {
+			// A priority change is not a status change, whatever its wording.
+			p.status = ""
+		}
+		if m := ownerTo.FindStringSubmatch(l.Log); m != nil {
+			p.owner = m[1]
+		}
+		if m := tagList.FindStringSubmatch(l.Log); m != nil {
+			lower := strings.ToLower(l.Log)
+			if strings.Contains(lower, "add") || strings.Contains(lower, "remov") {
+				p.tags = &tagEdit{add: !strings.Contains(lower, "remov"), tags: splitTags(m[1])}
+				p.status = ""
+			}
+		}
+		if p.owner != "" {
+			p.status = ""
+		}
+		entries = append(entries, p)
+	}
+
+	// Work back from the current values to those at creation.
+	priority := a.Priority
+	tags := map[string]bool{}
+	for _, t := range a.Tags {
+		tags[t] = true
+	}
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		if e.priority[1] != "" {
+			priority = e.priority[0]
+		}
+		if e.tags != nil {
+			for _, t := range e.tags.tags {
+				tags[t] = !e.tags.add
+			}
+		}
+	}
+
+	created := a.CreatedAt
+	changes := []FieldChange{{Time: created, Actor: a.Source, Field: FieldStatus, To: "open"}}
+	if priority != "" {
+		changes = append(changes, FieldChange{Time: created, Actor: a.Source, Field: FieldPriority, To: priority})
+	}
+	if current := tagString(tags); current != "" {
+		changes = append(changes, FieldChange{Time: created, Actor: a.Source, Field: FieldTags, To: current})
+	}
+
+	status, owner := "open", ""
+	for _, e := range entries {
+		add := func(field, from, to string) {
+			changes = append(changes, FieldChange{Time: e.log.CreatedAt, Actor: e.log.Owner, Field: field, From: from, To: to, Log: e.log.Log})
+		}
+		if e.status != "" && e.status != status {
+			add(FieldStatus, status, e.status)
+			status = e.status
+		}
+		if to := e.priority[1]; to != "" && to != priority {
+			from := priority
+			if e.priority[0] != "" {
+				from = e.priority[0]
+			}
+			add(FieldPriority, from, to)
+			priority = to
+		}
+		if e.owner != "" && e.owner != owner {
+			add(FieldOwner, owner, e.owner)
+			owner = e.owner
+		}
+		if e.tags != nil {
+			before := tagString(tags)
+			for _, t := range e.tags.tags {
+				tags[t] = e.tags.add
+			}
+			if after := tagString(tags); after != before {
+				add(FieldTags, before, after)
+			}
+		}
+	}
+	return changes
+}
+
+// splitTags splits a log's "[a, b]" tag list.
+func splitTags(s string) []string {
+	var out []string
+	for _, t := range strings.Split(s, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// tagString lists the tags set in tags, sorted and comma-separated.
+func tagString(tags map[string]bool) string {
+	var out []string
+	for t, on := range tags {
+		if on {
+			out = append(out, t)
+		}
+	}
+	sort.Strings(out)
+	return strings.Join(out, ", ")
+}
@@ -0,0 +1,182 @@
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"mime"
+	"strings"
+	"time"
+)
+
+// Title returns the default heading for a digest.
+func (d Digest) Title() string {
+	return fmt.Sprintf("OpsGenie digest: %s – %s",
+		d.Start.Format("Jan 2"), d.End.Add(-time.Second).Format("Jan 2, 2006"))
+}
+
+// trendText renders the week-over-week change as a short phrase.
+func (d Digest) trendText() string {
+	pct, ok := d.Trend()
+	if !ok {
+		return "no data for the previous period"
+	}
+	return fmt.Sprintf("%+.0f%% vs previous period (%d)", pct, d.PreviousTotal)
+}
+
+// formatDuration renders a duration compactly, or "n/a" when unset.
+func formatDuration(dur time.Duration) string {
+	if dur <= 0 {
+		return "n/a"
+	}
+	return dur.String()
+}
+
+// bar renders a proportional text bar for the daily volume chart.
+func bar(count, max int) string {
+	const width = 20
+	if max == 0 || count == 0 {
+		return ""
+	}
+	n := count * width / max
+	if n == 0 {
+		n = 1
+	}
+	return strings.Repeat("█", n)
+}
+
+func (d Digest) maxDaily() int {
+	max := 0
+	for _, day := range d.Daily {
+		if day.Count > max {
+			max = day.Count
+		}
+	}
+	return max
+}
+
+// RenderMarkdown writes the digest as GitHub-flavoured Markdown, suitable for
+// Slack, chat webhooks or a plain-text email body.
+func RenderMarkdown(w io.Writer, d Digest) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", d.Title())
+
+	b.WriteString("## Alert volume\n\n")
+	fmt.Fprintf(&b, "**%d alerts** — %s\n\n", d.TotalAlerts, d.trendText())
+	b.WriteString("| Day | Alerts | |\n|-----|-------:|---|\n")
+	max := d.maxDaily()
+	for _, day := range d.Daily {
+		fmt.Fprintf(&b, "| %s | %d | %s |\n", day.Date, day.Count, bar(day.Count, max))
+	}
+
+	b.WriteString("\n## Top noisy services\n\n")
+	if len(d.TopServices) == 0 {
+		b.WriteString("_No alerts in this period._\n")
+	} else {
+		b.WriteString("| Service | Alerts |\n|---------|-------:|\n")
+		for _, s := range d.TopServices {
+			fmt.Fprintf(&b, "| %s | %d |\n", s.Service, s.Count)
+		}
+	}
+
+	b.WriteString("\n## Response times\n\n")
+	fmt.Fprintf(&b, "- **MTTA:** %s (%d acknowledged)\n", formatDuration(d.MTTA), d.Acknowledged)
+	fmt.Fprintf(&b, "- **MTTR:** %s (%d closed)\n", formatDuration(d.MTTR), d.Closed)
+
+	if len(d.OnCall) > 0 {
+		b.WriteString("\n## Upcoming on-call\n\n")
+		b.WriteString("| Schedule | Rotation | Recipient | Start | End |\n|----------|----------|-----------|-------|-----|\n")
+		for _, s := range d.OnCall {
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n", s.Schedule, s.Rotation, s.Recipient, s.Start, s.End)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+var htmlTmpl = template.Must(template.New("digest").Funcs(template.FuncMap{
+	"duration": formatDuration,
+	"bar":      bar,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>{{.D.Title}}</title></head>
+<body style="font-family: -apple-system, Helvetica, Arial, sans-serif; color: #172b4d;">
+<h1>{{.D.Title}}</h1>
+<h2>Alert volume</h2>
+<p><strong>{{.D.TotalAlerts}} alerts</strong> &mdash; {{.Trend}}</p>
+<table cellpadding="4" cellspacing="0">
+<tr><th align="left">Day</th><th align="right">Alerts</th><th></th></tr>
+{{- range .D.Daily}}
+<tr><td>{{.Date}}</td><td align="right">{{.Count}}</td><td style="color: #0052cc;">{{bar .Count $.Max}}</td></tr>
+{{- end}}
+</table>
+<h2>Top noisy services</h2>
+{{- if .D.TopServices}}
+<table cellpadding="4" cellspacing="0">
+<tr><th align="left">Service</th><th align="right">Alerts</th></tr>
+{{- range .D.TopServices}}
+<tr><td>{{.Service}}</td><td align="right">{{.Count}}</td></tr>
+{{- end}}
+</table>
+{{- else}}
+<p><em>No alerts in this period.</em></p>
+{{- end}}
+<h2>Response times</h2>
+<ul>
+<li><strong>MTTA:</strong> {{duration .D.MTTA}} ({{.D.Acknowledged}} acknowledged)</li>
+<li><strong>MTTR:</strong> {{duration .D.MTTR}} ({{.D.Closed}} closed)</li>
+</ul>
+{{- if .D.OnCall}}
+<h2>Upcoming on-call</h2>
+<table cellpadding="4" cellspacing="0">
+<tr><th align="left">Schedule</th><th align="left">Rotation</th><th align="left">Recipient</th><th align="left">Start</th><th align="left">End</th></tr>
+{{- range .D.OnCall}}
+<tr><td>{{.Schedule}}</td><td>{{.Rotation}}</td><td>{{.Recipient}}</td><td>{{.Start}}</td><td>{{.End}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+</body>
+</html>
+`))
+
+// RenderHTML writes the digest as a standalone HTML document with inline
+// styles, so it renders in mail clients that strip <style> blocks.
+func RenderHTML(w io.Writer, d Digest) error {
+	return htmlTmpl.Execute(w, struct {
+		D     Digest
+		Trend string
+		Max   int
+	}{d, d.trendText(), d.maxDaily()})
+}
+
+// EmailHeaders are the RFC 5322 headers written ahead of an email body.
+type EmailHeaders struct {
+	From    string
+	To      []string
+	Subject string
+}
+
+// RenderEmail writes a complete HTML email message (headers followed by the
+// HTML digest) that can be piped straight into `sendmail -t`.
+func RenderEmail(w io.Writer, d Digest, h EmailHeaders) error {
+	subject := h.Subject
+	if subject == "" {
+		subject = d.Title()
+	}
+	var b strings.Builder
+	if h.From != "" {
+		fmt.Fprintf(&b, "From: %s\r\n", h.From)
+	}
+	if len(h.To) > 0 {
+		fmt.Fprintf(&b, "To: %s\r\n", strings.Join(h.To, ", "))
+	}
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/html; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return err
+	}
+	return RenderHTML(w, d)
+}
@@ -0,0 +1,293 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+)
+
+var (
+	testStart = time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	testEnd   = testStart.AddDate(0, 0, 7)
+)
+
+func alertAt(ts time.Time, service string, ackMs, closeMs int64) api.AlertResponse {
+	a := api.AlertResponse{
+		ID:        ts.Format(time.RFC3339),
+		Source:    "datadog",
+		Tags:      []string{"env:prod"},
+		CreatedAt: ts.Format(time.RFC3339Nano),
+	}
+	if service != "" {
+		a.Tags = append(a.Tags, "service:"+service)
+	}
+	if ackMs > 0 || closeMs > 0 {
+		a.Report = &api.AlertReport{AckTime: ackMs, CloseTime: closeMs}
+	}
+	return a
+}
+
+func testAlerts() []api.AlertResponse {
+	return []api.AlertResponse{
+		// Previous period
+		alertAt(testStart.AddDate(0, 0, -3), "search", 0, 0),
+		alertAt(testStart.AddDate(0, 0, -1), "search", 0, 0),
+		// Current period
+		alertAt(testStart.Add(1*time.Hour), "checkout", 60_000, 600_000),
+		alertAt(testStart.Add(2*time.Hour), "checkout", 120_000, 0),
+		alertAt(testStart.AddDate(0, 0, 2), "checkout", 0, 0),
+		alertAt(testStart.AddDate(0, 0, 2), "search", 0, 1_200_000),
+		alertAt(testStart.AddDate(0, 0, 6), "", 0, 0),
+		// Outside both windows
+		alertAt(testEnd.Add(time.Minute), "checkout", 0, 0),
+		{ID: "bad-date", CreatedAt: "not a time"},
+	}
+}
+
+func TestBuild_Counts(t *testing.T) {
+	d := Build(testAlerts(), testStart, testEnd, 0, "service")
+
+	if d.TotalAlerts != 5 {
+		t.Errorf("TotalAlerts = %d, want 5", d.TotalAlerts)
+	}
+	if d.PreviousTotal != 2 {
+		t.Errorf("PreviousTotal = %d, want 2", d.PreviousTotal)
+	}
+	if len(d.Daily) != 7 {
+		t.Fatalf("len(Daily) = %d, want 7", len(d.Daily))
+	}
+	wantDaily := []int{2, 0, 2, 0, 0, 0, 1}
+	for i, want := range wantDaily {
+		if d.Daily[i].Count != want {
+			t.Errorf("Daily[%d] (%s) = %d, want %d", i, d.Daily[i].Date, d.Daily[i].Count, want)
+		}
+	}
+	if d.Daily[0].Date != "2024-03-04" {
+		t.Errorf("Daily[0].Date = %q, want 2024-03-04", d.Daily[0].Date)
+	}
+}
+
+func TestBuild_TopServices(t *testing.T) {
+	d := Build(testAlerts(), testStart, testEnd, 2, "service")
+
+	if len(d.TopServices) != 2 {
+		t.Fatalf("len(TopServices) = %d, want 2", len(d.TopServices))
+	}
+	if d.TopServices[0].Service != "checkout" || d.TopServices[0].Count != 3 {
+		t.Errorf("TopServices[0] = %+v, want checkout/3", d.TopServices[0])
+	}
+	// NoService and "search" both have 1; ties sort by name.
+	if d.TopServices[1].Service != NoService {
+		t.Errorf("TopServices[1] = %+v, want %s", d.TopServices[1], NoService)
+	}
+}
+
+func TestBuild_ServiceTagKey(t *testing.T) {
+	a := alertAt(testStart, "", 0, 0)
+	a.Tags = append(a.Tags, "svc:billing", "service:ignored")
+	d := Build([]api.AlertResponse{a}, testStart, testEnd, 0, "svc")
+	if len(d.TopServices) != 1 || d.TopServices[0].Service != "billing" {
+		t.Errorf("TopServices = %+v, want billing from the svc: tag", d.TopServices)
+	}
+}
+
+func TestBuild_ResponseTimes(t *testing.T) {
+	d := Build(testAlerts(), testStart, testEnd, 0, "service")
+
+	if d.Acknowledged != 2 {
+		t.Errorf("Acknowledged = %d, want 2", d.Acknowledged)
+	}
+	if d.MTTA != 90*time.Second {
+		t.Errorf("MTTA = %s, want 1m30s", d.MTTA)
+	}
+	if d.Closed != 2 {
+		t.Errorf("Closed = %d, want 2", d.Closed)
+	}
+	if d.MTTR != 15*time.Minute {
+		t.Errorf("MTTR = %s, want 15m0s", d.MTTR)
+	}
+	if d.MTTRSeconds != 900 {
+		t.Errorf("MTTRSeconds = %v, want 900", d.MTTRSeconds)
+	}
+}
+
+func TestTrend(t *testing.T) {
+	d := Digest{TotalAlerts: 15, PreviousTotal: 10}
+	pct, ok := d.Trend()
+	if !ok || pct != 50 {
+		t.Errorf("Trend() = %v, %v; want 50, true", pct, ok)
+	}
+	if _, ok := (Digest{TotalAlerts: 3}).Trend(); ok {
+		t.Error("Trend() with no previous data should report ok=false")
+	}
+}
+
+func TestShiftsFromTimeline(t *testing.T) {
+	tl := api.ScheduleTimeline{
+		FinalTimeline: api.TimelineLayer{Rotations: []api.TimelineRotation{
+			{Name: "weekly", Periods: []api.TimelinePeriod{
+				{StartDate: "2024-03-11T09:00:00Z", EndDate: "2024-03-18T09:00:00Z", Recipient: api.Responder{Name: "bob@example.com"}},
+			}},
+			{Name: "weekend", Periods: []api.TimelinePeriod{
+				{StartDate: "2024-03-09T09:00:00Z", EndDate: "2024-03-11T09:00:00Z", Recipient: api.Responder{ID: "u-1"}},
+			}},
+		}},
+	}
+	shifts := ShiftsFromTimeline("Primary", tl)
+	if len(shifts) != 2 {
+		t.Fatalf("len(shifts) = %d, want 2", len(shifts))
+	}
+	if shifts[0].Rotation != "weekend" || shifts[0].Recipient != "u-1" {
+		t.Errorf("shifts[0] = %+v, want weekend rotation with ID fallback", shifts[0])
+	}
+	if shifts[1].Schedule != "Primary" || shifts[1].Recipient != "bob@example.com" {
+		t.Errorf("shifts[1] = %+v", shifts[1])
+	}
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	d := Build(testAlerts(), testStart, testEnd, 5, "service")
+	d.OnCall = []Shift{{Schedule: "Primary", Rotation: "weekly", Recipient: "bob@example.com", Start: "s", End: "e"}}
+
+	var buf bytes.Buffer
+	if err := RenderMarkdown(&buf, d); err != nil {
+		t.Fatalf("RenderMarkdown: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"# OpsGenie digest: Mar 4 – Mar 10, 2024",
+		"**5 alerts** — +150% vs previous period (2)",
+		"| 2024-03-04 | 2 |",
+		"| checkout | 3 |",
+		"**MTTA:** 1m30s (2 acknowledged)",
+		"**MTTR:** 15m0s (2 closed)",
+		"## Upcoming on-call",
+		"| Primary | weekly | bob@example.com |",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("markdown output missing %q\n%s", want, out)
+		}
+	}
+}
+
+func TestRenderMarkdown_Empty(t *testing.T) {
+	d := Build(nil, testStart, testEnd, 5, "service")
+	var buf bytes.Buffer
+	if err := RenderMarkdown(&buf, d); err != nil {
+		t.Fatalf("RenderMarkdown: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "No alerts in this period") {
+		t.Errorf("expected empty-period note, got:\n%s", out)
+	}
+	if !strings.Contains(out, "MTTA:** n/a") {
+		t.Errorf("expected n/a MTTA, got:\n%s", out)
+	}
+	if strings.Contains(out, "Upcoming on-call") {
+		t.Error("on-call section should be omitted when there are no shifts")
+	}
+}
+
+func TestRenderHTML_EscapesContent(t *testing.T) {
+	d := Build([]api.AlertResponse{alertAt(testStart, "<script>", 0, 0)}, testStart, testEnd, 5, "service")
+	var buf bytes.Buffer
+	if err := RenderHTML(&buf, d); err != nil {
+		t.Fatalf("RenderHTML: %v", err)
+	}
+	out := buf.String()
+	if strings.Contains(out, "<script>") {
+		t.Error("service name should be HTML-escaped")
+	}
+	if !strings.Contains(out, "&lt;script&gt;") {
+		t.Errorf("expected escaped service in output:\n%s", out)
+	}
+	if !strings.HasPrefix(out, "<!DOCTYPE html>") {
+		t.Error("expected a standalone HTML document")
+	}
+}
+
+func TestRenderEmail_Headers(t *testing.T) {
+	d := Build(nil, testStart, testEnd, 5, "service")
+	var buf bytes.Buffer
+	err := RenderEmail(&buf, d, EmailHeaders{
+		From: "opsgenie@example.com",
+		To:   []string{"a@example.com", "b@example.com"},
+	})
+	if err != nil {
+		t.Fatalf("RenderEmail: %v", err)
+	}
+	out := buf.String()
+	head, body, ok := strings.Cut(out, "\r\n\r\n")
+	if !ok {
+		t.Fatalf("expected blank line between headers and body:\n%s", out)
+	}
+	for _, want := range []string{
+		"From: opsgenie@example.com",
+		"To: a@example.com, b@example.com",
+		"Subject: =?utf-8?q?",
+		"Content-Type: text/html; charset=UTF-8",
+	} {
+		if !strings.Contains(head, want) {
+			t.Errorf("headers missing %q:\n%s", want, head)
+		}
+	}
+	if !strings.HasPrefix(body, "<!DOCTYPE html>") {
+		t.Errorf("body should be the HTML digest, got:\n%s", body)
+	}
+}
+
+func TestAnalyzeHeartbeat(t *testing.T) {
+	closed := func(openMin int64) api.AlertResponse {
+		return api.AlertResponse{Status: "closed", Report: &api.AlertReport{CloseTime: openMin * 60 * 1000}}
+	}
+	alerts := []api.AlertResponse{
+		closed(5), closed(12), closed(25), closed(3), // late pings of a 30m heartbeat
+		closed(240),      // outage
+		{Status: "open"}, // still expired
+	}
+	a := AnalyzeHeartbeat(alerts, 30*time.Minute, time.Minute, 0.75)
+	if a.Expiries != 6 || a.LatePings != 4 || a.Outages != 1 || a.Open != 1 {
+		t.Errorf("counts = %+v", a)
+	}
+	if a.LongestGap != 55*time.Minute {
+		t.Errorf("longest gap = %s, want 55m", a.LongestGap)
+	}
+	// 75% of gaps (33m, 35m, 42m, 55m) are covered by 42m, plus a minute.
+	if a.Recommended != 43*time.Minute {
+		t.Errorf("recommended = %s, want 43m", a.Recommended)
+	}
+
+	if got := AnalyzeHeartbeat(nil, time.Hour, time.Hour, 0.9); got.Recommended != time.Hour {
+		t.Errorf("recommended without alerts = %s, want the current interval", got.Recommended)
+	}
+}
+
+func TestAlertHistory(t *testing.T) {
+	a := api.AlertResponse{Priority: "P1", Tags: []string{"db", "prod"}, Source: "api", CreatedAt: "2024-01-15T10:00:00Z"}
+	logs := []api.AlertLog{
+		{Log: "Alert closed by alice@example.com", Owner: "alice@example.com", CreatedAt: "2024-01-15T10:30:00Z"},
+		{Log: "Alert acknowledged via Web", Owner: "alice@example.com", CreatedAt: "2024-01-15T10:05:00Z"},
+		{Log: "Priority changed from P3 to P1", Owner: "bob@example.com", CreatedAt: "2024-01-15T10:10:00Z"},
+		{Log: "Added tags [db]", Owner: "bob@example.com", CreatedAt: "2024-01-15T10:11:00Z"},
+		{Log: "Alert is assigned to carol@example.com", Owner: "bob@example.com", CreatedAt: "2024-01-15T10:12:00Z"},
+		{Log: "Alert notified to alice@example.com via email", Owner: "System", CreatedAt: "2024-01-15T10:01:00Z"},
+	}
+	var got []string
+	for _, c := range AlertHistory(a, logs) {
+		got = append(got, c.Field+":"+c.From+">"+c.To)
+	}
+	want := []string{
+		"status:>open", "priority:>P3", "tags:>prod",
+		"status:open>acknowledged",
+		"priority:P3>P1",
+		"tags:prod>db, prod",
+		"owner:>carol@example.com",
+		"status:acknowledged>closed",
+	}
+	if strings.Join(got, " | ") != strings.Join(want, " | ") {
+		t.Errorf("history =\n  %s\nwant\n  %s", strings.Join(got, " | "), strings.Join(want, " | "))
+	}
+}
@@ -0,0 +1,136 @@
+package routing
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+)
+
+// RuleOutcome is how one notification rule treats a simulated alert:
+// whether it fires and, if it does, the notifications it sends.
+type RuleOutcome struct {
+	RuleID string `json:"ruleId"`
+	Rule   string `json:"rule"`
+	Order  int    `json:"order"`
+	Fires  bool   `json:"fires"`
+	// Reason says why a rule does not fire.
+	Reason string         `json:"reason,omitempty"`
+	Sends  []Notification `json:"sends,omitempty"`
+	// RepeatEvery is how often the sends repeat until the alert is
+	// acknowledged, or 0 when they do not.
+	RepeatEvery time.Duration `json:"-"`
+	RepeatMins  int           `json:"repeatEveryMinutes,omitempty"`
+}
+
+// Notification is one contact a firing rule notifies, After the alert.
+type Notification struct {
+	After     time.Duration `json:"-"`
+	AfterMins int           `json:"afterMinutes"`
+	At        time.Time     `json:"at"`
+	Method    string        `json:"method"`
+	To        string        `json:"to,omitempty"`
+}
+
+// SimulateNotifications walks a user's notification rules for actionType
+// in order, as OpsGenie does for an alert a raised at t: the first enabled
+// rule whose conditions, time restriction (in the user's location loc) and
+// schedules match fires, and the rules after it are not reached. schedule
+// names the schedule whose on-call started or ended, for schedule-start
+// and schedule-end rules. Rules for other actions are left out; every
+// other rule gets an outcome, with the reason it does not fire.
+func SimulateNotifications(rules []api.NotificationRuleResponse, actionType string, a api.AlertResponse, schedule string, t time.Time, loc *time.Location) []RuleOutcome {
+	var matching []api.NotificationRuleResponse
+	for _, r := range rules {
+		if r.ActionType == actionType {
+			matching = append(matching, r)
+		}
+	}
+	sort.SliceStable(matching, func(i, j int) bool { return matching[i].Order < matching[j].Order })
+
+	out := []RuleOutcome{}
+	fired := ""
+	for _, r := range matching {
+		o := RuleOutcome{RuleID: r.ID, Rule: r.Name, Order: r.Order}
+		if o.Rule == "" {
+			o.Rule = r.ID
+		}
+		switch {
+		case fired != "":
+			o.Reason = "not reached: " + fired + " applies"
+		case !r.Enabled:
+			o.Reason = "disabled"
+		case !Match(r.Criteria, a):
+			o.Reason = "conditions do not match"
+		case !InTime(r.TimeRestriction, t, loc):
+			o.Reason = "outside its time restriction"
+		case !forSchedule(r.Schedules, schedule):
+			o.Reason = "only for schedules " + scheduleNames(r.Schedules)
+		default:
+			o.Fires, fired = true, o.Rule
+			o.Sends = sends(r.Steps, t)
+			if r.Repeat != nil && r.Repeat.Enabled && r.Repeat.LoopAfter > 0 {
+				o.RepeatEvery = time.Duration(r.Repeat.LoopAfter) * time.Minute
+				o.RepeatMins = r.Repeat.LoopAfter
+			}
+			if len(o.Sends) == 0 {
+				o.Reason = "fires, but has no enabled steps"
+			}
+		}
+		out = append(out, o)
+	}
+	return out
+}
+
+// sends returns the notifications of a rule's enabled steps, by delay.
+func sends(steps []api.NotificationStep, t time.Time) []Notification {
+	var out []Notification
+	for _, s := range steps {
+		if !s.Enabled {
+			continue
+		}
+		d := delay(s.SendAfter)
+		out = append(out, Notification{After: d, AfterMins: int(d / time.Minute), At: t.Add(d), Method: s.Contact.Method, To: s.Contact.To})
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].After < out[j].After })
+	return out
+}
+
+// delay converts an API time amount to a duration; the unit defaults to
+// minutes.
+func delay(d api.DelayInfo) time.Duration {
+	unit := time.Minute
+	switch d.TimeUnit {
+	case "hours":
+		unit = time.Hour
+	case "days":
+		unit = 24 * time.Hour
+	}
+	return time.Duration(d.TimeAmount) * unit
+}
+
+// forSchedule reports whether a rule limited to schedules applies to
+// schedule, by ID or name. A rule without schedules applies to all.
+func forSchedule(schedules []api.TeamRef, schedule string) bool {
+	if len(schedules) == 0 {
+		return true
+	}
+	for _, s := range schedules {
+		if schedule != "" && (s.ID == schedule || strings.EqualFold(s.Name, schedule)) {
+			return true
+		}
+	}
+	return false
+}
+
+func scheduleNames(schedules []api.TeamRef) string {
+	names := make([]string, len(schedules))
+	for i, s := range schedules {
+		names[i] = s.Name
+		if names[i] == "" {
+			names[i] = s.ID
+		}
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,69 @@
+package routing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+)
+
+func TestSimulateNotifications(t *testing.T) {
+	step := func(method, to string, after int, enabled bool) api.NotificationStep {
+		return api.NotificationStep{Contact: api.NotificationContact{Method: method, To: to}, SendAfter: api.DelayInfo{TimeAmount: after}, Enabled: enabled}
+	}
+	p1 := &api.Filter{Type: "match-all-conditions", Conditions: []api.Condition{{Field: "priority", Operation: "equals", ExpectedValue: "P1"}}}
+	nights := &api.TimeRestriction{Type: "time-of-day", Restriction: &api.TimeRange{StartHour: 22, EndHour: 7}}
+	rules := []api.NotificationRuleResponse{
+		{ID: "r4", Name: "Fallback", ActionType: "create-alert", Order: 4, Enabled: true, Steps: []api.NotificationStep{step("email", "a@example.com", 0, true)}},
+		{ID: "r1", Name: "Old", ActionType: "create-alert", Order: 1, Enabled: false},
+		{ID: "r2", Name: "P1 only", ActionType: "create-alert", Order: 2, Enabled: true, Criteria: p1},
+		{ID: "r3", Name: "Nights", ActionType: "create-alert", Order: 3, Enabled: true, TimeRestriction: nights,
+			Steps:  []api.NotificationStep{step("voice", "+15550100", 5, true), step("sms", "+15550100", 0, true), step("email", "a@example.com", 1, false)},
+			Repeat: &api.NotificationRepeat{LoopAfter: 10, Enabled: true}},
+		{ID: "r5", Name: "Closed", ActionType: "closed-alert", Order: 1, Enabled: true},
+	}
+	alert := api.AlertResponse{Message: "Disk full", Priority: "P3"}
+	at := time.Date(2024, 6, 3, 3, 0, 0, 0, time.UTC)
+
+	got := SimulateNotifications(rules, "create-alert", alert, "", at, time.UTC)
+	if len(got) != 4 {
+		t.Fatalf("got %d outcomes, want the 4 create-alert rules: %+v", len(got), got)
+	}
+	wantReasons := []string{"disabled", "conditions do not match", "", "not reached: Nights applies"}
+	for i, o := range got {
+		if o.Reason != wantReasons[i] {
+			t.Errorf("%s: reason = %q, want %q", o.Rule, o.Reason, wantReasons[i])
+		}
+	}
+	nightsOut := got[2]
+	if !nightsOut.Fires || nightsOut.RepeatEvery != 10*time.Minute {
+		t.Fatalf("Nights should fire and repeat every 10m: %+v", nightsOut)
+	}
+	if len(nightsOut.Sends) != 2 || nightsOut.Sends[0].Method != "sms" || nightsOut.Sends[1].Method != "voice" {
+		t.Fatalf("sends = %+v, want sms then voice (disabled email left out)", nightsOut.Sends)
+	}
+	if want := at.Add(5 * time.Minute); !nightsOut.Sends[1].At.Equal(want) {
+		t.Errorf("voice at %s, want %s", nightsOut.Sends[1].At, want)
+	}
+
+	// At noon the night rule is skipped and the fallback fires.
+	got = SimulateNotifications(rules, "create-alert", alert, "", at.Add(9*time.Hour), time.UTC)
+	if got[2].Reason != "outside its time restriction" || !got[3].Fires {
+		t.Errorf("at noon: %+v", got)
+	}
+}
+
+func TestSimulateNotifications_Schedules(t *testing.T) {
+	rules := []api.NotificationRuleResponse{{
+		ID: "r1", Name: "Shift start", ActionType: "schedule-start", Enabled: true,
+		Schedules: []api.TeamRef{{ID: "s1", Name: "Primary"}},
+		Steps:     []api.NotificationStep{{Contact: api.NotificationContact{Method: "mobile"}, Enabled: true}},
+	}}
+	at := time.Now()
+	if got := SimulateNotifications(rules, "schedule-start", api.AlertResponse{}, "primary", at, time.UTC); !got[0].Fires {
+		t.Errorf("rule for Primary should fire: %+v", got[0])
+	}
+	if got := SimulateNotifications(rules, "schedule-start", api.AlertResponse{}, "Secondary", at, time.UTC); got[0].Reason != "only for schedules Primary" {
+		t.Errorf("reason = %q", got[0].Reason)
+	}
+}
@@ -0,0 +1,237 @@
+// Package routing evaluates OpsGenie routing rule and policy filters against
+// an alert and classifies alert log entries, to explain after the fact how
+// an alert was routed. It works from the current configuration, which may
+// have changed since the alert was created. It also simulates a user's
+// notification rules for a hypothetical alert.
+package routing
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+)
+
+// Match reports whether alert a passes filter f. A nil filter, or one with
+// no conditions, matches everything. String comparisons ignore case, as
+// OpsGenie's do.
+func Match(f *api.Filter, a api.AlertResponse) bool {
+	if f == nil || f.Type == "match-all" || len(f.Conditions) == 0 {
+		return true
+	}
+	matchAny := f.Type == "match-any-condition"
+	for _, c := range f.Conditions {
+		ok := matchCondition(c, a)
+		if matchAny && ok {
+			return true
+		}
+		if !matchAny && !ok {
+			return false
+		}
+	}
+	return !matchAny
+}
+
+func matchCondition(c api.Condition, a api.AlertResponse) bool {
+	values, list := fieldValues(c, a)
+	op := strings.ToLower(c.Operation)
+	want := strings.ToLower(c.ExpectedValue)
+
+	var ok bool
+	switch {
+	case op == "is-empty":
+		ok = len(values) == 0 || (!list && values[0] == "")
+	case op == "contains-key":
+		for k := range a.Details {
+			if strings.EqualFold(k, c.ExpectedValue) {
+				ok = true
+			}
+		}
+	case op == "contains-value":
+		for _, v := range a.Details {
+			if strings.EqualFold(v, c.ExpectedValue) {
+				ok = true
+			}
+		}
+	default:
+		// A list "contains" an element; a string contains a substring.
+		if list && op == "contains" {
+			op = "equals"
+		}
+		for _, v := range values {
+			if compare(op, strings.ToLower(v), want, c.Field) {
+				ok = true
+				break
+			}
+		}
+	}
+	return ok != c.Not
+}
+
+// fieldValues returns the alert values a condition tests, and whether the
+// field is a list.
+func fieldValues(c api.Condition, a api.AlertResponse) ([]string, bool) {
+	switch c.Field {
+	case "message":
+		return []string{a.Message}, false
+	case "alias":
+		return []string{a.Alias}, false
+	case "description":
+		return []string{a.Description}, false
+	case "source":
+		return []string{a.Source}, false
+	case "entity":
+		return []string{a.Entity}, false
+	case "priority":
+		return []string{a.Priority}, false
+	case "tags":
+		return a.Tags, true
+	case "actions":
+		return a.Actions, true
+	case "details", "extra-properties":
+		if c.Key == "" {
+			var out []string
+			for k, v := range a.Details {
+				out = append(out, k+"="+v)
+			}
+			return out, true
+		}
+		for k, v := range a.Details {
+			if strings.EqualFold(k, c.Key) {
+				return []string{v}, false
+			}
+		}
+		return nil, false
+	case "teams":
+		var out []string
+		for _, t := range a.Teams {
+			out = append(out, t.Name, t.ID)
+		}
+		return out, true
+	case "recipients":
+		var out []string
+		for _, r := range a.Responders {
+			out = append(out, r.Name, r.Username, r.ID)
+		}
+		return out, true
+	}
+	return nil, false
+}
+
+// compare applies a string operation to lowercased v and want.
+func compare(op, v, want, field string) bool {
+	switch op {
+	case "equals":
+		return v == want
+	case "equals-ignore-whitespace":
+		return strings.Join(strings.Fields(v), "") == strings.Join(strings.Fields(want), "")
+	case "contains":
+		return strings.Contains(v, want)
+	case "starts-with":
+		return strings.HasPrefix(v, want)
+	case "ends-with":
+		return strings.HasSuffix(v, want)
+	case "matches":
+		re, err := regexp.Compile("(?i)^(?:" + want + ")$")
+		return err == nil && re.MatchString(v)
+	case "greater-than", "less-than":
+		x, errX := number(v, field)
+		y, errY := number(want, field)
+		if errX != nil || errY != nil {
+			return false
+		}
+		if op == "greater-than" {
+			return x > y
+		}
+		return x < y
+	}
+	return false
+}
+
+// number parses a numeric condition value. Priorities compare by severity,
+// so "priority greater-than p3" matches P1 and P2.
+func number(s, field string) (float64, error) {
+	if field == "priority" {
+		n, err := strconv.Atoi(strings.TrimPrefix(s, "p"))
+		return float64(-n), err
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// InTime reports whether t falls inside restriction r, in the location
+// loc. A nil restriction always applies.
+func InTime(r *api.TimeRestriction, t time.Time, loc *time.Location) bool {
+	if r == nil {
+		return true
+	}
+	if loc != nil {
+		t = t.In(loc)
+	}
+	switch r.Type {
+	case "time-of-day":
+		if r.Restriction == nil {
+			return true
+		}
+		now := t.Hour()*60 + t.Minute()
+		return inWindow(now, r.Restriction.StartHour*60+r.Restriction.StartMin, r.Restriction.EndHour*60+r.Restriction.EndMin)
+	case "weekday-and-time-of-day":
+		now := weekMinute(strings.ToLower(t.Weekday().String()), t.Hour(), t.Minute())
+		for _, w := range r.Restrictions {
+			if inWindow(now, weekMinute(w.StartDay, w.StartHour, w.StartMin), weekMinute(w.EndDay, w.EndHour, w.EndMin)) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// inWindow reports whether now lies in [start, end), wrapping around when
+// end is not after start.
+func inWindow(now, start, end int) bool {
+	if start < end {
+		return now >= start && now < end
+	}
+	return now >= start || now < end
+}
+
+var weekdayIndex = map[string]int{
+	"monday": 0, "tuesday": 1, "wednesday": 2, "thursday": 3,
+	"friday": 4, "saturday": 5, "sunday": 6,
+}
+
+func weekMinute(day string, hour, min int) int {
+	return weekdayIndex[strings.ToLower(day)]*24*60 + hour*60 + min
+}
+
+// Log entry kinds returned by Classify.
+const (
+	KindRouting      = "routing"
+	KindPolicy       = "policy"
+	KindEscalation   = "escalation"
+	KindNotification = "notify"
+	KindAction       = "action"
+	KindOther        = "log"
+)
+
+// Classify returns what kind of event an alert log entry records, judged
+// by its wording.
+func Classify(log string) string {
+	l := strings.ToLower(log)
+	switch {
+	case strings.Contains(l, "routing rule") || strings.Contains(l, "routed"):
+		return KindRouting
+	case strings.Contains(l, "policy"):
+		return KindPolicy
+	case strings.Contains(l, "escalat"):
+		return KindEscalation
+	case strings.Contains(l, "notif") || strings.Contains(l, " sent to") || strings.Contains(l, "via sms") || strings.Contains(l, "voice"):
+		return KindNotification
+	case strings.Contains(l, "acknowledged") || strings.Contains(l, "closed") || strings.Contains(l, "snoozed") ||
+		strings.Contains(l, "assigned") || strings.Contains(l, "added") || strings.Contains(l, "removed"):
+		return KindAction
+	}
+	return KindOther
+}
@@ -0,0 +1,89 @@
+package routing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/roboalchemist/opsgenie-cli/pkg/api"
+)
+
+func TestMatch(t *testing.T) {
+	a := api.AlertResponse{
+		Message:  "Disk full on db-1",
+		Priority: "P2",
+		Tags:     []string{"prod", "database"},
+		Details:  map[string]string{"region": "eu-west-1"},
+	}
+	cond := func(field, op, value string) api.Condition {
+		return api.Condition{Field: field, Operation: op, ExpectedValue: value}
+	}
+	tests := []struct {
+		name string
+		f    *api.Filter
+		want bool
+	}{
+		{"nil", nil, true},
+		{"match-all", &api.Filter{Type: "match-all"}, true},
+		{"substring ignores case", &api.Filter{Type: "match-all-conditions", Conditions: []api.Condition{cond("message", "contains", "DISK")}}, true},
+		{"tag element", &api.Filter{Type: "match-all-conditions", Conditions: []api.Condition{cond("tags", "contains", "prod")}}, true},
+		{"tag is not a substring", &api.Filter{Type: "match-all-conditions", Conditions: []api.Condition{cond("tags", "contains", "data")}}, false},
+		{"detail by key", &api.Filter{Type: "match-all-conditions", Conditions: []api.Condition{{Field: "extra-properties", Key: "region", Operation: "starts-with", ExpectedValue: "eu-"}}}, true},
+		{"not", &api.Filter{Type: "match-all-conditions", Conditions: []api.Condition{{Field: "tags", Operation: "contains", ExpectedValue: "test", Not: true}}}, true},
+		{"all fails on one", &api.Filter{Type: "match-all-conditions", Conditions: []api.Condition{cond("message", "contains", "disk"), cond("source", "equals", "nagios")}}, false},
+		{"any passes on one", &api.Filter{Type: "match-any-condition", Conditions: []api.Condition{cond("source", "equals", "nagios"), cond("message", "matches", "disk.*")}}, true},
+		{"priority by severity", &api.Filter{Type: "match-all-conditions", Conditions: []api.Condition{cond("priority", "greater-than", "P3")}}, true},
+		{"empty field", &api.Filter{Type: "match-all-conditions", Conditions: []api.Condition{cond("description", "is-empty", "")}}, true},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.f, a); got != tt.want {
+			t.Errorf("%s: Match = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestInTime(t *testing.T) {
+	// Wednesday 2024-01-17 22:30 UTC, 23:30 in Berlin.
+	at := time.Date(2024, 1, 17, 22, 30, 0, 0, time.UTC)
+	night := &api.TimeRestriction{Type: "time-of-day", Restriction: &api.TimeRange{StartHour: 22, EndHour: 6}}
+	if !InTime(night, at, time.UTC) {
+		t.Error("22:30 should be inside 22:00-06:00")
+	}
+	hour := &api.TimeRestriction{Type: "time-of-day", Restriction: &api.TimeRange{StartHour: 22, EndHour: 23}}
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip(err)
+	}
+	if InTime(hour, at, berlin) {
+		t.Error("23:30 Berlin time should be outside 22:00-23:00")
+	}
+}
+
+func TestInTime_Weekdays(t *testing.T) {
+	weekend := &api.TimeRestriction{Type: "weekday-and-time-of-day", Restrictions: []api.TimeRange{
+		{StartDay: "friday", StartHour: 18, EndDay: "monday", EndHour: 8},
+	}}
+	sat := time.Date(2024, 1, 20, 12, 0, 0, 0, time.UTC)
+	wed := time.Date(2024, 1, 17, 12, 0, 0, 0, time.UTC)
+	if !InTime(weekend, sat, nil) {
+		t.Error("Saturday should be inside the weekend window")
+	}
+	if InTime(weekend, wed, nil) {
+		t.Error("Wednesday should be outside the weekend window")
+	}
+}
+
+func TestClassify(t *testing.T) {
+	tests := map[string]string{
+		"Alert is routed to team [Platform] by routing rule [Default]": KindRouting,
+		"Alert policy [Raise DB priority] applied":                     KindPolicy,
+		"Escalation [Platform_escalation] started":                     KindEscalation,
+		"Sent email notification to alice@example.com":                 KindNotification,
+		"Alert acknowledged via web":                                   KindAction,
+		"Alert created via API":                                        KindOther,
+	}
+	for log, want := range tests {
+		if got := Classify(log); got != want {
+			t.Errorf("Classify(%q) = %q, want %q", log, got, want)
+		}
+	}
+}
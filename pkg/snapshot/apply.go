@@ -0,0 +1,388 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Writer is the subset of api.Client used to apply configuration.
+type Writer interface {
+	Post(path string, body, result interface{}) error
+	Put(path string, body, result interface{}) error
+	Patch(path string, body, result interface{}) error
+}
+
+// Plan actions.
+const (
+	ActionCreate    = "create"
+	ActionUpdate    = "update"
+	ActionUnchanged = "unchanged"
+)
+
+// parentKey names the field a single-file import uses to name the schedule
+// or team that owns a nested resource.
+const parentKey = "_parent"
+
+// Change is one planned step of an apply.
+type Change struct {
+	Action string
+	// Resource is the desired state. ID and ParentID refer to the live
+	// objects when they already exist.
+	Resource Resource
+	// Fields lists the top-level fields that differ, for updates.
+	Fields []string
+}
+
+// Load reads resource definitions from an export directory, or from a
+// single JSON/YAML file mapping each kind to a list of resources, e.g.
+//
+//	teams:
+//	  - name: Platform
+//	rotations:
+//	  - _parent: Platform On-Call   # owning schedule
+//	    name: weekly
+func Load(path string) ([]Resource, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	var rs []Resource
+	if info.IsDir() {
+		rs, err = loadDir(path)
+	} else {
+		rs, err = loadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	sortResources(rs)
+	return rs, nil
+}
+
+// LoadKind reads definitions of one top-level kind from a JSON/YAML file
+// holding either a plain list of them or a document like Load reads with
+// only that kind. Every definition must have a name.
+func LoadKind(file, kind string) ([]Resource, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var rs []Resource
+	var items []map[string]interface{}
+	if decode(b, &items) == nil {
+		for _, data := range items {
+			rs = append(rs, localResource(kind, "", "", data))
+		}
+	} else if rs, err = loadFile(file); err != nil {
+		return nil, err
+	}
+	for i, r := range rs {
+		if r.Kind != kind {
+			return nil, fmt.Errorf("%s: found %s, expected only %s", file, r.Kind, kind)
+		}
+		if r.Name == "" {
+			return nil, fmt.Errorf("%s: %s definition %d has no name", file, kind, i+1)
+		}
+	}
+	sortResources(rs)
+	return rs, nil
+}
+
+// Filter keeps only resources of the given kinds (all when empty).
+func Filter(rs []Resource, kinds []string) ([]Resource, error) {
+	if len(kinds) == 0 {
+		return rs, nil
+	}
+	want := map[string]bool{}
+	for _, k := range kinds {
+		if !isKind(k) {
+			return nil, fmt.Errorf("unknown resource kind %q (valid: %s)", k, strings.Join(Kinds, ", "))
+		}
+		want[k] = true
+	}
+	var out []Resource
+	for _, r := range rs {
+		if want[r.Kind] {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+func loadDir(dir string) ([]Resource, error) {
+	var out []Resource
+	for _, k := range Kinds {
+		root := filepath.Join(dir, k)
+		if _, err := os.Stat(root); os.IsNotExist(err) {
+			continue
+		}
+		err := filepath.WalkDir(root, func(p string, d os.DirEntry, err error) error {
+			if err != nil || d.IsDir() || !isDataFile(p) {
+				return err
+			}
+			rel, err := filepath.Rel(root, p)
+			if err != nil {
+				return err
+			}
+			parts := strings.Split(filepath.ToSlash(rel), "/")
+			var parent string
+			switch {
+			case len(parts) == 1 && (!isNested(k) || k == "policies"):
+			case len(parts) == 2 && isNested(k):
+				if !(k == "policies" && parts[0] == "_global") {
+					parent = parts[0]
+				}
+			default:
+				return fmt.Errorf("%s: unexpected location for %s", p, k)
+			}
+
+			b, err := os.ReadFile(p)
+			if err != nil {
+				return err
+			}
+			var data map[string]interface{}
+			if err := decode(b, &data); err != nil {
+				return fmt.Errorf("%s: %w", p, err)
+			}
+			stem := strings.TrimSuffix(filepath.Base(p), filepath.Ext(p))
+			out = append(out, localResource(k, parent, stem, data))
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func loadFile(file string) ([]Resource, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string][]map[string]interface{}
+	if err := decode(b, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	var out []Resource
+	for k, items := range doc {
+		if !isKind(k) {
+			return nil, fmt.Errorf("%s: unknown resource kind %q (valid: %s)", file, k, strings.Join(Kinds, ", "))
+		}
+		for _, data := range items {
+			parent := stringField(data, parentKey)
+			delete(data, parentKey)
+			if isNested(k) && k != "policies" && parent == "" {
+				return nil, fmt.Errorf("%s: %s %q needs a %s", file, k, stringField(data, "name"), parentKey)
+			}
+			out = append(out, localResource(k, parent, "", data))
+		}
+	}
+	return out, nil
+}
+
+func isDataFile(p string) bool {
+	switch filepath.Ext(p) {
+	case ".json", ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+// isNested reports whether resources of kind k may belong to a parent
+// schedule or team.
+func isNested(k string) bool {
+	return k == "rotations" || k == "routing-rules" || k == "policies"
+}
+
+// decode parses JSON or YAML and normalises the result through JSON, so
+// local values compare equal to the ones decoded from API responses.
+func decode(b []byte, v interface{}) error {
+	var raw interface{}
+	if err := yaml.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	j, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(j, v)
+}
+
+func localResource(k, parent, stem string, data map[string]interface{}) Resource {
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	name := stringField(data, "name")
+	if name == "" {
+		name = stem
+	}
+	return Resource{Kind: k, Parent: parent, ID: stringField(data, "id"), Name: name, Data: data}
+}
+
+// PlanChanges compares local definitions with live resources and returns
+// the changes needed to make the account match. Resources are matched by
+// ID when the local definition carries one that exists, otherwise by kind,
+// parent and name. Live resources with no local definition are left alone.
+func PlanChanges(local, live []Resource) ([]Change, error) {
+	byID := map[string]Resource{}
+	byName := map[string]Resource{}
+	parents := map[string]string{} // parent kind/name -> live ID
+	for _, r := range live {
+		if r.ID != "" {
+			byID[r.Kind+"/"+r.ID] = r
+		}
+		byName[matchKey(r)] = r
+		if r.Kind == "teams" || r.Kind == "schedules" {
+			parents[r.Kind+"/"+fileName(r.Name)] = r.ID
+		}
+	}
+	planned := map[string]bool{}
+	for _, r := range local {
+		if r.Kind == "teams" || r.Kind == "schedules" {
+			planned[r.Kind+"/"+fileName(r.Name)] = true
+		}
+	}
+
+	changes := make([]Change, 0, len(local))
+	for _, r := range local {
+		if r.Parent != "" {
+			pk := parentKind(r.Kind) + "/" + fileName(r.Parent)
+			r.ParentID = parents[pk]
+			if r.ParentID == "" && !planned[pk] {
+				return nil, fmt.Errorf("%s %q: %s %q not found", r.Kind, r.Name, strings.TrimSuffix(parentKind(r.Kind), "s"), r.Parent)
+			}
+		}
+
+		cur, ok := byID[r.Kind+"/"+r.ID]
+		if r.ID == "" || !ok {
+			cur, ok = byName[matchKey(r)]
+		}
+		if !ok {
+			r.ID = ""
+			changes = append(changes, Change{Action: ActionCreate, Resource: r})
+			continue
+		}
+
+		r.ID = cur.ID
+		if r.ParentID == "" {
+			r.ParentID = cur.ParentID
+		}
+		fields := diff(r.Data, cur.Data)
+		action := ActionUpdate
+		if len(fields) == 0 {
+			action = ActionUnchanged
+		}
+		changes = append(changes, Change{Action: action, Resource: r, Fields: fields})
+	}
+	return changes, nil
+}
+
+func matchKey(r Resource) string {
+	return r.Kind + "/" + fileName(r.Parent) + "/" + r.Name
+}
+
+func parentKind(k string) string {
+	if k == "rotations" {
+		return "schedules"
+	}
+	return "teams"
+}
+
+// diff returns the sorted top-level fields of want that differ from have.
+func diff(want, have map[string]interface{}) []string {
+	var fields []string
+	for k, v := range want {
+		if k == "id" {
+			continue
+		}
+		if !reflect.DeepEqual(v, have[k]) {
+			fields = append(fields, k)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// Apply performs the create and update changes in order. Parents created
+// earlier in the run are used for nested resources that reference them.
+// It stops at the first failure.
+func Apply(w Writer, changes []Change) error {
+	created := map[string]string{} // parent kind/name -> new ID
+	for _, c := range changes {
+		r := c.Resource
+		if c.Action == ActionUnchanged {
+			continue
+		}
+		if r.Parent != "" && r.ParentID == "" {
+			r.ParentID = created[parentKind(r.Kind)+"/"+fileName(r.Parent)]
+		}
+
+		body := make(map[string]interface{}, len(r.Data))
+		for k, v := range r.Data {
+			if k != "id" {
+				body[k] = v
+			}
+		}
+		create, update, method := endpoints(r)
+
+		var resp struct {
+			Data map[string]interface{} `json:"data"`
+		}
+		var err error
+		switch {
+		case c.Action == ActionCreate:
+			err = w.Post(create, body, &resp)
+		case method == "PUT":
+			err = w.Put(update, body, &resp)
+		default:
+			err = w.Patch(update, body, &resp)
+		}
+		if err != nil {
+			return fmt.Errorf("%s %s %q: %w", c.Action, r.Kind, r.Name, err)
+		}
+		if c.Action == ActionCreate && (r.Kind == "teams" || r.Kind == "schedules") {
+			created[r.Kind+"/"+fileName(r.Name)] = stringField(resp.Data, "id")
+		}
+	}
+	return nil
+}
+
+// endpoints returns the create path, update path and update method for r.
+func endpoints(r Resource) (create, update, method string) {
+	switch r.Kind {
+	case "teams":
+		return "/v2/teams", "/v2/teams/" + r.ID, "PATCH"
+	case "schedules":
+		return "/v2/schedules", "/v2/schedules/" + r.ID, "PATCH"
+	case "rotations":
+		base := "/v2/schedules/" + r.ParentID + "/rotations"
+		return base, base + "/" + r.ID, "PATCH"
+	case "escalations":
+		return "/v2/escalations", "/v2/escalations/" + r.ID, "PATCH"
+	case "integrations":
+		return "/v2/integrations", "/v2/integrations/" + r.ID, "PUT"
+	case "policies":
+		query := ""
+		if r.ParentID != "" {
+			query = "?" + url.Values{"teamId": {r.ParentID}}.Encode()
+		}
+		return "/v2/policies" + query, "/v2/policies/" + r.ID + query, "PUT"
+	case "services":
+		return "/v1/services", "/v1/services/" + r.ID, "PATCH"
+	case "heartbeats":
+		// Heartbeats are addressed by name.
+		return "/v2/heartbeats", "/v2/heartbeats/" + url.PathEscape(r.Name), "PATCH"
+	default: // routing-rules
+		base := "/v2/teams/" + r.ParentID + "/routing-rules"
+		return base, base + "/" + r.ID, "PATCH"
+	}
+}
@@ -0,0 +1,186 @@
+package snapshot
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// fakeWriter records write calls as "METHOD path".
+type fakeWriter struct {
+	calls  []string
+	bodies []map[string]interface{}
+}
+
+func (f *fakeWriter) record(method, path string, body, result interface{}) error {
+	f.calls = append(f.calls, method+" "+path)
+	f.bodies = append(f.bodies, body.(map[string]interface{}))
+	if resp, ok := result.(*struct {
+		Data map[string]interface{} `json:"data"`
+	}); ok {
+		resp.Data = map[string]interface{}{"id": "new-" + body.(map[string]interface{})["name"].(string)}
+	}
+	return nil
+}
+
+func (f *fakeWriter) Post(path string, body, result interface{}) error {
+	return f.record("POST", path, body, result)
+}
+
+func (f *fakeWriter) Put(path string, body, result interface{}) error {
+	return f.record("PUT", path, body, result)
+}
+
+func (f *fakeWriter) Patch(path string, body, result interface{}) error {
+	return f.record("PATCH", path, body, result)
+}
+
+func TestLoad_RoundTripIsUnchanged(t *testing.T) {
+	live, err := Collect(testGetter(), nil)
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	for _, format := range []string{FormatJSON, FormatYAML} {
+		dir := t.TempDir()
+		if _, err := Write(dir, live, format, nil); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		local, err := Load(dir)
+		if err != nil {
+			t.Fatalf("Load: %v", err)
+		}
+		if len(local) != len(live) {
+			t.Fatalf("%s: loaded %d resources, want %d", format, len(local), len(live))
+		}
+		changes, err := PlanChanges(local, live)
+		if err != nil {
+			t.Fatalf("PlanChanges: %v", err)
+		}
+		for _, c := range changes {
+			if c.Action != ActionUnchanged {
+				t.Errorf("%s: %s %s/%s planned %s %v", format, c.Resource.Kind, c.Resource.Parent, c.Resource.Name, c.Action, c.Fields)
+			}
+		}
+	}
+}
+
+func TestPlanAndApply(t *testing.T) {
+	live, err := Collect(testGetter(), []string{"teams", "schedules", "rotations", "policies"})
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	_ = os.WriteFile(file, []byte(`
+teams:
+  - name: Platform
+    description: Platform engineering
+  - name: Data
+schedules:
+  - name: Data On-Call
+    timezone: UTC
+rotations:
+  - _parent: Data On-Call
+    name: weekly
+  - _parent: Alpha
+    name: weekly
+    type: weekly
+policies:
+  - _parent: Platform
+    name: Quiet
+    type: notification
+`), 0o644)
+
+	local, err := Load(file)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	changes, err := PlanChanges(local, live)
+	if err != nil {
+		t.Fatalf("PlanChanges: %v", err)
+	}
+	var got []string
+	for _, c := range changes {
+		got = append(got, c.Action+" "+c.Resource.Kind+"/"+c.Resource.Parent+"/"+c.Resource.Name)
+	}
+	want := []string{
+		"create teams//Data",
+		"update teams//Platform",
+		"create schedules//Data On-Call",
+		"unchanged rotations/Alpha/weekly",
+		"create rotations/Data On-Call/weekly",
+		"unchanged policies/Platform/Quiet",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("plan =\n%v\nwant\n%v", got, want)
+	}
+
+	w := &fakeWriter{}
+	if err := Apply(w, changes); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	wantCalls := []string{
+		"POST /v2/teams",
+		"PATCH /v2/teams/t1",
+		"POST /v2/schedules",
+		"POST /v2/schedules/new-Data On-Call/rotations",
+	}
+	if !reflect.DeepEqual(w.calls, wantCalls) {
+		t.Errorf("calls =\n%v\nwant\n%v", w.calls, wantCalls)
+	}
+	if _, ok := w.bodies[1]["id"]; ok {
+		t.Error("update body must not carry the id")
+	}
+}
+
+func TestPlanChanges_UnknownParent(t *testing.T) {
+	local := []Resource{{Kind: "routing-rules", Parent: "Nobody", Name: "r", Data: map[string]interface{}{"name": "r"}}}
+	if _, err := PlanChanges(local, nil); err == nil {
+		t.Error("expected error for unknown parent team")
+	}
+}
+
+func TestLoadKind(t *testing.T) {
+	dir := t.TempDir()
+	list := filepath.Join(dir, "list.yaml")
+	_ = os.WriteFile(list, []byte("- name: b\n  interval: 5\n- name: a\n"), 0o644)
+	rs, err := LoadKind(list, "heartbeats")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rs) != 2 || rs[0].Name != "a" || rs[0].Kind != "heartbeats" || rs[1].Data["interval"] != float64(5) {
+		t.Errorf("unexpected resources: %+v", rs)
+	}
+
+	doc := filepath.Join(dir, "doc.yaml")
+	_ = os.WriteFile(doc, []byte("heartbeats:\n  - name: a\n"), 0o644)
+	if rs, err := LoadKind(doc, "heartbeats"); err != nil || len(rs) != 1 {
+		t.Errorf("kind document: %v, %v", rs, err)
+	}
+
+	for name, body := range map[string]string{
+		"other.yaml":   "teams:\n  - name: a\n",
+		"noname.yaml":  "- interval: 5\n",
+		"invalid.yaml": "name: [",
+	} {
+		p := filepath.Join(dir, name)
+		_ = os.WriteFile(p, []byte(body), 0o644)
+		if _, err := LoadKind(p, "heartbeats"); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestLoad_SingleFileErrors(t *testing.T) {
+	dir := t.TempDir()
+	for name, body := range map[string]string{
+		"kind.yaml":   "widgets:\n  - name: x\n",
+		"parent.yaml": "rotations:\n  - name: weekly\n",
+	} {
+		p := filepath.Join(dir, name)
+		_ = os.WriteFile(p, []byte(body), 0o644)
+		if _, err := Load(p); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package snapshot
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ManifestName is the file inside an archive listing the SHA-256 of every
+// other file, in the format of sha256sum.
+const ManifestName = "SHA256SUMS"
+
+// archiveTime is the modification time of every archive entry, so the
+// archive depends only on the exported content.
+var archiveTime = time.Unix(0, 0).UTC()
+
+// WriteArchive writes resources as a gzipped tarball with the same layout
+// as Write, plus a ManifestName file. Entries are sorted by path and carry
+// fixed times, owners and modes, so the same configuration always produces
+// byte-identical archives. It returns the paths of the resource files, in
+// resource order.
+func WriteArchive(w io.Writer, resources []Resource, format string) ([]string, error) {
+	rels := paths(resources, format)
+	files := make(map[string][]byte, len(resources))
+	for i, r := range resources {
+		data, err := Encode(r.Data, format)
+		if err != nil {
+			return nil, fmt.Errorf("encoding %s: %w", rels[i], err)
+		}
+		rels[i] = filepath.ToSlash(rels[i])
+		files[rels[i]] = data
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var manifest strings.Builder
+	for _, name := range names {
+		sum := sha256.Sum256(files[name])
+		fmt.Fprintf(&manifest, "%s  %s\n", hex.EncodeToString(sum[:]), name)
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	add := func(name string, data []byte) error {
+		hdr := &tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     name,
+			Mode:     0o644,
+			Size:     int64(len(data)),
+			ModTime:  archiveTime,
+			Format:   tar.FormatPAX,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		_, err := tw.Write(data)
+		return err
+	}
+	if err := add(ManifestName, []byte(manifest.String())); err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		if err := add(name, files[name]); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return rels, nil
+}
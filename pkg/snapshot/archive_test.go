@@ -0,0 +1,71 @@
+package snapshot
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestWriteArchive_DeterministicWithManifest(t *testing.T) {
+	rs, err := Collect(testGetter(), nil)
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	var first, second bytes.Buffer
+	if _, err := WriteArchive(&first, rs, FormatJSON); err != nil {
+		t.Fatalf("WriteArchive: %v", err)
+	}
+	rs2, _ := Collect(testGetter(), nil)
+	if _, err := WriteArchive(&second, rs2, FormatJSON); err != nil {
+		t.Fatalf("WriteArchive: %v", err)
+	}
+	if !bytes.Equal(first.Bytes(), second.Bytes()) {
+		t.Fatal("archive is not deterministic")
+	}
+
+	gz, err := gzip.NewReader(&first)
+	if err != nil {
+		t.Fatalf("gzip: %v", err)
+	}
+	tr := tar.NewReader(gz)
+	files := map[string]string{}
+	var order []string
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("tar: %v", err)
+		}
+		if hdr.ModTime.Unix() != 0 || hdr.Uid != 0 || hdr.Uname != "" {
+			t.Errorf("%s: entry carries environment metadata: %+v", hdr.Name, hdr)
+		}
+		b, _ := io.ReadAll(tr)
+		files[hdr.Name] = string(b)
+		order = append(order, hdr.Name)
+	}
+	if order[0] != ManifestName {
+		t.Errorf("first entry = %q, want %s", order[0], ManifestName)
+	}
+	if _, ok := files["teams/Platform.json"]; !ok {
+		t.Errorf("missing teams/Platform.json in %v", order)
+	}
+
+	lines := strings.Split(strings.TrimSpace(files[ManifestName]), "\n")
+	if len(lines) != len(files)-1 {
+		t.Fatalf("manifest lists %d files, archive has %d", len(lines), len(files)-1)
+	}
+	for _, line := range lines {
+		sum, name, _ := strings.Cut(line, "  ")
+		got := sha256.Sum256([]byte(files[name]))
+		if hex.EncodeToString(got[:]) != sum {
+			t.Errorf("manifest hash mismatch for %s", name)
+		}
+	}
+}
@@ -0,0 +1,331 @@
+// Package snapshot exports OpsGenie configuration to a directory tree of
+// one-file-per-resource documents, suitable for git-backed backups, and
+// applies such definitions back to an account.
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Getter is the subset of api.Client used to read configuration.
+type Getter interface {
+	GetWithParams(path string, params url.Values, result interface{}) error
+	ListAll(path string, params url.Values, result interface{}) error
+}
+
+// Resource is a single exported configuration object.
+type Resource struct {
+	Kind string
+	// Parent is the name of the owning schedule or team for nested kinds
+	// (rotations, routing rules, team policies); empty otherwise.
+	Parent   string
+	ParentID string
+	ID       string
+	Name     string
+	Data     map[string]interface{}
+}
+
+// kind describes how to collect one resource type.
+type kind struct {
+	name string
+	// collect returns every resource of this kind. Top-level kinds that
+	// others depend on (teams, schedules) are passed via ctx.
+	collect func(g Getter, ctx *collectContext) ([]Resource, error)
+}
+
+type collectContext struct {
+	teams     []Resource
+	schedules []Resource
+}
+
+// Kinds lists the exportable resource kinds in dependency order.
+var Kinds = []string{
+	"teams", "schedules", "rotations", "escalations", "integrations",
+	"policies", "services", "heartbeats", "routing-rules",
+}
+
+// volatileFields are stripped from every resource so exports only change
+// when configuration does.
+var volatileFields = []string{"lastPingTime", "expired", "_links", "links", "createdAt", "updatedAt"}
+
+// Collect fetches the requested kinds (all of Kinds when empty) and returns
+// them sorted by kind, parent and name.
+func Collect(g Getter, kinds []string) ([]Resource, error) {
+	return collect(g, kinds, true, nil)
+}
+
+// CollectProgress is like Collect but calls progress after each kind is
+// fetched, with the number of kinds fetched so far and in total (parents
+// fetched for nested kinds included).
+func CollectProgress(g Getter, kinds []string, progress func(kind string, done, total int)) ([]Resource, error) {
+	return collect(g, kinds, true, progress)
+}
+
+// CollectRaw is like Collect but keeps volatile fields such as timestamps,
+// for callers that inspect resources rather than store them.
+func CollectRaw(g Getter, kinds []string) ([]Resource, error) {
+	return collect(g, kinds, false, nil)
+}
+
+func collect(g Getter, kinds []string, strip bool, progress func(kind string, done, total int)) ([]Resource, error) {
+	if len(kinds) == 0 {
+		kinds = Kinds
+	}
+	want := map[string]bool{}
+	for _, k := range kinds {
+		if !isKind(k) {
+			return nil, fmt.Errorf("unknown resource kind %q (valid: %s)", k, strings.Join(Kinds, ", "))
+		}
+		want[k] = true
+	}
+
+	// Teams and schedules are needed as parents even when not exported.
+	var needed []kind
+	for _, k := range kindTable {
+		if want[k.name] ||
+			(k.name == "teams" && (want["routing-rules"] || want["policies"])) ||
+			(k.name == "schedules" && want["rotations"]) {
+			needed = append(needed, k)
+		}
+	}
+
+	ctx := &collectContext{}
+	var all []Resource
+	for i, k := range needed {
+		res, err := k.collect(g, ctx)
+		if err != nil {
+			return nil, fmt.Errorf("exporting %s: %w", k.name, err)
+		}
+		switch k.name {
+		case "teams":
+			ctx.teams = res
+		case "schedules":
+			ctx.schedules = res
+		}
+		if want[k.name] {
+			all = append(all, res...)
+		}
+		if progress != nil {
+			progress(k.name, i+1, len(needed))
+		}
+	}
+
+	if strip {
+		for i := range all {
+			clean(all[i].Data)
+		}
+	}
+	sortResources(all)
+	return all, nil
+}
+
+func isKind(k string) bool {
+	for _, known := range Kinds {
+		if k == known {
+			return true
+		}
+	}
+	return false
+}
+
+func sortResources(rs []Resource) {
+	order := map[string]int{}
+	for i, k := range Kinds {
+		order[k] = i
+	}
+	sort.SliceStable(rs, func(i, j int) bool {
+		a, b := rs[i], rs[j]
+		if a.Kind != b.Kind {
+			return order[a.Kind] < order[b.Kind]
+		}
+		if a.Parent != b.Parent {
+			return a.Parent < b.Parent
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.ID < b.ID
+	})
+}
+
+func clean(m map[string]interface{}) {
+	for _, f := range volatileFields {
+		delete(m, f)
+	}
+}
+
+var kindTable = []kind{
+	{"teams", func(g Getter, _ *collectContext) ([]Resource, error) {
+		return listWithDetail(g, "teams", "/v2/teams", "/v2/teams/", nil)
+	}},
+	{"schedules", func(g Getter, _ *collectContext) ([]Resource, error) {
+		return listWithDetail(g, "schedules", "/v2/schedules", "/v2/schedules/", nil)
+	}},
+	{"rotations", func(g Getter, ctx *collectContext) ([]Resource, error) {
+		var out []Resource
+		for _, s := range ctx.schedules {
+			items, err := list(g, "/v2/schedules/"+s.ID+"/rotations", nil)
+			if err != nil {
+				return nil, err
+			}
+			for _, it := range items {
+				out = append(out, newResource("rotations", it, s))
+			}
+		}
+		return out, nil
+	}},
+	{"escalations", func(g Getter, _ *collectContext) ([]Resource, error) {
+		return listOnly(g, "escalations", "/v2/escalations")
+	}},
+	{"integrations", func(g Getter, _ *collectContext) ([]Resource, error) {
+		return listWithDetail(g, "integrations", "/v2/integrations", "/v2/integrations/", nil)
+	}},
+	{"policies", func(g Getter, ctx *collectContext) ([]Resource, error) {
+		// Global alert policies first, then each team's policies.
+		// Notification policies only exist per team.
+		owners := append([]Resource{{}}, ctx.teams...)
+		var out []Resource
+		for _, owner := range owners {
+			var params url.Values
+			ptypes := []string{"alert"}
+			if owner.ID != "" {
+				params = url.Values{"teamId": {owner.ID}}
+				ptypes = append(ptypes, "notification")
+			}
+			for _, ptype := range ptypes {
+				items, err := list(g, "/v2/policies/"+ptype, params)
+				if err != nil {
+					return nil, err
+				}
+				for _, it := range items {
+					var detail map[string]interface{}
+					if err := g.GetWithParams("/v2/policies/"+stringField(it, "id"), params, &detail); err != nil {
+						return nil, err
+					}
+					if detail == nil {
+						detail = it
+					}
+					if _, ok := detail["type"]; !ok {
+						detail["type"] = ptype
+					}
+					out = append(out, newResource("policies", detail, owner))
+				}
+			}
+		}
+		return out, nil
+	}},
+	{"services", func(g Getter, _ *collectContext) ([]Resource, error) {
+		// Services are paged; large accounts have more than one page.
+		var items []map[string]interface{}
+		if err := g.ListAll("/v1/services", nil, &items); err != nil {
+			return nil, err
+		}
+		out := make([]Resource, 0, len(items))
+		for _, it := range items {
+			out = append(out, newResource("services", it, Resource{}))
+		}
+		return out, nil
+	}},
+	{"heartbeats", func(g Getter, _ *collectContext) ([]Resource, error) {
+		return listOnly(g, "heartbeats", "/v2/heartbeats")
+	}},
+	{"routing-rules", func(g Getter, ctx *collectContext) ([]Resource, error) {
+		var out []Resource
+		for _, t := range ctx.teams {
+			items, err := list(g, "/v2/teams/"+t.ID+"/routing-rules", nil)
+			if err != nil {
+				return nil, err
+			}
+			for _, it := range items {
+				out = append(out, newResource("routing-rules", it, t))
+			}
+		}
+		return out, nil
+	}},
+}
+
+func newResource(kind string, data map[string]interface{}, parent Resource) Resource {
+	return Resource{
+		Kind:     kind,
+		Parent:   parent.Name,
+		ParentID: parent.ID,
+		ID:       stringField(data, "id"),
+		Name:     stringField(data, "name"),
+		Data:     data,
+	}
+}
+
+func listOnly(g Getter, kindName, path string) ([]Resource, error) {
+	items, err := list(g, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Resource, 0, len(items))
+	for _, it := range items {
+		out = append(out, newResource(kindName, it, Resource{}))
+	}
+	return out, nil
+}
+
+// listWithDetail lists a collection and then fetches each item, since list
+// endpoints often return summaries only.
+func listWithDetail(g Getter, kindName, listPath, detailPrefix string, params url.Values) ([]Resource, error) {
+	items, err := list(g, listPath, params)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Resource, 0, len(items))
+	for _, it := range items {
+		var detail map[string]interface{}
+		if err := g.GetWithParams(detailPrefix+stringField(it, "id"), nil, &detail); err != nil {
+			return nil, err
+		}
+		if detail == nil {
+			detail = it
+		}
+		out = append(out, newResource(kindName, detail, Resource{}))
+	}
+	return out, nil
+}
+
+// list fetches a collection. Some endpoints wrap the array in an object
+// (e.g. {"heartbeats": [...]}); the first array field is used in that case.
+func list(g Getter, path string, params url.Values) ([]map[string]interface{}, error) {
+	var raw json.RawMessage
+	if err := g.GetWithParams(path, params, &raw); err != nil {
+		return nil, err
+	}
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	var items []map[string]interface{}
+	if err := json.Unmarshal(raw, &items); err == nil {
+		return items, nil
+	}
+	var wrapped map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &wrapped); err != nil {
+		return nil, fmt.Errorf("unexpected response from %s", path)
+	}
+	keys := make([]string, 0, len(wrapped))
+	for k := range wrapped {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := json.Unmarshal(wrapped[k], &items); err == nil {
+			return items, nil
+		}
+	}
+	return nil, nil
+}
+
+func stringField(m map[string]interface{}, key string) string {
+	if s, ok := m[key].(string); ok {
+		return s
+	}
+	return ""
+}
@@ -0,0 +1,213 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fakeGetter serves canned "data" payloads keyed by path (plus encoded
+// query, when present).
+type fakeGetter map[string]string
+
+func (f fakeGetter) GetWithParams(path string, params url.Values, result interface{}) error {
+	key := path
+	if len(params) > 0 {
+		key += "?" + params.Encode()
+	}
+	body, ok := f[key]
+	if !ok {
+		return fmt.Errorf("unexpected request %s", key)
+	}
+	return json.Unmarshal([]byte(body), result)
+}
+
+// ListAll serves path like GetWithParams, as one page.
+func (f fakeGetter) ListAll(path string, params url.Values, result interface{}) error {
+	return f.GetWithParams(path, params, result)
+}
+
+func testGetter() fakeGetter {
+	return fakeGetter{
+		"/v2/teams":                           `[{"id":"t1","name":"Platform"}]`,
+		"/v2/teams/t1":                        `{"id":"t1","name":"Platform","members":[{"user":{"username":"a@example.com"}}]}`,
+		"/v2/schedules":                       `[{"id":"s2","name":"Zeta"},{"id":"s1","name":"Alpha"}]`,
+		"/v2/schedules/s1":                    `{"id":"s1","name":"Alpha","timezone":"UTC"}`,
+		"/v2/schedules/s2":                    `{"id":"s2","name":"Zeta","timezone":"UTC"}`,
+		"/v2/schedules/s1/rotations":          `[{"id":"r1","name":"weekly","type":"weekly"}]`,
+		"/v2/schedules/s2/rotations":          `[]`,
+		"/v2/escalations":                     `[{"id":"e1","name":"Default","rules":[]}]`,
+		"/v2/integrations":                    `[{"id":"i1","name":"Datadog"}]`,
+		"/v2/integrations/i1":                 `{"id":"i1","name":"Datadog","type":"Datadog","_links":{"x":"y"}}`,
+		"/v2/policies/alert":                  `[{"id":"p1","name":"Global P1"}]`,
+		"/v2/policies/p1":                     `{"id":"p1","name":"Global P1","type":"alert"}`,
+		"/v2/policies/alert?teamId=t1":        `[]`,
+		"/v2/policies/notification?teamId=t1": `[{"id":"p2","name":"Quiet"}]`,
+		"/v2/policies/p2?teamId=t1":           `{"id":"p2","name":"Quiet"}`,
+		"/v1/services":                        `[{"id":"svc1","name":"api"}]`,
+		"/v2/heartbeats":                      `{"heartbeats":[{"name":"cron","interval":10,"lastPingTime":"2024-01-01T00:00:00Z"}]}`,
+		"/v2/teams/t1/routing-rules":          `[{"id":"rr1","name":"Default","order":0}]`,
+	}
+}
+
+func TestCollect_AllKinds(t *testing.T) {
+	rs, err := Collect(testGetter(), nil)
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	var got []string
+	for _, r := range rs {
+		got = append(got, r.Path(FormatJSON))
+	}
+	want := []string{
+		"teams/Platform.json",
+		"schedules/Alpha.json",
+		"schedules/Zeta.json",
+		"rotations/Alpha/weekly.json",
+		"escalations/Default.json",
+		"integrations/Datadog.json",
+		"policies/_global/Global-P1.json",
+		"policies/Platform/Quiet.json",
+		"services/api.json",
+		"heartbeats/cron.json",
+		"routing-rules/Platform/Default.json",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("paths =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	for _, r := range rs {
+		switch r.Kind {
+		case "integrations":
+			if _, ok := r.Data["_links"]; ok {
+				t.Error("volatile _links should be stripped")
+			}
+		case "heartbeats":
+			if _, ok := r.Data["lastPingTime"]; ok {
+				t.Error("volatile lastPingTime should be stripped")
+			}
+		case "policies":
+			if r.Name == "Quiet" && r.Data["type"] != "notification" {
+				t.Errorf("policy type should default from endpoint, got %v", r.Data["type"])
+			}
+		case "routing-rules":
+			if _, ok := r.Data["order"]; !ok {
+				t.Error("routing rule order is configuration and must be kept")
+			}
+		}
+	}
+}
+
+func TestCollect_SubsetFetchesParents(t *testing.T) {
+	rs, err := Collect(testGetter(), []string{"rotations"})
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	if len(rs) != 1 || rs[0].Kind != "rotations" || rs[0].Parent != "Alpha" || rs[0].ParentID != "s1" {
+		t.Errorf("unexpected resources: %+v", rs)
+	}
+}
+
+func TestCollect_UnknownKind(t *testing.T) {
+	if _, err := Collect(testGetter(), []string{"widgets"}); err == nil {
+		t.Error("expected error for unknown kind")
+	}
+}
+
+func TestWrite_DeterministicAndPrunes(t *testing.T) {
+	dir := t.TempDir()
+	rs, err := Collect(testGetter(), nil)
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+
+	// A stale file from a deleted schedule and an unrelated user file.
+	stale := filepath.Join(dir, "schedules", "Deleted.json")
+	notes := filepath.Join(dir, "schedules", "README.md")
+	_ = os.MkdirAll(filepath.Dir(stale), 0o755)
+	_ = os.WriteFile(stale, []byte("{}"), 0o644)
+	_ = os.WriteFile(notes, []byte("notes"), 0o644)
+
+	if _, err := Write(dir, rs, FormatJSON, nil); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	first, err := os.ReadFile(filepath.Join(dir, "teams", "Platform.json"))
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Error("stale export file should be removed")
+	}
+	if _, err := os.Stat(notes); err != nil {
+		t.Error("non-export files must be left alone")
+	}
+
+	// Re-collecting and re-writing yields identical bytes.
+	rs2, _ := Collect(testGetter(), nil)
+	if _, err := Write(dir, rs2, FormatJSON, nil); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	second, _ := os.ReadFile(filepath.Join(dir, "teams", "Platform.json"))
+	if string(first) != string(second) {
+		t.Error("export is not deterministic")
+	}
+	if !strings.HasPrefix(string(first), "{\n  \"id\": \"t1\",") {
+		t.Errorf("expected sorted, indented JSON, got:\n%s", first)
+	}
+}
+
+func TestWrite_YAMLAndNameCollision(t *testing.T) {
+	dir := t.TempDir()
+	rs := []Resource{
+		{Kind: "teams", ID: "t1", Name: "Ops/Team", Data: map[string]interface{}{"id": "t1", "name": "Ops/Team"}},
+		{Kind: "teams", ID: "t2", Name: "Ops Team", Data: map[string]interface{}{"id": "t2", "name": "Ops Team"}},
+	}
+	paths, err := Write(dir, rs, FormatYAML, []string{"teams"})
+	if err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	want := []string{"teams/Ops-Team.yaml", "teams/Ops-Team-t2.yaml"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("paths = %v, want %v", paths, want)
+	}
+	b, _ := os.ReadFile(filepath.Join(dir, want[1]))
+	if string(b) != "id: t2\nname: Ops Team\n" {
+		t.Errorf("unexpected YAML:\n%s", b)
+	}
+}
+
+func TestEncode_UnknownFormat(t *testing.T) {
+	if _, err := Encode(map[string]interface{}{}, "toml"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
+
+// pagedGetter answers ListAll for /v1/services with every page combined,
+// where GetWithParams would see the first page only.
+type pagedGetter struct{ fakeGetter }
+
+func (g pagedGetter) ListAll(path string, params url.Values, result interface{}) error {
+	if path == "/v1/services" {
+		return json.Unmarshal([]byte(`[{"id":"svc1","name":"api"},{"id":"svc2","name":"web"}]`), result)
+	}
+	return g.fakeGetter.ListAll(path, params, result)
+}
+
+func TestCollect_ServicesFollowsPages(t *testing.T) {
+	rs, err := Collect(pagedGetter{testGetter()}, []string{"services"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, r := range rs {
+		names = append(names, r.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"api", "web"}) {
+		t.Errorf("services = %v, want every page", names)
+	}
+}
@@ -0,0 +1,168 @@
+package snapshot
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Formats supported for exported files.
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// fileName turns a resource name into a safe, stable file name stem.
+func fileName(s string) string {
+	s = strings.Trim(unsafeFileChars.ReplaceAllString(s, "-"), "-.")
+	if s == "" {
+		return "_"
+	}
+	return s
+}
+
+// Path returns the path of r relative to the export root:
+// <kind>/<name>.<ext>, or <kind>/<parent>/<name>.<ext> for nested kinds.
+// Global policies live under policies/_global.
+func (r Resource) Path(format string) string {
+	name := r.Name
+	if name == "" {
+		name = r.ID
+	}
+	parts := []string{r.Kind}
+	switch {
+	case r.Parent != "":
+		parts = append(parts, fileName(r.Parent))
+	case r.Kind == "policies":
+		parts = append(parts, "_global")
+	}
+	parts = append(parts, fileName(name)+"."+format)
+	return filepath.Join(parts...)
+}
+
+// Encode renders a resource's data in the given format. Map keys are
+// sorted by both encoders, so output is deterministic.
+func Encode(data map[string]interface{}, format string) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(data); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case FormatYAML:
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(data); err != nil {
+			return nil, err
+		}
+		if err := enc.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unknown format %q (use json or yaml)", format)
+	}
+}
+
+// paths returns the relative export path of each resource. Two resources
+// with the same name (e.g. teams renamed to collide) must not overwrite each
+// other, so later ones are disambiguated with their ID.
+func paths(resources []Resource, format string) []string {
+	out := make([]string, len(resources))
+	seen := map[string]bool{}
+	for i, r := range resources {
+		rel := r.Path(format)
+		if seen[rel] && r.ID != "" {
+			rel = strings.TrimSuffix(rel, "."+format) + "-" + fileName(r.ID) + "." + format
+		}
+		seen[rel] = true
+		out[i] = rel
+	}
+	return out
+}
+
+// Write writes resources under dir, one file per resource, and removes
+// stale export files (same extension, inside the exported kinds'
+// directories) left over from resources that no longer exist. It returns
+// the relative paths written, in order.
+func Write(dir string, resources []Resource, format string, kinds []string) ([]string, error) {
+	if len(kinds) == 0 {
+		kinds = Kinds
+	}
+	written := paths(resources, format)
+	keep := map[string]bool{}
+
+	for i, r := range resources {
+		rel := written[i]
+		keep[rel] = true
+
+		data, err := Encode(r.Data, format)
+		if err != nil {
+			return nil, fmt.Errorf("encoding %s: %w", rel, err)
+		}
+		full := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(full, data, 0o644); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, k := range kinds {
+		if err := prune(dir, k, format, keep); err != nil {
+			return nil, err
+		}
+	}
+	return written, nil
+}
+
+// prune removes export files of the given format under dir/kind that are
+// not in keep, then removes any directories left empty.
+func prune(dir, kind, format string, keep map[string]bool) error {
+	root := filepath.Join(dir, kind)
+	if _, err := os.Stat(root); os.IsNotExist(err) {
+		return nil
+	}
+	var dirs []string
+	err := filepath.WalkDir(root, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			dirs = append(dirs, p)
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		if filepath.Ext(p) == "."+format && !keep[rel] {
+			return os.Remove(p)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	// Deepest first, so nested empty directories collapse.
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if entries, err := os.ReadDir(dirs[i]); err == nil && len(entries) == 0 {
+			_ = os.Remove(dirs[i])
+		}
+	}
+	return nil
+}
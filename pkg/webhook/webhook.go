@@ -0,0 +1,128 @@
+// Package webhook receives the callbacks of an OpsGenie Webhook
+// integration: each alert action is POSTed as a JSON body.
+package webhook
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// maxBody bounds the size of a callback body.
+const maxBody = 1 << 20
+
+// Event is one validated webhook callback.
+type Event struct {
+	ReceivedAt  time.Time       `json:"receivedAt"`
+	Action      string          `json:"action"`
+	AlertID     string          `json:"alertId"`
+	TinyID      string          `json:"tinyId,omitempty"`
+	Message     string          `json:"message,omitempty"`
+	Integration string          `json:"integrationName,omitempty"`
+	Payload     json.RawMessage `json:"payload"`
+}
+
+// Parse validates a callback body: a JSON object with an action and an
+// alert that has an alertId.
+func Parse(body []byte) (Event, error) {
+	var p struct {
+		Action *string `json:"action"`
+		Alert  *struct {
+			AlertID string `json:"alertId"`
+			TinyID  string `json:"tinyId"`
+			Message string `json:"message"`
+		} `json:"alert"`
+		IntegrationName string `json:"integrationName"`
+	}
+	if err := json.Unmarshal(body, &p); err != nil {
+		return Event{}, fmt.Errorf("payload is not a JSON object: %w", err)
+	}
+	switch {
+	case p.Action == nil || *p.Action == "":
+		return Event{}, fmt.Errorf("payload has no action")
+	case p.Alert == nil:
+		return Event{}, fmt.Errorf("payload has no alert")
+	case p.Alert.AlertID == "":
+		return Event{}, fmt.Errorf("payload alert has no alertId")
+	}
+	return Event{
+		Action:      *p.Action,
+		AlertID:     p.Alert.AlertID,
+		TinyID:      p.Alert.TinyID,
+		Message:     p.Alert.Message,
+		Integration: p.IntegrationName,
+		Payload:     json.RawMessage(body),
+	}, nil
+}
+
+// Handler validates the callbacks POSTed to Path and passes them to
+// Deliver. Rejected requests are answered with an error status and logged
+// to Log.
+type Handler struct {
+	// Path is the URL path callbacks are sent to; "" means "/".
+	Path string
+	// Headers must all be present with these values, e.g. a secret set
+	// as a custom header on the integration.
+	Headers map[string]string
+	// Actions limits the events delivered to these actions (Create,
+	// Acknowledge, Close, ...); others are answered but dropped. Empty
+	// means all.
+	Actions []string
+	// Deliver is called with each accepted event, before the response is
+	// sent.
+	Deliver func(Event)
+	Log     io.Writer
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := h.Path
+	if path == "" {
+		path = "/"
+	}
+	if r.URL.Path != path {
+		h.reject(w, r, http.StatusNotFound, "no webhook at "+r.URL.Path)
+		return
+	}
+	if r.Method != http.MethodPost {
+		h.reject(w, r, http.StatusMethodNotAllowed, "webhooks must be POSTed")
+		return
+	}
+	for name, want := range h.Headers {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get(name)), []byte(want)) != 1 {
+			h.reject(w, r, http.StatusUnauthorized, "missing or wrong "+name+" header")
+			return
+		}
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBody))
+	if err != nil {
+		h.reject(w, r, http.StatusRequestEntityTooLarge, err.Error())
+		return
+	}
+	ev, err := Parse(body)
+	if err != nil {
+		h.reject(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	ev.ReceivedAt = time.Now().UTC()
+	if len(h.Actions) == 0 || slices.Contains(h.Actions, ev.Action) {
+		h.Deliver(ev)
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"result": "ok"})
+}
+
+func (h *Handler) reject(w http.ResponseWriter, r *http.Request, status int, msg string) {
+	if h.Log != nil {
+		fmt.Fprintf(h.Log, "%s %s: %d %s\n", r.Method, r.URL.Path, status, msg)
+	}
+	writeJSON(w, status, map[string]string{"message": msg})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
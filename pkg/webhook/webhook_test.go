@@ -0,0 +1,81 @@
+package webhook
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const createBody = `{"action":"Create","alert":{"alertId":"a1","tinyId":"42","message":"Disk full"},"integrationName":"Webhook"}`
+
+func TestParse(t *testing.T) {
+	ev, err := Parse([]byte(createBody))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ev.Action != "Create" || ev.AlertID != "a1" || ev.TinyID != "42" || ev.Message != "Disk full" || ev.Integration != "Webhook" {
+		t.Errorf("event = %+v", ev)
+	}
+	if string(ev.Payload) != createBody {
+		t.Errorf("payload = %s", ev.Payload)
+	}
+
+	for body, want := range map[string]string{
+		`[1]`:                             "not a JSON object",
+		`{"alert":{"alertId":"a1"}}`:      "no action",
+		`{"action":"Close"}`:              "no alert",
+		`{"action":"Close","alert":{}}`:   "no alertId",
+		`{"action":"","alert":{"a":"b"}}`: "no action",
+	} {
+		if _, err := Parse([]byte(body)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Parse(%s) = %v, want error containing %q", body, err, want)
+		}
+	}
+}
+
+func TestHandler(t *testing.T) {
+	var got []Event
+	var log bytes.Buffer
+	h := &Handler{
+		Path:    "/hook",
+		Headers: map[string]string{"X-Secret": "s3cret"},
+		Actions: []string{"Create"},
+		Deliver: func(ev Event) { got = append(got, ev) },
+		Log:     &log,
+	}
+	send := func(method, path, secret, body string) int {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if secret != "" {
+			req.Header.Set("X-Secret", secret)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	cases := []struct {
+		method, path, secret, body string
+		want                       int
+	}{
+		{http.MethodPost, "/hook", "s3cret", createBody, http.StatusOK},
+		{http.MethodPost, "/hook", "s3cret", strings.Replace(createBody, "Create", "Close", 1), http.StatusOK},
+		{http.MethodPost, "/hook", "wrong", createBody, http.StatusUnauthorized},
+		{http.MethodPost, "/hook", "", createBody, http.StatusUnauthorized},
+		{http.MethodGet, "/hook", "s3cret", "", http.StatusMethodNotAllowed},
+		{http.MethodPost, "/other", "s3cret", createBody, http.StatusNotFound},
+		{http.MethodPost, "/hook", "s3cret", `{"action":"Create"}`, http.StatusBadRequest},
+	}
+	for _, c := range cases {
+		if code := send(c.method, c.path, c.secret, c.body); code != c.want {
+			t.Errorf("%s %s secret=%q: status %d, want %d", c.method, c.path, c.secret, code, c.want)
+		}
+	}
+	if len(got) != 1 || got[0].Action != "Create" || got[0].ReceivedAt.IsZero() {
+		t.Errorf("delivered %+v, want only the Create event", got)
+	}
+	if !strings.Contains(log.String(), "401 missing or wrong X-Secret header") {
+		t.Errorf("log = %q", log.String())
+	}
+}
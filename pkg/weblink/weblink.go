@@ -0,0 +1,56 @@
+// Package weblink builds OpsGenie web UI deep links for resources.
+package weblink
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+)
+
+// EnvBaseURL names the environment variable that overrides the web UI root.
+const EnvBaseURL = "OPSGENIE_WEB_URL"
+
+// paths maps resource kinds to web UI path templates; %s is the ID.
+var paths = map[string]string{
+	"alert":    "/alert/detail/%s/details",
+	"incident": "/incident/detail/%s/details",
+	"team":     "/teams/dashboard/%s/main",
+	"schedule": "/settings/schedule/detail/%s",
+}
+
+// Kinds returns the resource kinds that have web links, sorted.
+func Kinds() []string {
+	kinds := make([]string, 0, len(paths))
+	for k := range paths {
+		kinds = append(kinds, k)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// BaseURL returns the web UI root for an account. OPSGENIE_WEB_URL
+// overrides it, for custom domains and testing.
+func BaseURL(account, region string) string {
+	if override := os.Getenv(EnvBaseURL); override != "" {
+		return strings.TrimRight(override, "/")
+	}
+	host := "app.opsgenie.com"
+	if strings.EqualFold(region, "eu") {
+		host = "app.eu.opsgenie.com"
+	}
+	return "https://" + account + "." + host
+}
+
+// URL returns the deep link to a resource under base.
+func URL(base, kind, id string) (string, error) {
+	tmpl, ok := paths[kind]
+	if !ok {
+		return "", fmt.Errorf("unknown resource kind %q (valid: %s)", kind, strings.Join(Kinds(), ", "))
+	}
+	if id == "" {
+		return "", fmt.Errorf("%s ID is required", kind)
+	}
+	return base + fmt.Sprintf(tmpl, url.PathEscape(id)), nil
+}
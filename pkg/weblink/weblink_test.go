@@ -0,0 +1,38 @@
+package weblink
+
+import "testing"
+
+func TestBaseURL(t *testing.T) {
+	t.Setenv("OPSGENIE_WEB_URL", "")
+	if got := BaseURL("acme", "us"); got != "https://acme.app.opsgenie.com" {
+		t.Errorf("us: %s", got)
+	}
+	if got := BaseURL("acme", "EU"); got != "https://acme.app.eu.opsgenie.com" {
+		t.Errorf("eu: %s", got)
+	}
+	t.Setenv("OPSGENIE_WEB_URL", "https://ops.example.com/")
+	if got := BaseURL("acme", "us"); got != "https://ops.example.com" {
+		t.Errorf("override: %s", got)
+	}
+}
+
+func TestURL(t *testing.T) {
+	cases := map[string]string{
+		"alert":    "https://x/alert/detail/a%2F1/details",
+		"incident": "https://x/incident/detail/a%2F1/details",
+		"team":     "https://x/teams/dashboard/a%2F1/main",
+		"schedule": "https://x/settings/schedule/detail/a%2F1",
+	}
+	for kind, want := range cases {
+		got, err := URL("https://x", kind, "a/1")
+		if err != nil || got != want {
+			t.Errorf("%s: got %s, %v; want %s", kind, got, err, want)
+		}
+	}
+	if _, err := URL("https://x", "widget", "1"); err == nil {
+		t.Error("expected error for unknown kind")
+	}
+	if _, err := URL("https://x", "alert", ""); err == nil {
+		t.Error("expected error for empty ID")
+	}
+}
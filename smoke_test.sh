@@ -85,20 +85,29 @@ check_exit "teams --help" teams --help
 # 10. All resource parent commands respond to --help
 RESOURCE_COMMANDS=(
     account
+    advisor
+    alert-policies
     alerts
+    apply
     contacts
     custom-roles
     deployments
     escalations
+    export
     forwarding-rules
     heartbeats
     incidents
     integrations
+    lint
     maintenance
+    mock-server
+    notification-policies
     notification-rules
     on-call
+    open
     policies
     postmortems
+    report
     schedule-overrides
     schedule-rotations
     schedules